@@ -0,0 +1,142 @@
+package business
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// rbacResource is a resource Kiali lists, identified by its API group and its plural name
+type rbacResource struct {
+	group    string
+	resource string
+}
+
+func (r rbacResource) String() string {
+	if r.group == "" {
+		return r.resource
+	}
+	return r.resource + "." + r.group
+}
+
+// kialiListedResources are the resources Kiali lists in every accessible namespace
+var kialiListedResources = []rbacResource{
+	{group: "", resource: "pods"},
+	{group: "", resource: "services"},
+	{group: "", resource: "endpoints"},
+	{group: "apps", resource: "deployments"},
+	{group: "apps", resource: "replicasets"},
+	{group: "apps", resource: "statefulsets"},
+	{group: "apps", resource: "daemonsets"},
+}
+
+// kialiListedIstioResources are the Istio resources Kiali lists in every accessible namespace when the Istio API is installed
+var kialiListedIstioResources = []rbacResource{
+	{group: kubernetes.NetworkingGroupVersionV1Beta1.Group, resource: kubernetes.VirtualServices},
+	{group: kubernetes.NetworkingGroupVersionV1Beta1.Group, resource: kubernetes.DestinationRules},
+	{group: kubernetes.NetworkingGroupVersionV1Beta1.Group, resource: kubernetes.Gateways},
+	{group: kubernetes.NetworkingGroupVersionV1Beta1.Group, resource: kubernetes.ServiceEntries},
+	{group: kubernetes.NetworkingGroupVersionV1Beta1.Group, resource: kubernetes.Sidecars},
+	{group: kubernetes.SecurityGroupVersion.Group, resource: kubernetes.AuthorizationPolicies},
+	{group: kubernetes.SecurityGroupVersion.Group, resource: kubernetes.PeerAuthentications},
+	{group: kubernetes.SecurityGroupVersion.Group, resource: kubernetes.RequestAuthentications},
+}
+
+// ValidateAccessibleNamespacesRBAC checks, in every cluster, that the Kiali service account can list the resources
+// Kiali needs in each namespace of the Deployment.AccessibleNamespaces config. A mismatch between the config and
+// the RBAC of the service account otherwise shows up as confusing partial failures.
+// When every namespace is accessible ("**"), the permissions are checked cluster wide.
+// Only the namespaces, and the clusters, the user can access are checked.
+// The result has one entry per cluster and namespace, sorted by cluster, with the resources that can't be listed.
+func (in *NamespaceService) ValidateAccessibleNamespacesRBAC(ctx context.Context) ([]models.AccessibleNamespaceRBAC, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "ValidateAccessibleNamespacesRBAC",
+		observability.Attribute("package", "business"),
+	)
+	defer end()
+
+	// The checks of the Kiali service account are only reported for the namespaces the user can access
+	userNamespaces, err := in.GetNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userClusterNamespaces := map[string]map[string]bool{}
+	for _, ns := range userNamespaces {
+		if userClusterNamespaces[ns.Cluster] == nil {
+			userClusterNamespaces[ns.Cluster] = map[string]bool{}
+		}
+		userClusterNamespaces[ns.Cluster][ns.Name] = true
+	}
+
+	namespaces := config.Get().Deployment.AccessibleNamespaces
+	if in.isAccessibleNamespaces["**"] {
+		namespaces = []string{""}
+	}
+
+	clusters := make([]string, 0, len(in.kialiSAClients))
+	for cluster := range in.kialiSAClients {
+		if len(userClusterNamespaces[cluster]) > 0 {
+			clusters = append(clusters, cluster)
+		}
+	}
+	sort.Strings(clusters)
+
+	result := []models.AccessibleNamespaceRBAC{}
+	for _, cluster := range clusters {
+		for _, namespace := range namespaces {
+			if namespace == "" || userClusterNamespaces[cluster][namespace] {
+				result = append(result, models.AccessibleNamespaceRBAC{Cluster: cluster, Namespace: namespace})
+			}
+		}
+	}
+
+	wg := sync.WaitGroup{}
+	for i := range result {
+		client := in.kialiSAClients[result[i].Cluster]
+		resources := kialiListedResources
+		if client.IsIstioAPI() {
+			resources = append(append([]rbacResource{}, kialiListedResources...), kialiListedIstioResources...)
+		}
+		wg.Add(1)
+		go func(check *models.AccessibleNamespaceRBAC, client kubernetes.ClientInterface, resources []rbacResource) {
+			defer wg.Done()
+			*check = checkListPermissions(ctx, client, check.Cluster, check.Namespace, resources)
+		}(&result[i], client, resources)
+	}
+	wg.Wait()
+
+	return result, nil
+}
+
+// checkListPermissions returns the resources the client can't list in the namespace.
+// The resources whose permissions can't be checked are not reported as missing, the error is reported instead.
+func checkListPermissions(ctx context.Context, client kubernetes.ClientInterface, cluster, namespace string, resources []rbacResource) models.AccessibleNamespaceRBAC {
+	check := models.AccessibleNamespaceRBAC{Cluster: cluster, Namespace: namespace, MissingResources: []string{}}
+	for _, r := range resources {
+		ssars, err := client.GetSelfSubjectAccessReview(ctx, namespace, r.group, r.resource, []string{"list"})
+		if err != nil {
+			log.Errorf("Error checking the permission to list [%s] [namespace: %s, cluster: %s]: %v", r, namespace, cluster, err)
+			if check.Error == "" {
+				check.Error = err.Error()
+			}
+			continue
+		}
+		allowed := false
+		for _, ssar := range ssars {
+			allowed = allowed || ssar.Status.Allowed
+		}
+		if !allowed {
+			check.MissingResources = append(check.MissingResources, r.String())
+		}
+	}
+	if len(check.MissingResources) > 0 {
+		log.Warningf("The Kiali service account can't list %v in the accessible namespace [%s] of cluster [%s]", check.MissingResources, namespace, cluster)
+	}
+	return check
+}
@@ -0,0 +1,141 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	auth_v1 "k8s.io/api/authorization/v1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+)
+
+// Allows to list everything but the resources of the forbidden namespaces, the reviews of the broken namespaces fail.
+type namespaceAccessReview struct {
+	kubernetes.ClientInterface
+	forbidden map[string]bool
+	broken    map[string]bool
+}
+
+func (a *namespaceAccessReview) GetSelfSubjectAccessReview(ctx context.Context, namespace, api, resourceType string, verbs []string) ([]*auth_v1.SelfSubjectAccessReview, error) {
+	if a.broken[namespace] {
+		return nil, fmt.Errorf("unable to review the access to namespace [%s]", namespace)
+	}
+	ssars := []*auth_v1.SelfSubjectAccessReview{}
+	for _, verb := range verbs {
+		ssars = append(ssars, &auth_v1.SelfSubjectAccessReview{
+			Spec: auth_v1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &auth_v1.ResourceAttributes{Namespace: namespace, Verb: verb, Group: api, Resource: resourceType},
+			},
+			Status: auth_v1.SubjectAccessReviewStatus{Allowed: !a.forbidden[namespace]},
+		})
+	}
+	return ssars, nil
+}
+
+func TestValidateAccessibleNamespacesRBAC(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	conf.Deployment.AccessibleNamespaces = []string{"bookinfo", "forbidden", "broken"}
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "forbidden"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "broken"}},
+	)
+	k8s.IstioAPIEnabled = true
+	client := &namespaceAccessReview{
+		ClientInterface: k8s,
+		forbidden:       map[string]bool{"forbidden": true},
+		broken:          map[string]bool{"broken": true},
+	}
+	nsservice := setupNamespaceService(client, conf)
+
+	checks, err := nsservice.ValidateAccessibleNamespacesRBAC(context.TODO())
+	require.NoError(err)
+	require.Len(checks, 3)
+
+	assert.Equal(conf.KubernetesConfig.ClusterName, checks[0].Cluster)
+	assert.Equal("bookinfo", checks[0].Namespace)
+	assert.Empty(checks[0].MissingResources)
+	assert.Empty(checks[0].Error)
+
+	assert.Equal("forbidden", checks[1].Namespace)
+	assert.Len(checks[1].MissingResources, len(kialiListedResources)+len(kialiListedIstioResources))
+	assert.Contains(checks[1].MissingResources, "pods")
+	assert.Contains(checks[1].MissingResources, "deployments.apps")
+	assert.Contains(checks[1].MissingResources, "virtualservices.networking.istio.io")
+	assert.Empty(checks[1].Error)
+
+	assert.Equal("broken", checks[2].Namespace)
+	assert.Empty(checks[2].MissingResources)
+	assert.Contains(checks[2].Error, "broken")
+}
+
+func TestValidateAccessibleNamespacesRBACWithoutIstioAPI(t *testing.T) {
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.Deployment.AccessibleNamespaces = []string{"forbidden"}
+	k8s := kubetest.NewFakeK8sClient(&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "forbidden"}})
+	k8s.IstioAPIEnabled = false
+	client := &namespaceAccessReview{ClientInterface: k8s, forbidden: map[string]bool{"forbidden": true}}
+	nsservice := setupNamespaceService(client, conf)
+
+	checks, err := nsservice.ValidateAccessibleNamespacesRBAC(context.TODO())
+	require.NoError(err)
+	require.Len(checks, 1)
+	require.Len(checks[0].MissingResources, len(kialiListedResources))
+	require.NotContains(checks[0].MissingResources, "virtualservices.networking.istio.io")
+}
+
+func TestValidateAccessibleNamespacesRBACClusterWide(t *testing.T) {
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.Deployment.AccessibleNamespaces = []string{"**"}
+	client := &namespaceAccessReview{ClientInterface: kubetest.NewFakeK8sClient(&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}})}
+	nsservice := setupNamespaceService(client, conf)
+
+	checks, err := nsservice.ValidateAccessibleNamespacesRBAC(context.TODO())
+	require.NoError(err)
+	require.Len(checks, 1)
+	require.Equal("", checks[0].Namespace)
+	require.Empty(checks[0].MissingResources)
+}
+
+func TestValidateAccessibleNamespacesRBACOnlyUserNamespaces(t *testing.T) {
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.Deployment.AccessibleNamespaces = []string{"bookinfo", "forbidden"}
+	conf.KubernetesConfig.CacheEnabled = false
+	config.Set(conf)
+
+	saClient := &namespaceAccessReview{
+		ClientInterface: kubetest.NewFakeK8sClient(
+			&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+			&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "forbidden"}},
+		),
+		forbidden: map[string]bool{"forbidden": true},
+	}
+	// The user can't see the forbidden namespace
+	userClient := kubetest.NewFakeK8sClient(&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}})
+	nsservice := NewNamespaceService(
+		map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: userClient},
+		map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: saClient},
+	)
+
+	checks, err := nsservice.ValidateAccessibleNamespacesRBAC(context.TODO())
+	require.NoError(err)
+	require.Len(checks, 1)
+	require.Equal("bookinfo", checks[0].Namespace)
+	require.Empty(checks[0].MissingResources)
+}
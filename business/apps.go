@@ -32,6 +32,9 @@ type AppCriteria struct {
 	IncludeHealth         bool
 	RateInterval          string
 	QueryTime             time.Time
+	// GroupLabel is the label used to group the workloads into applications.
+	// Empty means the app label of the namespace, see models.Namespace.GetIstioLabels.
+	GroupLabel string
 }
 
 func joinMap(m1 map[string][]string, m2 map[string]string) {
@@ -77,6 +80,10 @@ func (in *AppService) GetAppList(ctx context.Context, criteria AppCriteria) (mod
 		Cluster:   criteria.Cluster,
 		Apps:      []models.AppListItem{},
 	}
+	// In single cluster the apps are fetched from the home cluster
+	if appList.Cluster == "" && len(in.userClients) == 1 {
+		appList.Cluster = config.Get().KubernetesConfig.ClusterName
+	}
 
 	var err error
 	var allApps []namespaceApps
@@ -95,7 +102,7 @@ func (in *AppService) GetAppList(ctx context.Context, criteria AppCriteria) (mod
 			wg.Add(1)
 			go func(c string) {
 				defer wg.Done()
-				nsApps, error2 := in.fetchNamespaceApps(ctx, criteria.Namespace, c, "")
+				nsApps, error2 := in.fetchNamespaceApps(ctx, criteria.Namespace, c, "", criteria.GroupLabel)
 				if error2 != nil {
 					resultsCh <- result{cluster: c, nsApps: nil, err: error2}
 				} else {
@@ -163,6 +170,7 @@ func (in *AppService) GetAppList(ctx context.Context, criteria AppCriteria) (mod
 		for keyApp, valueApp := range clusterApps {
 			appItem := &models.AppListItem{
 				Name:         keyApp,
+				GroupKey:     valueApp.groupLabel,
 				IstioSidecar: true,
 				Health:       models.EmptyAppHealth(),
 			}
@@ -217,9 +225,14 @@ func (in *AppService) GetAppList(ctx context.Context, criteria AppCriteria) (mod
 				}
 			}
 			if criteria.IncludeHealth {
-				appItem.Health, err = in.businessLayer.Health.GetAppHealth(ctx, criteria.Namespace, valueApp.cluster, appItem.Name, criteria.RateInterval, criteria.QueryTime, valueApp)
-				if err != nil {
-					log.Errorf("Error fetching Health in namespace %s for app %s: %s", criteria.Namespace, appItem.Name, err)
+				if criteria.GroupLabel == "" || criteria.GroupLabel == conf.IstioLabels.AppLabelName {
+					appItem.Health, err = in.businessLayer.Health.GetAppHealth(ctx, criteria.Namespace, valueApp.cluster, appItem.Name, criteria.RateInterval, criteria.QueryTime, valueApp)
+					if err != nil {
+						log.Errorf("Error fetching Health in namespace %s for app %s: %s", criteria.Namespace, appItem.Name, err)
+					}
+				} else {
+					// Request rates are reported per app label, so only the workload statuses apply to a custom group
+					appItem.Health.WorkloadStatuses = valueApp.Workloads.CastWorkloadStatuses()
 				}
 			}
 			appItem.Cluster = valueApp.cluster
@@ -250,7 +263,7 @@ func (in *AppService) GetAppDetails(ctx context.Context, criteria AppCriteria) (
 	}
 	appInstance.Namespace = *ns
 
-	namespaceApps, err := in.fetchNamespaceApps(ctx, criteria.Namespace, criteria.Cluster, criteria.AppName)
+	namespaceApps, err := in.fetchNamespaceApps(ctx, criteria.Namespace, criteria.Cluster, criteria.AppName, ns.GetIstioLabels(config.Get().IstioLabels).AppLabelName)
 	if err != nil {
 		return *appInstance, err
 	}
@@ -289,28 +302,28 @@ func (in *AppService) GetAppDetails(ctx context.Context, criteria AppCriteria) (
 	return *appInstance, nil
 }
 
-// AppDetails holds Services and Workloads having the same "app" label
+// AppDetails holds Services and Workloads having the same "app" label, or the same value of the grouping label
 type appDetails struct {
-	app       string
-	cluster   string
-	Services  []models.ServiceOverview
-	Workloads models.Workloads
+	app        string
+	cluster    string
+	groupLabel string
+	Services   []models.ServiceOverview
+	Workloads  models.Workloads
 }
 
 // NamespaceApps is a map of app_name and cluster x AppDetails
 type namespaceApps = map[string]*appDetails
 
-func castAppDetails(allEntities namespaceApps, ss *models.ServiceList, w *models.Workload, cluster string) {
-	appLabel := config.Get().IstioLabels.AppLabelName
-
-	if app, ok := w.Labels[appLabel]; ok {
+func castAppDetails(allEntities namespaceApps, ss *models.ServiceList, w *models.Workload, cluster string, groupLabel string) {
+	if app, ok := w.Labels[groupLabel]; ok {
 		if appEntities, ok := allEntities[app]; ok {
 			appEntities.Workloads = append(appEntities.Workloads, w)
 		} else {
 			allEntities[app] = &appDetails{
-				app:       app,
-				cluster:   cluster,
-				Workloads: models.Workloads{w},
+				app:        app,
+				cluster:    cluster,
+				groupLabel: groupLabel,
+				Workloads:  models.Workloads{w},
 			}
 		}
 		if ss != nil {
@@ -332,26 +345,30 @@ func castAppDetails(allEntities namespaceApps, ss *models.ServiceList, w *models
 }
 
 // Helper method to fetch all applications for a given namespace.
+// The workloads are grouped into applications by the value of the groupLabel,
+// when empty the app label of the namespace is used.
 // Optionally if appName parameter is provided, it filters apps for that name.
 // Return an error on any problem.
-func (in *AppService) fetchNamespaceApps(ctx context.Context, namespace string, cluster string, appName string) (namespaceApps, error) {
+func (in *AppService) fetchNamespaceApps(ctx context.Context, namespace string, cluster string, appName string, groupLabel string) (namespaceApps, error) {
 	var ss *models.ServiceList
 	var ws models.Workloads
-	cfg := config.Get()
-
-	appNameSelector := ""
-	if appName != "" {
-		selector := labels.Set(map[string]string{cfg.IstioLabels.AppLabelName: appName})
-		appNameSelector = selector.String()
-	}
 
 	// Check if user has access to the namespace (RBAC) in cache scenarios and/or
 	// if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
-	if _, err := in.businessLayer.Namespace.GetNamespaceByCluster(ctx, namespace, cluster); err != nil {
+	ns, err := in.businessLayer.Namespace.GetNamespaceByCluster(ctx, namespace, cluster)
+	if err != nil {
 		return nil, err
 	}
+	if groupLabel == "" {
+		groupLabel = ns.GetIstioLabels(config.Get().IstioLabels).AppLabelName
+	}
+
+	appNameSelector := ""
+	if appName != "" {
+		selector := labels.Set(map[string]string{groupLabel: appName})
+		appNameSelector = selector.String()
+	}
 
-	var err error
 	ws, err = in.businessLayer.Workload.fetchWorkloadsFromCluster(ctx, cluster, namespace, appNameSelector)
 	if err != nil {
 		return nil, err
@@ -371,7 +388,7 @@ func (in *AppService) fetchNamespaceApps(ctx context.Context, namespace string,
 		if err != nil {
 			return nil, err
 		}
-		castAppDetails(allEntities, ss, w, cluster)
+		castAppDetails(allEntities, ss, w, cluster, groupLabel)
 	}
 
 	return allEntities, nil
@@ -7,6 +7,7 @@ import (
 	osproject_v1 "github.com/openshift/api/project/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	apps_v1 "k8s.io/api/apps/v1"
 	core_v1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -14,6 +15,7 @@ import (
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/prometheus/prometheustest"
 )
 
@@ -56,9 +58,135 @@ func TestGetAppListFromDeployments(t *testing.T) {
 	require.NoError(err)
 
 	assert.Equal("Namespace", appList.Namespace.Name)
+	assert.Equal(conf.KubernetesConfig.ClusterName, appList.Cluster)
 
 	assert.Equal(1, len(appList.Apps))
 	assert.Equal("httpbin", appList.Apps[0].Name)
+	assert.Equal(conf.KubernetesConfig.ClusterName, appList.Apps[0].Cluster)
+}
+
+func fakeComponentDeployment(name string, labels map[string]string) *apps_v1.Deployment {
+	return &apps_v1.Deployment{
+		TypeMeta:   v1.TypeMeta{Kind: "Deployment"},
+		ObjectMeta: v1.ObjectMeta{Name: name, Namespace: "Namespace"},
+		Spec: apps_v1.DeploymentSpec{
+			Template: core_v1.PodTemplateSpec{ObjectMeta: v1.ObjectMeta{Labels: labels}},
+		},
+		Status: apps_v1.DeploymentStatus{Replicas: 1, AvailableReplicas: 1},
+	}
+}
+
+func TestGetAppListGroupedByCustomLabel(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "Namespace"}},
+		fakeComponentDeployment("reviews-v1", map[string]string{"app": "reviews", "component": "frontend"}),
+		fakeComponentDeployment("reviews-v2", map[string]string{"app": "reviews", "component": "backend"}),
+		fakeComponentDeployment("ratings-v1", map[string]string{"app": "ratings", "component": "backend"}),
+		fakeComponentDeployment("details-v1", map[string]string{"app": "details"}),
+	)
+	SetupBusinessLayer(t, k8s, *conf)
+	svc := setupAppService(map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s})
+
+	appsByName := func(criteria AppCriteria) map[string]models.AppListItem {
+		appList, err := svc.GetAppList(context.TODO(), criteria)
+		require.NoError(err)
+		apps := map[string]models.AppListItem{}
+		for _, app := range appList.Apps {
+			apps[app.Name] = app
+		}
+		return apps
+	}
+
+	// The app label is used by default
+	apps := appsByName(AppCriteria{Namespace: "Namespace"})
+	require.Len(apps, 3)
+	assert.Contains(apps, "reviews")
+	assert.Contains(apps, "ratings")
+	assert.Contains(apps, "details")
+	assert.Equal("app", apps["reviews"].GroupKey)
+
+	apps = appsByName(AppCriteria{Namespace: "Namespace", GroupLabel: "component", IncludeHealth: true})
+	require.Len(apps, 2)
+	require.Contains(apps, "frontend")
+	require.Contains(apps, "backend")
+	assert.Equal("component", apps["backend"].GroupKey)
+	assert.Equal("backend", apps["backend"].Labels["component"])
+	assert.Equal("ratings,reviews", apps["backend"].Labels["app"])
+
+	workloads := []string{}
+	for _, status := range apps["backend"].Health.WorkloadStatuses {
+		workloads = append(workloads, status.Name)
+	}
+	assert.ElementsMatch([]string{"reviews-v2", "ratings-v1"}, workloads)
+	assert.Len(apps["frontend"].Health.WorkloadStatuses, 1)
+}
+
+func TestGetAppListWithNamespaceAppLabel(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	deployment := func(name, namespace string, labels map[string]string) *apps_v1.Deployment {
+		d := fakeComponentDeployment(name, labels)
+		d.Namespace = namespace
+		return d
+	}
+	k8s := kubetest.NewFakeK8sClient(
+		// The bookinfo team labels the workloads with their own app label
+		&core_v1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "bookinfo", Annotations: map[string]string{
+			models.AppLabelNameAnnotation:     "team-app",
+			models.VersionLabelNameAnnotation: "team-version",
+		}}},
+		deployment("reviews-v1", "bookinfo", map[string]string{"team-app": "reviews", "team-version": "v1", "app": "bookinfo"}),
+		deployment("reviews-v2", "bookinfo", map[string]string{"team-app": "reviews", "team-version": "v2", "app": "bookinfo"}),
+		deployment("ratings-v1", "bookinfo", map[string]string{"team-app": "ratings", "team-version": "v1", "app": "bookinfo"}),
+		&core_v1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "travels"}},
+		deployment("cars-v1", "travels", map[string]string{"app": "cars", "version": "v1", "team-app": "travels"}),
+		deployment("hotels-v1", "travels", map[string]string{"app": "hotels", "version": "v1", "team-app": "travels"}),
+	)
+	SetupBusinessLayer(t, k8s, *conf)
+	svc := setupAppService(map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s})
+
+	appWorkloads := func(namespace string) map[string][]string {
+		appList, err := svc.GetAppList(context.TODO(), AppCriteria{Namespace: namespace})
+		require.NoError(err)
+		apps := map[string][]string{}
+		for _, app := range appList.Apps {
+			details, err := svc.GetAppDetails(context.TODO(), AppCriteria{Namespace: namespace, Cluster: app.Cluster, AppName: app.Name})
+			require.NoError(err)
+			for _, w := range details.Workloads {
+				apps[app.Name] = append(apps[app.Name], w.WorkloadName)
+			}
+		}
+		return apps
+	}
+
+	apps := appWorkloads("bookinfo")
+	require.Len(apps, 2)
+	assert.ElementsMatch([]string{"reviews-v1", "reviews-v2"}, apps["reviews"])
+	assert.ElementsMatch([]string{"ratings-v1"}, apps["ratings"])
+
+	apps = appWorkloads("travels")
+	require.Len(apps, 2)
+	assert.ElementsMatch([]string{"cars-v1"}, apps["cars"])
+	assert.ElementsMatch([]string{"hotels-v1"}, apps["hotels"])
+
+	// The labels required by Istio are checked with the labels of the namespace
+	workloads, err := svc.businessLayer.Workload.fetchWorkloads(context.TODO(), "bookinfo", "")
+	require.NoError(err)
+	require.Len(workloads, 3)
+	for _, w := range workloads {
+		assert.True(w.AppLabel)
+		assert.True(w.VersionLabel)
+	}
 }
 
 func TestGetAppFromDeployments(t *testing.T) {
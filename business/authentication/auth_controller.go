@@ -7,7 +7,9 @@ import (
 
 	"k8s.io/client-go/tools/clientcmd/api"
 
+	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
 )
 
 // TerminateSessionError is a helper type implementing the error interface.
@@ -96,6 +98,46 @@ func (e *AuthenticationFailureError) Error() string {
 	return e.Reason
 }
 
+// newUserAuthInfo returns the AuthInfo to access the cluster API on behalf of the owner of the token.
+// When impersonation is enabled the token isn't sent to the cluster API: it is validated with a
+// TokenReview done by the Kiali SA and the authenticated user and groups are the ones impersonated.
+func newUserAuthInfo(token string, businessInstantiator func(authInfo *api.AuthInfo) (*business.Layer, error)) (*api.AuthInfo, error) {
+	authInfo := &api.AuthInfo{Token: token}
+	if !config.Get().Auth.Impersonation.Enabled {
+		return authInfo, nil
+	}
+
+	kialiToken, err := kubernetes.GetKialiTokenForHomeCluster()
+	if err != nil {
+		return nil, fmt.Errorf("error reading the Kiali ServiceAccount token: %w", err)
+	}
+
+	bs, err := businessInstantiator(&api.AuthInfo{Token: kialiToken})
+	if err != nil {
+		return nil, fmt.Errorf("error instantiating the business layer: %w", err)
+	}
+
+	user, err := bs.TokenReview.GetTokenUser(authInfo)
+	if err != nil {
+		return nil, &AuthenticationFailureError{
+			Detail:     err,
+			HttpStatus: http.StatusUnauthorized,
+			Reason:     "token is not valid or is expired",
+		}
+	}
+
+	authInfo.Username = user.Username
+	authInfo.ImpersonateGroups = user.Groups
+	// The extra fields, like the scopes of the token, are part of the identity the RBAC rules are evaluated with
+	if len(user.Extra) > 0 {
+		authInfo.ImpersonateUserExtra = make(map[string][]string, len(user.Extra))
+		for key, values := range user.Extra {
+			authInfo.ImpersonateUserExtra[key] = values
+		}
+	}
+	return authInfo, nil
+}
+
 var authController AuthController
 
 // GetAuthController gets the authentication controller that is currently configured and handling
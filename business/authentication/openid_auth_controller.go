@@ -227,10 +227,16 @@ func (c OpenIdAuthController) ValidateSession(r *http.Request, w http.ResponseWr
 		}
 	}
 
-	var token string
+	var authInfo *api.AuthInfo
 	if !conf.Auth.OpenId.DisableRBAC {
 		// If RBAC is ENABLED, check that the user has privileges on the cluster.
-		bs, err := business.Get(&api.AuthInfo{Token: sPayload.Token})
+		authInfo, err = newUserAuthInfo(sPayload.Token, business.Get)
+		if err != nil {
+			log.Warningf("Token error!: %v", err)
+			return nil, nil
+		}
+
+		bs, err := business.Get(authInfo)
 		if err != nil {
 			log.Warningf("Could not get the business layer!!: %v", err)
 			return nil, fmt.Errorf("could not get the business layer: %w", err)
@@ -241,16 +247,15 @@ func (c OpenIdAuthController) ValidateSession(r *http.Request, w http.ResponseWr
 			log.Warningf("Token error!: %v", err)
 			return nil, nil
 		}
-
-		token = sPayload.Token
 	} else {
 		// If RBAC is off, it's assumed that the kubernetes cluster will reject the OpenId token.
 		// Instead, we use the Kiali token and this has the side effect that all users will share the
 		// same privileges.
-		token, err = kubernetes.GetKialiTokenForHomeCluster()
+		token, err := kubernetes.GetKialiTokenForHomeCluster()
 		if err != nil {
 			return nil, fmt.Errorf("error reading the Kiali ServiceAccount token: %w", err)
 		}
+		authInfo = &api.AuthInfo{Token: token}
 	}
 
 	// Internal header used to propagate the subject of the request for audit purposes
@@ -259,7 +264,7 @@ func (c OpenIdAuthController) ValidateSession(r *http.Request, w http.ResponseWr
 	return &UserSessionData{
 		ExpiresOn: sData.ExpiresOn,
 		Username:  sPayload.Subject,
-		AuthInfo:  &api.AuthInfo{Token: token},
+		AuthInfo:  authInfo,
 	}, nil
 }
 
@@ -1369,8 +1374,13 @@ func validateOpenIdTokenInHouse(openIdParams *openidFlowHelper) error {
 // verifyOpenIdUserAccess checks that the provided token has enough privileges on the cluster to
 // allow a login to Kiali.
 func verifyOpenIdUserAccess(token string, businessInstantiator func(authInfo *api.AuthInfo) (*business.Layer, error)) (int, string, error) {
+	authInfo, err := newUserAuthInfo(token, businessInstantiator)
+	if err != nil {
+		return http.StatusUnauthorized, "Token is not valid or is expired", err
+	}
+
 	// Create business layer using the id_token
-	bsLayer, err := businessInstantiator(&api.AuthInfo{Token: token})
+	bsLayer, err := businessInstantiator(authInfo)
 	if err != nil {
 		return http.StatusInternalServerError, "Error instantiating the business layer", err
 	}
@@ -98,6 +98,12 @@ func (o openshiftAuthController) Authenticate(r *http.Request, w http.ResponseWr
 		}
 	}
 
+	authInfo, err := newUserAuthInfo(token, o.businessInstantiator)
+	if err != nil {
+		o.SessionStore.TerminateSession(r, w)
+		return nil, err
+	}
+
 	err = o.SessionStore.CreateSession(r, w, config.AuthStrategyOpenshift, expiresOn, openshiftSessionPayload{Token: token})
 	if err != nil {
 		return nil, err
@@ -106,7 +112,7 @@ func (o openshiftAuthController) Authenticate(r *http.Request, w http.ResponseWr
 	return &UserSessionData{
 		ExpiresOn: expiresOn,
 		Username:  user.Metadata.Name,
-		AuthInfo:  &api.AuthInfo{Token: token},
+		AuthInfo:  authInfo,
 	}, nil
 }
 
@@ -156,12 +162,18 @@ func (o openshiftAuthController) ValidateSession(r *http.Request, w http.Respons
 
 	user, err := bs.OpenshiftOAuth.GetUserInfo(token)
 	if err == nil {
+		authInfo, authErr := newUserAuthInfo(token, o.businessInstantiator)
+		if authErr != nil {
+			log.Warningf("Token error: %v", authErr)
+			return nil, nil
+		}
+
 		// Internal header used to propagate the subject of the request for audit purposes
 		r.Header.Add("Kiali-User", user.Metadata.Name)
 		return &UserSessionData{
 			ExpiresOn: expires,
 			Username:  user.Metadata.Name,
-			AuthInfo:  &api.AuthInfo{Token: token},
+			AuthInfo:  authInfo,
 		}, nil
 	}
 
@@ -73,8 +73,14 @@ func (c tokenAuthController) Authenticate(r *http.Request, w http.ResponseWriter
 		return nil, errors.New("token is empty")
 	}
 
+	authInfo, err := newUserAuthInfo(token, c.businessInstantiator)
+	if err != nil {
+		c.SessionStore.TerminateSession(r, w)
+		return nil, err
+	}
+
 	// Create a bs layer with the received token to check its validity.
-	bs, err := c.businessInstantiator(&api.AuthInfo{Token: token})
+	bs, err := c.businessInstantiator(authInfo)
 	if err != nil {
 		return nil, fmt.Errorf("error instantiating the business layer: %w", err)
 	}
@@ -104,7 +110,7 @@ func (c tokenAuthController) Authenticate(r *http.Request, w http.ResponseWriter
 	return &UserSessionData{
 		ExpiresOn: timeExpire,
 		Username:  extractSubjectFromK8sToken(token),
-		AuthInfo:  &api.AuthInfo{Token: token},
+		AuthInfo:  authInfo,
 	}, nil
 }
 
@@ -124,8 +130,14 @@ func (c tokenAuthController) ValidateSession(r *http.Request, w http.ResponseWri
 		return nil, nil
 	}
 
+	authInfo, err := newUserAuthInfo(sPayload.Token, c.businessInstantiator)
+	if err != nil {
+		log.Warningf("Token error!!: %v", err)
+		return nil, nil
+	}
+
 	// Check token validity.
-	bs, err := c.businessInstantiator(&api.AuthInfo{Token: sPayload.Token})
+	bs, err := c.businessInstantiator(authInfo)
 	if err != nil {
 		return nil, fmt.Errorf("could not get the business layer: %w", err)
 	}
@@ -143,7 +155,7 @@ func (c tokenAuthController) ValidateSession(r *http.Request, w http.ResponseWri
 	return &UserSessionData{
 		ExpiresOn: sData.ExpiresOn,
 		Username:  extractSubjectFromK8sToken(sPayload.Token),
-		AuthInfo:  &api.AuthInfo{Token: sPayload.Token},
+		AuthInfo:  authInfo,
 	}, nil
 }
 
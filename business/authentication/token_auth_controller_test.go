@@ -4,6 +4,8 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -43,6 +45,58 @@ func TestTokenAuthControllerAuthenticatesCorrectly(t *testing.T) {
 	assert.Equal(t, expectedExpiration, response.Cookies()[0].Expires)
 }
 
+func TestTokenAuthControllerImpersonatesTokenUser(t *testing.T) {
+	clockTime := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	util.Clock = util.ClockMock{Time: clockTime}
+
+	conf := config.NewConfig()
+	conf.LoginToken.SigningKey = "kiali67890123456"
+	conf.Auth.Impersonation.Enabled = true
+	config.Set(conf)
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("kiali-token"), 0o600))
+	defaultServiceAccountPath := kubernetes.DefaultServiceAccountPath
+	kubernetes.DefaultServiceAccountPath = tokenFile
+	t.Cleanup(func() {
+		kubernetes.DefaultServiceAccountPath = defaultServiceAccountPath
+		kubernetes.KialiTokenForHomeCluster = ""
+	})
+	kubernetes.KialiTokenForHomeCluster = ""
+
+	k8s := kubetest.NewK8SClientMock()
+	k8s.On("GetProjects", "").Return([]osproject_v1.Project{
+		{ObjectMeta: meta_v1.ObjectMeta{Name: "Foo"}},
+	}, nil)
+
+	requestBody := strings.NewReader("token=" + testToken)
+	request := httptest.NewRequest(http.MethodPost, "/api/authenticate", requestBody)
+	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	var instantiatedWith []*api.AuthInfo
+	controller := NewTokenAuthController(CookieSessionPersistor{}, func(authInfo *api.AuthInfo) (*business.Layer, error) {
+		instantiatedWith = append(instantiatedWith, authInfo)
+		k8sclients := make(map[string]kubernetes.ClientInterface)
+		k8sclients[conf.KubernetesConfig.ClusterName] = k8s
+		return business.NewWithBackends(k8sclients, k8sclients, nil, nil), nil
+	})
+
+	rr := httptest.NewRecorder()
+	mockClientFactory := kubetest.NewK8SClientFactoryMock(k8s)
+	business.SetWithBackends(mockClientFactory, nil)
+	sData, err := controller.Authenticate(request, rr)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, sData)
+	// The Kiali SA reviews the token, then the namespaces are listed impersonating its user.
+	assert.Len(t, instantiatedWith, 2)
+	assert.Equal(t, "kiali-token", instantiatedWith[0].Token)
+	assert.Empty(t, instantiatedWith[0].Username)
+	assert.Equal(t, "system:serviceaccount:k8s_user", instantiatedWith[1].Username)
+	assert.Equal(t, testToken, sData.AuthInfo.Token)
+	assert.Equal(t, "system:serviceaccount:k8s_user", sData.AuthInfo.Username)
+}
+
 func TestTokenAuthControllerRejectsUserWithoutPrivilegesInAnyNamespace(t *testing.T) {
 	clockTime := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
 	util.Clock = util.ClockMock{Time: clockTime}
@@ -0,0 +1,268 @@
+package business
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strings"
+
+	api_security_v1beta1 "istio.io/api/security/v1beta1"
+	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// EvaluateAuthorization simulates the AuthorizationPolicies applied to a workload on a synthetic request.
+// It follows the Istio precedence: a matching DENY policy denies the request, then the request is allowed when
+// no ALLOW policy applies to the workload or when one of them matches. CUSTOM policies can't be evaluated, a matching
+// one is reported as CUSTOM when the native policies allow the request.
+// This is read-only, nothing is enforced. Conditions using keys that can't be derived from the request match for the
+// DENY policies, so that they fail closed like Istio does, and never match for the other ones.
+// It uses following parameters:
+// - "namespace": 		namespace of the workload
+// - "workloadSelector":	labels of the workload, like "app=reviews,version=v1"
+// - "request":			attributes of the request
+func (in *IstioConfigService) EvaluateAuthorization(ctx context.Context, namespace, workloadSelector string, request models.AuthorizationRequest) (models.AuthorizationDecision, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "EvaluateAuthorization",
+		observability.Attribute("package", "business"),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("workloadSelector", workloadSelector),
+	)
+	defer end()
+
+	namespaces := []string{namespace}
+	// Policies of the root namespace apply to the workloads of every namespace
+	if rootNamespace := in.config.ExternalServices.Istio.RootNamespace; rootNamespace != "" && rootNamespace != namespace {
+		namespaces = append(namespaces, rootNamespace)
+	}
+
+	authorizationPolicies := []*security_v1beta1.AuthorizationPolicy{}
+	for _, ns := range namespaces {
+		criteria := IstioConfigCriteria{
+			Namespace:                    ns,
+			Cluster:                      in.config.KubernetesConfig.ClusterName,
+			IncludeAuthorizationPolicies: true,
+		}
+		istioConfigList, err := in.GetIstioConfigList(ctx, criteria)
+		if err != nil {
+			return models.AuthorizationDecision{}, err
+		}
+		authorizationPolicies = append(authorizationPolicies, kubernetes.FilterAuthorizationPoliciesBySelector(workloadSelector, istioConfigList.AuthorizationPolicies)...)
+	}
+
+	return evaluateAuthorizationPolicies(authorizationPolicies, request), nil
+}
+
+func evaluateAuthorizationPolicies(authorizationPolicies []*security_v1beta1.AuthorizationPolicy, request models.AuthorizationRequest) models.AuthorizationDecision {
+	// Sorted to always report the same policy when several of them match
+	sort.Slice(authorizationPolicies, func(i, j int) bool {
+		if authorizationPolicies[i].Namespace != authorizationPolicies[j].Namespace {
+			return authorizationPolicies[i].Namespace < authorizationPolicies[j].Namespace
+		}
+		return authorizationPolicies[i].Name < authorizationPolicies[j].Name
+	})
+
+	var customMatch, denyMatch, allowMatch *models.AuthorizationPolicyMatch
+	hasAllowPolicies := false
+	for _, ap := range authorizationPolicies {
+		action := ap.Spec.Action
+		if action == api_security_v1beta1.AuthorizationPolicy_ALLOW {
+			hasAllowPolicies = true
+		}
+		ruleIndex := matchingAuthorizationRule(ap.Spec.Rules, request, action == api_security_v1beta1.AuthorizationPolicy_DENY)
+		if ruleIndex < 0 {
+			continue
+		}
+		match := &models.AuthorizationPolicyMatch{
+			Name:      ap.Name,
+			Namespace: ap.Namespace,
+			Action:    action.String(),
+			Rule:      ruleIndex,
+		}
+		switch action {
+		case api_security_v1beta1.AuthorizationPolicy_CUSTOM:
+			if customMatch == nil {
+				customMatch = match
+			}
+		case api_security_v1beta1.AuthorizationPolicy_DENY:
+			if denyMatch == nil {
+				denyMatch = match
+			}
+		case api_security_v1beta1.AuthorizationPolicy_ALLOW:
+			if allowMatch == nil {
+				allowMatch = match
+			}
+		}
+	}
+
+	var decision models.AuthorizationDecision
+	switch {
+	case denyMatch != nil:
+		decision = models.AuthorizationDecision{Decision: models.AuthorizationDeny, Reason: "The request matches a DENY policy", Policy: denyMatch}
+	case !hasAllowPolicies:
+		decision = models.AuthorizationDecision{Decision: models.AuthorizationAllow, Reason: "No ALLOW policy applies to the workload"}
+	case allowMatch != nil:
+		decision = models.AuthorizationDecision{Decision: models.AuthorizationAllow, Reason: "The request matches an ALLOW policy", Policy: allowMatch}
+	default:
+		decision = models.AuthorizationDecision{Decision: models.AuthorizationDeny, Reason: "The request doesn't match any of the ALLOW policies of the workload"}
+	}
+
+	// The extension provider is asked first, but it can only deny what the native policies allow
+	if customMatch != nil && decision.Decision == models.AuthorizationAllow {
+		decision = models.AuthorizationDecision{Decision: models.AuthorizationCustom, Reason: "The decision is delegated to the extension provider of a CUSTOM policy", Policy: customMatch}
+	}
+	return decision
+}
+
+// matchingAuthorizationRule returns the index of the first rule matching the request, or -1.
+// A policy without rules never matches. The unknown condition keys match when matchUnknown is set.
+func matchingAuthorizationRule(rules []*api_security_v1beta1.Rule, request models.AuthorizationRequest, matchUnknown bool) int {
+	for i, rule := range rules {
+		if rule == nil {
+			continue
+		}
+		if matchAuthorizationRule(rule, request, matchUnknown) {
+			return i
+		}
+	}
+	return -1
+}
+
+// matchAuthorizationRule checks that any of the sources, any of the operations and all the conditions match
+func matchAuthorizationRule(rule *api_security_v1beta1.Rule, request models.AuthorizationRequest, matchUnknown bool) bool {
+	if len(rule.From) > 0 {
+		matched := false
+		for _, from := range rule.From {
+			if from != nil && matchAuthorizationSource(from.Source, request.Source) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if len(rule.To) > 0 {
+		matched := false
+		for _, to := range rule.To {
+			if to != nil && matchAuthorizationOperation(to.Operation, request.Operation) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	for _, condition := range rule.When {
+		if condition != nil && !matchAuthorizationCondition(condition, request, matchUnknown) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchAuthorizationSource(source *api_security_v1beta1.Source, request models.AuthorizationSource) bool {
+	if source == nil {
+		return true
+	}
+	return matchAuthorizationValues(source.Principals, source.NotPrincipals, request.Principal, matchAuthorizationString) &&
+		matchAuthorizationValues(source.RequestPrincipals, source.NotRequestPrincipals, request.RequestPrincipal, matchAuthorizationString) &&
+		matchAuthorizationValues(source.Namespaces, source.NotNamespaces, request.Namespace, matchAuthorizationString) &&
+		matchAuthorizationValues(source.IpBlocks, source.NotIpBlocks, request.IP, matchAuthorizationIP) &&
+		matchAuthorizationValues(source.RemoteIpBlocks, source.NotRemoteIpBlocks, request.IP, matchAuthorizationIP)
+}
+
+func matchAuthorizationOperation(operation *api_security_v1beta1.Operation, request models.AuthorizationOperation) bool {
+	if operation == nil {
+		return true
+	}
+	matchHost := func(pattern, value string) bool {
+		return matchAuthorizationString(strings.ToLower(pattern), strings.ToLower(value))
+	}
+	matchExact := func(pattern, value string) bool {
+		return pattern == value
+	}
+	return matchAuthorizationValues(operation.Hosts, operation.NotHosts, request.Host, matchHost) &&
+		matchAuthorizationValues(operation.Ports, operation.NotPorts, request.Port, matchExact) &&
+		matchAuthorizationValues(operation.Methods, operation.NotMethods, request.Method, matchExact) &&
+		matchAuthorizationValues(operation.Paths, operation.NotPaths, request.Path, matchAuthorizationString)
+}
+
+func matchAuthorizationCondition(condition *api_security_v1beta1.Condition, request models.AuthorizationRequest, matchUnknown bool) bool {
+	var value string
+	match := matchAuthorizationString
+	switch condition.Key {
+	case "source.ip", "remote.ip":
+		value = request.Source.IP
+		match = matchAuthorizationIP
+	case "source.namespace":
+		value = request.Source.Namespace
+	case "source.principal":
+		value = request.Source.Principal
+	case "request.auth.principal":
+		value = request.Source.RequestPrincipal
+	case "destination.port":
+		value = request.Operation.Port
+	default:
+		return matchUnknown
+	}
+	return matchAuthorizationValues(condition.Values, condition.NotValues, value, match)
+}
+
+// matchAuthorizationValues checks that the value matches any of the values, when set, and none of the notValues
+func matchAuthorizationValues(values, notValues []string, value string, match func(pattern, value string) bool) bool {
+	if len(values) > 0 {
+		if value == "" {
+			return false
+		}
+		matched := false
+		for _, v := range values {
+			if match(v, value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if value != "" {
+		for _, v := range notValues {
+			if match(v, value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matchAuthorizationString supports the exact, prefix "abc*", suffix "*abc" and presence "*" matches
+func matchAuthorizationString(pattern, value string) bool {
+	switch {
+	case pattern == "*":
+		return value != ""
+	case strings.HasPrefix(pattern, "*"):
+		return strings.HasSuffix(value, strings.TrimPrefix(pattern, "*"))
+	case strings.HasSuffix(pattern, "*"):
+		return strings.HasPrefix(value, strings.TrimSuffix(pattern, "*"))
+	default:
+		return pattern == value
+	}
+}
+
+// matchAuthorizationIP supports single IPs and CIDR blocks
+func matchAuthorizationIP(pattern, value string) bool {
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return false
+	}
+	if _, block, err := net.ParseCIDR(pattern); err == nil {
+		return block.Contains(ip)
+	}
+	patternIP := net.ParseIP(pattern)
+	return patternIP != nil && patternIP.Equal(ip)
+}
@@ -0,0 +1,209 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_security_v1beta1 "istio.io/api/security/v1beta1"
+	api_v1beta1 "istio.io/api/type/v1beta1"
+	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+func fakeAuthorizationPolicy(name, namespace string, action api_security_v1beta1.AuthorizationPolicy_Action, selector map[string]string, rules ...*api_security_v1beta1.Rule) *security_v1beta1.AuthorizationPolicy {
+	ap := &security_v1beta1.AuthorizationPolicy{}
+	ap.Name = name
+	ap.Namespace = namespace
+	ap.Spec.Action = action
+	if selector != nil {
+		ap.Spec.Selector = &api_v1beta1.WorkloadSelector{MatchLabels: selector}
+	}
+	ap.Spec.Rules = rules
+	return ap
+}
+
+func mockEvaluateAuthorization(t *testing.T, objects ...runtime.Object) IstioConfigService {
+	t.Helper()
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	config.Set(conf)
+
+	objects = append(objects,
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+	)
+	k8s := kubetest.NewFakeK8sClient(objects...)
+	SetupBusinessLayer(t, k8s, *conf)
+
+	k8sclients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	return NewWithBackends(k8sclients, k8sclients, nil, nil).IstioConfig
+}
+
+var productpageGetRequest = models.AuthorizationRequest{
+	Source: models.AuthorizationSource{
+		Principal: "cluster.local/ns/bookinfo/sa/bookinfo-productpage",
+		Namespace: "bookinfo",
+		IP:        "10.0.0.12",
+	},
+	Operation: models.AuthorizationOperation{
+		Host:   "reviews.bookinfo.svc.cluster.local",
+		Port:   "9080",
+		Method: "GET",
+		Path:   "/reviews/1",
+	},
+}
+
+func TestEvaluateAuthorizationDefaultAllow(t *testing.T) {
+	require := require.New(t)
+
+	// The policy selects other workloads
+	configService := mockEvaluateAuthorization(t,
+		fakeAuthorizationPolicy("ratings-allow-nothing", "bookinfo", api_security_v1beta1.AuthorizationPolicy_ALLOW, map[string]string{"app": "ratings"}),
+	)
+
+	decision, err := configService.EvaluateAuthorization(context.TODO(), "bookinfo", "app=reviews,version=v1", productpageGetRequest)
+	require.NoError(err)
+	require.Equal(models.AuthorizationAllow, decision.Decision)
+	require.Nil(decision.Policy)
+}
+
+func TestEvaluateAuthorizationDenyOverridesAllow(t *testing.T) {
+	require := require.New(t)
+
+	configService := mockEvaluateAuthorization(t,
+		fakeAuthorizationPolicy("allow-bookinfo", "bookinfo", api_security_v1beta1.AuthorizationPolicy_ALLOW, map[string]string{"app": "reviews"},
+			&api_security_v1beta1.Rule{From: []*api_security_v1beta1.Rule_From{{Source: &api_security_v1beta1.Source{Namespaces: []string{"bookinfo"}}}}},
+		),
+		// Mesh wide DENY policy in the root namespace
+		fakeAuthorizationPolicy("deny-writes", "istio-system", api_security_v1beta1.AuthorizationPolicy_DENY, nil,
+			&api_security_v1beta1.Rule{To: []*api_security_v1beta1.Rule_To{{Operation: &api_security_v1beta1.Operation{Methods: []string{"POST"}}}}},
+			&api_security_v1beta1.Rule{To: []*api_security_v1beta1.Rule_To{{Operation: &api_security_v1beta1.Operation{Paths: []string{"/reviews/*"}, NotPaths: []string{"/reviews/public"}}}}},
+		),
+	)
+
+	decision, err := configService.EvaluateAuthorization(context.TODO(), "bookinfo", "app=reviews,version=v1", productpageGetRequest)
+	require.NoError(err)
+	require.Equal(models.AuthorizationDeny, decision.Decision)
+	require.Equal(&models.AuthorizationPolicyMatch{Name: "deny-writes", Namespace: "istio-system", Action: "DENY", Rule: 1}, decision.Policy)
+
+	publicRequest := productpageGetRequest
+	publicRequest.Operation.Path = "/reviews/public"
+	decision, err = configService.EvaluateAuthorization(context.TODO(), "bookinfo", "app=reviews,version=v1", publicRequest)
+	require.NoError(err)
+	require.Equal(models.AuthorizationAllow, decision.Decision)
+	require.Equal(&models.AuthorizationPolicyMatch{Name: "allow-bookinfo", Namespace: "bookinfo", Action: "ALLOW", Rule: 0}, decision.Policy)
+}
+
+func TestEvaluateAuthorizationPolicies(t *testing.T) {
+	reviews := map[string]string{"app": "reviews"}
+	fromProductpage := &api_security_v1beta1.Rule{From: []*api_security_v1beta1.Rule_From{{Source: &api_security_v1beta1.Source{
+		Principals: []string{"cluster.local/ns/bookinfo/sa/bookinfo-productpage"},
+	}}}}
+	fromOtherNamespace := &api_security_v1beta1.Rule{From: []*api_security_v1beta1.Rule_From{{Source: &api_security_v1beta1.Source{
+		Namespaces: []string{"other"},
+	}}}}
+
+	cases := map[string]struct {
+		policies         []*security_v1beta1.AuthorizationPolicy
+		expectedDecision string
+		expectedPolicy   string
+	}{
+		"No policy allows": {
+			expectedDecision: models.AuthorizationAllow,
+		},
+		"Allow nothing denies": {
+			policies:         []*security_v1beta1.AuthorizationPolicy{fakeAuthorizationPolicy("allow-nothing", "bookinfo", api_security_v1beta1.AuthorizationPolicy_ALLOW, reviews)},
+			expectedDecision: models.AuthorizationDeny,
+		},
+		"Not matching allow denies": {
+			policies:         []*security_v1beta1.AuthorizationPolicy{fakeAuthorizationPolicy("allow-other", "bookinfo", api_security_v1beta1.AuthorizationPolicy_ALLOW, reviews, fromOtherNamespace)},
+			expectedDecision: models.AuthorizationDeny,
+		},
+		"Matching allow allows": {
+			policies: []*security_v1beta1.AuthorizationPolicy{
+				fakeAuthorizationPolicy("allow-other", "bookinfo", api_security_v1beta1.AuthorizationPolicy_ALLOW, reviews, fromOtherNamespace),
+				fakeAuthorizationPolicy("allow-productpage", "bookinfo", api_security_v1beta1.AuthorizationPolicy_ALLOW, reviews, fromProductpage),
+			},
+			expectedDecision: models.AuthorizationAllow,
+			expectedPolicy:   "allow-productpage",
+		},
+		"Deny without rules never matches": {
+			policies:         []*security_v1beta1.AuthorizationPolicy{fakeAuthorizationPolicy("deny-nothing", "bookinfo", api_security_v1beta1.AuthorizationPolicy_DENY, reviews)},
+			expectedDecision: models.AuthorizationAllow,
+		},
+		"Deny with an empty rule denies everything": {
+			policies:         []*security_v1beta1.AuthorizationPolicy{fakeAuthorizationPolicy("deny-all", "bookinfo", api_security_v1beta1.AuthorizationPolicy_DENY, reviews, &api_security_v1beta1.Rule{})},
+			expectedDecision: models.AuthorizationDeny,
+			expectedPolicy:   "deny-all",
+		},
+		"Custom is delegated when allowed": {
+			policies: []*security_v1beta1.AuthorizationPolicy{
+				fakeAuthorizationPolicy("ext-authz", "bookinfo", api_security_v1beta1.AuthorizationPolicy_CUSTOM, reviews, fromProductpage),
+			},
+			expectedDecision: models.AuthorizationCustom,
+			expectedPolicy:   "ext-authz",
+		},
+		"Custom can't bypass deny": {
+			policies: []*security_v1beta1.AuthorizationPolicy{
+				fakeAuthorizationPolicy("ext-authz", "bookinfo", api_security_v1beta1.AuthorizationPolicy_CUSTOM, reviews, fromProductpage),
+				fakeAuthorizationPolicy("deny-all", "bookinfo", api_security_v1beta1.AuthorizationPolicy_DENY, reviews, &api_security_v1beta1.Rule{}),
+			},
+			expectedDecision: models.AuthorizationDeny,
+			expectedPolicy:   "deny-all",
+		},
+		"Audit is ignored": {
+			policies:         []*security_v1beta1.AuthorizationPolicy{fakeAuthorizationPolicy("audit-all", "bookinfo", api_security_v1beta1.AuthorizationPolicy_AUDIT, reviews, &api_security_v1beta1.Rule{})},
+			expectedDecision: models.AuthorizationAllow,
+		},
+		"Conditions are evaluated": {
+			policies: []*security_v1beta1.AuthorizationPolicy{
+				fakeAuthorizationPolicy("deny-subnet", "bookinfo", api_security_v1beta1.AuthorizationPolicy_DENY, reviews, &api_security_v1beta1.Rule{
+					When: []*api_security_v1beta1.Condition{{Key: "source.ip", Values: []string{"10.0.0.0/24"}}, {Key: "destination.port", NotValues: []string{"8080"}}},
+				}),
+			},
+			expectedDecision: models.AuthorizationDeny,
+			expectedPolicy:   "deny-subnet",
+		},
+		"Unsupported conditions match for deny": {
+			policies: []*security_v1beta1.AuthorizationPolicy{
+				fakeAuthorizationPolicy("deny-header", "bookinfo", api_security_v1beta1.AuthorizationPolicy_DENY, reviews, &api_security_v1beta1.Rule{
+					When: []*api_security_v1beta1.Condition{{Key: "request.headers[x-user]", Values: []string{"*"}}},
+				}),
+			},
+			expectedDecision: models.AuthorizationDeny,
+			expectedPolicy:   "deny-header",
+		},
+		"Unsupported conditions never match for allow": {
+			policies: []*security_v1beta1.AuthorizationPolicy{
+				fakeAuthorizationPolicy("allow-header", "bookinfo", api_security_v1beta1.AuthorizationPolicy_ALLOW, reviews, &api_security_v1beta1.Rule{
+					When: []*api_security_v1beta1.Condition{{Key: "request.headers[x-user]", Values: []string{"*"}}},
+				}),
+			},
+			expectedDecision: models.AuthorizationDeny,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			decision := evaluateAuthorizationPolicies(tc.policies, productpageGetRequest)
+			assert.Equal(tc.expectedDecision, decision.Decision)
+			assert.NotEmpty(decision.Reason)
+			if tc.expectedPolicy == "" {
+				assert.Nil(decision.Policy)
+			} else if assert.NotNil(decision.Policy) {
+				assert.Equal(tc.expectedPolicy, decision.Policy.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package authorization
+
+import (
+	api_security_v1beta "istio.io/api/security/v1beta1"
+	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
+
+	"github.com/kiali/kiali/models"
+)
+
+// ExtensionProviderChecker validates that a CUSTOM AuthorizationPolicy delegates to an extension provider
+// of the mesh config. Istio doesn't reject a policy referencing an unknown provider, which then silently fails.
+type ExtensionProviderChecker struct {
+	AuthorizationPolicy *security_v1beta.AuthorizationPolicy
+	ExtensionProviders  []string
+}
+
+func (ep ExtensionProviderChecker) Check() ([]*models.IstioCheck, bool) {
+	if ep.AuthorizationPolicy.Spec.Action != api_security_v1beta.AuthorizationPolicy_CUSTOM {
+		return make([]*models.IstioCheck, 0), true
+	}
+
+	providerName := ""
+	if provider := ep.AuthorizationPolicy.Spec.GetProvider(); provider != nil {
+		providerName = provider.Name
+	}
+	for _, name := range ep.ExtensionProviders {
+		if name == providerName {
+			return make([]*models.IstioCheck, 0), true
+		}
+	}
+
+	check := models.Build("authorizationpolicy.provider.notfound", "spec/provider/name")
+	return []*models.IstioCheck{&check}, false
+}
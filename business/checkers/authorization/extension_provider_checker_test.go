@@ -0,0 +1,68 @@
+package authorization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	api_security_v1beta "istio.io/api/security/v1beta1"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestCustomPolicyWithExistingProvider(t *testing.T) {
+	assert := assert.New(t)
+
+	vals, valid := ExtensionProviderChecker{
+		AuthorizationPolicy: data.CreateCustomAuthorizationPolicy("ext-authz", "bookinfo", "opa"),
+		ExtensionProviders:  []string{"envoy-als", "opa"},
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
+
+func TestCustomPolicyWithDanglingProvider(t *testing.T) {
+	assert := assert.New(t)
+
+	vals, valid := ExtensionProviderChecker{
+		AuthorizationPolicy: data.CreateCustomAuthorizationPolicy("ext-authz", "bookinfo", "missing"),
+		ExtensionProviders:  []string{"opa"},
+	}.Check()
+
+	assert.False(valid)
+	assert.Len(vals, 1)
+	assert.Equal(models.ErrorSeverity, vals[0].Severity)
+	assert.Equal("spec/provider/name", vals[0].Path)
+	assert.NoError(validations.ConfirmIstioCheckMessage("authorizationpolicy.provider.notfound", vals[0]))
+}
+
+func TestCustomPolicyWithoutProviders(t *testing.T) {
+	assert := assert.New(t)
+
+	// The mesh config doesn't declare any extension provider
+	vals, valid := ExtensionProviderChecker{
+		AuthorizationPolicy: data.CreateCustomAuthorizationPolicy("ext-authz", "bookinfo", "opa"),
+		ExtensionProviders:  []string{},
+	}.Check()
+
+	assert.False(valid)
+	assert.Len(vals, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("authorizationpolicy.provider.notfound", vals[0]))
+}
+
+func TestNonCustomPolicyIgnoresProviders(t *testing.T) {
+	assert := assert.New(t)
+
+	authPolicy := data.CreateEmptyAuthorizationPolicy("deny-all", "bookinfo")
+	authPolicy.Spec.Action = api_security_v1beta.AuthorizationPolicy_DENY
+
+	vals, valid := ExtensionProviderChecker{
+		AuthorizationPolicy: authPolicy,
+		ExtensionProviders:  []string{},
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
@@ -25,7 +25,9 @@ type AuthorizationPolicyChecker struct {
 	VirtualServices       []*networking_v1beta1.VirtualService
 	RegistryServices      []*kubernetes.RegistryService
 	PolicyAllowAny        bool
-	Cluster               string
+	// ExtensionProviders are the names of the extension providers of the mesh config, nil when it is unknown
+	ExtensionProviders []string
+	Cluster            string
 }
 
 func (a AuthorizationPolicyChecker) Check() models.IstioValidations {
@@ -62,6 +64,9 @@ func (a AuthorizationPolicyChecker) runChecks(authPolicy *security_v1beta.Author
 			ServiceEntries: serviceHosts, VirtualServices: a.VirtualServices, RegistryServices: a.RegistryServices, PolicyAllowAny: a.PolicyAllowAny},
 		authorization.PrincipalsChecker{AuthorizationPolicy: authPolicy, ServiceAccounts: a.ServiceAccountNames(strings.Replace(config.Get().ExternalServices.Istio.IstioIdentityDomain, "svc.", "", 1))},
 	}
+	if a.ExtensionProviders != nil {
+		enabledCheckers = append(enabledCheckers, authorization.ExtensionProviderChecker{AuthorizationPolicy: authPolicy, ExtensionProviders: a.ExtensionProviders})
+	}
 
 	for _, checker := range enabledCheckers {
 		checks, validChecker := checker.Check()
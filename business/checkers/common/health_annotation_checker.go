@@ -0,0 +1,71 @@
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/kiali/kiali/models"
+)
+
+// HealthAnnotationChecker validates the health configuration annotations of a service or workload.
+// The rate annotation is a ';' separated list of tolerances like '4XX,10,20,http,inbound',
+// the frontend ignores the whole annotation when any of them can't be parsed.
+type HealthAnnotationChecker struct {
+	Annotations map[string]string
+}
+
+func (h HealthAnnotationChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+
+	annotation, found := h.Annotations[string(models.RateHealthAnnotation)]
+	if !found {
+		return validations, true
+	}
+
+	valid := true
+	for i, tolerance := range strings.Split(annotation, ";") {
+		path := fmt.Sprintf("metadata/annotations/%s[%d]", models.RateHealthAnnotation, i)
+		degraded, failure, err := parseRateTolerance(tolerance)
+		if err != nil {
+			validation := models.Build("generic.healthannotation.malformed", path)
+			validations = append(validations, &validation)
+			valid = false
+			continue
+		}
+		if degraded < 0 || failure > 100 || degraded > failure {
+			validation := models.Build("generic.healthannotation.outofrange", path)
+			validations = append(validations, &validation)
+		}
+	}
+
+	return validations, valid
+}
+
+// parseRateTolerance parses a tolerance of the rate annotation returning its thresholds.
+func parseRateTolerance(tolerance string) (float64, float64, error) {
+	fields := strings.Split(tolerance, ",")
+	if len(fields) != 5 {
+		return 0, 0, fmt.Errorf("expected 5 fields, found %d", len(fields))
+	}
+
+	// The 'x' in codes like '4XX' stands for any digit
+	code := strings.NewReplacer("x", `\d`, "X", `\d`).Replace(fields[0])
+	for _, expr := range []string{code, fields[3], fields[4]} {
+		if _, err := regexp.Compile(expr); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	degraded, err := strconv.ParseFloat(strings.TrimSpace(fields[1]), 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	failure, err := strconv.ParseFloat(strings.TrimSpace(fields[2]), 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return degraded, failure, nil
+}
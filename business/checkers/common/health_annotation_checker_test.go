@@ -0,0 +1,71 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func healthAnnotationValidations(t *testing.T, annotation string) validations.IstioCheckTestAsserter {
+	vals, valid := HealthAnnotationChecker{
+		Annotations: map[string]string{string(models.RateHealthAnnotation): annotation},
+	}.Check()
+	return validations.IstioCheckTestAsserter{T: t, Validations: vals, Valid: valid}
+}
+
+func TestHealthAnnotationValid(t *testing.T) {
+	healthAnnotationValidations(t, "4XX,10,20,http,inbound").AssertNoValidations()
+	healthAnnotationValidations(t, "5xx,0,100,http,outbound;[1-9],5.5,10,grpc,.*").AssertNoValidations()
+}
+
+func TestHealthAnnotationMissing(t *testing.T) {
+	vals, valid := HealthAnnotationChecker{Annotations: map[string]string{"other": "annotation"}}.Check()
+	validations.IstioCheckTestAsserter{T: t, Validations: vals, Valid: valid}.AssertNoValidations()
+}
+
+func TestHealthAnnotationMalformedJSON(t *testing.T) {
+	tb := healthAnnotationValidations(t, `{"code": "4XX", "degraded": 10, "failure": 20}`)
+	tb.AssertValidationsPresent(1, false)
+	tb.AssertValidationAt(0, models.ErrorSeverity, "metadata/annotations/health.kiali.io/rate[0]", "generic.healthannotation.malformed")
+}
+
+func TestHealthAnnotationMalformed(t *testing.T) {
+	cases := map[string]string{
+		"missing fields":        "4XX,10,20",
+		"non numeric threshold": "4XX,ten,20,http,inbound",
+		"invalid code":          "4XX(,10,20,http,inbound",
+		"invalid protocol":      "4XX,10,20,http[,inbound",
+	}
+
+	for name, annotation := range cases {
+		t.Run(name, func(t *testing.T) {
+			tb := healthAnnotationValidations(t, annotation)
+			tb.AssertValidationsPresent(1, false)
+			tb.AssertValidationAt(0, models.ErrorSeverity, "metadata/annotations/health.kiali.io/rate[0]", "generic.healthannotation.malformed")
+		})
+	}
+}
+
+func TestHealthAnnotationOutOfRange(t *testing.T) {
+	cases := map[string]string{
+		"negative degraded":          "4XX,-1,20,http,inbound",
+		"failure above 100":          "4XX,10,120,http,inbound",
+		"degraded greater than fail": "4XX,30,20,http,inbound",
+	}
+
+	for name, annotation := range cases {
+		t.Run(name, func(t *testing.T) {
+			tb := healthAnnotationValidations(t, annotation)
+			tb.AssertValidationsPresent(1, true)
+			tb.AssertValidationAt(0, models.WarningSeverity, "metadata/annotations/health.kiali.io/rate[0]", "generic.healthannotation.outofrange")
+		})
+	}
+}
+
+func TestHealthAnnotationMixedTolerances(t *testing.T) {
+	tb := healthAnnotationValidations(t, "4XX,10,20,http,inbound;5XX,30,20,http,inbound;grpc")
+	tb.AssertValidationsPresent(2, false)
+	tb.AssertValidationAt(0, models.WarningSeverity, "metadata/annotations/health.kiali.io/rate[1]", "generic.healthannotation.outofrange")
+	tb.AssertValidationAt(1, models.ErrorSeverity, "metadata/annotations/health.kiali.io/rate[2]", "generic.healthannotation.malformed")
+}
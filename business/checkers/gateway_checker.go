@@ -23,6 +23,12 @@ func (g GatewayChecker) Check() models.IstioValidations {
 		Gateways: g.Gateways,
 		Cluster:  g.Cluster,
 	}.Check()
+	validations.MergeValidations(gateways.ConflictChecker{
+		Gateways:              g.Gateways,
+		WorkloadsPerNamespace: g.WorkloadsPerNamespace,
+		IsGatewayToNamespace:  g.IsGatewayToNamespace,
+		Cluster:               g.Cluster,
+	}.Check())
 
 	for _, gw := range g.Gateways {
 		validations.MergeValidations(g.runSingleChecks(gw))
@@ -0,0 +1,73 @@
+package gateways
+
+import (
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// ConflictChecker looks for the Gateways bound to the same ingress workload through different selectors
+// that share the same host+port combination.
+type ConflictChecker struct {
+	Cluster               string
+	Gateways              []*networking_v1beta1.Gateway
+	WorkloadsPerNamespace map[string]models.WorkloadList
+	IsGatewayToNamespace  bool
+}
+
+// Check validates that the Gateways visible to each ingress workload don't share the same host+port combination.
+// The Gateways with the same selector are already compared by the MultiMatchChecker, so only the workloads
+// selected by several different selectors are checked here.
+func (c ConflictChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	for _, wls := range c.WorkloadsPerNamespace {
+		for _, wl := range wls.Workloads {
+			gws := c.gatewaysForWorkload(wls.Namespace.Name, wl.Labels)
+			if !multipleSelectors(gws) {
+				continue
+			}
+			// All the Gateways bind the same workload, so they are compared as a single group
+			validations.MergeValidations(MultiMatchChecker{
+				Cluster:  c.Cluster,
+				Gateways: gws,
+			}.check(func(*networking_v1beta1.Gateway) string { return "" }))
+		}
+	}
+
+	return validations
+}
+
+// gatewaysForWorkload returns the Gateways visible to a workload, or none when the workload is not selected
+// explicitly by any Gateway, as a Gateway without selector would match any workload, not only the ingress ones.
+func (c ConflictChecker) gatewaysForWorkload(namespace string, workloadLabels map[string]string) []*networking_v1beta1.Gateway {
+	candidates := make([]*networking_v1beta1.Gateway, 0, len(c.Gateways))
+	for _, gw := range c.Gateways {
+		if c.IsGatewayToNamespace && gw.Namespace != namespace {
+			continue
+		}
+		candidates = append(candidates, gw)
+	}
+
+	gws := kubernetes.FilterGatewaysBySelector(labels.Set(workloadLabels).String(), candidates)
+	for _, gw := range gws {
+		if len(gw.Spec.Selector) > 0 {
+			return gws
+		}
+	}
+	return nil
+}
+
+func multipleSelectors(gws []*networking_v1beta1.Gateway) bool {
+	if len(gws) < 2 {
+		return false
+	}
+	for _, gw := range gws[1:] {
+		if gatewaySelector(gw) != gatewaySelector(gws[0]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+package gateways
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func ingressWorkloads() map[string]models.WorkloadList {
+	return map[string]models.WorkloadList{
+		"istio-system": data.CreateWorkloadList("istio-system",
+			data.CreateWorkloadListItem("istio-ingressgateway", map[string]string{"istio": "ingressgateway", "app": "istio-ingressgateway"}),
+			data.CreateWorkloadListItem("internal-ingressgateway", map[string]string{"istio": "internal-ingressgateway"})),
+		"bookinfo": data.CreateWorkloadList("bookinfo",
+			data.CreateWorkloadListItem("reviews-v1", map[string]string{"app": "reviews", "version": "v1"})),
+	}
+}
+
+func TestConflictingGatewaysOnDistinctSelectors(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	assert := assert.New(t)
+	require := require.New(t)
+
+	gws := []*networking_v1beta1.Gateway{
+		data.AddServerToGateway(data.CreateServer([]string{"bookinfo.example.com"}, 443, "https", "HTTPS"),
+			data.CreateEmptyGateway("bookinfo-gateway", "bookinfo", map[string]string{"istio": "ingressgateway"})),
+		data.AddServerToGateway(data.CreateServer([]string{"*.example.com"}, 443, "https", "HTTPS"),
+			data.AddServerToGateway(data.CreateServer([]string{"*.example.com"}, 80, "http", "HTTP"),
+				data.CreateEmptyGateway("wildcard-gateway", "travels", map[string]string{"app": "istio-ingressgateway"}))),
+	}
+
+	vals := ConflictChecker{
+		Gateways:              gws,
+		WorkloadsPerNamespace: ingressWorkloads(),
+	}.Check()
+
+	require.Len(vals, 2)
+
+	bookinfoKey := models.IstioValidationKey{ObjectType: "gateway", Namespace: "bookinfo", Name: "bookinfo-gateway"}
+	wildcardKey := models.IstioValidationKey{ObjectType: "gateway", Namespace: "travels", Name: "wildcard-gateway"}
+
+	validation, ok := vals[bookinfoKey]
+	require.True(ok)
+	assert.True(validation.Valid)
+	require.Len(validation.Checks, 1)
+	assert.Equal(models.WarningSeverity, validation.Checks[0].Severity)
+	assert.Equal("spec/servers[0]/hosts[0]", validation.Checks[0].Path)
+	assert.Equal(models.CheckMessage("gateways.multimatch"), validation.Checks[0].GetFullMessage())
+	assert.Equal([]models.IstioValidationKey{wildcardKey}, validation.References)
+
+	// Only the HTTPS server binds the same port
+	validation, ok = vals[wildcardKey]
+	require.True(ok)
+	require.Len(validation.Checks, 1)
+	assert.Equal("spec/servers[1]/hosts[0]", validation.Checks[0].Path)
+	assert.Equal([]models.IstioValidationKey{bookinfoKey}, validation.References)
+}
+
+func TestConflictingGatewaysOnSharedSelector(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	selector := map[string]string{"istio": "ingressgateway"}
+	gws := []*networking_v1beta1.Gateway{
+		data.AddServerToGateway(data.CreateServer([]string{"bookinfo.example.com"}, 443, "https", "HTTPS"),
+			data.CreateEmptyGateway("bookinfo-gateway", "bookinfo", selector)),
+		data.AddServerToGateway(data.CreateServer([]string{"*.example.com"}, 443, "https", "HTTPS"),
+			data.CreateEmptyGateway("wildcard-gateway", "travels", selector)),
+	}
+
+	// The Gateways with the same selector are reported by the MultiMatchChecker
+	vals := ConflictChecker{
+		Gateways:              gws,
+		WorkloadsPerNamespace: ingressWorkloads(),
+	}.Check()
+
+	assert.Empty(t, vals)
+	assert.Len(t, MultiMatchChecker{Gateways: gws}.Check(), 2)
+}
+
+func TestNonConflictingGateways(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	gws := []*networking_v1beta1.Gateway{
+		data.AddServerToGateway(data.CreateServer([]string{"*.example.com"}, 443, "https", "HTTPS"),
+			data.CreateEmptyGateway("bookinfo-gateway", "bookinfo", map[string]string{"istio": "ingressgateway"})),
+		// Distinct ingress workload
+		data.AddServerToGateway(data.CreateServer([]string{"*.example.com"}, 443, "https", "HTTPS"),
+			data.CreateEmptyGateway("internal-gateway", "travels", map[string]string{"istio": "internal-ingressgateway"})),
+		// Hosts not overlapping
+		data.AddServerToGateway(data.CreateServer([]string{"*.example.org", "api.example.net"}, 443, "https", "HTTPS"),
+			data.CreateEmptyGateway("org-gateway", "travels", map[string]string{"app": "istio-ingressgateway"})),
+		// Port not shared
+		data.AddServerToGateway(data.CreateServer([]string{"*"}, 8080, "http", "HTTP"),
+			data.CreateEmptyGateway("http-gateway", "travels", map[string]string{"app": "istio-ingressgateway"})),
+	}
+
+	vals := ConflictChecker{
+		Gateways:              gws,
+		WorkloadsPerNamespace: ingressWorkloads(),
+	}.Check()
+
+	assert.Empty(t, vals)
+}
+
+func TestConflictingGatewaysWithoutSelector(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	gws := []*networking_v1beta1.Gateway{
+		data.AddServerToGateway(data.CreateServer([]string{"*"}, 443, "https", "HTTPS"),
+			data.CreateEmptyGateway("default-gateway", "istio-system", nil)),
+		data.AddServerToGateway(data.CreateServer([]string{"bookinfo.example.com"}, 443, "tls", "TLS"),
+			data.CreateEmptyGateway("bookinfo-gateway", "bookinfo", map[string]string{"istio": "ingressgateway"})),
+	}
+
+	vals := ConflictChecker{
+		Gateways:              gws,
+		WorkloadsPerNamespace: ingressWorkloads(),
+	}.Check()
+
+	assert.Len(t, vals, 2)
+
+	// The wildcard host is not compared when it is skipped by the configuration
+	conf.KialiFeatureFlags.Validations.SkipWildcardGatewayHosts = true
+	config.Set(conf)
+
+	vals = ConflictChecker{
+		Gateways:              gws,
+		WorkloadsPerNamespace: ingressWorkloads(),
+	}.Check()
+
+	assert.Empty(t, vals)
+}
+
+func TestConflictingGatewaysToNamespace(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	gws := []*networking_v1beta1.Gateway{
+		data.AddServerToGateway(data.CreateServer([]string{"bookinfo.example.com"}, 443, "https", "HTTPS"),
+			data.CreateEmptyGateway("bookinfo-gateway", "istio-system", map[string]string{"istio": "ingressgateway"})),
+		data.AddServerToGateway(data.CreateServer([]string{"bookinfo.example.com"}, 443, "https", "HTTPS"),
+			data.CreateEmptyGateway("bookinfo-gateway", "bookinfo", map[string]string{"app": "istio-ingressgateway"})),
+	}
+
+	// The Gateway in the bookinfo namespace doesn't apply to the ingress workload of istio-system
+	vals := ConflictChecker{
+		Gateways:              gws,
+		WorkloadsPerNamespace: ingressWorkloads(),
+		IsGatewayToNamespace:  true,
+	}.Check()
+
+	assert.Empty(t, vals)
+}
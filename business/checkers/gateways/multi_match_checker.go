@@ -41,6 +41,11 @@ type Host struct {
 
 // Check validates that no two gateways share the same host+port combination
 func (m MultiMatchChecker) Check() models.IstioValidations {
+	return m.check(gatewaySelector)
+}
+
+// check compares the host+port combinations of the gateways that share the same group
+func (m MultiMatchChecker) check(group func(*networking_v1beta1.Gateway) string) models.IstioValidations {
 	validations := models.IstioValidations{}
 	m.existingList = map[string][]Host{}
 	m.hostRegexpCache = map[string]regexp.Regexp{}
@@ -49,10 +54,7 @@ func (m MultiMatchChecker) Check() models.IstioValidations {
 		gatewayRuleName := g.Name
 		gatewayNamespace := g.Namespace
 
-		selectorString := ""
-		if len(g.Spec.Selector) > 0 {
-			selectorString = labels.Set(g.Spec.Selector).String()
-		}
+		selectorString := group(g)
 		for i, server := range g.Spec.Servers {
 			if server == nil {
 				continue
@@ -102,6 +104,13 @@ func (m MultiMatchChecker) Check() models.IstioValidations {
 	return validations
 }
 
+func gatewaySelector(g *networking_v1beta1.Gateway) string {
+	if len(g.Spec.Selector) > 0 {
+		return labels.Set(g.Spec.Selector).String()
+	}
+	return ""
+}
+
 func createError(gatewayRuleName, namespace, cluster string, serverIndex, hostIndex int) models.IstioValidations {
 	key := models.IstioValidationKey{Name: gatewayRuleName, Namespace: namespace, ObjectType: GatewayCheckerType, Cluster: cluster}
 	checks := models.Build("gateways.multimatch",
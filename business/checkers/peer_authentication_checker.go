@@ -16,7 +16,15 @@ type PeerAuthenticationChecker struct {
 	PeerAuthentications   []*security_v1beta.PeerAuthentication
 	MTLSDetails           kubernetes.MTLSDetails
 	WorkloadsPerNamespace map[string]models.WorkloadList
-	Cluster               string
+	// The container and target ports of the pods selected by each PeerAuthentication, by PeerAuthnPortsKey.
+	// The port-level mTLS of the PeerAuthentications selecting no pods is not validated.
+	WorkloadPorts map[string]map[uint32]bool
+	Cluster       string
+}
+
+// PeerAuthnPortsKey is the key of the ports of the pods selected by a PeerAuthentication
+func PeerAuthnPortsKey(peerAuthn *security_v1beta.PeerAuthentication) string {
+	return peerAuthn.Namespace + "/" + peerAuthn.Name
 }
 
 func (m PeerAuthenticationChecker) Check() models.IstioValidations {
@@ -49,6 +57,10 @@ func (m PeerAuthenticationChecker) runChecks(peerAuthn *security_v1beta.PeerAuth
 		enabledCheckers = append(enabledCheckers, peerauthentications.DisabledNamespaceWideChecker{PeerAuthn: peerAuthn, DestinationRules: m.MTLSDetails.DestinationRules})
 	}
 
+	if workloadPorts, found := m.WorkloadPorts[PeerAuthnPortsKey(peerAuthn)]; found {
+		enabledCheckers = append(enabledCheckers, peerauthentications.PortLevelMtlsChecker{PeerAuthn: peerAuthn, MeshPeerAuthentications: m.MTLSDetails.MeshPeerAuthentications, WorkloadPorts: workloadPorts})
+	}
+
 	// PeerAuthentications into  the root namespace namespace are considered Mesh-wide objects
 	if config.IsRootNamespace(peerAuthn.Namespace) {
 		enabledCheckers = append(enabledCheckers,
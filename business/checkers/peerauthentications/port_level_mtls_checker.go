@@ -0,0 +1,60 @@
+package peerauthentications
+
+import (
+	"fmt"
+	"sort"
+
+	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// PortLevelMtlsChecker validates the port-level mTLS overrides of a PeerAuthentication selecting workloads.
+// An override is flagged when its port isn't exposed by the selected workloads, as it's silently ignored,
+// or when it downgrades the mTLS mode while a mesh-wide PeerAuthentication enforces STRICT mTLS.
+type PortLevelMtlsChecker struct {
+	PeerAuthn               *security_v1beta.PeerAuthentication
+	MeshPeerAuthentications []*security_v1beta.PeerAuthentication
+	// The container and target ports of the workloads selected by the PeerAuthn
+	WorkloadPorts map[uint32]bool
+}
+
+func (c PortLevelMtlsChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+
+	// Port-level mTLS only applies to the PeerAuthentications with a workload selector
+	if c.PeerAuthn.Spec.Selector == nil || len(c.PeerAuthn.Spec.PortLevelMtls) == 0 {
+		return validations, true
+	}
+
+	meshStrict := false
+	for _, mpa := range c.MeshPeerAuthentications {
+		if kubernetes.PeerAuthnHasStrictMTLS(mpa) {
+			meshStrict = true
+			break
+		}
+	}
+
+	ports := make([]int, 0, len(c.PeerAuthn.Spec.PortLevelMtls))
+	for port := range c.PeerAuthn.Spec.PortLevelMtls {
+		ports = append(ports, int(port))
+	}
+	sort.Ints(ports)
+
+	for _, port := range ports {
+		path := fmt.Sprintf("spec/portLevelMtls/%d", port)
+		if !c.WorkloadPorts[uint32(port)] {
+			check := models.Build("peerauthentications.mtls.portnotfound", path)
+			validations = append(validations, &check)
+		}
+		if mtls := c.PeerAuthn.Spec.PortLevelMtls[uint32(port)]; meshStrict && mtls != nil {
+			if mode := mtls.Mode.String(); mode == "PERMISSIVE" || mode == "DISABLE" {
+				check := models.Build("peerauthentications.mtls.portdowngrade", path+"/mode")
+				validations = append(validations, &check)
+			}
+		}
+	}
+
+	return validations, true
+}
@@ -0,0 +1,96 @@
+package peerauthentications
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+// Context: PeerAuthn overrides the mTLS of a port exposed by the selected workloads
+// Context: No mesh-wide PeerAuthn
+// It doesn't return any validation
+func TestPortLevelMtlsOnExposedPort(t *testing.T) {
+	assert := assert.New(t)
+
+	peerAuthn := data.AddPortLevelMtlsToPeerAuthn(9080, "PERMISSIVE",
+		data.CreateEmptyPeerAuthenticationWithSelector("reviews", "bookinfo", data.CreateOneLabelSelector("reviews")))
+
+	vals, valid := PortLevelMtlsChecker{
+		PeerAuthn:     peerAuthn,
+		WorkloadPorts: map[uint32]bool{9080: true},
+	}.Check()
+
+	assert.Empty(vals)
+	assert.True(valid)
+}
+
+// Context: PeerAuthn overrides the mTLS of a port not exposed by the selected workloads
+// It returns a validation
+func TestPortLevelMtlsOnMissingPort(t *testing.T) {
+	assert := assert.New(t)
+
+	peerAuthn := data.AddPortLevelMtlsToPeerAuthn(8080, "STRICT",
+		data.AddPortLevelMtlsToPeerAuthn(9080, "STRICT",
+			data.CreateEmptyPeerAuthenticationWithSelector("reviews", "bookinfo", data.CreateOneLabelSelector("reviews"))))
+
+	vals, valid := PortLevelMtlsChecker{
+		PeerAuthn:     peerAuthn,
+		WorkloadPorts: map[uint32]bool{9080: true},
+	}.Check()
+
+	assert.True(valid)
+	assert.Len(vals, 1)
+	assert.Equal(models.WarningSeverity, vals[0].Severity)
+	assert.Equal("spec/portLevelMtls/8080", vals[0].Path)
+	assert.NoError(validations.ConfirmIstioCheckMessage("peerauthentications.mtls.portnotfound", vals[0]))
+}
+
+// Context: Mesh-wide PeerAuthn enables STRICT mTLS
+// Context: PeerAuthn overrides the mTLS of an exposed port with PERMISSIVE and of another with DISABLE
+// It returns a validation per port
+func TestPortLevelMtlsDowngradesStrictMesh(t *testing.T) {
+	assert := assert.New(t)
+
+	peerAuthn := data.AddPortLevelMtlsToPeerAuthn(9090, "DISABLE",
+		data.AddPortLevelMtlsToPeerAuthn(9080, "PERMISSIVE",
+			data.CreateEmptyPeerAuthenticationWithSelector("reviews", "bookinfo", data.CreateOneLabelSelector("reviews"))))
+
+	vals, valid := PortLevelMtlsChecker{
+		PeerAuthn:               peerAuthn,
+		MeshPeerAuthentications: []*security_v1beta.PeerAuthentication{data.CreateEmptyMeshPeerAuthentication("default", data.CreateMTLS("STRICT"))},
+		WorkloadPorts:           map[uint32]bool{9080: true, 9090: true},
+	}.Check()
+
+	assert.True(valid)
+	assert.Len(vals, 2)
+	assert.Equal("spec/portLevelMtls/9080/mode", vals[0].Path)
+	assert.Equal("spec/portLevelMtls/9090/mode", vals[1].Path)
+	for _, val := range vals {
+		assert.Equal(models.WarningSeverity, val.Severity)
+		assert.NoError(validations.ConfirmIstioCheckMessage("peerauthentications.mtls.portdowngrade", val))
+	}
+}
+
+// Context: Mesh-wide PeerAuthn enables STRICT mTLS
+// Context: PeerAuthn keeps STRICT mTLS on an exposed port
+// It doesn't return any validation
+func TestPortLevelMtlsStrictOnStrictMesh(t *testing.T) {
+	assert := assert.New(t)
+
+	peerAuthn := data.AddPortLevelMtlsToPeerAuthn(9080, "STRICT",
+		data.CreateEmptyPeerAuthenticationWithSelector("reviews", "bookinfo", data.CreateOneLabelSelector("reviews")))
+
+	vals, valid := PortLevelMtlsChecker{
+		PeerAuthn:               peerAuthn,
+		MeshPeerAuthentications: []*security_v1beta.PeerAuthentication{data.CreateEmptyMeshPeerAuthentication("default", data.CreateMTLS("STRICT"))},
+		WorkloadPorts:           map[uint32]bool{9080: true},
+	}.Check()
+
+	assert.Empty(vals)
+	assert.True(valid)
+}
@@ -5,6 +5,7 @@ import (
 	core_v1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 
+	"github.com/kiali/kiali/business/checkers/common"
 	"github.com/kiali/kiali/business/checkers/services"
 	"github.com/kiali/kiali/models"
 )
@@ -33,6 +34,7 @@ func (sc ServiceChecker) runSingleChecks(service v1.Service) models.IstioValidat
 
 	enabledCheckers := []Checker{
 		services.PortMappingChecker{Service: service, Deployments: sc.Deployments, Pods: sc.Pods},
+		common.HealthAnnotationChecker{Annotations: service.Annotations},
 	}
 
 	for _, checker := range enabledCheckers {
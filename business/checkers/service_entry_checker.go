@@ -18,7 +18,11 @@ type ServiceEntryChecker struct {
 }
 
 func (s ServiceEntryChecker) Check() models.IstioValidations {
-	validations := models.IstioValidations{}
+	// Multinamespace checkers
+	validations := serviceentries.MultiHostChecker{
+		ServiceEntries: s.ServiceEntries,
+		Cluster:        s.Cluster,
+	}.Check()
 
 	weMap := serviceentries.GroupWorkloadEntriesByLabels(s.WorkloadEntries)
 
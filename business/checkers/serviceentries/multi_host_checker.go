@@ -0,0 +1,115 @@
+package serviceentries
+
+import (
+	"fmt"
+	"strings"
+
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+
+	"github.com/kiali/kiali/models"
+)
+
+const (
+	ServiceEntryCheckerType = "serviceentry"
+	exportToAll             = "*"
+	exportToCurrent         = "."
+	exportToNone            = "~"
+)
+
+// MultiHostChecker flags the ServiceEntries declaring the same host, or overlapping wildcard hosts,
+// when they are visible from a common namespace: Istio resolution of that host is undefined.
+type MultiHostChecker struct {
+	Cluster        string
+	ServiceEntries []*networking_v1beta1.ServiceEntry
+}
+
+func (m MultiHostChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	for i, se := range m.ServiceEntries {
+		for _, other := range m.ServiceEntries[i+1:] {
+			if !shareVisibility(se, other) {
+				continue
+			}
+			for hostIndex, host := range se.Spec.Hosts {
+				for otherHostIndex, otherHost := range other.Spec.Hosts {
+					if hostsOverlap(host, otherHost) {
+						validations.MergeValidations(m.overlapValidation(se, hostIndex, other))
+						validations.MergeValidations(m.overlapValidation(other, otherHostIndex, se))
+					}
+				}
+			}
+		}
+	}
+
+	return validations
+}
+
+func (m MultiHostChecker) overlapValidation(se *networking_v1beta1.ServiceEntry, hostIndex int, conflicting *networking_v1beta1.ServiceEntry) models.IstioValidations {
+	key := models.IstioValidationKey{ObjectType: ServiceEntryCheckerType, Name: se.Name, Namespace: se.Namespace, Cluster: m.Cluster}
+	check := models.Build("serviceentries.hosts.overlap", fmt.Sprintf("spec/hosts[%d]", hostIndex))
+	return models.IstioValidations{key: &models.IstioValidation{
+		Name:       se.Name,
+		ObjectType: ServiceEntryCheckerType,
+		Valid:      true,
+		Checks:     []*models.IstioCheck{&check},
+		References: []models.IstioValidationKey{
+			{ObjectType: ServiceEntryCheckerType, Name: conflicting.Name, Namespace: conflicting.Namespace, Cluster: m.Cluster},
+		},
+	}}
+}
+
+// hostsOverlap returns true when both hosts can resolve the same hostname, considering wildcards
+func hostsOverlap(host, other string) bool {
+	if host == other || host == exportToAll || other == exportToAll {
+		return true
+	}
+	return withinWildcard(host, other) || withinWildcard(other, host)
+}
+
+// withinWildcard returns true when the host is a subdomain of the wildcard host, i.e. bar.foo.com or *.bar.foo.com within *.foo.com.
+// The wildcard doesn't match its parent domain (foo.com).
+func withinWildcard(host, wildcard string) bool {
+	if !strings.HasPrefix(wildcard, "*.") {
+		return false
+	}
+	return strings.HasSuffix(host, wildcard[1:])
+}
+
+// shareVisibility returns true when there is a namespace where both ServiceEntries are exported
+func shareVisibility(se, other *networking_v1beta1.ServiceEntry) bool {
+	visible, allVisible := exportedNamespaces(se)
+	otherVisible, otherAllVisible := exportedNamespaces(other)
+	if allVisible {
+		return otherAllVisible || len(otherVisible) > 0
+	}
+	if otherAllVisible {
+		return len(visible) > 0
+	}
+	for ns := range visible {
+		if otherVisible[ns] {
+			return true
+		}
+	}
+	return false
+}
+
+// exportedNamespaces resolves the exportTo of a ServiceEntry, an empty exportTo means exported to all namespaces
+func exportedNamespaces(se *networking_v1beta1.ServiceEntry) (map[string]bool, bool) {
+	namespaces := map[string]bool{}
+	if len(se.Spec.ExportTo) == 0 {
+		return namespaces, true
+	}
+	for _, exportTo := range se.Spec.ExportTo {
+		switch exportTo {
+		case exportToAll:
+			return namespaces, true
+		case exportToCurrent:
+			namespaces[se.Namespace] = true
+		case exportToNone:
+		default:
+			namespaces[exportTo] = true
+		}
+	}
+	return namespaces, false
+}
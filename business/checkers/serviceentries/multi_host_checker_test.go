@@ -0,0 +1,101 @@
+package serviceentries
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func serviceEntryKey(name, namespace string) models.IstioValidationKey {
+	return models.IstioValidationKey{ObjectType: "serviceentry", Name: name, Namespace: namespace}
+}
+
+func assertOverlap(t *testing.T, vals models.IstioValidations, name, namespace, path string, references ...models.IstioValidationKey) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	validation, ok := vals[serviceEntryKey(name, namespace)]
+	require.True(ok)
+	assert.True(validation.Valid)
+	require.Len(validation.Checks, 1)
+	assert.Equal("KIA1202", validation.Checks[0].Code)
+	assert.Equal(models.WarningSeverity, validation.Checks[0].Severity)
+	assert.Equal(path, validation.Checks[0].Path)
+	assert.ElementsMatch(references, validation.References)
+}
+
+func TestServiceEntriesExactHostOverlap(t *testing.T) {
+	vals := MultiHostChecker{
+		ServiceEntries: []*networking_v1beta1.ServiceEntry{
+			data.CreateEmptyMeshExternalServiceEntry("google", "bookinfo", []string{"www.google.com"}),
+			data.CreateEmptyMeshExternalServiceEntry("google-api", "bookinfo", []string{"api.google.com", "www.google.com"}),
+			data.CreateEmptyMeshExternalServiceEntry("other-google", "default", []string{"www.google.com"}),
+		},
+	}.Check()
+
+	assert.Len(t, vals, 3)
+	assertOverlap(t, vals, "google", "bookinfo", "spec/hosts[0]", serviceEntryKey("google-api", "bookinfo"), serviceEntryKey("other-google", "default"))
+	assertOverlap(t, vals, "google-api", "bookinfo", "spec/hosts[1]", serviceEntryKey("google", "bookinfo"), serviceEntryKey("other-google", "default"))
+	assertOverlap(t, vals, "other-google", "default", "spec/hosts[0]", serviceEntryKey("google", "bookinfo"), serviceEntryKey("google-api", "bookinfo"))
+}
+
+func TestServiceEntriesWildcardHostOverlap(t *testing.T) {
+	vals := MultiHostChecker{
+		ServiceEntries: []*networking_v1beta1.ServiceEntry{
+			data.CreateEmptyMeshExternalServiceEntry("wildcard", "bookinfo", []string{"*.foo.com"}),
+			data.CreateEmptyMeshExternalServiceEntry("bar", "bookinfo", []string{"bar.foo.com"}),
+			data.CreateEmptyMeshExternalServiceEntry("sub-wildcard", "bookinfo", []string{"www.example.com", "*.baz.foo.com"}),
+		},
+	}.Check()
+
+	assert.Len(t, vals, 3)
+	assertOverlap(t, vals, "wildcard", "bookinfo", "spec/hosts[0]", serviceEntryKey("bar", "bookinfo"), serviceEntryKey("sub-wildcard", "bookinfo"))
+	assertOverlap(t, vals, "bar", "bookinfo", "spec/hosts[0]", serviceEntryKey("wildcard", "bookinfo"))
+	assertOverlap(t, vals, "sub-wildcard", "bookinfo", "spec/hosts[1]", serviceEntryKey("wildcard", "bookinfo"))
+}
+
+func TestServiceEntriesNoHostOverlap(t *testing.T) {
+	assert := assert.New(t)
+
+	vals := MultiHostChecker{
+		ServiceEntries: []*networking_v1beta1.ServiceEntry{
+			data.CreateEmptyMeshExternalServiceEntry("google", "bookinfo", []string{"www.google.com"}),
+			data.CreateEmptyMeshExternalServiceEntry("wildcard", "bookinfo", []string{"*.foo.com"}),
+			// The wildcard doesn't match the parent domain
+			data.CreateEmptyMeshExternalServiceEntry("foo", "bookinfo", []string{"foo.com"}),
+			data.CreateEmptyMeshInternalServiceEntry("ratings", "bookinfo", []string{"ratings.mesh.internal"}),
+		},
+	}.Check()
+
+	assert.Empty(vals)
+}
+
+func TestServiceEntriesHostOverlapNotExported(t *testing.T) {
+	assert := assert.New(t)
+
+	local := data.CreateEmptyMeshExternalServiceEntry("google", "bookinfo", []string{"www.google.com"})
+	local.Spec.ExportTo = []string{"."}
+	other := data.CreateEmptyMeshExternalServiceEntry("google", "default", []string{"www.google.com"})
+	other.Spec.ExportTo = []string{"default", "travels"}
+	hidden := data.CreateEmptyMeshExternalServiceEntry("hidden-google", "travels", []string{"www.google.com"})
+	hidden.Spec.ExportTo = []string{"~"}
+
+	vals := MultiHostChecker{
+		ServiceEntries: []*networking_v1beta1.ServiceEntry{local, other, hidden},
+	}.Check()
+	assert.Empty(vals)
+
+	// Exported to bookinfo, it conflicts with the local one
+	other.Spec.ExportTo = []string{"bookinfo"}
+	vals = MultiHostChecker{
+		ServiceEntries: []*networking_v1beta1.ServiceEntry{local, other, hidden},
+	}.Check()
+	assert.Len(vals, 2)
+	assertOverlap(t, vals, "google", "bookinfo", "spec/hosts[0]", serviceEntryKey("google", "default"))
+	assertOverlap(t, vals, "google", "default", "spec/hosts[0]", serviceEntryKey("google", "bookinfo"))
+}
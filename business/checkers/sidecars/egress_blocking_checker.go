@@ -0,0 +1,57 @@
+package sidecars
+
+import (
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// EgressBlockingChecker warns when the egress listeners of a Sidecar leave out the control plane, or hosts called by
+// the workloads the Sidecar applies to. Their proxies can't reach those hosts anymore.
+type EgressBlockingChecker struct {
+	Sidecar *networking_v1beta1.Sidecar
+	// Host of the istiod service, not checked when empty
+	ControlPlaneHost string
+	// Hosts called by the workloads of the Sidecar, as reported by the telemetry
+	CalledHosts    []string
+	ServiceEntries []*networking_v1beta1.ServiceEntry
+}
+
+func (c EgressBlockingChecker) Check() ([]*models.IstioCheck, bool) {
+	checks := make([]*models.IstioCheck, 0)
+
+	controlPlaneBlocked, blockedHosts := c.BlockedHosts()
+	if controlPlaneBlocked {
+		check := models.Build("sidecar.egress.controlplaneblocked", "spec/egress")
+		checks = append(checks, &check)
+	}
+	if len(blockedHosts) > 0 {
+		check := models.Build("sidecar.egress.calledhostblocked", "spec/egress")
+		checks = append(checks, &check)
+	}
+
+	return checks, true
+}
+
+// BlockedHosts tells whether the Sidecar blocks the control plane, and returns the called hosts it blocks
+func (c EgressBlockingChecker) BlockedHosts() (bool, []string) {
+	blockedHosts := []string{}
+	if len(c.Sidecar.Spec.Egress) == 0 {
+		return false, blockedHosts
+	}
+
+	controlPlaneBlocked := c.ControlPlaneHost != "" &&
+		!kubernetes.SidecarEgressAllows(c.Sidecar, c.ControlPlaneHost, c.Sidecar.Namespace, c.ServiceEntries)
+	seen := map[string]bool{}
+	for _, host := range c.CalledHosts {
+		if seen[host] {
+			continue
+		}
+		seen[host] = true
+		if !kubernetes.SidecarEgressAllows(c.Sidecar, host, c.Sidecar.Namespace, c.ServiceEntries) {
+			blockedHosts = append(blockedHosts, host)
+		}
+	}
+	return controlPlaneBlocked, blockedHosts
+}
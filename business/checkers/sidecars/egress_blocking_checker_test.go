@@ -0,0 +1,75 @@
+package sidecars
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestEgressBlockingOverRestrictive(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	checker := EgressBlockingChecker{
+		Sidecar:          data.AddHostsToSidecar([]string{"./*"}, data.CreateSidecar("sidecar", "bookinfo")),
+		ControlPlaneHost: "istiod.istio-system.svc.cluster.local",
+		CalledHosts: []string{
+			"ratings.bookinfo.svc.cluster.local",
+			"details.travels.svc.cluster.local",
+			"details.travels.svc.cluster.local",
+		},
+	}
+	vals, valid := checker.Check()
+
+	assert.True(valid)
+	assert.Len(vals, 2)
+	assert.Equal(models.WarningSeverity, vals[0].Severity)
+	assert.Equal("spec/egress", vals[0].Path)
+	assert.NoError(validations.ConfirmIstioCheckMessage("sidecar.egress.controlplaneblocked", vals[0]))
+	assert.Equal(models.WarningSeverity, vals[1].Severity)
+	assert.Equal("spec/egress", vals[1].Path)
+	assert.NoError(validations.ConfirmIstioCheckMessage("sidecar.egress.calledhostblocked", vals[1]))
+
+	controlPlaneBlocked, blockedHosts := checker.BlockedHosts()
+	assert.True(controlPlaneBlocked)
+	assert.Equal([]string{"details.travels.svc.cluster.local"}, blockedHosts)
+}
+
+func TestEgressBlockingPermissive(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	calledHosts := []string{"ratings.bookinfo.svc.cluster.local", "details.travels.svc.cluster.local"}
+	for _, hosts := range [][]string{
+		{"./*", "istio-system/*", "travels/details.travels.svc.cluster.local"},
+		{"*/*"},
+	} {
+		vals, valid := EgressBlockingChecker{
+			Sidecar:          data.AddHostsToSidecar(hosts, data.CreateSidecar("sidecar", "bookinfo")),
+			ControlPlaneHost: "istiod.istio-system.svc.cluster.local",
+			CalledHosts:      calledHosts,
+		}.Check()
+		assert.Empty(vals)
+		assert.True(valid)
+	}
+
+	// Without egress listeners nothing is restricted
+	vals, valid := EgressBlockingChecker{
+		Sidecar:          data.CreateSidecar("sidecar", "bookinfo"),
+		ControlPlaneHost: "istiod.istio-system.svc.cluster.local",
+		CalledHosts:      calledHosts,
+	}.Check()
+	assert.Empty(vals)
+	assert.True(valid)
+
+	// The control plane is not checked without its host
+	vals, _ = EgressBlockingChecker{
+		Sidecar: data.AddHostsToSidecar([]string{"./*"}, data.CreateSidecar("sidecar", "bookinfo")),
+	}.Check()
+	assert.Empty(vals)
+}
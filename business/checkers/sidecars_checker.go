@@ -18,6 +18,8 @@ type SidecarChecker struct {
 	WorkloadsPerNamespace map[string]models.WorkloadList
 	RegistryServices      []*kubernetes.RegistryService
 	Cluster               string
+	// Host of the istiod service the proxies must reach, not checked when empty
+	ControlPlaneHost string
 }
 
 func (s SidecarChecker) Check() models.IstioValidations {
@@ -67,6 +69,7 @@ func (s SidecarChecker) runChecks(sidecar *networking_v1beta1.Sidecar) models.Is
 		sidecars.EgressHostChecker{Sidecar: sidecar, ServiceEntries: serviceHosts, RegistryServices: s.RegistryServices},
 		sidecars.GlobalChecker{Sidecar: sidecar},
 		sidecars.OutboundTrafficPolicyModeChecker{Sidecar: sidecar},
+		sidecars.EgressBlockingChecker{Sidecar: sidecar, ControlPlaneHost: s.ControlPlaneHost, ServiceEntries: s.ServiceEntries},
 	}
 
 	for _, checker := range enabledCheckers {
@@ -64,6 +64,8 @@ func (in VirtualServiceChecker) runChecks(virtualService *networking_v1beta1.Vir
 	enabledCheckers := []Checker{
 		virtualservices.RouteChecker{VirtualService: virtualService, Namespaces: in.Namespaces.GetNames()},
 		virtualservices.SubsetPresenceChecker{Namespaces: in.Namespaces.GetNames(), VirtualService: virtualService, DestinationRules: in.DestinationRules},
+		virtualservices.UnreachableRouteChecker{VirtualService: virtualService},
+		virtualservices.DelegateChecker{VirtualService: virtualService, VirtualServices: in.VirtualServices},
 		common.ExportToNamespaceChecker{ExportTo: virtualService.Spec.ExportTo, Namespaces: in.Namespaces},
 	}
 
@@ -0,0 +1,35 @@
+package virtualservices
+
+import (
+	"fmt"
+
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+type DelegateChecker struct {
+	VirtualService  *networking_v1beta1.VirtualService
+	VirtualServices []*networking_v1beta1.VirtualService
+}
+
+// Check validates that the HTTP routes delegated to other VirtualServices point to existing VirtualServices
+func (checker DelegateChecker) Check() ([]*models.IstioCheck, bool) {
+	valid := true
+	validations := make([]*models.IstioCheck, 0)
+
+	for routeIdx, httpRoute := range checker.VirtualService.Spec.Http {
+		if httpRoute == nil || httpRoute.Delegate == nil {
+			continue
+		}
+		if kubernetes.FindVirtualServiceDelegate(httpRoute.Delegate, checker.VirtualService.Namespace, checker.VirtualServices) == nil {
+			path := fmt.Sprintf("spec/http[%d]/delegate", routeIdx)
+			validation := models.Build("virtualservices.delegate.notfound", path)
+			validations = append(validations, &validation)
+			valid = false
+		}
+	}
+
+	return validations, valid
+}
@@ -0,0 +1,58 @@
+package virtualservices
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func addDelegateRoute(name, namespace string, vs *networking_v1beta1.VirtualService) *networking_v1beta1.VirtualService {
+	vs.Spec.Http = append(vs.Spec.Http, &api_networking_v1beta1.HTTPRoute{
+		Delegate: &api_networking_v1beta1.Delegate{Name: name, Namespace: namespace},
+	})
+	return vs
+}
+
+func TestDelegateChainFound(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	root := addDelegateRoute("bookinfo-routes", "bookinfo", data.CreateEmptyVirtualService("bookinfo", "istio-system", []string{"bookinfo.example.com"}))
+	routes := addDelegateRoute("reviews", "", data.CreateEmptyVirtualService("bookinfo-routes", "bookinfo", []string{}))
+	reviews := data.AddHttpRoutesToVirtualService(data.CreateHttpRouteDestination("reviews", "v1", 100),
+		data.CreateEmptyVirtualService("reviews", "bookinfo", []string{}))
+	allVs := []*networking_v1beta1.VirtualService{root, routes, reviews}
+
+	for _, vs := range allVs {
+		vals, valid := DelegateChecker{VirtualService: vs, VirtualServices: allVs}.Check()
+		assert.True(valid)
+		assert.Empty(vals)
+	}
+}
+
+func TestDelegateNotFound(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	reviews := data.CreateEmptyVirtualService("reviews", "bookinfo", []string{})
+	// The delegate is looked up in the namespace of the VirtualService when not given
+	vs := addDelegateRoute("reviews", "", addDelegateRoute("reviews", "travels",
+		data.CreateEmptyVirtualService("bookinfo", "istio-system", []string{"bookinfo.example.com"})))
+
+	vals, valid := DelegateChecker{VirtualService: vs, VirtualServices: []*networking_v1beta1.VirtualService{reviews, vs}}.Check()
+	assert.False(valid)
+	assert.Len(vals, 2)
+	for i, val := range vals {
+		assert.Equal(models.ErrorSeverity, val.Severity)
+		assert.NoError(validations.ConfirmIstioCheckMessage("virtualservices.delegate.notfound", val))
+		assert.Equal(fmt.Sprintf("spec/http[%d]/delegate", i), val.Path)
+	}
+}
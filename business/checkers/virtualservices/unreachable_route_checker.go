@@ -0,0 +1,258 @@
+package virtualservices
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+
+	"github.com/kiali/kiali/models"
+)
+
+type UnreachableRouteChecker struct {
+	VirtualService *networking_v1beta1.VirtualService
+}
+
+// Check returns a warning for each http, tcp or tls route which can never be reached because an earlier route of the
+// same kind matches all its requests: either the earlier route has no match (catch-all) or one of its matches is less
+// restrictive than each of the matches of the route.
+// The comparison is conservative: the regular expressions are only compared for equality, so some shadowed routes can be missed,
+// but a reachable route is never flagged.
+func (checker UnreachableRouteChecker) Check() ([]*models.IstioCheck, bool) {
+	checks := make([]*models.IstioCheck, 0)
+	spec := &checker.VirtualService.Spec
+
+	appendUnreachableRoutes("http", len(spec.Http), func(earlier, later int) bool {
+		if spec.Http[earlier] == nil || spec.Http[later] == nil {
+			return false
+		}
+		earlierMatch, laterMatch := spec.Http[earlier].Match, spec.Http[later].Match
+		return coversAll(len(earlierMatch), len(laterMatch), func(e, l int) bool {
+			return httpMatchCovers(earlierMatch[e], laterMatch[l])
+		})
+	}, &checks)
+
+	appendUnreachableRoutes("tcp", len(spec.Tcp), func(earlier, later int) bool {
+		if spec.Tcp[earlier] == nil || spec.Tcp[later] == nil {
+			return false
+		}
+		earlierMatch, laterMatch := spec.Tcp[earlier].Match, spec.Tcp[later].Match
+		return coversAll(len(earlierMatch), len(laterMatch), func(e, l int) bool {
+			return l4MatchCovers(earlierMatch[e], laterMatch[l])
+		})
+	}, &checks)
+
+	appendUnreachableRoutes("tls", len(spec.Tls), func(earlier, later int) bool {
+		if spec.Tls[earlier] == nil || spec.Tls[later] == nil {
+			return false
+		}
+		earlierMatch, laterMatch := spec.Tls[earlier].Match, spec.Tls[later].Match
+		return coversAll(len(earlierMatch), len(laterMatch), func(e, l int) bool {
+			return tlsMatchCovers(earlierMatch[e], laterMatch[l])
+		})
+	}, &checks)
+
+	return checks, true
+}
+
+// appendUnreachableRoutes flags the routes of a kind shadowed by any earlier route, once per route
+func appendUnreachableRoutes(kind string, routes int, shadows func(earlier, later int) bool, checks *[]*models.IstioCheck) {
+	for later := 1; later < routes; later++ {
+		for earlier := 0; earlier < later; earlier++ {
+			if shadows(earlier, later) {
+				validation := models.Build("virtualservices.route.unreachable", fmt.Sprintf("spec/%s[%d]", kind, later))
+				*checks = append(*checks, &validation)
+				break
+			}
+		}
+	}
+}
+
+// coversAll returns true when the matches of an earlier route cover all the matches of a later route.
+// A route without matches matches all the requests. The matches of a route are ORed, so each match of the later route
+// has to be covered by one of the matches of the earlier route.
+func coversAll(earlierMatches, laterMatches int, covers func(earlier, later int) bool) bool {
+	if earlierMatches == 0 {
+		return true
+	}
+	if laterMatches == 0 {
+		return false
+	}
+	for l := 0; l < laterMatches; l++ {
+		covered := false
+		for e := 0; e < earlierMatches && !covered; e++ {
+			covered = covers(e, l)
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+// httpMatchCovers returns true when all the requests matched by the later match are matched by the earlier one,
+// that is every condition of the earlier match is implied by a condition of the later match
+func httpMatchCovers(earlier, later *api_networking_v1beta1.HTTPMatchRequest) bool {
+	if earlier == nil {
+		return true
+	}
+	if later == nil {
+		later = &api_networking_v1beta1.HTTPMatchRequest{}
+	}
+	// A case-sensitive uri doesn't cover a case-insensitive one
+	if earlier.Uri != nil && !earlier.IgnoreUriCase && later.IgnoreUriCase {
+		return false
+	}
+	if !stringMatchCovers(earlier.Uri, later.Uri) ||
+		!stringMatchCovers(earlier.Scheme, later.Scheme) ||
+		!stringMatchCovers(earlier.Method, later.Method) ||
+		!stringMatchCovers(earlier.Authority, later.Authority) ||
+		!stringMatchesCover(earlier.Headers, later.Headers) ||
+		!stringMatchesCover(earlier.QueryParams, later.QueryParams) {
+		return false
+	}
+	// The requests without a header are only covered when the later match excludes the same header
+	for name, earlierWithout := range earlier.WithoutHeaders {
+		if laterWithout, found := later.WithoutHeaders[name]; !found || !proto.Equal(earlierWithout, laterWithout) {
+			return false
+		}
+	}
+	return portCovers(earlier.Port, later.Port) &&
+		labelsCover(earlier.SourceLabels, later.SourceLabels) &&
+		gatewaysCover(earlier.Gateways, later.Gateways) &&
+		(earlier.SourceNamespace == "" || earlier.SourceNamespace == later.SourceNamespace)
+}
+
+func l4MatchCovers(earlier, later *api_networking_v1beta1.L4MatchAttributes) bool {
+	if earlier == nil {
+		return true
+	}
+	if later == nil {
+		later = &api_networking_v1beta1.L4MatchAttributes{}
+	}
+	return valuesCover(earlier.DestinationSubnets, later.DestinationSubnets, equalValue) &&
+		portCovers(earlier.Port, later.Port) &&
+		labelsCover(earlier.SourceLabels, later.SourceLabels) &&
+		gatewaysCover(earlier.Gateways, later.Gateways) &&
+		(earlier.SourceNamespace == "" || earlier.SourceNamespace == later.SourceNamespace)
+}
+
+func tlsMatchCovers(earlier, later *api_networking_v1beta1.TLSMatchAttributes) bool {
+	if earlier == nil {
+		return true
+	}
+	if later == nil {
+		later = &api_networking_v1beta1.TLSMatchAttributes{}
+	}
+	return valuesCover(earlier.SniHosts, later.SniHosts, sniHostCovers) &&
+		valuesCover(earlier.DestinationSubnets, later.DestinationSubnets, equalValue) &&
+		portCovers(earlier.Port, later.Port) &&
+		labelsCover(earlier.SourceLabels, later.SourceLabels) &&
+		gatewaysCover(earlier.Gateways, later.Gateways) &&
+		(earlier.SourceNamespace == "" || earlier.SourceNamespace == later.SourceNamespace)
+}
+
+// stringMatchCovers returns true when all the values matched by the later StringMatch are matched by the earlier one.
+// An unset StringMatch matches any value, regular expressions only cover the same regular expression.
+func stringMatchCovers(earlier, later *api_networking_v1beta1.StringMatch) bool {
+	if earlier == nil {
+		return true
+	}
+	if later == nil {
+		return false
+	}
+	switch earlierMatch := earlier.MatchType.(type) {
+	case nil:
+		// An empty StringMatch only checks the presence of the value
+		return true
+	case *api_networking_v1beta1.StringMatch_Exact:
+		laterExact, ok := later.MatchType.(*api_networking_v1beta1.StringMatch_Exact)
+		return ok && laterExact.Exact == earlierMatch.Exact
+	case *api_networking_v1beta1.StringMatch_Prefix:
+		switch laterMatch := later.MatchType.(type) {
+		case *api_networking_v1beta1.StringMatch_Exact:
+			return strings.HasPrefix(laterMatch.Exact, earlierMatch.Prefix)
+		case *api_networking_v1beta1.StringMatch_Prefix:
+			return strings.HasPrefix(laterMatch.Prefix, earlierMatch.Prefix)
+		}
+	case *api_networking_v1beta1.StringMatch_Regex:
+		// ".*" and "" match any value
+		if earlierMatch.Regex == ".*" || earlierMatch.Regex == "" {
+			return true
+		}
+		laterRegex, ok := later.MatchType.(*api_networking_v1beta1.StringMatch_Regex)
+		return ok && laterRegex.Regex == earlierMatch.Regex
+	}
+	return false
+}
+
+// stringMatchesCover returns true when each StringMatch of the earlier map is covered by the one of the same key in the later map
+func stringMatchesCover(earlier, later map[string]*api_networking_v1beta1.StringMatch) bool {
+	for key, earlierMatch := range earlier {
+		laterMatch, found := later[key]
+		if !found || !stringMatchCovers(earlierMatch, laterMatch) {
+			return false
+		}
+	}
+	return true
+}
+
+func portCovers(earlier, later uint32) bool {
+	return earlier == 0 || earlier == later
+}
+
+func labelsCover(earlier, later map[string]string) bool {
+	for key, value := range earlier {
+		if laterValue, found := later[key]; !found || laterValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+// gatewaysCover returns true when the later gateways are a subset of the earlier ones.
+// No gateways means the gateways of the VirtualService, so they only cover the same.
+func gatewaysCover(earlier, later []string) bool {
+	if len(earlier) == 0 {
+		return true
+	}
+	return valuesCover(earlier, later, equalValue)
+}
+
+// valuesCover returns true when each of the later values is covered by one of the earlier ones.
+// No earlier values matches any value, while no later values can only be covered by no earlier values.
+func valuesCover(earlier, later []string, covers func(earlier, later string) bool) bool {
+	if len(earlier) == 0 {
+		return true
+	}
+	if len(later) == 0 {
+		return false
+	}
+	for _, laterValue := range later {
+		covered := false
+		for _, earlierValue := range earlier {
+			if covers(earlierValue, laterValue) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+func equalValue(earlier, later string) bool {
+	return earlier == later
+}
+
+// sniHostCovers returns true when the earlier SNI host is the same or a wildcard matching the later one
+func sniHostCovers(earlier, later string) bool {
+	if earlier == later || earlier == "*" {
+		return true
+	}
+	return strings.HasPrefix(earlier, "*.") && strings.HasSuffix(later, earlier[1:])
+}
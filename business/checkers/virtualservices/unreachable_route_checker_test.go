@@ -0,0 +1,123 @@
+package virtualservices
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func prefixMatch(prefix string) *api_networking_v1beta1.HTTPMatchRequest {
+	return &api_networking_v1beta1.HTTPMatchRequest{Uri: &api_networking_v1beta1.StringMatch{MatchType: &api_networking_v1beta1.StringMatch_Prefix{Prefix: prefix}}}
+}
+
+func exactMatch(exact string) *api_networking_v1beta1.HTTPMatchRequest {
+	return &api_networking_v1beta1.HTTPMatchRequest{Uri: &api_networking_v1beta1.StringMatch{MatchType: &api_networking_v1beta1.StringMatch_Exact{Exact: exact}}}
+}
+
+func fakeRoutesVirtualService(matches ...[]*api_networking_v1beta1.HTTPMatchRequest) *networking_v1beta1.VirtualService {
+	vs := data.CreateEmptyVirtualService("reviews", "test", []string{"reviews"})
+	for _, match := range matches {
+		vs.Spec.Http = append(vs.Spec.Http, &api_networking_v1beta1.HTTPRoute{
+			Match: match,
+			Route: []*api_networking_v1beta1.HTTPRouteDestination{data.CreateHttpRouteDestination("reviews", "", 100)},
+		})
+	}
+	return vs
+}
+
+func TestUnreachableRouteAfterCatchAll(t *testing.T) {
+	assert := assert.New(t)
+
+	vals, valid := UnreachableRouteChecker{
+		VirtualService: fakeRoutesVirtualService(
+			nil,
+			[]*api_networking_v1beta1.HTTPMatchRequest{prefixMatch("/reviews")},
+			[]*api_networking_v1beta1.HTTPMatchRequest{exactMatch("/ratings")},
+		),
+	}.Check()
+
+	assert.True(valid)
+	assert.Len(vals, 2)
+	assert.NoError(validations.ConfirmIstioCheckMessage("virtualservices.route.unreachable", vals[0]))
+	assert.Equal(models.WarningSeverity, vals[0].Severity)
+	assert.Equal("spec/http[1]", vals[0].Path)
+	assert.Equal("spec/http[2]", vals[1].Path)
+}
+
+func TestUnreachableRouteShadowedByPrefix(t *testing.T) {
+	assert := assert.New(t)
+
+	headerMatch := exactMatch("/reviews/1")
+	headerMatch.Headers = map[string]*api_networking_v1beta1.StringMatch{
+		"end-user": {MatchType: &api_networking_v1beta1.StringMatch_Exact{Exact: "jason"}},
+	}
+	vals, valid := UnreachableRouteChecker{
+		VirtualService: fakeRoutesVirtualService(
+			[]*api_networking_v1beta1.HTTPMatchRequest{prefixMatch("/reviews"), prefixMatch("/ratings")},
+			// Both matches are covered by the prefixes of the first route
+			[]*api_networking_v1beta1.HTTPMatchRequest{headerMatch, prefixMatch("/ratings/v2")},
+			// "/details" is not covered
+			[]*api_networking_v1beta1.HTTPMatchRequest{exactMatch("/reviews/2"), exactMatch("/details")},
+		),
+	}.Check()
+
+	assert.True(valid)
+	assert.Len(vals, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("virtualservices.route.unreachable", vals[0]))
+	assert.Equal("spec/http[1]", vals[0].Path)
+}
+
+func TestReachableRoutesWellOrdered(t *testing.T) {
+	assert := assert.New(t)
+
+	ignoreCase := prefixMatch("/reviews")
+	ignoreCase.IgnoreUriCase = true
+	headerMatch := prefixMatch("/reviews")
+	headerMatch.Headers = map[string]*api_networking_v1beta1.StringMatch{
+		"end-user": {MatchType: &api_networking_v1beta1.StringMatch_Exact{Exact: "jason"}},
+	}
+	vals, valid := UnreachableRouteChecker{
+		VirtualService: fakeRoutesVirtualService(
+			[]*api_networking_v1beta1.HTTPMatchRequest{headerMatch},
+			[]*api_networking_v1beta1.HTTPMatchRequest{exactMatch("/reviews")},
+			// A case-sensitive prefix doesn't cover a case-insensitive one
+			[]*api_networking_v1beta1.HTTPMatchRequest{ignoreCase},
+			[]*api_networking_v1beta1.HTTPMatchRequest{prefixMatch("/")},
+			// The catch-all route is the last one
+			nil,
+		),
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
+
+func TestUnreachableTcpAndTlsRoutes(t *testing.T) {
+	assert := assert.New(t)
+
+	vs := data.CreateEmptyVirtualService("reviews", "test", []string{"reviews"})
+	vs.Spec.Tcp = []*api_networking_v1beta1.TCPRoute{
+		{Match: []*api_networking_v1beta1.L4MatchAttributes{{Port: 27017}}},
+		{Match: []*api_networking_v1beta1.L4MatchAttributes{{Port: 27018}}},
+		{},
+		{Match: []*api_networking_v1beta1.L4MatchAttributes{{Port: 27017, SourceLabels: map[string]string{"app": "reviews"}}}},
+	}
+	vs.Spec.Tls = []*api_networking_v1beta1.TLSRoute{
+		{Match: []*api_networking_v1beta1.TLSMatchAttributes{{SniHosts: []string{"*.bookinfo.com"}}}},
+		{Match: []*api_networking_v1beta1.TLSMatchAttributes{{SniHosts: []string{"reviews.bookinfo.com"}}}},
+		{Match: []*api_networking_v1beta1.TLSMatchAttributes{{SniHosts: []string{"bookinfo.com"}}}},
+	}
+
+	vals, valid := UnreachableRouteChecker{VirtualService: vs}.Check()
+
+	assert.True(valid)
+	assert.Len(vals, 2)
+	assert.Equal("spec/tcp[3]", vals[0].Path)
+	assert.Equal("spec/tls[1]", vals[1].Path)
+}
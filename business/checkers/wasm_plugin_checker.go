@@ -3,14 +3,16 @@ package checkers
 import (
 	extentions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
 
+	"github.com/kiali/kiali/business/checkers/wasmplugins"
 	"github.com/kiali/kiali/models"
 )
 
-const WasmPluginCheckerType = "wasmplugin"
-
 type WasmPluginChecker struct {
-	Namespaces  models.Namespaces
-	WasmPlugins []*extentions_v1alpha1.WasmPlugin
+	Namespaces            models.Namespaces
+	WasmPlugins           []*extentions_v1alpha1.WasmPlugin
+	WorkloadsPerNamespace map[string]models.WorkloadList
+	RootNamespace         string
+	Cluster               string
 }
 
 // An Object Checker runs all checkers for an specific object type (i.e.: pod, route rule,...)
@@ -20,5 +22,13 @@ type WasmPluginChecker struct {
 func (in WasmPluginChecker) Check() models.IstioValidations {
 	validations := models.IstioValidations{}
 
+	// Group Validations
+	validations.MergeValidations(wasmplugins.PriorityCollisionChecker{
+		WasmPlugins:           in.WasmPlugins,
+		WorkloadsPerNamespace: in.WorkloadsPerNamespace,
+		RootNamespace:         in.RootNamespace,
+		Cluster:               in.Cluster,
+	}.Check())
+
 	return validations
 }
@@ -0,0 +1,70 @@
+package wasmplugins
+
+import (
+	extentions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+const WasmPluginCheckerType = "wasmplugin"
+
+// PriorityCollisionChecker flags the WasmPlugins applied to a same workload at the same phase with the same priority.
+// Istio then orders them by name and namespace, which is rarely the intended order.
+type PriorityCollisionChecker struct {
+	WasmPlugins           []*extentions_v1alpha1.WasmPlugin
+	WorkloadsPerNamespace map[string]models.WorkloadList
+	RootNamespace         string
+	Cluster               string
+}
+
+func (p PriorityCollisionChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	for _, wls := range p.WorkloadsPerNamespace {
+		for _, wl := range wls.Workloads {
+			applied := kubernetes.FilterWasmPluginsByWorkload(p.WasmPlugins, p.RootNamespace, wls.Namespace.Name, labels.Set(wl.Labels).String())
+			// The WasmPlugins are sorted by phase and priority, so the colliding ones are adjacent
+			for start := 0; start < len(applied); {
+				end := start + 1
+				for end < len(applied) && sameSlot(applied[start], applied[end]) {
+					end++
+				}
+				if end-start > 1 {
+					validations.MergeValidations(p.buildCollisionValidations(applied[start:end]))
+				}
+				start = end
+			}
+		}
+	}
+
+	return validations
+}
+
+func sameSlot(a, b *extentions_v1alpha1.WasmPlugin) bool {
+	return a.Spec.Phase == b.Spec.Phase && kubernetes.WasmPluginPriority(a) == kubernetes.WasmPluginPriority(b)
+}
+
+// buildCollisionValidations returns a validation per colliding WasmPlugin, referencing the other ones
+func (p PriorityCollisionChecker) buildCollisionValidations(colliding []*extentions_v1alpha1.WasmPlugin) models.IstioValidations {
+	validations := models.IstioValidations{}
+	for _, wp := range colliding {
+		references := make([]models.IstioValidationKey, 0, len(colliding)-1)
+		for _, other := range colliding {
+			if other != wp {
+				references = append(references, models.IstioValidationKey{ObjectType: WasmPluginCheckerType, Name: other.Name, Namespace: other.Namespace, Cluster: p.Cluster})
+			}
+		}
+		key := models.IstioValidationKey{ObjectType: WasmPluginCheckerType, Name: wp.Name, Namespace: wp.Namespace, Cluster: p.Cluster}
+		check := models.Build("wasmplugins.priority.collision", "spec/priority")
+		validations[key] = &models.IstioValidation{
+			Name:       wp.Name,
+			ObjectType: WasmPluginCheckerType,
+			Valid:      true,
+			Checks:     []*models.IstioCheck{&check},
+			References: references,
+		}
+	}
+	return validations
+}
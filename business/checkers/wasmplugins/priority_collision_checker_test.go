@@ -0,0 +1,73 @@
+package wasmplugins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_extensions_v1alpha1 "istio.io/api/extensions/v1alpha1"
+	extentions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func workloadsPerNamespace() map[string]models.WorkloadList {
+	return map[string]models.WorkloadList{
+		"bookinfo": data.CreateWorkloadList("bookinfo",
+			data.CreateWorkloadListItem("reviews-v1", map[string]string{"app": "reviews", "version": "v1"}),
+			data.CreateWorkloadListItem("ratings-v1", map[string]string{"app": "ratings", "version": "v1"}),
+		),
+	}
+}
+
+// Context: WasmPlugins applied to a workload at different phases or priorities
+// It doesn't return any validation
+func TestOrderedWasmPlugins(t *testing.T) {
+	assert := assert.New(t)
+
+	vals := PriorityCollisionChecker{
+		WasmPlugins: []*extentions_v1alpha1.WasmPlugin{
+			data.CreateWasmPlugin("mesh-authn", "istio-system", nil, api_extensions_v1alpha1.PluginPhase_AUTHN, 10),
+			data.CreateWasmPlugin("namespace-authn", "bookinfo", nil, api_extensions_v1alpha1.PluginPhase_AUTHN, 5),
+			// Same priority as mesh-authn, but in another phase
+			data.CreateWasmPlugin("reviews-stats", "bookinfo", map[string]string{"app": "reviews"}, api_extensions_v1alpha1.PluginPhase_STATS, 10),
+		},
+		WorkloadsPerNamespace: workloadsPerNamespace(),
+		RootNamespace:         "istio-system",
+	}.Check()
+
+	assert.Empty(vals)
+}
+
+// Context: Two WasmPlugins applied to reviews at the same phase and priority
+// Context: A WasmPlugin with the same phase and priority only applied to ratings
+// It returns a validation for each WasmPlugin colliding on reviews
+func TestCollidingWasmPlugins(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	vals := PriorityCollisionChecker{
+		WasmPlugins: []*extentions_v1alpha1.WasmPlugin{
+			data.CreateWasmPlugin("namespace-authz", "bookinfo", nil, api_extensions_v1alpha1.PluginPhase_AUTHZ, 0),
+			data.CreateWasmPlugin("reviews-authz", "bookinfo", map[string]string{"app": "reviews"}, api_extensions_v1alpha1.PluginPhase_AUTHZ, 0),
+			data.CreateWasmPlugin("ratings-authz", "bookinfo", map[string]string{"app": "ratings"}, api_extensions_v1alpha1.PluginPhase_AUTHZ, 1),
+		},
+		WorkloadsPerNamespace: workloadsPerNamespace(),
+		RootNamespace:         "istio-system",
+		Cluster:               "east",
+	}.Check()
+
+	require.Len(vals, 2)
+	for name, other := range map[string]string{"namespace-authz": "reviews-authz", "reviews-authz": "namespace-authz"} {
+		validation, ok := vals[models.IstioValidationKey{ObjectType: WasmPluginCheckerType, Name: name, Namespace: "bookinfo", Cluster: "east"}]
+		require.True(ok)
+		assert.True(validation.Valid)
+		require.Len(validation.Checks, 1)
+		assert.Equal(models.WarningSeverity, validation.Checks[0].Severity)
+		assert.Equal("spec/priority", validation.Checks[0].Path)
+		assert.NoError(validations.ConfirmIstioCheckMessage("wasmplugins.priority.collision", validation.Checks[0]))
+		assert.Equal([]models.IstioValidationKey{{ObjectType: WasmPluginCheckerType, Name: other, Namespace: "bookinfo", Cluster: "east"}}, validation.References)
+	}
+}
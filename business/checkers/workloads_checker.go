@@ -3,6 +3,7 @@ package checkers
 import (
 	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
 
+	"github.com/kiali/kiali/business/checkers/common"
 	"github.com/kiali/kiali/business/checkers/workloads"
 	"github.com/kiali/kiali/models"
 )
@@ -34,6 +35,7 @@ func (w WorkloadChecker) runChecks(workload models.WorkloadListItem, namespace s
 
 	enabledCheckers := []Checker{
 		workloads.UncoveredWorkloadChecker{Workload: workload, Namespace: namespace, AuthorizationPolicies: w.AuthorizationPolicies},
+		common.HealthAnnotationChecker{Annotations: workload.HealthAnnotations},
 	}
 
 	for _, checker := range enabledCheckers {
@@ -0,0 +1,162 @@
+package business
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// DebugBundleLogLines is the number of the most recent log lines of every container included in a debug bundle
+const DebugBundleLogLines int64 = 1000
+
+// WriteWorkloadDebugBundle writes a zip archive gathering what is needed to debug the workload:
+// the workload itself, the specs and the recent logs of its pods, the config dump and the sync status of their
+// proxies and the Istio config of the workload namespace applied to it.
+// The workload is expected to be fetched with GetWorkload, which checks the access to its namespace.
+// The entries that can't be fetched don't fail the bundle, they are reported in the errors.txt entry.
+func (in *WorkloadService) WriteWorkloadDebugBundle(ctx context.Context, cluster, namespace string, workload *models.Workload, w io.Writer) error {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "WriteWorkloadDebugBundle",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("workloadName", workload.Name),
+	)
+	defer end()
+
+	userClient, ok := in.userClients[cluster]
+	if !ok {
+		return fmt.Errorf("cluster [%s] is not found or is not accessible for Kiali", cluster)
+	}
+
+	zw := zip.NewWriter(w)
+	bundleErrors := []string{}
+	addError := func(entry string, err error) {
+		bundleErrors = append(bundleErrors, fmt.Sprintf("%s: %s", entry, err))
+	}
+
+	if err := writeJSONEntry(zw, "workload.json", workload); err != nil {
+		return err
+	}
+
+	for _, p := range workload.Pods {
+		podEntry := "pods/" + p.Name + ".json"
+		pod, err := userClient.GetPod(namespace, p.Name)
+		if err != nil {
+			addError(podEntry, err)
+			continue
+		}
+		if err := writeJSONEntry(zw, podEntry, pod); err != nil {
+			return err
+		}
+
+		if !config.IsFeatureDisabled(config.FeatureLogView) {
+			for _, container := range pod.Spec.Containers {
+				logsEntry := "logs/" + p.Name + "/" + container.Name + ".log"
+				if err := writePodLogsEntry(zw, userClient, logsEntry, pod, container.Name); err != nil {
+					addError(logsEntry, err)
+				}
+			}
+		}
+
+		if !p.HasIstioSidecar() {
+			continue
+		}
+		if status := in.businessLayer.ProxyStatus.GetPodProxyStatus(cluster, namespace, p.Name); status != nil {
+			if err := writeJSONEntry(zw, "proxy_status/"+p.Name+".json", status); err != nil {
+				return err
+			}
+		}
+		configDumpEntry := "config_dump/" + p.Name + ".json"
+		dump, err := in.businessLayer.ProxyStatus.GetConfigDump(cluster, namespace, p.Name)
+		if err != nil {
+			addError(configDumpEntry, err)
+			continue
+		}
+		if err := writeJSONEntry(zw, configDumpEntry, dump.ConfigDump); err != nil {
+			return err
+		}
+	}
+
+	istioConfig, err := in.getWorkloadIstioConfig(ctx, cluster, namespace, workload)
+	if err != nil {
+		addError("istio_config.json", err)
+	} else if err := writeJSONEntry(zw, "istio_config.json", istioConfig); err != nil {
+		return err
+	}
+
+	if len(bundleErrors) > 0 {
+		entry, err := zw.Create("errors.txt")
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(entry, strings.Join(bundleErrors, "\n")+"\n"); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// getWorkloadIstioConfig returns the Istio config of the workload namespace applied to the workload: the
+// VirtualServices and DestinationRules of its services and the Sidecars and AuthorizationPolicies selecting it
+func (in *WorkloadService) getWorkloadIstioConfig(ctx context.Context, cluster, namespace string, workload *models.Workload) (models.IstioConfigList, error) {
+	istioConfigList, err := in.businessLayer.IstioConfig.GetIstioConfigList(ctx, IstioConfigCriteria{
+		Cluster:                      cluster,
+		Namespace:                    namespace,
+		IncludeVirtualServices:       true,
+		IncludeDestinationRules:      true,
+		IncludeSidecars:              true,
+		IncludeAuthorizationPolicies: true,
+	})
+	if err != nil {
+		return models.IstioConfigList{}, err
+	}
+
+	workloadConfig := models.IstioConfigList{Namespace: istioConfigList.Namespace}
+	for _, svc := range workload.Services {
+		workloadConfig.VirtualServices = append(workloadConfig.VirtualServices, kubernetes.FilterVirtualServicesByService(istioConfigList.VirtualServices, svc.Namespace, svc.Name)...)
+		workloadConfig.DestinationRules = append(workloadConfig.DestinationRules, kubernetes.FilterDestinationRulesByService(istioConfigList.DestinationRules, svc.Namespace, svc.Name)...)
+	}
+	wSelector := labels.Set(workload.Labels).AsSelector().String()
+	workloadConfig.Sidecars = kubernetes.FilterSidecarsBySelector(wSelector, istioConfigList.Sidecars)
+	workloadConfig.AuthorizationPolicies = kubernetes.FilterAuthorizationPoliciesBySelector(wSelector, istioConfigList.AuthorizationPolicies)
+	return workloadConfig, nil
+}
+
+func writeJSONEntry(zw *zip.Writer, name string, obj interface{}) error {
+	entry, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(entry)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(obj)
+}
+
+func writePodLogsEntry(zw *zip.Writer, userClient kubernetes.ClientInterface, name string, pod *core_v1.Pod, container string) error {
+	tailLines := DebugBundleLogLines
+	logsReader, err := userClient.StreamPodLogs(pod.Namespace, pod.Name, &core_v1.PodLogOptions{Container: container, Timestamps: true, TailLines: &tailLines})
+	if err != nil {
+		return err
+	}
+	defer logsReader.Close()
+
+	entry, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(entry, logsReader)
+	return err
+}
@@ -3,12 +3,15 @@ package business
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/prometheus/common/model"
 	"k8s.io/apimachinery/pkg/api/errors"
 
+	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
 	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/observability"
 	"github.com/kiali/kiali/prometheus"
@@ -27,6 +30,8 @@ type NamespaceHealthCriteria struct {
 	Cluster        string
 	QueryTime      time.Time
 	RateInterval   string
+	// OnlyUnhealthy drops the healthy workloads, only applied to the workloads health
+	OnlyUnhealthy bool
 }
 
 // Annotation Filter for Health
@@ -90,8 +95,9 @@ func (in *HealthService) getAppHealth(namespace, cluster, app, rateInterval stri
 	return health, errRate
 }
 
-// GetWorkloadHealth returns a workload health from just Namespace and workload (thus, it fetches data from K8S and Prometheus)
-func (in *HealthService) GetWorkloadHealth(ctx context.Context, namespace, cluster, workload, rateInterval string, queryTime time.Time, w *models.Workload) (models.WorkloadHealth, error) {
+// GetWorkloadHealth returns a workload health from just Namespace and workload (thus, it fetches data from K8S and Prometheus).
+// With includeEvents, the recent Warning events of the pods of the workload are summarized in the health.
+func (in *HealthService) GetWorkloadHealth(ctx context.Context, namespace, cluster, workload, rateInterval string, queryTime time.Time, w *models.Workload, includeEvents bool) (models.WorkloadHealth, error) {
 	var end observability.EndFunc
 	_, end = observability.StartSpan(ctx, "GetWorkloadHealth",
 		observability.Attribute("package", "business"),
@@ -99,23 +105,33 @@ func (in *HealthService) GetWorkloadHealth(ctx context.Context, namespace, clust
 		observability.Attribute("workload", workload),
 		observability.Attribute("rateInterval", rateInterval),
 		observability.Attribute("queryTime", queryTime),
+		observability.Attribute("includeEvents", includeEvents),
 	)
 	defer end()
 
+	health := models.WorkloadHealth{
+		WorkloadStatus: w.CastWorkloadStatus(),
+		Requests:       models.NewEmptyRequestHealth(),
+	}
+
+	// The events only complete the health, a user not allowed to list them still gets it
+	if includeEvents {
+		if events, err := in.getWorkloadEvents(namespace, cluster, queryTime, w); err != nil {
+			log.Errorf("Error fetching the events of workload [%s] in namespace [%s]: %v", workload, namespace, err)
+		} else {
+			health.Events = events
+		}
+	}
+
 	// Perf: do not bother fetching request rate if workload has no sidecar
 	if !w.IstioSidecar {
-		return models.WorkloadHealth{
-			WorkloadStatus: w.CastWorkloadStatus(),
-			Requests:       models.NewEmptyRequestHealth(),
-		}, nil
+		return health, nil
 	}
 
 	// Add Telemetry info
 	rate, err := in.getWorkloadRequestsHealth(namespace, cluster, workload, rateInterval, queryTime, w)
-	return models.WorkloadHealth{
-		WorkloadStatus: w.CastWorkloadStatus(),
-		Requests:       rate,
-	}, err
+	health.Requests = rate
+	return health, err
 }
 
 // GetNamespaceAppHealth returns a health for all apps in given Namespace (thus, it fetches data from K8S and Prometheus)
@@ -136,7 +152,7 @@ func (in *HealthService) GetNamespaceAppHealth(ctx context.Context, criteria Nam
 		return nil, fmt.Errorf("Cluster [%s] is not found or is not accessible for Kiali", cluster)
 	}
 
-	appEntities, err := in.businessLayer.App.fetchNamespaceApps(ctx, criteria.Namespace, cluster, "")
+	appEntities, err := in.businessLayer.App.fetchNamespaceApps(ctx, criteria.Namespace, cluster, "", "")
 	if err != nil {
 		return nil, err
 	}
@@ -174,11 +190,12 @@ func (in *HealthService) getNamespaceAppHealth(appEntities namespaceApps, criter
 	if sidecarPresent && criteria.IncludeMetrics {
 		// Fetch services requests rates
 		rates, err := in.prom.GetAllRequestRates(namespace, cluster, rateInterval, queryTime)
-		if err != nil {
+		partial := prometheus.IsPartialData(err)
+		if err != nil && !partial {
 			return allHealth, errors.NewServiceUnavailable(err.Error())
 		}
 		// Fill with collected request rates
-		fillAppRequestRates(allHealth, rates)
+		fillAppRequestRates(allHealth, rates, rateInterval, partial)
 	}
 
 	return allHealth, nil
@@ -243,7 +260,8 @@ func (in *HealthService) getNamespaceServiceHealth(services *models.ServiceList,
 
 	if criteria.IncludeMetrics {
 		// Fetch services requests rates
-		rates, _ := in.prom.GetNamespaceServicesRequestRates(namespace, cluster, rateInterval, queryTime)
+		rates, err := in.prom.GetNamespaceServicesRequestRates(namespace, cluster, rateInterval, queryTime)
+		partial := prometheus.IsPartialData(err)
 		// Fill with collected request rates
 		lblDestSvc := model.LabelName("destination_service_name")
 		for _, sample := range rates {
@@ -253,13 +271,15 @@ func (in *HealthService) getNamespaceServiceHealth(services *models.ServiceList,
 			}
 		}
 		for _, health := range allHealth {
-			health.Requests.CombineReporters()
+			health.Requests.PartialData = partial
+			completeRequestHealth(&health.Requests, rateInterval)
 		}
 	}
 	return allHealth
 }
 
 // GetNamespaceWorkloadHealth returns a health for all workloads in given Namespace (thus, it fetches data from K8S and Prometheus)
+// With OnlyUnhealthy only the degraded or failing workloads are returned.
 func (in *HealthService) GetNamespaceWorkloadHealth(ctx context.Context, criteria NamespaceHealthCriteria) (models.NamespaceWorkloadHealth, error) {
 	namespace := criteria.Namespace
 	rateInterval := criteria.RateInterval
@@ -312,18 +332,28 @@ func (in *HealthService) getNamespaceWorkloadHealth(ws models.Workloads, criteri
 	if hasSidecar && criteria.IncludeMetrics {
 		// Fetch services requests rates
 		rates, err := in.prom.GetAllRequestRates(namespace, cluster, rateInterval, queryTime)
-		if err != nil {
+		partial := prometheus.IsPartialData(err)
+		if err != nil && !partial {
 			return allHealth, errors.NewServiceUnavailable(err.Error())
 		}
 		// Fill with collected request rates
-		fillWorkloadRequestRates(allHealth, rates)
+		fillWorkloadRequestRates(allHealth, rates, rateInterval, partial)
+	}
+
+	if criteria.OnlyUnhealthy {
+		conf := config.Get()
+		for name, health := range allHealth {
+			if !isUnhealthyWorkload(conf, namespace, name, health) {
+				delete(allHealth, name)
+			}
+		}
 	}
 
 	return allHealth, nil
 }
 
 // fillAppRequestRates aggregates requests rates from metrics fetched from Prometheus, and stores the result in the health map.
-func fillAppRequestRates(allHealth models.NamespaceAppHealth, rates model.Vector) {
+func fillAppRequestRates(allHealth models.NamespaceAppHealth, rates model.Vector, rateInterval string, partial bool) {
 	lblDest := model.LabelName("destination_canonical_service")
 	lblSrc := model.LabelName("source_canonical_service")
 
@@ -338,12 +368,13 @@ func fillAppRequestRates(allHealth models.NamespaceAppHealth, rates model.Vector
 		}
 	}
 	for _, health := range allHealth {
-		health.Requests.CombineReporters()
+		health.Requests.PartialData = partial
+		completeRequestHealth(&health.Requests, rateInterval)
 	}
 }
 
 // fillWorkloadRequestRates aggregates requests rates from metrics fetched from Prometheus, and stores the result in the health map.
-func fillWorkloadRequestRates(allHealth models.NamespaceWorkloadHealth, rates model.Vector) {
+func fillWorkloadRequestRates(allHealth models.NamespaceWorkloadHealth, rates model.Vector, rateInterval string, partial bool) {
 	lblDest := model.LabelName("destination_workload")
 	lblSrc := model.LabelName("source_workload")
 	for _, sample := range rates {
@@ -357,34 +388,88 @@ func fillWorkloadRequestRates(allHealth models.NamespaceWorkloadHealth, rates mo
 		}
 	}
 	for _, health := range allHealth {
-		health.Requests.CombineReporters()
+		health.Requests.PartialData = partial
+		completeRequestHealth(&health.Requests, rateInterval)
 	}
 }
 
 func (in *HealthService) getServiceRequestsHealth(namespace, cluster, service, rateInterval string, queryTime time.Time, svc *models.Service) (models.RequestHealth, error) {
 	rqHealth := models.NewEmptyRequestHealth()
+	var inbound model.Vector
+	var err error
 	if svc.Type == "External" {
-		// ServiceEntry from Istio Registry
-		// Telemetry doesn't collect a namespace
-		namespace = "unknown"
+		inbound, err = in.getExternalServiceRequestRates(namespace, cluster, service, rateInterval, queryTime)
+	} else {
+		inbound, err = in.prom.GetServiceRequestRates(namespace, cluster, service, rateInterval, queryTime)
 	}
-	inbound, err := in.prom.GetServiceRequestRates(namespace, cluster, service, rateInterval, queryTime)
-	if err != nil {
+	rqHealth.PartialData = prometheus.IsPartialData(err)
+	if err != nil && !rqHealth.PartialData {
 		return rqHealth, errors.NewServiceUnavailable(err.Error())
 	}
 	for _, sample := range inbound {
 		rqHealth.AggregateInbound(sample)
 	}
 	rqHealth.HealthAnnotations = svc.HealthAnnotations
-	rqHealth.CombineReporters()
+	completeRequestHealth(&rqHealth, rateInterval)
 	return rqHealth, nil
 }
 
+// getExternalServiceRequestRates fetches the request rates of a ServiceEntry from Istio Registry.
+// Depending on the mesh, its telemetry may or may not carry the namespace and cluster, so the ones of the service are tried first
+// and then the values configured in health_config.external_services. The first combination with traffic is used.
+func (in *HealthService) getExternalServiceRequestRates(namespace, cluster, service, rateInterval string, queryTime time.Time) (model.Vector, error) {
+	extConf := config.Get().HealthConfig.ExternalServices
+	namespaces := []string{namespace}
+	if extConf.Namespace != "" && extConf.Namespace != namespace {
+		namespaces = append(namespaces, extConf.Namespace)
+	}
+	clusters := []string{cluster}
+	if extConf.Cluster != "" && extConf.Cluster != cluster {
+		clusters = append(clusters, extConf.Cluster)
+	}
+
+	type namespaceCluster struct {
+		namespace string
+		cluster   string
+	}
+	combinations := make([]namespaceCluster, 0, len(namespaces)*len(clusters))
+	for _, ns := range namespaces {
+		for _, cl := range clusters {
+			combinations = append(combinations, namespaceCluster{namespace: ns, cluster: cl})
+		}
+	}
+
+	// The combinations are queried concurrently, their results are then looked at in order of preference
+	rates := make([]model.Vector, len(combinations))
+	errs := make([]error, len(combinations))
+	wg := sync.WaitGroup{}
+	wg.Add(len(combinations))
+	for idx, combination := range combinations {
+		go func(i int, c namespaceCluster) {
+			defer wg.Done()
+			rates[i], errs[i] = in.prom.GetServiceRequestRates(c.namespace, c.cluster, service, rateInterval, queryTime)
+		}(idx, combination)
+	}
+	wg.Wait()
+
+	inbound := model.Vector{}
+	for i := range combinations {
+		if errs[i] != nil && !prometheus.IsPartialData(errs[i]) {
+			return inbound, errs[i]
+		}
+		if len(rates[i]) > 0 {
+			return rates[i], errs[i]
+		}
+	}
+	return inbound, nil
+}
+
 func (in *HealthService) getAppRequestsHealth(namespace, cluster, app, rateInterval string, queryTime time.Time) (models.RequestHealth, error) {
 	rqHealth := models.NewEmptyRequestHealth()
 
 	inbound, outbound, err := in.prom.GetAppRequestRates(namespace, cluster, app, rateInterval, queryTime)
-	if err != nil {
+	rqHealth.PartialData = prometheus.IsPartialData(err)
+	if err != nil && !rqHealth.PartialData {
 		return rqHealth, errors.NewServiceUnavailable(err.Error())
 	}
 	for _, sample := range inbound {
@@ -393,7 +478,7 @@ func (in *HealthService) getAppRequestsHealth(namespace, cluster, app, rateInter
 	for _, sample := range outbound {
 		rqHealth.AggregateOutbound(sample)
 	}
-	rqHealth.CombineReporters()
+	completeRequestHealth(&rqHealth, rateInterval)
 	return rqHealth, nil
 }
 
@@ -401,7 +486,8 @@ func (in *HealthService) getWorkloadRequestsHealth(namespace, cluster, workload,
 	rqHealth := models.NewEmptyRequestHealth()
 	// @TODO include w.Cluster into query
 	inbound, outbound, err := in.prom.GetWorkloadRequestRates(namespace, cluster, workload, rateInterval, queryTime)
-	if err != nil {
+	rqHealth.PartialData = prometheus.IsPartialData(err)
+	if err != nil && !rqHealth.PartialData {
 		return rqHealth, err
 	}
 	for _, sample := range inbound {
@@ -413,6 +499,26 @@ func (in *HealthService) getWorkloadRequestsHealth(namespace, cluster, workload,
 	if len(w.Pods) > 0 {
 		rqHealth.HealthAnnotations = models.GetHealthAnnotation(w.HealthAnnotations, HealthAnnotation)
 	}
+	completeRequestHealth(&rqHealth, rateInterval)
+	return rqHealth, nil
+}
+
+// completeRequestHealth combines the reporters of the rates of a requests health once all the samples are aggregated,
+// then discards the rates below the minimum number of requests and exposes the rates by response flags when enabled.
+func completeRequestHealth(rqHealth *models.RequestHealth, rateInterval string) {
 	rqHealth.CombineReporters()
-	return rqHealth, err
+	discardInsufficientTraffic(rqHealth, rateInterval)
+	if config.Get().HealthConfig.IncludeResponseFlags {
+		rqHealth.SetResponseFlags()
+	}
+}
+
+// discardInsufficientTraffic discards the rates of a requests health below the minimum number of requests of the health config
+func discardInsufficientTraffic(rqHealth *models.RequestHealth, rateInterval string) {
+	interval, err := model.ParseDuration(rateInterval)
+	if err != nil {
+		log.Debugf("Invalid rate interval [%s] for the minimum number of requests of the health: %s", rateInterval, err)
+		return
+	}
+	rqHealth.DiscardInsufficientTraffic(config.Get().HealthConfig.MinRequests, time.Duration(interval))
 }
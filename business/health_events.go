@@ -0,0 +1,87 @@
+package business
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	core_v1 "k8s.io/api/core/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+)
+
+// criticalEventReasons are the reasons of the Warning events reporting a problem keeping the pods from running
+var criticalEventReasons = map[string]bool{
+	"BackOff":                true,
+	"Failed":                 true,
+	"FailedAttachVolume":     true,
+	"FailedCreatePodSandBox": true,
+	"FailedMount":            true,
+	"FailedScheduling":       true,
+}
+
+// getWorkloadEvents returns the Warning events of the pods of a workload seen within the events lookback window
+// before the queryTime, the most recent first.
+func (in *HealthService) getWorkloadEvents(namespace, cluster string, queryTime time.Time, w *models.Workload) (*models.WorkloadEvents, error) {
+	client, ok := in.userClients[cluster]
+	if !ok {
+		return nil, fmt.Errorf("Cluster [%s] is not found or is not accessible for Kiali", cluster)
+	}
+
+	pods := map[string]bool{}
+	for _, pod := range w.Pods {
+		pods[pod.Name] = true
+	}
+
+	summary := &models.WorkloadEvents{Events: []models.WorkloadEvent{}}
+	if len(pods) == 0 {
+		return summary, nil
+	}
+
+	// The field selector is not honored by every client, so the events are filtered again
+	events, err := client.GetEvents(namespace, "type="+core_v1.EventTypeWarning)
+	if err != nil {
+		return nil, err
+	}
+
+	since := queryTime.Add(-time.Duration(config.Get().HealthConfig.EventsLookbackDuration) * time.Second)
+	for _, event := range events {
+		if event.Type != core_v1.EventTypeWarning || event.InvolvedObject.Kind != "Pod" || !pods[event.InvolvedObject.Name] {
+			continue
+		}
+		lastSeen := eventLastSeen(event)
+		if lastSeen.Before(since) || lastSeen.After(queryTime) {
+			continue
+		}
+		critical := criticalEventReasons[event.Reason]
+		summary.Critical = summary.Critical || critical
+		summary.Events = append(summary.Events, models.WorkloadEvent{
+			Pod:      event.InvolvedObject.Name,
+			Reason:   event.Reason,
+			Message:  event.Message,
+			Count:    event.Count,
+			LastSeen: lastSeen,
+			Critical: critical,
+		})
+	}
+
+	sort.SliceStable(summary.Events, func(i, j int) bool {
+		return summary.Events[i].LastSeen.After(summary.Events[j].LastSeen)
+	})
+	return summary, nil
+}
+
+// eventLastSeen returns when an event was last seen. Depending on the reporter, only some of the timestamps are set.
+func eventLastSeen(event core_v1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
@@ -0,0 +1,222 @@
+package business
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+// healthStatus mirrors the statuses the frontend computes for the health, ordered by priority
+type healthStatus int
+
+const (
+	healthStatusNA healthStatus = iota
+	healthStatusHealthy
+	healthStatusNotReady
+	healthStatusDegraded
+	healthStatusFailure
+)
+
+// rateTolerance is a tolerance of the health config with its expressions compiled
+type rateTolerance struct {
+	code      *regexp.Regexp
+	protocol  *regexp.Regexp
+	direction *regexp.Regexp
+	degraded  float64
+	failure   float64
+}
+
+// isUnhealthyWorkload reports whether the combined status of the workload, of its requests and of its events is degraded or failing.
+// A workload scaled down to zero replicas is not ready but it is not a problem.
+func isUnhealthyWorkload(conf *config.Config, namespace, name string, health *models.WorkloadHealth) bool {
+	status := requestsHealthStatus(conf, namespace, name, "workload", health.Requests)
+	if health.WorkloadStatus != nil {
+		if workloadStatus := workloadHealthStatus(health.WorkloadStatus); workloadStatus > status {
+			status = workloadStatus
+		}
+	}
+	if health.Events != nil && health.Events.Critical && status < healthStatusDegraded {
+		status = healthStatusDegraded
+	}
+	return status >= healthStatusDegraded
+}
+
+// workloadHealthStatus evaluates the replicas and the synced proxies of a workload, the same way the frontend does
+func workloadHealthStatus(ws *models.WorkloadStatus) healthStatus {
+	desired, current, available := ws.DesiredReplicas, ws.CurrentReplicas, ws.AvailableReplicas
+	// User has scaled down a workload, then desired replicas will be 0 and it's not an error condition
+	if desired == 0 {
+		return healthStatusNotReady
+	}
+	// Available pods but less than desired
+	if current > 0 && available > 0 && (current < desired || available < desired) {
+		return healthStatusDegraded
+	}
+	if available == 0 {
+		return healthStatusFailure
+	}
+	// Pending Pods means problems
+	if desired == available && available != current {
+		return healthStatusFailure
+	}
+	if ws.SyncedProxies >= 0 && ws.SyncedProxies < desired {
+		return healthStatusDegraded
+	}
+	if desired == current && current == available {
+		return healthStatusHealthy
+	}
+	return healthStatusDegraded
+}
+
+// requestsHealthStatus evaluates the error ratio of the inbound and outbound requests against the tolerances
+// of the rate annotation, or of the first health config rate matching the object.
+// A tolerance only applies to the requests of the directions it matches, summed.
+func requestsHealthStatus(conf *config.Config, namespace, name, kind string, requests models.RequestHealth) healthStatus {
+	tolerances, ok := annotationTolerances(requests.HealthAnnotations)
+	if !ok {
+		tolerances = configTolerances(conf, namespace, name, kind)
+	}
+
+	status := healthStatusNA
+	for _, tolerance := range tolerances {
+		// Requests by protocol and code of the directions of the tolerance
+		combined := map[string]map[string]float64{}
+		for direction, rates := range map[string]map[string]map[string]float64{"inbound": requests.Inbound, "outbound": requests.Outbound} {
+			if !tolerance.direction.MatchString(direction) {
+				continue
+			}
+			for protocol, codes := range rates {
+				if _, ok := combined[protocol]; !ok {
+					combined[protocol] = map[string]float64{}
+				}
+				for code, rate := range codes {
+					combined[protocol][code] += rate
+				}
+			}
+		}
+
+		for protocol, codes := range combined {
+			if !tolerance.protocol.MatchString(protocol) {
+				continue
+			}
+			requestRate, errorRate := 0.0, 0.0
+			for code, rate := range codes {
+				requestRate += rate
+				if tolerance.code.MatchString(code) {
+					errorRate += rate
+				}
+			}
+			if requestRate == 0 {
+				continue
+			}
+			if toleranceStatus := tolerance.status(100 * errorRate / requestRate); toleranceStatus > status {
+				status = toleranceStatus
+			}
+		}
+	}
+	return status
+}
+
+func (t rateTolerance) status(errorPercentage float64) healthStatus {
+	if errorPercentage > 0 {
+		if errorPercentage >= t.failure {
+			return healthStatusFailure
+		}
+		if errorPercentage >= t.degraded {
+			return healthStatusDegraded
+		}
+	}
+	return healthStatusHealthy
+}
+
+// annotationTolerances parses the rate annotation like '4XX,10,20,http,inbound;5XX,5,10,http,.*'.
+// As the frontend does, the whole annotation is ignored when any of its tolerances is not valid.
+func annotationTolerances(annotations map[string]string) ([]rateTolerance, bool) {
+	annotation := annotations[string(models.RateHealthAnnotation)]
+	if annotation == "" {
+		return nil, false
+	}
+
+	tolerances := []rateTolerance{}
+	for _, value := range strings.Split(annotation, ";") {
+		fields := strings.Split(value, ",")
+		if len(fields) != 5 {
+			return nil, false
+		}
+		degraded, err := strconv.ParseFloat(strings.TrimSpace(fields[1]), 64)
+		if err != nil {
+			return nil, false
+		}
+		failure, err := strconv.ParseFloat(strings.TrimSpace(fields[2]), 64)
+		if err != nil || degraded > failure {
+			return nil, false
+		}
+		tolerance, err := newRateTolerance(fields[0], fields[3], fields[4], degraded, failure)
+		if err != nil {
+			return nil, false
+		}
+		tolerances = append(tolerances, tolerance)
+	}
+	return tolerances, true
+}
+
+// configTolerances returns the tolerances of the first health config rate matching the object, the last rate is the default one
+func configTolerances(conf *config.Config, namespace, name, kind string) []rateTolerance {
+	rates := conf.HealthConfig.Rate
+	if len(rates) == 0 {
+		return nil
+	}
+
+	rate := rates[len(rates)-1]
+	for _, r := range rates {
+		if matchHealthExpr(r.Namespace, namespace) && matchHealthExpr(r.Name, name) && matchHealthExpr(r.Kind, kind) {
+			rate = r
+			break
+		}
+	}
+
+	tolerances := []rateTolerance{}
+	for _, t := range rate.Tolerance {
+		tolerance, err := newRateTolerance(t.Code, t.Protocol, t.Direction, float64(t.Degraded), float64(t.Failure))
+		if err != nil {
+			log.Errorf("Invalid health config tolerance %v: %v", t, err)
+			continue
+		}
+		tolerances = append(tolerances, tolerance)
+	}
+	return tolerances
+}
+
+// newRateTolerance compiles the expressions of a tolerance, the 'x' in codes like '4XX' stands for any digit
+func newRateTolerance(code, protocol, direction string, degraded, failure float64) (rateTolerance, error) {
+	codeExpr, err := regexp.Compile(strings.NewReplacer("x", `\d`, "X", `\d`).Replace(code))
+	if err != nil {
+		return rateTolerance{}, err
+	}
+	protocolExpr, err := regexp.Compile(protocol)
+	if err != nil {
+		return rateTolerance{}, err
+	}
+	directionExpr, err := regexp.Compile(direction)
+	if err != nil {
+		return rateTolerance{}, err
+	}
+	return rateTolerance{code: codeExpr, protocol: protocolExpr, direction: directionExpr, degraded: degraded, failure: failure}, nil
+}
+
+// matchHealthExpr matches a value with an expression of the health config, an empty expression matches everything
+func matchHealthExpr(expr, value string) bool {
+	if expr == "" {
+		return true
+	}
+	matched, err := regexp.MatchString(expr, value)
+	if err != nil {
+		log.Errorf("Invalid health config expression %s: %v", expr, err)
+		return false
+	}
+	return matched
+}
@@ -0,0 +1,117 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+)
+
+func TestRequestsHealthStatus(t *testing.T) {
+	conf := config.NewConfig()
+	conf.AddHealthDefault()
+
+	requests := func(inbound, outbound map[string]map[string]float64, annotations map[string]string) models.RequestHealth {
+		rh := models.NewEmptyRequestHealth()
+		rh.Inbound = inbound
+		rh.Outbound = outbound
+		rh.HealthAnnotations = annotations
+		return rh
+	}
+
+	cases := map[string]struct {
+		requests models.RequestHealth
+		expected healthStatus
+	}{
+		"No traffic": {
+			requests: requests(nil, nil, nil),
+			expected: healthStatusNA,
+		},
+		"Only successful requests": {
+			requests: requests(map[string]map[string]float64{"http": {"200": 10}}, nil, nil),
+			expected: healthStatusHealthy,
+		},
+		"Any 5XX degrades": {
+			requests: requests(map[string]map[string]float64{"http": {"200": 99, "500": 1}}, nil, nil),
+			expected: healthStatusDegraded,
+		},
+		"Inbound and outbound are summed": {
+			requests: requests(map[string]map[string]float64{"http": {"200": 5}}, map[string]map[string]float64{"http": {"200": 4, "503": 1}}, nil),
+			expected: healthStatusFailure,
+		},
+		"gRPC errors": {
+			requests: requests(map[string]map[string]float64{"grpc": {"0": 8, "14": 2}}, nil, nil),
+			expected: healthStatusFailure,
+		},
+		"Annotation overrides the config": {
+			requests: requests(map[string]map[string]float64{"http": {"200": 8, "404": 2}}, nil, map[string]string{string(models.RateHealthAnnotation): "4XX,30,50,http,.*"}),
+			expected: healthStatusHealthy,
+		},
+		"Annotation tolerance of a direction ignores the other one": {
+			requests: requests(map[string]map[string]float64{"http": {"200": 10}}, map[string]map[string]float64{"http": {"200": 5, "503": 5}}, map[string]string{string(models.RateHealthAnnotation): "5XX,10,20,http,inbound"}),
+			expected: healthStatusHealthy,
+		},
+		"Annotation tolerances per direction": {
+			requests: requests(map[string]map[string]float64{"http": {"200": 10}}, map[string]map[string]float64{"http": {"200": 5, "503": 5}}, map[string]string{string(models.RateHealthAnnotation): "5XX,10,20,http,inbound;5XX,60,80,http,outbound"}),
+			expected: healthStatusHealthy,
+		},
+		"Invalid annotation is ignored": {
+			requests: requests(map[string]map[string]float64{"http": {"200": 8, "404": 2}}, nil, map[string]string{string(models.RateHealthAnnotation): "4XX,50,30,http,.*"}),
+			expected: healthStatusFailure,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, requestsHealthStatus(conf, "bookinfo", "reviews-v1", "workload", tc.requests))
+		})
+	}
+}
+
+func TestRequestsHealthStatusConfigDirection(t *testing.T) {
+	conf := config.NewConfig()
+	conf.HealthConfig.Rate = []config.Rate{
+		{
+			Tolerance: []config.Tolerance{
+				{Code: "5XX", Protocol: "http", Direction: "inbound", Degraded: 5, Failure: 10},
+			},
+		},
+	}
+
+	rh := models.NewEmptyRequestHealth()
+	rh.Outbound = map[string]map[string]float64{"http": {"200": 5, "503": 5}}
+	assert.Equal(t, healthStatusNA, requestsHealthStatus(conf, "bookinfo", "reviews-v1", "workload", rh))
+
+	rh.Inbound = map[string]map[string]float64{"http": {"200": 9, "503": 1}}
+	assert.Equal(t, healthStatusFailure, requestsHealthStatus(conf, "bookinfo", "reviews-v1", "workload", rh))
+}
+
+func TestWorkloadHealthStatus(t *testing.T) {
+	assert := assert.New(t)
+	status := func(desired, current, available, syncedProxies int32) healthStatus {
+		return workloadHealthStatus(&models.WorkloadStatus{DesiredReplicas: desired, CurrentReplicas: current, AvailableReplicas: available, SyncedProxies: syncedProxies})
+	}
+
+	assert.Equal(healthStatusHealthy, status(2, 2, 2, -1))
+	assert.Equal(healthStatusNotReady, status(0, 0, 0, -1))
+	assert.Equal(healthStatusDegraded, status(2, 2, 1, -1))
+	assert.Equal(healthStatusFailure, status(2, 2, 0, -1))
+	// Pending pods
+	assert.Equal(healthStatusFailure, status(2, 3, 2, -1))
+	assert.Equal(healthStatusDegraded, status(2, 2, 2, 1))
+}
+
+func TestUnhealthyWorkloadWithCriticalEvents(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	health := models.EmptyWorkloadHealth()
+	health.WorkloadStatus = &models.WorkloadStatus{DesiredReplicas: 1, CurrentReplicas: 1, AvailableReplicas: 1, SyncedProxies: -1}
+	assert.False(isUnhealthyWorkload(conf, "ns", "reviews-v1", health))
+
+	health.Events = &models.WorkloadEvents{Events: []models.WorkloadEvent{{Pod: "reviews-v1-1", Reason: "Unhealthy"}}}
+	assert.False(isUnhealthyWorkload(conf, "ns", "reviews-v1", health))
+
+	health.Events.Critical = true
+	assert.True(isUnhealthyWorkload(conf, "ns", "reviews-v1", health))
+}
@@ -2,6 +2,7 @@ package business
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/kubernetes/kubetest"
 	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus"
 	"github.com/kiali/kiali/prometheus/prometheustest"
 )
 
@@ -66,6 +68,82 @@ func TestGetServiceHealth(t *testing.T) {
 	assert.Equal(emptyResult, health.Requests.Outbound)
 }
 
+func TestGetExternalServiceHealth(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(
+		&osproject_v1.Project{ObjectMeta: meta_v1.ObjectMeta{Name: "ns"}},
+	)
+	k8s.OpenShift = true
+	clients := make(map[string]kubernetes.ClientInterface)
+	clients[conf.KubernetesConfig.ClusterName] = k8s
+	queryTime := time.Date(2017, 1, 15, 0, 0, 0, 0, time.UTC)
+	setupGlobalMeshConfig()
+
+	mockSvc := models.Service{}
+	mockSvc.Name = "api.example.com"
+	mockSvc.Type = "External"
+
+	result := map[string]map[string]float64{
+		"http": {
+			"200": 14,
+			"404": 1.4,
+		},
+		"grpc": {
+			"0": 14,
+			"7": 1.4,
+		},
+	}
+
+	cases := map[string]struct {
+		// Namespace and cluster recorded by the telemetry
+		namespace string
+		cluster   string
+	}{
+		"Telemetry with namespace and cluster": {
+			namespace: "ns",
+			cluster:   conf.KubernetesConfig.ClusterName,
+		},
+		"Telemetry with namespace only": {
+			namespace: "ns",
+			cluster:   "unknown",
+		},
+		"Telemetry with cluster only": {
+			namespace: "unknown",
+			cluster:   conf.KubernetesConfig.ClusterName,
+		},
+		"Telemetry without namespace and cluster": {
+			namespace: "unknown",
+			cluster:   "unknown",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			prom := new(prometheustest.PromClientMock)
+			for _, ns := range []string{"ns", "unknown"} {
+				for _, cluster := range []string{conf.KubernetesConfig.ClusterName, "unknown"} {
+					if ns == tc.namespace && cluster == tc.cluster {
+						prom.MockServiceRequestRates(ns, cluster, "api.example.com", serviceRates)
+					} else {
+						prom.MockServiceRequestRates(ns, cluster, "api.example.com", model.Vector{})
+					}
+				}
+			}
+			hs := HealthService{prom: prom, businessLayer: NewWithBackends(clients, clients, prom, nil), userClients: clients}
+
+			health, err := hs.GetServiceHealth(context.TODO(), "ns", conf.KubernetesConfig.ClusterName, "api.example.com", "1m", queryTime, &mockSvc)
+			assert.NoError(err)
+			// All the combinations are queried concurrently
+			prom.AssertNumberOfCalls(t, "GetServiceRequestRates", 4)
+			assert.Equal(result, health.Requests.Inbound)
+		})
+	}
+}
+
 func TestGetAppHealth(t *testing.T) {
 	assert := assert.New(t)
 	conf := config.NewConfig()
@@ -152,7 +230,7 @@ func TestGetWorkloadHealth(t *testing.T) {
 	mockWorkload.Name = "reviews-v1"
 	mockWorkload.IstioSidecar = true
 
-	health, _ := hs.GetWorkloadHealth(context.TODO(), "ns", conf.KubernetesConfig.ClusterName, "reviews-v1", "1m", queryTime, &mockWorkload)
+	health, _ := hs.GetWorkloadHealth(context.TODO(), "ns", conf.KubernetesConfig.ClusterName, "reviews-v1", "1m", queryTime, &mockWorkload, false)
 
 	prom.AssertNumberOfCalls(t, "GetWorkloadRequestRates", 1)
 	result := map[string]map[string]float64{
@@ -236,7 +314,7 @@ func TestGetWorkloadHealthWithoutIstio(t *testing.T) {
 	mockWorkload := models.Workload{}
 	mockWorkload.Name = "reviews-v1"
 
-	health, _ := hs.GetWorkloadHealth(context.TODO(), "ns", conf.KubernetesConfig.ClusterName, "reviews-v1", "1m", queryTime, &mockWorkload)
+	health, _ := hs.GetWorkloadHealth(context.TODO(), "ns", conf.KubernetesConfig.ClusterName, "reviews-v1", "1m", queryTime, &mockWorkload, false)
 
 	prom.AssertNumberOfCalls(t, "GetWorkloadRequestRates", 0)
 	assert.Equal(emptyResult, health.Requests.Inbound)
@@ -441,6 +519,231 @@ func TestGetNamespaceWorkloadsHealthMultiCluster(t *testing.T) {
 
 }
 
+func workloadRequestsSample(workload, code string, value float64) *model.Sample {
+	return &model.Sample{
+		Metric: model.Metric{
+			"destination_workload": model.LabelValue(workload),
+			"source_workload":      "unknown",
+			"request_protocol":     "http",
+			"response_code":        model.LabelValue(code),
+			"reporter":             "destination",
+		},
+		Value:     model.SampleValue(value),
+		Timestamp: model.Now(),
+	}
+}
+
+func TestGetNamespaceWorkloadHealthOnlyUnhealthy(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.AddHealthDefault()
+	config.Set(conf)
+
+	queryTime := time.Date(2017, 1, 15, 0, 0, 0, 0, time.UTC)
+	prom := new(prometheustest.PromClientMock)
+	prom.On("GetAllRequestRates", "tutorial", conf.KubernetesConfig.ClusterName, "1m", queryTime).Return(model.Vector{
+		workloadRequestsSample("healthy", "200", 10),
+		workloadRequestsSample("failing-requests", "200", 8),
+		workloadRequestsSample("failing-requests", "503", 2),
+		// 4XX are degraded from 10% by default, the annotation tolerates them
+		workloadRequestsSample("tolerated-requests", "200", 8),
+		workloadRequestsSample("tolerated-requests", "404", 2),
+	}, nil)
+
+	ready := func(name string, desired, current, available int32) *models.Workload {
+		return &models.Workload{
+			WorkloadListItem:  models.WorkloadListItem{Name: name, IstioSidecar: true, HealthAnnotations: map[string]string{}},
+			DesiredReplicas:   desired,
+			CurrentReplicas:   current,
+			AvailableReplicas: available,
+		}
+	}
+	tolerated := ready("tolerated-requests", 1, 1, 1)
+	tolerated.HealthAnnotations[string(models.RateHealthAnnotation)] = "4XX,30,50,http,.*"
+	workloads := models.Workloads{
+		ready("healthy", 1, 1, 1),
+		ready("scaled-down", 0, 0, 0),
+		ready("missing-replicas", 2, 2, 1),
+		ready("not-available", 1, 1, 0),
+		ready("failing-requests", 1, 1, 1),
+		tolerated,
+	}
+
+	hs := HealthService{prom: prom}
+	criteria := NamespaceHealthCriteria{Namespace: "tutorial", Cluster: conf.KubernetesConfig.ClusterName, RateInterval: "1m", QueryTime: queryTime, IncludeMetrics: true, OnlyUnhealthy: true}
+
+	health, err := hs.getNamespaceWorkloadHealth(workloads, criteria)
+	require.NoError(err)
+	assert.Len(health, 3)
+	assert.Contains(health, "missing-replicas")
+	assert.Contains(health, "not-available")
+	assert.Contains(health, "failing-requests")
+
+	criteria.OnlyUnhealthy = false
+	health, err = hs.getNamespaceWorkloadHealth(workloads, criteria)
+	require.NoError(err)
+	assert.Len(health, 6)
+}
+
+func TestGetNamespaceWorkloadHealthMinRequests(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.HealthConfig.MinRequests = 100
+	config.Set(conf)
+
+	// Over 1m: 90 requests below the minimum, 100 at the minimum and 120 above it
+	queryTime := time.Date(2017, 1, 15, 0, 0, 0, 0, time.UTC)
+	prom := new(prometheustest.PromClientMock)
+	prom.On("GetAllRequestRates", "tutorial", conf.KubernetesConfig.ClusterName, "1m", queryTime).Return(model.Vector{
+		workloadRequestsSample("below", "200", 1),
+		workloadRequestsSample("below", "503", 0.5),
+		workloadRequestsSample("at", "200", 1.5),
+		workloadRequestsSample("at", "503", 1.0/6),
+		workloadRequestsSample("above", "200", 1.5),
+		workloadRequestsSample("above", "503", 0.5),
+	}, nil)
+
+	workloads := models.Workloads{}
+	for _, name := range []string{"below", "at", "above", "idle"} {
+		workloads = append(workloads, &models.Workload{WorkloadListItem: models.WorkloadListItem{Name: name, IstioSidecar: true}})
+	}
+
+	hs := HealthService{prom: prom}
+	criteria := NamespaceHealthCriteria{Namespace: "tutorial", Cluster: conf.KubernetesConfig.ClusterName, RateInterval: "1m", QueryTime: queryTime, IncludeMetrics: true}
+	health, err := hs.getNamespaceWorkloadHealth(workloads, criteria)
+	require.NoError(err)
+
+	assert.True(health["below"].Requests.InsufficientTraffic)
+	assert.Empty(health["below"].Requests.Inbound)
+
+	assert.False(health["at"].Requests.InsufficientTraffic)
+	assert.Equal(map[string]float64{"200": 1.5, "503": 1.0 / 6}, health["at"].Requests.Inbound["http"])
+
+	assert.False(health["above"].Requests.InsufficientTraffic)
+	assert.Equal(map[string]float64{"200": 1.5, "503": 0.5}, health["above"].Requests.Inbound["http"])
+
+	// No traffic at all is not reported as insufficient
+	assert.False(health["idle"].Requests.InsufficientTraffic)
+	assert.Empty(health["idle"].Requests.Inbound)
+}
+
+func TestGetNamespaceWorkloadHealthDefaultMinRequests(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	// Over 1m: less than a request, rounded to 0
+	queryTime := time.Date(2017, 1, 15, 0, 0, 0, 0, time.UTC)
+	prom := new(prometheustest.PromClientMock)
+	prom.On("GetAllRequestRates", "tutorial", conf.KubernetesConfig.ClusterName, "1m", queryTime).Return(model.Vector{
+		workloadRequestsSample("trickle", "503", 0.005),
+	}, nil)
+
+	workloads := models.Workloads{&models.Workload{WorkloadListItem: models.WorkloadListItem{Name: "trickle", IstioSidecar: true}}}
+
+	hs := HealthService{prom: prom}
+	criteria := NamespaceHealthCriteria{Namespace: "tutorial", Cluster: conf.KubernetesConfig.ClusterName, RateInterval: "1m", QueryTime: queryTime, IncludeMetrics: true}
+	health, err := hs.getNamespaceWorkloadHealth(workloads, criteria)
+	require.NoError(err)
+
+	assert.False(health["trickle"].Requests.InsufficientTraffic)
+	assert.Equal(map[string]float64{"503": 0.005}, health["trickle"].Requests.Inbound["http"])
+}
+
+func TestGetNamespaceWorkloadHealthPartialData(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	// The backend answered with the rates of the stores available
+	queryTime := time.Date(2017, 1, 15, 0, 0, 0, 0, time.UTC)
+	prom := new(prometheustest.PromClientMock)
+	prom.On("GetAllRequestRates", "tutorial", conf.KubernetesConfig.ClusterName, "1m", queryTime).Return(model.Vector{
+		workloadRequestsSample("available", "200", 1.5),
+	}, &prometheus.PartialDataError{Reason: "No StoreAPIs matched for this query"})
+
+	workloads := models.Workloads{
+		&models.Workload{WorkloadListItem: models.WorkloadListItem{Name: "available", IstioSidecar: true}},
+		&models.Workload{WorkloadListItem: models.WorkloadListItem{Name: "missing", IstioSidecar: true}},
+	}
+
+	hs := HealthService{prom: prom}
+	criteria := NamespaceHealthCriteria{Namespace: "tutorial", Cluster: conf.KubernetesConfig.ClusterName, RateInterval: "1m", QueryTime: queryTime, IncludeMetrics: true}
+	health, err := hs.getNamespaceWorkloadHealth(workloads, criteria)
+	require.NoError(err)
+
+	assert.True(health["available"].Requests.PartialData)
+	assert.Equal(map[string]float64{"200": 1.5}, health["available"].Requests.Inbound["http"])
+	assert.True(health["missing"].Requests.PartialData)
+	assert.Empty(health["missing"].Requests.Inbound)
+
+	// Any other error still fails the health
+	prom = new(prometheustest.PromClientMock)
+	prom.On("GetAllRequestRates", "tutorial", conf.KubernetesConfig.ClusterName, "1m", queryTime).Return(model.Vector{}, errors.New("unavailable"))
+	hs = HealthService{prom: prom}
+	_, err = hs.getNamespaceWorkloadHealth(workloads, criteria)
+	require.Error(err)
+}
+
+func TestGetNamespaceWorkloadHealthResponseFlags(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	flagsSample := func(destination, source, reporter, code, flags string, value float64) *model.Sample {
+		sample := workloadRequestsSample(destination, code, value)
+		sample.Metric["source_workload"] = model.LabelValue(source)
+		sample.Metric["reporter"] = model.LabelValue(reporter)
+		sample.Metric["response_flags"] = model.LabelValue(flags)
+		return sample
+	}
+
+	queryTime := time.Date(2017, 1, 15, 0, 0, 0, 0, time.UTC)
+	prom := new(prometheustest.PromClientMock)
+	prom.On("GetAllRequestRates", "tutorial", config.Get().KubernetesConfig.ClusterName, "1m", queryTime).Return(model.Vector{
+		flagsSample("reviews", "productpage", "destination", "200", "-", 10),
+		flagsSample("reviews", "productpage", "destination", "503", "UO", 2),
+		flagsSample("reviews", "productpage", "source", "503", "UO", 1.5),
+		flagsSample("reviews", "productpage", "source", "503", "UF", 0.5),
+		flagsSample("reviews", "productpage", "source", "404", "NR", 0.2),
+		flagsSample("ratings", "reviews", "source", "503", "UH", 1),
+	}, nil)
+
+	workloads := models.Workloads{}
+	for _, name := range []string{"reviews", "ratings"} {
+		workloads = append(workloads, &models.Workload{WorkloadListItem: models.WorkloadListItem{Name: name, IstioSidecar: true}})
+	}
+
+	conf := config.NewConfig()
+	config.Set(conf)
+	hs := HealthService{prom: prom}
+	criteria := NamespaceHealthCriteria{Namespace: "tutorial", Cluster: conf.KubernetesConfig.ClusterName, RateInterval: "1m", QueryTime: queryTime, IncludeMetrics: true}
+	health, err := hs.getNamespaceWorkloadHealth(workloads, criteria)
+	require.NoError(err)
+	// The response flags are only broken down when enabled
+	assert.Nil(health["reviews"].Requests.ResponseFlags)
+	assert.Equal(map[string]float64{"200": 10, "503": 2, "404": 0.2}, health["reviews"].Requests.Inbound["http"])
+
+	conf.HealthConfig.IncludeResponseFlags = true
+	config.Set(conf)
+	health, err = hs.getNamespaceWorkloadHealth(workloads, criteria)
+	require.NoError(err)
+
+	// The reporters are combined like the rates by status code, keeping the highest rate
+	require.NotNil(health["reviews"].Requests.ResponseFlags)
+	assert.Equal(map[string]float64{"-": 10, "UO": 2, "UF": 0.5, "NR": 0.2}, health["reviews"].Requests.ResponseFlags.Inbound["http"])
+	assert.Equal(map[string]float64{"UH": 1}, health["reviews"].Requests.ResponseFlags.Outbound["http"])
+	assert.Equal(map[string]float64{"UH": 1}, health["ratings"].Requests.ResponseFlags.Inbound["http"])
+	assert.Empty(health["ratings"].Requests.ResponseFlags.Outbound)
+}
+
 var (
 	sampleReviewsToHttpbin200 = model.Sample{
 		Metric: model.Metric{
@@ -669,3 +972,80 @@ func fakeDeploymentsHealthReview() []apps_v1.Deployment {
 		},
 	}
 }
+
+func TestGetWorkloadHealthWithEvents(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	queryTime := time.Date(2017, 1, 15, 0, 0, 0, 0, time.UTC)
+	event := func(name, pod, eventType, reason string, lastSeen time.Time) *core_v1.Event {
+		return &core_v1.Event{
+			ObjectMeta:     meta_v1.ObjectMeta{Name: name, Namespace: "ns"},
+			InvolvedObject: core_v1.ObjectReference{Kind: "Pod", Name: pod, Namespace: "ns"},
+			Type:           eventType,
+			Reason:         reason,
+			Message:        reason + " message",
+			Count:          3,
+			LastTimestamp:  meta_v1.NewTime(lastSeen),
+		}
+	}
+	k8s := kubetest.NewFakeK8sClient(
+		&osproject_v1.Project{ObjectMeta: meta_v1.ObjectMeta{Name: "ns"}},
+		event("scheduling", "reviews-v1-pending", core_v1.EventTypeWarning, "FailedScheduling", queryTime.Add(-time.Minute)),
+		event("probe", "reviews-v1-running", core_v1.EventTypeWarning, "Unhealthy", queryTime.Add(-2*time.Minute)),
+		event("old", "reviews-v1-running", core_v1.EventTypeWarning, "BackOff", queryTime.Add(-time.Hour)),
+		event("pulled", "reviews-v1-running", core_v1.EventTypeNormal, "Pulled", queryTime.Add(-time.Minute)),
+		event("other", "ratings-v1-running", core_v1.EventTypeWarning, "FailedMount", queryTime.Add(-time.Minute)),
+	)
+	prom := new(prometheustest.PromClientMock)
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	hs := HealthService{prom: prom, businessLayer: NewWithBackends(clients, clients, prom, nil), userClients: clients}
+
+	mockWorkload := models.Workload{}
+	mockWorkload.Name = "reviews-v1"
+	mockWorkload.Pods = models.Pods{{Name: "reviews-v1-pending"}, {Name: "reviews-v1-running"}}
+
+	health, err := hs.GetWorkloadHealth(context.TODO(), "ns", conf.KubernetesConfig.ClusterName, "reviews-v1", "1m", queryTime, &mockWorkload, true)
+	require.NoError(err)
+	require.NotNil(health.Events)
+	require.True(health.Events.Critical)
+	require.Equal([]models.WorkloadEvent{
+		{Pod: "reviews-v1-pending", Reason: "FailedScheduling", Message: "FailedScheduling message", Count: 3, LastSeen: queryTime.Add(-time.Minute), Critical: true},
+		{Pod: "reviews-v1-running", Reason: "Unhealthy", Message: "Unhealthy message", Count: 3, LastSeen: queryTime.Add(-2 * time.Minute)},
+	}, health.Events.Events)
+
+	health, err = hs.GetWorkloadHealth(context.TODO(), "ns", conf.KubernetesConfig.ClusterName, "reviews-v1", "1m", queryTime, &mockWorkload, false)
+	require.NoError(err)
+	require.Nil(health.Events)
+}
+
+// Fails to list the events, like a user without the permission to.
+type forbiddenEventsClient struct{ kubernetes.ClientInterface }
+
+func (c *forbiddenEventsClient) GetEvents(namespace, fieldSelector string) ([]core_v1.Event, error) {
+	return nil, errors.New("events is forbidden: User \"alice\" cannot list resource \"events\" in API group \"\" in the namespace \"ns\"")
+}
+
+func TestGetWorkloadHealthEventsError(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(&osproject_v1.Project{ObjectMeta: meta_v1.ObjectMeta{Name: "ns"}})
+	prom := new(prometheustest.PromClientMock)
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: &forbiddenEventsClient{ClientInterface: k8s}}
+	hs := HealthService{prom: prom, businessLayer: NewWithBackends(clients, clients, prom, nil), userClients: clients}
+
+	mockWorkload := models.Workload{}
+	mockWorkload.Name = "reviews-v1"
+	mockWorkload.Pods = models.Pods{{Name: "reviews-v1-running"}}
+	mockWorkload.DesiredReplicas = 1
+
+	// The events are omitted, the rest of the health is still returned
+	health, err := hs.GetWorkloadHealth(context.TODO(), "ns", conf.KubernetesConfig.ClusterName, "reviews-v1", "1m", time.Now(), &mockWorkload, true)
+	require.NoError(err)
+	require.Nil(health.Events)
+	require.NotNil(health.WorkloadStatus)
+}
@@ -0,0 +1,98 @@
+package business
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+const (
+	istioGatewayLabel           = "istio"
+	istioOperatorComponentLabel = "operator.istio.io/component"
+)
+
+// isIngressGatewayLabeled returns true when the workload has the labels set by Istio on the ingress gateways
+func isIngressGatewayLabeled(workloadLabels map[string]string) bool {
+	return workloadLabels[istioGatewayLabel] == "ingressgateway" || workloadLabels[istioOperatorComponentLabel] == "IngressGateways"
+}
+
+// isEgressGatewayLabeled returns true when the workload has the labels set by Istio on the egress gateways
+func isEgressGatewayLabeled(workloadLabels map[string]string) bool {
+	return workloadLabels[istioGatewayLabel] == "egressgateway" || workloadLabels[istioOperatorComponentLabel] == "EgressGateways"
+}
+
+// ResolveIngressGateway tells whether a workload is an ingress gateway and returns the hosts exposed through it.
+// A workload is an ingress gateway when it has the Istio ingress gateway labels, or when a Gateway selects it explicitly,
+// so the custom named gateways are detected too. The egress gateways are never ingress gateways. Gateways without
+// selector are bound to any gateway workload: their hosts are included, but they don't make a workload an ingress gateway.
+// When gatewayToNamespace is set, only the Gateways of the workload namespace are bound to it.
+func ResolveIngressGateway(namespace string, workloadLabels map[string]string, gateways []*networking_v1beta1.Gateway, gatewayToNamespace bool) (bool, []string) {
+	if isEgressGatewayLabeled(workloadLabels) {
+		return false, nil
+	}
+
+	candidates := make([]*networking_v1beta1.Gateway, 0, len(gateways))
+	for _, gw := range gateways {
+		if gatewayToNamespace && gw.Namespace != namespace {
+			continue
+		}
+		candidates = append(candidates, gw)
+	}
+
+	isIngress := isIngressGatewayLabeled(workloadLabels)
+	bound := kubernetes.FilterGatewaysBySelector(labels.Set(workloadLabels).String(), candidates)
+	for _, gw := range bound {
+		isIngress = isIngress || len(gw.Spec.Selector) > 0
+	}
+	if !isIngress {
+		return false, nil
+	}
+
+	unique := map[string]bool{}
+	hostnames := []string{}
+	for _, gw := range bound {
+		for _, server := range gw.Spec.Servers {
+			if server == nil {
+				continue
+			}
+			for _, host := range server.Hosts {
+				// Hosts can be given in <target-namespace>/hostname syntax
+				if i := strings.Index(host, "/"); i >= 0 {
+					host = host[i+1:]
+				}
+				if !unique[host] {
+					unique[host] = true
+					hostnames = append(hostnames, host)
+				}
+			}
+		}
+	}
+	sort.Strings(hostnames)
+
+	return true, hostnames
+}
+
+// setIngressGateway flags the workload when it is an ingress gateway and sets the hosts of the Gateways bound to it
+func (in *WorkloadService) setIngressGateway(ctx context.Context, criteria WorkloadCriteria, workload *models.Workload) error {
+	// The Gateways binding a workload can live in any namespace
+	istioConfigList, err := in.businessLayer.IstioConfig.GetIstioConfigList(ctx, IstioConfigCriteria{
+		AllNamespaces:      true,
+		Cluster:            criteria.Cluster,
+		Namespace:          criteria.Namespace,
+		ExcludedNamespaces: in.config.ExternalServices.Istio.ReferencesExcludedNamespacesRegexps(),
+		IncludeGateways:    true,
+	})
+	if err != nil {
+		return err
+	}
+
+	gatewayToNamespace := in.businessLayer.Validations.isGatewayToNamespace()
+	workload.IsIngressGateway, workload.IngressHostnames = ResolveIngressGateway(criteria.Namespace, workload.Labels, istioConfigList.Gateways, gatewayToNamespace)
+	return nil
+}
@@ -0,0 +1,104 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestGetWorkloadCustomIngressGateway(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	config.Set(conf)
+
+	fakeDeployment := func(name, namespace string, labels map[string]string) *apps_v1.Deployment {
+		return &apps_v1.Deployment{
+			ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: apps_v1.DeploymentSpec{
+				Template: core_v1.PodTemplateSpec{ObjectMeta: meta_v1.ObjectMeta{Labels: labels}},
+			},
+		}
+	}
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "gateways"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		fakeDeployment("edge-proxy", "gateways", map[string]string{"app": "edge-proxy", "gateway": "edge"}),
+		fakeDeployment("reviews-v1", "bookinfo", map[string]string{"app": "reviews", "version": "v1"}),
+	)
+	cache := SetupBusinessLayer(t, k8s, *conf)
+	cache.SetRegistryStatus(&kubernetes.RegistryStatus{
+		Configuration: &kubernetes.RegistryConfiguration{
+			Gateways: []*networking_v1beta1.Gateway{
+				data.AddServerToGateway(data.CreateServer([]string{"bookinfo/bookinfo.example.com", "*.travels.example.com"}, 443, "https", "HTTPS"),
+					data.AddServerToGateway(data.CreateServer([]string{"bookinfo.example.com"}, 80, "http", "HTTP"),
+						data.CreateEmptyGateway("bookinfo-gateway", "bookinfo", map[string]string{"gateway": "edge"}))),
+				// Bound to any gateway workload, but it doesn't make a workload an ingress gateway
+				data.AddServerToGateway(data.CreateServer([]string{"*.example.org"}, 80, "http", "HTTP"),
+					data.CreateEmptyGateway("default-gateway", "gateways", nil)),
+			},
+		},
+	})
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	svc := NewWithBackends(clients, clients, nil, nil).Workload
+
+	workload, err := svc.GetWorkload(context.TODO(), WorkloadCriteria{Cluster: conf.KubernetesConfig.ClusterName, Namespace: "gateways", WorkloadName: "edge-proxy", IncludeIstioResources: true})
+	require.NoError(err)
+	assert.True(workload.IsIngressGateway)
+	assert.Equal([]string{"*.example.org", "*.travels.example.com", "bookinfo.example.com"}, workload.IngressHostnames)
+
+	workload, err = svc.GetWorkload(context.TODO(), WorkloadCriteria{Cluster: conf.KubernetesConfig.ClusterName, Namespace: "bookinfo", WorkloadName: "reviews-v1", IncludeIstioResources: true})
+	require.NoError(err)
+	assert.False(workload.IsIngressGateway)
+	assert.Empty(workload.IngressHostnames)
+
+	// The Istio resources are not fetched
+	workload, err = svc.GetWorkload(context.TODO(), WorkloadCriteria{Cluster: conf.KubernetesConfig.ClusterName, Namespace: "gateways", WorkloadName: "edge-proxy"})
+	require.NoError(err)
+	assert.False(workload.IsIngressGateway)
+}
+
+func TestResolveIngressGateway(t *testing.T) {
+	assert := assert.New(t)
+
+	gateways := []*networking_v1beta1.Gateway{
+		data.AddServerToGateway(data.CreateServer([]string{"bookinfo.example.com"}, 80, "http", "HTTP"),
+			data.CreateEmptyGateway("bookinfo-gateway", "bookinfo", map[string]string{"istio": "ingressgateway"})),
+		data.AddServerToGateway(data.CreateServer([]string{"api.example.com"}, 443, "tls", "TLS"),
+			data.CreateEmptyGateway("egress-gateway", "istio-system", map[string]string{"istio": "egressgateway"})),
+	}
+
+	// Labeled as an ingress gateway by Istio
+	isIngress, hostnames := ResolveIngressGateway("istio-system", map[string]string{"istio": "ingressgateway"}, gateways, false)
+	assert.True(isIngress)
+	assert.Equal([]string{"bookinfo.example.com"}, hostnames)
+
+	isIngress, hostnames = ResolveIngressGateway("istio-system", map[string]string{"operator.istio.io/component": "IngressGateways"}, nil, false)
+	assert.True(isIngress)
+	assert.Empty(hostnames)
+
+	// The Gateway of another namespace doesn't bind the workload
+	isIngress, hostnames = ResolveIngressGateway("istio-system", map[string]string{"istio": "ingressgateway"}, gateways, true)
+	assert.True(isIngress)
+	assert.Empty(hostnames)
+
+	// The egress gateways are selected by Gateways too
+	isIngress, hostnames = ResolveIngressGateway("istio-system", map[string]string{"istio": "egressgateway"}, gateways, false)
+	assert.False(isIngress)
+	assert.Empty(hostnames)
+}
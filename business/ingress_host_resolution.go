@@ -0,0 +1,197 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kiali/kiali/business/checkers"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// ResolveIngressHost resolves which ingress Gateway servers handle the requests to a hostname from outside of the mesh,
+// with their TLS settings, and which VirtualService routes them. A host given with a port, like "shop.example.com:443",
+// only matches the servers of that port, otherwise the servers of all the ports match.
+// As Envoy does, the most specific server host matching the hostname wins on a port of a Gateway, an exact host over a
+// wildcard one, and the most specific VirtualService host wins among the VirtualServices bound to the Gateway. The
+// VirtualServices of the TLS passthrough servers must also have a TLS route matching the hostname as SNI on the port.
+// An empty list of matches is returned when no server handles the hostname.
+// It uses following parameters:
+// - "cluster":	cluster of the Gateways
+// - "host":	hostname of the requests, optionally followed by the port
+func (in *IstioConfigService) ResolveIngressHost(ctx context.Context, cluster, host string) (*models.IngressHostResolution, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "ResolveIngressHost",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("host", host),
+	)
+	defer end()
+
+	hostname, port, err := parseIngressHost(host)
+	if err != nil {
+		return nil, err
+	}
+
+	istioConfigList, err := in.getIngressConfig(ctx, cluster, false)
+	if err != nil {
+		return nil, err
+	}
+	gateways := istioConfigList.Gateways
+	sort.Slice(gateways, func(i, j int) bool {
+		if gateways[i].Namespace != gateways[j].Namespace {
+			return gateways[i].Namespace < gateways[j].Namespace
+		}
+		return gateways[i].Name < gateways[j].Name
+	})
+
+	resolution := &models.IngressHostResolution{Hostname: hostname, Port: port, Matches: []models.IngressHostMatch{}}
+	for _, gw := range gateways {
+		if isEgressGatewayLabeled(gw.Spec.Selector) {
+			continue
+		}
+		resolution.Matches = append(resolution.Matches, resolveGatewayHost(cluster, gw, istioConfigList.VirtualServices, hostname, port)...)
+	}
+	return resolution, nil
+}
+
+// matchingServer is the server of a Gateway matching a hostname on a port
+type matchingServer struct {
+	server          *api_networking_v1beta1.Server
+	host            string
+	targetNamespace string
+}
+
+// resolveGatewayHost returns the servers of a Gateway handling a hostname, the most specific one per port
+func resolveGatewayHost(cluster string, gw *networking_v1beta1.Gateway, virtualServices []*networking_v1beta1.VirtualService, hostname string, port uint32) []models.IngressHostMatch {
+	byPort := map[uint32]matchingServer{}
+	for _, server := range gw.Spec.Servers {
+		if server == nil || server.Port == nil || (port != 0 && server.Port.Number != port) {
+			continue
+		}
+		for _, gwHost := range server.Hosts {
+			targetNamespace, gwHost := parseGatewayHost(gwHost, gw.Namespace)
+			if !hostCovers(gwHost, hostname) {
+				continue
+			}
+			if current, found := byPort[server.Port.Number]; found && !moreSpecificHost(gwHost, current.host) {
+				continue
+			}
+			byPort[server.Port.Number] = matchingServer{server: server, host: gwHost, targetNamespace: targetNamespace}
+		}
+	}
+
+	ports := make([]int, 0, len(byPort))
+	for p := range byPort {
+		ports = append(ports, int(p))
+	}
+	sort.Ints(ports)
+
+	bound := boundVirtualServices(gw, virtualServices)
+	matches := make([]models.IngressHostMatch, 0, len(ports))
+	for _, p := range ports {
+		ms := byPort[uint32(p)]
+		match := models.IngressHostMatch{
+			Gateway:    models.IstioValidationKey{ObjectType: checkers.GatewayCheckerType, Name: gw.Name, Namespace: gw.Namespace, Cluster: cluster},
+			ServerHost: ms.host,
+			Port:       ms.server.Port.Number,
+			Protocol:   ms.server.Port.Protocol,
+			TLSMode:    serverTLSMode(ms.server),
+		}
+		if ms.server.Tls != nil {
+			match.CredentialName = ms.server.Tls.CredentialName
+			match.HttpsRedirect = ms.server.Tls.HttpsRedirect
+		}
+		if vs, vsHost := routingVirtualService(bound, ms, match.TLSMode, hostname); vs != nil {
+			match.VirtualService = &models.IstioValidationKey{ObjectType: checkers.VirtualCheckerType, Name: vs.Name, Namespace: vs.Namespace, Cluster: cluster}
+			match.VirtualServiceHost = vsHost
+		}
+		matches = append(matches, match)
+	}
+	return matches
+}
+
+// routingVirtualService returns the VirtualService bound to the Gateway with the most specific host matching the hostname,
+// with the host. The VirtualServices of a TLS passthrough server route the hostname by SNI.
+func routingVirtualService(bound []*networking_v1beta1.VirtualService, ms matchingServer, tlsMode, hostname string) (*networking_v1beta1.VirtualService, string) {
+	passthrough := tlsMode == api_networking_v1beta1.ServerTLSSettings_PASSTHROUGH.String() ||
+		tlsMode == api_networking_v1beta1.ServerTLSSettings_AUTO_PASSTHROUGH.String()
+
+	var routing *networking_v1beta1.VirtualService
+	routingHost := ""
+	for _, vs := range bound {
+		if ms.targetNamespace != "*" && ms.targetNamespace != vs.Namespace {
+			continue
+		}
+		if passthrough && !routesSNI(vs, hostname, ms.server.Port.Number) {
+			continue
+		}
+		for _, vsHost := range vs.Spec.Hosts {
+			vsHost = strings.ToLower(vsHost)
+			if !hostCovers(vsHost, hostname) {
+				continue
+			}
+			if routing == nil || moreSpecificHost(vsHost, routingHost) ||
+				(vsHost == routingHost && vs.Namespace+"/"+vs.Name < routing.Namespace+"/"+routing.Name) {
+				routing = vs
+				routingHost = vsHost
+			}
+		}
+	}
+	return routing, routingHost
+}
+
+// routesSNI tells whether a TLS route of a VirtualService matches a hostname as SNI on a port
+func routesSNI(vs *networking_v1beta1.VirtualService, hostname string, port uint32) bool {
+	for _, route := range vs.Spec.Tls {
+		if route == nil {
+			continue
+		}
+		for _, match := range route.Match {
+			if match == nil || (match.Port != 0 && match.Port != port) {
+				continue
+			}
+			for _, sniHost := range match.SniHosts {
+				if hostCovers(strings.ToLower(sniHost), hostname) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// moreSpecificHost tells whether a host matches less hostnames than another host, both matching the same hostname
+func moreSpecificHost(host, other string) bool {
+	wildcard, otherWildcard := strings.HasPrefix(host, "*"), strings.HasPrefix(other, "*")
+	if wildcard != otherWildcard {
+		return !wildcard
+	}
+	return len(host) > len(other)
+}
+
+// parseIngressHost splits a host given as hostname[:port], the port is 0 when not set
+func parseIngressHost(host string) (string, uint32, error) {
+	hostname := host
+	port := uint64(0)
+	if i := strings.LastIndex(host, ":"); i >= 0 {
+		var err error
+		hostname = host[:i]
+		port, err = strconv.ParseUint(host[i+1:], 10, 16)
+		if err != nil || port == 0 {
+			return "", 0, api_errors.NewBadRequest(fmt.Sprintf("invalid port in host [%s]", host))
+		}
+	}
+	hostname = strings.ToLower(strings.TrimSuffix(hostname, "."))
+	if hostname == "" {
+		return "", 0, api_errors.NewBadRequest(fmt.Sprintf("invalid host [%s]", host))
+	}
+	return hostname, uint32(port), nil
+}
@@ -0,0 +1,170 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func addTLSRouteToVirtualService(sniHosts []string, port uint32, vs *networking_v1beta1.VirtualService) *networking_v1beta1.VirtualService {
+	vs.Spec.Tls = append(vs.Spec.Tls, &api_networking_v1beta1.TLSRoute{
+		Match: []*api_networking_v1beta1.TLSMatchAttributes{{SniHosts: sniHosts, Port: port}},
+		Route: []*api_networking_v1beta1.RouteDestination{{Destination: &api_networking_v1beta1.Destination{Host: "bank"}}},
+	})
+	return vs
+}
+
+func setupIngressHostResolution(t *testing.T) (IstioConfigService, string) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	wildcardServer := data.CreateServer([]string{"*.example.com"}, 443, "https", "HTTPS")
+	wildcardServer.Tls = &api_networking_v1beta1.ServerTLSSettings{Mode: api_networking_v1beta1.ServerTLSSettings_SIMPLE, CredentialName: "wildcard-cert"}
+	shopServer := data.CreateServer([]string{"shop/shop.example.com"}, 443, "https-shop", "HTTPS")
+	shopServer.Tls = &api_networking_v1beta1.ServerTLSSettings{Mode: api_networking_v1beta1.ServerTLSSettings_SIMPLE, CredentialName: "shop-cert"}
+	httpServer := data.CreateServer([]string{"*"}, 80, "http", "HTTP")
+	httpServer.Tls = &api_networking_v1beta1.ServerTLSSettings{HttpsRedirect: true}
+	passthroughServer := data.CreateServer([]string{"*.secure.io"}, 443, "tls", "TLS")
+	passthroughServer.Tls = &api_networking_v1beta1.ServerTLSSettings{Mode: api_networking_v1beta1.ServerTLSSettings_PASSTHROUGH}
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "shop"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bank"}},
+		data.AddServerToGateway(httpServer, data.AddServerToGateway(shopServer, data.AddServerToGateway(wildcardServer,
+			data.CreateEmptyGateway("shop-gateway", "shop", map[string]string{"istio": "ingressgateway"})))),
+		data.AddServerToGateway(passthroughServer,
+			data.CreateEmptyGateway("passthrough-gateway", "istio-system", map[string]string{"istio": "ingressgateway"})),
+		data.AddServerToGateway(data.CreateServer([]string{"shop.example.com"}, 443, "tls", "TLS"),
+			data.CreateEmptyGateway("egress-gateway", "istio-system", map[string]string{"istio": "egressgateway"})),
+		data.AddGatewaysToVirtualService([]string{"shop/shop-gateway"}, data.CreateEmptyVirtualService("shop", "shop", []string{"shop.example.com"})),
+		data.AddGatewaysToVirtualService([]string{"shop-gateway"}, data.CreateEmptyVirtualService("catchall", "shop", []string{"*.example.com"})),
+		data.AddGatewaysToVirtualService([]string{"istio-system/passthrough-gateway"},
+			addTLSRouteToVirtualService([]string{"*.secure.io"}, 443, data.CreateEmptyVirtualService("secure", "bank", []string{"*.secure.io"}))),
+		// More specific, but routing another port
+		data.AddGatewaysToVirtualService([]string{"istio-system/passthrough-gateway"},
+			addTLSRouteToVirtualService([]string{"api.secure.io"}, 8443, data.CreateEmptyVirtualService("secure-8443", "bank", []string{"api.secure.io"}))),
+	)
+	SetupBusinessLayer(t, k8s, *conf)
+	t.Cleanup(func() { kialiCache = nil })
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	return NewWithBackends(clients, clients, nil, nil).IstioConfig, conf.KubernetesConfig.ClusterName
+}
+
+func TestResolveIngressHostExact(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	svc, cluster := setupIngressHostResolution(t)
+	shopGateway := models.IstioValidationKey{ObjectType: "gateway", Name: "shop-gateway", Namespace: "shop", Cluster: cluster}
+	shopVS := &models.IstioValidationKey{ObjectType: "virtualservice", Name: "shop", Namespace: "shop", Cluster: cluster}
+	httpsMatch := models.IngressHostMatch{
+		Gateway:            shopGateway,
+		ServerHost:         "shop.example.com",
+		Port:               443,
+		Protocol:           "HTTPS",
+		TLSMode:            "SIMPLE",
+		CredentialName:     "shop-cert",
+		VirtualService:     shopVS,
+		VirtualServiceHost: "shop.example.com",
+	}
+
+	resolution, err := svc.ResolveIngressHost(context.TODO(), cluster, "shop.example.com:443")
+	require.NoError(err)
+	assert.Equal(&models.IngressHostResolution{Hostname: "shop.example.com", Port: 443, Matches: []models.IngressHostMatch{httpsMatch}}, resolution)
+
+	// All the ports without port
+	resolution, err = svc.ResolveIngressHost(context.TODO(), cluster, "Shop.Example.com")
+	require.NoError(err)
+	assert.Equal(&models.IngressHostResolution{Hostname: "shop.example.com", Matches: []models.IngressHostMatch{
+		{
+			Gateway:            shopGateway,
+			ServerHost:         "*",
+			Port:               80,
+			Protocol:           "HTTP",
+			TLSMode:            "NONE",
+			HttpsRedirect:      true,
+			VirtualService:     shopVS,
+			VirtualServiceHost: "shop.example.com",
+		},
+		httpsMatch,
+	}}, resolution)
+}
+
+func TestResolveIngressHostWildcard(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	svc, cluster := setupIngressHostResolution(t)
+
+	resolution, err := svc.ResolveIngressHost(context.TODO(), cluster, "cart.example.com:443")
+	require.NoError(err)
+	assert.Equal([]models.IngressHostMatch{{
+		Gateway:            models.IstioValidationKey{ObjectType: "gateway", Name: "shop-gateway", Namespace: "shop", Cluster: cluster},
+		ServerHost:         "*.example.com",
+		Port:               443,
+		Protocol:           "HTTPS",
+		TLSMode:            "SIMPLE",
+		CredentialName:     "wildcard-cert",
+		VirtualService:     &models.IstioValidationKey{ObjectType: "virtualservice", Name: "catchall", Namespace: "shop", Cluster: cluster},
+		VirtualServiceHost: "*.example.com",
+	}}, resolution.Matches)
+
+	// Wildcard SNI of a passthrough server, the more specific VirtualService routes another port
+	resolution, err = svc.ResolveIngressHost(context.TODO(), cluster, "api.secure.io:443")
+	require.NoError(err)
+	assert.Equal([]models.IngressHostMatch{{
+		Gateway:            models.IstioValidationKey{ObjectType: "gateway", Name: "passthrough-gateway", Namespace: "istio-system", Cluster: cluster},
+		ServerHost:         "*.secure.io",
+		Port:               443,
+		Protocol:           "TLS",
+		TLSMode:            "PASSTHROUGH",
+		VirtualService:     &models.IstioValidationKey{ObjectType: "virtualservice", Name: "secure", Namespace: "bank", Cluster: cluster},
+		VirtualServiceHost: "*.secure.io",
+	}}, resolution.Matches)
+
+	// The wildcard doesn't match the parent domain
+	resolution, err = svc.ResolveIngressHost(context.TODO(), cluster, "secure.io:443")
+	require.NoError(err)
+	assert.Empty(resolution.Matches)
+}
+
+func TestResolveIngressHostNoMatch(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	svc, cluster := setupIngressHostResolution(t)
+
+	resolution, err := svc.ResolveIngressHost(context.TODO(), cluster, "shop.example.com:8443")
+	require.NoError(err)
+	assert.Equal(&models.IngressHostResolution{Hostname: "shop.example.com", Port: 8443, Matches: []models.IngressHostMatch{}}, resolution)
+
+	resolution, err = svc.ResolveIngressHost(context.TODO(), cluster, "unknown.org:443")
+	require.NoError(err)
+	assert.Empty(resolution.Matches)
+
+	// Only the catch-all server, without VirtualService
+	resolution, err = svc.ResolveIngressHost(context.TODO(), cluster, "unknown.org")
+	require.NoError(err)
+	require.Len(resolution.Matches, 1)
+	assert.Equal("*", resolution.Matches[0].ServerHost)
+	assert.Nil(resolution.Matches[0].VirtualService)
+
+	_, err = svc.ResolveIngressHost(context.TODO(), cluster, "shop.example.com:https")
+	require.Error(err)
+	assert.True(errors.IsBadRequest(err))
+}
@@ -0,0 +1,300 @@
+package business
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kiali/kiali/business/checkers"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// tlsModeNone is the TLS mode of the hosts exposed without encryption
+const tlsModeNone = "NONE"
+
+// GetIngressHosts returns the hosts exposed outside of the mesh by the ingress Gateways and the K8sGateways of the
+// namespaces visible in the cluster. The hosts are deduplicated, with the Gateways exposing them, the VirtualServices
+// and HTTPRoutes routing them and their TLS modes. A wildcard host is expanded to the more specific hosts of its routes,
+// the wildcard itself is still returned, as it is exposed too.
+func (in *IstioConfigService) GetIngressHosts(ctx context.Context, cluster string) ([]models.IngressHost, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetIngressHosts",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+	)
+	defer end()
+
+	istioConfigList, err := in.getIngressConfig(ctx, cluster, true)
+	if err != nil {
+		return nil, err
+	}
+
+	hosts := ingressHosts{cluster: cluster, byHostname: map[string]*models.IngressHost{}}
+	for _, gw := range istioConfigList.Gateways {
+		if isEgressGatewayLabeled(gw.Spec.Selector) {
+			continue
+		}
+		hosts.addGateway(gw, istioConfigList.VirtualServices)
+	}
+	for _, gw := range istioConfigList.K8sGateways {
+		hosts.addK8sGateway(gw, istioConfigList.K8sHTTPRoutes)
+	}
+
+	return hosts.list(), nil
+}
+
+// getIngressConfig returns the Gateways and VirtualServices of the namespaces visible in the cluster, with the K8sGateways
+// and HTTPRoutes when asked
+func (in *IstioConfigService) getIngressConfig(ctx context.Context, cluster string, includeK8sGateways bool) (models.IstioConfigList, error) {
+	namespaces, err := in.businessLayer.Namespace.GetNamespacesForCluster(ctx, cluster)
+	if err != nil {
+		return models.IstioConfigList{}, err
+	}
+
+	// The namespaces are fetched concurrently, their configs are merged in the order of the namespaces
+	nsConfigLists := make([]models.IstioConfigList, len(namespaces))
+	errs := make([]error, len(namespaces))
+	wg := sync.WaitGroup{}
+	wg.Add(len(namespaces))
+	for idx, ns := range namespaces {
+		go func(i int, namespace string) {
+			defer wg.Done()
+			nsConfigLists[i], errs[i] = in.GetIstioConfigList(ctx, IstioConfigCriteria{
+				Cluster:                cluster,
+				Namespace:              namespace,
+				IncludeGateways:        true,
+				IncludeVirtualServices: true,
+				IncludeK8sGateways:     includeK8sGateways,
+				IncludeK8sHTTPRoutes:   includeK8sGateways,
+			})
+		}(idx, ns.Name)
+	}
+	wg.Wait()
+
+	istioConfigList := models.IstioConfigList{}
+	for i := range namespaces {
+		if errs[i] != nil {
+			return models.IstioConfigList{}, errs[i]
+		}
+		istioConfigList = istioConfigList.MergeConfigs(nsConfigLists[i])
+	}
+	return istioConfigList, nil
+}
+
+type ingressHosts struct {
+	cluster    string
+	byHostname map[string]*models.IngressHost
+}
+
+func (ih ingressHosts) add(hostname, tlsMode string, gateway models.IstioValidationKey, route *models.IstioValidationKey) {
+	host, ok := ih.byHostname[hostname]
+	if !ok {
+		host = &models.IngressHost{Hostname: hostname, TLSModes: []string{}, Gateways: []models.IstioValidationKey{}, Routes: []models.IstioValidationKey{}}
+		ih.byHostname[hostname] = host
+	}
+	if !containsString(host.TLSModes, tlsMode) {
+		host.TLSModes = append(host.TLSModes, tlsMode)
+	}
+	if !containsKey(host.Gateways, gateway) {
+		host.Gateways = append(host.Gateways, gateway)
+	}
+	if route != nil && !containsKey(host.Routes, *route) {
+		host.Routes = append(host.Routes, *route)
+	}
+}
+
+func (ih ingressHosts) addGateway(gw *networking_v1beta1.Gateway, virtualServices []*networking_v1beta1.VirtualService) {
+	gwKey := models.IstioValidationKey{ObjectType: checkers.GatewayCheckerType, Name: gw.Name, Namespace: gw.Namespace, Cluster: ih.cluster}
+
+	bound := boundVirtualServices(gw, virtualServices)
+
+	for _, server := range gw.Spec.Servers {
+		if server == nil {
+			continue
+		}
+		tlsMode := serverTLSMode(server)
+		for _, gwHost := range server.Hosts {
+			targetNamespace, gwHost := parseGatewayHost(gwHost, gw.Namespace)
+			ih.add(gwHost, tlsMode, gwKey, nil)
+
+			for _, vs := range bound {
+				if targetNamespace != "*" && targetNamespace != vs.Namespace {
+					continue
+				}
+				vsKey := models.IstioValidationKey{ObjectType: checkers.VirtualCheckerType, Name: vs.Name, Namespace: vs.Namespace, Cluster: ih.cluster}
+				for _, vsHost := range vs.Spec.Hosts {
+					if hostname, ok := intersectHosts(gwHost, vsHost); ok {
+						ih.add(hostname, tlsMode, gwKey, &vsKey)
+					}
+				}
+			}
+		}
+	}
+}
+
+func (ih ingressHosts) addK8sGateway(gw *k8s_networking_v1beta1.Gateway, httpRoutes []*k8s_networking_v1beta1.HTTPRoute) {
+	gwKey := models.IstioValidationKey{ObjectType: checkers.K8sGatewayCheckerType, Name: gw.Name, Namespace: gw.Namespace, Cluster: ih.cluster}
+
+	for _, listener := range gw.Spec.Listeners {
+		// A listener without hostname matches all the hostnames
+		listenerHost := "*"
+		if listener.Hostname != nil && *listener.Hostname != "" {
+			listenerHost = strings.ToLower(string(*listener.Hostname))
+		}
+		tlsMode := tlsModeNone
+		if listener.TLS != nil {
+			tlsMode = string(k8s_networking_v1beta1.TLSModeTerminate)
+			if listener.TLS.Mode != nil {
+				tlsMode = string(*listener.TLS.Mode)
+			}
+		}
+		ih.add(listenerHost, tlsMode, gwKey, nil)
+
+		for _, route := range httpRoutes {
+			if !isRouteAttachedToListener(route, gw, listener) {
+				continue
+			}
+			routeKey := models.IstioValidationKey{ObjectType: checkers.K8sHTTPRouteCheckerType, Name: route.Name, Namespace: route.Namespace, Cluster: ih.cluster}
+			// A route without hostnames takes the hostname of the listener
+			if len(route.Spec.Hostnames) == 0 {
+				ih.add(listenerHost, tlsMode, gwKey, &routeKey)
+				continue
+			}
+			for _, routeHost := range route.Spec.Hostnames {
+				if hostname, ok := intersectHosts(listenerHost, string(routeHost)); ok {
+					ih.add(hostname, tlsMode, gwKey, &routeKey)
+				}
+			}
+		}
+	}
+}
+
+func (ih ingressHosts) list() []models.IngressHost {
+	hosts := make([]models.IngressHost, 0, len(ih.byHostname))
+	for _, host := range ih.byHostname {
+		sort.Strings(host.TLSModes)
+		sortKeys(host.Gateways)
+		sortKeys(host.Routes)
+		hosts = append(hosts, *host)
+	}
+	sort.Slice(hosts, func(i, j int) bool {
+		return hosts[i].Hostname < hosts[j].Hostname
+	})
+	return hosts
+}
+
+// boundVirtualServices returns the VirtualServices bound to a Gateway
+func boundVirtualServices(gw *networking_v1beta1.Gateway, virtualServices []*networking_v1beta1.VirtualService) []*networking_v1beta1.VirtualService {
+	bound := []*networking_v1beta1.VirtualService{}
+	for _, vs := range virtualServices {
+		for _, gwName := range vs.Spec.Gateways {
+			if gwName == gw.Namespace+"/"+gw.Name || (gwName == gw.Name && vs.Namespace == gw.Namespace) {
+				bound = append(bound, vs)
+				break
+			}
+		}
+	}
+	return bound
+}
+
+// serverTLSMode returns the TLS mode of a Gateway server, NONE when the traffic is not encrypted
+func serverTLSMode(server *api_networking_v1beta1.Server) string {
+	if server.Tls != nil && (server.Port == nil || !strings.EqualFold(server.Port.Protocol, "HTTP")) {
+		return server.Tls.Mode.String()
+	}
+	return tlsModeNone
+}
+
+// parseGatewayHost splits a host of a Gateway server given in <target-namespace>/hostname syntax, the target namespace
+// restricting the namespaces of the VirtualServices. The target namespace is "*" when not set.
+func parseGatewayHost(gwHost, gwNamespace string) (string, string) {
+	targetNamespace := "*"
+	if i := strings.Index(gwHost, "/"); i >= 0 {
+		targetNamespace, gwHost = gwHost[:i], gwHost[i+1:]
+		if targetNamespace == "." {
+			targetNamespace = gwNamespace
+		}
+	}
+	return targetNamespace, strings.ToLower(gwHost)
+}
+
+// isRouteAttachedToListener tells whether a parent reference of the HTTPRoute targets the listener of the K8sGateway
+func isRouteAttachedToListener(route *k8s_networking_v1beta1.HTTPRoute, gw *k8s_networking_v1beta1.Gateway, listener k8s_networking_v1beta1.Listener) bool {
+	for _, parentRef := range route.Spec.ParentRefs {
+		if parentRef.Kind != nil && string(*parentRef.Kind) != "Gateway" {
+			continue
+		}
+		namespace := route.Namespace
+		if parentRef.Namespace != nil && *parentRef.Namespace != "" {
+			namespace = string(*parentRef.Namespace)
+		}
+		if string(parentRef.Name) != gw.Name || namespace != gw.Namespace {
+			continue
+		}
+		if parentRef.SectionName == nil || *parentRef.SectionName == listener.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// intersectHosts returns the most specific of two hosts when one of them matches the other, like the
+// route host expanding the wildcard host of a Gateway. The hosts may use a wildcard as leftmost label.
+func intersectHosts(a, b string) (string, bool) {
+	a = strings.ToLower(a)
+	b = strings.ToLower(b)
+	if hostCovers(a, b) {
+		return b, true
+	}
+	if hostCovers(b, a) {
+		return a, true
+	}
+	return "", false
+}
+
+// hostCovers tells whether every hostname matched by host is matched by pattern too
+func hostCovers(pattern, host string) bool {
+	if pattern == "*" {
+		return true
+	}
+	if strings.HasPrefix(pattern, "*") {
+		return strings.HasSuffix(host, pattern[1:])
+	}
+	return pattern == host
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsKey(keys []models.IstioValidationKey, key models.IstioValidationKey) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func sortKeys(keys []models.IstioValidationKey) {
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].ObjectType != keys[j].ObjectType {
+			return keys[i].ObjectType < keys[j].ObjectType
+		}
+		if keys[i].Namespace != keys[j].Namespace {
+			return keys[i].Namespace < keys[j].Namespace
+		}
+		return keys[i].Name < keys[j].Name
+	})
+}
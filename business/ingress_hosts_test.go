@@ -0,0 +1,128 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestGetIngressHosts(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+	cluster := conf.KubernetesConfig.ClusterName
+
+	httpsServer := data.CreateServer([]string{"*.example.com"}, 443, "https", "HTTPS")
+	httpsServer.Tls = &api_networking_v1beta1.ServerTLSSettings{Mode: api_networking_v1beta1.ServerTLSSettings_SIMPLE, CredentialName: "example-cert"}
+	httpServer := data.CreateServer([]string{"./bookinfo.example.com"}, 80, "http", "HTTP")
+	httpServer.Tls = &api_networking_v1beta1.ServerTLSSettings{HttpsRedirect: true}
+
+	httpsListener := data.CreateListener("https", "*.travels.io", 443, "HTTPS")
+	terminate := k8s_networking_v1beta1.TLSModeTerminate
+	httpsListener.TLS = &k8s_networking_v1beta1.GatewayTLSConfig{Mode: &terminate}
+	sectionName := k8s_networking_v1beta1.SectionName("http")
+	bookinfoRoute := data.AddParentRefToHTTPRoute("travels-gateway", "travels", data.CreateEmptyHTTPRoute("bookinfo", "bookinfo", nil))
+	bookinfoRoute.Spec.ParentRefs[0].SectionName = &sectionName
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "travels"}},
+		data.AddServerToGateway(httpsServer, data.AddServerToGateway(httpServer,
+			data.CreateEmptyGateway("bookinfo-gateway", "bookinfo", map[string]string{"istio": "ingressgateway"}))),
+		data.AddServerToGateway(data.CreateServer([]string{"api.external.com"}, 443, "tls", "TLS"),
+			data.CreateEmptyGateway("egress-gateway", "istio-system", map[string]string{"istio": "egressgateway"})),
+		data.AddGatewaysToVirtualService([]string{"bookinfo-gateway"}, data.CreateEmptyVirtualService("bookinfo", "bookinfo", []string{"bookinfo.example.com"})),
+		data.AddGatewaysToVirtualService([]string{"bookinfo/bookinfo-gateway", "mesh"}, data.CreateEmptyVirtualService("travels", "travels", []string{"travels.example.com", "travels"})),
+		data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"}),
+		data.AddListenerToK8sGateway(httpsListener,
+			data.AddListenerToK8sGateway(data.CreateListener("http", "bookinfo.example.com", 80, "HTTP"),
+				data.CreateEmptyK8sGateway("travels-gateway", "travels"))),
+		data.CreateHTTPRoute("travels", "travels", "travels-gateway", []string{"api.travels.io"}),
+		bookinfoRoute,
+	)
+	k8s.OpenShift = false
+	k8s.GatewayAPIEnabled = true
+	SetupBusinessLayer(t, k8s, *conf)
+	// The namespaces are cached by token in the global cache
+	t.Cleanup(func() { kialiCache = nil })
+
+	clients := map[string]kubernetes.ClientInterface{cluster: k8s}
+	istioConfigService := NewWithBackends(clients, clients, nil, nil).IstioConfig
+	hosts, err := istioConfigService.GetIngressHosts(context.TODO(), cluster)
+	require.NoError(err)
+
+	gateway := models.IstioValidationKey{ObjectType: "gateway", Name: "bookinfo-gateway", Namespace: "bookinfo", Cluster: cluster}
+	k8sGateway := models.IstioValidationKey{ObjectType: "k8sgateway", Name: "travels-gateway", Namespace: "travels", Cluster: cluster}
+	assert.Equal([]models.IngressHost{
+		{
+			Hostname: "*.example.com",
+			TLSModes: []string{"SIMPLE"},
+			Gateways: []models.IstioValidationKey{gateway},
+			Routes:   []models.IstioValidationKey{},
+		},
+		{
+			Hostname: "*.travels.io",
+			TLSModes: []string{"Terminate"},
+			Gateways: []models.IstioValidationKey{k8sGateway},
+			Routes:   []models.IstioValidationKey{},
+		},
+		{
+			Hostname: "api.travels.io",
+			TLSModes: []string{"Terminate"},
+			Gateways: []models.IstioValidationKey{k8sGateway},
+			Routes:   []models.IstioValidationKey{{ObjectType: "k8shttproute", Name: "travels", Namespace: "travels", Cluster: cluster}},
+		},
+		{
+			Hostname: "bookinfo.example.com",
+			TLSModes: []string{"NONE", "SIMPLE"},
+			Gateways: []models.IstioValidationKey{gateway, k8sGateway},
+			Routes: []models.IstioValidationKey{
+				{ObjectType: "k8shttproute", Name: "bookinfo", Namespace: "bookinfo", Cluster: cluster},
+				{ObjectType: "virtualservice", Name: "bookinfo", Namespace: "bookinfo", Cluster: cluster},
+			},
+		},
+		{
+			Hostname: "travels.example.com",
+			TLSModes: []string{"SIMPLE"},
+			Gateways: []models.IstioValidationKey{gateway},
+			Routes:   []models.IstioValidationKey{{ObjectType: "virtualservice", Name: "travels", Namespace: "travels", Cluster: cluster}},
+		},
+	}, hosts)
+}
+
+func TestIntersectHosts(t *testing.T) {
+	assert := assert.New(t)
+
+	cases := []struct {
+		a, b     string
+		expected string
+		ok       bool
+	}{
+		{a: "*", b: "bookinfo.example.com", expected: "bookinfo.example.com", ok: true},
+		{a: "*.example.com", b: "Bookinfo.Example.com", expected: "bookinfo.example.com", ok: true},
+		{a: "*.example.com", b: "*.bookinfo.example.com", expected: "*.bookinfo.example.com", ok: true},
+		{a: "bookinfo.example.com", b: "*", expected: "bookinfo.example.com", ok: true},
+		{a: "*.example.com", b: "example.com", ok: false},
+		{a: "bookinfo.example.com", b: "travels.example.com", ok: false},
+	}
+	for _, c := range cases {
+		hostname, ok := intersectHosts(c.a, c.b)
+		assert.Equal(c.ok, ok, c.a+" "+c.b)
+		assert.Equal(c.expected, hostname, c.a+" "+c.b)
+	}
+}
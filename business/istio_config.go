@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	"gopkg.in/yaml.v2"
 	extentions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
@@ -17,6 +19,7 @@ import (
 	api_errors "k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	api_types "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/kiali/kiali/config"
@@ -60,7 +63,17 @@ type IstioConfigCriteria struct {
 	IncludeWasmPlugins            bool
 	IncludeTelemetry              bool
 	LabelSelector                 string
-	WorkloadSelector              string
+	// AnnotationSelector filters the objects by their annotations, see ParseAnnotationSelector for the syntax.
+	// Annotations are not indexed by the listers, so the filter is applied on the fetched objects
+	// and every object of the namespace is listed no matter how selective the annotations are.
+	AnnotationSelector string
+	// ExcludedNamespaces are regular expressions of the namespaces whose objects are skipped by the AllNamespaces
+	// fetches, like the cross-namespace fetches looking for references. The Namespace of the criteria is never excluded.
+	ExcludedNamespaces []*regexp.Regexp
+	WorkloadSelector   string
+	// AllowPartial bounds the list by the configured list timeout. When it expires the types fetched so far
+	// are returned and the missing ones are reported in TimedOut, so only callers that check TimedOut should set it.
+	AllowPartial bool
 }
 
 func (icc IstioConfigCriteria) Include(resource string) bool {
@@ -147,6 +160,7 @@ func (in *IstioConfigService) GetIstioConfigList(ctx context.Context, criteria I
 		RequestAuthentications: []*security_v1beta1.RequestAuthentication{},
 	}
 	conf := config.Get()
+	timedOut := map[string]bool{}
 	// Check if user has access to the namespace (RBAC) in cache scenarios and/or
 	// if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
 	for cluster := range in.userClients {
@@ -185,6 +199,12 @@ func (in *IstioConfigService) GetIstioConfigList(ctx context.Context, criteria I
 		istioConfigList.RequestAuthentications = append(istioConfigList.RequestAuthentications, singleClusterConfigList.RequestAuthentications...)
 		istioConfigList.WasmPlugins = append(istioConfigList.WasmPlugins, singleClusterConfigList.WasmPlugins...)
 		istioConfigList.Telemetries = append(istioConfigList.Telemetries, singleClusterConfigList.Telemetries...)
+		for _, objectType := range singleClusterConfigList.TimedOut {
+			if !timedOut[objectType] {
+				timedOut[objectType] = true
+				istioConfigList.TimedOut = append(istioConfigList.TimedOut, objectType)
+			}
+		}
 		istioConfigList.Namespace = singleClusterConfigList.Namespace
 		istioConfigList.IstioValidations = istioConfigList.IstioValidations.MergeValidations(singleClusterConfigList.IstioValidations)
 	}
@@ -325,7 +345,7 @@ func (in *IstioConfigService) getIstioConfigListForCluster(ctx context.Context,
 			istioConfigList.RequestAuthentications = registryConfiguration.RequestAuthentications
 		}
 
-		return istioConfigList, nil
+		return istioConfigList, filterIstioConfigListByCriteria(&istioConfigList, criteria)
 	}
 	kubeCache := in.kialiCache.GetKubeCaches()[cluster]
 	if kubeCache == nil {
@@ -361,6 +381,34 @@ func (in *IstioConfigService) getIstioConfigListForCluster(ctx context.Context,
 		workloadSelector = criteria.WorkloadSelector
 	}
 
+	// When a partial list is allowed a single slow type shouldn't hang the whole list, so the fan-out is bounded by the list timeout.
+	// The first error cancels the calls still in flight, as the list can't be returned anyway.
+	var cancel context.CancelFunc
+	listCtx := ctx
+	if timeout := in.config.KubernetesConfig.ListTimeout; criteria.AllowPartial && timeout > 0 {
+		listCtx, cancel = context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+	} else {
+		listCtx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	// The goroutines fetch into their own variables and only store the objects in the list, under the lock,
+	// while the list context is alive. So on timeout the list holds only the types that arrived in time and
+	// no goroutine writes it anymore.
+	var fetchedLock sync.Mutex
+	fetched := make(map[string]bool, len(istioConfigTypes))
+	setFetched := func(ctx context.Context, objectType string, set func()) {
+		fetchedLock.Lock()
+		defer fetchedLock.Unlock()
+		if ctx.Err() != nil {
+			return
+		}
+		if set != nil {
+			set()
+		}
+		fetched[objectType] = true
+	}
+
 	errChan := make(chan error, 15)
 
 	var wg sync.WaitGroup
@@ -370,298 +418,408 @@ func (in *IstioConfigService) getIstioConfigListForCluster(ctx context.Context,
 
 	go func(ctx context.Context, errChan chan error) {
 		defer wg.Done()
-		if criteria.Include(kubernetes.DestinationRules) {
-			var err error
-			// Check if namespace is cached
-			// @TODO better way to check IsResourceCached per kubeCache
-			if IsResourceCached(criteria.Namespace, kubernetes.DestinationRules) {
-				istioConfigList.DestinationRules, err = kubeCache.GetDestinationRules(criteria.Namespace, criteria.LabelSelector)
-			} else {
-				drl, e := userClient.Istio().NetworkingV1beta1().DestinationRules(criteria.Namespace).List(ctx, listOpts)
-				istioConfigList.DestinationRules = drl.Items
-				err = e
-			}
-			if err != nil {
-				errChan <- err
-			}
+		if !criteria.Include(kubernetes.DestinationRules) || ctx.Err() != nil {
+			setFetched(ctx, kubernetes.DestinationRules, nil)
+			return
+		}
+		var objects []*networking_v1beta1.DestinationRule
+		var err error
+		// Check if namespace is cached
+		if IsResourceCached(criteria.Namespace, kubernetes.DestinationRules) {
+			objects, err = kubeCache.GetDestinationRules(criteria.Namespace, criteria.LabelSelector)
+		} else {
+			list, e := userClient.Istio().NetworkingV1beta1().DestinationRules(criteria.Namespace).List(ctx, listOpts)
+			objects = list.Items
+			err = e
+		}
+		if err != nil {
+			errChan <- err
+			cancel()
+			return
 		}
-	}(ctx, errChan)
+		setFetched(ctx, kubernetes.DestinationRules, func() { istioConfigList.DestinationRules = objects })
+	}(listCtx, errChan)
 
 	go func(ctx context.Context, errChan chan error) {
 		defer wg.Done()
-		if criteria.Include(kubernetes.EnvoyFilters) {
-			var err error
-			if IsResourceCached(criteria.Namespace, kubernetes.EnvoyFilters) {
-				istioConfigList.EnvoyFilters, err = kubeCache.GetEnvoyFilters(criteria.Namespace, criteria.LabelSelector)
-			} else {
-				efl, e := userClient.Istio().NetworkingV1alpha3().EnvoyFilters(criteria.Namespace).List(ctx, listOpts)
-				istioConfigList.EnvoyFilters = efl.Items
-				err = e
-			}
-			if err == nil {
-				if isWorkloadSelector {
-					istioConfigList.EnvoyFilters = kubernetes.FilterEnvoyFiltersBySelector(workloadSelector, istioConfigList.EnvoyFilters)
-				}
-			} else {
-				errChan <- err
-			}
+		if !criteria.Include(kubernetes.EnvoyFilters) || ctx.Err() != nil {
+			setFetched(ctx, kubernetes.EnvoyFilters, nil)
+			return
+		}
+		var objects []*networking_v1alpha3.EnvoyFilter
+		var err error
+		// Check if namespace is cached
+		if IsResourceCached(criteria.Namespace, kubernetes.EnvoyFilters) {
+			objects, err = kubeCache.GetEnvoyFilters(criteria.Namespace, criteria.LabelSelector)
+		} else {
+			list, e := userClient.Istio().NetworkingV1alpha3().EnvoyFilters(criteria.Namespace).List(ctx, listOpts)
+			objects = list.Items
+			err = e
+		}
+		if err != nil {
+			errChan <- err
+			cancel()
+			return
+		}
+		if isWorkloadSelector {
+			objects = kubernetes.FilterEnvoyFiltersBySelector(workloadSelector, objects)
 		}
-	}(ctx, errChan)
+		setFetched(ctx, kubernetes.EnvoyFilters, func() { istioConfigList.EnvoyFilters = objects })
+	}(listCtx, errChan)
 
 	go func(ctx context.Context, errChan chan error) {
 		defer wg.Done()
-		if criteria.Include(kubernetes.Gateways) {
-			var err error
-			// Check if namespace is cached
-			if IsResourceCached(criteria.Namespace, kubernetes.Gateways) {
-				istioConfigList.Gateways, err = kubeCache.GetGateways(criteria.Namespace, criteria.LabelSelector)
-			} else {
-				log.Debugf("Listing Gateways for namespace [%s] with labelSelector [%s]", criteria.Namespace, criteria.LabelSelector)
-				gwl, e := userClient.Istio().NetworkingV1beta1().Gateways(criteria.Namespace).List(ctx, listOpts)
-				istioConfigList.Gateways = gwl.Items
-				err = e
-			}
-			if err == nil {
-				if isWorkloadSelector {
-					istioConfigList.Gateways = kubernetes.FilterGatewaysBySelector(workloadSelector, istioConfigList.Gateways)
-				}
-			} else {
-				errChan <- err
-			}
+		if !criteria.Include(kubernetes.Gateways) || ctx.Err() != nil {
+			setFetched(ctx, kubernetes.Gateways, nil)
+			return
+		}
+		var objects []*networking_v1beta1.Gateway
+		var err error
+		// Check if namespace is cached
+		if IsResourceCached(criteria.Namespace, kubernetes.Gateways) {
+			objects, err = kubeCache.GetGateways(criteria.Namespace, criteria.LabelSelector)
+		} else {
+			list, e := userClient.Istio().NetworkingV1beta1().Gateways(criteria.Namespace).List(ctx, listOpts)
+			objects = list.Items
+			err = e
 		}
-	}(ctx, errChan)
+		if err != nil {
+			errChan <- err
+			cancel()
+			return
+		}
+		if isWorkloadSelector {
+			objects = kubernetes.FilterGatewaysBySelector(workloadSelector, objects)
+		}
+		setFetched(ctx, kubernetes.Gateways, func() { istioConfigList.Gateways = objects })
+	}(listCtx, errChan)
 
 	go func(ctx context.Context, errChan chan error) {
 		defer wg.Done()
-		if userClient.IsGatewayAPI() && criteria.Include(kubernetes.K8sGateways) {
-			var err error
-			// ignore an error as system could not be configured to support K8s Gateway API
-			// Check if namespace is cached
-			if IsResourceCached(criteria.Namespace, kubernetes.K8sGateways) {
-				istioConfigList.K8sGateways, err = kubeCache.GetK8sGateways(criteria.Namespace, criteria.LabelSelector)
-			}
-			// TODO gwl.Items, there is conflict itself in Gateway API between returned types referenced or not
-			//else {
-			//	if gwl, e := userClient.GatewayAPI().GatewayV1beta1().Gateways(criteria.Namespace).List(ctx, listOpts); e == nil {
-			//		istioConfigList.K8sGateways = gwl.Items
-			//	}
-			//}
-			if err != nil {
-				errChan <- err
-			}
+		if !(userClient.IsGatewayAPI() && criteria.Include(kubernetes.K8sGateways)) || ctx.Err() != nil {
+			setFetched(ctx, kubernetes.K8sGateways, nil)
+			return
+		}
+		var objects []*k8s_networking_v1beta1.Gateway
+		var err error
+		// ignore an error as system could not be configured to support K8s Gateway API
+		// Check if namespace is cached
+		if IsResourceCached(criteria.Namespace, kubernetes.K8sGateways) {
+			objects, err = kubeCache.GetK8sGateways(criteria.Namespace, criteria.LabelSelector)
+		}
+		// TODO gwl.Items, there is conflict itself in Gateway API between returned types referenced or not
+		if err != nil {
+			errChan <- err
+			cancel()
+			return
 		}
-	}(ctx, errChan)
+		setFetched(ctx, kubernetes.K8sGateways, func() { istioConfigList.K8sGateways = objects })
+	}(listCtx, errChan)
 
 	go func(ctx context.Context, errChan chan error) {
 		defer wg.Done()
-		if userClient.IsGatewayAPI() && criteria.Include(kubernetes.K8sHTTPRoutes) {
-			var err error
-			// ignore an error as system could not be configured to support K8s Gateway API
-			// Check if namespace is cached
-			if IsResourceCached(criteria.Namespace, kubernetes.K8sHTTPRoutes) {
-				istioConfigList.K8sHTTPRoutes, err = kubeCache.GetK8sHTTPRoutes(criteria.Namespace, criteria.LabelSelector)
-			}
-			// TODO gwl.Items, there is conflict itself in Gateway API between returned types referenced or not
-			//else {
-			//	if gwl, e := userClient.GatewayAPI().GatewayV1beta1().HTTPRoutes(criteria.Namespace).List(ctx, listOpts); e == nil {
-			//		istioConfigList.K8sHTTPRoutes = gwl.Items
-			//	}
-			//}
-			if err != nil {
-				errChan <- err
-			}
+		if !(userClient.IsGatewayAPI() && criteria.Include(kubernetes.K8sHTTPRoutes)) || ctx.Err() != nil {
+			setFetched(ctx, kubernetes.K8sHTTPRoutes, nil)
+			return
+		}
+		var objects []*k8s_networking_v1beta1.HTTPRoute
+		var err error
+		// ignore an error as system could not be configured to support K8s Gateway API
+		// Check if namespace is cached
+		if IsResourceCached(criteria.Namespace, kubernetes.K8sHTTPRoutes) {
+			objects, err = kubeCache.GetK8sHTTPRoutes(criteria.Namespace, criteria.LabelSelector)
+		}
+		// TODO gwl.Items, there is conflict itself in Gateway API between returned types referenced or not
+		if err != nil {
+			errChan <- err
+			cancel()
+			return
 		}
-	}(ctx, errChan)
+		setFetched(ctx, kubernetes.K8sHTTPRoutes, func() { istioConfigList.K8sHTTPRoutes = objects })
+	}(listCtx, errChan)
 
 	go func(ctx context.Context, errChan chan error) {
 		defer wg.Done()
-		if criteria.Include(kubernetes.ServiceEntries) {
-			var err error
-			// Check if namespace is cached
-			if IsResourceCached(criteria.Namespace, kubernetes.ServiceEntries) {
-				istioConfigList.ServiceEntries, err = kubeCache.GetServiceEntries(criteria.Namespace, criteria.LabelSelector)
-			} else {
-				sel, e := userClient.Istio().NetworkingV1beta1().ServiceEntries(criteria.Namespace).List(ctx, listOpts)
-				istioConfigList.ServiceEntries = sel.Items
-				err = e
-			}
-			if err != nil {
-				errChan <- err
-			}
+		if !criteria.Include(kubernetes.ServiceEntries) || ctx.Err() != nil {
+			setFetched(ctx, kubernetes.ServiceEntries, nil)
+			return
+		}
+		var objects []*networking_v1beta1.ServiceEntry
+		var err error
+		// Check if namespace is cached
+		if IsResourceCached(criteria.Namespace, kubernetes.ServiceEntries) {
+			objects, err = kubeCache.GetServiceEntries(criteria.Namespace, criteria.LabelSelector)
+		} else {
+			list, e := userClient.Istio().NetworkingV1beta1().ServiceEntries(criteria.Namespace).List(ctx, listOpts)
+			objects = list.Items
+			err = e
+		}
+		if err != nil {
+			errChan <- err
+			cancel()
+			return
 		}
-	}(ctx, errChan)
+		setFetched(ctx, kubernetes.ServiceEntries, func() { istioConfigList.ServiceEntries = objects })
+	}(listCtx, errChan)
 
 	go func(ctx context.Context, errChan chan error) {
 		defer wg.Done()
-		if criteria.Include(kubernetes.Sidecars) {
-			var err error
-			if IsResourceCached(criteria.Namespace, kubernetes.Sidecars) {
-				istioConfigList.Sidecars, err = kubeCache.GetSidecars(criteria.Namespace, criteria.LabelSelector)
-			} else {
-				scl, e := userClient.Istio().NetworkingV1beta1().Sidecars(criteria.Namespace).List(ctx, listOpts)
-				istioConfigList.Sidecars = scl.Items
-				err = e
-			}
-			if err == nil {
-				if isWorkloadSelector {
-					istioConfigList.Sidecars = kubernetes.FilterSidecarsBySelector(workloadSelector, istioConfigList.Sidecars)
-				}
-			} else {
-				errChan <- err
-			}
+		if !criteria.Include(kubernetes.Sidecars) || ctx.Err() != nil {
+			setFetched(ctx, kubernetes.Sidecars, nil)
+			return
+		}
+		var objects []*networking_v1beta1.Sidecar
+		var err error
+		// Check if namespace is cached
+		if IsResourceCached(criteria.Namespace, kubernetes.Sidecars) {
+			objects, err = kubeCache.GetSidecars(criteria.Namespace, criteria.LabelSelector)
+		} else {
+			list, e := userClient.Istio().NetworkingV1beta1().Sidecars(criteria.Namespace).List(ctx, listOpts)
+			objects = list.Items
+			err = e
 		}
-	}(ctx, errChan)
+		if err != nil {
+			errChan <- err
+			cancel()
+			return
+		}
+		if isWorkloadSelector {
+			objects = kubernetes.FilterSidecarsBySelector(workloadSelector, objects)
+		}
+		setFetched(ctx, kubernetes.Sidecars, func() { istioConfigList.Sidecars = objects })
+	}(listCtx, errChan)
 
 	go func(ctx context.Context, errChan chan error) {
 		defer wg.Done()
-		if criteria.Include(kubernetes.VirtualServices) {
-			var err error
-			// Check if namespace is cached
-			if IsResourceCached(criteria.Namespace, kubernetes.VirtualServices) {
-				istioConfigList.VirtualServices, err = kubeCache.GetVirtualServices(criteria.Namespace, criteria.LabelSelector)
-			} else {
-				vsl, e := userClient.Istio().NetworkingV1beta1().VirtualServices(criteria.Namespace).List(ctx, listOpts)
-				istioConfigList.VirtualServices = vsl.Items
-				err = e
-			}
-			if err != nil {
-				errChan <- err
-			}
+		if !criteria.Include(kubernetes.VirtualServices) || ctx.Err() != nil {
+			setFetched(ctx, kubernetes.VirtualServices, nil)
+			return
+		}
+		var objects []*networking_v1beta1.VirtualService
+		var err error
+		// Check if namespace is cached
+		if IsResourceCached(criteria.Namespace, kubernetes.VirtualServices) {
+			objects, err = kubeCache.GetVirtualServices(criteria.Namespace, criteria.LabelSelector)
+		} else {
+			list, e := userClient.Istio().NetworkingV1beta1().VirtualServices(criteria.Namespace).List(ctx, listOpts)
+			objects = list.Items
+			err = e
+		}
+		if err != nil {
+			errChan <- err
+			cancel()
+			return
 		}
-	}(ctx, errChan)
+		setFetched(ctx, kubernetes.VirtualServices, func() { istioConfigList.VirtualServices = objects })
+	}(listCtx, errChan)
 
 	go func(ctx context.Context, errChan chan error) {
 		defer wg.Done()
-		if criteria.Include(kubernetes.WorkloadEntries) {
-			var err error
-			if IsResourceCached(criteria.Namespace, kubernetes.WorkloadEntries) {
-				istioConfigList.WorkloadEntries, err = kubeCache.GetWorkloadEntries(criteria.Namespace, criteria.LabelSelector)
-			} else {
-				wel, e := userClient.Istio().NetworkingV1beta1().WorkloadEntries(criteria.Namespace).List(ctx, listOpts)
-				istioConfigList.WorkloadEntries = wel.Items
-				err = e
-			}
-			if err != nil {
-				errChan <- err
-			}
+		if !criteria.Include(kubernetes.WorkloadEntries) || ctx.Err() != nil {
+			setFetched(ctx, kubernetes.WorkloadEntries, nil)
+			return
+		}
+		var objects []*networking_v1beta1.WorkloadEntry
+		var err error
+		// Check if namespace is cached
+		if IsResourceCached(criteria.Namespace, kubernetes.WorkloadEntries) {
+			objects, err = kubeCache.GetWorkloadEntries(criteria.Namespace, criteria.LabelSelector)
+		} else {
+			list, e := userClient.Istio().NetworkingV1beta1().WorkloadEntries(criteria.Namespace).List(ctx, listOpts)
+			objects = list.Items
+			err = e
 		}
-	}(ctx, errChan)
+		if err != nil {
+			errChan <- err
+			cancel()
+			return
+		}
+		setFetched(ctx, kubernetes.WorkloadEntries, func() { istioConfigList.WorkloadEntries = objects })
+	}(listCtx, errChan)
 
 	go func(ctx context.Context, errChan chan error) {
 		defer wg.Done()
-		if criteria.Include(kubernetes.WorkloadGroups) {
-			var err error
-			if IsResourceCached(criteria.Namespace, kubernetes.WorkloadGroups) {
-				istioConfigList.WorkloadGroups, err = kubeCache.GetWorkloadGroups(criteria.Namespace, criteria.LabelSelector)
-			} else {
-				wgl, e := userClient.Istio().NetworkingV1beta1().WorkloadGroups(criteria.Namespace).List(ctx, listOpts)
-				istioConfigList.WorkloadGroups = wgl.Items
-				err = e
-			}
-			if err != nil {
-				errChan <- err
-			}
+		if !criteria.Include(kubernetes.WorkloadGroups) || ctx.Err() != nil {
+			setFetched(ctx, kubernetes.WorkloadGroups, nil)
+			return
+		}
+		var objects []*networking_v1beta1.WorkloadGroup
+		var err error
+		// Check if namespace is cached
+		if IsResourceCached(criteria.Namespace, kubernetes.WorkloadGroups) {
+			objects, err = kubeCache.GetWorkloadGroups(criteria.Namespace, criteria.LabelSelector)
+		} else {
+			list, e := userClient.Istio().NetworkingV1beta1().WorkloadGroups(criteria.Namespace).List(ctx, listOpts)
+			objects = list.Items
+			err = e
+		}
+		if err != nil {
+			errChan <- err
+			cancel()
+			return
 		}
-	}(ctx, errChan)
+		setFetched(ctx, kubernetes.WorkloadGroups, func() { istioConfigList.WorkloadGroups = objects })
+	}(listCtx, errChan)
 
 	go func(ctx context.Context, errChan chan error) {
 		defer wg.Done()
-		if criteria.Include(kubernetes.WasmPlugins) {
-			var err error
-			if IsResourceCached(criteria.Namespace, kubernetes.WasmPlugins) {
-				istioConfigList.WasmPlugins, err = kubeCache.GetWasmPlugins(criteria.Namespace, criteria.LabelSelector)
-			} else {
-				wgl, e := userClient.Istio().ExtensionsV1alpha1().WasmPlugins(criteria.Namespace).List(ctx, listOpts)
-				istioConfigList.WasmPlugins = wgl.Items
-				err = e
-			}
-			if err != nil {
-				errChan <- err
-			}
+		if !criteria.Include(kubernetes.WasmPlugins) || ctx.Err() != nil {
+			setFetched(ctx, kubernetes.WasmPlugins, nil)
+			return
+		}
+		var objects []*extentions_v1alpha1.WasmPlugin
+		var err error
+		// Check if namespace is cached
+		if IsResourceCached(criteria.Namespace, kubernetes.WasmPlugins) {
+			objects, err = kubeCache.GetWasmPlugins(criteria.Namespace, criteria.LabelSelector)
+		} else {
+			list, e := userClient.Istio().ExtensionsV1alpha1().WasmPlugins(criteria.Namespace).List(ctx, listOpts)
+			objects = list.Items
+			err = e
+		}
+		if err != nil {
+			errChan <- err
+			cancel()
+			return
 		}
-	}(ctx, errChan)
+		setFetched(ctx, kubernetes.WasmPlugins, func() { istioConfigList.WasmPlugins = objects })
+	}(listCtx, errChan)
 
 	go func(ctx context.Context, errChan chan error) {
 		defer wg.Done()
-		if criteria.Include(kubernetes.Telemetries) {
-			var err error
-			if IsResourceCached(criteria.Namespace, kubernetes.Telemetries) {
-				istioConfigList.Telemetries, err = kubeCache.GetTelemetries(criteria.Namespace, criteria.LabelSelector)
-			} else {
-				wgl, e := userClient.Istio().TelemetryV1alpha1().Telemetries(criteria.Namespace).List(ctx, listOpts)
-				istioConfigList.Telemetries = wgl.Items
-				err = e
-			}
-			if err != nil {
-				errChan <- err
-			}
+		if !criteria.Include(kubernetes.Telemetries) || ctx.Err() != nil {
+			setFetched(ctx, kubernetes.Telemetries, nil)
+			return
+		}
+		var objects []*v1alpha1.Telemetry
+		var err error
+		// Check if namespace is cached
+		if IsResourceCached(criteria.Namespace, kubernetes.Telemetries) {
+			objects, err = kubeCache.GetTelemetries(criteria.Namespace, criteria.LabelSelector)
+		} else {
+			list, e := userClient.Istio().TelemetryV1alpha1().Telemetries(criteria.Namespace).List(ctx, listOpts)
+			objects = list.Items
+			err = e
 		}
-	}(ctx, errChan)
+		if err != nil {
+			errChan <- err
+			cancel()
+			return
+		}
+		setFetched(ctx, kubernetes.Telemetries, func() { istioConfigList.Telemetries = objects })
+	}(listCtx, errChan)
 
 	go func(ctx context.Context, errChan chan error) {
 		defer wg.Done()
-		if criteria.Include(kubernetes.AuthorizationPolicies) {
-			var err error
-			if IsResourceCached(criteria.Namespace, kubernetes.AuthorizationPolicies) {
-				istioConfigList.AuthorizationPolicies, err = kubeCache.GetAuthorizationPolicies(criteria.Namespace, criteria.LabelSelector)
-			} else {
-				apl, e := userClient.Istio().SecurityV1beta1().AuthorizationPolicies(criteria.Namespace).List(ctx, listOpts)
-				istioConfigList.AuthorizationPolicies = apl.Items
-				err = e
-			}
-			if err == nil {
-				if isWorkloadSelector {
-					istioConfigList.AuthorizationPolicies = kubernetes.FilterAuthorizationPoliciesBySelector(workloadSelector, istioConfigList.AuthorizationPolicies)
-				}
-			} else {
-				errChan <- err
-			}
+		if !criteria.Include(kubernetes.AuthorizationPolicies) || ctx.Err() != nil {
+			setFetched(ctx, kubernetes.AuthorizationPolicies, nil)
+			return
+		}
+		var objects []*security_v1beta1.AuthorizationPolicy
+		var err error
+		// Check if namespace is cached
+		if IsResourceCached(criteria.Namespace, kubernetes.AuthorizationPolicies) {
+			objects, err = kubeCache.GetAuthorizationPolicies(criteria.Namespace, criteria.LabelSelector)
+		} else {
+			list, e := userClient.Istio().SecurityV1beta1().AuthorizationPolicies(criteria.Namespace).List(ctx, listOpts)
+			objects = list.Items
+			err = e
+		}
+		if err != nil {
+			errChan <- err
+			cancel()
+			return
+		}
+		if isWorkloadSelector {
+			objects = kubernetes.FilterAuthorizationPoliciesBySelector(workloadSelector, objects)
 		}
-	}(ctx, errChan)
+		setFetched(ctx, kubernetes.AuthorizationPolicies, func() { istioConfigList.AuthorizationPolicies = objects })
+	}(listCtx, errChan)
 
 	go func(ctx context.Context, errChan chan error) {
 		defer wg.Done()
-		if criteria.Include(kubernetes.PeerAuthentications) {
-			var err error
-			if IsResourceCached(criteria.Namespace, kubernetes.PeerAuthentications) {
-				istioConfigList.PeerAuthentications, err = kubeCache.GetPeerAuthentications(criteria.Namespace, criteria.LabelSelector)
-			} else {
-				pal, e := userClient.Istio().SecurityV1beta1().PeerAuthentications(criteria.Namespace).List(ctx, listOpts)
-				istioConfigList.PeerAuthentications = pal.Items
-				err = e
-			}
-			if err == nil {
-				if isWorkloadSelector {
-					istioConfigList.PeerAuthentications = kubernetes.FilterPeerAuthenticationsBySelector(workloadSelector, istioConfigList.PeerAuthentications)
-				}
-			} else {
-				errChan <- err
-			}
+		if !criteria.Include(kubernetes.PeerAuthentications) || ctx.Err() != nil {
+			setFetched(ctx, kubernetes.PeerAuthentications, nil)
+			return
+		}
+		var objects []*security_v1beta1.PeerAuthentication
+		var err error
+		// Check if namespace is cached
+		if IsResourceCached(criteria.Namespace, kubernetes.PeerAuthentications) {
+			objects, err = kubeCache.GetPeerAuthentications(criteria.Namespace, criteria.LabelSelector)
+		} else {
+			list, e := userClient.Istio().SecurityV1beta1().PeerAuthentications(criteria.Namespace).List(ctx, listOpts)
+			objects = list.Items
+			err = e
+		}
+		if err != nil {
+			errChan <- err
+			cancel()
+			return
 		}
-	}(ctx, errChan)
+		if isWorkloadSelector {
+			objects = kubernetes.FilterPeerAuthenticationsBySelector(workloadSelector, objects)
+		}
+		setFetched(ctx, kubernetes.PeerAuthentications, func() { istioConfigList.PeerAuthentications = objects })
+	}(listCtx, errChan)
 
 	go func(ctx context.Context, errChan chan error) {
 		defer wg.Done()
-		if criteria.Include(kubernetes.RequestAuthentications) {
-			var err error
-			if IsResourceCached(criteria.Namespace, kubernetes.RequestAuthentications) {
-				istioConfigList.RequestAuthentications, err = kubeCache.GetRequestAuthentications(criteria.Namespace, criteria.LabelSelector)
-			} else {
-				ral, e := userClient.Istio().SecurityV1beta1().RequestAuthentications(criteria.Namespace).List(ctx, listOpts)
-				istioConfigList.RequestAuthentications = ral.Items
-				err = e
-			}
-			if err == nil {
-				if isWorkloadSelector {
-					istioConfigList.RequestAuthentications = kubernetes.FilterRequestAuthenticationsBySelector(workloadSelector, istioConfigList.RequestAuthentications)
-				}
-			} else {
-				errChan <- err
-			}
+		if !criteria.Include(kubernetes.RequestAuthentications) || ctx.Err() != nil {
+			setFetched(ctx, kubernetes.RequestAuthentications, nil)
+			return
+		}
+		var objects []*security_v1beta1.RequestAuthentication
+		var err error
+		// Check if namespace is cached
+		if IsResourceCached(criteria.Namespace, kubernetes.RequestAuthentications) {
+			objects, err = kubeCache.GetRequestAuthentications(criteria.Namespace, criteria.LabelSelector)
+		} else {
+			list, e := userClient.Istio().SecurityV1beta1().RequestAuthentications(criteria.Namespace).List(ctx, listOpts)
+			objects = list.Items
+			err = e
+		}
+		if err != nil {
+			errChan <- err
+			cancel()
+			return
 		}
-	}(ctx, errChan)
+		if isWorkloadSelector {
+			objects = kubernetes.FilterRequestAuthenticationsBySelector(workloadSelector, objects)
+		}
+		setFetched(ctx, kubernetes.RequestAuthentications, func() { istioConfigList.RequestAuthentications = objects })
+	}(listCtx, errChan)
 
-	wg.Wait()
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-listCtx.Done():
+	}
+
+	// Also checked when all the goroutines are done, as the types that finished after the list context was done were dropped
+	if listCtx.Err() != nil {
+		if ctx.Err() != nil {
+			// The caller is gone, nobody is waiting for the list
+			return models.IstioConfigList{}, ctx.Err()
+		}
+		if listCtx.Err() == context.DeadlineExceeded {
+			fetchedLock.Lock()
+			partialList := partialIstioConfigList(istioConfigList, fetched)
+			fetchedLock.Unlock()
+			log.Warningf("Timeout listing Istio config of namespace [%s] in cluster [%s]. Missing types: %v", criteria.Namespace, cluster, partialList.TimedOut)
+			if err := filterIstioConfigListByCriteria(&partialList, criteria); err != nil {
+				return models.IstioConfigList{}, err
+			}
+			return partialList, nil
+		}
+		// Cancelled by a failing type, its error is already in the channel
+		return models.IstioConfigList{}, <-errChan
+	}
 
 	close(errChan)
 	for e := range errChan {
@@ -671,9 +829,326 @@ func (in *IstioConfigService) getIstioConfigListForCluster(ctx context.Context,
 		}
 	}
 
+	if err := filterIstioConfigListByCriteria(&istioConfigList, criteria); err != nil {
+		return models.IstioConfigList{}, err
+	}
+
 	return istioConfigList, nil
 }
 
+// istioConfigTypes are the object types fetched by the Istio config list
+var istioConfigTypes = []string{
+	kubernetes.DestinationRules,
+	kubernetes.EnvoyFilters,
+	kubernetes.Gateways,
+	kubernetes.K8sGateways,
+	kubernetes.K8sHTTPRoutes,
+	kubernetes.ServiceEntries,
+	kubernetes.Sidecars,
+	kubernetes.VirtualServices,
+	kubernetes.WorkloadEntries,
+	kubernetes.WorkloadGroups,
+	kubernetes.WasmPlugins,
+	kubernetes.Telemetries,
+	kubernetes.AuthorizationPolicies,
+	kubernetes.PeerAuthentications,
+	kubernetes.RequestAuthentications,
+}
+
+// partialIstioConfigList copies the types of the list that were fetched before the timeout.
+// The types that weren't are reported in TimedOut and left empty.
+func partialIstioConfigList(istioConfigList models.IstioConfigList, fetched map[string]bool) models.IstioConfigList {
+	partialList := models.IstioConfigList{
+		Namespace: istioConfigList.Namespace,
+
+		DestinationRules: []*networking_v1beta1.DestinationRule{},
+		EnvoyFilters:     []*networking_v1alpha3.EnvoyFilter{},
+		Gateways:         []*networking_v1beta1.Gateway{},
+		VirtualServices:  []*networking_v1beta1.VirtualService{},
+		ServiceEntries:   []*networking_v1beta1.ServiceEntry{},
+		Sidecars:         []*networking_v1beta1.Sidecar{},
+		WorkloadEntries:  []*networking_v1beta1.WorkloadEntry{},
+		WorkloadGroups:   []*networking_v1beta1.WorkloadGroup{},
+		WasmPlugins:      []*extentions_v1alpha1.WasmPlugin{},
+		Telemetries:      []*v1alpha1.Telemetry{},
+
+		K8sGateways:   []*k8s_networking_v1beta1.Gateway{},
+		K8sHTTPRoutes: []*k8s_networking_v1beta1.HTTPRoute{},
+
+		AuthorizationPolicies:  []*security_v1beta1.AuthorizationPolicy{},
+		PeerAuthentications:    []*security_v1beta1.PeerAuthentication{},
+		RequestAuthentications: []*security_v1beta1.RequestAuthentication{},
+
+		TimedOut: []string{},
+	}
+
+	for _, objectType := range istioConfigTypes {
+		if !fetched[objectType] {
+			partialList.TimedOut = append(partialList.TimedOut, objectType)
+			continue
+		}
+		switch objectType {
+		case kubernetes.DestinationRules:
+			partialList.DestinationRules = istioConfigList.DestinationRules
+		case kubernetes.EnvoyFilters:
+			partialList.EnvoyFilters = istioConfigList.EnvoyFilters
+		case kubernetes.Gateways:
+			partialList.Gateways = istioConfigList.Gateways
+		case kubernetes.K8sGateways:
+			partialList.K8sGateways = istioConfigList.K8sGateways
+		case kubernetes.K8sHTTPRoutes:
+			partialList.K8sHTTPRoutes = istioConfigList.K8sHTTPRoutes
+		case kubernetes.ServiceEntries:
+			partialList.ServiceEntries = istioConfigList.ServiceEntries
+		case kubernetes.Sidecars:
+			partialList.Sidecars = istioConfigList.Sidecars
+		case kubernetes.VirtualServices:
+			partialList.VirtualServices = istioConfigList.VirtualServices
+		case kubernetes.WorkloadEntries:
+			partialList.WorkloadEntries = istioConfigList.WorkloadEntries
+		case kubernetes.WorkloadGroups:
+			partialList.WorkloadGroups = istioConfigList.WorkloadGroups
+		case kubernetes.WasmPlugins:
+			partialList.WasmPlugins = istioConfigList.WasmPlugins
+		case kubernetes.Telemetries:
+			partialList.Telemetries = istioConfigList.Telemetries
+		case kubernetes.AuthorizationPolicies:
+			partialList.AuthorizationPolicies = istioConfigList.AuthorizationPolicies
+		case kubernetes.PeerAuthentications:
+			partialList.PeerAuthentications = istioConfigList.PeerAuthentications
+		case kubernetes.RequestAuthentications:
+			partialList.RequestAuthentications = istioConfigList.RequestAuthentications
+		}
+	}
+	return partialList
+}
+
+// filterIstioConfigListByCriteria keeps the objects of the list matching the annotation selector of the criteria
+// and not in its excluded namespaces.
+func filterIstioConfigListByCriteria(istioConfigList *models.IstioConfigList, criteria IstioConfigCriteria) error {
+	// The excluded namespaces go first, they usually drop most of the objects
+	if criteria.AllNamespaces {
+		filterIstioConfigListByNamespaces(istioConfigList, criteria.ExcludedNamespaces, criteria.Namespace)
+	}
+	return filterIstioConfigListByAnnotations(istioConfigList, criteria.AnnotationSelector)
+}
+
+// filterIstioConfigListByAnnotations keeps the objects of the list whose annotations match the annotationSelector
+func filterIstioConfigListByAnnotations(istioConfigList *models.IstioConfigList, annotationSelector string) error {
+	if annotationSelector == "" {
+		return nil
+	}
+
+	selector, err := ParseAnnotationSelector(annotationSelector)
+	if err != nil {
+		return api_errors.NewBadRequest(fmt.Sprintf("invalid annotationSelector [%s]: %s", annotationSelector, err))
+	}
+	filterIstioConfigList(istioConfigList, func(obj meta_v1.Object) bool {
+		return selector.Matches(obj.GetAnnotations())
+	})
+	return nil
+}
+
+// annotationRequirement is a term of an AnnotationSelector
+type annotationRequirement struct {
+	key      string
+	value    string
+	hasValue bool
+	negated  bool
+}
+
+// AnnotationSelector selects objects by their annotations. Unlike with the label selectors, any value is accepted:
+// annotations often hold URLs, JSON or text longer than a label value.
+type AnnotationSelector []annotationRequirement
+
+// ParseAnnotationSelector parses the comma separated terms 'key=value', 'key!=value', 'key' and '!key'.
+// The keys must be valid annotation keys, the values can't contain a comma.
+func ParseAnnotationSelector(selector string) (AnnotationSelector, error) {
+	parsed := AnnotationSelector{}
+	for _, term := range strings.Split(selector, ",") {
+		requirement := annotationRequirement{}
+		if i := strings.Index(term, "="); i >= 0 {
+			requirement.key, requirement.value, requirement.hasValue = term[:i], strings.TrimSpace(term[i+1:]), true
+			if strings.HasSuffix(requirement.key, "!") {
+				requirement.key, requirement.negated = strings.TrimSuffix(requirement.key, "!"), true
+			}
+		} else {
+			requirement.key = strings.TrimSpace(term)
+			if strings.HasPrefix(requirement.key, "!") {
+				requirement.key, requirement.negated = strings.TrimPrefix(requirement.key, "!"), true
+			}
+		}
+		requirement.key = strings.TrimSpace(requirement.key)
+		if errs := validation.IsQualifiedName(requirement.key); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid annotation key [%s]: %s", requirement.key, strings.Join(errs, "; "))
+		}
+		parsed = append(parsed, requirement)
+	}
+	return parsed, nil
+}
+
+// Matches tells whether the annotations meet every term of the selector
+func (s AnnotationSelector) Matches(annotations map[string]string) bool {
+	for _, requirement := range s {
+		value, found := annotations[requirement.key]
+		matched := found && (!requirement.hasValue || value == requirement.value)
+		if matched == requirement.negated {
+			return false
+		}
+	}
+	return true
+}
+
+// filterIstioConfigListByNamespaces drops the objects of the namespaces matching the excluded regular expressions,
+// except the ones of the kept namespace
+func filterIstioConfigListByNamespaces(istioConfigList *models.IstioConfigList, excludedNamespaces []*regexp.Regexp, keptNamespace string) {
+	if len(excludedNamespaces) == 0 {
+		return
+	}
+
+	excluded := map[string]bool{}
+	filterIstioConfigList(istioConfigList, func(obj meta_v1.Object) bool {
+		namespace := obj.GetNamespace()
+		if namespace == keptNamespace {
+			return true
+		}
+		isExcluded, ok := excluded[namespace]
+		if !ok {
+			for _, excludePattern := range excludedNamespaces {
+				if excludePattern.MatchString(namespace) {
+					isExcluded = true
+					break
+				}
+			}
+			excluded[namespace] = isExcluded
+		}
+		return !isExcluded
+	})
+}
+
+// filterIstioConfigList keeps the objects of every type of the list for which matches returns true
+func filterIstioConfigList(istioConfigList *models.IstioConfigList, matches func(obj meta_v1.Object) bool) {
+	destinationRules := []*networking_v1beta1.DestinationRule{}
+	for _, dr := range istioConfigList.DestinationRules {
+		if matches(dr) {
+			destinationRules = append(destinationRules, dr)
+		}
+	}
+	istioConfigList.DestinationRules = destinationRules
+
+	envoyFilters := []*networking_v1alpha3.EnvoyFilter{}
+	for _, ef := range istioConfigList.EnvoyFilters {
+		if matches(ef) {
+			envoyFilters = append(envoyFilters, ef)
+		}
+	}
+	istioConfigList.EnvoyFilters = envoyFilters
+
+	gateways := []*networking_v1beta1.Gateway{}
+	for _, gw := range istioConfigList.Gateways {
+		if matches(gw) {
+			gateways = append(gateways, gw)
+		}
+	}
+	istioConfigList.Gateways = gateways
+
+	k8sGateways := []*k8s_networking_v1beta1.Gateway{}
+	for _, gw := range istioConfigList.K8sGateways {
+		if matches(gw) {
+			k8sGateways = append(k8sGateways, gw)
+		}
+	}
+	istioConfigList.K8sGateways = k8sGateways
+
+	k8sHTTPRoutes := []*k8s_networking_v1beta1.HTTPRoute{}
+	for _, route := range istioConfigList.K8sHTTPRoutes {
+		if matches(route) {
+			k8sHTTPRoutes = append(k8sHTTPRoutes, route)
+		}
+	}
+	istioConfigList.K8sHTTPRoutes = k8sHTTPRoutes
+
+	virtualServices := []*networking_v1beta1.VirtualService{}
+	for _, vs := range istioConfigList.VirtualServices {
+		if matches(vs) {
+			virtualServices = append(virtualServices, vs)
+		}
+	}
+	istioConfigList.VirtualServices = virtualServices
+
+	serviceEntries := []*networking_v1beta1.ServiceEntry{}
+	for _, se := range istioConfigList.ServiceEntries {
+		if matches(se) {
+			serviceEntries = append(serviceEntries, se)
+		}
+	}
+	istioConfigList.ServiceEntries = serviceEntries
+
+	sidecars := []*networking_v1beta1.Sidecar{}
+	for _, sc := range istioConfigList.Sidecars {
+		if matches(sc) {
+			sidecars = append(sidecars, sc)
+		}
+	}
+	istioConfigList.Sidecars = sidecars
+
+	workloadEntries := []*networking_v1beta1.WorkloadEntry{}
+	for _, we := range istioConfigList.WorkloadEntries {
+		if matches(we) {
+			workloadEntries = append(workloadEntries, we)
+		}
+	}
+	istioConfigList.WorkloadEntries = workloadEntries
+
+	workloadGroups := []*networking_v1beta1.WorkloadGroup{}
+	for _, wg := range istioConfigList.WorkloadGroups {
+		if matches(wg) {
+			workloadGroups = append(workloadGroups, wg)
+		}
+	}
+	istioConfigList.WorkloadGroups = workloadGroups
+
+	wasmPlugins := []*extentions_v1alpha1.WasmPlugin{}
+	for _, wp := range istioConfigList.WasmPlugins {
+		if matches(wp) {
+			wasmPlugins = append(wasmPlugins, wp)
+		}
+	}
+	istioConfigList.WasmPlugins = wasmPlugins
+
+	telemetries := []*v1alpha1.Telemetry{}
+	for _, tm := range istioConfigList.Telemetries {
+		if matches(tm) {
+			telemetries = append(telemetries, tm)
+		}
+	}
+	istioConfigList.Telemetries = telemetries
+
+	authorizationPolicies := []*security_v1beta1.AuthorizationPolicy{}
+	for _, ap := range istioConfigList.AuthorizationPolicies {
+		if matches(ap) {
+			authorizationPolicies = append(authorizationPolicies, ap)
+		}
+	}
+	istioConfigList.AuthorizationPolicies = authorizationPolicies
+
+	peerAuthentications := []*security_v1beta1.PeerAuthentication{}
+	for _, pa := range istioConfigList.PeerAuthentications {
+		if matches(pa) {
+			peerAuthentications = append(peerAuthentications, pa)
+		}
+	}
+	istioConfigList.PeerAuthentications = peerAuthentications
+
+	requestAuthentications := []*security_v1beta1.RequestAuthentication{}
+	for _, ra := range istioConfigList.RequestAuthentications {
+		if matches(ra) {
+			requestAuthentications = append(requestAuthentications, ra)
+		}
+	}
+	istioConfigList.RequestAuthentications = requestAuthentications
+}
+
 // GetIstioConfigDetails returns a specific Istio configuration object.
 // It uses following parameters:
 // - "namespace": 		namespace where configuration is stored
@@ -817,9 +1292,32 @@ func (in *IstioConfigService) GetIstioConfigDetails(ctx context.Context, cluster
 
 	wg.Wait()
 
+	if err == nil {
+		istioConfigDetail.SetStatus()
+	}
+
 	return istioConfigDetail, err
 }
 
+// getRegistryConfiguration returns the Istio config of all the namespaces known by the Istio registry of the cluster
+func (in *IstioConfigService) getRegistryConfiguration(cluster string) (*kubernetes.RegistryConfiguration, error) {
+	registryCriteria := RegistryCriteria{
+		AllNamespaces: true,
+	}
+	if _, ok := in.businessLayer.RegistryStatuses[cluster]; !ok {
+		return nil, fmt.Errorf("Registry Cache for Cluster [%s] is not found or is not accessible for Kiali", cluster)
+	}
+	registryStatus := in.businessLayer.RegistryStatuses[cluster]
+	registryConfiguration, err := registryStatus.GetRegistryConfiguration(registryCriteria)
+	if err != nil {
+		return nil, err
+	}
+	if registryConfiguration == nil {
+		return nil, errors.New("RegistryConfiguration is nil. This is an unexpected case. Is the Kiali cache disabled ?")
+	}
+	return registryConfiguration, nil
+}
+
 // GetIstioConfigDetailsFromRegistry returns a specific Istio configuration object from Istio Registry.
 // The returned object is Read only.
 // It uses following parameters:
@@ -839,20 +1337,10 @@ func (in *IstioConfigService) GetIstioConfigDetailsFromRegistry(ctx context.Cont
 		Delete: false,
 	}
 
-	registryCriteria := RegistryCriteria{
-		AllNamespaces: true,
-	}
-	if _, ok := in.businessLayer.RegistryStatuses[cluster]; !ok {
-		return istioConfigDetail, fmt.Errorf("Registry Cache for Cluster [%s] is not found or is not accessible for Kiali", cluster)
-	}
-	registryStatus := in.businessLayer.RegistryStatuses[cluster]
-	registryConfiguration, err := registryStatus.GetRegistryConfiguration(registryCriteria)
+	registryConfiguration, err := in.getRegistryConfiguration(cluster)
 	if err != nil {
 		return istioConfigDetail, err
 	}
-	if registryConfiguration == nil {
-		return istioConfigDetail, errors.New("RegistryConfiguration is nil. This is an unexpected case. Is the Kiali cache disabled ?")
-	}
 
 	switch objectType {
 	case kubernetes.DestinationRules:
@@ -1071,17 +1559,20 @@ func (in *IstioConfigService) DeleteIstioConfigDetail(cluster, namespace, resour
 	return nil
 }
 
-func (in *IstioConfigService) UpdateIstioConfigDetail(cluster, namespace, resourceType, name, jsonPatch string) (models.IstioConfigDetails, error) {
+// UpdateIstioConfigDetail patches an Istio object with a JSON patch or a merge patch.
+// When the resourceVersion is not empty, the update fails with an IstioConfigConflictError if the object has been modified since.
+func (in *IstioConfigService) UpdateIstioConfigDetail(cluster, namespace, resourceType, name, jsonPatch, resourceVersion string) (models.IstioConfigDetails, error) {
 	istioConfigDetail := models.IstioConfigDetails{}
 	istioConfigDetail.Namespace = models.Namespace{Name: namespace}
 	istioConfigDetail.ObjectType = resourceType
 
 	patchOpts := meta_v1.PatchOptions{}
 	ctx := context.TODO()
-	patchType := api_types.MergePatchType
-	bytePatch := []byte(jsonPatch)
+	patchType, bytePatch, err := buildIstioConfigPatch(jsonPatch, resourceVersion)
+	if err != nil {
+		return istioConfigDetail, api_errors.NewBadRequest(err.Error())
+	}
 
-	var err error
 	switch resourceType {
 	case kubernetes.DestinationRules:
 		istioConfigDetail.DestinationRule = &networking_v1beta1.DestinationRule{}
@@ -1132,6 +1623,10 @@ func (in *IstioConfigService) UpdateIstioConfigDetail(cluster, namespace, resour
 		err = fmt.Errorf("object type not found: %v", resourceType)
 	}
 
+	if err != nil && resourceVersion != "" && isResourceVersionConflict(err) {
+		err = &IstioConfigConflictError{msg: fmt.Sprintf("%s [%s] in namespace [%s] has been modified since resourceVersion [%s]: %v", resourceType, name, namespace, resourceVersion, err)}
+	}
+
 	// Cache is stopped after a Create/Update/Delete operation to force a refresh
 	if kialiCache != nil && err == nil {
 		kialiCache.Refresh(namespace)
@@ -1139,123 +1634,171 @@ func (in *IstioConfigService) UpdateIstioConfigDetail(cluster, namespace, resour
 	return istioConfigDetail, err
 }
 
-func (in *IstioConfigService) CreateIstioConfigDetail(cluster, namespace, resourceType string, body []byte) (models.IstioConfigDetails, error) {
+// IstioConfigConflictError is returned when an Istio object has been modified since the resourceVersion an update is based on
+type IstioConfigConflictError struct {
+	msg string
+}
+
+func (in *IstioConfigConflictError) Error() string {
+	return in.msg
+}
+
+func IsIstioConfigConflictError(err error) bool {
+	_, isConflictError := err.(*IstioConfigConflictError)
+	return isConflictError
+}
+
+// resourceVersionTestFailed is the error of a JSON patch whose resourceVersion test operation failed
+const resourceVersionTestFailed = "testing value /metadata/resourceVersion failed"
+
+// buildIstioConfigPatch returns the type of the patch, a JSON patch when it is an array of operations, otherwise a merge patch.
+// When a resourceVersion is expected the patch is made conditional so the object isn't clobbered by a concurrent edit:
+// a test operation is prepended to a JSON patch, the resourceVersion is set as precondition of a merge patch.
+func buildIstioConfigPatch(jsonPatch, resourceVersion string) (api_types.PatchType, []byte, error) {
+	if strings.HasPrefix(strings.TrimSpace(jsonPatch), "[") {
+		if resourceVersion == "" {
+			return api_types.JSONPatchType, []byte(jsonPatch), nil
+		}
+		operations := []json.RawMessage{}
+		if err := json.Unmarshal([]byte(jsonPatch), &operations); err != nil {
+			return "", nil, fmt.Errorf("invalid JSON patch: %v", err)
+		}
+		test, err := json.Marshal(map[string]string{"op": "test", "path": "/metadata/resourceVersion", "value": resourceVersion})
+		if err != nil {
+			return "", nil, err
+		}
+		bytePatch, err := json.Marshal(append([]json.RawMessage{test}, operations...))
+		return api_types.JSONPatchType, bytePatch, err
+	}
+
+	if resourceVersion == "" {
+		return api_types.MergePatchType, []byte(jsonPatch), nil
+	}
+	patch := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(jsonPatch), &patch); err != nil {
+		return "", nil, fmt.Errorf("invalid merge patch: %v", err)
+	}
+	metadata, ok := patch["metadata"].(map[string]interface{})
+	if !ok {
+		if patch["metadata"] != nil {
+			return "", nil, fmt.Errorf("invalid merge patch: metadata is not an object")
+		}
+		metadata = map[string]interface{}{}
+		patch["metadata"] = metadata
+	}
+	// The API server rejects with a conflict the patches whose resourceVersion isn't the current one
+	metadata["resourceVersion"] = resourceVersion
+	bytePatch, err := json.Marshal(patch)
+	return api_types.MergePatchType, bytePatch, err
+}
+
+// isResourceVersionConflict tells whether a patch failed because its resourceVersion precondition wasn't met
+func isResourceVersionConflict(err error) bool {
+	return api_errors.IsConflict(err) || strings.Contains(err.Error(), resourceVersionTestFailed)
+}
+
+// parseIstioConfigDetail unmarshals the JSON body of an Istio object of the given type into the matching field of the details
+func parseIstioConfigDetail(namespace, resourceType string, body []byte) (models.IstioConfigDetails, error) {
 	istioConfigDetail := models.IstioConfigDetails{}
 	istioConfigDetail.Namespace = models.Namespace{Name: namespace}
 	istioConfigDetail.ObjectType = resourceType
 
-	createOpts := meta_v1.CreateOptions{}
-	ctx := context.TODO()
-
 	var err error
 	switch resourceType {
 	case kubernetes.DestinationRules:
 		istioConfigDetail.DestinationRule = &networking_v1beta1.DestinationRule{}
 		err = json.Unmarshal(body, istioConfigDetail.DestinationRule)
-		if err != nil {
-			return istioConfigDetail, api_errors.NewBadRequest(err.Error())
-		}
-		istioConfigDetail.DestinationRule, err = in.userClients[cluster].Istio().NetworkingV1beta1().DestinationRules(namespace).Create(ctx, istioConfigDetail.DestinationRule, createOpts)
 	case kubernetes.EnvoyFilters:
 		istioConfigDetail.EnvoyFilter = &networking_v1alpha3.EnvoyFilter{}
 		err = json.Unmarshal(body, istioConfigDetail.EnvoyFilter)
-		if err != nil {
-			return istioConfigDetail, api_errors.NewBadRequest(err.Error())
-		}
-		istioConfigDetail.EnvoyFilter, err = in.userClients[cluster].Istio().NetworkingV1alpha3().EnvoyFilters(namespace).Create(ctx, istioConfigDetail.EnvoyFilter, createOpts)
 	case kubernetes.Gateways:
 		istioConfigDetail.Gateway = &networking_v1beta1.Gateway{}
 		err = json.Unmarshal(body, istioConfigDetail.Gateway)
-		if err != nil {
-			return istioConfigDetail, api_errors.NewBadRequest(err.Error())
-		}
-		istioConfigDetail.Gateway, err = in.userClients[cluster].Istio().NetworkingV1beta1().Gateways(namespace).Create(ctx, istioConfigDetail.Gateway, createOpts)
 	case kubernetes.K8sGateways:
 		istioConfigDetail.K8sGateway = &k8s_networking_v1beta1.Gateway{}
 		err = json.Unmarshal(body, istioConfigDetail.K8sGateway)
-		if err != nil {
-			return istioConfigDetail, api_errors.NewBadRequest(err.Error())
-		}
-		istioConfigDetail.K8sGateway, err = in.userClients[cluster].GatewayAPI().GatewayV1beta1().Gateways(namespace).Create(ctx, istioConfigDetail.K8sGateway, createOpts)
 	case kubernetes.K8sHTTPRoutes:
 		istioConfigDetail.K8sHTTPRoute = &k8s_networking_v1beta1.HTTPRoute{}
 		err = json.Unmarshal(body, istioConfigDetail.K8sHTTPRoute)
-		if err != nil {
-			return istioConfigDetail, api_errors.NewBadRequest(err.Error())
-		}
-		istioConfigDetail.K8sHTTPRoute, err = in.userClients[cluster].GatewayAPI().GatewayV1beta1().HTTPRoutes(namespace).Create(ctx, istioConfigDetail.K8sHTTPRoute, createOpts)
 	case kubernetes.ServiceEntries:
 		istioConfigDetail.ServiceEntry = &networking_v1beta1.ServiceEntry{}
 		err = json.Unmarshal(body, istioConfigDetail.ServiceEntry)
-		if err != nil {
-			return istioConfigDetail, api_errors.NewBadRequest(err.Error())
-		}
-		istioConfigDetail.ServiceEntry, err = in.userClients[cluster].Istio().NetworkingV1beta1().ServiceEntries(namespace).Create(ctx, istioConfigDetail.ServiceEntry, createOpts)
 	case kubernetes.Sidecars:
 		istioConfigDetail.Sidecar = &networking_v1beta1.Sidecar{}
 		err = json.Unmarshal(body, istioConfigDetail.Sidecar)
-		if err != nil {
-			return istioConfigDetail, api_errors.NewBadRequest(err.Error())
-		}
-		istioConfigDetail.Sidecar, err = in.userClients[cluster].Istio().NetworkingV1beta1().Sidecars(namespace).Create(ctx, istioConfigDetail.Sidecar, createOpts)
 	case kubernetes.VirtualServices:
 		istioConfigDetail.VirtualService = &networking_v1beta1.VirtualService{}
 		err = json.Unmarshal(body, istioConfigDetail.VirtualService)
-		if err != nil {
-			return istioConfigDetail, api_errors.NewBadRequest(err.Error())
-		}
-		istioConfigDetail.VirtualService, err = in.userClients[cluster].Istio().NetworkingV1beta1().VirtualServices(namespace).Create(ctx, istioConfigDetail.VirtualService, createOpts)
 	case kubernetes.WorkloadEntries:
 		istioConfigDetail.WorkloadEntry = &networking_v1beta1.WorkloadEntry{}
 		err = json.Unmarshal(body, istioConfigDetail.WorkloadEntry)
-		if err != nil {
-			return istioConfigDetail, api_errors.NewBadRequest(err.Error())
-		}
-		istioConfigDetail.WorkloadEntry, err = in.userClients[cluster].Istio().NetworkingV1beta1().WorkloadEntries(namespace).Create(ctx, istioConfigDetail.WorkloadEntry, createOpts)
 	case kubernetes.WorkloadGroups:
 		istioConfigDetail.WorkloadGroup = &networking_v1beta1.WorkloadGroup{}
 		err = json.Unmarshal(body, istioConfigDetail.WorkloadGroup)
-		if err != nil {
-			return istioConfigDetail, api_errors.NewBadRequest(err.Error())
-		}
-		istioConfigDetail.WorkloadGroup, err = in.userClients[cluster].Istio().NetworkingV1beta1().WorkloadGroups(namespace).Create(ctx, istioConfigDetail.WorkloadGroup, createOpts)
 	case kubernetes.WasmPlugins:
 		istioConfigDetail.WasmPlugin = &extentions_v1alpha1.WasmPlugin{}
 		err = json.Unmarshal(body, istioConfigDetail.WasmPlugin)
-		if err != nil {
-			return istioConfigDetail, api_errors.NewBadRequest(err.Error())
-		}
-		istioConfigDetail.WasmPlugin, err = in.userClients[cluster].Istio().ExtensionsV1alpha1().WasmPlugins(namespace).Create(ctx, istioConfigDetail.WasmPlugin, createOpts)
 	case kubernetes.Telemetries:
 		istioConfigDetail.Telemetry = &v1alpha1.Telemetry{}
 		err = json.Unmarshal(body, istioConfigDetail.Telemetry)
-		if err != nil {
-			return istioConfigDetail, api_errors.NewBadRequest(err.Error())
-		}
-		istioConfigDetail.Telemetry, err = in.userClients[cluster].Istio().TelemetryV1alpha1().Telemetries(namespace).Create(ctx, istioConfigDetail.Telemetry, createOpts)
 	case kubernetes.AuthorizationPolicies:
 		istioConfigDetail.AuthorizationPolicy = &security_v1beta1.AuthorizationPolicy{}
 		err = json.Unmarshal(body, istioConfigDetail.AuthorizationPolicy)
-		if err != nil {
-			return istioConfigDetail, api_errors.NewBadRequest(err.Error())
-		}
-		istioConfigDetail.AuthorizationPolicy, err = in.userClients[cluster].Istio().SecurityV1beta1().AuthorizationPolicies(namespace).Create(ctx, istioConfigDetail.AuthorizationPolicy, createOpts)
 	case kubernetes.PeerAuthentications:
 		istioConfigDetail.PeerAuthentication = &security_v1beta1.PeerAuthentication{}
 		err = json.Unmarshal(body, istioConfigDetail.PeerAuthentication)
-		if err != nil {
-			return istioConfigDetail, api_errors.NewBadRequest(err.Error())
-		}
-		istioConfigDetail.PeerAuthentication, err = in.userClients[cluster].Istio().SecurityV1beta1().PeerAuthentications(namespace).Create(ctx, istioConfigDetail.PeerAuthentication, createOpts)
 	case kubernetes.RequestAuthentications:
 		istioConfigDetail.RequestAuthentication = &security_v1beta1.RequestAuthentication{}
 		err = json.Unmarshal(body, istioConfigDetail.RequestAuthentication)
-		if err != nil {
-			return istioConfigDetail, api_errors.NewBadRequest(err.Error())
-		}
-		istioConfigDetail.RequestAuthentication, err = in.userClients[cluster].Istio().SecurityV1beta1().RequestAuthentications(namespace).Create(ctx, istioConfigDetail.RequestAuthentication, createOpts)
 	default:
-		err = fmt.Errorf("object type not found: %v", resourceType)
+		return istioConfigDetail, fmt.Errorf("object type not found: %v", resourceType)
+	}
+	if err != nil {
+		return istioConfigDetail, api_errors.NewBadRequest(err.Error())
+	}
+	return istioConfigDetail, nil
+}
+
+func (in *IstioConfigService) CreateIstioConfigDetail(cluster, namespace, resourceType string, body []byte) (models.IstioConfigDetails, error) {
+	istioConfigDetail, err := parseIstioConfigDetail(namespace, resourceType, body)
+	if err != nil {
+		return istioConfigDetail, err
+	}
+
+	createOpts := meta_v1.CreateOptions{}
+	ctx := context.TODO()
+
+	switch resourceType {
+	case kubernetes.DestinationRules:
+		istioConfigDetail.DestinationRule, err = in.userClients[cluster].Istio().NetworkingV1beta1().DestinationRules(namespace).Create(ctx, istioConfigDetail.DestinationRule, createOpts)
+	case kubernetes.EnvoyFilters:
+		istioConfigDetail.EnvoyFilter, err = in.userClients[cluster].Istio().NetworkingV1alpha3().EnvoyFilters(namespace).Create(ctx, istioConfigDetail.EnvoyFilter, createOpts)
+	case kubernetes.Gateways:
+		istioConfigDetail.Gateway, err = in.userClients[cluster].Istio().NetworkingV1beta1().Gateways(namespace).Create(ctx, istioConfigDetail.Gateway, createOpts)
+	case kubernetes.K8sGateways:
+		istioConfigDetail.K8sGateway, err = in.userClients[cluster].GatewayAPI().GatewayV1beta1().Gateways(namespace).Create(ctx, istioConfigDetail.K8sGateway, createOpts)
+	case kubernetes.K8sHTTPRoutes:
+		istioConfigDetail.K8sHTTPRoute, err = in.userClients[cluster].GatewayAPI().GatewayV1beta1().HTTPRoutes(namespace).Create(ctx, istioConfigDetail.K8sHTTPRoute, createOpts)
+	case kubernetes.ServiceEntries:
+		istioConfigDetail.ServiceEntry, err = in.userClients[cluster].Istio().NetworkingV1beta1().ServiceEntries(namespace).Create(ctx, istioConfigDetail.ServiceEntry, createOpts)
+	case kubernetes.Sidecars:
+		istioConfigDetail.Sidecar, err = in.userClients[cluster].Istio().NetworkingV1beta1().Sidecars(namespace).Create(ctx, istioConfigDetail.Sidecar, createOpts)
+	case kubernetes.VirtualServices:
+		istioConfigDetail.VirtualService, err = in.userClients[cluster].Istio().NetworkingV1beta1().VirtualServices(namespace).Create(ctx, istioConfigDetail.VirtualService, createOpts)
+	case kubernetes.WorkloadEntries:
+		istioConfigDetail.WorkloadEntry, err = in.userClients[cluster].Istio().NetworkingV1beta1().WorkloadEntries(namespace).Create(ctx, istioConfigDetail.WorkloadEntry, createOpts)
+	case kubernetes.WorkloadGroups:
+		istioConfigDetail.WorkloadGroup, err = in.userClients[cluster].Istio().NetworkingV1beta1().WorkloadGroups(namespace).Create(ctx, istioConfigDetail.WorkloadGroup, createOpts)
+	case kubernetes.WasmPlugins:
+		istioConfigDetail.WasmPlugin, err = in.userClients[cluster].Istio().ExtensionsV1alpha1().WasmPlugins(namespace).Create(ctx, istioConfigDetail.WasmPlugin, createOpts)
+	case kubernetes.Telemetries:
+		istioConfigDetail.Telemetry, err = in.userClients[cluster].Istio().TelemetryV1alpha1().Telemetries(namespace).Create(ctx, istioConfigDetail.Telemetry, createOpts)
+	case kubernetes.AuthorizationPolicies:
+		istioConfigDetail.AuthorizationPolicy, err = in.userClients[cluster].Istio().SecurityV1beta1().AuthorizationPolicies(namespace).Create(ctx, istioConfigDetail.AuthorizationPolicy, createOpts)
+	case kubernetes.PeerAuthentications:
+		istioConfigDetail.PeerAuthentication, err = in.userClients[cluster].Istio().SecurityV1beta1().PeerAuthentications(namespace).Create(ctx, istioConfigDetail.PeerAuthentication, createOpts)
+	case kubernetes.RequestAuthentications:
+		istioConfigDetail.RequestAuthentication, err = in.userClients[cluster].Istio().SecurityV1beta1().RequestAuthentications(namespace).Create(ctx, istioConfigDetail.RequestAuthentication, createOpts)
 	}
 	// Cache is stopped after a Create/Update/Delete operation to force a refresh
 	if kialiCache != nil && err == nil {
@@ -1291,7 +1834,7 @@ func (in *IstioConfigService) IsAmbientEnabled() bool {
 	return false
 }
 
-func (in *IstioConfigService) GetIstioConfigPermissions(ctx context.Context, namespaces []string, cluster string) models.IstioConfigPermissions {
+func (in *IstioConfigService) GetIstioConfigPermissions(ctx context.Context, namespaces []string, cluster string) (models.IstioConfigPermissions, error) {
 	var end observability.EndFunc
 	ctx, end = observability.StartSpan(ctx, "GetIstioConfigPermissions",
 		observability.Attribute("package", "business"),
@@ -1303,87 +1846,93 @@ func (in *IstioConfigService) GetIstioConfigPermissions(ctx context.Context, nam
 
 	k8s, ok := in.userClients[cluster]
 	if !ok {
-		log.Errorf("Cluster %s doesn't exist ", cluster)
-		return nil
+		return nil, fmt.Errorf("cluster %s doesn't exist", cluster)
 	}
 
-	if len(namespaces) > 0 {
-		networkingPermissions := make(models.IstioConfigPermissions, len(namespaces))
-		k8sNetworkingPermissions := make(models.IstioConfigPermissions, len(namespaces))
-		securityPermissions := make(models.IstioConfigPermissions, len(namespaces))
-
-		wg := sync.WaitGroup{}
-		// We will query 2 times per namespace (networking.istio.io and security.istio.io)
-		wg.Add(len(namespaces) * 3)
-		for _, ns := range namespaces {
-			networkingRP := make(models.ResourcesPermissions, len(newNetworkingConfigTypes))
-			k8sNetworkingRP := make(models.ResourcesPermissions, len(newK8sNetworkingConfigTypes))
-			securityRP := make(models.ResourcesPermissions, len(newSecurityConfigTypes))
-			networkingPermissions[ns] = &networkingRP
-			k8sNetworkingPermissions[ns] = &k8sNetworkingRP
-			securityPermissions[ns] = &securityRP
-			/*
-				We can optimize this logic.
-				Instead of query all editable objects of networking.istio.io and security.istio.io we can query
-				only one per API, that will save several queries to the backend.
-
-				Synced with:
-				https://github.com/kiali/kiali-operator/blob/master/roles/default/kiali-deploy/templates/kubernetes/role.yaml#L62
-			*/
-			go func(ctx context.Context, namespace string, wg *sync.WaitGroup, networkingPermissions *models.ResourcesPermissions) {
-				defer wg.Done()
-				canCreate, canUpdate, canDelete := getPermissionsApi(ctx, k8s, cluster, namespace, kubernetes.NetworkingGroupVersionV1Beta1.Group, allResources)
-				for _, rs := range newNetworkingConfigTypes {
-					networkingRP[rs] = &models.ResourcePermissions{
-						Create: canCreate,
-						Update: canUpdate,
-						Delete: canDelete,
-					}
-				}
-			}(ctx, ns, &wg, &networkingRP)
+	if len(namespaces) == 0 {
+		return istioConfigPermissions, nil
+	}
 
-			go func(ctx context.Context, namespace string, wg *sync.WaitGroup, k8sNetworkingPermissions *models.ResourcesPermissions) {
-				defer wg.Done()
-				canCreate, canUpdate, canDelete := getPermissionsApi(ctx, k8s, cluster, namespace, kubernetes.K8sNetworkingGroupVersionV1Beta1.Group, allResources)
-				for _, rs := range newK8sNetworkingConfigTypes {
-					k8sNetworkingRP[rs] = &models.ResourcePermissions{
-						Create: canCreate && in.userClients[cluster].IsGatewayAPI(),
-						Update: canUpdate && in.userClients[cluster].IsGatewayAPI(),
-						Delete: canDelete && in.userClients[cluster].IsGatewayAPI(),
-					}
-				}
-			}(ctx, ns, &wg, &k8sNetworkingRP)
+	// Every page load asks for the permissions again, a user browsing around gets them from the cache for a short time
+	if kialiCache != nil {
+		if cached, found := kialiCache.GetIstioConfigPermissions(kubernetes.UserCacheKey(k8s), cluster, namespaces); found {
+			return cached, nil
+		}
+	}
 
-			go func(ctx context.Context, namespace string, wg *sync.WaitGroup, securityPermissions *models.ResourcesPermissions) {
+	/*
+		We can optimize this logic.
+		Instead of query all editable objects of networking.istio.io and security.istio.io we can query
+		only one per API, that will save several queries to the backend.
+
+		Synced with:
+		https://github.com/kiali/kiali-operator/blob/master/roles/default/kiali-deploy/templates/kubernetes/role.yaml#L62
+	*/
+	isGatewayAPI := k8s.IsGatewayAPI()
+	apiGroups := []struct {
+		api     string
+		types   []string
+		enabled bool
+	}{
+		{api: kubernetes.NetworkingGroupVersionV1Beta1.Group, types: newNetworkingConfigTypes, enabled: true},
+		{api: kubernetes.K8sNetworkingGroupVersionV1Beta1.Group, types: newK8sNetworkingConfigTypes, enabled: isGatewayAPI},
+		{api: kubernetes.SecurityGroupVersion.Group, types: newSecurityConfigTypes, enabled: true},
+	}
+
+	for _, ns := range namespaces {
+		allRP := make(models.ResourcesPermissions, len(newNetworkingConfigTypes)+len(newSecurityConfigTypes)+len(newK8sNetworkingConfigTypes))
+		istioConfigPermissions[ns] = &allRP
+	}
+
+	// The permissions of the namespace set are cached with their own duration, reading the cached SelfSubjectAccessReviews
+	// to compute them would add up the staleness of both caches
+	fresh := kialiCache != nil && in.config.KubernetesConfig.CacheIstioConfigPermissionsDuration > 0
+
+	// Bound the number of concurrent SelfSubjectAccessReviews, a check per API group and namespace can be a lot of requests
+	concurrency := in.config.KubernetesConfig.PermissionsConcurrency
+	if concurrency <= 0 {
+		concurrency = len(namespaces) * len(apiGroups)
+	}
+	sem := make(chan struct{}, concurrency)
+
+	var mu sync.Mutex
+	failed := false
+	wg := sync.WaitGroup{}
+	wg.Add(len(namespaces) * len(apiGroups))
+	for _, ns := range namespaces {
+		for _, group := range apiGroups {
+			go func(namespace, api string, types []string, enabled bool) {
 				defer wg.Done()
-				canCreate, canUpdate, canDelete := getPermissionsApi(ctx, k8s, cluster, namespace, kubernetes.SecurityGroupVersion.Group, allResources)
-				for _, rs := range newSecurityConfigTypes {
-					securityRP[rs] = &models.ResourcePermissions{
-						Create: canCreate,
-						Update: canUpdate,
-						Delete: canDelete,
+				sem <- struct{}{}
+				canCreate, canUpdate, canDelete, err := getPermissionsApi(ctx, k8s, cluster, namespace, api, allResources, fresh)
+				<-sem
+
+				mu.Lock()
+				defer mu.Unlock()
+				// The types that failed keep their permissions denied, the error tells them apart from the ones without permissions
+				checkErr := ""
+				if err != nil {
+					log.Errorf("Error getting permissions [namespace: %s, api: %s]: %v", namespace, api, err)
+					checkErr = fmt.Sprintf("permission check failed [api: %s]: %v", api, err)
+					failed = true
+				}
+				for _, rs := range types {
+					(*istioConfigPermissions[namespace])[rs] = &models.ResourcePermissions{
+						Create: canCreate && enabled,
+						Update: canUpdate && enabled,
+						Delete: canDelete && enabled,
+						Error:  checkErr,
 					}
 				}
-			}(ctx, ns, &wg, &securityRP)
+			}(ns, group.api, group.types, group.enabled)
 		}
-		wg.Wait()
+	}
+	wg.Wait()
 
-		// Join networking and security permissions into a single result
-		for _, ns := range namespaces {
-			allRP := make(models.ResourcesPermissions, len(newNetworkingConfigTypes)+len(newSecurityConfigTypes)+len(newK8sNetworkingConfigTypes))
-			istioConfigPermissions[ns] = &allRP
-			for resource, permissions := range *networkingPermissions[ns] {
-				(*istioConfigPermissions[ns])[resource] = permissions
-			}
-			for resource, permissions := range *k8sNetworkingPermissions[ns] {
-				(*istioConfigPermissions[ns])[resource] = permissions
-			}
-			for resource, permissions := range *securityPermissions[ns] {
-				(*istioConfigPermissions[ns])[resource] = permissions
-			}
-		}
+	if kialiCache != nil && !failed {
+		kialiCache.SetIstioConfigPermissions(kubernetes.UserCacheKey(k8s), cluster, namespaces, istioConfigPermissions)
 	}
-	return istioConfigPermissions
+	return istioConfigPermissions, nil
 }
 
 func getPermissions(ctx context.Context, k8s kubernetes.ClientInterface, cluster string, namespace, objectType string) (bool, bool, bool) {
@@ -1391,19 +1940,32 @@ func getPermissions(ctx context.Context, k8s kubernetes.ClientInterface, cluster
 
 	if api, ok := kubernetes.ResourceTypesToAPI[objectType]; ok {
 		resourceType := objectType
-		return getPermissionsApi(ctx, k8s, cluster, namespace, api, resourceType)
+		canCreate, canPatch, canDelete, err := getPermissionsApi(ctx, k8s, cluster, namespace, api, resourceType, false)
+		if err != nil {
+			log.Errorf("Error getting permissions [namespace: %s, api: %s, resourceType: %s]: %v", namespace, api, resourceType, err)
+		}
+		return canCreate, canPatch, canDelete
 	}
 	return canCreate, canPatch, canDelete
 }
 
-func getPermissionsApi(ctx context.Context, k8s kubernetes.ClientInterface, cluster string, namespace, api, resourceType string) (bool, bool, bool) {
+// getPermissionsApi checks the permissions of the user on a resource type. The cached permissions are skipped when fresh
+// is set, the result is cached anyway.
+func getPermissionsApi(ctx context.Context, k8s kubernetes.ClientInterface, cluster string, namespace, api, resourceType string, fresh bool) (bool, bool, bool, error) {
 	var canCreate, canPatch, canDelete bool
 	conf := config.Get()
 
 	// In view only mode, there is not need to check RBAC permissions, return false early
 	if conf.Deployment.ViewOnlyMode {
 		log.Debug("View only mode configured, skipping RBAC checks")
-		return canCreate, canPatch, canDelete
+		return canCreate, canPatch, canDelete, nil
+	}
+
+	// Permissions rarely change while a user browses, the SelfSubjectAccessReviews are cached per user for a short time
+	if kialiCache != nil && !fresh {
+		if permissions, found := kialiCache.GetPermissions(kubernetes.UserCacheKey(k8s), cluster, namespace, api, resourceType); found {
+			return permissions.Create, permissions.Update, permissions.Delete, nil
+		}
 	}
 
 	/*
@@ -1415,23 +1977,182 @@ func getPermissionsApi(ctx context.Context, k8s kubernetes.ClientInterface, clus
 		https://github.com/kiali/kiali-operator/blob/master/roles/default/kiali-deploy/templates/kubernetes/role.yaml#L62
 	*/
 	ssars, permErr := k8s.GetSelfSubjectAccessReview(ctx, namespace, api, resourceType, []string{"create", "patch", "delete"})
-	if permErr == nil {
-		for _, ssar := range ssars {
-			if ssar.Spec.ResourceAttributes != nil {
-				switch ssar.Spec.ResourceAttributes.Verb {
-				case "create":
-					canCreate = ssar.Status.Allowed
-				case "patch":
-					canPatch = ssar.Status.Allowed
-				case "delete":
-					canDelete = ssar.Status.Allowed
+	if permErr != nil {
+		return canCreate, canPatch, canDelete, permErr
+	}
+	for _, ssar := range ssars {
+		if ssar.Spec.ResourceAttributes != nil {
+			switch ssar.Spec.ResourceAttributes.Verb {
+			case "create":
+				canCreate = ssar.Status.Allowed
+			case "patch":
+				canPatch = ssar.Status.Allowed
+			case "delete":
+				canDelete = ssar.Status.Allowed
+			}
+		}
+	}
+	if kialiCache != nil {
+		kialiCache.SetPermissions(kubernetes.UserCacheKey(k8s), cluster, namespace, api, resourceType, models.ResourcePermissions{Create: canCreate, Update: canPatch, Delete: canDelete})
+	}
+	return canCreate, canPatch, canDelete, nil
+}
+
+// GetOrphanedConfig returns the DestinationRules and VirtualServices of the namespace whose hosts don't resolve
+// to any Service, ServiceEntry or Istio registry service visible from the namespace. A VirtualService is only
+// orphaned when none of its route destinations resolve.
+func (in *IstioConfigService) GetOrphanedConfig(ctx context.Context, namespace string) (models.IstioConfigList, error) {
+	orphanedConfig := models.IstioConfigList{
+		Namespace:        models.Namespace{Name: namespace},
+		DestinationRules: []*networking_v1beta1.DestinationRule{},
+		VirtualServices:  []*networking_v1beta1.VirtualService{},
+	}
+
+	criteria := IstioConfigCriteria{
+		Namespace:               namespace,
+		IncludeDestinationRules: true,
+		IncludeVirtualServices:  true,
+	}
+	istioConfigList, err := in.GetIstioConfigList(ctx, criteria)
+	if err != nil {
+		return orphanedConfig, err
+	}
+
+	hostResolves, err := in.hostResolver(ctx, namespace)
+	if err != nil {
+		return orphanedConfig, err
+	}
+
+	// Every host is resolved once for all the DestinationRules pointing to it
+	resolvedHosts := make(map[string]bool)
+	for _, dr := range istioConfigList.DestinationRules {
+		host := dr.Spec.Host
+		if _, found := resolvedHosts[host]; found {
+			continue
+		}
+		resolvedHosts[host] = hostResolves(host)
+		if !resolvedHosts[host] {
+			orphanedConfig.DestinationRules = append(orphanedConfig.DestinationRules, kubernetes.FilterDestinationRulesByHostname(istioConfigList.DestinationRules, host)...)
+		}
+	}
+
+	for _, vs := range kubernetes.FilterAutogeneratedVirtualServices(istioConfigList.VirtualServices) {
+		hosts := virtualServiceDestinationHosts(vs)
+		orphaned := len(hosts) > 0
+		for _, host := range hosts {
+			if _, found := resolvedHosts[host]; !found {
+				resolvedHosts[host] = hostResolves(host)
+			}
+			if resolvedHosts[host] {
+				orphaned = false
+				break
+			}
+		}
+		if orphaned {
+			orphanedConfig.VirtualServices = append(orphanedConfig.VirtualServices, vs)
+		}
+	}
+
+	return orphanedConfig, nil
+}
+
+// visibleServiceEntries returns the ServiceEntries exported to a namespace
+func visibleServiceEntries(namespace string, serviceEntries []*networking_v1beta1.ServiceEntry) []*networking_v1beta1.ServiceEntry {
+	visible := []*networking_v1beta1.ServiceEntry{}
+	for _, se := range serviceEntries {
+		// no exportTo field, means object exported to all namespaces
+		exported := len(se.Spec.ExportTo) == 0
+		for _, exportToNs := range se.Spec.ExportTo {
+			exported = exported || checkExportTo(exportToNs, namespace, se.Namespace)
+		}
+		if exported {
+			visible = append(visible, se)
+		}
+	}
+	return visible
+}
+
+// hostResolver returns a func telling if a host, as written in Istio config of the namespace, resolves to a
+// ServiceEntry or a service visible from the namespace. The exportTo of ServiceEntries and of registry services
+// is respected. Kubernetes services are only looked up when the Istio registry is not available.
+func (in *IstioConfigService) hostResolver(ctx context.Context, namespace string) (func(string) bool, error) {
+	namespaces, err := in.businessLayer.Namespace.GetNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	namespaceNames := models.Namespaces(namespaces).GetNames()
+
+	serviceEntryList, err := in.GetIstioConfigList(ctx, IstioConfigCriteria{AllNamespaces: true, IncludeServiceEntries: true})
+	if err != nil {
+		return nil, err
+	}
+	serviceEntryHosts := kubernetes.ServiceEntryHostnames(visibleServiceEntries(namespace, serviceEntryList.ServiceEntries))
+
+	istioAPIEnabled := in.config.ExternalServices.Istio.IstioAPIEnabled
+	var registryServices []*kubernetes.RegistryService
+	if istioAPIEnabled {
+		registryServices, err = in.businessLayer.RegistryStatus.GetRegistryServices(RegistryCriteria{AllNamespaces: true})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return func(hostname string) bool {
+		host := kubernetes.GetHost(hostname, namespace, namespaceNames)
+		if host.IsWildcard() {
+			return true
+		}
+
+		if kubernetes.HasMatchingServiceEntries(host.String(), serviceEntryHosts) {
+			return true
+		}
+
+		if istioAPIEnabled {
+			return kubernetes.HasMatchingRegistryService(namespace, host.String(), registryServices)
+		}
+
+		// Covering 'servicename.namespace' host format scenario
+		localSvc, localNs := kubernetes.ParseTwoPartHost(host)
+		services, err := in.kialiCache.GetServices(localNs, nil)
+		if err != nil {
+			// Not knowing the services of the namespace is not a proof of the host being orphaned
+			log.Debugf("Unable to get the services of namespace [%s]: %s", localNs, err)
+			return true
+		}
+		return kubernetes.HasMatchingServices(localSvc, services)
+	}, nil
+}
+
+func virtualServiceDestinationHosts(vs *networking_v1beta1.VirtualService) []string {
+	hosts := []string{}
+	for _, httpRoute := range vs.Spec.Http {
+		if httpRoute != nil {
+			for _, dest := range httpRoute.Route {
+				if dest != nil && dest.Destination != nil {
+					hosts = append(hosts, dest.Destination.Host)
 				}
 			}
 		}
-	} else {
-		log.Errorf("Error getting permissions [namespace: %s, api: %s, resourceType: %s]: %v", namespace, api, "*", permErr)
 	}
-	return canCreate, canPatch, canDelete
+	for _, tcpRoute := range vs.Spec.Tcp {
+		if tcpRoute != nil {
+			for _, dest := range tcpRoute.Route {
+				if dest != nil && dest.Destination != nil {
+					hosts = append(hosts, dest.Destination.Host)
+				}
+			}
+		}
+	}
+	for _, tlsRoute := range vs.Spec.Tls {
+		if tlsRoute != nil {
+			for _, dest := range tlsRoute.Route {
+				if dest != nil && dest.Destination != nil {
+					hosts = append(hosts, dest.Destination.Host)
+				}
+			}
+		}
+	}
+	return hosts
 }
 
 func checkType(types []string, name string) bool {
@@ -1443,7 +2164,18 @@ func checkType(types []string, name string) bool {
 	return false
 }
 
-func ParseIstioConfigCriteria(cluster, namespace, objects, labelSelector, workloadSelector string, allNamespaces bool) IstioConfigCriteria {
+// ParseIstioConfigCriteria builds the criteria of a request listing Istio config. The objects are the comma separated types to list,
+// like "gateways,virtualservices". Without objects specified, the default types of the config (api.istio_config_types) apply,
+// all the types when they're not configured.
+func ParseIstioConfigCriteria(cluster, namespace, objects, labelSelector, annotationSelector, workloadSelector string, allNamespaces bool) IstioConfigCriteria {
+	if objects == "" {
+		objects = strings.Join(config.Get().API.IstioConfigTypes, ",")
+	}
+	return parseIstioConfigCriteria(cluster, namespace, objects, labelSelector, annotationSelector, workloadSelector, allNamespaces)
+}
+
+// parseIstioConfigCriteria builds the criteria listing the objects types, all the types when objects is empty
+func parseIstioConfigCriteria(cluster, namespace, objects, labelSelector, annotationSelector, workloadSelector string, allNamespaces bool) IstioConfigCriteria {
 	defaultInclude := objects == ""
 	criteria := IstioConfigCriteria{}
 	criteria.IncludeGateways = defaultInclude
@@ -1462,6 +2194,7 @@ func ParseIstioConfigCriteria(cluster, namespace, objects, labelSelector, worklo
 	criteria.IncludeWasmPlugins = defaultInclude
 	criteria.IncludeTelemetry = defaultInclude
 	criteria.LabelSelector = labelSelector
+	criteria.AnnotationSelector = annotationSelector
 	criteria.WorkloadSelector = workloadSelector
 
 	if cluster != "" {
@@ -0,0 +1,320 @@
+package business
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// ValidateBundle validates a multi-document YAML bundle of Istio objects before it is applied to a namespace.
+// The objects of the bundle replace the ones with the same type and name found in the home cluster, or are added to them,
+// and the checkers run on that proposed state. So the references between objects of the bundle are resolved,
+// like a VirtualService routing to a subset of a DestinationRule of the same bundle.
+// Documents without namespace are placed in the namespace, documents of other namespaces are rejected.
+// It returns the validations of the objects of the bundle. Objects without checkers are reported valid.
+func (in *IstioConfigService) ValidateBundle(ctx context.Context, namespace string, yamlBytes []byte) (models.IstioValidations, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "ValidateBundle",
+		observability.Attribute("package", "business"),
+		observability.Attribute("namespace", namespace),
+	)
+	defer end()
+
+	cluster := in.config.KubernetesConfig.ClusterName
+	bundle := models.IstioConfigList{Namespace: models.Namespace{Name: namespace, Cluster: cluster}}
+	keys := []models.IstioValidationKey{}
+	seen := map[models.IstioValidationKey]bool{}
+
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(yamlBytes)))
+	for index := 0; ; index++ {
+		document, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, api_errors.NewBadRequest(fmt.Sprintf("document %d: %s", index, err))
+		}
+		body, err := utilyaml.ToJSON(document)
+		if err != nil {
+			return nil, api_errors.NewBadRequest(fmt.Sprintf("document %d: %s", index, err))
+		}
+		// Empty documents or documents with only comments
+		if trimmed := bytes.TrimSpace(body); len(trimmed) == 0 || string(trimmed) == "null" {
+			continue
+		}
+
+		typeMeta := meta_v1.TypeMeta{}
+		if err := json.Unmarshal(body, &typeMeta); err != nil {
+			return nil, api_errors.NewBadRequest(fmt.Sprintf("document %d: %s", index, err))
+		}
+		resourceType, ok := bundleResourceType(typeMeta)
+		if !ok {
+			return nil, api_errors.NewBadRequest(fmt.Sprintf("document %d: kind %q of %q is not a supported Istio object", index, typeMeta.Kind, typeMeta.APIVersion))
+		}
+		detail, err := parseIstioConfigDetail(namespace, resourceType, body)
+		if err != nil {
+			return nil, api_errors.NewBadRequest(fmt.Sprintf("document %d: %s", index, err))
+		}
+
+		object := addBundleObject(&bundle, detail)
+		if object.GetName() == "" {
+			return nil, api_errors.NewBadRequest(fmt.Sprintf("document %d: %s without name", index, typeMeta.Kind))
+		}
+		if object.GetNamespace() == "" {
+			object.SetNamespace(namespace)
+		} else if object.GetNamespace() != namespace {
+			return nil, api_errors.NewBadRequest(fmt.Sprintf("document %d: %s %s belongs to namespace %s, not %s", index, typeMeta.Kind, object.GetName(), object.GetNamespace(), namespace))
+		}
+
+		objectType := models.ObjectTypeSingular[resourceType]
+		if objectType == "" {
+			objectType = resourceType
+		}
+		key := models.IstioValidationKey{ObjectType: objectType, Name: object.GetName(), Namespace: namespace, Cluster: cluster}
+		if seen[key] {
+			return nil, api_errors.NewBadRequest(fmt.Sprintf("document %d: %s %s is defined more than once", index, typeMeta.Kind, object.GetName()))
+		}
+		seen[key] = true
+		keys = append(keys, key)
+	}
+
+	validations, err := in.businessLayer.Validations.getValidations(ctx, cluster, namespace, "", "", &bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	bundleValidations := models.IstioValidations{}
+	for _, key := range keys {
+		if validation, ok := validations[key]; ok {
+			bundleValidations[key] = validation
+			continue
+		}
+		bundleValidations[key] = &models.IstioValidation{
+			Name:       key.Name,
+			ObjectType: key.ObjectType,
+			Valid:      true,
+			Checks:     []*models.IstioCheck{},
+			References: []models.IstioValidationKey{},
+		}
+	}
+	return bundleValidations, nil
+}
+
+// bundleResourceType returns the Istio config type of a document, like "virtualservices".
+// The API group is checked too, Istio and Gateway API objects share kinds.
+func bundleResourceType(typeMeta meta_v1.TypeMeta) (string, bool) {
+	group := typeMeta.GroupVersionKind().Group
+	for resourceType, kind := range kubernetes.PluralType {
+		switch resourceType {
+		case kubernetes.K8sGateways:
+			kind = kubernetes.K8sActualGatewayType
+		case kubernetes.K8sHTTPRoutes:
+			kind = kubernetes.K8sActualHTTPRouteType
+		}
+		if kind == typeMeta.Kind && kubernetes.ResourceTypesToAPI[resourceType] == group {
+			return resourceType, true
+		}
+	}
+	return "", false
+}
+
+// addBundleObject appends the object held by the details to the list and returns it
+func addBundleObject(bundle *models.IstioConfigList, detail models.IstioConfigDetails) meta_v1.Object {
+	switch {
+	case detail.AuthorizationPolicy != nil:
+		bundle.AuthorizationPolicies = append(bundle.AuthorizationPolicies, detail.AuthorizationPolicy)
+		return detail.AuthorizationPolicy
+	case detail.DestinationRule != nil:
+		bundle.DestinationRules = append(bundle.DestinationRules, detail.DestinationRule)
+		return detail.DestinationRule
+	case detail.EnvoyFilter != nil:
+		bundle.EnvoyFilters = append(bundle.EnvoyFilters, detail.EnvoyFilter)
+		return detail.EnvoyFilter
+	case detail.Gateway != nil:
+		bundle.Gateways = append(bundle.Gateways, detail.Gateway)
+		return detail.Gateway
+	case detail.K8sGateway != nil:
+		bundle.K8sGateways = append(bundle.K8sGateways, detail.K8sGateway)
+		return detail.K8sGateway
+	case detail.K8sHTTPRoute != nil:
+		bundle.K8sHTTPRoutes = append(bundle.K8sHTTPRoutes, detail.K8sHTTPRoute)
+		return detail.K8sHTTPRoute
+	case detail.PeerAuthentication != nil:
+		bundle.PeerAuthentications = append(bundle.PeerAuthentications, detail.PeerAuthentication)
+		return detail.PeerAuthentication
+	case detail.RequestAuthentication != nil:
+		bundle.RequestAuthentications = append(bundle.RequestAuthentications, detail.RequestAuthentication)
+		return detail.RequestAuthentication
+	case detail.ServiceEntry != nil:
+		bundle.ServiceEntries = append(bundle.ServiceEntries, detail.ServiceEntry)
+		return detail.ServiceEntry
+	case detail.Sidecar != nil:
+		bundle.Sidecars = append(bundle.Sidecars, detail.Sidecar)
+		return detail.Sidecar
+	case detail.VirtualService != nil:
+		bundle.VirtualServices = append(bundle.VirtualServices, detail.VirtualService)
+		return detail.VirtualService
+	case detail.WorkloadEntry != nil:
+		bundle.WorkloadEntries = append(bundle.WorkloadEntries, detail.WorkloadEntry)
+		return detail.WorkloadEntry
+	case detail.WorkloadGroup != nil:
+		bundle.WorkloadGroups = append(bundle.WorkloadGroups, detail.WorkloadGroup)
+		return detail.WorkloadGroup
+	case detail.WasmPlugin != nil:
+		bundle.WasmPlugins = append(bundle.WasmPlugins, detail.WasmPlugin)
+		return detail.WasmPlugin
+	default:
+		bundle.Telemetries = append(bundle.Telemetries, detail.Telemetry)
+		return detail.Telemetry
+	}
+}
+
+// overlayIstioConfigList replaces the objects of the list by the proposed ones with the same type, namespace and name,
+// and adds the others. Only the types used by the checkers are handled.
+func overlayIstioConfigList(list, proposed models.IstioConfigList) models.IstioConfigList {
+	proposedKeys := map[string]bool{}
+	addKey := func(objectType string, object meta_v1.Object) {
+		proposedKeys[objectType+"/"+object.GetNamespace()+"/"+object.GetName()] = true
+	}
+	replaced := func(objectType string, object meta_v1.Object) bool {
+		return proposedKeys[objectType+"/"+object.GetNamespace()+"/"+object.GetName()]
+	}
+
+	for _, o := range proposed.AuthorizationPolicies {
+		addKey(kubernetes.AuthorizationPolicies, o)
+	}
+	for _, o := range proposed.DestinationRules {
+		addKey(kubernetes.DestinationRules, o)
+	}
+	for _, o := range proposed.Gateways {
+		addKey(kubernetes.Gateways, o)
+	}
+	for _, o := range proposed.K8sGateways {
+		addKey(kubernetes.K8sGateways, o)
+	}
+	for _, o := range proposed.K8sHTTPRoutes {
+		addKey(kubernetes.K8sHTTPRoutes, o)
+	}
+	for _, o := range proposed.PeerAuthentications {
+		addKey(kubernetes.PeerAuthentications, o)
+	}
+	for _, o := range proposed.RequestAuthentications {
+		addKey(kubernetes.RequestAuthentications, o)
+	}
+	for _, o := range proposed.ServiceEntries {
+		addKey(kubernetes.ServiceEntries, o)
+	}
+	for _, o := range proposed.Sidecars {
+		addKey(kubernetes.Sidecars, o)
+	}
+	for _, o := range proposed.VirtualServices {
+		addKey(kubernetes.VirtualServices, o)
+	}
+	for _, o := range proposed.WorkloadEntries {
+		addKey(kubernetes.WorkloadEntries, o)
+	}
+
+	authorizationPolicies := []*security_v1beta1.AuthorizationPolicy{}
+	for _, o := range list.AuthorizationPolicies {
+		if !replaced(kubernetes.AuthorizationPolicies, o) {
+			authorizationPolicies = append(authorizationPolicies, o)
+		}
+	}
+	list.AuthorizationPolicies = append(authorizationPolicies, proposed.AuthorizationPolicies...)
+
+	destinationRules := []*networking_v1beta1.DestinationRule{}
+	for _, o := range list.DestinationRules {
+		if !replaced(kubernetes.DestinationRules, o) {
+			destinationRules = append(destinationRules, o)
+		}
+	}
+	list.DestinationRules = append(destinationRules, proposed.DestinationRules...)
+
+	gateways := []*networking_v1beta1.Gateway{}
+	for _, o := range list.Gateways {
+		if !replaced(kubernetes.Gateways, o) {
+			gateways = append(gateways, o)
+		}
+	}
+	list.Gateways = append(gateways, proposed.Gateways...)
+
+	k8sGateways := []*k8s_networking_v1beta1.Gateway{}
+	for _, o := range list.K8sGateways {
+		if !replaced(kubernetes.K8sGateways, o) {
+			k8sGateways = append(k8sGateways, o)
+		}
+	}
+	list.K8sGateways = append(k8sGateways, proposed.K8sGateways...)
+
+	k8sHTTPRoutes := []*k8s_networking_v1beta1.HTTPRoute{}
+	for _, o := range list.K8sHTTPRoutes {
+		if !replaced(kubernetes.K8sHTTPRoutes, o) {
+			k8sHTTPRoutes = append(k8sHTTPRoutes, o)
+		}
+	}
+	list.K8sHTTPRoutes = append(k8sHTTPRoutes, proposed.K8sHTTPRoutes...)
+
+	peerAuthentications := []*security_v1beta1.PeerAuthentication{}
+	for _, o := range list.PeerAuthentications {
+		if !replaced(kubernetes.PeerAuthentications, o) {
+			peerAuthentications = append(peerAuthentications, o)
+		}
+	}
+	list.PeerAuthentications = append(peerAuthentications, proposed.PeerAuthentications...)
+
+	requestAuthentications := []*security_v1beta1.RequestAuthentication{}
+	for _, o := range list.RequestAuthentications {
+		if !replaced(kubernetes.RequestAuthentications, o) {
+			requestAuthentications = append(requestAuthentications, o)
+		}
+	}
+	list.RequestAuthentications = append(requestAuthentications, proposed.RequestAuthentications...)
+
+	serviceEntries := []*networking_v1beta1.ServiceEntry{}
+	for _, o := range list.ServiceEntries {
+		if !replaced(kubernetes.ServiceEntries, o) {
+			serviceEntries = append(serviceEntries, o)
+		}
+	}
+	list.ServiceEntries = append(serviceEntries, proposed.ServiceEntries...)
+
+	sidecars := []*networking_v1beta1.Sidecar{}
+	for _, o := range list.Sidecars {
+		if !replaced(kubernetes.Sidecars, o) {
+			sidecars = append(sidecars, o)
+		}
+	}
+	list.Sidecars = append(sidecars, proposed.Sidecars...)
+
+	virtualServices := []*networking_v1beta1.VirtualService{}
+	for _, o := range list.VirtualServices {
+		if !replaced(kubernetes.VirtualServices, o) {
+			virtualServices = append(virtualServices, o)
+		}
+	}
+	list.VirtualServices = append(virtualServices, proposed.VirtualServices...)
+
+	workloadEntries := []*networking_v1beta1.WorkloadEntry{}
+	for _, o := range list.WorkloadEntries {
+		if !replaced(kubernetes.WorkloadEntries, o) {
+			workloadEntries = append(workloadEntries, o)
+		}
+	}
+	list.WorkloadEntries = append(workloadEntries, proposed.WorkloadEntries...)
+
+	return list
+}
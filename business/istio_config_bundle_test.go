@@ -0,0 +1,227 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func mockValidateBundle(t *testing.T) IstioConfigService {
+	t.Helper()
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	reviewsLabels := map[string]string{"app": "reviews", "version": "v1"}
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.ConfigMap{ObjectMeta: meta_v1.ObjectMeta{Name: "istio", Namespace: "istio-system"}},
+		&core_v1.Service{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo", Labels: map[string]string{"app": "reviews"}},
+			Spec:       core_v1.ServiceSpec{Selector: map[string]string{"app": "reviews"}},
+		},
+		&apps_v1.Deployment{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "reviews-v1", Namespace: "bookinfo", Labels: reviewsLabels},
+			Spec: apps_v1.DeploymentSpec{
+				Selector: &meta_v1.LabelSelector{MatchLabels: reviewsLabels},
+				Template: core_v1.PodTemplateSpec{ObjectMeta: meta_v1.ObjectMeta{Labels: reviewsLabels}},
+			},
+		},
+	)
+	cache := SetupBusinessLayer(t, k8s, *conf)
+
+	reviews := &kubernetes.RegistryService{}
+	reviews.Hostname = "reviews.bookinfo.svc.cluster.local"
+	reviews.Attributes.Name = "reviews"
+	reviews.Attributes.Namespace = "bookinfo"
+	reviews.Attributes.LabelSelectors = map[string]string{"app": "reviews"}
+	cache.SetRegistryStatus(&kubernetes.RegistryStatus{
+		Configuration: &kubernetes.RegistryConfiguration{
+			// Replaced by the bundle
+			DestinationRules: []*networking_v1beta1.DestinationRule{data.CreateEmptyDestinationRule("bookinfo", "reviews", "reviews")},
+		},
+		Services: []*kubernetes.RegistryService{reviews},
+	})
+
+	k8sclients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	return NewWithBackends(k8sclients, k8sclients, nil, nil).IstioConfig
+}
+
+func bundleValidation(validations models.IstioValidations, objectType, name string) *models.IstioValidation {
+	for key, validation := range validations {
+		if key.ObjectType == objectType && key.Name == name {
+			return validation
+		}
+	}
+	return nil
+}
+
+func bundleCheckCodes(validation *models.IstioValidation) []string {
+	codes := []string{}
+	for _, check := range validation.Checks {
+		codes = append(codes, check.Code)
+	}
+	return codes
+}
+
+func TestValidateBundle(t *testing.T) {
+	require := require.New(t)
+	configService := mockValidateBundle(t)
+
+	bundle := `
+apiVersion: networking.istio.io/v1beta1
+kind: VirtualService
+metadata:
+  name: reviews
+spec:
+  hosts:
+  - reviews
+  http:
+  - route:
+    - destination:
+        host: reviews
+        subset: v1
+---
+# The subset referenced by the VirtualService
+apiVersion: networking.istio.io/v1beta1
+kind: DestinationRule
+metadata:
+  name: reviews
+  namespace: bookinfo
+spec:
+  host: reviews
+  subsets:
+  - name: v1
+    labels:
+      version: v1
+---
+apiVersion: telemetry.istio.io/v1alpha1
+kind: Telemetry
+metadata:
+  name: reviews-tracing
+`
+	validations, err := configService.ValidateBundle(context.TODO(), "bookinfo", []byte(bundle))
+	require.NoError(err)
+	require.Len(validations, 3)
+
+	vs := bundleValidation(validations, "virtualservice", "reviews")
+	require.NotNil(vs)
+	require.True(vs.Valid)
+	require.NotContains(bundleCheckCodes(vs), "KIA1107")
+
+	dr := bundleValidation(validations, "destinationrule", "reviews")
+	require.NotNil(dr)
+	require.True(dr.Valid)
+	require.Empty(dr.Checks)
+
+	// No checker for Telemetries
+	tm := bundleValidation(validations, "telemetry", "reviews-tracing")
+	require.NotNil(tm)
+	require.True(tm.Valid)
+}
+
+func TestValidateBundleBroken(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	configService := mockValidateBundle(t)
+
+	bundle := `
+apiVersion: networking.istio.io/v1beta1
+kind: VirtualService
+metadata:
+  name: reviews
+spec:
+  hosts:
+  - reviews
+  http:
+  - route:
+    - destination:
+        host: reviews
+        subset: v2
+      weight: 50
+    - destination:
+        host: reviews
+        subset: v3
+      weight: 50
+---
+apiVersion: networking.istio.io/v1beta1
+kind: DestinationRule
+metadata:
+  name: reviews
+spec:
+  host: reviews
+  subsets:
+  - name: v3
+    labels:
+      version: v3
+`
+	validations, err := configService.ValidateBundle(context.TODO(), "bookinfo", []byte(bundle))
+	require.NoError(err)
+
+	vs := bundleValidation(validations, "virtualservice", "reviews")
+	require.NotNil(vs)
+	assert.Contains(bundleCheckCodes(vs), "KIA1107")
+
+	// The subset is referenced by the VirtualService but no workload has its labels
+	dr := bundleValidation(validations, "destinationrule", "reviews")
+	require.NotNil(dr)
+	assert.False(dr.Valid)
+	assert.Contains(bundleCheckCodes(dr), "KIA0203")
+
+	invalidBundles := map[string]string{
+		"Unknown kind": `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: reviews
+`,
+		"Other namespace": `
+apiVersion: networking.istio.io/v1beta1
+kind: DestinationRule
+metadata:
+  name: reviews
+  namespace: other
+spec:
+  host: reviews
+`,
+		"Duplicated object": `
+apiVersion: networking.istio.io/v1beta1
+kind: DestinationRule
+metadata:
+  name: reviews
+spec:
+  host: reviews
+---
+apiVersion: networking.istio.io/v1beta1
+kind: DestinationRule
+metadata:
+  name: reviews
+spec:
+  host: reviews.bookinfo.svc.cluster.local
+`,
+		"Malformed spec": `
+apiVersion: networking.istio.io/v1beta1
+kind: DestinationRule
+metadata:
+  name: reviews
+spec:
+  host: [reviews]
+`,
+	}
+	for name, invalidBundle := range invalidBundles {
+		_, err := configService.ValidateBundle(context.TODO(), "bookinfo", []byte(invalidBundle))
+		assert.True(api_errors.IsBadRequest(err), name)
+	}
+}
@@ -0,0 +1,232 @@
+package business
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// GetIstioConfigByApp returns the Istio config of a namespace grouped by the application it applies to.
+// Objects with a workload selector are assigned to the apps of the workloads they select, VirtualServices,
+// DestinationRules and HTTPRoutes to the apps of the services they target.
+// Objects without a selector, the ones not matching any app and the types that can't be scoped to a workload
+// (ServiceEntries, WorkloadEntries, WorkloadGroups, WasmPlugins, Telemetries and K8s Gateways) are returned as unscoped.
+// An object can belong to several apps.
+func (in *IstioConfigService) GetIstioConfigByApp(ctx context.Context, cluster, namespace string) (models.IstioConfigByApp, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetIstioConfigByApp",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+	)
+	defer end()
+
+	istioConfigList, err := in.GetIstioConfigList(ctx, parseIstioConfigCriteria(cluster, namespace, "", "", "", "", false))
+	if err != nil {
+		return models.IstioConfigByApp{}, err
+	}
+
+	workloads, err := in.businessLayer.Workload.fetchWorkloadsFromCluster(ctx, cluster, namespace, "")
+	if err != nil {
+		return models.IstioConfigByApp{}, err
+	}
+
+	kubeCache, err := in.kialiCache.GetKubeCache(cluster)
+	if err != nil {
+		return models.IstioConfigByApp{}, err
+	}
+	services, err := kubeCache.GetServices(namespace, nil)
+	if err != nil {
+		return models.IstioConfigByApp{}, err
+	}
+
+	appLabel := in.config.IstioLabels.AppLabelName
+	byApp := models.IstioConfigByApp{
+		Apps:     map[string]models.IstioConfigList{},
+		Unscoped: models.IstioConfigList{Namespace: istioConfigList.Namespace},
+	}
+
+	// Keys are "<type>/<name>" for the assigned objects and "<app>/<type>/<name>" for the objects already in an app
+	assigned := map[string]bool{}
+	added := map[string]bool{}
+	assign := func(app, objectType, name string) bool {
+		key := objectType + "/" + name
+		assigned[key] = true
+		if added[app+"/"+key] {
+			return false
+		}
+		added[app+"/"+key] = true
+		return true
+	}
+
+	// Namespace-wide objects are filtered out first, the selector filters would match them with every workload
+	selectedAPs := istioConfigList.AuthorizationPolicies[:0:0]
+	for _, ap := range istioConfigList.AuthorizationPolicies {
+		if ap.Spec.Selector != nil && len(ap.Spec.Selector.MatchLabels) > 0 {
+			selectedAPs = append(selectedAPs, ap)
+		}
+	}
+	selectedPAs := istioConfigList.PeerAuthentications[:0:0]
+	for _, pa := range istioConfigList.PeerAuthentications {
+		if pa.Spec.Selector != nil && len(pa.Spec.Selector.MatchLabels) > 0 {
+			selectedPAs = append(selectedPAs, pa)
+		}
+	}
+	selectedRAs := istioConfigList.RequestAuthentications[:0:0]
+	for _, ra := range istioConfigList.RequestAuthentications {
+		if ra.Spec.Selector != nil && len(ra.Spec.Selector.MatchLabels) > 0 {
+			selectedRAs = append(selectedRAs, ra)
+		}
+	}
+	selectedSidecars := istioConfigList.Sidecars[:0:0]
+	for _, sc := range istioConfigList.Sidecars {
+		if sc.Spec.WorkloadSelector != nil && len(sc.Spec.WorkloadSelector.Labels) > 0 {
+			selectedSidecars = append(selectedSidecars, sc)
+		}
+	}
+	selectedEFs := istioConfigList.EnvoyFilters[:0:0]
+	for _, ef := range istioConfigList.EnvoyFilters {
+		if ef.Spec.WorkloadSelector != nil && len(ef.Spec.WorkloadSelector.Labels) > 0 {
+			selectedEFs = append(selectedEFs, ef)
+		}
+	}
+	selectedGWs := istioConfigList.Gateways[:0:0]
+	for _, gw := range istioConfigList.Gateways {
+		if len(gw.Spec.Selector) > 0 {
+			selectedGWs = append(selectedGWs, gw)
+		}
+	}
+
+	for _, wk := range workloads {
+		app, ok := wk.Labels[appLabel]
+		if !ok || app == "" {
+			continue
+		}
+		appConfig, ok := byApp.Apps[app]
+		if !ok {
+			appConfig = models.IstioConfigList{Namespace: istioConfigList.Namespace}
+		}
+		workloadSelector := labels.Set(wk.Labels).String()
+		for _, ap := range kubernetes.FilterAuthorizationPoliciesBySelector(workloadSelector, selectedAPs) {
+			if assign(app, kubernetes.AuthorizationPolicies, ap.Name) {
+				appConfig.AuthorizationPolicies = append(appConfig.AuthorizationPolicies, ap)
+			}
+		}
+		for _, pa := range kubernetes.FilterPeerAuthenticationsBySelector(workloadSelector, selectedPAs) {
+			if assign(app, kubernetes.PeerAuthentications, pa.Name) {
+				appConfig.PeerAuthentications = append(appConfig.PeerAuthentications, pa)
+			}
+		}
+		for _, ra := range kubernetes.FilterRequestAuthenticationsBySelector(workloadSelector, selectedRAs) {
+			if assign(app, kubernetes.RequestAuthentications, ra.Name) {
+				appConfig.RequestAuthentications = append(appConfig.RequestAuthentications, ra)
+			}
+		}
+		for _, sc := range kubernetes.FilterSidecarsBySelector(workloadSelector, selectedSidecars) {
+			if assign(app, kubernetes.Sidecars, sc.Name) {
+				appConfig.Sidecars = append(appConfig.Sidecars, sc)
+			}
+		}
+		for _, ef := range kubernetes.FilterEnvoyFiltersBySelector(workloadSelector, selectedEFs) {
+			if assign(app, kubernetes.EnvoyFilters, ef.Name) {
+				appConfig.EnvoyFilters = append(appConfig.EnvoyFilters, ef)
+			}
+		}
+		for _, gw := range kubernetes.FilterGatewaysBySelector(workloadSelector, selectedGWs) {
+			if assign(app, kubernetes.Gateways, gw.Name) {
+				appConfig.Gateways = append(appConfig.Gateways, gw)
+			}
+		}
+		byApp.Apps[app] = appConfig
+	}
+
+	for _, svc := range services {
+		app := svc.Labels[appLabel]
+		if app == "" {
+			app = svc.Spec.Selector[appLabel]
+		}
+		if app == "" {
+			continue
+		}
+		appConfig, ok := byApp.Apps[app]
+		if !ok {
+			appConfig = models.IstioConfigList{Namespace: istioConfigList.Namespace}
+		}
+		for _, vs := range kubernetes.FilterVirtualServicesByService(istioConfigList.VirtualServices, namespace, svc.Name) {
+			if assign(app, kubernetes.VirtualServices, vs.Name) {
+				appConfig.VirtualServices = append(appConfig.VirtualServices, vs)
+			}
+		}
+		for _, dr := range kubernetes.FilterDestinationRulesByService(istioConfigList.DestinationRules, namespace, svc.Name) {
+			if assign(app, kubernetes.DestinationRules, dr.Name) {
+				appConfig.DestinationRules = append(appConfig.DestinationRules, dr)
+			}
+		}
+		for _, route := range kubernetes.FilterK8sHTTPRoutesByService(istioConfigList.K8sHTTPRoutes, namespace, svc.Name) {
+			if assign(app, kubernetes.K8sHTTPRoutes, route.Name) {
+				appConfig.K8sHTTPRoutes = append(appConfig.K8sHTTPRoutes, route)
+			}
+		}
+		byApp.Apps[app] = appConfig
+	}
+
+	unscoped := &byApp.Unscoped
+	for _, ap := range istioConfigList.AuthorizationPolicies {
+		if !assigned[kubernetes.AuthorizationPolicies+"/"+ap.Name] {
+			unscoped.AuthorizationPolicies = append(unscoped.AuthorizationPolicies, ap)
+		}
+	}
+	for _, pa := range istioConfigList.PeerAuthentications {
+		if !assigned[kubernetes.PeerAuthentications+"/"+pa.Name] {
+			unscoped.PeerAuthentications = append(unscoped.PeerAuthentications, pa)
+		}
+	}
+	for _, ra := range istioConfigList.RequestAuthentications {
+		if !assigned[kubernetes.RequestAuthentications+"/"+ra.Name] {
+			unscoped.RequestAuthentications = append(unscoped.RequestAuthentications, ra)
+		}
+	}
+	for _, sc := range istioConfigList.Sidecars {
+		if !assigned[kubernetes.Sidecars+"/"+sc.Name] {
+			unscoped.Sidecars = append(unscoped.Sidecars, sc)
+		}
+	}
+	for _, ef := range istioConfigList.EnvoyFilters {
+		if !assigned[kubernetes.EnvoyFilters+"/"+ef.Name] {
+			unscoped.EnvoyFilters = append(unscoped.EnvoyFilters, ef)
+		}
+	}
+	for _, gw := range istioConfigList.Gateways {
+		if !assigned[kubernetes.Gateways+"/"+gw.Name] {
+			unscoped.Gateways = append(unscoped.Gateways, gw)
+		}
+	}
+	for _, vs := range istioConfigList.VirtualServices {
+		if !assigned[kubernetes.VirtualServices+"/"+vs.Name] {
+			unscoped.VirtualServices = append(unscoped.VirtualServices, vs)
+		}
+	}
+	for _, dr := range istioConfigList.DestinationRules {
+		if !assigned[kubernetes.DestinationRules+"/"+dr.Name] {
+			unscoped.DestinationRules = append(unscoped.DestinationRules, dr)
+		}
+	}
+	for _, route := range istioConfigList.K8sHTTPRoutes {
+		if !assigned[kubernetes.K8sHTTPRoutes+"/"+route.Name] {
+			unscoped.K8sHTTPRoutes = append(unscoped.K8sHTTPRoutes, route)
+		}
+	}
+	unscoped.ServiceEntries = istioConfigList.ServiceEntries
+	unscoped.WorkloadEntries = istioConfigList.WorkloadEntries
+	unscoped.WorkloadGroups = istioConfigList.WorkloadGroups
+	unscoped.WasmPlugins = istioConfigList.WasmPlugins
+	unscoped.Telemetries = istioConfigList.Telemetries
+	unscoped.K8sGateways = istioConfigList.K8sGateways
+	unscoped.TimedOut = istioConfigList.TimedOut
+
+	return byApp, nil
+}
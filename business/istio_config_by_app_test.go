@@ -0,0 +1,82 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	api_security_v1beta1 "istio.io/api/security/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+)
+
+// fakeRoutingVirtualService routes all the traffic of the host to the same host
+func fakeRoutingVirtualService(name, namespace, host string) *networking_v1beta1.VirtualService {
+	vs := &networking_v1beta1.VirtualService{}
+	vs.Name = name
+	vs.Namespace = namespace
+	vs.Spec.Hosts = []string{host}
+	vs.Spec.Http = []*api_networking_v1beta1.HTTPRoute{{
+		Route: []*api_networking_v1beta1.HTTPRouteDestination{{Destination: &api_networking_v1beta1.Destination{Host: host}}},
+	}}
+	return vs
+}
+
+func TestGetIstioConfigByApp(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "Namespace"}},
+		fakeComponentDeployment("reviews-v1", map[string]string{"app": "reviews", "version": "v1"}),
+		fakeComponentDeployment("reviews-v2", map[string]string{"app": "reviews", "version": "v2"}),
+		fakeComponentDeployment("ratings-v1", map[string]string{"app": "ratings", "version": "v1"}),
+		&core_v1.Service{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "Namespace", Labels: map[string]string{"app": "reviews"}},
+			Spec:       core_v1.ServiceSpec{Selector: map[string]string{"app": "reviews"}},
+		},
+		fakeAuthorizationPolicy("reviews-allow", "Namespace", api_security_v1beta1.AuthorizationPolicy_ALLOW, map[string]string{"app": "reviews"}),
+		fakeAuthorizationPolicy("namespace-deny", "Namespace", api_security_v1beta1.AuthorizationPolicy_DENY, nil),
+		fakeAuthorizationPolicy("details-allow", "Namespace", api_security_v1beta1.AuthorizationPolicy_ALLOW, map[string]string{"app": "details"}),
+		fakeRoutingVirtualService("reviews-vs", "Namespace", "reviews.Namespace.svc.cluster.local"),
+		fakeRoutingVirtualService("external-vs", "Namespace", "www.example.com"),
+	)
+	SetupBusinessLayer(t, k8s, *conf)
+
+	k8sclients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	configService := NewWithBackends(k8sclients, k8sclients, nil, nil).IstioConfig
+
+	byApp, err := configService.GetIstioConfigByApp(context.TODO(), conf.KubernetesConfig.ClusterName, "Namespace")
+	require.NoError(err)
+
+	require.Contains(byApp.Apps, "reviews")
+	reviews := byApp.Apps["reviews"]
+	// Selected by both versions, reported once
+	require.Len(reviews.AuthorizationPolicies, 1)
+	assert.Equal("reviews-allow", reviews.AuthorizationPolicies[0].Name)
+	require.Len(reviews.VirtualServices, 1)
+	assert.Equal("reviews-vs", reviews.VirtualServices[0].Name)
+
+	require.Contains(byApp.Apps, "ratings")
+	assert.Empty(byApp.Apps["ratings"].AuthorizationPolicies)
+	assert.Empty(byApp.Apps["ratings"].VirtualServices)
+
+	unscopedAPs := []string{}
+	for _, ap := range byApp.Unscoped.AuthorizationPolicies {
+		unscopedAPs = append(unscopedAPs, ap.Name)
+	}
+	assert.ElementsMatch([]string{"namespace-deny", "details-allow"}, unscopedAPs)
+	require.Len(byApp.Unscoped.VirtualServices, 1)
+	assert.Equal("external-vs", byApp.Unscoped.VirtualServices[0].Name)
+}
@@ -0,0 +1,74 @@
+package business
+
+import (
+	"context"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// GetSecurityConfigBySelector returns the AuthorizationPolicies, PeerAuthentications and RequestAuthentications of a
+// namespace applying to the workloads with the labels of the workload selector, like "app=reviews,version=v1".
+// The namespace-wide objects, without selector, apply to every workload and are included.
+// The validations of the returned objects are included when the Istio API is enabled.
+func (in *IstioConfigService) GetSecurityConfigBySelector(ctx context.Context, cluster, namespace, workloadSelector string) (models.IstioConfigList, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetSecurityConfigBySelector",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("workloadSelector", workloadSelector),
+	)
+	defer end()
+
+	criteria := IstioConfigCriteria{
+		Cluster:                       cluster,
+		Namespace:                     namespace,
+		IncludeAuthorizationPolicies:  true,
+		IncludePeerAuthentications:    true,
+		IncludeRequestAuthentications: true,
+		WorkloadSelector:              workloadSelector,
+	}
+
+	// The validations are run on the whole namespace, some checkers need the objects not selecting the workload
+	var validations models.IstioValidations
+	var validationsErr error
+	validationsDone := make(chan struct{})
+	if in.config.ExternalServices.Istio.IstioAPIEnabled {
+		go func() {
+			defer close(validationsDone)
+			validations, validationsErr = in.businessLayer.Validations.GetValidations(ctx, cluster, namespace, "", "")
+		}()
+	} else {
+		close(validationsDone)
+	}
+
+	istioConfigList, err := in.GetIstioConfigList(ctx, criteria)
+	<-validationsDone
+	if err != nil {
+		return models.IstioConfigList{}, err
+	}
+	if validationsErr != nil {
+		return models.IstioConfigList{}, validationsErr
+	}
+
+	selected := map[models.IstioValidationKey]bool{}
+	for _, ap := range istioConfigList.AuthorizationPolicies {
+		selected[models.BuildKey(models.ObjectTypeSingular[kubernetes.AuthorizationPolicies], ap.Name, ap.Namespace)] = true
+	}
+	for _, pa := range istioConfigList.PeerAuthentications {
+		selected[models.BuildKey(models.ObjectTypeSingular[kubernetes.PeerAuthentications], pa.Name, pa.Namespace)] = true
+	}
+	for _, ra := range istioConfigList.RequestAuthentications {
+		selected[models.BuildKey(models.ObjectTypeSingular[kubernetes.RequestAuthentications], ra.Name, ra.Namespace)] = true
+	}
+	istioConfigList.IstioValidations = models.IstioValidations{}
+	for key, validation := range validations {
+		if selected[models.BuildKey(key.ObjectType, key.Name, key.Namespace)] {
+			istioConfigList.IstioValidations[key] = validation
+		}
+	}
+
+	return istioConfigList, nil
+}
@@ -0,0 +1,127 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_security_v1beta1 "istio.io/api/security/v1beta1"
+	api_v1beta1 "istio.io/api/type/v1beta1"
+	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func fakeRequestAuthentication(name, namespace string, selector map[string]string) *security_v1beta1.RequestAuthentication {
+	ra := &security_v1beta1.RequestAuthentication{ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: namespace}}
+	ra.Spec.Selector = &api_v1beta1.WorkloadSelector{MatchLabels: selector}
+	return ra
+}
+
+func TestGetSecurityConfigBySelector(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	aps := []*security_v1beta1.AuthorizationPolicy{
+		fakeAuthorizationPolicy("reviews-allow", "Namespace", api_security_v1beta1.AuthorizationPolicy_ALLOW, map[string]string{"app": "reviews"}),
+		fakeAuthorizationPolicy("namespace-deny", "Namespace", api_security_v1beta1.AuthorizationPolicy_DENY, nil),
+		fakeAuthorizationPolicy("ratings-allow", "Namespace", api_security_v1beta1.AuthorizationPolicy_ALLOW, map[string]string{"app": "ratings"}),
+	}
+	pas := []*security_v1beta1.PeerAuthentication{
+		data.CreateEmptyPeerAuthenticationWithSelector("reviews-v1-strict", "Namespace", map[string]string{"app": "reviews", "version": "v1"}),
+		data.CreateEmptyPeerAuthenticationWithSelector("reviews-v2-strict", "Namespace", map[string]string{"app": "reviews", "version": "v2"}),
+	}
+	ras := []*security_v1beta1.RequestAuthentication{
+		fakeRequestAuthentication("reviews-jwt", "Namespace", map[string]string{"app": "reviews"}),
+		fakeRequestAuthentication("ratings-jwt", "Namespace", map[string]string{"app": "ratings"}),
+	}
+	objects := []runtime.Object{
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "Namespace"}},
+		&core_v1.ConfigMap{ObjectMeta: meta_v1.ObjectMeta{Name: "istio", Namespace: "istio-system"}},
+		fakeComponentDeployment("reviews-v1", map[string]string{"app": "reviews", "version": "v1"}),
+		fakeComponentDeployment("ratings-v1", map[string]string{"app": "ratings", "version": "v1"}),
+	}
+	for _, ap := range aps {
+		objects = append(objects, ap)
+	}
+	for _, pa := range pas {
+		objects = append(objects, pa)
+	}
+	for _, ra := range ras {
+		objects = append(objects, ra)
+	}
+	k8s := kubetest.NewFakeK8sClient(objects...)
+	cache := SetupBusinessLayer(t, k8s, *conf)
+	// The validations are run on the config of the Istio registry
+	cache.SetRegistryStatus(&kubernetes.RegistryStatus{Configuration: &kubernetes.RegistryConfiguration{
+		AuthorizationPolicies:  aps,
+		PeerAuthentications:    pas,
+		RequestAuthentications: ras,
+	}})
+
+	k8sclients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	configService := NewWithBackends(k8sclients, k8sclients, nil, nil).IstioConfig
+
+	securityConfig, err := configService.GetSecurityConfigBySelector(context.TODO(), conf.KubernetesConfig.ClusterName, "Namespace", "app=reviews,version=v1")
+	require.NoError(err)
+
+	apNames := []string{}
+	for _, ap := range securityConfig.AuthorizationPolicies {
+		apNames = append(apNames, ap.Name)
+	}
+	assert.ElementsMatch([]string{"reviews-allow", "namespace-deny"}, apNames)
+	require.Len(securityConfig.PeerAuthentications, 1)
+	assert.Equal("reviews-v1-strict", securityConfig.PeerAuthentications[0].Name)
+	require.Len(securityConfig.RequestAuthentications, 1)
+	assert.Equal("reviews-jwt", securityConfig.RequestAuthentications[0].Name)
+
+	// Only the validations of the returned objects
+	validated := []string{}
+	for key := range securityConfig.IstioValidations {
+		validated = append(validated, key.ObjectType+"/"+key.Name)
+	}
+	assert.ElementsMatch([]string{
+		"authorizationpolicy/reviews-allow",
+		"authorizationpolicy/namespace-deny",
+		"peerauthentication/reviews-v1-strict",
+		"requestauthentication/reviews-jwt",
+	}, validated)
+	assert.Contains(securityConfig.IstioValidations, models.IstioValidationKey{ObjectType: "peerauthentication", Name: "reviews-v1-strict", Namespace: "Namespace", Cluster: conf.KubernetesConfig.ClusterName})
+}
+
+func TestGetSecurityConfigBySelectorWithoutIstioAPI(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "Namespace"}},
+		fakeAuthorizationPolicy("ratings-allow", "Namespace", api_security_v1beta1.AuthorizationPolicy_ALLOW, map[string]string{"app": "ratings"}),
+		fakeRequestAuthentication("reviews-jwt", "Namespace", map[string]string{"app": "reviews"}),
+	)
+	SetupBusinessLayer(t, k8s, *conf)
+
+	k8sclients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	configService := NewWithBackends(k8sclients, k8sclients, nil, nil).IstioConfig
+
+	securityConfig, err := configService.GetSecurityConfigBySelector(context.TODO(), conf.KubernetesConfig.ClusterName, "Namespace", "app=reviews")
+	require.NoError(err)
+	assert.Empty(securityConfig.AuthorizationPolicies)
+	assert.Empty(securityConfig.PeerAuthentications)
+	require.Len(securityConfig.RequestAuthentications, 1)
+	assert.Empty(securityConfig.IstioValidations)
+}
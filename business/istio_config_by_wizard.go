@@ -0,0 +1,121 @@
+package business
+
+import (
+	"context"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// GetWizardManagedConfig returns the Istio config of a namespace created by the Kiali wizards, grouped by the scenario
+// of the wizard read from the kiali_wizard label, so it can be told apart from the config written by hand.
+// The objects without the label are not returned.
+func (in *IstioConfigService) GetWizardManagedConfig(ctx context.Context, cluster, namespace string) (models.IstioConfigByWizard, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetWizardManagedConfig",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+	)
+	defer end()
+
+	istioConfigList, err := in.GetIstioConfigList(ctx, parseIstioConfigCriteria(cluster, namespace, "", "", "", "", false))
+	if err != nil {
+		return models.IstioConfigByWizard{}, err
+	}
+
+	scenarios := map[string]*models.IstioConfigList{}
+	// scenarioConfig returns the config of the scenario of an object, nil when the object was not created by a wizard
+	scenarioConfig := func(labels map[string]string) *models.IstioConfigList {
+		scenario, ok := getKialiScenario(labels)
+		if !ok {
+			return nil
+		}
+		if _, found := scenarios[scenario]; !found {
+			scenarios[scenario] = &models.IstioConfigList{Namespace: istioConfigList.Namespace}
+		}
+		return scenarios[scenario]
+	}
+
+	for _, dr := range istioConfigList.DestinationRules {
+		if config := scenarioConfig(dr.Labels); config != nil {
+			config.DestinationRules = append(config.DestinationRules, dr)
+		}
+	}
+	for _, ef := range istioConfigList.EnvoyFilters {
+		if config := scenarioConfig(ef.Labels); config != nil {
+			config.EnvoyFilters = append(config.EnvoyFilters, ef)
+		}
+	}
+	for _, gw := range istioConfigList.Gateways {
+		if config := scenarioConfig(gw.Labels); config != nil {
+			config.Gateways = append(config.Gateways, gw)
+		}
+	}
+	for _, se := range istioConfigList.ServiceEntries {
+		if config := scenarioConfig(se.Labels); config != nil {
+			config.ServiceEntries = append(config.ServiceEntries, se)
+		}
+	}
+	for _, sc := range istioConfigList.Sidecars {
+		if config := scenarioConfig(sc.Labels); config != nil {
+			config.Sidecars = append(config.Sidecars, sc)
+		}
+	}
+	for _, vs := range istioConfigList.VirtualServices {
+		if config := scenarioConfig(vs.Labels); config != nil {
+			config.VirtualServices = append(config.VirtualServices, vs)
+		}
+	}
+	for _, we := range istioConfigList.WorkloadEntries {
+		if config := scenarioConfig(we.Labels); config != nil {
+			config.WorkloadEntries = append(config.WorkloadEntries, we)
+		}
+	}
+	for _, wg := range istioConfigList.WorkloadGroups {
+		if config := scenarioConfig(wg.Labels); config != nil {
+			config.WorkloadGroups = append(config.WorkloadGroups, wg)
+		}
+	}
+	for _, wp := range istioConfigList.WasmPlugins {
+		if config := scenarioConfig(wp.Labels); config != nil {
+			config.WasmPlugins = append(config.WasmPlugins, wp)
+		}
+	}
+	for _, tm := range istioConfigList.Telemetries {
+		if config := scenarioConfig(tm.Labels); config != nil {
+			config.Telemetries = append(config.Telemetries, tm)
+		}
+	}
+	for _, gw := range istioConfigList.K8sGateways {
+		if config := scenarioConfig(gw.Labels); config != nil {
+			config.K8sGateways = append(config.K8sGateways, gw)
+		}
+	}
+	for _, route := range istioConfigList.K8sHTTPRoutes {
+		if config := scenarioConfig(route.Labels); config != nil {
+			config.K8sHTTPRoutes = append(config.K8sHTTPRoutes, route)
+		}
+	}
+	for _, ap := range istioConfigList.AuthorizationPolicies {
+		if config := scenarioConfig(ap.Labels); config != nil {
+			config.AuthorizationPolicies = append(config.AuthorizationPolicies, ap)
+		}
+	}
+	for _, pa := range istioConfigList.PeerAuthentications {
+		if config := scenarioConfig(pa.Labels); config != nil {
+			config.PeerAuthentications = append(config.PeerAuthentications, pa)
+		}
+	}
+	for _, ra := range istioConfigList.RequestAuthentications {
+		if config := scenarioConfig(ra.Labels); config != nil {
+			config.RequestAuthentications = append(config.RequestAuthentications, ra)
+		}
+	}
+
+	byWizard := models.IstioConfigByWizard{Scenarios: map[string]models.IstioConfigList{}}
+	for scenario, config := range scenarios {
+		byWizard.Scenarios[scenario] = *config
+	}
+	return byWizard, nil
+}
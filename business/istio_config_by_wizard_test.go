@@ -0,0 +1,75 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+)
+
+func TestGetWizardManagedConfig(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	config.Set(conf)
+
+	wizardVS := fakeRoutingVirtualService("reviews", "bookinfo", "reviews")
+	wizardVS.Labels = map[string]string{"kiali_wizard": "request_routing"}
+	handWrittenVS := fakeRoutingVirtualService("ratings", "bookinfo", "ratings")
+	wizardDR := &networking_v1beta1.DestinationRule{ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo", Labels: map[string]string{"kiali_wizard": "request_routing"}}}
+	faultDR := &networking_v1beta1.DestinationRule{ObjectMeta: meta_v1.ObjectMeta{Name: "details", Namespace: "bookinfo", Labels: map[string]string{"kiali_wizard": "fault_injection"}}}
+	handWrittenDR := &networking_v1beta1.DestinationRule{ObjectMeta: meta_v1.ObjectMeta{Name: "ratings", Namespace: "bookinfo", Labels: map[string]string{"app": "ratings"}}}
+	wizardAP := &security_v1beta1.AuthorizationPolicy{ObjectMeta: meta_v1.ObjectMeta{Name: "deny-all", Namespace: "bookinfo", Labels: map[string]string{"kiali_wizard": "AuthorizationPolicy"}}}
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		wizardVS, handWrittenVS, wizardDR, faultDR, handWrittenDR, wizardAP,
+	)
+	SetupBusinessLayer(t, k8s, *conf)
+	t.Cleanup(func() { kialiCache = nil })
+
+	k8sclients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	configService := NewWithBackends(k8sclients, k8sclients, nil, nil).IstioConfig
+
+	byWizard, err := configService.GetWizardManagedConfig(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo")
+	require.NoError(err)
+	require.Len(byWizard.Scenarios, 3)
+
+	routing := byWizard.Scenarios["request_routing"]
+	assert.Equal("bookinfo", routing.Namespace.Name)
+	require.Len(routing.VirtualServices, 1)
+	assert.Equal("reviews", routing.VirtualServices[0].Name)
+	require.Len(routing.DestinationRules, 1)
+	assert.Equal("reviews", routing.DestinationRules[0].Name)
+	assert.Empty(routing.AuthorizationPolicies)
+
+	fault := byWizard.Scenarios["fault_injection"]
+	assert.Empty(fault.VirtualServices)
+	require.Len(fault.DestinationRules, 1)
+	assert.Equal("details", fault.DestinationRules[0].Name)
+
+	authorization := byWizard.Scenarios["AuthorizationPolicy"]
+	require.Len(authorization.AuthorizationPolicies, 1)
+	assert.Equal("deny-all", authorization.AuthorizationPolicies[0].Name)
+
+	// No wizard-created config
+	k8s = kubetest.NewFakeK8sClient(&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}}, handWrittenVS, handWrittenDR)
+	SetupBusinessLayer(t, k8s, *conf)
+	k8sclients = map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	configService = NewWithBackends(k8sclients, k8sclients, nil, nil).IstioConfig
+
+	byWizard, err = configService.GetWizardManagedConfig(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo")
+	require.NoError(err)
+	assert.Empty(byWizard.Scenarios)
+}
@@ -0,0 +1,195 @@
+package business
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// driftObject holds the metadata and the spec of an Istio object to compare
+type driftObject struct {
+	objectType string
+	meta       meta_v1.Object
+	spec       interface{}
+}
+
+// CompareRegistryToLive compares the live Istio config of a namespace with the one known by the Istio registry (istiod's view).
+// It reports the objects present in only one of them and the ones with a different spec.
+// Useful to find why an object is not taking effect: istiod didn't get it yet or rejected it.
+// The Istio registry is only available for the home cluster.
+func (in *IstioConfigService) CompareRegistryToLive(ctx context.Context, namespace string) (models.IstioConfigDrift, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "CompareRegistryToLive",
+		observability.Attribute("package", "business"),
+		observability.Attribute("namespace", namespace),
+	)
+	defer end()
+
+	cluster := in.config.KubernetesConfig.ClusterName
+	drift := models.IstioConfigDrift{
+		Namespace:    namespace,
+		LiveOnly:     []models.IstioConfigDriftItem{},
+		RegistryOnly: []models.IstioConfigDriftItem{},
+		SpecMismatch: []models.IstioConfigDriftItem{},
+	}
+
+	registryConfiguration, err := in.getRegistryConfiguration(cluster)
+	if err != nil {
+		return drift, err
+	}
+
+	// A partial live list isn't allowed: the missing types would be reported as only known by the registry
+	criteria := parseIstioConfigCriteria(cluster, namespace, "", "", "", "", false)
+	liveConfig, err := in.GetIstioConfigList(ctx, criteria)
+	if err != nil {
+		return drift, err
+	}
+	if len(liveConfig.TimedOut) > 0 {
+		return drift, fmt.Errorf("timeout listing the live Istio config of namespace [%s], missing types: %v", namespace, liveConfig.TimedOut)
+	}
+
+	registryConfig := models.IstioConfigList{
+		DestinationRules:       registryConfiguration.DestinationRules,
+		EnvoyFilters:           registryConfiguration.EnvoyFilters,
+		Gateways:               registryConfiguration.Gateways,
+		ServiceEntries:         registryConfiguration.ServiceEntries,
+		Sidecars:               registryConfiguration.Sidecars,
+		VirtualServices:        registryConfiguration.VirtualServices,
+		WorkloadEntries:        registryConfiguration.WorkloadEntries,
+		WorkloadGroups:         registryConfiguration.WorkloadGroups,
+		WasmPlugins:            registryConfiguration.WasmPlugins,
+		Telemetries:            registryConfiguration.Telemetries,
+		K8sGateways:            registryConfiguration.K8sGateways,
+		K8sHTTPRoutes:          registryConfiguration.K8sHTTPRoutes,
+		AuthorizationPolicies:  registryConfiguration.AuthorizationPolicies,
+		PeerAuthentications:    registryConfiguration.PeerAuthentications,
+		RequestAuthentications: registryConfiguration.RequestAuthentications,
+	}
+
+	liveObjects := driftObjects(liveConfig, namespace)
+	registryObjects := driftObjects(registryConfig, namespace)
+
+	for key, live := range liveObjects {
+		registry, found := registryObjects[key]
+		if !found {
+			drift.LiveOnly = append(drift.LiveOnly, driftItem(&live, nil))
+			continue
+		}
+		if !sameSpec(live.spec, registry.spec) {
+			drift.SpecMismatch = append(drift.SpecMismatch, driftItem(&live, &registry))
+		}
+	}
+	for key, registry := range registryObjects {
+		if _, found := liveObjects[key]; !found {
+			drift.RegistryOnly = append(drift.RegistryOnly, driftItem(nil, &registry))
+		}
+	}
+
+	sortDriftItems(drift.LiveOnly)
+	sortDriftItems(drift.RegistryOnly)
+	sortDriftItems(drift.SpecMismatch)
+	return drift, nil
+}
+
+// driftItem builds the item of an object, live or registry is nil when the object is missing there
+func driftItem(live, registry *driftObject) models.IstioConfigDriftItem {
+	item := models.IstioConfigDriftItem{}
+	if live != nil {
+		item.ObjectType = live.objectType
+		item.Name = live.meta.GetName()
+		item.LiveResourceVersion = live.meta.GetResourceVersion()
+	}
+	if registry != nil {
+		item.ObjectType = registry.objectType
+		item.Name = registry.meta.GetName()
+		item.RegistryResourceVersion = registry.meta.GetResourceVersion()
+	}
+	return item
+}
+
+func sortDriftItems(items []models.IstioConfigDriftItem) {
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].ObjectType != items[j].ObjectType {
+			return items[i].ObjectType < items[j].ObjectType
+		}
+		return items[i].Name < items[j].Name
+	})
+}
+
+// sameSpec compares the specs by their JSON representation, the Istio API types are protobuf messages that can't be compared with reflection
+func sameSpec(spec, other interface{}) bool {
+	specJSON, err := json.Marshal(spec)
+	if err != nil {
+		return false
+	}
+	otherJSON, err := json.Marshal(other)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(specJSON, otherJSON)
+}
+
+// driftObjects indexes the objects of the namespace by type and name
+func driftObjects(list models.IstioConfigList, namespace string) map[string]driftObject {
+	objects := map[string]driftObject{}
+	add := func(objectType string, meta meta_v1.Object, spec interface{}) {
+		if meta.GetNamespace() == namespace {
+			objects[objectType+"/"+meta.GetName()] = driftObject{objectType: objectType, meta: meta, spec: spec}
+		}
+	}
+
+	for _, o := range list.DestinationRules {
+		add(kubernetes.DestinationRules, o, &o.Spec)
+	}
+	for _, o := range list.EnvoyFilters {
+		add(kubernetes.EnvoyFilters, o, &o.Spec)
+	}
+	for _, o := range list.Gateways {
+		add(kubernetes.Gateways, o, &o.Spec)
+	}
+	for _, o := range list.ServiceEntries {
+		add(kubernetes.ServiceEntries, o, &o.Spec)
+	}
+	for _, o := range list.Sidecars {
+		add(kubernetes.Sidecars, o, &o.Spec)
+	}
+	for _, o := range list.VirtualServices {
+		add(kubernetes.VirtualServices, o, &o.Spec)
+	}
+	for _, o := range list.WorkloadEntries {
+		add(kubernetes.WorkloadEntries, o, &o.Spec)
+	}
+	for _, o := range list.WorkloadGroups {
+		add(kubernetes.WorkloadGroups, o, &o.Spec)
+	}
+	for _, o := range list.WasmPlugins {
+		add(kubernetes.WasmPlugins, o, &o.Spec)
+	}
+	for _, o := range list.Telemetries {
+		add(kubernetes.Telemetries, o, &o.Spec)
+	}
+	for _, o := range list.K8sGateways {
+		add(kubernetes.K8sGateways, o, &o.Spec)
+	}
+	for _, o := range list.K8sHTTPRoutes {
+		add(kubernetes.K8sHTTPRoutes, o, &o.Spec)
+	}
+	for _, o := range list.AuthorizationPolicies {
+		add(kubernetes.AuthorizationPolicies, o, &o.Spec)
+	}
+	for _, o := range list.PeerAuthentications {
+		add(kubernetes.PeerAuthentications, o, &o.Spec)
+	}
+	for _, o := range list.RequestAuthentications {
+		add(kubernetes.RequestAuthentications, o, &o.Spec)
+	}
+	return objects
+}
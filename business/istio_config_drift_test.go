@@ -0,0 +1,80 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestCompareRegistryToLive(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	reviewsVS := data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"})
+	liveOnlyDR := data.CreateEmptyDestinationRule("bookinfo", "ratings", "ratings")
+	liveGW := data.AddServerToGateway(data.CreateServer([]string{"bookinfo.example.com"}, 80, "http", "http"),
+		data.CreateEmptyGateway("bookinfo-gateway", "bookinfo", map[string]string{"istio": "ingressgateway"}))
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		reviewsVS,
+		liveOnlyDR,
+		liveGW,
+	)
+	cache := SetupBusinessLayer(t, k8s, *conf)
+
+	// istiod got an older version of the Gateway, not the DestinationRule yet and still has a deleted VirtualService
+	registryGW := data.AddServerToGateway(data.CreateServer([]string{"old.example.com"}, 80, "http", "http"),
+		data.CreateEmptyGateway("bookinfo-gateway", "bookinfo", map[string]string{"istio": "ingressgateway"}))
+	cache.SetRegistryStatus(&kubernetes.RegistryStatus{
+		Configuration: &kubernetes.RegistryConfiguration{
+			Gateways: []*networking_v1beta1.Gateway{registryGW},
+			VirtualServices: []*networking_v1beta1.VirtualService{
+				data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"}),
+				data.CreateEmptyVirtualService("details", "bookinfo", []string{"details"}),
+				// Other namespaces are ignored
+				data.CreateEmptyVirtualService("reviews", "travels", []string{"reviews"}),
+			},
+		},
+	})
+
+	k8sclients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	configService := NewWithBackends(k8sclients, k8sclients, nil, nil).IstioConfig
+
+	drift, err := configService.CompareRegistryToLive(context.TODO(), "bookinfo")
+	require.NoError(err)
+
+	assert.Equal("bookinfo", drift.Namespace)
+	assert.Equal([]models.IstioConfigDriftItem{{ObjectType: kubernetes.DestinationRules, Name: "ratings"}}, drift.LiveOnly)
+	assert.Equal([]models.IstioConfigDriftItem{{ObjectType: kubernetes.VirtualServices, Name: "details"}}, drift.RegistryOnly)
+	assert.Equal([]models.IstioConfigDriftItem{{ObjectType: kubernetes.Gateways, Name: "bookinfo-gateway"}}, drift.SpecMismatch)
+}
+
+func TestCompareRegistryToLiveWithoutRegistry(t *testing.T) {
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}})
+	SetupBusinessLayer(t, k8s, *conf)
+
+	k8sclients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	configService := NewWithBackends(k8sclients, k8sclients, nil, nil).IstioConfig
+
+	_, err := configService.CompareRegistryToLive(context.TODO(), "bookinfo")
+	assert.Error(t, err)
+}
@@ -2,16 +2,26 @@ package business
 
 import (
 	"context"
+	"fmt"
+	"regexp"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/wrappers"
 	osproject_v1 "github.com/openshift/api/project/v1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
 	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	istiofake "istio.io/client-go/pkg/clientset/versioned/fake"
 	auth_v1 "k8s.io/api/authorization/v1"
+	core_v1 "k8s.io/api/core/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	api_types "k8s.io/apimachinery/pkg/types"
+	k8stesting "k8s.io/client-go/testing"
 
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
@@ -26,7 +36,7 @@ func TestParseListParams(t *testing.T) {
 	namespace := "bookinfo"
 	objects := ""
 	labelSelector := ""
-	criteria := ParseIstioConfigCriteria(cluster, namespace, objects, labelSelector, "", false)
+	criteria := ParseIstioConfigCriteria(cluster, namespace, objects, labelSelector, "", "", false)
 
 	assert.Equal(t, cluster, criteria.Cluster)
 	assert.Equal(t, namespace, criteria.Namespace)
@@ -36,7 +46,7 @@ func TestParseListParams(t *testing.T) {
 	assert.False(t, criteria.AllNamespaces)
 
 	objects = "gateways"
-	criteria = ParseIstioConfigCriteria("", namespace, objects, labelSelector, "", false)
+	criteria = ParseIstioConfigCriteria("", namespace, objects, labelSelector, "", "", false)
 
 	assert.Equal(t, "", criteria.Cluster)
 	assert.True(t, criteria.IncludeGateways)
@@ -46,7 +56,7 @@ func TestParseListParams(t *testing.T) {
 	assert.False(t, criteria.AllNamespaces)
 	assert.Equal(t, namespace, criteria.Namespace)
 
-	criteria = ParseIstioConfigCriteria("", "", objects, labelSelector, "", true)
+	criteria = ParseIstioConfigCriteria("", "", objects, labelSelector, "", "", true)
 
 	assert.True(t, criteria.IncludeGateways)
 	assert.False(t, criteria.IncludeVirtualServices)
@@ -56,7 +66,7 @@ func TestParseListParams(t *testing.T) {
 	assert.Equal(t, "", criteria.Namespace)
 
 	objects = "virtualservices"
-	criteria = ParseIstioConfigCriteria("", namespace, objects, labelSelector, "", false)
+	criteria = ParseIstioConfigCriteria("", namespace, objects, labelSelector, "", "", false)
 
 	assert.False(t, criteria.IncludeGateways)
 	assert.True(t, criteria.IncludeVirtualServices)
@@ -66,7 +76,7 @@ func TestParseListParams(t *testing.T) {
 	assert.Equal(t, namespace, criteria.Namespace)
 
 	objects = "destinationrules"
-	criteria = ParseIstioConfigCriteria("", namespace, objects, labelSelector, "", false)
+	criteria = ParseIstioConfigCriteria("", namespace, objects, labelSelector, "", "", false)
 
 	assert.False(t, criteria.IncludeGateways)
 	assert.False(t, criteria.IncludeVirtualServices)
@@ -76,7 +86,7 @@ func TestParseListParams(t *testing.T) {
 	assert.Equal(t, namespace, criteria.Namespace)
 
 	objects = "serviceentries"
-	criteria = ParseIstioConfigCriteria("", namespace, objects, labelSelector, "", false)
+	criteria = ParseIstioConfigCriteria("", namespace, objects, labelSelector, "", "", false)
 
 	assert.False(t, criteria.IncludeGateways)
 	assert.False(t, criteria.IncludeVirtualServices)
@@ -86,7 +96,7 @@ func TestParseListParams(t *testing.T) {
 	assert.Equal(t, namespace, criteria.Namespace)
 
 	objects = "virtualservices"
-	criteria = ParseIstioConfigCriteria("", namespace, objects, labelSelector, "", false)
+	criteria = ParseIstioConfigCriteria("", namespace, objects, labelSelector, "", "", false)
 
 	assert.False(t, criteria.IncludeGateways)
 	assert.True(t, criteria.IncludeVirtualServices)
@@ -96,7 +106,7 @@ func TestParseListParams(t *testing.T) {
 	assert.Equal(t, namespace, criteria.Namespace)
 
 	objects = "destinationrules,virtualservices"
-	criteria = ParseIstioConfigCriteria("", namespace, objects, labelSelector, "", false)
+	criteria = ParseIstioConfigCriteria("", namespace, objects, labelSelector, "", "", false)
 
 	assert.False(t, criteria.IncludeGateways)
 	assert.True(t, criteria.IncludeVirtualServices)
@@ -106,7 +116,7 @@ func TestParseListParams(t *testing.T) {
 	assert.Equal(t, namespace, criteria.Namespace)
 
 	objects = "notsupported"
-	criteria = ParseIstioConfigCriteria("", namespace, objects, labelSelector, "", false)
+	criteria = ParseIstioConfigCriteria("", namespace, objects, labelSelector, "", "", false)
 
 	assert.False(t, criteria.IncludeGateways)
 	assert.False(t, criteria.IncludeVirtualServices)
@@ -116,6 +126,30 @@ func TestParseListParams(t *testing.T) {
 	assert.Equal(t, namespace, criteria.Namespace)
 }
 
+func TestParseListParamsConfiguredDefaultTypes(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	conf.API.IstioConfigTypes = []string{kubernetes.Gateways, kubernetes.VirtualServices}
+	config.Set(conf)
+	t.Cleanup(func() { config.Set(config.NewConfig()) })
+
+	// Without objects, the configured types are listed
+	criteria := ParseIstioConfigCriteria("", "bookinfo", "", "", "", "", false)
+	assert.True(criteria.IncludeGateways)
+	assert.True(criteria.IncludeVirtualServices)
+	assert.False(criteria.IncludeDestinationRules)
+	assert.False(criteria.IncludeServiceEntries)
+	assert.False(criteria.IncludeAuthorizationPolicies)
+	assert.False(criteria.IncludeTelemetry)
+	assert.Equal("bookinfo", criteria.Namespace)
+
+	// The objects requested override the configured types
+	criteria = ParseIstioConfigCriteria("", "bookinfo", kubernetes.DestinationRules, "", "", "", false)
+	assert.False(criteria.IncludeGateways)
+	assert.False(criteria.IncludeVirtualServices)
+	assert.True(criteria.IncludeDestinationRules)
+}
+
 func TestGetIstioConfigList(t *testing.T) {
 	assert := assert.New(t)
 	conf := config.NewConfig()
@@ -214,6 +248,32 @@ func TestGetIstioConfigDetails(t *testing.T) {
 	assert.Error(err)
 }
 
+func TestGetIstioConfigDetailsK8sGatewayStatus(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	gw := data.CreateEmptyK8sGateway("gateway", "bookinfo")
+	gw.Status.Conditions = []meta_v1.Condition{
+		{Type: "Programmed", Status: meta_v1.ConditionTrue, Reason: "Programmed"},
+	}
+	k8s := kubetest.NewFakeK8sClient(&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}})
+	// Created through the client since the fake clientset guesses the resource version based on the Kind.
+	_, err := k8s.GatewayAPI().GatewayV1beta1().Gateways("bookinfo").Create(context.TODO(), gw, meta_v1.CreateOptions{})
+	require.NoError(err)
+	SetupBusinessLayer(t, k8s, *conf)
+
+	k8sclients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: &fakeAccessReview{k8s}}
+	configService := NewWithBackends(k8sclients, k8sclients, nil, nil).IstioConfig
+
+	istioConfigDetails, err := configService.GetIstioConfigDetails(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", kubernetes.K8sGateways, "gateway")
+	require.NoError(err)
+	require.NotNil(istioConfigDetails.Status)
+	require.Equal([]models.IstioConfigCondition{
+		{Type: "Programmed", Status: "True", Reason: "Programmed"},
+	}, istioConfigDetails.Status.Conditions)
+}
+
 func TestCheckMulticlusterPermissions(t *testing.T) {
 	assert := assert.New(t)
 
@@ -420,6 +480,249 @@ func (a *fakeAccessReview) GetSelfSubjectAccessReview(ctx context.Context, names
 	return fakeGetSelfSubjectAccessReview(), nil
 }
 
+// Tracks the concurrent SelfSubjectAccessReviews, the ones of the "broken" namespace fail.
+type concurrentAccessReview struct {
+	kubernetes.ClientInterface
+	calls       int32
+	inFlight    int32
+	maxInFlight int32
+}
+
+func (a *concurrentAccessReview) GetSelfSubjectAccessReview(ctx context.Context, namespace, api, resourceType string, verbs []string) ([]*auth_v1.SelfSubjectAccessReview, error) {
+	atomic.AddInt32(&a.calls, 1)
+	inFlight := atomic.AddInt32(&a.inFlight, 1)
+	defer atomic.AddInt32(&a.inFlight, -1)
+	for {
+		max := atomic.LoadInt32(&a.maxInFlight)
+		if inFlight <= max || atomic.CompareAndSwapInt32(&a.maxInFlight, max, inFlight) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	if namespace == "broken" {
+		return nil, fmt.Errorf("the server is currently unable to handle the request")
+	}
+	return fakeGetSelfSubjectAccessReview(), nil
+}
+
+func TestGetIstioConfigPermissions(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.PermissionsConcurrency = 2
+	config.Set(conf)
+
+	namespaces := []string{"broken"}
+	objects := []runtime.Object{&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "broken"}}}
+	for i := 0; i < 10; i++ {
+		ns := fmt.Sprintf("ns-%d", i)
+		namespaces = append(namespaces, ns)
+		objects = append(objects, &core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: ns}})
+	}
+	k8s := kubetest.NewFakeK8sClient(objects...)
+	SetupBusinessLayer(t, k8s, *conf)
+
+	accessReview := &concurrentAccessReview{ClientInterface: k8s}
+	k8sclients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: accessReview}
+	configService := NewWithBackends(k8sclients, k8sclients, nil, nil).IstioConfig
+
+	permissions, err := configService.GetIstioConfigPermissions(context.TODO(), namespaces, conf.KubernetesConfig.ClusterName)
+	require.NoError(err)
+	assert.LessOrEqual(atomic.LoadInt32(&accessReview.maxInFlight), int32(2))
+
+	// The failed checks are reported along the permissions of the other namespaces
+	require.Len(permissions, len(namespaces))
+	gwPermissions := (*permissions["ns-0"])[kubernetes.Gateways]
+	require.NotNil(gwPermissions)
+	assert.True(gwPermissions.Create)
+	assert.True(gwPermissions.Update)
+	assert.False(gwPermissions.Delete)
+	assert.Empty(gwPermissions.Error)
+	brokenPermissions := (*permissions["broken"])[kubernetes.Gateways]
+	require.NotNil(brokenPermissions)
+	assert.False(brokenPermissions.Create)
+	assert.Contains(brokenPermissions.Error, "permission check failed")
+
+	permissions, err = configService.GetIstioConfigPermissions(context.TODO(), namespaces[1:], conf.KubernetesConfig.ClusterName)
+	require.NoError(err)
+	require.Len(permissions, len(namespaces)-1)
+}
+
+// Counts the SelfSubjectAccessReviews of a user.
+type countingAccessReview struct {
+	kubernetes.ClientInterface
+	calls int32
+}
+
+func (a *countingAccessReview) GetSelfSubjectAccessReview(ctx context.Context, namespace, api, resourceType string, verbs []string) ([]*auth_v1.SelfSubjectAccessReview, error) {
+	atomic.AddInt32(&a.calls, 1)
+	return fakeGetSelfSubjectAccessReview(), nil
+}
+
+func TestGetIstioConfigPermissionsCached(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "test"}})
+	k8s.Token = "user-token"
+	SetupBusinessLayer(t, k8s, *conf)
+
+	accessReview := &countingAccessReview{ClientInterface: k8s}
+	k8sclients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: accessReview}
+	configService := NewWithBackends(k8sclients, k8sclients, nil, nil).IstioConfig
+
+	permissions, err := configService.GetIstioConfigPermissions(context.TODO(), []string{"test"}, conf.KubernetesConfig.ClusterName)
+	require.NoError(err)
+	calls := atomic.LoadInt32(&accessReview.calls)
+	require.NotZero(calls)
+
+	// The second call hits the cache
+	cachedPermissions, err := configService.GetIstioConfigPermissions(context.TODO(), []string{"test"}, conf.KubernetesConfig.ClusterName)
+	require.NoError(err)
+	assert.Equal(calls, atomic.LoadInt32(&accessReview.calls))
+	assert.Equal(permissions, cachedPermissions)
+
+	// Another user doesn't share the cached permissions
+	otherK8s := kubetest.NewFakeK8sClient(&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "test"}})
+	otherK8s.Token = "other-user-token"
+	otherAccessReview := &countingAccessReview{ClientInterface: otherK8s}
+	otherClients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: otherAccessReview}
+	_, err = NewWithBackends(otherClients, k8sclients, nil, nil).IstioConfig.GetIstioConfigPermissions(context.TODO(), []string{"test"}, conf.KubernetesConfig.ClusterName)
+	require.NoError(err)
+	assert.Equal(calls, atomic.LoadInt32(&otherAccessReview.calls))
+}
+
+func TestGetIstioConfigPermissionsDeniedExpire(t *testing.T) {
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	// The fake denies the delete verb
+	conf.KubernetesConfig.CacheTokenPermissionsDeniedDuration = 0
+	conf.KubernetesConfig.CacheIstioConfigPermissionsDuration = 0
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "test"}})
+	SetupBusinessLayer(t, k8s, *conf)
+
+	accessReview := &countingAccessReview{ClientInterface: k8s}
+	k8sclients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: accessReview}
+	configService := NewWithBackends(k8sclients, k8sclients, nil, nil).IstioConfig
+
+	_, err := configService.GetIstioConfigPermissions(context.TODO(), []string{"test"}, conf.KubernetesConfig.ClusterName)
+	require.NoError(err)
+	calls := atomic.LoadInt32(&accessReview.calls)
+
+	_, err = configService.GetIstioConfigPermissions(context.TODO(), []string{"test"}, conf.KubernetesConfig.ClusterName)
+	require.NoError(err)
+	require.Equal(2*calls, atomic.LoadInt32(&accessReview.calls))
+}
+
+func TestGetIstioConfigPermissionsNamespacesCached(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	// Only the permissions of the namespace sets are cached
+	conf.KubernetesConfig.CacheTokenPermissionsDuration = 0
+	conf.KubernetesConfig.CacheTokenPermissionsDeniedDuration = 0
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "travels"}},
+	)
+	k8s.Token = "user-token"
+	SetupBusinessLayer(t, k8s, *conf)
+
+	accessReview := &countingAccessReview{ClientInterface: k8s}
+	k8sclients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: accessReview}
+	configService := NewWithBackends(k8sclients, k8sclients, nil, nil).IstioConfig
+
+	permissions, err := configService.GetIstioConfigPermissions(context.TODO(), []string{"bookinfo", "travels"}, conf.KubernetesConfig.ClusterName)
+	require.NoError(err)
+	calls := atomic.LoadInt32(&accessReview.calls)
+	require.NotZero(calls)
+
+	// Same set of namespaces in another order
+	cachedPermissions, err := configService.GetIstioConfigPermissions(context.TODO(), []string{"travels", "bookinfo"}, conf.KubernetesConfig.ClusterName)
+	require.NoError(err)
+	assert.Equal(calls, atomic.LoadInt32(&accessReview.calls))
+	assert.Equal(permissions, cachedPermissions)
+
+	// The callers don't modify the cached permissions
+	(*cachedPermissions["bookinfo"])[kubernetes.Gateways].Delete = true
+	cachedPermissions, err = configService.GetIstioConfigPermissions(context.TODO(), []string{"bookinfo", "travels"}, conf.KubernetesConfig.ClusterName)
+	require.NoError(err)
+	assert.False((*cachedPermissions["bookinfo"])[kubernetes.Gateways].Delete)
+
+	// Another set of namespaces is checked again
+	_, err = configService.GetIstioConfigPermissions(context.TODO(), []string{"bookinfo"}, conf.KubernetesConfig.ClusterName)
+	require.NoError(err)
+	assert.Equal(calls+calls/2, atomic.LoadInt32(&accessReview.calls))
+}
+
+func TestGetIstioConfigPermissionsNamespacesCacheNotStacked(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	for _, namespacesDuration := range []int{10, 0} {
+		conf := config.NewConfig()
+		conf.KubernetesConfig.CacheIstioConfigPermissionsDuration = namespacesDuration
+		config.Set(conf)
+
+		k8s := kubetest.NewFakeK8sClient(&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}})
+		k8s.Token = "user-token"
+		cache := SetupBusinessLayer(t, k8s, *conf)
+
+		accessReview := &countingAccessReview{ClientInterface: k8s}
+		k8sclients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: accessReview}
+		configService := NewWithBackends(k8sclients, k8sclients, nil, nil).IstioConfig
+
+		// Permissions checked before the user was granted access
+		cache.SetPermissions(kubernetes.UserCacheKey(accessReview), conf.KubernetesConfig.ClusterName, "bookinfo", kubernetes.NetworkingGroupVersionV1Beta1.Group, allResources, models.ResourcePermissions{})
+
+		permissions, err := configService.GetIstioConfigPermissions(context.TODO(), []string{"bookinfo"}, conf.KubernetesConfig.ClusterName)
+		require.NoError(err)
+		if namespacesDuration > 0 {
+			// The permissions cached for the namespace set are not computed from the cached ones
+			assert.True((*permissions["bookinfo"])[kubernetes.Gateways].Create)
+		} else {
+			assert.False((*permissions["bookinfo"])[kubernetes.Gateways].Create)
+		}
+	}
+}
+
+func TestGetIstioConfigPermissionsErrorNotCached(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "broken"}})
+	SetupBusinessLayer(t, k8s, *conf)
+
+	accessReview := &concurrentAccessReview{ClientInterface: k8s}
+	k8sclients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: accessReview}
+	configService := NewWithBackends(k8sclients, k8sclients, nil, nil).IstioConfig
+
+	permissions, err := configService.GetIstioConfigPermissions(context.TODO(), []string{"broken"}, conf.KubernetesConfig.ClusterName)
+	require.NoError(err)
+	require.NotEmpty((*permissions["broken"])[kubernetes.Gateways].Error)
+	calls := atomic.LoadInt32(&accessReview.calls)
+
+	// The failed check is retried
+	permissions, err = configService.GetIstioConfigPermissions(context.TODO(), []string{"broken"}, conf.KubernetesConfig.ClusterName)
+	require.NoError(err)
+	assert.NotEmpty((*permissions["broken"])[kubernetes.Gateways].Error)
+	assert.Equal(2*calls, atomic.LoadInt32(&accessReview.calls))
+}
+
 func mockGetIstioConfigDetails(t *testing.T) IstioConfigService {
 	conf := config.NewConfig()
 	config.Set(conf)
@@ -545,13 +848,76 @@ func TestUpdateIstioConfigDetails(t *testing.T) {
 	k8sclients[conf.KubernetesConfig.ClusterName] = k8s
 	configService := IstioConfigService{userClients: k8sclients, kialiCache: cache}
 
-	updatedVirtualService, err := configService.UpdateIstioConfigDetail(conf.KubernetesConfig.ClusterName, "test", "virtualservices", "reviews-to-update", "{}")
+	updatedVirtualService, err := configService.UpdateIstioConfigDetail(conf.KubernetesConfig.ClusterName, "test", "virtualservices", "reviews-to-update", "{}", "")
 	assert.Equal("test", updatedVirtualService.Namespace.Name)
 	assert.Equal("virtualservices", updatedVirtualService.ObjectType)
 	assert.Equal("reviews-to-update", updatedVirtualService.VirtualService.Name)
 	assert.Nil(err)
 }
 
+func TestUpdateIstioConfigDetailsWithResourceVersion(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	vs := data.CreateEmptyVirtualService("reviews-to-update", "test", []string{"reviews"})
+	vs.ResourceVersion = "100"
+	k8s := kubetest.NewFakeK8sClient(vs)
+	cache := SetupBusinessLayer(t, k8s, *config.NewConfig())
+	conf := config.Get()
+
+	k8sclients := make(map[string]kubernetes.ClientInterface)
+	k8sclients[conf.KubernetesConfig.ClusterName] = k8s
+	configService := IstioConfigService{userClients: k8sclients, kialiCache: cache}
+
+	// The object changed since the version the JSON patch is based on
+	_, err := configService.UpdateIstioConfigDetail(conf.KubernetesConfig.ClusterName, "test", "virtualservices", "reviews-to-update",
+		`[{"op": "add", "path": "/spec/hosts/-", "value": "ratings"}]`, "99")
+	require.Error(err)
+	assert.True(IsIstioConfigConflictError(err))
+
+	updated, err := configService.UpdateIstioConfigDetail(conf.KubernetesConfig.ClusterName, "test", "virtualservices", "reviews-to-update",
+		`[{"op": "add", "path": "/spec/hosts/-", "value": "ratings"}]`, "100")
+	require.NoError(err)
+	assert.Equal([]string{"reviews", "ratings"}, updated.VirtualService.Spec.Hosts)
+
+	updated, err = configService.UpdateIstioConfigDetail(conf.KubernetesConfig.ClusterName, "test", "virtualservices", "reviews-to-update",
+		`{"spec": {"hosts": ["details"]}}`, "100")
+	require.NoError(err)
+	assert.Equal([]string{"details"}, updated.VirtualService.Spec.Hosts)
+
+	// The API server rejects the merge patches with a stale resourceVersion
+	k8s.IstioClientset.(*istiofake.Clientset).PrependReactor("patch", "virtualservices", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, api_errors.NewConflict(networking_v1beta1.SchemeGroupVersion.WithResource("virtualservices").GroupResource(), "reviews-to-update", fmt.Errorf("the object has been modified"))
+	})
+	_, err = configService.UpdateIstioConfigDetail(conf.KubernetesConfig.ClusterName, "test", "virtualservices", "reviews-to-update",
+		`{"spec": {"hosts": ["ratings"]}}`, "99")
+	require.Error(err)
+	assert.True(IsIstioConfigConflictError(err))
+}
+
+func TestBuildIstioConfigPatch(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	patchType, patch, err := buildIstioConfigPatch(`{"spec": {}}`, "")
+	require.NoError(err)
+	assert.Equal(api_types.MergePatchType, patchType)
+	assert.Equal(`{"spec": {}}`, string(patch))
+
+	patchType, patch, err = buildIstioConfigPatch(`{"metadata": {"labels": {"app": "reviews"}}}`, "100")
+	require.NoError(err)
+	assert.Equal(api_types.MergePatchType, patchType)
+	assert.JSONEq(`{"metadata": {"labels": {"app": "reviews"}, "resourceVersion": "100"}}`, string(patch))
+
+	patchType, patch, err = buildIstioConfigPatch(` [{"op": "remove", "path": "/spec/hosts/0"}]`, "100")
+	require.NoError(err)
+	assert.Equal(api_types.JSONPatchType, patchType)
+	assert.JSONEq(`[{"op": "test", "path": "/metadata/resourceVersion", "value": "100"}, {"op": "remove", "path": "/spec/hosts/0"}]`, string(patch))
+
+	_, _, err = buildIstioConfigPatch(`[{"op": "remove"`, "100")
+	assert.Error(err)
+}
+
 func TestCreateIstioConfigDetails(t *testing.T) {
 	assert := assert.New(t)
 	k8s := kubetest.NewFakeK8sClient()
@@ -609,3 +975,279 @@ func TestFilterIstioObjectsForWorkloadSelector(t *testing.T) {
 	pa := kubernetes.FilterPeerAuthenticationsBySelector(s, istioConfigList.PeerAuthentications)
 	assert.Equal(1, len(pa))
 }
+
+func mockGetOrphanedConfig(t *testing.T, conf *config.Config, registryStatus *kubernetes.RegistryStatus) IstioConfigService {
+	t.Helper()
+	config.Set(conf)
+
+	privateSE := data.CreateEmptyMeshExternalServiceEntry("private", "other", []string{"private.example.com"})
+	privateSE.Spec.ExportTo = []string{"."}
+	fakeIstioObjects := []runtime.Object{
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "other"}},
+		&core_v1.Service{ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"}},
+		data.CreateEmptyMeshExternalServiceEntry("api", "other", []string{"api.example.com"}),
+		privateSE,
+		data.CreateEmptyDestinationRule("bookinfo", "reviews", "reviews"),
+		data.CreateEmptyDestinationRule("bookinfo", "reviews-fqdn", "reviews.bookinfo.svc.cluster.local"),
+		data.CreateEmptyDestinationRule("bookinfo", "ratings", "ratings"),
+		data.CreateEmptyDestinationRule("bookinfo", "api", "api.example.com"),
+		data.CreateEmptyDestinationRule("bookinfo", "private", "private.example.com"),
+		data.CreateEmptyDestinationRule("bookinfo", "wildcard", "*.example.org"),
+		data.AddHttpRoutesToVirtualService(data.CreateHttpRouteDestination("reviews", "", -1),
+			data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"})),
+		data.AddHttpRoutesToVirtualService(data.CreateHttpRouteDestination("ratings", "", -1),
+			data.CreateEmptyVirtualService("ratings", "bookinfo", []string{"ratings"})),
+		data.AddHttpRoutesToVirtualService(data.CreateHttpRouteDestination("reviews", "", 50),
+			data.AddHttpRoutesToVirtualService(data.CreateHttpRouteDestination("ratings", "", 50),
+				data.CreateEmptyVirtualService("split", "bookinfo", []string{"split"}))),
+	}
+	k8s := kubetest.NewFakeK8sClient(fakeIstioObjects...)
+
+	cache := SetupBusinessLayer(t, k8s, *conf)
+	if registryStatus != nil {
+		cache.SetRegistryStatus(registryStatus)
+	}
+
+	k8sclients := make(map[string]kubernetes.ClientInterface)
+	k8sclients[conf.KubernetesConfig.ClusterName] = k8s
+	return NewWithBackends(k8sclients, k8sclients, nil, nil).IstioConfig
+}
+
+func TestGetOrphanedConfig(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+
+	configService := mockGetOrphanedConfig(t, conf, nil)
+	orphanedConfig, err := configService.GetOrphanedConfig(context.TODO(), "bookinfo")
+	require.NoError(err)
+
+	drNames := []string{}
+	for _, dr := range orphanedConfig.DestinationRules {
+		drNames = append(drNames, dr.Name)
+	}
+	// The private ServiceEntry is not exported to the bookinfo namespace
+	require.ElementsMatch([]string{"ratings", "private"}, drNames)
+
+	// The split VirtualService still routes part of the traffic to an existing service
+	require.Len(orphanedConfig.VirtualServices, 1)
+	require.Equal("ratings", orphanedConfig.VirtualServices[0].Name)
+}
+
+func TestGetOrphanedConfigFromRegistry(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+
+	reviews := &kubernetes.RegistryService{}
+	reviews.Hostname = "reviews.bookinfo.svc.cluster.local"
+	reviews.Attributes.Name = "reviews"
+	reviews.Attributes.Namespace = "bookinfo"
+	// ratings is only exported to the other namespace
+	ratings := &kubernetes.RegistryService{}
+	ratings.Hostname = "ratings.bookinfo.svc.cluster.local"
+	ratings.Attributes.Name = "ratings"
+	ratings.Attributes.Namespace = "bookinfo"
+	ratings.Attributes.ExportTo = map[string]bool{"other": true}
+	apiSE := data.CreateEmptyMeshExternalServiceEntry("api", "other", []string{"api.example.com"})
+
+	registryStatus := &kubernetes.RegistryStatus{
+		Configuration: &kubernetes.RegistryConfiguration{
+			ServiceEntries: []*networking_v1beta1.ServiceEntry{apiSE},
+		},
+		Services: []*kubernetes.RegistryService{reviews, ratings},
+	}
+
+	configService := mockGetOrphanedConfig(t, conf, registryStatus)
+	orphanedConfig, err := configService.GetOrphanedConfig(context.TODO(), "bookinfo")
+	require.NoError(err)
+
+	drNames := []string{}
+	for _, dr := range orphanedConfig.DestinationRules {
+		drNames = append(drNames, dr.Name)
+	}
+	// The private ServiceEntry is not part of the registry
+	require.ElementsMatch([]string{"ratings", "private"}, drNames)
+	require.Len(orphanedConfig.VirtualServices, 1)
+	require.Equal("ratings", orphanedConfig.VirtualServices[0].Name)
+}
+
+func TestGetIstioConfigListRegistryMaxObjects(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.RegistryMaxObjects = 2
+
+	registryStatus := &kubernetes.RegistryStatus{
+		Configuration: &kubernetes.RegistryConfiguration{
+			ServiceEntries: []*networking_v1beta1.ServiceEntry{
+				data.CreateEmptyMeshExternalServiceEntry("api", "other", []string{"api.example.com"}),
+			},
+			DestinationRules: []*networking_v1beta1.DestinationRule{
+				data.CreateEmptyDestinationRule("bookinfo", "reviews", "reviews"),
+				data.CreateEmptyDestinationRule("bookinfo", "ratings", "ratings"),
+			},
+		},
+	}
+	configService := mockGetOrphanedConfig(t, conf, registryStatus)
+
+	// The limit only applies to the responses of the API, the internal callers need the whole registry
+	criteria := ParseIstioConfigCriteria(conf.KubernetesConfig.ClusterName, "", "", "", "", "", true)
+	istioConfigList, err := configService.GetIstioConfigList(context.TODO(), criteria)
+	require.NoError(err)
+	require.Equal(3, istioConfigList.Count())
+}
+
+func TestGetIstioConfigListFilteredByAnnotations(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	config.Set(conf)
+
+	owned := data.CreateEmptyVirtualService("owned", "bookinfo", []string{"reviews"})
+	owned.Annotations = map[string]string{"owner": "team-a", "kiali.io/docs": "https://example.com/team-a/routing"}
+	otherOwner := data.CreateEmptyVirtualService("other-owner", "bookinfo", []string{"ratings"})
+	otherOwner.Annotations = map[string]string{"owner": "team-b"}
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		owned,
+		otherOwner,
+		data.CreateEmptyVirtualService("unowned", "bookinfo", []string{"details"}),
+	)
+	SetupBusinessLayer(t, k8s, *conf)
+
+	k8sclients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	configService := NewWithBackends(k8sclients, k8sclients, nil, nil).IstioConfig
+
+	vsNames := func(annotationSelector string) []string {
+		criteria := IstioConfigCriteria{
+			Namespace:              "bookinfo",
+			IncludeVirtualServices: true,
+			AnnotationSelector:     annotationSelector,
+		}
+		istioConfigList, err := configService.GetIstioConfigList(context.TODO(), criteria)
+		require.NoError(err)
+		names := []string{}
+		for _, vs := range istioConfigList.VirtualServices {
+			names = append(names, vs.Name)
+		}
+		return names
+	}
+
+	require.ElementsMatch([]string{"owned", "other-owner", "unowned"}, vsNames(""))
+	require.ElementsMatch([]string{"owned"}, vsNames("owner=team-a"))
+	require.ElementsMatch([]string{"owned", "other-owner"}, vsNames("owner"))
+	require.ElementsMatch([]string{"unowned"}, vsNames("!owner"))
+	require.ElementsMatch([]string{"other-owner", "unowned"}, vsNames("owner!=team-a"))
+	require.ElementsMatch([]string{"owned"}, vsNames("owner, kiali.io/docs=https://example.com/team-a/routing"))
+
+	_, err := configService.GetIstioConfigList(context.TODO(), IstioConfigCriteria{
+		Namespace:              "bookinfo",
+		IncludeVirtualServices: true,
+		AnnotationSelector:     "owner in (",
+	})
+	require.Error(err)
+}
+
+// mockSlowVirtualServicesList returns a service whose VirtualServices are not cached and
+// whose list blocks until the test ends, as an API server that doesn't answer.
+func mockSlowVirtualServicesList(t *testing.T, conf *config.Config) (IstioConfigService, <-chan struct{}) {
+	t.Helper()
+	conf.KubernetesConfig.CacheIstioTypes = []string{"DestinationRule", "Gateway"}
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		data.CreateEmptyDestinationRule("bookinfo", "reviews", "reviews"),
+		data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"}),
+	)
+	SetupBusinessLayer(t, k8s, *conf)
+
+	listing := make(chan struct{})
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	k8s.IstioClientset.(*istiofake.Clientset).PrependReactor("list", "virtualservices", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		close(listing)
+		<-release
+		return false, nil, nil
+	})
+
+	k8sclients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	return NewWithBackends(k8sclients, k8sclients, nil, nil).IstioConfig, listing
+}
+
+func TestGetIstioConfigListCancelled(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	conf.KubernetesConfig.ListTimeout = 0
+
+	configService, listing := mockSlowVirtualServicesList(t, conf)
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	go func() {
+		<-listing
+		cancel()
+	}()
+
+	criteria := IstioConfigCriteria{
+		Namespace:               "bookinfo",
+		IncludeDestinationRules: true,
+		IncludeVirtualServices:  true,
+	}
+	_, err := configService.GetIstioConfigList(ctx, criteria)
+	require.ErrorIs(err, context.Canceled)
+}
+
+func TestGetIstioConfigListTimeout(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	conf.KubernetesConfig.ListTimeout = 1
+
+	configService, _ := mockSlowVirtualServicesList(t, conf)
+
+	criteria := IstioConfigCriteria{
+		Namespace:               "bookinfo",
+		IncludeDestinationRules: true,
+		IncludeVirtualServices:  true,
+		AllowPartial:            true,
+	}
+	istioConfigList, err := configService.GetIstioConfigList(context.TODO(), criteria)
+	require.NoError(err)
+	require.Equal([]string{kubernetes.VirtualServices}, istioConfigList.TimedOut)
+	require.Len(istioConfigList.DestinationRules, 1)
+	require.Empty(istioConfigList.VirtualServices)
+}
+
+func TestGetIstioConfigListExcludedNamespaces(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+
+	registryStatus := &kubernetes.RegistryStatus{
+		Configuration: &kubernetes.RegistryConfiguration{
+			DestinationRules: []*networking_v1beta1.DestinationRule{
+				data.CreateEmptyDestinationRule("bookinfo", "reviews", "reviews"),
+				data.CreateEmptyDestinationRule("istio-system", "reviews", "reviews.bookinfo.svc.cluster.local"),
+				data.CreateEmptyDestinationRule("other", "reviews", "reviews.bookinfo.svc.cluster.local"),
+			},
+		},
+	}
+	configService := mockGetOrphanedConfig(t, conf, registryStatus)
+
+	criteria := IstioConfigCriteria{
+		AllNamespaces:           true,
+		Cluster:                 conf.KubernetesConfig.ClusterName,
+		Namespace:               "bookinfo",
+		IncludeDestinationRules: true,
+		// The namespace of the criteria is never excluded
+		ExcludedNamespaces: []*regexp.Regexp{regexp.MustCompile("istio-.*"), regexp.MustCompile("bookinfo")},
+	}
+	istioConfigList, err := configService.GetIstioConfigList(context.TODO(), criteria)
+	require.NoError(err)
+
+	namespaces := []string{}
+	for _, dr := range istioConfigList.DestinationRules {
+		namespaces = append(namespaces, dr.Namespace)
+	}
+	require.ElementsMatch([]string{"bookinfo", "other"}, namespaces)
+}
@@ -0,0 +1,54 @@
+package business
+
+import (
+	"context"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// GetDeletionImpact returns what is affected by the deletion of an Istio object: the Istio objects referencing it,
+// like the VirtualServices bound to a Gateway, and the services and workloads it applies to, like the ones of the host
+// of a DestinationRule. The references are the ones shown in the details of the object.
+// It uses following parameters:
+// - "cluster":		cluster of the object
+// - "namespace":	namespace of the object
+// - "objectType":	type of the object, like "gateways"
+// - "object":		name of the object
+func (in *IstioValidationsService) GetDeletionImpact(ctx context.Context, cluster, namespace, objectType, object string) (*models.DeletionImpact, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetDeletionImpact",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("objectType", objectType),
+		observability.Attribute("object", object),
+	)
+	defer end()
+
+	// The object must exist, the references of a missing object are empty
+	if _, err := in.businessLayer.IstioConfig.GetIstioConfigDetails(ctx, cluster, namespace, objectType, object); err != nil {
+		return nil, err
+	}
+
+	_, referencesMap, err := in.GetIstioObjectValidations(ctx, cluster, namespace, objectType, object)
+	if err != nil {
+		return nil, err
+	}
+
+	impact := &models.DeletionImpact{
+		ObjectType:         models.ObjectTypeSingular[objectType],
+		Name:               object,
+		Namespace:          namespace,
+		ObjectReferences:   []models.IstioReference{},
+		ServiceReferences:  []models.ServiceReference{},
+		WorkloadReferences: []models.WorkloadReference{},
+	}
+	key := models.IstioReferenceKey{ObjectType: impact.ObjectType, Name: object, Namespace: namespace}
+	if references, ok := referencesMap[key]; ok && references != nil {
+		impact.ObjectReferences = append(impact.ObjectReferences, references.ObjectReferences...)
+		impact.ServiceReferences = append(impact.ServiceReferences, references.ServiceReferences...)
+		impact.WorkloadReferences = append(impact.WorkloadReferences, references.WorkloadReferences...)
+	}
+	return impact, nil
+}
@@ -0,0 +1,72 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestGetDeletionImpactGateway(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	config.Set(conf)
+
+	selector := map[string]string{"istio": "ingressgateway"}
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "travels"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		&core_v1.ConfigMap{ObjectMeta: meta_v1.ObjectMeta{Name: "istio", Namespace: "istio-system"}},
+		data.CreateEmptyGateway("bookinfo-gateway", "bookinfo", selector),
+		data.CreateEmptyGateway("unused-gateway", "bookinfo", selector),
+		data.AddGatewaysToVirtualService([]string{"bookinfo-gateway"}, data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"})),
+		// A VirtualService of another namespace bound to the Gateway
+		data.AddGatewaysToVirtualService([]string{"bookinfo/bookinfo-gateway"}, data.CreateEmptyVirtualService("travels", "travels", []string{"travels"})),
+		// Same name of Gateway, but in its own namespace
+		data.AddGatewaysToVirtualService([]string{"bookinfo-gateway"}, data.CreateEmptyVirtualService("other", "travels", []string{"other"})),
+	)
+	SetupBusinessLayer(t, k8s, *conf)
+	t.Cleanup(func() { kialiCache = nil })
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	vs := NewWithBackends(clients, clients, nil, nil).Validations
+
+	impact, err := vs.GetDeletionImpact(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", kubernetes.Gateways, "bookinfo-gateway")
+	require.NoError(err)
+	assert.Equal("gateway", impact.ObjectType)
+	assert.Equal("bookinfo-gateway", impact.Name)
+	assert.Equal("bookinfo", impact.Namespace)
+	assert.ElementsMatch([]models.IstioReference{
+		{ObjectType: "virtualservice", Name: "reviews", Namespace: "bookinfo"},
+		{ObjectType: "virtualservice", Name: "travels", Namespace: "travels"},
+	}, impact.ObjectReferences)
+	assert.Empty(impact.ServiceReferences)
+	assert.Empty(impact.WorkloadReferences)
+
+	// Nothing references the Gateway, its deletion has no impact
+	impact, err = vs.GetDeletionImpact(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", kubernetes.Gateways, "unused-gateway")
+	require.NoError(err)
+	assert.Equal("unused-gateway", impact.Name)
+	assert.NotNil(impact.ObjectReferences)
+	assert.Empty(impact.ObjectReferences)
+	assert.Empty(impact.ServiceReferences)
+	assert.Empty(impact.WorkloadReferences)
+
+	_, err = vs.GetDeletionImpact(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", kubernetes.Gateways, "missing")
+	require.Error(err)
+	assert.True(errors.IsNotFound(err))
+}
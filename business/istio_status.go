@@ -38,7 +38,13 @@ func (iss *IstioStatusService) GetStatus(ctx context.Context, cluster string) (k
 		return nil, err
 	}
 
-	return ics.Merge(iss.getAddonComponentStatus()), nil
+	// The statuses are gathered concurrently, they are sorted to be stable between calls
+	statuses := ics.Merge(iss.getAddonComponentStatus(cluster))
+	for i := range statuses {
+		statuses[i].Cluster = cluster
+	}
+	statuses.Sort()
+	return statuses, nil
 }
 
 func (iss *IstioStatusService) getIstioComponentStatus(ctx context.Context, cluster string) (kubernetes.IstioComponentStatus, error) {
@@ -204,7 +210,9 @@ func GetWorkloadStatus(wl models.Workload) string {
 	return status
 }
 
-func (iss *IstioStatusService) getAddonComponentStatus() kubernetes.IstioComponentStatus {
+// getAddonComponentStatus checks the addons of a cluster. The addons of the cluster are the global ones, unless
+// the URLs of the addon are overridden for the cluster.
+func (iss *IstioStatusService) getAddonComponentStatus(cluster string) kubernetes.IstioComponentStatus {
 	var wg sync.WaitGroup
 	wg.Add(4)
 
@@ -212,16 +220,25 @@ func (iss *IstioStatusService) getAddonComponentStatus() kubernetes.IstioCompone
 	extServices := config.Get().ExternalServices
 	ics := kubernetes.IstioComponentStatus{}
 
-	go getAddonStatus("prometheus", true, extServices.Prometheus.IsCore, &extServices.Prometheus.Auth, extServices.Prometheus.URL, extServices.Prometheus.HealthCheckUrl, staChan, &wg)
-	go getAddonStatus("grafana", extServices.Grafana.Enabled, extServices.Grafana.IsCore, &extServices.Grafana.Auth, extServices.Grafana.InClusterURL, extServices.Grafana.HealthCheckUrl, staChan, &wg)
-	go iss.getTracingStatus("jaeger", extServices.Tracing.Enabled, extServices.Tracing.IsCore, staChan, &wg)
+	promURL, promHealthCheckUrl := addonClusterURLs(cluster, extServices.Prometheus.URL, extServices.Prometheus.HealthCheckUrl, extServices.Prometheus.Clusters)
+	go getAddonStatus("prometheus", true, extServices.Prometheus.IsCore, &extServices.Prometheus.Auth, promURL, promHealthCheckUrl, staChan, &wg)
+	grafanaURL, grafanaHealthCheckUrl := addonClusterURLs(cluster, extServices.Grafana.InClusterURL, extServices.Grafana.HealthCheckUrl, extServices.Grafana.Clusters)
+	go getAddonStatus("grafana", extServices.Grafana.Enabled, extServices.Grafana.IsCore, &extServices.Grafana.Auth, grafanaURL, grafanaHealthCheckUrl, staChan, &wg)
+	// The tracing client only reaches the global tracing, the tracing of a cluster is checked by its URL
+	if clusterTracing, ok := extServices.Tracing.Clusters[cluster]; ok && (clusterTracing.URL != "" || clusterTracing.HealthCheckUrl != "") {
+		tracingURL, tracingHealthCheckUrl := addonClusterURLs(cluster, extServices.Tracing.InClusterURL, "", extServices.Tracing.Clusters)
+		go getAddonStatus("jaeger", extServices.Tracing.Enabled, extServices.Tracing.IsCore, &extServices.Tracing.Auth, tracingURL, tracingHealthCheckUrl, staChan, &wg)
+	} else {
+		go iss.getTracingStatus("jaeger", extServices.Tracing.Enabled, extServices.Tracing.IsCore, staChan, &wg)
+	}
 
 	// Custom dashboards may use the main Prometheus config
 	customProm := extServices.CustomDashboards.Prometheus
 	if customProm.URL == "" {
 		customProm = extServices.Prometheus
 	}
-	go getAddonStatus("custom dashboards", extServices.CustomDashboards.Enabled, extServices.CustomDashboards.IsCore, &customProm.Auth, customProm.URL, customProm.HealthCheckUrl, staChan, &wg)
+	customPromURL, customPromHealthCheckUrl := addonClusterURLs(cluster, customProm.URL, customProm.HealthCheckUrl, customProm.Clusters)
+	go getAddonStatus("custom dashboards", extServices.CustomDashboards.Enabled, extServices.CustomDashboards.IsCore, &customProm.Auth, customPromURL, customPromHealthCheckUrl, staChan, &wg)
 
 	wg.Wait()
 
@@ -233,6 +250,22 @@ func (iss *IstioStatusService) getAddonComponentStatus() kubernetes.IstioCompone
 	return ics
 }
 
+// addonClusterURLs returns the URL and the health check URL of the addon of a cluster, falling back to the global ones.
+// The global health check URL is not used with the URL of a cluster, it targets the global addon.
+func addonClusterURLs(cluster, url, healthCheckUrl string, clusters map[string]config.AddonClusterConfig) (string, string) {
+	clusterAddon, ok := clusters[cluster]
+	if !ok {
+		return url, healthCheckUrl
+	}
+	if clusterAddon.URL != "" {
+		return clusterAddon.URL, clusterAddon.HealthCheckUrl
+	}
+	if clusterAddon.HealthCheckUrl != "" {
+		return url, clusterAddon.HealthCheckUrl
+	}
+	return url, healthCheckUrl
+}
+
 func getAddonStatus(name string, enabled bool, isCore bool, auth *config.Auth, url string, healthCheckUrl string, staChan chan<- kubernetes.IstioComponentStatus, wg *sync.WaitGroup) {
 	defer wg.Done()
 
@@ -255,8 +288,12 @@ func getAddonStatus(name string, enabled bool, isCore bool, auth *config.Auth, u
 	}
 
 	// Call the addOn service endpoint to find out whether is reachable or not
+	// The TLS config comes from the auth of the addOn, so a private CA is trusted with ca_file
 	_, statusCode, _, err := httputil.HttpGet(url, auth, 10*time.Second, nil, nil)
 	if err != nil || statusCode > 399 {
+		if err != nil {
+			log.Errorf("Error fetching availability of the %s service: %v", name, err)
+		}
 		staChan <- kubernetes.IstioComponentStatus{
 			kubernetes.ComponentStatus{
 				Name:   name,
@@ -2,15 +2,19 @@ package business
 
 import (
 	"context"
+	"encoding/pem"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 
 	"github.com/gorilla/mux"
 	osproject_v1 "github.com/openshift/api/project/v1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	apps_v1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -303,6 +307,110 @@ func TestGrafanaNotWorking(t *testing.T) {
 	assertNotPresent(assert, icsl, "custom dashboards")
 }
 
+func TestComponentStatusOrder(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	grafanaCalls, prometheusCalls := 0, 0
+	objects := []runtime.Object{
+		fakeDeploymentWithStatus("istio-egressgateway", map[string]string{"app": "istio-egressgateway"}, unhealthyStatus),
+		&osproject_v1.Project{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+	}
+	k8s := mockDeploymentCall(objects, true)
+	addOnsStetup := defaultAddOnCalls(&grafanaCalls, &prometheusCalls)
+	addOnsStetup["grafana"] = addOnsSetup{Url: "/grafana/mock", StatusCode: 501, CallCount: &grafanaCalls}
+	addOnsStetup["prometheus"] = addOnsSetup{Url: "/prometheus/mock", StatusCode: 501, CallCount: &prometheusCalls}
+	httpServer := mockServer(t, mockAddOnCalls(addOnsStetup))
+
+	conf := addonAddMockUrls(httpServer.URL, config.NewConfig(), false)
+	config.Set(conf)
+	SetupBusinessLayer(t, k8s, *conf)
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	iss := NewWithBackends(clients, clients, nil, mockFailingJaeger).IstioStatus
+
+	names := func(icsl kubernetes.IstioComponentStatus) []string {
+		result := []string{}
+		for _, ics := range icsl {
+			result = append(result, ics.Name)
+		}
+		return result
+	}
+
+	icsl, err := iss.GetStatus(context.TODO(), conf.KubernetesConfig.ClusterName)
+	require.NoError(err)
+	// Core components first, by name
+	assert.Equal([]string{"istio-ingressgateway", "istiod", "grafana", "istio-egressgateway", "jaeger", "prometheus"}, names(icsl))
+
+	for i := 0; i < 10; i++ {
+		again, err := iss.GetStatus(context.TODO(), conf.KubernetesConfig.ClusterName)
+		require.NoError(err)
+		assert.Equal(icsl, again)
+	}
+}
+
+func TestAddonStatusPerCluster(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	objs, b1, _ := sampleIstioComponent()
+	objs = append(objs, &osproject_v1.Project{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}})
+	k8s := mockDeploymentCall(objs, b1)
+
+	grafanaCalls, prometheusCalls, westGrafanaCalls, westPrometheusCalls := 0, 0, 0, 0
+	addOnsStetup := defaultAddOnCalls(&grafanaCalls, &prometheusCalls)
+	addOnsStetup["west-prometheus"] = addOnsSetup{Url: "/west/prometheus/mock", StatusCode: 501, CallCount: &westPrometheusCalls}
+	addOnsStetup["west-grafana"] = addOnsSetup{Url: "/west/grafana/mock", StatusCode: 200, CallCount: &westGrafanaCalls}
+	addOnsStetup["west-jaeger"] = addOnsSetup{Url: "/west/jaeger/mock", StatusCode: 501}
+	httpServer := mockServer(t, mockAddOnCalls(addOnsStetup))
+
+	conf := addonAddMockUrls(httpServer.URL, config.NewConfig(), false)
+	conf.ExternalServices.Prometheus.Clusters = map[string]config.AddonClusterConfig{
+		"west": {URL: httpServer.URL + "/west/prometheus/mock"},
+	}
+	conf.ExternalServices.Grafana.Clusters = map[string]config.AddonClusterConfig{
+		"west": {URL: httpServer.URL + "/west/grafana/wrong", HealthCheckUrl: httpServer.URL + "/west/grafana/mock"},
+	}
+	conf.ExternalServices.Tracing.Clusters = map[string]config.AddonClusterConfig{
+		"west": {URL: httpServer.URL + "/west/jaeger/mock"},
+	}
+	config.Set(conf)
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s, "west": k8s}
+	clientFactory := kubetest.NewK8SClientFactoryMock(nil)
+	clientFactory.SetClients(clients)
+	cache := newTestingCache(t, clientFactory, *conf)
+	cache.SetRegistryStatus(&kubernetes.RegistryStatus{})
+	kialiCache = cache
+	iss := NewWithBackends(clients, clients, nil, mockJaeger).IstioStatus
+
+	// The home cluster uses the global addons
+	icsl, err := iss.GetStatus(context.TODO(), conf.KubernetesConfig.ClusterName)
+	require.NoError(err)
+	assertNotPresent(assert, icsl, "prometheus")
+	assertNotPresent(assert, icsl, "grafana")
+	assertNotPresent(assert, icsl, "jaeger")
+	assert.Equal(1, grafanaCalls)
+	assert.Equal(1, prometheusCalls)
+	assert.Zero(westGrafanaCalls)
+	assert.Zero(westPrometheusCalls)
+
+	// The west cluster uses its own addons, the custom dashboards fall back to the global Prometheus
+	icsl, err = iss.GetStatus(context.TODO(), "west")
+	require.NoError(err)
+	assertComponent(assert, icsl, "prometheus", kubernetes.ComponentUnreachable, false)
+	assertComponent(assert, icsl, "jaeger", kubernetes.ComponentUnreachable, false)
+	assertNotPresent(assert, icsl, "grafana")
+	assertNotPresent(assert, icsl, "custom dashboards")
+	for _, ics := range icsl {
+		assert.Equal("west", ics.Cluster)
+	}
+	assert.Equal(1, grafanaCalls)
+	assert.Equal(1, prometheusCalls)
+	assert.Equal(1, westGrafanaCalls)
+	assert.Equal(1, westPrometheusCalls)
+}
+
 func TestFailingTracingService(t *testing.T) {
 	assert := assert.New(t)
 
@@ -331,6 +439,49 @@ func TestFailingTracingService(t *testing.T) {
 	assertComponent(assert, icsl, "jaeger", kubernetes.ComponentUnreachable, false)
 }
 
+func TestAddonStatusCustomCA(t *testing.T) {
+	httpServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(httpServer.Close)
+
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: httpServer.Certificate().Raw})
+	if err := os.WriteFile(caFile, caPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		auth      config.Auth
+		reachable bool
+	}{
+		"System roots don't trust the private CA": {auth: config.Auth{}, reachable: false},
+		"CA file trusts the private CA":           {auth: config.Auth{CAFile: caFile}, reachable: true},
+		"Insecure skip verify":                    {auth: config.Auth{InsecureSkipVerify: true}, reachable: true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var wg sync.WaitGroup
+			wg.Add(1)
+			staChan := make(chan kubernetes.IstioComponentStatus, 1)
+			auth := tc.auth
+			getAddonStatus("grafana", true, false, &auth, httpServer.URL, "", staChan, &wg)
+			wg.Wait()
+			close(staChan)
+
+			icsl := kubernetes.IstioComponentStatus{}
+			for stat := range staChan {
+				icsl.Merge(stat)
+			}
+			if tc.reachable {
+				assertNotPresent(assert.New(t), icsl, "grafana")
+			} else {
+				assertComponent(assert.New(t), icsl, "grafana", kubernetes.ComponentUnreachable, false)
+			}
+		})
+	}
+}
+
 func TestOverriddenUrls(t *testing.T) {
 	assert := assert.New(t)
 
@@ -7,7 +7,6 @@ import (
 
 	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
-	core_v1 "k8s.io/api/core/v1"
 
 	"github.com/kiali/kiali/business/checkers"
 	"github.com/kiali/kiali/business/references"
@@ -36,6 +35,12 @@ type ReferenceChecker interface {
 // all the enabled checkers. If service is "" then the whole namespace is validated.
 // If service is not empty string, then all of its associated Istio objects are validated.
 func (in *IstioValidationsService) GetValidations(ctx context.Context, cluster, namespace, service, workload string) (models.IstioValidations, error) {
+	return in.getValidations(ctx, cluster, namespace, service, workload, nil)
+}
+
+// getValidations runs the checkers on the Istio config of the cluster.
+// When proposed is not nil, its objects replace or are added to the ones of the cluster before running the checkers.
+func (in *IstioValidationsService) getValidations(ctx context.Context, cluster, namespace, service, workload string, proposed *models.IstioConfigList) (models.IstioValidations, error) {
 	var end observability.EndFunc
 	ctx, end = observability.StartSpan(ctx, "GetValidations",
 		observability.Attribute("package", "business"),
@@ -92,7 +97,7 @@ func (in *IstioValidationsService) GetValidations(ctx context.Context, cluster,
 	}
 
 	// We fetch without target service as some validations will require full-namespace details
-	go in.fetchIstioConfigList(ctx, &istioConfigList, &mtlsDetails, &rbacDetails, cluster, namespace, errChan, &wg)
+	go in.fetchIstioConfigList(ctx, &istioConfigList, &mtlsDetails, &rbacDetails, cluster, namespace, proposed, errChan, &wg)
 
 	if workload != "" {
 		// load only requested workload
@@ -144,14 +149,14 @@ func (in *IstioValidationsService) getAllObjectCheckers(istioConfigList models.I
 		checkers.VirtualServiceChecker{Namespaces: namespaces, VirtualServices: istioConfigList.VirtualServices, DestinationRules: istioConfigList.DestinationRules, Cluster: cluster},
 		checkers.DestinationRulesChecker{Namespaces: namespaces, DestinationRules: istioConfigList.DestinationRules, MTLSDetails: mtlsDetails, ServiceEntries: istioConfigList.ServiceEntries, Cluster: cluster},
 		checkers.GatewayChecker{Gateways: istioConfigList.Gateways, WorkloadsPerNamespace: workloadsPerNamespace, IsGatewayToNamespace: in.isGatewayToNamespace(), Cluster: cluster},
-		checkers.PeerAuthenticationChecker{PeerAuthentications: mtlsDetails.PeerAuthentications, MTLSDetails: mtlsDetails, WorkloadsPerNamespace: workloadsPerNamespace, Cluster: cluster},
+		checkers.PeerAuthenticationChecker{PeerAuthentications: mtlsDetails.PeerAuthentications, MTLSDetails: mtlsDetails, WorkloadsPerNamespace: workloadsPerNamespace, WorkloadPorts: in.getPeerAuthnWorkloadPorts(cluster, mtlsDetails.PeerAuthentications), Cluster: cluster},
 		checkers.ServiceEntryChecker{ServiceEntries: istioConfigList.ServiceEntries, Namespaces: namespaces, WorkloadEntries: istioConfigList.WorkloadEntries, Cluster: cluster},
-		checkers.AuthorizationPolicyChecker{AuthorizationPolicies: rbacDetails.AuthorizationPolicies, Namespaces: namespaces, ServiceEntries: istioConfigList.ServiceEntries, WorkloadsPerNamespace: workloadsPerNamespace, MtlsDetails: mtlsDetails, VirtualServices: istioConfigList.VirtualServices, RegistryServices: registryServices, PolicyAllowAny: in.isPolicyAllowAny(), Cluster: cluster},
-		checkers.SidecarChecker{Sidecars: istioConfigList.Sidecars, Namespaces: namespaces, WorkloadsPerNamespace: workloadsPerNamespace, ServiceEntries: istioConfigList.ServiceEntries, RegistryServices: registryServices, Cluster: cluster},
+		checkers.AuthorizationPolicyChecker{AuthorizationPolicies: rbacDetails.AuthorizationPolicies, Namespaces: namespaces, ServiceEntries: istioConfigList.ServiceEntries, WorkloadsPerNamespace: workloadsPerNamespace, MtlsDetails: mtlsDetails, VirtualServices: istioConfigList.VirtualServices, RegistryServices: registryServices, PolicyAllowAny: in.isPolicyAllowAny(), ExtensionProviders: in.meshExtensionProviders(cluster), Cluster: cluster},
+		checkers.SidecarChecker{Sidecars: istioConfigList.Sidecars, Namespaces: namespaces, WorkloadsPerNamespace: workloadsPerNamespace, ServiceEntries: istioConfigList.ServiceEntries, RegistryServices: registryServices, Cluster: cluster, ControlPlaneHost: in.businessLayer.Workload.controlPlaneHost(cluster)},
 		checkers.RequestAuthenticationChecker{RequestAuthentications: istioConfigList.RequestAuthentications, WorkloadsPerNamespace: workloadsPerNamespace, Cluster: cluster},
 		checkers.WorkloadChecker{AuthorizationPolicies: rbacDetails.AuthorizationPolicies, WorkloadsPerNamespace: workloadsPerNamespace, Cluster: cluster},
 		checkers.K8sGatewayChecker{K8sGateways: istioConfigList.K8sGateways, Cluster: cluster},
-		checkers.WasmPluginChecker{WasmPlugins: istioConfigList.WasmPlugins, Namespaces: namespaces},
+		checkers.WasmPluginChecker{WasmPlugins: istioConfigList.WasmPlugins, Namespaces: namespaces, WorkloadsPerNamespace: workloadsPerNamespace, RootNamespace: config.Get().ExternalServices.Istio.RootNamespace, Cluster: cluster},
 		checkers.TelemetryChecker{Telemetries: istioConfigList.Telemetries, Namespaces: namespaces},
 		checkers.K8sHTTPRouteChecker{K8sHTTPRoutes: istioConfigList.K8sHTTPRoutes, K8sGateways: istioConfigList.K8sGateways, Namespaces: namespaces, RegistryServices: registryServices, Cluster: cluster},
 	}
@@ -202,7 +207,7 @@ func (in *IstioValidationsService) GetIstioObjectValidations(ctx context.Context
 		wg.Add(1)
 	}
 
-	go in.fetchIstioConfigList(ctx, &istioConfigList, &mtlsDetails, &rbacDetails, cluster, namespace, errChan, &wg)
+	go in.fetchIstioConfigList(ctx, &istioConfigList, &mtlsDetails, &rbacDetails, cluster, namespace, nil, errChan, &wg)
 	go in.fetchAllWorkloads(ctx, &workloadsPerNamespace, cluster, &namespaces, errChan, &wg)
 	go in.fetchNonLocalmTLSConfigs(&mtlsDetails, cluster, errChan, &wg)
 
@@ -236,6 +241,7 @@ func (in *IstioValidationsService) GetIstioObjectValidations(ctx context.Context
 		sidecarsChecker := checkers.SidecarChecker{
 			Sidecars: istioConfigList.Sidecars, Namespaces: namespaces,
 			WorkloadsPerNamespace: workloadsPerNamespace, ServiceEntries: istioConfigList.ServiceEntries, RegistryServices: registryServices,
+			ControlPlaneHost: in.businessLayer.Workload.controlPlaneHost(cluster),
 		}
 		objectCheckers = []ObjectChecker{sidecarsChecker}
 		referenceChecker = references.SidecarReferences{Sidecars: istioConfigList.Sidecars, Namespace: namespace, Namespaces: namespaces, ServiceEntries: istioConfigList.ServiceEntries, RegistryServices: registryServices, WorkloadsPerNamespace: workloadsPerNamespace}
@@ -244,12 +250,13 @@ func (in *IstioValidationsService) GetIstioObjectValidations(ctx context.Context
 			AuthorizationPolicies: rbacDetails.AuthorizationPolicies,
 			Namespaces:            namespaces, ServiceEntries: istioConfigList.ServiceEntries,
 			WorkloadsPerNamespace: workloadsPerNamespace, MtlsDetails: mtlsDetails, VirtualServices: istioConfigList.VirtualServices, RegistryServices: registryServices, PolicyAllowAny: in.isPolicyAllowAny(),
+			ExtensionProviders: in.meshExtensionProviders(cluster),
 		}
 		objectCheckers = []ObjectChecker{authPoliciesChecker}
 		referenceChecker = references.AuthorizationPolicyReferences{AuthorizationPolicies: rbacDetails.AuthorizationPolicies, Namespace: namespace, Namespaces: namespaces, VirtualServices: istioConfigList.VirtualServices, ServiceEntries: istioConfigList.ServiceEntries, RegistryServices: registryServices, WorkloadsPerNamespace: workloadsPerNamespace}
 	case kubernetes.PeerAuthentications:
 		// Validations on PeerAuthentications
-		peerAuthnChecker := checkers.PeerAuthenticationChecker{PeerAuthentications: mtlsDetails.PeerAuthentications, MTLSDetails: mtlsDetails, WorkloadsPerNamespace: workloadsPerNamespace}
+		peerAuthnChecker := checkers.PeerAuthenticationChecker{PeerAuthentications: mtlsDetails.PeerAuthentications, MTLSDetails: mtlsDetails, WorkloadsPerNamespace: workloadsPerNamespace, WorkloadPorts: in.getPeerAuthnWorkloadPorts(cluster, mtlsDetails.PeerAuthentications)}
 		objectCheckers = []ObjectChecker{peerAuthnChecker}
 		referenceChecker = references.PeerAuthReferences{MTLSDetails: mtlsDetails, WorkloadsPerNamespace: workloadsPerNamespace}
 	case kubernetes.WorkloadEntries:
@@ -263,7 +270,8 @@ func (in *IstioValidationsService) GetIstioObjectValidations(ctx context.Context
 	case kubernetes.EnvoyFilters:
 		// Validation on EnvoyFilters are not yet in place
 	case kubernetes.WasmPlugins:
-		// Validation on WasmPlugins is not expected
+		wasmPluginChecker := checkers.WasmPluginChecker{WasmPlugins: istioConfigList.WasmPlugins, Namespaces: namespaces, WorkloadsPerNamespace: workloadsPerNamespace, RootNamespace: config.Get().ExternalServices.Istio.RootNamespace}
+		objectCheckers = []ObjectChecker{wasmPluginChecker}
 	case kubernetes.Telemetries:
 		// Validation on Telemetries is not expected
 	case kubernetes.K8sGateways:
@@ -393,7 +401,7 @@ func (in *IstioValidationsService) fetchWorkload(ctx context.Context, rValue *ma
 	}
 }
 
-func (in *IstioValidationsService) fetchIstioConfigList(ctx context.Context, rValue *models.IstioConfigList, mtlsDetails *kubernetes.MTLSDetails, rbacDetails *kubernetes.RBACDetails, cluster, namespace string, errChan chan error, wg *sync.WaitGroup) {
+func (in *IstioValidationsService) fetchIstioConfigList(ctx context.Context, rValue *models.IstioConfigList, mtlsDetails *kubernetes.MTLSDetails, rbacDetails *kubernetes.RBACDetails, cluster, namespace string, proposed *models.IstioConfigList, errChan chan error, wg *sync.WaitGroup) {
 	defer wg.Done()
 	if len(errChan) > 0 {
 		return
@@ -413,6 +421,7 @@ func (in *IstioValidationsService) fetchIstioConfigList(ctx context.Context, rVa
 		IncludePeerAuthentications:    true,
 		IncludeK8sHTTPRoutes:          true,
 		IncludeK8sGateways:            true,
+		IncludeWasmPlugins:            true,
 	}
 	istioConfigMap, err := in.businessLayer.IstioConfig.GetIstioConfigMap(ctx, criteria)
 	if err != nil {
@@ -420,6 +429,9 @@ func (in *IstioValidationsService) fetchIstioConfigList(ctx context.Context, rVa
 		return
 	}
 	istioConfigList := istioConfigMap[criteria.Cluster]
+	if proposed != nil {
+		istioConfigList = overlayIstioConfigList(istioConfigList, *proposed)
+	}
 
 	// Filter VS
 	filteredVSs := in.filterVSExportToNamespaces(namespace, istioConfigList.VirtualServices)
@@ -452,6 +464,9 @@ func (in *IstioValidationsService) fetchIstioConfigList(ctx context.Context, rVa
 	// All WorkloadEntries
 	rValue.WorkloadEntries = append(rValue.WorkloadEntries, istioConfigList.WorkloadEntries...)
 
+	// All WasmPlugins, the ones of the root namespace apply to the whole mesh
+	rValue.WasmPlugins = append(rValue.WasmPlugins, istioConfigList.WasmPlugins...)
+
 	in.filterPeerAuths(namespace, mtlsDetails, istioConfigList.PeerAuthentications)
 
 	in.filterAuthPolicies(namespace, rbacDetails, istioConfigList.AuthorizationPolicies)
@@ -560,13 +575,7 @@ func (in *IstioValidationsService) fetchNonLocalmTLSConfigs(mtlsDetails *kuberne
 	}
 	cfg := config.Get()
 
-	var istioConfig *core_v1.ConfigMap
-	var err error
-	if IsNamespaceCached(cfg.IstioNamespace) {
-		istioConfig, err = kialiCache.GetConfigMap(cfg.IstioNamespace, cfg.ExternalServices.Istio.ConfigMapName)
-	} else {
-		istioConfig, err = userClient.GetConfigMap(cfg.IstioNamespace, cfg.ExternalServices.Istio.ConfigMapName)
-	}
+	istioConfig, err := newReadThrough(kubeCache, userClient).getConfigMap(cfg.IstioNamespace, cfg.ExternalServices.Istio.ConfigMapName)
 	if err != nil {
 		errChan <- err
 		return
@@ -621,6 +630,28 @@ func (in *IstioValidationsService) isPolicyAllowAny() bool {
 	return allowAny
 }
 
+// meshExtensionProviders returns the names of the extension providers of the mesh config of the cluster,
+// nil when the mesh config can't be read
+func (in *IstioValidationsService) meshExtensionProviders(cluster string) []string {
+	kubeCache, err := kialiCache.GetKubeCache(cluster)
+	if err != nil {
+		log.Debugf("Unable to get the kube cache of cluster [%s] to read the extension providers: %s", cluster, err)
+		return nil
+	}
+	cfg := config.Get()
+	istioConfig, err := kubeCache.GetConfigMap(cfg.IstioNamespace, cfg.ExternalServices.Istio.ConfigMapName)
+	if err != nil {
+		log.Debugf("Unable to read the extension providers of the mesh config: %s", err)
+		return nil
+	}
+	meshConfig, err := kubernetes.GetIstioConfigMap(istioConfig)
+	if err != nil {
+		log.Debugf("Unable to read the extension providers of the mesh config: %s", err)
+		return nil
+	}
+	return meshConfig.GetExtensionProviderNames()
+}
+
 func checkExportTo(exportToNs string, namespace string, ownNs string) bool {
 	// check if namespaces where it is exported to, or if it is exported to all namespaces, or export to own namespace
 	return exportToNs == "*" || exportToNs == namespace || (exportToNs == "." && ownNs == namespace)
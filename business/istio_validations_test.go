@@ -356,3 +356,45 @@ func yamlFixtureLoaderFor(file string) *validations.YamlFixtureLoader {
 	path := fmt.Sprintf("../tests/data/validations/exportto/cns/%s", file)
 	return &validations.YamlFixtureLoader{Filename: path}
 }
+
+func TestGetValidationsCustomAuthorizationPolicyProviders(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		&core_v1.ConfigMap{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "istio", Namespace: "istio-system"},
+			Data:       map[string]string{"mesh": "extensionProviders:\n- name: opa\n  envoyExtAuthzGrpc:\n    service: opa.opa.svc.cluster.local\n    port: 9191\n"},
+		},
+		data.CreateCustomAuthorizationPolicy("valid-ext-authz", "bookinfo", "opa"),
+		data.CreateCustomAuthorizationPolicy("dangling-ext-authz", "bookinfo", "missing"),
+	)
+	SetupBusinessLayer(t, k8s, *conf)
+	t.Cleanup(func() { kialiCache = nil })
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	vs := NewWithBackends(clients, clients, nil, nil).Validations
+	validations, err := vs.GetValidations(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "", "")
+	require.NoError(err)
+
+	key := func(name string) models.IstioValidationKey {
+		return models.IstioValidationKey{ObjectType: "authorizationpolicy", Name: name, Namespace: "bookinfo", Cluster: conf.KubernetesConfig.ClusterName}
+	}
+
+	require.Contains(validations, key("valid-ext-authz"))
+	assert.True(validations[key("valid-ext-authz")].Valid)
+	assert.Empty(validations[key("valid-ext-authz")].Checks)
+
+	require.Contains(validations, key("dangling-ext-authz"))
+	dangling := validations[key("dangling-ext-authz")]
+	assert.False(dangling.Valid)
+	require.Len(dangling.Checks, 1)
+	assert.Equal("KIA0107", dangling.Checks[0].Code)
+	assert.Equal("spec/provider/name", dangling.Checks[0].Path)
+}
@@ -0,0 +1,113 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+	"github.com/kiali/kiali/util/jsondiff"
+)
+
+// serverManagedMetadata are the metadata fields set by the API server and the controllers, never part of an applied configuration
+var serverManagedMetadata = []string{"creationTimestamp", "generation", "managedFields", "resourceVersion", "selfLink", "uid"}
+
+// ComputeKubernetesDrift compares the configuration last applied with kubectl to a Deployment or a Service, kept in its
+// kubectl.kubernetes.io/last-applied-configuration annotation, with the live object.
+// Only the values of the applied configuration are compared: the fields only set in the live object are mostly defaulted
+// by the API server, so they are not reported as drift. The status and the server-managed fields are stripped before diffing.
+// It uses following parameters:
+// - "cluster":		cluster of the object
+// - "namespace":	namespace of the object
+// - "kind":		kind of the object, "Deployment" or "Service"
+// - "name":		name of the object
+func (in *WorkloadService) ComputeKubernetesDrift(ctx context.Context, cluster, namespace, kind, name string) (*models.KubernetesDrift, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "ComputeKubernetesDrift",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("kind", kind),
+		observability.Attribute("name", name),
+	)
+	defer end()
+
+	// Check if user has access to the namespace (RBAC) in cache scenarios and/or
+	// if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
+	if _, err := in.businessLayer.Namespace.GetNamespaceByCluster(ctx, namespace, cluster); err != nil {
+		return nil, err
+	}
+
+	kubeCache, err := in.cache.GetKubeCache(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	var object meta_v1.Object
+	switch kind {
+	case kubernetes.DeploymentType:
+		object, err = kubeCache.GetDeployment(namespace, name)
+	case kubernetes.ServiceType:
+		object, err = kubeCache.GetService(namespace, name)
+	default:
+		return nil, fmt.Errorf("kind not supported: %v", kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	drift := &models.KubernetesDrift{
+		Kind:        kind,
+		Name:        name,
+		Namespace:   namespace,
+		Differences: []jsondiff.Difference{},
+	}
+	lastApplied, found := object.GetAnnotations()[core_v1.LastAppliedConfigAnnotation]
+	if !found {
+		return drift, nil
+	}
+	drift.LastApplied = true
+
+	var applied interface{}
+	if err := json.Unmarshal([]byte(lastApplied), &applied); err != nil {
+		return nil, fmt.Errorf("invalid last applied configuration of %s [%s/%s]: %s", kind, namespace, name, err)
+	}
+	live, err := jsondiff.Normalize(object)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, difference := range jsondiff.Diff(stripServerManagedFields(applied), stripServerManagedFields(live)) {
+		if difference.Operation != jsondiff.OperationAdded {
+			drift.Differences = append(drift.Differences, difference)
+		}
+	}
+	drift.InSync = len(drift.Differences) == 0
+	return drift, nil
+}
+
+// stripServerManagedFields removes from a normalized object its status, its type, which is not set in the objects of the cache,
+// and the metadata managed by the server, including the last applied configuration itself
+func stripServerManagedFields(object interface{}) interface{} {
+	fields, ok := object.(map[string]interface{})
+	if !ok {
+		return object
+	}
+	delete(fields, "apiVersion")
+	delete(fields, "kind")
+	delete(fields, "status")
+	if metadata, ok := fields["metadata"].(map[string]interface{}); ok {
+		for _, field := range serverManagedMetadata {
+			delete(metadata, field)
+		}
+		if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+			delete(annotations, core_v1.LastAppliedConfigAnnotation)
+		}
+	}
+	return fields
+}
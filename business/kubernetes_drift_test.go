@@ -0,0 +1,142 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/util/jsondiff"
+)
+
+// fakeAppliedDeployment returns a Deployment as applied with kubectl, with its last applied configuration
+func fakeAppliedDeployment(t *testing.T, name string, replicas int32) *apps_v1.Deployment {
+	deployment := &apps_v1.Deployment{
+		TypeMeta:   meta_v1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: "bookinfo", Labels: map[string]string{"app": "reviews"}},
+		Spec: apps_v1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &meta_v1.LabelSelector{MatchLabels: map[string]string{"app": "reviews"}},
+			Template: core_v1.PodTemplateSpec{
+				ObjectMeta: meta_v1.ObjectMeta{Labels: map[string]string{"app": "reviews"}},
+				Spec: core_v1.PodSpec{
+					Containers: []core_v1.Container{{Name: "reviews", Image: "docker.io/istio/examples-bookinfo-reviews-v1:1.17.0"}},
+				},
+			},
+		},
+	}
+	applied, err := json.Marshal(deployment)
+	require.NoError(t, err)
+
+	live := deployment.DeepCopy()
+	live.Annotations = map[string]string{
+		core_v1.LastAppliedConfigAnnotation: string(applied),
+		"deployment.kubernetes.io/revision": "1",
+	}
+	// Server-managed fields and defaults, not part of the applied configuration
+	live.ResourceVersion = "1234"
+	live.Generation = 2
+	live.UID = "6f4c7a92-3d1b-4c2a-9f3e-1a2b3c4d5e6f"
+	live.Spec.RevisionHistoryLimit = &replicas
+	live.Spec.Template.Spec.Containers[0].ImagePullPolicy = core_v1.PullIfNotPresent
+	live.Status.ReadyReplicas = replicas
+	return live
+}
+
+func setupDriftWorkloadService(t *testing.T, objects ...runtime.Object) (WorkloadService, *config.Config) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	objects = append(objects, &core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}})
+	k8s := kubetest.NewFakeK8sClient(objects...)
+	SetupBusinessLayer(t, k8s, *conf)
+	t.Cleanup(func() { kialiCache = nil })
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	return NewWithBackends(clients, clients, nil, nil).Workload, conf
+}
+
+func TestComputeKubernetesDriftDeployment(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	inSync := fakeAppliedDeployment(t, "reviews-v1", 1)
+	drifted := fakeAppliedDeployment(t, "reviews-v2", 1)
+	// Scaled and patched out of kubectl apply
+	replicas := int32(3)
+	drifted.Spec.Replicas = &replicas
+	drifted.Spec.Template.Spec.Containers[0].Image = "docker.io/istio/examples-bookinfo-reviews-v2:1.17.0"
+	delete(drifted.Labels, "app")
+	notApplied := &apps_v1.Deployment{ObjectMeta: meta_v1.ObjectMeta{Name: "reviews-v3", Namespace: "bookinfo"}}
+
+	svc, conf := setupDriftWorkloadService(t, inSync, drifted, notApplied)
+	cluster := conf.KubernetesConfig.ClusterName
+
+	drift, err := svc.ComputeKubernetesDrift(context.TODO(), cluster, "bookinfo", kubernetes.DeploymentType, "reviews-v1")
+	require.NoError(err)
+	assert.Equal("Deployment", drift.Kind)
+	assert.Equal("reviews-v1", drift.Name)
+	assert.True(drift.LastApplied)
+	assert.True(drift.InSync)
+	assert.Empty(drift.Differences)
+
+	drift, err = svc.ComputeKubernetesDrift(context.TODO(), cluster, "bookinfo", kubernetes.DeploymentType, "reviews-v2")
+	require.NoError(err)
+	assert.True(drift.LastApplied)
+	assert.False(drift.InSync)
+	assert.Equal([]jsondiff.Difference{
+		{Path: "metadata.labels", Operation: jsondiff.OperationRemoved, From: map[string]interface{}{"app": "reviews"}},
+		{Path: "spec.replicas", Operation: jsondiff.OperationChanged, From: float64(1), To: float64(3)},
+		{Path: "spec.template.spec.containers[0].image", Operation: jsondiff.OperationChanged, From: "docker.io/istio/examples-bookinfo-reviews-v1:1.17.0", To: "docker.io/istio/examples-bookinfo-reviews-v2:1.17.0"},
+	}, drift.Differences)
+
+	drift, err = svc.ComputeKubernetesDrift(context.TODO(), cluster, "bookinfo", kubernetes.DeploymentType, "reviews-v3")
+	require.NoError(err)
+	assert.False(drift.LastApplied)
+	assert.False(drift.InSync)
+	assert.Empty(drift.Differences)
+
+	_, err = svc.ComputeKubernetesDrift(context.TODO(), cluster, "bookinfo", kubernetes.DeploymentType, "missing")
+	require.Error(err)
+	assert.True(errors.IsNotFound(err))
+
+	_, err = svc.ComputeKubernetesDrift(context.TODO(), cluster, "bookinfo", "ConfigMap", "reviews-v1")
+	require.Error(err)
+}
+
+func TestComputeKubernetesDriftService(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	service := &core_v1.Service{
+		TypeMeta:   meta_v1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"},
+		Spec: core_v1.ServiceSpec{
+			Ports:    []core_v1.ServicePort{{Name: "http", Port: 9080}},
+			Selector: map[string]string{"app": "reviews"},
+		},
+	}
+	applied, err := json.Marshal(service)
+	require.NoError(err)
+	service.Annotations = map[string]string{core_v1.LastAppliedConfigAnnotation: string(applied)}
+	// The cluster IP allocated by the server and the label added to the selector are only set in the live object
+	service.Spec.ClusterIP = "10.96.12.34"
+	service.Spec.Selector = map[string]string{"app": "reviews", "version": "v1"}
+
+	svc, conf := setupDriftWorkloadService(t, service)
+	drift, err := svc.ComputeKubernetesDrift(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", kubernetes.ServiceType, "reviews")
+	require.NoError(err)
+	assert.True(drift.LastApplied)
+	assert.True(drift.InSync)
+	assert.Empty(drift.Differences)
+}
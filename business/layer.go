@@ -3,6 +3,7 @@ package business
 import (
 	"context"
 	"sync"
+	"time"
 
 	"k8s.io/client-go/tools/clientcmd/api"
 
@@ -46,8 +47,13 @@ var (
 	kialiCache       cache.KialiCache
 	once             sync.Once
 	prometheusClient prometheus.ClientInterface
+	// Stops the periodic refresh of the clusters in the cache
+	stopClustersRefresh context.CancelFunc
 )
 
+// clustersRefreshInterval is how often the Kiali instances of the clusters in the cache are rediscovered.
+const clustersRefreshInterval = 5 * time.Minute
+
 // sets the global kiali cache var.
 func initKialiCache() {
 	conf := config.Get()
@@ -97,6 +103,13 @@ func initKialiCache() {
 		}
 
 		kialiCache = cache
+
+		// Keep the Kiali instances of the clusters in the cache from going stale.
+		var ctx context.Context
+		ctx, stopClustersRefresh = context.WithCancel(context.Background())
+		SAClients := clientFactory.GetSAClients()
+		saLayer := NewWithBackends(SAClients, SAClients, nil, nil)
+		go saLayer.Mesh.refreshClustersPeriodically(ctx, clustersRefreshInterval)
 	}
 }
 
@@ -175,7 +188,7 @@ func NewWithBackends(userClients map[string]kubernetes.ClientInterface, kialiSAC
 	temporaryLayer.IstioCerts = IstioCertsService{k8s: userClients[homeClusterName], businessLayer: temporaryLayer}
 	temporaryLayer.Jaeger = JaegerService{loader: jaegerClient, businessLayer: temporaryLayer}
 	temporaryLayer.k8sClients = userClients
-	temporaryLayer.Mesh = NewMeshService(userClients[homeClusterName], temporaryLayer, nil)
+	temporaryLayer.Mesh = NewMeshService(userClients[homeClusterName], kialiSAClients, temporaryLayer, nil)
 	temporaryLayer.Namespace = NewNamespaceService(userClients, kialiSAClients)
 	temporaryLayer.OpenshiftOAuth = OpenshiftOAuthService{k8s: userClients[homeClusterName]}
 	temporaryLayer.ProxyStatus = ProxyStatusService{kialiSAClients: kialiSAClients, kialiCache: kialiCache, businessLayer: temporaryLayer}
@@ -183,7 +196,7 @@ func NewWithBackends(userClients map[string]kubernetes.ClientInterface, kialiSAC
 	temporaryLayer.ProxyLogging = ProxyLoggingService{userClients: userClients, proxyStatus: &temporaryLayer.ProxyStatus}
 	temporaryLayer.RegistryStatus = RegistryStatusService{k8s: userClients[homeClusterName], businessLayer: temporaryLayer}
 	temporaryLayer.TLS = TLSService{userClients: userClients, kialiCache: kialiCache, businessLayer: temporaryLayer}
-	temporaryLayer.Svc = SvcService{config: *config.Get(), kialiCache: kialiCache, businessLayer: temporaryLayer, prom: prom, userClients: userClients}
+	temporaryLayer.Svc = SvcService{config: *config.Get(), kialiCache: kialiCache, businessLayer: temporaryLayer, prom: prom, userClients: userClients, kialiSAClients: kialiSAClients}
 	temporaryLayer.TokenReview = NewTokenReview(userClients[homeClusterName])
 	temporaryLayer.Validations = IstioValidationsService{userClients: userClients, businessLayer: temporaryLayer}
 	temporaryLayer.Workload = *NewWorkloadService(userClients, prom, kialiCache, temporaryLayer, config.Get())
@@ -198,6 +211,9 @@ func NewWithBackends(userClients map[string]kubernetes.ClientInterface, kialiSAC
 }
 
 func Stop() {
+	if stopClustersRefresh != nil {
+		stopClustersRefresh()
+	}
 	if kialiCache != nil {
 		kialiCache.Stop()
 	}
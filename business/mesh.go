@@ -9,11 +9,9 @@ import (
 	"time"
 
 	"gopkg.in/yaml.v2"
-	v1 "k8s.io/api/apps/v1"
 	core_v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/rest"
 
 	"github.com/kiali/kiali/config"
@@ -31,8 +29,9 @@ const (
 // when Istio is installed with multi-cluster enabled. Prefer initializing this
 // type via the NewMeshService function.
 type MeshService struct {
-	k8s   kubernetes.ClientInterface
-	layer *Layer
+	k8s            kubernetes.ClientInterface
+	kialiSAClients map[string]kubernetes.ClientInterface
+	layer          *Layer
 
 	// newRemoteClient is a helper variable holding a function that should return an
 	// initialized kubernetes client using the specified config argument. This was created,
@@ -40,53 +39,11 @@ type MeshService struct {
 	newRemoteClient func(config *rest.Config) (kubernetes.ClientInterface, error)
 }
 
-// Cluster holds some metadata about a cluster that is
-// part of the mesh.
-type Cluster struct {
-	// ApiEndpoint is the URL where the Kubernetes/Cluster API Server can be contacted
-	ApiEndpoint string `json:"apiEndpoint"`
+// Cluster holds some metadata about a cluster that is part of the mesh.
+type Cluster = kubernetes.Cluster
 
-	// IsKialiHome specifies if this cluster is hosting this Kiali instance (and the observed Mesh Control Plane)
-	IsKialiHome bool `json:"isKialiHome"`
-
-	// IsGatewayToNamespace specifies the PILOT_SCOPE_GATEWAY_TO_NAMESPACE environment variable in Control PLane
-	IsGatewayToNamespace bool `json:"isGatewayToNamespace"`
-
-	// KialiInstances is the list of Kialis discovered in the cluster.
-	KialiInstances []KialiInstance `json:"kialiInstances"`
-
-	// Name specifies the CLUSTER_ID as known by the Control Plane
-	Name string `json:"name"`
-
-	// Network specifies the logical NETWORK_ID as known by the Control Plane
-	Network string `json:"network"`
-
-	// SecretName is the name of the kubernetes "remote cluster secret" that was mounted to the file system and where data of this cluster was resolved
-	SecretName string `json:"secretName"`
-}
-
-// KialiInstance represents a Kiali installation. It holds some data about
-// where and how Kiali was deployed.
-type KialiInstance struct {
-	// ServiceName is the name of the Kubernetes service associated to the Kiali installation. The Kiali Service is the
-	// entity that is looked for in order to determine if a Kiali instance is available.
-	ServiceName string `json:"serviceName"`
-
-	// Namespace is the name of the namespace where is Kiali installed on.
-	Namespace string `json:"namespace"`
-
-	// OperatorResource contains the namespace and the name of the Kiali CR that the user
-	// created to install Kiali via the operator. This can be blank if the operator wasn't used
-	// to install Kiali. This resource is populated from annotations in the Service. It has
-	// the format "namespace/resource_name".
-	OperatorResource string `json:"operatorResource"`
-
-	// Url is the URI that can be used to access Kiali.
-	Url string `json:"url"`
-
-	// Version is the Kiali version as reported by annotations in the Service.
-	Version string `json:"version"`
-}
+// KialiInstance represents a Kiali installation.
+type KialiInstance = kubernetes.KialiInstance
 
 type meshIdConfig struct {
 	DefaultConfig struct {
@@ -100,10 +57,10 @@ type meshTrafficPolicyConfig struct {
 	} `yaml:"outboundTrafficPolicy,omitempty"`
 }
 
-// NewMeshService initializes a new MeshService structure with the given k8sClients client and
+// NewMeshService initializes a new MeshService structure with the given k8sClients client, Kiali SA clients and
 // newRemoteClientFunc arguments (see the MeshService struct for details). The newRemoteClientFunc
 // can be passed a nil value and a default function will be used.
-func NewMeshService(k8s kubernetes.ClientInterface, layer *Layer, newRemoteClientFunc func(config *rest.Config) (kubernetes.ClientInterface, error)) MeshService {
+func NewMeshService(k8s kubernetes.ClientInterface, kialiSAClients map[string]kubernetes.ClientInterface, layer *Layer, newRemoteClientFunc func(config *rest.Config) (kubernetes.ClientInterface, error)) MeshService {
 	if newRemoteClientFunc == nil {
 		newRemoteClientFunc = func(config *rest.Config) (kubernetes.ClientInterface, error) {
 			return kubernetes.NewClientFromConfig(config)
@@ -112,14 +69,157 @@ func NewMeshService(k8s kubernetes.ClientInterface, layer *Layer, newRemoteClien
 
 	return MeshService{
 		k8s:             k8s,
+		kialiSAClients:  kialiSAClients,
 		layer:           layer,
 		newRemoteClient: newRemoteClientFunc,
 	}
 }
 
-// GetClusters resolves the Kubernetes clusters that are hosting the mesh. Resolution
+// GetClusters returns the Kubernetes clusters that are hosting the mesh. The clusters are
+// taken from the cache, which RefreshClusters keeps up to date. When the cache doesn't have
+// them yet, they are resolved and stored in the cache.
+func (in *MeshService) GetClusters(r *http.Request) ([]Cluster, error) {
+	if kialiCache != nil {
+		if clusters := kialiCache.GetClusters(); clusters != nil {
+			if r != nil {
+				guessKialiURLs(clusters, r)
+			}
+			return clusters, nil
+		}
+	}
+
+	clusters, err := in.resolveClusters(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if kialiCache != nil {
+		kialiCache.SetClusters(clusters)
+	}
+
+	return clusters, nil
+}
+
+// RefreshClusters resolves the clusters again, so the clusters added or removed from the mesh are
+// picked up, rediscovers the Kiali instances of every cluster using the Kiali SA clients and
+// updates the clusters in the cache.
+func (in *MeshService) RefreshClusters(ctx context.Context) ([]Cluster, error) {
+	clusters, err := in.resolveClusters(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var known []Cluster
+	if kialiCache != nil {
+		known = kialiCache.GetClusters()
+	}
+
+	for i := range clusters {
+		// The instances of the home cluster are shared with its resolution, they are copied before being merged.
+		resolved := make([]KialiInstance, len(clusters[i].KialiInstances))
+		copy(resolved, clusters[i].KialiInstances)
+		clusters[i].KialiInstances = resolved
+		for _, k := range known {
+			if k.Name == clusters[i].Name {
+				clusters[i].KialiInstances = mergeKialiInstances(k.KialiInstances, clusters[i].KialiInstances)
+				break
+			}
+		}
+
+		client, ok := in.kialiSAClients[clusters[i].Name]
+		if !ok {
+			log.Debugf("No Kiali SA client for cluster [%s]. Its Kiali instances won't be refreshed", clusters[i].Name)
+			continue
+		}
+
+		instances, err := discoverKialiInstances(client)
+		if err != nil {
+			log.Warningf("Discovery for Kiali instances in cluster [%s] failed, keeping the previously discovered ones: %s", clusters[i].Name, err)
+			continue
+		}
+		clusters[i].KialiInstances = mergeKialiInstances(clusters[i].KialiInstances, instances)
+	}
+
+	if kialiCache != nil {
+		kialiCache.SetClusters(clusters)
+	}
+
+	return clusters, nil
+}
+
+// refreshClustersPeriodically calls RefreshClusters every interval until the context is cancelled.
+func (in *MeshService) refreshClustersPeriodically(ctx context.Context, interval time.Duration) {
+	log.Debug("Starting periodic refresh of the mesh clusters")
+	for {
+		select {
+		case <-ctx.Done():
+			log.Debug("Stopping periodic refresh of the mesh clusters")
+			return
+		case <-time.After(interval):
+			if _, err := in.RefreshClusters(ctx); err != nil {
+				log.Warningf("Unable to refresh the mesh clusters: %s", err)
+			}
+		}
+	}
+}
+
+// discoverKialiInstances finds the Kiali installations of a cluster by looking for the Kiali
+// service across all namespaces.
+func discoverKialiInstances(client kubernetes.ClientInterface) ([]KialiInstance, error) {
+	// The operator and the helm charts set this well known label. It's also
+	// present in the Istio addon manifest of Kiali.
+	services, err := client.GetClusterServicesByLabels("app.kubernetes.io/part-of=kiali")
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	instances := make([]KialiInstance, 0, len(services))
+	for _, svc := range services {
+		instances = append(instances, convertKialiServiceToInstance(&svc))
+	}
+
+	return instances, nil
+}
+
+// mergeKialiInstances returns the discovered instances. The URL of an instance that was already known
+// is kept when the service doesn't set it since it was guessed from a request.
+func mergeKialiInstances(known []KialiInstance, discovered []KialiInstance) []KialiInstance {
+	for i := range discovered {
+		if discovered[i].Url != "" {
+			continue
+		}
+		for _, k := range known {
+			if k.Namespace == discovered[i].Namespace && k.ServiceName == discovered[i].ServiceName {
+				discovered[i].Url = k.Url
+				break
+			}
+		}
+	}
+	return discovered
+}
+
+// guessKialiURLs sets the URL of the Kiali instances of the home cluster that don't have one
+// using the request made to this Kiali.
+func guessKialiURLs(clusters []Cluster, r *http.Request) {
+	for i := range clusters {
+		if !clusters[i].IsKialiHome {
+			continue
+		}
+		// The instances slice may be shared with the cache so it's replaced rather than modified.
+		instances := make([]KialiInstance, len(clusters[i].KialiInstances))
+		copy(instances, clusters[i].KialiInstances)
+		for j := range instances {
+			if len(instances[j].Url) == 0 {
+				instances[j].Url = httputil.GuessKialiURL(r)
+			}
+		}
+		clusters[i].KialiInstances = instances
+	}
+}
+
+// resolveClusters resolves the Kubernetes clusters that are hosting the mesh. Resolution
 // is done as best-effort using the resources that are present in the cluster.
-func (in *MeshService) GetClusters(r *http.Request) (clusters []Cluster, errVal error) {
+func (in *MeshService) resolveClusters(r *http.Request) (clusters []Cluster, errVal error) {
 	var err error
 
 	remoteClusters, err := in.resolveRemoteClustersFromSecrets()
@@ -286,23 +386,12 @@ func findKialiInNamespace(ctx context.Context, namespace string, clusterName str
 	if kialiNs != nil {
 		// The operator and the helm charts set this fixed label. It's also
 		// present in the Istio addon manifest of Kiali.
-		var services []core_v1.Service
-		var getSvcErr error
-		if IsNamespaceCached(kialiNs.Name) {
-			var tmpSvc []core_v1.Service
-			tmpSvc, getSvcErr = kialiCache.GetServices(kialiNs.Name, nil)
-			if getSvcErr == nil {
-				selector := (labels.Set{"app.kubernetes.io/part-of": "kiali"}).AsSelector()
-				services = kubernetes.FilterServicesByLabels(selector, tmpSvc)
-			}
-		} else {
-			k8s, ok := layer.k8sClients[clusterName]
-			if !ok {
-				log.Warningf("Discovery for Kiali instances in cluster [%s] failed: k8s client not found", clusterName)
-				return
-			}
-			services, getSvcErr = k8s.GetServicesByLabels(kialiNs.Name, "app.kubernetes.io/part-of=kiali")
+		k8s, ok := layer.k8sClients[clusterName]
+		if !ok {
+			log.Warningf("Discovery for Kiali instances in cluster [%s] failed: k8s client not found", clusterName)
+			return
 		}
+		services, getSvcErr := newReadThrough(kialiCache, k8s).getServicesByLabels(kialiNs.Name, "app.kubernetes.io/part-of=kiali")
 		if getSvcErr != nil && !errors.IsNotFound(getSvcErr) {
 			log.Warningf("Discovery for Kiali instances in cluster [%s] failed when finding the service in [%s] namespace: %s", clusterName, namespace, getSvcErr.Error())
 			return
@@ -362,13 +451,7 @@ func (in *MeshService) findRemoteKiali(clusterName string, cluster kubernetes.Re
 func (in *MeshService) resolveKialiNetwork() (string, error) {
 	conf := config.Get()
 
-	var istioSidecarConfig *core_v1.ConfigMap
-	var err error
-	if IsNamespaceCached(conf.IstioNamespace) {
-		istioSidecarConfig, err = kialiCache.GetConfigMap(conf.IstioNamespace, conf.ExternalServices.Istio.IstioSidecarInjectorConfigMapName)
-	} else {
-		istioSidecarConfig, err = in.k8s.GetConfigMap(conf.IstioNamespace, conf.ExternalServices.Istio.IstioSidecarInjectorConfigMapName)
-	}
+	istioSidecarConfig, err := newReadThrough(kialiCache, in.k8s).getConfigMap(conf.IstioNamespace, conf.ExternalServices.Istio.IstioSidecarInjectorConfigMapName)
 	if err != nil {
 		// Don't return an error, as this may mean that Kiali is not installed along the control plane.
 		// This setup is OK, it's just that it's not within our multi-cluster assumptions.
@@ -511,13 +594,7 @@ func (in *MeshService) resolveNetwork(clusterName string, cluster kubernetes.Rem
 func (in *MeshService) OutboundTrafficPolicy() (*models.OutboundPolicy, error) {
 	cfg := config.Get()
 	otp := models.OutboundPolicy{Mode: "ALLOW_ANY"}
-	var istioConfig *core_v1.ConfigMap
-	var err error
-	if IsNamespaceCached(cfg.IstioNamespace) {
-		istioConfig, err = kialiCache.GetConfigMap(cfg.IstioNamespace, cfg.ExternalServices.Istio.ConfigMapName)
-	} else {
-		istioConfig, err = in.k8s.GetConfigMap(cfg.IstioNamespace, cfg.ExternalServices.Istio.ConfigMapName)
-	}
+	istioConfig, err := newReadThrough(kialiCache, in.k8s).getConfigMap(cfg.IstioNamespace, cfg.ExternalServices.Istio.ConfigMapName)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			err = fmt.Errorf("%w in namespace [%s]", err, cfg.IstioNamespace)
@@ -547,15 +624,8 @@ func (in *MeshService) OutboundTrafficPolicy() (*models.OutboundPolicy, error) {
 func (in *MeshService) IstiodResourceThresholds() (*models.IstiodThresholds, error) {
 	conf := config.Get()
 
-	var istioDeployment *v1.Deployment
 	istioDeploymentConfig := conf.ExternalServices.Istio.IstiodDeploymentName
-	var err error
-
-	if IsNamespaceCached(conf.IstioNamespace) {
-		istioDeployment, err = kialiCache.GetDeployment(conf.IstioNamespace, istioDeploymentConfig)
-	} else {
-		istioDeployment, err = in.k8s.GetDeployment(conf.IstioNamespace, istioDeploymentConfig)
-	}
+	istioDeployment, err := newReadThrough(kialiCache, in.k8s).getDeployment(conf.IstioNamespace, istioDeploymentConfig)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			log.Debugf("Istiod deployment [%s] not found in namespace [%s]", istioDeploymentConfig, conf.IstioNamespace)
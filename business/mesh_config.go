@@ -0,0 +1,71 @@
+package business
+
+import (
+	"context"
+	"fmt"
+
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// GetMeshConfig returns the mesh config of a control plane revision of a cluster, parsed from the "mesh" entry of its
+// Istio ConfigMap. Like istioctl, the ConfigMap of a revision is named after the Istio ConfigMap suffixed with the
+// revision ("istio-canary"), the default revision using the Istio ConfigMap itself.
+// It uses following parameters:
+// - "cluster":		cluster of the control plane
+// - "revision":	revision of the control plane, empty for the default revision
+func (in *MeshService) GetMeshConfig(ctx context.Context, cluster, revision string) (*models.MeshConfig, error) {
+	var end observability.EndFunc
+	_, end = observability.StartSpan(ctx, "GetMeshConfig",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("revision", revision),
+	)
+	defer end()
+
+	conf := config.Get()
+	if revision == "" {
+		revision = defaultRevision
+	}
+	configMapName := meshConfigMapName(conf, revision)
+
+	kubeCache, err := kialiCache.GetKubeCache(cluster)
+	if err != nil {
+		return nil, err
+	}
+	configMap, err := kubeCache.GetConfigMap(conf.IstioNamespace, configMapName)
+	if err != nil {
+		return nil, err
+	}
+
+	meshConfigYaml, ok := configMap.Data["mesh"]
+	if !ok {
+		return nil, fmt.Errorf("mesh config not found in ConfigMap [%s/%s]", conf.IstioNamespace, configMapName)
+	}
+	meshConfig := map[string]interface{}{}
+	if err := k8syaml.Unmarshal([]byte(meshConfigYaml), &meshConfig); err != nil {
+		return nil, fmt.Errorf("invalid mesh config in ConfigMap [%s/%s]: %s", conf.IstioNamespace, configMapName, err)
+	}
+	// An empty mesh entry parses to nil
+	if meshConfig == nil {
+		meshConfig = map[string]interface{}{}
+	}
+
+	return &models.MeshConfig{
+		Cluster:   cluster,
+		Revision:  revision,
+		ConfigMap: configMapName,
+		Config:    meshConfig,
+	}, nil
+}
+
+// meshConfigMapName returns the name of the Istio ConfigMap of a control plane revision
+func meshConfigMapName(conf *config.Config, revision string) string {
+	if revision == defaultRevision {
+		return conf.ExternalServices.Istio.ConfigMapName
+	}
+	return conf.ExternalServices.Istio.ConfigMapName + "-" + revision
+}
@@ -0,0 +1,119 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+)
+
+// sampleMeshConfig is the mesh entry of an Istio ConfigMap as installed by istioctl with the demo profile
+const sampleMeshConfig = `accessLogFile: /dev/stdout
+defaultConfig:
+  discoveryAddress: istiod.istio-system.svc:15012
+  proxyMetadata: {}
+  tracing:
+    zipkin:
+      address: zipkin.istio-system:9411
+enablePrometheusMerge: true
+extensionProviders:
+- envoyOtelAls:
+    port: 4317
+    service: opentelemetry-collector.istio-system.svc.cluster.local
+  name: otel
+rootNamespace: istio-system
+trustDomain: cluster.local
+`
+
+func setupMeshConfigService(t *testing.T) (MeshService, string) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		&core_v1.ConfigMap{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "istio", Namespace: "istio-system"},
+			Data:       map[string]string{"mesh": sampleMeshConfig, "meshNetworks": "networks: {}"},
+		},
+		&core_v1.ConfigMap{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "istio-canary", Namespace: "istio-system"},
+			Data:       map[string]string{"mesh": "outboundTrafficPolicy:\n  mode: REGISTRY_ONLY\n"},
+		},
+		&core_v1.ConfigMap{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "istio-broken", Namespace: "istio-system"},
+			Data:       map[string]string{"meshNetworks": "networks: {}"},
+		},
+	)
+	SetupBusinessLayer(t, k8s, *conf)
+	t.Cleanup(func() { kialiCache = nil })
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	return NewWithBackends(clients, clients, nil, nil).Mesh, conf.KubernetesConfig.ClusterName
+}
+
+func TestGetMeshConfig(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	svc, cluster := setupMeshConfigService(t)
+
+	meshConfig, err := svc.GetMeshConfig(context.TODO(), cluster, "")
+	require.NoError(err)
+	assert.Equal("default", meshConfig.Revision)
+	assert.Equal("istio", meshConfig.ConfigMap)
+	assert.Equal("/dev/stdout", meshConfig.Config["accessLogFile"])
+	assert.Equal(true, meshConfig.Config["enablePrometheusMerge"])
+	assert.Equal(map[string]interface{}{
+		"discoveryAddress": "istiod.istio-system.svc:15012",
+		"proxyMetadata":    map[string]interface{}{},
+		"tracing": map[string]interface{}{
+			"zipkin": map[string]interface{}{"address": "zipkin.istio-system:9411"},
+		},
+	}, meshConfig.Config["defaultConfig"])
+	assert.Equal([]interface{}{
+		map[string]interface{}{
+			"name": "otel",
+			"envoyOtelAls": map[string]interface{}{
+				"port":    int64(4317),
+				"service": "opentelemetry-collector.istio-system.svc.cluster.local",
+			},
+		},
+	}, meshConfig.Config["extensionProviders"])
+
+	meshConfig, err = svc.GetMeshConfig(context.TODO(), cluster, "default")
+	require.NoError(err)
+	assert.Equal("istio", meshConfig.ConfigMap)
+
+	meshConfig, err = svc.GetMeshConfig(context.TODO(), cluster, "canary")
+	require.NoError(err)
+	assert.Equal("canary", meshConfig.Revision)
+	assert.Equal("istio-canary", meshConfig.ConfigMap)
+	assert.Equal(map[string]interface{}{
+		"outboundTrafficPolicy": map[string]interface{}{"mode": "REGISTRY_ONLY"},
+	}, meshConfig.Config)
+}
+
+func TestGetMeshConfigErrors(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	svc, cluster := setupMeshConfigService(t)
+
+	_, err := svc.GetMeshConfig(context.TODO(), cluster, "missing")
+	require.Error(err)
+	assert.True(errors.IsNotFound(err))
+
+	_, err = svc.GetMeshConfig(context.TODO(), cluster, "broken")
+	require.Error(err)
+
+	_, err = svc.GetMeshConfig(context.TODO(), "unknown", "")
+	require.Error(err)
+}
@@ -1,6 +1,7 @@
 package business
 
 import (
+	"context"
 	"fmt"
 	"net/http/httptest"
 	"os"
@@ -697,3 +698,64 @@ func TestIstiodResourceThresholds(t *testing.T) {
 		})
 	}
 }
+
+func TestRefreshClustersDiscoversKialiInstances(t *testing.T) {
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.InCluster = false
+	conf.KubernetesConfig.ClusterName = "east"
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "istio-system"}},
+		&core_v1.Service{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "kiali",
+				Namespace: "istio-system",
+				Labels: map[string]string{
+					"app.kubernetes.io/part-of": "kiali",
+					"app.kubernetes.io/version": "v1.70.0",
+				},
+				Annotations: map[string]string{
+					"kiali.io/external-url": "https://kiali.east.example.com",
+				},
+			},
+		},
+	)
+	cache := NewTestingCache(t, k8s, *conf)
+	kialiCache = cache
+	SetKialiControlPlaneCluster(&Cluster{Name: "east", IsKialiHome: true})
+	t.Cleanup(func() {
+		kialiCache = nil
+		kialiControlPlaneClusterCached = false
+		kialiControlPlaneCluster = nil
+	})
+	cache.SetClusters([]kubernetes.Cluster{
+		{Name: "east", IsKialiHome: true},
+		{Name: "west"},
+	})
+
+	clients := map[string]kubernetes.ClientInterface{"east": k8s}
+	layer := NewWithBackends(clients, clients, nil, nil)
+
+	clusters, err := layer.Mesh.RefreshClusters(context.Background())
+	require.NoError(err)
+
+	// The clusters are resolved again, the west cluster is no longer part of the mesh.
+	require.Len(clusters, 1)
+	require.Equal("east", clusters[0].Name)
+	require.Len(clusters[0].KialiInstances, 1)
+	instance := clusters[0].KialiInstances[0]
+	require.Equal("kiali", instance.ServiceName)
+	require.Equal("istio-system", instance.Namespace)
+	require.Equal("v1.70.0", instance.Version)
+	require.Equal("https://kiali.east.example.com", instance.Url)
+	require.Empty(kialiControlPlaneCluster.KialiInstances)
+
+	// The refreshed clusters are stored in the cache and served by GetClusters.
+	require.Equal(clusters, cache.GetClusters())
+	cached, err := layer.Mesh.GetClusters(nil)
+	require.NoError(err)
+	require.Equal(clusters, cached)
+}
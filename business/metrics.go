@@ -1,11 +1,14 @@
 package business
 
 import (
+	"fmt"
 	"math"
 	"sort"
 	"strings"
 	"sync"
 
+	"k8s.io/apimachinery/pkg/api/errors"
+
 	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/prometheus"
 )
@@ -26,6 +29,127 @@ func (in *MetricsService) GetMetrics(q models.IstioMetricsQuery, scaler func(n s
 	return in.fetchAllMetrics(q, lb, grouping, scaler)
 }
 
+// destinationWorkloadLabels are the labels service metrics can be grouped by when
+// they are broken down by destination workload.
+var destinationWorkloadLabels = []string{
+	"destination_canonical_revision",
+	"destination_cluster",
+	"destination_version",
+	"destination_workload",
+	"destination_workload_namespace",
+}
+
+// GetServiceMetricsByWorkload returns the inbound metrics of a service grouped by the destination workload
+// and version that served the requests. This is useful to compare the workloads behind the same service
+// e.g. v1 and v2 during a canary rollout. Additional grouping labels must be destination workload labels.
+// A BadRequest error is returned when the query can't be grouped by destination workload.
+func (in *MetricsService) GetServiceMetricsByWorkload(q models.IstioMetricsQuery, scaler func(n string) float64) (models.MetricsMap, error) {
+	if q.Service == "" {
+		return nil, errors.NewBadRequest("metrics by destination workload require a service")
+	}
+	if q.Direction != "inbound" {
+		return nil, errors.NewBadRequest("metrics by destination workload must have 'inbound' direction as they are associated with traffic to the service")
+	}
+
+	grouping := map[string]bool{"destination_workload": true, "destination_version": true}
+	byLabels := []string{"destination_workload", "destination_version"}
+	for _, lbl := range q.ByLabels {
+		if !isDestinationWorkloadLabel(lbl) {
+			return nil, errors.NewBadRequest(fmt.Sprintf("metrics by destination workload cannot be grouped by '%s'. Use: '%s'", lbl, strings.Join(destinationWorkloadLabels, "', '")))
+		}
+		if !grouping[lbl] {
+			grouping[lbl] = true
+			byLabels = append(byLabels, lbl)
+		}
+	}
+	q.ByLabels = byLabels
+
+	return in.GetMetrics(q, scaler)
+}
+
+// MaxMetricsWorkloads is the maximum number of workloads whose metrics can be fetched in a single request
+const MaxMetricsWorkloads = 20
+
+// Each workload already fetches its metrics concurrently, so only a few workloads are fetched at a time
+// to limit the concurrent queries to Prometheus (see https://github.com/kiali/kiali/issues/5584)
+const maxConcurrentWorkloadsMetrics = 4
+
+// GetWorkloadsMetrics returns the metrics of several workloads of a namespace in a single map. The workloads are queried
+// concurrently and each series is tagged by its workload with the workload label of the direction of the query,
+// e.g. destination_workload for inbound metrics, as if the query was grouped by workload.
+// A BadRequest error is returned when no workload is given or when there are more than MaxMetricsWorkloads.
+func (in *MetricsService) GetWorkloadsMetrics(q models.IstioMetricsQuery, workloads []string, scaler func(n string) float64) (models.MetricsMap, error) {
+	uniqueWorkloads := []string{}
+	seen := map[string]bool{}
+	for _, workload := range workloads {
+		if workload != "" && !seen[workload] {
+			seen[workload] = true
+			uniqueWorkloads = append(uniqueWorkloads, workload)
+		}
+	}
+	if len(uniqueWorkloads) == 0 {
+		return nil, errors.NewBadRequest("metrics of workloads require at least one workload")
+	}
+	if len(uniqueWorkloads) > MaxMetricsWorkloads {
+		return nil, errors.NewBadRequest(fmt.Sprintf("metrics of workloads are limited to %d workloads, got %d", MaxMetricsWorkloads, len(uniqueWorkloads)))
+	}
+
+	workloadLabel := destination + "_workload"
+	if q.Direction == "outbound" {
+		workloadLabel = source + "_workload"
+	}
+
+	type workloadMetrics struct {
+		metrics models.MetricsMap
+		err     error
+	}
+	results := make([]workloadMetrics, len(uniqueWorkloads))
+	sem := make(chan struct{}, maxConcurrentWorkloadsMetrics)
+	var wg sync.WaitGroup
+	for i, workload := range uniqueWorkloads {
+		wg.Add(1)
+		go func(i int, wq models.IstioMetricsQuery) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i].metrics, results[i].err = in.GetMetrics(wq, scaler)
+		}(i, models.IstioMetricsQuery{
+			RangeQuery:      q.RangeQuery,
+			Filters:         q.Filters,
+			Cluster:         q.Cluster,
+			Namespace:       q.Namespace,
+			Workload:        workload,
+			Direction:       q.Direction,
+			RequestProtocol: q.RequestProtocol,
+			Reporter:        q.Reporter,
+		})
+	}
+	wg.Wait()
+
+	metrics := make(models.MetricsMap)
+	for i, result := range results {
+		if result.err != nil {
+			return nil, result.err
+		}
+		for name, series := range result.metrics {
+			for _, m := range series {
+				m.Labels[workloadLabel] = uniqueWorkloads[i]
+				metrics[name] = append(metrics[name], m)
+			}
+		}
+	}
+	return metrics, nil
+}
+
+func isDestinationWorkloadLabel(label string) bool {
+	for _, lbl := range destinationWorkloadLabels {
+		if lbl == label {
+			return true
+		}
+	}
+	return false
+}
+
 func createMetricsLabelsBuilder(q *models.IstioMetricsQuery) *MetricsLabelsBuilder {
 	lb := NewMetricsLabelsBuilder(q.Direction)
 	if q.Reporter != "both" {
@@ -2,11 +2,17 @@ package business
 
 import (
 	"fmt"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	prom_v1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
 
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/models"
@@ -218,6 +224,92 @@ func TestGetFilteredAppMetrics(t *testing.T) {
 	assert.NotNil(rqSizeOut)
 }
 
+func TestGetAppMetricsExemplars(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	srv, api, err := setupMocked()
+	require.NoError(err)
+
+	labels := `{reporter="source",source_workload_namespace="bookinfo",source_canonical_service="productpage"}`
+	api.MockRange("sum(rate(istio_requests_total"+labels+"[5m]))", 1.5)
+	api.MockHistoRange("istio_request_duration_milliseconds", labels+"[5m]", 0.35, 0.2, 0.3, 0.4)
+	api.MockHistoRange("istio_request_bytes", labels+"[5m]", 0.35, 0.2, 0.3, 0.4)
+	// The series returned by the mocked range queries
+	seriesLabels := model.LabelSet{"reporter": "destination", "__name__": "whatever", "instance": "whatever", "job": "whatever"}
+	api.OnQueryExemplars("sum(rate(istio_requests_total"+labels+"[5m]))", []prom_v1.ExemplarQueryResult{
+		{
+			SeriesLabels: seriesLabels,
+			Exemplars:    []prom_v1.Exemplar{{Labels: model.LabelSet{"trace_id": "4bf92f3577b34da6a3ce929d0e0e4736"}, Value: 1, Timestamp: 1000}},
+		},
+		{
+			// Not aggregated by the returned series
+			SeriesLabels: model.LabelSet{"reporter": "source"},
+			Exemplars:    []prom_v1.Exemplar{{Labels: model.LabelSet{"trace_id": "00f067aa0ba902b7"}, Value: 1, Timestamp: 1000}},
+		},
+	}, nil)
+	api.OnQueryExemplars("istio_request_duration_milliseconds_bucket"+labels, []prom_v1.ExemplarQueryResult{
+		{
+			SeriesLabels: seriesLabels,
+			Exemplars:    []prom_v1.Exemplar{{Labels: model.LabelSet{"traceID": "a3ce929d0e0e4736", "span_id": "00f067aa"}, Value: 250, Timestamp: 2000}},
+		},
+	}, nil)
+	// A backend without exemplar storage
+	api.OnQueryExemplars("istio_request_bytes_bucket"+labels, nil, fmt.Errorf("exemplar storage is not enabled"))
+
+	q := models.IstioMetricsQuery{
+		Namespace: "bookinfo",
+		App:       "productpage",
+	}
+	q.FillDefaults()
+	q.RateInterval = "5m"
+	q.Exemplars = true
+	q.Filters = []string{"request_count", "request_duration_millis", "request_size"}
+	metrics, err := srv.GetMetrics(q, func(name string) float64 {
+		if name == "request_duration_millis" {
+			return 0.001
+		}
+		return 1.0
+	})
+	require.NoError(err)
+
+	require.Len(metrics["request_count"], 1)
+	assert.Equal([]models.Exemplar{
+		{Labels: map[string]string{"trace_id": "4bf92f3577b34da6a3ce929d0e0e4736"}, TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", Timestamp: 1000, Value: 1},
+	}, metrics["request_count"][0].Exemplars)
+
+	require.Len(metrics["request_duration_millis"], 1)
+	assert.Equal("avg", metrics["request_duration_millis"][0].Stat)
+	assert.Equal([]models.Exemplar{
+		{Labels: map[string]string{"traceID": "a3ce929d0e0e4736", "span_id": "00f067aa"}, TraceID: "a3ce929d0e0e4736", Timestamp: 2000, Value: 0.25},
+	}, metrics["request_duration_millis"][0].Exemplars)
+
+	require.Len(metrics["request_size"], 1)
+	assert.Empty(metrics["request_size"][0].Exemplars)
+	assert.Equal(0.35, metrics["request_size"][0].Datapoints[0].Value)
+}
+
+func TestGetAppMetricsWithoutExemplars(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	srv, api, err := setupMocked()
+	require.NoError(err)
+	api.MockRange(`sum(rate(istio_requests_total{reporter="source",source_workload_namespace="bookinfo",source_canonical_service="productpage"}[5m]))`, 1.5)
+
+	q := models.IstioMetricsQuery{
+		Namespace: "bookinfo",
+		App:       "productpage",
+	}
+	q.FillDefaults()
+	q.RateInterval = "5m"
+	q.Filters = []string{"request_count"}
+	metrics, err := srv.GetMetrics(q, nil)
+	require.NoError(err)
+
+	require.Len(metrics["request_count"], 1)
+	assert.Nil(metrics["request_count"][0].Exemplars)
+	api.AssertNotCalled(t, "QueryExemplars", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestGetAppMetricsInstantRates(t *testing.T) {
 	assert := assert.New(t)
 	srv, api, err := setupMocked()
@@ -468,3 +560,144 @@ func createSample(value float64) *model.Sample {
 		Metric:    model.Metric{},
 	}
 }
+
+func TestGetServiceMetricsByWorkload(t *testing.T) {
+	assert := assert.New(t)
+	srv, api, err := setupMocked()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	q := models.IstioMetricsQuery{
+		Namespace: "bookinfo",
+		Service:   "reviews",
+	}
+	q.FillDefaults()
+	q.Direction = "inbound"
+	q.RateInterval = "5m"
+	q.Quantiles = []string{"0.99"}
+	q.ByLabels = []string{"destination_workload_namespace", "destination_version"}
+
+	var queries int32
+	api.SpyArgumentsAndReturnEmpty(func(args mock.Arguments) {
+		query := args[1].(string)
+		assert.Contains(query, `destination_service_name="reviews"`)
+		if strings.Contains(query, "histogram_quantile") {
+			assert.Contains(query, " by (le,destination_workload,destination_version,destination_workload_namespace)")
+		} else {
+			assert.Contains(query, " by (destination_workload,destination_version,destination_workload_namespace)")
+		}
+		atomic.AddInt32(&queries, 1)
+	})
+
+	_, err = srv.GetServiceMetricsByWorkload(q, nil)
+	assert.NoError(err)
+	assert.NotZero(queries)
+}
+
+func TestGetServiceMetricsByWorkloadBadRequest(t *testing.T) {
+	srv, api, err := setupMocked()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	api.SpyArgumentsAndReturnEmpty(func(args mock.Arguments) {
+		t.Error("Unexpected call to client while having bad request")
+	})
+
+	sourceGrouping := models.IstioMetricsQuery{Namespace: "bookinfo", Service: "reviews", Direction: "inbound"}
+	sourceGrouping.ByLabels = []string{"source_workload"}
+
+	cases := map[string]models.IstioMetricsQuery{
+		"missing service":    {Namespace: "bookinfo", Direction: "inbound"},
+		"outbound direction": {Namespace: "bookinfo", Service: "reviews", Direction: "outbound"},
+		"source grouping":    sourceGrouping,
+	}
+	for name, q := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := srv.GetServiceMetricsByWorkload(q, nil)
+			assert.True(t, errors.IsBadRequest(err), "expected a BadRequest error but got: %v", err)
+		})
+	}
+}
+
+func TestGetWorkloadsMetrics(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	config.Set(config.NewConfig())
+
+	workloads := []string{"reviews-v1", "reviews-v2", "reviews-v3"}
+	prom := new(prometheustest.PromClientMock)
+
+	// Each query waits for the queries of all the workloads to start, they can only all start when run concurrently
+	var started, inFlight, maxInFlight int32
+	allStarted := make(chan struct{})
+	for i, workload := range workloads {
+		wk := workload
+		prom.On("FetchRateRange", "istio_requests_total", mock.MatchedBy(func(lbl []string) bool {
+			return strings.Contains(strings.Join(lbl, ","), `destination_workload="`+wk+`"`)
+		}), "response_code", mock.Anything).Run(func(args mock.Arguments) {
+			current := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				max := atomic.LoadInt32(&maxInFlight)
+				if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+					break
+				}
+			}
+			if atomic.AddInt32(&started, 1) == int32(len(workloads)) {
+				close(allStarted)
+			}
+			select {
+			case <-allStarted:
+			case <-time.After(2 * time.Second):
+			}
+		}).Return(prometheus.Metric{Matrix: model.Matrix{{
+			Metric: model.Metric{"response_code": "200"},
+			Values: []model.SamplePair{{Timestamp: 0, Value: model.SampleValue(i + 1)}},
+		}}})
+	}
+
+	q := models.IstioMetricsQuery{Namespace: "bookinfo"}
+	q.FillDefaults()
+	q.Direction = "inbound"
+	q.Filters = []string{"request_count"}
+	q.ByLabels = []string{"response_code"}
+	// The duplicates are queried once
+	metrics, err := NewMetricsService(prom).GetWorkloadsMetrics(q, append(workloads, "reviews-v1"), nil)
+	require.NoError(err)
+	prom.AssertNumberOfCalls(t, "FetchRateRange", len(workloads))
+	assert.Equal(int32(len(workloads)), maxInFlight)
+
+	// The series are tagged by workload
+	require.Len(metrics["request_count"], len(workloads))
+	for i, workload := range workloads {
+		m := metrics["request_count"][i]
+		assert.Equal(map[string]string{"response_code": "200", "destination_workload": workload}, m.Labels)
+		assert.Equal(float64(i+1), m.Datapoints[0].Value)
+	}
+}
+
+func TestGetWorkloadsMetricsBadRequest(t *testing.T) {
+	config.Set(config.NewConfig())
+	prom := new(prometheustest.PromClientMock)
+	srv := NewMetricsService(prom)
+
+	tooMany := []string{}
+	for i := 0; i <= MaxMetricsWorkloads; i++ {
+		tooMany = append(tooMany, fmt.Sprintf("workload-%d", i))
+	}
+	cases := map[string][]string{
+		"no workload":        {},
+		"empty workload":     {""},
+		"too many workloads": tooMany,
+	}
+	for name, workloads := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := srv.GetWorkloadsMetrics(models.IstioMetricsQuery{Namespace: "bookinfo", Direction: "inbound"}, workloads, nil)
+			assert.True(t, errors.IsBadRequest(err), "expected a BadRequest error but got: %v", err)
+		})
+	}
+	prom.AssertNotCalled(t, "FetchRateRange", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
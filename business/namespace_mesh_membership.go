@@ -0,0 +1,80 @@
+package business
+
+import (
+	"context"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// IsAmbientEnabledForNamespace returns true when the namespace is labeled for the ambient dataplane mode
+func IsAmbientEnabledForNamespace(ns models.Namespace) bool {
+	return ns.Labels[models.AmbientDataplaneModeLabel] == "ambient"
+}
+
+// isInjectionEnabledForNamespace returns true when the namespace enables the sidecar injection,
+// by the injection label or by the revision label. The injection label disabling it has precedence.
+func isInjectionEnabledForNamespace(ns models.Namespace, conf *config.Config) bool {
+	switch ns.Labels[conf.IstioLabels.InjectionLabelName] {
+	case "enabled":
+		return true
+	case "disabled":
+		return false
+	}
+	return ns.Labels[conf.IstioLabels.InjectionLabelRev] != ""
+}
+
+// GetNamespacesMeshMembership returns the namespaces of GetNamespaces, across the clusters, classified by their mesh membership.
+// A namespace enabling the sidecar injection or labeled for ambient is a mesh member, otherwise it is one when any of its pods
+// has a sidecar or is captured by ambient.
+func (in *NamespaceService) GetNamespacesMeshMembership(ctx context.Context) ([]models.Namespace, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetNamespacesMeshMembership",
+		observability.Attribute("package", "business"),
+	)
+	defer end()
+
+	namespaces, err := in.GetNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := config.Get()
+	// The namespaces may come from the cache, don't modify them
+	classified := make([]models.Namespace, len(namespaces))
+	for i, ns := range namespaces {
+		if ns.MeshMember, err = in.meshMembership(ns, conf); err != nil {
+			return nil, err
+		}
+		classified[i] = ns
+	}
+	return classified, nil
+}
+
+func (in *NamespaceService) meshMembership(ns models.Namespace, conf *config.Config) (models.MeshMembership, error) {
+	if isInjectionEnabledForNamespace(ns, conf) {
+		return models.MeshMemberInjection, nil
+	}
+	if IsAmbientEnabledForNamespace(ns) {
+		return models.MeshMemberAmbient, nil
+	}
+	if kialiCache == nil {
+		return models.MeshMemberNone, nil
+	}
+
+	kubeCache, err := kialiCache.GetKubeCache(ns.Cluster)
+	if err != nil {
+		return "", err
+	}
+	pods, err := kubeCache.GetPods(ns.Name, "")
+	if err != nil {
+		return "", err
+	}
+	mPods := models.Pods{}
+	mPods.Parse(pods)
+	if mPods.HasAnyIstioSidecar() || mPods.HasAnyAmbient() {
+		return models.MeshMemberWorkloads, nil
+	}
+	return models.MeshMemberNone, nil
+}
@@ -0,0 +1,78 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+func TestGetNamespacesMeshMembership(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	fakeNamespace := func(name string, labels map[string]string) *core_v1.Namespace {
+		return &core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	fakePod := func(name, namespace string, annotations map[string]string) *core_v1.Pod {
+		return &core_v1.Pod{
+			ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: namespace, Annotations: annotations},
+			Spec:       core_v1.PodSpec{Containers: []core_v1.Container{{Name: "app"}, {Name: "istio-proxy"}}},
+		}
+	}
+
+	k8s := kubetest.NewFakeK8sClient(
+		fakeNamespace("bookinfo", map[string]string{"istio-injection": "enabled"}),
+		fakeNamespace("travels", map[string]string{"istio.io/rev": "canary"}),
+		// The injection label disabling the injection has precedence on the revision label
+		fakeNamespace("legacy", map[string]string{"istio-injection": "disabled", "istio.io/rev": "canary"}),
+		fakeNamespace("ambient", map[string]string{"istio.io/dataplane-mode": "ambient"}),
+		fakeNamespace("meshed", nil),
+		fakePod("reviews-v1", "meshed", map[string]string{"sidecar.istio.io/status": `{"containers":["istio-proxy"]}`}),
+		fakeNamespace("captured", nil),
+		fakePod("ratings-v1", "captured", map[string]string{"ambient.istio.io/redirection": "enabled"}),
+		fakeNamespace("plain", nil),
+		fakePod("nginx", "plain", nil),
+	)
+	k8s.OpenShift = false
+	SetupBusinessLayer(t, k8s, *conf)
+	// The namespaces are cached by token in the global cache
+	t.Cleanup(func() { kialiCache = nil })
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	nsService := NewWithBackends(clients, clients, nil, nil).Namespace
+	namespaces, err := nsService.GetNamespacesMeshMembership(context.TODO())
+	require.NoError(err)
+
+	membership := map[string]models.MeshMembership{}
+	for _, ns := range namespaces {
+		membership[ns.Name] = ns.MeshMember
+	}
+	assert.Equal(map[string]models.MeshMembership{
+		"bookinfo": models.MeshMemberInjection,
+		"travels":  models.MeshMemberInjection,
+		"legacy":   models.MeshMemberNone,
+		"ambient":  models.MeshMemberAmbient,
+		"meshed":   models.MeshMemberWorkloads,
+		"captured": models.MeshMemberWorkloads,
+		"plain":    models.MeshMemberNone,
+	}, membership)
+
+	// The namespaces of GetNamespaces are not classified
+	namespaces, err = nsService.GetNamespaces(context.TODO())
+	require.NoError(err)
+	for _, ns := range namespaces {
+		assert.Empty(ns.MeshMember)
+	}
+}
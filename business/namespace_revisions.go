@@ -0,0 +1,58 @@
+package business
+
+import (
+	"context"
+	"sort"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// GetNamespacesByRevision returns the accessible namespaces of a cluster grouped by the control plane revision injecting them,
+// sorted by revision, to see which namespaces are on a canary during an upgrade. The revision of a namespace is its revision
+// label (istio.io/rev), the namespaces without it falling back to the default revision tag. Like Istio, the injection label
+// (istio-injection=enabled) has precedence on the revision label. The revision tags are resolved to the revisions they point to.
+// The namespaces come from GetNamespaces so the namespace cache is reused.
+func (in *NamespaceService) GetNamespacesByRevision(ctx context.Context, cluster string) ([]models.RevisionNamespaces, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetNamespacesByRevision",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+	)
+	defer end()
+
+	namespaces, err := in.GetNamespacesForCluster(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := config.Get()
+	tags := revisionTags(in.kialiSAClients[cluster], conf)
+	namespacesByRevision := map[string][]string{}
+	for _, ns := range namespaces {
+		revision := resolveRevision(tags, namespaceRevision(ns, conf))
+		namespacesByRevision[revision] = append(namespacesByRevision[revision], ns.Name)
+	}
+
+	revisions := make([]models.RevisionNamespaces, 0, len(namespacesByRevision))
+	for revision, names := range namespacesByRevision {
+		sort.Strings(names)
+		revisions = append(revisions, models.RevisionNamespaces{Revision: revision, Namespaces: names})
+	}
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision < revisions[j].Revision
+	})
+	return revisions, nil
+}
+
+// namespaceRevision returns the revision, or revision tag, injecting a namespace
+func namespaceRevision(ns models.Namespace, conf *config.Config) string {
+	if ns.Labels[conf.IstioLabels.InjectionLabelName] == "enabled" {
+		return defaultRevision
+	}
+	if revision := ns.Labels[conf.IstioLabels.InjectionLabelRev]; revision != "" {
+		return revision
+	}
+	return defaultRevision
+}
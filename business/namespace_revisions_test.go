@@ -0,0 +1,60 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+func TestGetNamespacesByRevision(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	fakeNamespace := func(name string, labels map[string]string) *core_v1.Namespace {
+		return &core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	k8s := kubetest.NewFakeK8sClient(
+		fakeNamespace("bookinfo", map[string]string{"istio.io/rev": "canary"}),
+		fakeNamespace("travels", map[string]string{"istio.io/rev": "canary"}),
+		fakeNamespace("legacy", map[string]string{"istio.io/rev": "1-17-2"}),
+		// The injection label has precedence on the revision label
+		fakeNamespace("mixed", map[string]string{"istio-injection": "enabled", "istio.io/rev": "canary"}),
+		fakeNamespace("plain", nil),
+		fakeNamespace("stable", map[string]string{"istio.io/rev": "prod-stable"}),
+		fakeRevisionTagWebhook("default", "1-18-0"),
+		fakeRevisionTagWebhook("prod-stable", "1-17-2"),
+	)
+	k8s.OpenShift = false
+	SetupBusinessLayer(t, k8s, *conf)
+	// The namespaces are cached by token in the global cache
+	t.Cleanup(func() { kialiCache = nil })
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	nsService := NewWithBackends(clients, clients, nil, nil).Namespace
+	revisions, err := nsService.GetNamespacesByRevision(context.TODO(), conf.KubernetesConfig.ClusterName)
+	require.NoError(err)
+
+	// The revision tags are resolved to their revisions
+	assert.Equal([]models.RevisionNamespaces{
+		{Revision: "1-17-2", Namespaces: []string{"legacy", "stable"}},
+		{Revision: "1-18-0", Namespaces: []string{"mixed", "plain"}},
+		{Revision: "canary", Namespaces: []string{"bookinfo", "travels"}},
+	}, revisions)
+
+	// No namespace on an unknown cluster
+	revisions, err = nsService.GetNamespacesByRevision(context.TODO(), "unknown")
+	require.NoError(err)
+	assert.Empty(revisions)
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 
 	osproject_v1 "github.com/openshift/api/project/v1"
+	auth_v1 "k8s.io/api/authorization/v1"
 	core_v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -77,7 +79,7 @@ func (in *NamespaceService) GetNamespaces(ctx context.Context) ([]models.Namespa
 	defer end()
 
 	if kialiCache != nil && in.homeClusterUserClient != nil {
-		if ns := kialiCache.GetNamespaces(in.homeClusterUserClient.GetToken()); ns != nil {
+		if ns := kialiCache.GetNamespaces(kubernetes.UserCacheKey(in.homeClusterUserClient)); ns != nil {
 			return ns, nil
 		}
 	}
@@ -268,7 +270,7 @@ func (in *NamespaceService) GetNamespaces(ctx context.Context) ([]models.Namespa
 	// store only the filtered set of namespaces in cache for the token
 	if kialiCache != nil && in.homeClusterUserClient != nil {
 		// just get the home cluster token because it is assumed tokens are identical across all clusters
-		kialiCache.SetNamespaces(in.homeClusterUserClient.GetToken(), resultns)
+		kialiCache.SetNamespaces(kubernetes.UserCacheKey(in.homeClusterUserClient), resultns)
 	}
 
 	return resultns, nil
@@ -413,6 +415,118 @@ func (in *NamespaceService) GetNamespacesForCluster(ctx context.Context, cluster
 	return clusterNamespaces, nil
 }
 
+// istioConfigGroups are the api groups of the objects Kiali manages as Istio config
+var istioConfigGroups = []string{
+	kubernetes.NetworkingGroupVersionV1Beta1.Group,
+	kubernetes.SecurityGroupVersion.Group,
+	kubernetes.ExtensionGroupVersionV1Alpha1.Group,
+	kubernetes.TelemetryGroupV1Alpha1.Group,
+	kubernetes.K8sNetworkingGroupVersionV1Beta1.Group,
+}
+
+// istioConfigWriteVerbs are the verbs Kiali uses to write Istio config, the same ones checked by getPermissionsApi
+var istioConfigWriteVerbs = []string{"create", "patch", "delete"}
+
+// istioConfigResources are the resources of the objects Kiali manages as Istio config, as named by the RBAC rules.
+// The Gateway API Gateways share their resource name with the Istio ones.
+var istioConfigResources = []string{
+	kubernetes.DestinationRules, kubernetes.EnvoyFilters, kubernetes.Gateways, kubernetes.ServiceEntries, kubernetes.Sidecars,
+	kubernetes.VirtualServices, kubernetes.WorkloadEntries, kubernetes.WorkloadGroups, kubernetes.WasmPlugins, kubernetes.Telemetries,
+	kubernetes.AuthorizationPolicies, kubernetes.PeerAuthentications, kubernetes.RequestAuthentications, "httproutes",
+}
+
+// GetNamespaceAccess returns, per cluster, the namespaces the user can access and whether the user
+// can write Istio config in each of them. The namespaces come from GetNamespaces so the namespace cache
+// is reused. The capabilities are computed with one SelfSubjectRulesReview per namespace rather than
+// a SelfSubjectAccessReview per resource and verb.
+func (in *NamespaceService) GetNamespaceAccess(ctx context.Context) ([]models.ClusterAccess, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetNamespaceAccess",
+		observability.Attribute("package", "business"),
+	)
+	defer end()
+
+	namespaces, err := in.GetNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	viewOnlyMode := config.Get().Deployment.ViewOnlyMode
+	if viewOnlyMode {
+		log.Debug("View only mode configured, skipping RBAC checks")
+	}
+
+	accessByCluster := make(map[string][]models.NamespaceAccess)
+	mu := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	for _, ns := range namespaces {
+		access := models.NamespaceAccess{Name: ns.Name}
+		client, ok := in.userClients[ns.Cluster]
+		if viewOnlyMode || !ok {
+			accessByCluster[ns.Cluster] = append(accessByCluster[ns.Cluster], access)
+			continue
+		}
+
+		wg.Add(1)
+		go func(cluster string, client kubernetes.ClientInterface, access models.NamespaceAccess) {
+			defer wg.Done()
+			review, err := client.GetSelfSubjectRulesReview(ctx, access.Name)
+			if err != nil {
+				log.Errorf("Error getting permissions [namespace: %s, cluster: %s]: %v", access.Name, cluster, err)
+			} else {
+				if review.Status.Incomplete {
+					log.Debugf("Permissions for namespace [%s] in cluster [%s] may be incomplete: %s", access.Name, cluster, review.Status.EvaluationError)
+				}
+				access.CanWriteIstioConfig = canWriteIstioConfig(review.Status.ResourceRules)
+			}
+
+			mu.Lock()
+			accessByCluster[cluster] = append(accessByCluster[cluster], access)
+			mu.Unlock()
+		}(ns.Cluster, client, access)
+	}
+	wg.Wait()
+
+	clusterAccess := make([]models.ClusterAccess, 0, len(accessByCluster))
+	for cluster, nsAccess := range accessByCluster {
+		sort.Slice(nsAccess, func(i, j int) bool { return nsAccess[i].Name < nsAccess[j].Name })
+		clusterAccess = append(clusterAccess, models.ClusterAccess{Cluster: cluster, Namespaces: nsAccess})
+	}
+	sort.Slice(clusterAccess, func(i, j int) bool { return clusterAccess[i].Cluster < clusterAccess[j].Cluster })
+
+	return clusterAccess, nil
+}
+
+// canWriteIstioConfig returns true when any of the rules allows writing objects of an Istio config group and type.
+// Rules restricted to resource names are ignored since they don't allow creating new objects.
+func canWriteIstioConfig(rules []auth_v1.ResourceRule) bool {
+	for _, rule := range rules {
+		if len(rule.ResourceNames) > 0 || len(rule.Resources) == 0 {
+			continue
+		}
+		if matchesAny(rule.APIGroups, istioConfigGroups) && matchesAny(rule.Resources, istioConfigResources) &&
+			matchesAny(rule.Verbs, istioConfigWriteVerbs) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAny returns true if values contains the wildcard or any of the candidates.
+func matchesAny(values []string, candidates []string) bool {
+	for _, v := range values {
+		if v == "*" {
+			return true
+		}
+		for _, c := range candidates {
+			if v == c {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // addIncludedNamespaces will look at all the namespaces and return all of them that match the Include list.
 // The returned results will be guaranteed to include the namespaces found in the given seed list.
 // There will be no duplicate namespaces in the returned list.
@@ -532,7 +646,7 @@ func (in *NamespaceService) GetNamespaceByCluster(ctx context.Context, namespace
 
 	// Cache already has included/excluded namespaces applied
 	if kialiCache != nil && in.homeClusterUserClient != nil {
-		if ns := kialiCache.GetNamespace(in.homeClusterUserClient.GetToken(), namespace, cluster); ns != nil {
+		if ns := kialiCache.GetNamespace(kubernetes.UserCacheKey(in.homeClusterUserClient), namespace, cluster); ns != nil {
 			return ns, nil
 		}
 	}
@@ -644,8 +758,8 @@ func (in *NamespaceService) UpdateNamespace(ctx context.Context, namespace strin
 func (in *NamespaceService) getNamespacesUsingKialiSA(cluster string, labelSelector string, forwardedError error) ([]core_v1.Namespace, error) {
 	// Check if we already are using the Kiali ServiceAccount token. If we are, no need to do further processing, since
 	// this would just circle back to the same results.
-	kialiToken := in.kialiSAClients[cluster].GetToken()
-	if in.userClients[cluster].GetToken() == kialiToken {
+	// An impersonating client also uses the Kiali token, but on behalf of the impersonated user.
+	if kubernetes.UserCacheKey(in.userClients[cluster]) == kubernetes.UserCacheKey(in.kialiSAClients[cluster]) {
 		return nil, forwardedError
 	}
 
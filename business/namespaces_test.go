@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	auth_v1 "k8s.io/api/authorization/v1"
 	core_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -272,4 +273,93 @@ func TestGetNamespacesForbiddenCached(t *testing.T) {
 	require.Error(err)
 }
 
+// setupRestrictedUser returns a client for a user that can only access the alpha and beta namespaces
+// and can only write Istio config in alpha.
+func setupRestrictedUser(conf *config.Config) *kubetest.FakeK8sClient {
+	conf.Deployment.AccessibleNamespaces = []string{"alpha", "beta"}
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "alpha"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "beta"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "gamma"}},
+	)
+	k8s.OpenShift = false
+	readRule := auth_v1.ResourceRule{Verbs: []string{"get", "list", "watch"}, APIGroups: []string{"*"}, Resources: []string{"*"}}
+	k8s.ResourceRules = map[string][]auth_v1.ResourceRule{
+		"alpha": {
+			readRule,
+			{Verbs: []string{"create", "patch", "delete"}, APIGroups: []string{"networking.istio.io"}, Resources: []string{"virtualservices"}},
+		},
+		"beta": {
+			readRule,
+			// Only an existing object can be patched so this doesn't count as being able to write Istio config.
+			{Verbs: []string{"patch"}, APIGroups: []string{"networking.istio.io"}, Resources: []string{"virtualservices"}, ResourceNames: []string{"reviews"}},
+			{Verbs: []string{"*"}, APIGroups: []string{"apps"}, Resources: []string{"deployments"}},
+			// Any group, but not an Istio config type
+			{Verbs: []string{"*"}, APIGroups: []string{"*"}, Resources: []string{"pods"}},
+		},
+		"gamma": {
+			{Verbs: []string{"*"}, APIGroups: []string{"*"}, Resources: []string{"*"}},
+		},
+	}
+	return k8s
+}
+
+func TestCanWriteIstioConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	write := []string{"create", "patch", "delete"}
+	assert.True(canWriteIstioConfig([]auth_v1.ResourceRule{{Verbs: write, APIGroups: []string{"networking.istio.io"}, Resources: []string{"virtualservices"}}}))
+	assert.True(canWriteIstioConfig([]auth_v1.ResourceRule{{Verbs: []string{"*"}, APIGroups: []string{"*"}, Resources: []string{"*"}}}))
+	assert.True(canWriteIstioConfig([]auth_v1.ResourceRule{{Verbs: write, APIGroups: []string{"*"}, Resources: []string{"authorizationpolicies"}}}))
+	assert.True(canWriteIstioConfig([]auth_v1.ResourceRule{{Verbs: write, APIGroups: []string{"gateway.networking.k8s.io"}, Resources: []string{"httproutes"}}}))
+	// The group matches, not the resources
+	assert.False(canWriteIstioConfig([]auth_v1.ResourceRule{{Verbs: []string{"*"}, APIGroups: []string{"*"}, Resources: []string{"pods"}}}))
+	assert.False(canWriteIstioConfig([]auth_v1.ResourceRule{{Verbs: write, APIGroups: []string{"networking.istio.io"}, Resources: []string{"deployments"}}}))
+	// The resources match, not the group
+	assert.False(canWriteIstioConfig([]auth_v1.ResourceRule{{Verbs: write, APIGroups: []string{"apps"}, Resources: []string{"virtualservices"}}}))
+}
+
+func TestGetNamespaceAccess(t *testing.T) {
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	k8s := setupRestrictedUser(conf)
+	SetupBusinessLayer(t, k8s, *conf)
+
+	nsservice := setupNamespaceService(k8s, conf)
+	access, err := nsservice.GetNamespaceAccess(context.TODO())
+	require.NoError(err)
+
+	require.Equal([]models.ClusterAccess{
+		{
+			Cluster: conf.KubernetesConfig.ClusterName,
+			Namespaces: []models.NamespaceAccess{
+				{Name: "alpha", CanWriteIstioConfig: true},
+				{Name: "beta", CanWriteIstioConfig: false},
+			},
+		},
+	}, access)
+}
+
+func TestGetNamespaceAccessViewOnlyMode(t *testing.T) {
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.Deployment.ViewOnlyMode = true
+	k8s := setupRestrictedUser(conf)
+	SetupBusinessLayer(t, k8s, *conf)
+
+	nsservice := setupNamespaceService(k8s, conf)
+	access, err := nsservice.GetNamespaceAccess(context.TODO())
+	require.NoError(err)
+
+	require.Len(access, 1)
+	require.Len(access[0].Namespaces, 2)
+	for _, ns := range access[0].Namespaces {
+		require.False(ns.CanWriteIstioConfig, "namespace %s should not be writable in view only mode", ns.Name)
+	}
+}
+
 // TODO: Add projects tests
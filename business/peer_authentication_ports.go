@@ -0,0 +1,89 @@
+package business
+
+import (
+	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/kiali/kiali/business/checkers"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+)
+
+// getPeerAuthnWorkloadPorts returns the ports exposed by the pods each PeerAuthentication selects, keyed by
+// checkers.PeerAuthnPortsKey: their container ports and the target ports of the services selecting them.
+// The PeerAuthentications selecting no pods are not in the result. The namespaces whose pods or services
+// can't be fetched are skipped, their port-level overrides are not validated.
+func (in *IstioValidationsService) getPeerAuthnWorkloadPorts(cluster string, peerAuthns []*security_v1beta.PeerAuthentication) map[string]map[uint32]bool {
+	workloadPorts := make(map[string]map[uint32]bool)
+	if kialiCache == nil {
+		return workloadPorts
+	}
+	kubeCache, err := kialiCache.GetKubeCache(cluster)
+	if err != nil {
+		log.Warningf("Port-level mTLS of the PeerAuthentications not validated: %s", err)
+		return workloadPorts
+	}
+
+	peerAuthnsPerNamespace := make(map[string][]*security_v1beta.PeerAuthentication)
+	for _, pa := range peerAuthns {
+		// Only the PeerAuthentications with a workload selector can override the mTLS of a port
+		if pa.Spec.Selector != nil && len(pa.Spec.PortLevelMtls) > 0 {
+			peerAuthnsPerNamespace[pa.Namespace] = append(peerAuthnsPerNamespace[pa.Namespace], pa)
+		}
+	}
+	for namespace, namespacePeerAuthns := range peerAuthnsPerNamespace {
+		pods, err := kubeCache.GetPods(namespace, "")
+		if err != nil {
+			log.Warningf("Port-level mTLS of the PeerAuthentications of namespace [%s] not validated: %s", namespace, err)
+			continue
+		}
+		services, err := kubeCache.GetServices(namespace, nil)
+		if err != nil {
+			log.Warningf("Port-level mTLS of the PeerAuthentications of namespace [%s] not validated: %s", namespace, err)
+			continue
+		}
+		for _, pod := range pods {
+			ports := podPorts(pod, services)
+			for _, pa := range kubernetes.FilterPeerAuthenticationsBySelector(labels.Set(pod.Labels).AsSelector().String(), namespacePeerAuthns) {
+				key := checkers.PeerAuthnPortsKey(pa)
+				if workloadPorts[key] == nil {
+					workloadPorts[key] = make(map[uint32]bool)
+				}
+				for _, port := range ports {
+					workloadPorts[key][port] = true
+				}
+			}
+		}
+	}
+	return workloadPorts
+}
+
+// podPorts returns the container ports of the pod and the numeric target ports of the services selecting it.
+// The named target ports resolve to container ports.
+func podPorts(pod core_v1.Pod, services []core_v1.Service) []uint32 {
+	ports := []uint32{}
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			ports = append(ports, uint32(port.ContainerPort))
+		}
+	}
+	for _, svc := range services {
+		if len(svc.Spec.Selector) == 0 || !labels.SelectorFromSet(svc.Spec.Selector).Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		for _, port := range svc.Spec.Ports {
+			if port.TargetPort.Type == intstr.String {
+				continue
+			}
+			// The target port defaults to the port
+			targetPort := port.TargetPort.IntVal
+			if targetPort == 0 {
+				targetPort = port.Port
+			}
+			ports = append(ports, uint32(targetPort))
+		}
+	}
+	return ports
+}
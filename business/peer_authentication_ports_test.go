@@ -0,0 +1,93 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/kiali/kiali/business/checkers"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestPeerAuthenticationPortLevelMtlsValidations(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.ConfigMap{ObjectMeta: meta_v1.ObjectMeta{Name: "istio", Namespace: "istio-system"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Pod{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "reviews-v1", Namespace: "bookinfo", Labels: map[string]string{"app": "reviews"}},
+			Spec: core_v1.PodSpec{Containers: []core_v1.Container{
+				{Name: "reviews", Ports: []core_v1.ContainerPort{{ContainerPort: 9080}}},
+			}},
+		},
+		&core_v1.Pod{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "ratings-v1", Namespace: "bookinfo", Labels: map[string]string{"app": "ratings"}},
+		},
+		// Selects no pods, its port-level override is not validated
+		&core_v1.Service{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "ratings", Namespace: "bookinfo"},
+			Spec: core_v1.ServiceSpec{
+				Selector: map[string]string{"app": "ratings"},
+				Ports:    []core_v1.ServicePort{{Port: 80, TargetPort: intstr.FromInt(9080)}},
+			},
+		},
+		data.CreateEmptyMeshPeerAuthentication("default", data.CreateMTLS("STRICT")),
+		data.AddPortLevelMtlsToPeerAuthn(9080, "STRICT",
+			data.CreateEmptyPeerAuthenticationWithSelector("reviews", "bookinfo", data.CreateOneLabelSelector("reviews"))),
+		data.AddPortLevelMtlsToPeerAuthn(8080, "STRICT",
+			data.CreateEmptyPeerAuthenticationWithSelector("ratings", "bookinfo", data.CreateOneLabelSelector("ratings"))),
+		data.AddPortLevelMtlsToPeerAuthn(8080, "STRICT",
+			data.CreateEmptyPeerAuthenticationWithSelector("details", "bookinfo", data.CreateOneLabelSelector("details"))),
+		data.CreateEmptyPeerAuthentication("default", "bookinfo", data.CreateMTLS("STRICT")),
+	)
+	SetupBusinessLayer(t, k8s, *conf)
+	t.Cleanup(func() { kialiCache = nil })
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	vs := NewWithBackends(clients, clients, nil, nil).Validations
+	validations, err := vs.GetValidations(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "", "")
+	require.NoError(err)
+
+	key := func(name string) models.IstioValidationKey {
+		return models.IstioValidationKey{ObjectType: checkers.PeerAuthenticationCheckerType, Name: name, Namespace: "bookinfo", Cluster: conf.KubernetesConfig.ClusterName}
+	}
+
+	// The override of the container port is valid
+	require.Contains(validations, key("reviews"))
+	assert.True(validations[key("reviews")].Valid)
+	assert.Empty(validations[key("reviews")].Checks)
+
+	// The pods of ratings only expose the target port of the service
+	require.Contains(validations, key("ratings"))
+	ratings := validations[key("ratings")]
+	require.Len(ratings.Checks, 1)
+	assert.Equal("KIA0507", ratings.Checks[0].Code)
+	assert.Equal("spec/portLevelMtls/8080", ratings.Checks[0].Path)
+
+	// Only the missing workload is reported
+	require.Contains(validations, key("details"))
+	for _, check := range validations[key("details")].Checks {
+		assert.NotEqual("KIA0507", check.Code)
+	}
+
+	// No port-level override
+	require.Contains(validations, key("default"))
+	for _, check := range validations[key("default")].Checks {
+		assert.NotEqual("KIA0507", check.Code)
+	}
+}
@@ -3,10 +3,13 @@ package business
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/kubernetes/cache"
 	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
 )
 
 type ProxyStatusService struct {
@@ -19,6 +22,82 @@ func (in *ProxyStatusService) GetPodProxyStatus(cluster, ns, pod string) *models
 	return castProxyStatus(kialiCache.GetPodProxyStatus(cluster, ns, pod))
 }
 
+// ProxyStatusCriteria filters and pages the proxy statuses of the mesh.
+// An empty Cluster or Namespace matches all of them. Page starts at 1.
+type ProxyStatusCriteria struct {
+	Cluster   string
+	Namespace string
+	Page      int
+	PageSize  int
+}
+
+// GetProxyStatuses returns a page of the proxy statuses known by istiod, correlated with the namespaces
+// of their pods so only the proxies of the namespaces accessible to the user are returned.
+// The proxies are sorted by cluster, namespace and pod.
+func (in *ProxyStatusService) GetProxyStatuses(ctx context.Context, criteria ProxyStatusCriteria) (*models.ProxyStatusList, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetProxyStatuses",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", criteria.Cluster),
+		observability.Attribute("namespace", criteria.Namespace),
+	)
+	defer end()
+
+	if criteria.Page < 1 || criteria.PageSize < 1 {
+		return nil, fmt.Errorf("invalid page [%d] or page size [%d]", criteria.Page, criteria.PageSize)
+	}
+
+	namespaces, err := in.businessLayer.Namespace.GetNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	accessibleNamespaces := make(map[string]bool, len(namespaces))
+	for _, ns := range namespaces {
+		accessibleNamespaces[ns.Cluster+"/"+ns.Name] = true
+	}
+
+	items := []models.ProxyStatusItem{}
+	for _, ps := range in.kialiCache.GetProxyStatuses(criteria.Cluster, criteria.Namespace) {
+		// Expected format <pod-name>.<namespace>
+		podId := strings.Split(ps.ProxyID, ".")
+		if len(podId) != 2 || !accessibleNamespaces[ps.ClusterID+"/"+podId[1]] {
+			continue
+		}
+		items = append(items, models.ProxyStatusItem{
+			Cluster:      ps.ClusterID,
+			Namespace:    podId[1],
+			Pod:          podId[0],
+			ProxyVersion: ps.ProxyVersion,
+			IstioVersion: ps.IstioVersion,
+			Status:       *castProxyStatus(ps),
+		})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Cluster != items[j].Cluster {
+			return items[i].Cluster < items[j].Cluster
+		}
+		if items[i].Namespace != items[j].Namespace {
+			return items[i].Namespace < items[j].Namespace
+		}
+		return items[i].Pod < items[j].Pod
+	})
+
+	proxyStatuses := &models.ProxyStatusList{
+		Total:    len(items),
+		Page:     criteria.Page,
+		PageSize: criteria.PageSize,
+		Items:    []models.ProxyStatusItem{},
+	}
+	if start := (criteria.Page - 1) * criteria.PageSize; start < len(items) {
+		stop := start + criteria.PageSize
+		if stop > len(items) {
+			stop = len(items)
+		}
+		proxyStatuses.Items = items[start:stop]
+	}
+	return proxyStatuses, nil
+}
+
 func castProxyStatus(ps *kubernetes.ProxyStatus) *models.ProxyStatus {
 	if ps == nil {
 		return nil
@@ -106,3 +185,124 @@ func buildDump(dump *kubernetes.ConfigDump, resource string, namespaces []models
 
 	return response, err
 }
+
+// GetRegistryEndpointsHealth correlates the Istio registry endpoints of a service with the health that
+// the proxy of the given pod reports for them, so it is known which endpoints are actually serving.
+func (in *ProxyStatusService) GetRegistryEndpointsHealth(cluster, namespace, service, proxyNamespace, proxyPod string) ([]models.EndpointHealth, error) {
+	criteria := RegistryCriteria{
+		Namespace:   namespace,
+		ServiceName: service,
+	}
+	registryEndpoints, err := in.businessLayer.RegistryStatus.GetRegistryEndpoints(criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	kialiSAClient, ok := in.kialiSAClients[cluster]
+	if !ok {
+		return nil, fmt.Errorf("cluster [%s] not found", cluster)
+	}
+
+	dump, err := kialiSAClient.GetConfigDumpWithEndpoints(proxyNamespace, proxyPod)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildEndpointsHealth(registryEndpoints, namespace, service, dump)
+}
+
+func buildEndpointsHealth(registryEndpoints []*kubernetes.RegistryEndpoint, namespace, service string, dump *kubernetes.ConfigDump) ([]models.EndpointHealth, error) {
+	endpointDump, err := dump.GetEndpoints()
+	if err != nil {
+		return nil, err
+	}
+
+	// Health statuses reported by the proxy per outbound cluster host, port and endpoint address.
+	// An endpoint shows up once per subset of the service.
+	proxyStatuses := make(map[string][]string)
+	for _, endpointConfigs := range [][]kubernetes.EnvoyEndpointConfig{endpointDump.DynamicEndpointConfigs, endpointDump.StaticEndpointConfigs} {
+		for _, endpointConfig := range endpointConfigs {
+			// outbound|<port>|<subset>|<hostname>
+			parts := strings.Split(endpointConfig.EndpointConfig.ClusterName, "|")
+			if len(parts) != 4 || parts[0] != "outbound" {
+				continue
+			}
+			for _, locality := range endpointConfig.EndpointConfig.Endpoints {
+				for _, lbEndpoint := range locality.LbEndpoints {
+					socketAddress := lbEndpoint.Endpoint.Address.SocketAddress
+					key := endpointHealthKey(parts[3], parts[1], socketAddress.Address, uint32(socketAddress.PortValue))
+					proxyStatuses[key] = append(proxyStatuses[key], lbEndpoint.HealthStatus)
+				}
+			}
+		}
+	}
+
+	endpointsHealth := []models.EndpointHealth{}
+	seen := make(map[string]bool)
+	for _, registryEndpoint := range registryEndpoints {
+		for _, ep := range registryEndpoint.Endpoints {
+			if ep.Service.Attributes.Namespace != namespace || ep.Service.Attributes.Name != service {
+				continue
+			}
+			key := endpointHealthKey(ep.Service.Hostname, fmt.Sprint(ep.ServicePort.Port), ep.Endpoint.Address, ep.Endpoint.EndpointPort)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			healthStatus := endpointHealthStatus(ep.Endpoint.HealthStatus, proxyStatuses[key])
+			endpointsHealth = append(endpointsHealth, models.EndpointHealth{
+				Address:      ep.Endpoint.Address,
+				Port:         ep.Endpoint.EndpointPort,
+				PortName:     ep.ServicePort.Name,
+				Workload:     ep.Endpoint.WorkloadName,
+				Namespace:    ep.Endpoint.Namespace,
+				HealthStatus: healthStatus,
+				Serving:      healthStatus == models.EndpointHealthy || healthStatus == models.EndpointDegraded,
+			})
+		}
+	}
+
+	return endpointsHealth, nil
+}
+
+func endpointHealthKey(hostname, servicePort, address string, port uint32) string {
+	return fmt.Sprintf("%s|%s|%s:%d", hostname, servicePort, address, port)
+}
+
+// proxyHealthStatus converts an Envoy health status. The severity orders them from the best to the worst.
+func proxyHealthStatus(envoyStatus string) (healthStatus string, severity int) {
+	switch envoyStatus {
+	case "DEGRADED":
+		return models.EndpointDegraded, 1
+	case "DRAINING":
+		return models.EndpointDraining, 2
+	case "UNHEALTHY", "TIMEOUT":
+		return models.EndpointUnhealthy, 3
+	}
+	// Envoy doesn't dump the UNKNOWN status but it balances traffic to those endpoints as healthy ones.
+	return models.EndpointHealthy, 0
+}
+
+// endpointHealthStatus returns the worst of the statuses the proxy reports for an endpoint.
+// Istio doesn't mark an endpoint unhealthy when the proxy ejects it by outlier detection, so an
+// endpoint unhealthy for the proxy while it isn't for the registry has been ejected.
+func endpointHealthStatus(registryStatus int32, proxyStatuses []string) string {
+	if len(proxyStatuses) == 0 {
+		return models.EndpointMissing
+	}
+
+	worstProxyStatus := proxyStatuses[0]
+	healthStatus, worst := proxyHealthStatus(worstProxyStatus)
+	for _, proxyStatus := range proxyStatuses[1:] {
+		if status, severity := proxyHealthStatus(proxyStatus); severity > worst {
+			worstProxyStatus, healthStatus, worst = proxyStatus, status, severity
+		}
+	}
+
+	// 2 is UnHealthy in the registry. TIMEOUT comes from health checking, not from outlier detection.
+	if worstProxyStatus == "UNHEALTHY" && registryStatus != 2 {
+		return models.EndpointEjected
+	}
+	return healthStatus
+}
@@ -0,0 +1,316 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/cache"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+const registryEndpointsJSON = `[
+  {
+    "svc": "reviews.bookinfo.svc.cluster.local",
+    "ep": [
+      {
+        "service": {"Attributes": {"Name": "reviews", "Namespace": "bookinfo"}, "hostname": "reviews.bookinfo.svc.cluster.local"},
+        "servicePort": {"name": "http", "port": 9080, "protocol": "HTTP"},
+        "endpoint": {"Address": "10.0.0.1", "EndpointPort": 9080, "WorkloadName": "reviews-v1", "Namespace": "bookinfo", "HealthStatus": 1}
+      },
+      {
+        "service": {"Attributes": {"Name": "reviews", "Namespace": "bookinfo"}, "hostname": "reviews.bookinfo.svc.cluster.local"},
+        "servicePort": {"name": "http", "port": 9080, "protocol": "HTTP"},
+        "endpoint": {"Address": "10.0.0.2", "EndpointPort": 9080, "WorkloadName": "reviews-v2", "Namespace": "bookinfo", "HealthStatus": 1}
+      },
+      {
+        "service": {"Attributes": {"Name": "reviews", "Namespace": "bookinfo"}, "hostname": "reviews.bookinfo.svc.cluster.local"},
+        "servicePort": {"name": "http", "port": 9080, "protocol": "HTTP"},
+        "endpoint": {"Address": "10.0.0.3", "EndpointPort": 9080, "WorkloadName": "reviews-v3", "Namespace": "bookinfo", "HealthStatus": 2}
+      },
+      {
+        "service": {"Attributes": {"Name": "reviews", "Namespace": "bookinfo"}, "hostname": "reviews.bookinfo.svc.cluster.local"},
+        "servicePort": {"name": "http", "port": 9080, "protocol": "HTTP"},
+        "endpoint": {"Address": "10.0.0.4", "EndpointPort": 9080, "WorkloadName": "reviews-v4", "Namespace": "bookinfo"}
+      },
+      {
+        "service": {"Attributes": {"Name": "reviews", "Namespace": "bookinfo"}, "hostname": "reviews.bookinfo.svc.cluster.local"},
+        "servicePort": {"name": "http", "port": 9080, "protocol": "HTTP"},
+        "endpoint": {"Address": "10.0.0.5", "EndpointPort": 9080, "WorkloadName": "reviews-v5", "Namespace": "bookinfo"}
+      }
+    ]
+  },
+  {
+    "svc": "details.bookinfo.svc.cluster.local",
+    "ep": [
+      {
+        "service": {"Attributes": {"Name": "details", "Namespace": "bookinfo"}, "hostname": "details.bookinfo.svc.cluster.local"},
+        "servicePort": {"name": "http", "port": 9080, "protocol": "HTTP"},
+        "endpoint": {"Address": "10.0.1.1", "EndpointPort": 9080, "WorkloadName": "details-v1", "Namespace": "bookinfo", "HealthStatus": 1}
+      }
+    ]
+  },
+  {
+    "svc": "api.example.com",
+    "ep": [
+      {
+        "service": {"Attributes": {"Name": "api.example.com", "Namespace": "bookinfo", "ServiceRegistry": "External"}, "hostname": "api.example.com"},
+        "servicePort": {"name": "https", "port": 443, "protocol": "TLS"},
+        "endpoint": {"Address": "api-1.example.com", "EndpointPort": 443}
+      },
+      {
+        "service": {"Attributes": {"Name": "api.example.com", "Namespace": "bookinfo", "ServiceRegistry": "External"}, "hostname": "api.example.com"},
+        "servicePort": {"name": "https", "port": 443, "protocol": "TLS"},
+        "endpoint": {"Address": "api-2.example.com", "EndpointPort": 443}
+      }
+    ]
+  }
+]`
+
+const endpointsConfigDumpJSON = `{
+  "configs": [
+    {
+      "@type": "type.googleapis.com/envoy.admin.v3.EndpointsConfigDump",
+      "dynamic_endpoint_configs": [
+        {
+          "endpoint_config": {
+            "cluster_name": "outbound|9080||reviews.bookinfo.svc.cluster.local",
+            "endpoints": [
+              {
+                "lb_endpoints": [
+                  {"endpoint": {"address": {"socket_address": {"address": "10.0.0.1", "port_value": 9080}}}, "health_status": "HEALTHY"},
+                  {"endpoint": {"address": {"socket_address": {"address": "10.0.0.2", "port_value": 9080}}}, "health_status": "UNHEALTHY"},
+                  {"endpoint": {"address": {"socket_address": {"address": "10.0.0.3", "port_value": 9080}}}, "health_status": "UNHEALTHY"},
+                  {"endpoint": {"address": {"socket_address": {"address": "10.0.0.5", "port_value": 9080}}}}
+                ]
+              }
+            ]
+          }
+        },
+        {
+          "endpoint_config": {
+            "cluster_name": "outbound|9080|v5|reviews.bookinfo.svc.cluster.local",
+            "endpoints": [
+              {
+                "lb_endpoints": [
+                  {"endpoint": {"address": {"socket_address": {"address": "10.0.0.5", "port_value": 9080}}}, "health_status": "DEGRADED"}
+                ]
+              }
+            ]
+          }
+        },
+        {
+          "endpoint_config": {
+            "cluster_name": "inbound|9080||",
+            "endpoints": [
+              {
+                "lb_endpoints": [
+                  {"endpoint": {"address": {"socket_address": {"address": "10.0.0.4", "port_value": 9080}}}, "health_status": "HEALTHY"}
+                ]
+              }
+            ]
+          }
+        }
+      ],
+      "static_endpoint_configs": [
+        {
+          "endpoint_config": {
+            "cluster_name": "outbound|443||api.example.com",
+            "endpoints": [
+              {
+                "lb_endpoints": [
+                  {"endpoint": {"address": {"socket_address": {"address": "api-1.example.com", "port_value": 443}}}, "health_status": "HEALTHY"},
+                  {"endpoint": {"address": {"socket_address": {"address": "api-2.example.com", "port_value": 443}}}, "health_status": "TIMEOUT"}
+                ]
+              }
+            ]
+          }
+        }
+      ]
+    }
+  ]
+}`
+
+func setupProxyStatusServiceWithRegistry(t *testing.T) ProxyStatusService {
+	t.Helper()
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	var registryEndpoints []*kubernetes.RegistryEndpoint
+	require.NoError(t, json.Unmarshal([]byte(registryEndpointsJSON), &registryEndpoints))
+	var dump kubernetes.ConfigDump
+	require.NoError(t, json.Unmarshal([]byte(endpointsConfigDumpJSON), &dump))
+
+	k8s := kubetest.NewFakeK8sClient(&core_v1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "bookinfo"}})
+	cache := SetupBusinessLayer(t, k8s, *conf)
+	cache.SetRegistryStatus(&kubernetes.RegistryStatus{Endpoints: registryEndpoints})
+
+	saClient := new(kubetest.K8SClientMock)
+	saClient.On("GetConfigDumpWithEndpoints", "bookinfo", "productpage-v1").Return(&dump, nil)
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	return ProxyStatusService{
+		kialiSAClients: map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: saClient},
+		businessLayer:  NewWithBackends(clients, clients, nil, nil),
+	}
+}
+
+func TestGetRegistryEndpointsHealth(t *testing.T) {
+	require := require.New(t)
+	proxyStatus := setupProxyStatusServiceWithRegistry(t)
+
+	endpointsHealth, err := proxyStatus.GetRegistryEndpointsHealth(config.Get().KubernetesConfig.ClusterName, "bookinfo", "reviews", "bookinfo", "productpage-v1")
+	require.NoError(err)
+	require.Equal([]models.EndpointHealth{
+		{Address: "10.0.0.1", Port: 9080, PortName: "http", Workload: "reviews-v1", Namespace: "bookinfo", HealthStatus: models.EndpointHealthy, Serving: true},
+		// Healthy for the registry but unhealthy for the proxy: ejected by outlier detection.
+		{Address: "10.0.0.2", Port: 9080, PortName: "http", Workload: "reviews-v2", Namespace: "bookinfo", HealthStatus: models.EndpointEjected, Serving: false},
+		{Address: "10.0.0.3", Port: 9080, PortName: "http", Workload: "reviews-v3", Namespace: "bookinfo", HealthStatus: models.EndpointUnhealthy, Serving: false},
+		// Only known by the proxy as an inbound endpoint.
+		{Address: "10.0.0.4", Port: 9080, PortName: "http", Workload: "reviews-v4", Namespace: "bookinfo", HealthStatus: models.EndpointMissing, Serving: false},
+		// The worst status across the subsets is reported.
+		{Address: "10.0.0.5", Port: 9080, PortName: "http", Workload: "reviews-v5", Namespace: "bookinfo", HealthStatus: models.EndpointDegraded, Serving: true},
+	}, endpointsHealth)
+}
+
+func TestGetRegistryEndpointsHealthDNSServiceEntry(t *testing.T) {
+	require := require.New(t)
+	proxyStatus := setupProxyStatusServiceWithRegistry(t)
+
+	endpointsHealth, err := proxyStatus.GetRegistryEndpointsHealth(config.Get().KubernetesConfig.ClusterName, "bookinfo", "api.example.com", "bookinfo", "productpage-v1")
+	require.NoError(err)
+	require.Equal([]models.EndpointHealth{
+		{Address: "api-1.example.com", Port: 443, PortName: "https", HealthStatus: models.EndpointHealthy, Serving: true},
+		{Address: "api-2.example.com", Port: 443, PortName: "https", HealthStatus: models.EndpointUnhealthy, Serving: false},
+	}, endpointsHealth)
+}
+
+func TestGetRegistryEndpointsHealthUnknownCluster(t *testing.T) {
+	proxyStatus := setupProxyStatusServiceWithRegistry(t)
+
+	_, err := proxyStatus.GetRegistryEndpointsHealth("unknown", "bookinfo", "reviews", "bookinfo", "productpage-v1")
+	require.Error(t, err)
+}
+
+func fakeProxyStatus(cluster, proxyID string) *kubernetes.ProxyStatus {
+	return &kubernetes.ProxyStatus{SyncStatus: kubernetes.SyncStatus{
+		ClusterID:     cluster,
+		ProxyID:       proxyID,
+		ProxyVersion:  "1.18.0",
+		IstioVersion:  "1.18.0",
+		ClusterSent:   "nonce",
+		ClusterAcked:  "nonce",
+		EndpointSent:  "nonce",
+		EndpointAcked: "nonce",
+		ListenerSent:  "nonce",
+		ListenerAcked: "nonce",
+		RouteSent:     "nonce",
+	}}
+}
+
+// proxyStatusCache returns fixed proxy statuses instead of the ones polled from istiod.
+type proxyStatusCache struct {
+	cache.KialiCache
+	proxyStatuses []*kubernetes.ProxyStatus
+}
+
+func (c *proxyStatusCache) GetProxyStatuses(cluster, namespace string) []*kubernetes.ProxyStatus {
+	proxyStatuses := []*kubernetes.ProxyStatus{}
+	for _, ps := range c.proxyStatuses {
+		if (cluster == "" || cluster == ps.ClusterID) && (namespace == "" || strings.HasSuffix(ps.ProxyID, "."+namespace)) {
+			proxyStatuses = append(proxyStatuses, ps)
+		}
+	}
+	return proxyStatuses
+}
+
+func setupProxyStatusServiceWithProxies(t *testing.T) (ProxyStatusService, string) {
+	t.Helper()
+
+	conf := config.NewConfig()
+	config.Set(conf)
+	cluster := conf.KubernetesConfig.ClusterName
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "travels"}},
+	)
+	cache := SetupBusinessLayer(t, k8s, *conf)
+	WithKialiCache(&proxyStatusCache{KialiCache: cache, proxyStatuses: []*kubernetes.ProxyStatus{
+		fakeProxyStatus(cluster, "reviews-v2.bookinfo"),
+		fakeProxyStatus(cluster, "reviews-v1.bookinfo"),
+		fakeProxyStatus(cluster, "details-v1.bookinfo"),
+		fakeProxyStatus(cluster, "cars-v1.travels"),
+		// Not accessible by the user
+		fakeProxyStatus(cluster, "istiod-7b69f8d5c4.istio-system"),
+	}})
+
+	clients := map[string]kubernetes.ClientInterface{cluster: k8s}
+	layer := NewWithBackends(clients, clients, nil, nil)
+	return layer.ProxyStatus, cluster
+}
+
+func TestGetProxyStatuses(t *testing.T) {
+	require := require.New(t)
+	proxyStatus, cluster := setupProxyStatusServiceWithProxies(t)
+
+	proxyStatuses, err := proxyStatus.GetProxyStatuses(context.TODO(), ProxyStatusCriteria{Page: 1, PageSize: 3})
+	require.NoError(err)
+	require.Equal(4, proxyStatuses.Total)
+	require.Len(proxyStatuses.Items, 3)
+	require.Equal(models.ProxyStatusItem{
+		Cluster:      cluster,
+		Namespace:    "bookinfo",
+		Pod:          "details-v1",
+		ProxyVersion: "1.18.0",
+		IstioVersion: "1.18.0",
+		Status:       models.ProxyStatus{CDS: "Synced", EDS: "Synced", LDS: "Synced", RDS: "Stale (Never Acknowledged)"},
+	}, proxyStatuses.Items[0])
+	require.Equal("reviews-v1", proxyStatuses.Items[1].Pod)
+	require.Equal("reviews-v2", proxyStatuses.Items[2].Pod)
+
+	proxyStatuses, err = proxyStatus.GetProxyStatuses(context.TODO(), ProxyStatusCriteria{Page: 2, PageSize: 3})
+	require.NoError(err)
+	require.Equal(4, proxyStatuses.Total)
+	require.Len(proxyStatuses.Items, 1)
+	require.Equal("travels", proxyStatuses.Items[0].Namespace)
+
+	proxyStatuses, err = proxyStatus.GetProxyStatuses(context.TODO(), ProxyStatusCriteria{Page: 3, PageSize: 3})
+	require.NoError(err)
+	require.Equal(4, proxyStatuses.Total)
+	require.Empty(proxyStatuses.Items)
+}
+
+func TestGetProxyStatusesByNamespace(t *testing.T) {
+	require := require.New(t)
+	proxyStatus, cluster := setupProxyStatusServiceWithProxies(t)
+
+	proxyStatuses, err := proxyStatus.GetProxyStatuses(context.TODO(), ProxyStatusCriteria{Cluster: cluster, Namespace: "travels", Page: 1, PageSize: 10})
+	require.NoError(err)
+	require.Equal(1, proxyStatuses.Total)
+	require.Equal("cars-v1", proxyStatuses.Items[0].Pod)
+
+	proxyStatuses, err = proxyStatus.GetProxyStatuses(context.TODO(), ProxyStatusCriteria{Namespace: "istio-system", Page: 1, PageSize: 10})
+	require.NoError(err)
+	require.Zero(proxyStatuses.Total)
+
+	proxyStatuses, err = proxyStatus.GetProxyStatuses(context.TODO(), ProxyStatusCriteria{Cluster: "unknown", Page: 1, PageSize: 10})
+	require.NoError(err)
+	require.Zero(proxyStatuses.Total)
+}
+
+func TestGetProxyStatusesInvalidPage(t *testing.T) {
+	proxyStatus, _ := setupProxyStatusServiceWithProxies(t)
+
+	_, err := proxyStatus.GetProxyStatuses(context.TODO(), ProxyStatusCriteria{Page: 0, PageSize: 10})
+	require.Error(t, err)
+}
@@ -0,0 +1,68 @@
+package business
+
+import (
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/cache"
+)
+
+// readThrough reads the resources of a cluster from the Kiali cache when their namespace is cached
+// and from the cluster API otherwise, so both paths return the same results to the callers.
+type readThrough struct {
+	kubeCache cache.KubeCache
+	k8s       kubernetes.ClientInterface
+}
+
+// newReadThrough returns a readThrough for the cache and the client of a cluster. The cache can be nil
+// when it is disabled, then the resources are always read through the client.
+func newReadThrough(kubeCache cache.KubeCache, k8s kubernetes.ClientInterface) readThrough {
+	return readThrough{kubeCache: kubeCache, k8s: k8s}
+}
+
+func (in readThrough) isCached(namespace string) bool {
+	return in.kubeCache != nil && in.kubeCache.CheckNamespace(namespace)
+}
+
+func (in readThrough) getConfigMap(namespace, name string) (*core_v1.ConfigMap, error) {
+	if in.isCached(namespace) {
+		return in.kubeCache.GetConfigMap(namespace, name)
+	}
+	return in.k8s.GetConfigMap(namespace, name)
+}
+
+func (in readThrough) getDeployment(namespace, name string) (*apps_v1.Deployment, error) {
+	if in.isCached(namespace) {
+		return in.kubeCache.GetDeployment(namespace, name)
+	}
+	return in.k8s.GetDeployment(namespace, name)
+}
+
+// getServicesByLabels returns the services of the namespace whose labels match the labels selector.
+// The list is empty, not nil, when no service matches.
+func (in readThrough) getServicesByLabels(namespace, labelsSelector string) ([]core_v1.Service, error) {
+	var services []core_v1.Service
+	if in.isCached(namespace) {
+		selector, err := labels.Parse(labelsSelector)
+		if err != nil {
+			return nil, err
+		}
+		allServices, err := in.kubeCache.GetServices(namespace, nil)
+		if err != nil {
+			return nil, err
+		}
+		services = kubernetes.FilterServicesByLabels(selector, allServices)
+	} else {
+		var err error
+		services, err = in.k8s.GetServicesByLabels(namespace, labelsSelector)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if services == nil {
+		services = []core_v1.Service{}
+	}
+	return services, nil
+}
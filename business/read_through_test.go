@@ -0,0 +1,99 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes/cache"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+)
+
+func fakeReadThroughObjects(namespaces ...string) []runtime.Object {
+	objects := []runtime.Object{}
+	for _, namespace := range namespaces {
+		objects = append(objects,
+			&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: namespace}},
+			&core_v1.ConfigMap{ObjectMeta: meta_v1.ObjectMeta{Name: "istio", Namespace: namespace}},
+			&apps_v1.Deployment{ObjectMeta: meta_v1.ObjectMeta{Name: "istiod", Namespace: namespace}},
+			&core_v1.Service{ObjectMeta: meta_v1.ObjectMeta{Name: "kiali", Namespace: namespace, Labels: map[string]string{"app.kubernetes.io/part-of": "kiali"}}},
+			&core_v1.Service{ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: namespace, Labels: map[string]string{"app": "reviews"}}},
+		)
+	}
+	return objects
+}
+
+// newReadThroughTestCache returns a cache of the bookinfo namespace only, the travels namespace is not cached
+func newReadThroughTestCache(t *testing.T) cache.KubeCache {
+	conf := config.NewConfig()
+	conf.Deployment.AccessibleNamespaces = []string{"bookinfo", "travels"}
+	conf.Deployment.ClusterWideAccess = false
+	conf.KubernetesConfig.CacheNamespaces = []string{"bookinfo"}
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(fakeReadThroughObjects("bookinfo", "travels")...)
+	k8s.OpenShift = false
+	kubeCache := SetupBusinessLayer(t, k8s, *conf)
+	// The global cache is set by the business layer
+	t.Cleanup(func() { kialiCache = nil })
+	return kubeCache
+}
+
+func assertReadThrough(t *testing.T, rt readThrough, namespace string) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	cm, err := rt.getConfigMap(namespace, "istio")
+	require.NoError(err)
+	assert.Equal("istio", cm.Name)
+
+	deployment, err := rt.getDeployment(namespace, "istiod")
+	require.NoError(err)
+	assert.Equal("istiod", deployment.Name)
+
+	services, err := rt.getServicesByLabels(namespace, "app.kubernetes.io/part-of=kiali")
+	require.NoError(err)
+	require.Len(services, 1)
+	assert.Equal("kiali", services[0].Name)
+
+	// Both paths return an empty list when no service matches
+	services, err = rt.getServicesByLabels(namespace, "app=ratings")
+	require.NoError(err)
+	assert.NotNil(services)
+	assert.Empty(services)
+}
+
+func TestReadThroughCachedNamespace(t *testing.T) {
+	kubeCache := newReadThroughTestCache(t)
+	require.True(t, kubeCache.CheckNamespace("bookinfo"))
+
+	// The client doesn't have the resources, they are read from the cache
+	rt := newReadThrough(kubeCache, kubetest.NewFakeK8sClient())
+	assertReadThrough(t, rt, "bookinfo")
+}
+
+func TestReadThroughNotCachedNamespace(t *testing.T) {
+	kubeCache := newReadThroughTestCache(t)
+	require.False(t, kubeCache.CheckNamespace("travels"))
+
+	k8s := kubetest.NewFakeK8sClient(fakeReadThroughObjects("travels")...)
+	assertReadThrough(t, newReadThrough(kubeCache, k8s), "travels")
+
+	_, err := newReadThrough(kubeCache, kubetest.NewFakeK8sClient()).getConfigMap("travels", "istio")
+	assert.Error(t, err)
+}
+
+func TestReadThroughWithoutCache(t *testing.T) {
+	var kubeCache cache.KialiCache
+	k8s := kubetest.NewFakeK8sClient(fakeReadThroughObjects("bookinfo")...)
+	assertReadThrough(t, newReadThrough(kubeCache, k8s), "bookinfo")
+
+	_, err := newReadThrough(nil, k8s).getDeployment("bookinfo", "details")
+	assert.Error(t, err)
+}
@@ -2,10 +2,12 @@ package business
 
 import (
 	"sync"
+	"time"
 
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/clientcmd/api"
 
+	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/log"
 )
@@ -63,6 +65,34 @@ func (in *RegistryStatusService) GetRegistryServices(criteria RegistryCriteria)
 	return registryServices, nil
 }
 
+// RegistryFreshness tells how old the registry data served from the cache is
+type RegistryFreshness struct {
+	FetchedAt time.Time
+	Age       time.Duration
+	// Stale is set when the age exceeds the registry stale threshold of the configuration
+	Stale bool
+}
+
+// GetRegistryFreshness returns the freshness of the registry data, or nil when there is no registry data.
+// The registry may be older than the cache duration when istiod can't be reached to refresh it.
+func (in *RegistryStatusService) GetRegistryFreshness() *RegistryFreshness {
+	if kialiCache == nil {
+		return nil
+	}
+	registryStatus := kialiCache.GetRegistryStatus()
+	if registryStatus == nil || registryStatus.FetchedAt.IsZero() {
+		return nil
+	}
+
+	age := time.Since(registryStatus.FetchedAt)
+	threshold := time.Duration(config.Get().ExternalServices.Istio.RegistryStaleThreshold) * time.Second
+	return &RegistryFreshness{
+		FetchedAt: registryStatus.FetchedAt,
+		Age:       age,
+		Stale:     threshold > 0 && age > threshold,
+	}
+}
+
 func filterRegistryConfiguration(registryStatus *kubernetes.RegistryStatus, criteria RegistryCriteria) *kubernetes.RegistryConfiguration {
 	filtered := kubernetes.RegistryConfiguration{}
 	if registryStatus == nil {
@@ -227,6 +257,11 @@ func (in *RegistryStatusService) checkAndRefresh() error {
 			registryStatus, err := in.refreshRegistryStatus()
 			if err != nil {
 				refreshLock.Unlock()
+				// When enabled, serve the previous registry while istiod can't be reached, its age tells it is not current
+				if previous := kialiCache.GetRegistryStatus(); config.Get().ExternalServices.Istio.RegistryServeStale && previous != nil && !previous.FetchedAt.IsZero() {
+					log.Warningf("Registry could not be refreshed, serving the registry fetched at %s: %s", previous.FetchedAt.Format(time.RFC3339), err)
+					return nil
+				}
 				return err
 			}
 			kialiCache.SetRegistryStatus(registryStatus)
@@ -286,6 +321,7 @@ func (in *RegistryStatusService) refreshRegistryStatus() (*kubernetes.RegistrySt
 		Configuration: registryConfiguration,
 		Endpoints:     registryEndpoints,
 		Services:      registryServices,
+		FetchedAt:     time.Now(),
 	}
 
 	return &registryStatus, nil
@@ -0,0 +1,102 @@
+package business
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+)
+
+func TestGetRegistryFreshness(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.RegistryStaleThreshold = 5 * 60
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}})
+	cache := SetupBusinessLayer(t, k8s, *conf)
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	registryStatusService := NewWithBackends(clients, clients, nil, nil).RegistryStatus
+
+	// The registry was not fetched from istiod
+	assert.Nil(registryStatusService.GetRegistryFreshness())
+
+	fetchedAt := time.Now().Add(-time.Minute)
+	cache.SetRegistryStatus(&kubernetes.RegistryStatus{FetchedAt: fetchedAt})
+	freshness := registryStatusService.GetRegistryFreshness()
+	require.NotNil(freshness)
+	assert.Equal(fetchedAt, freshness.FetchedAt)
+	assert.GreaterOrEqual(freshness.Age, time.Minute)
+	assert.False(freshness.Stale)
+
+	cache.SetRegistryStatus(&kubernetes.RegistryStatus{FetchedAt: time.Now().Add(-10 * time.Minute)})
+	freshness = registryStatusService.GetRegistryFreshness()
+	require.NotNil(freshness)
+	assert.GreaterOrEqual(freshness.Age, 10*time.Minute)
+	assert.True(freshness.Stale)
+
+	// No threshold, the registry is never stale
+	conf.ExternalServices.Istio.RegistryStaleThreshold = 0
+	config.Set(conf)
+	freshness = registryStatusService.GetRegistryFreshness()
+	require.NotNil(freshness)
+	assert.False(freshness.Stale)
+}
+
+// unreachableIstiodClient can't reach istiod to fetch the registry
+type unreachableIstiodClient struct {
+	kubernetes.ClientInterface
+}
+
+func (c *unreachableIstiodClient) GetRegistryConfiguration() (*kubernetes.RegistryConfiguration, error) {
+	return nil, fmt.Errorf("unable to proxy Istiod pods")
+}
+
+func (c *unreachableIstiodClient) GetRegistryEndpoints() ([]*kubernetes.RegistryEndpoint, error) {
+	return nil, fmt.Errorf("unable to proxy Istiod pods")
+}
+
+func (c *unreachableIstiodClient) GetRegistryServices() ([]*kubernetes.RegistryService, error) {
+	return nil, fmt.Errorf("unable to proxy Istiod pods")
+}
+
+func TestGetRegistryServicesIstiodUnreachable(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	// The registry always needs a refresh
+	conf.KubernetesConfig.CacheDuration = 0
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}})
+	cache := SetupBusinessLayer(t, k8s, *conf)
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: &unreachableIstiodClient{ClientInterface: k8s}}
+	registryStatusService := NewWithBackends(clients, clients, nil, nil).RegistryStatus
+
+	previous := &kubernetes.RegistryStatus{
+		FetchedAt: time.Now().Add(-time.Hour),
+		Services:  []*kubernetes.RegistryService{{IstioService: kubernetes.IstioService{Hostname: "reviews.bookinfo.svc.cluster.local"}}},
+	}
+	cache.SetRegistryStatus(previous)
+
+	// The previous registry is not served by default
+	_, err := registryStatusService.GetRegistryServices(RegistryCriteria{AllNamespaces: true})
+	require.Error(err)
+
+	conf.ExternalServices.Istio.RegistryServeStale = true
+	config.Set(conf)
+	registryServices, err := registryStatusService.GetRegistryServices(RegistryCriteria{AllNamespaces: true})
+	require.NoError(err)
+	assert.Equal(previous.Services, registryServices)
+}
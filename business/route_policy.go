@@ -0,0 +1,180 @@
+package business
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// Istio defaults of the route policy, applied when neither the VirtualService nor the DestinationRule set a value
+const (
+	// The request timeout is disabled
+	defaultRouteTimeout        = 0 * time.Second
+	defaultRouteRetryAttempts  = 2
+	defaultRouteRetryOn        = "connect-failure,refused-stream,unavailable,cancelled,retriable-status-codes"
+	defaultRouteMaxRetries     = 4294967295
+	defaultRouteConnectTimeout = 10 * time.Second
+	defaultRouteIdleTimeout    = time.Hour
+)
+
+// GetEffectiveRoutePolicy resolves the timeout and the retry policy applied to the requests of an http route to a service,
+// with the source of each value. The timeout and the retries come from the http route of the VirtualService, the limits
+// of the connections to the service from the traffic policy of its DestinationRule, the one of the subset the route
+// targets overriding it, and the Istio defaults apply to the values set by none of them.
+// It uses following parameters:
+// - "cluster":		cluster of the service
+// - "namespace":	namespace of the service
+// - "service":		name of the service
+// - "route":		name of the http route, or of one of its matches. When empty the first http route to the service is used
+func (in *SvcService) GetEffectiveRoutePolicy(ctx context.Context, cluster, namespace, service, route string) (*models.EffectiveRoutePolicy, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetEffectiveRoutePolicy",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("service", service),
+		observability.Attribute("route", route),
+	)
+	defer end()
+
+	// Check if user has access to the namespace (RBAC) in cache scenarios and/or
+	// if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
+	if _, err := in.businessLayer.Namespace.GetNamespaceByCluster(ctx, namespace, cluster); err != nil {
+		return nil, err
+	}
+
+	// The VirtualServices and DestinationRules of the service can live in any namespace
+	criteria := IstioConfigCriteria{
+		AllNamespaces:           true,
+		Cluster:                 cluster,
+		IncludeVirtualServices:  true,
+		IncludeDestinationRules: true,
+	}
+	istioConfigList, err := in.businessLayer.IstioConfig.GetIstioConfigList(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	vs, httpRoute, destination := findHTTPRoute(kubernetes.FilterVirtualServicesByService(istioConfigList.VirtualServices, namespace, service), namespace, service, route)
+	if route != "" && httpRoute == nil {
+		return nil, kubernetes.NewNotFound(route, kubernetes.NetworkingGroupVersionV1Beta1.Group, "route")
+	}
+
+	var dr *networking_v1beta1.DestinationRule
+	if drs := kubernetes.FilterDestinationRulesByService(istioConfigList.DestinationRules, namespace, service); len(drs) > 0 {
+		dr = drs[0]
+	}
+
+	return resolveRoutePolicy(vs, httpRoute, destination, dr), nil
+}
+
+// findHTTPRoute returns the first http route of the VirtualServices sending requests to the service, along with
+// its VirtualService and its destination to the service. When route is set, only the route or the match with that
+// name is considered.
+func findHTTPRoute(vss []*networking_v1beta1.VirtualService, namespace, service, route string) (*networking_v1beta1.VirtualService, *api_networking_v1beta1.HTTPRoute, *api_networking_v1beta1.Destination) {
+	for _, vs := range vss {
+		for _, httpRoute := range vs.Spec.Http {
+			if httpRoute == nil || (route != "" && !isHTTPRouteNamed(httpRoute, route)) {
+				continue
+			}
+			for _, dest := range httpRoute.Route {
+				if dest.Destination != nil && kubernetes.FilterByHost(dest.Destination.Host, vs.Namespace, service, namespace) {
+					return vs, httpRoute, dest.Destination
+				}
+			}
+		}
+	}
+	return nil, nil, nil
+}
+
+func isHTTPRouteNamed(httpRoute *api_networking_v1beta1.HTTPRoute, name string) bool {
+	if httpRoute.Name == name {
+		return true
+	}
+	for _, match := range httpRoute.Match {
+		if match != nil && match.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func resolveRoutePolicy(vs *networking_v1beta1.VirtualService, httpRoute *api_networking_v1beta1.HTTPRoute, destination *api_networking_v1beta1.Destination, dr *networking_v1beta1.DestinationRule) *models.EffectiveRoutePolicy {
+	defaultValue := func(value string) models.RoutePolicyValue {
+		return models.RoutePolicyValue{Value: value, Source: models.RoutePolicySourceDefault}
+	}
+	policy := &models.EffectiveRoutePolicy{
+		Timeout:            defaultValue(defaultRouteTimeout.String()),
+		RetryAttempts:      defaultValue(strconv.Itoa(defaultRouteRetryAttempts)),
+		RetryPerTryTimeout: defaultValue(defaultRouteTimeout.String()),
+		RetryOn:            defaultValue(defaultRouteRetryOn),
+		MaxRetries:         defaultValue(strconv.Itoa(defaultRouteMaxRetries)),
+		ConnectTimeout:     defaultValue(defaultRouteConnectTimeout.String()),
+		IdleTimeout:        defaultValue(defaultRouteIdleTimeout.String()),
+	}
+
+	if httpRoute != nil {
+		policy.Route = httpRoute.Name
+		vsValue := func(value string) models.RoutePolicyValue {
+			return models.RoutePolicyValue{Value: value, Source: models.RoutePolicySourceVirtualService, Resource: vs.Namespace + "/" + vs.Name}
+		}
+		if httpRoute.Timeout != nil {
+			policy.Timeout = vsValue(httpRoute.Timeout.AsDuration().String())
+		}
+		// The timeout of each try defaults to the timeout of the request
+		policy.RetryPerTryTimeout = policy.Timeout
+		if retries := httpRoute.Retries; retries != nil {
+			policy.RetryAttempts = vsValue(strconv.Itoa(int(retries.Attempts)))
+			if retries.PerTryTimeout != nil {
+				policy.RetryPerTryTimeout = vsValue(retries.PerTryTimeout.AsDuration().String())
+			}
+			if retries.RetryOn != "" {
+				policy.RetryOn = vsValue(retries.RetryOn)
+			}
+		}
+	}
+
+	if dr == nil {
+		return policy
+	}
+	drValue := func(value string) models.RoutePolicyValue {
+		return models.RoutePolicyValue{Value: value, Source: models.RoutePolicySourceDestinationRule, Resource: dr.Namespace + "/" + dr.Name}
+	}
+	var connectionPool *api_networking_v1beta1.ConnectionPoolSettings
+	if dr.Spec.TrafficPolicy != nil {
+		connectionPool = dr.Spec.TrafficPolicy.ConnectionPool
+	}
+	if destination != nil && destination.Subset != "" {
+		policy.Subset = destination.Subset
+		// Like Istio, each field set in the traffic policy of the subset replaces the field of the DestinationRule as a whole,
+		// the connection pool settings are not merged
+		for _, subset := range dr.Spec.Subsets {
+			if subset != nil && subset.Name == destination.Subset && subset.TrafficPolicy != nil && subset.TrafficPolicy.ConnectionPool != nil {
+				connectionPool = subset.TrafficPolicy.ConnectionPool
+			}
+		}
+	}
+	if connectionPool == nil {
+		return policy
+	}
+	if tcp := connectionPool.Tcp; tcp != nil && tcp.ConnectTimeout != nil {
+		policy.ConnectTimeout = drValue(tcp.ConnectTimeout.AsDuration().String())
+	}
+	if http := connectionPool.Http; http != nil {
+		if http.MaxRetries != 0 {
+			policy.MaxRetries = drValue(strconv.Itoa(int(http.MaxRetries)))
+		}
+		if http.IdleTimeout != nil {
+			policy.IdleTimeout = drValue(http.IdleTimeout.AsDuration().String())
+		}
+	}
+
+	return policy
+}
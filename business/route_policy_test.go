@@ -0,0 +1,152 @@
+package business
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestGetEffectiveRoutePolicy(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	config.Set(conf)
+
+	vs := data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"})
+	vs.Spec.Http = []*api_networking_v1beta1.HTTPRoute{
+		{
+			Name:    "reviews-v2",
+			Match:   []*api_networking_v1beta1.HTTPMatchRequest{{Name: "jason"}},
+			Route:   []*api_networking_v1beta1.HTTPRouteDestination{data.CreateHttpRouteDestination("reviews", "v2", -1)},
+			Timeout: durationpb.New(5 * time.Second),
+		},
+		{
+			Name:  "reviews-v1",
+			Route: []*api_networking_v1beta1.HTTPRouteDestination{data.CreateHttpRouteDestination("reviews", "v1", -1)},
+			Retries: &api_networking_v1beta1.HTTPRetry{
+				Attempts:      3,
+				PerTryTimeout: durationpb.New(2 * time.Second),
+				RetryOn:       "5xx",
+			},
+		},
+	}
+
+	dr := data.AddTrafficPolicyToDestinationRule(&api_networking_v1beta1.TrafficPolicy{
+		ConnectionPool: &api_networking_v1beta1.ConnectionPoolSettings{
+			Tcp:  &api_networking_v1beta1.ConnectionPoolSettings_TCPSettings{ConnectTimeout: durationpb.New(time.Second)},
+			Http: &api_networking_v1beta1.ConnectionPoolSettings_HTTPSettings{MaxRetries: 10},
+		},
+	}, data.CreateTestDestinationRule("bookinfo", "reviews", "reviews"))
+	// The connection pool of the subset v2 replaces the one of the DestinationRule
+	dr.Spec.Subsets[0].TrafficPolicy = &api_networking_v1beta1.TrafficPolicy{
+		ConnectionPool: &api_networking_v1beta1.ConnectionPoolSettings{
+			Http: &api_networking_v1beta1.ConnectionPoolSettings_HTTPSettings{MaxRetries: 20, IdleTimeout: durationpb.New(time.Minute)},
+		},
+	}
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		vs,
+		dr,
+	)
+	SetupBusinessLayer(t, k8s, *conf)
+	t.Cleanup(func() { kialiCache = nil })
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	svc := NewWithBackends(clients, clients, nil, nil).Svc
+
+	vsValue := func(value string) models.RoutePolicyValue {
+		return models.RoutePolicyValue{Value: value, Source: models.RoutePolicySourceVirtualService, Resource: "bookinfo/reviews"}
+	}
+	drValue := func(value string) models.RoutePolicyValue {
+		return models.RoutePolicyValue{Value: value, Source: models.RoutePolicySourceDestinationRule, Resource: "bookinfo/reviews"}
+	}
+	defaultValue := func(value string) models.RoutePolicyValue {
+		return models.RoutePolicyValue{Value: value, Source: models.RoutePolicySourceDefault}
+	}
+
+	// The VirtualService sets the timeout, the connection pool of the subset replaces the one of the DestinationRule,
+	// its connect timeout is not inherited
+	policy, err := svc.GetEffectiveRoutePolicy(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "reviews", "jason")
+	require.NoError(err)
+	assert.Equal(&models.EffectiveRoutePolicy{
+		Route:              "reviews-v2",
+		Subset:             "v2",
+		Timeout:            vsValue("5s"),
+		RetryAttempts:      defaultValue("2"),
+		RetryPerTryTimeout: vsValue("5s"),
+		RetryOn:            defaultValue(defaultRouteRetryOn),
+		MaxRetries:         drValue("20"),
+		ConnectTimeout:     defaultValue(defaultRouteConnectTimeout.String()),
+		IdleTimeout:        drValue("1m0s"),
+	}, policy)
+
+	// The VirtualService sets the retries, the subset v1 doesn't override the DestinationRule
+	policy, err = svc.GetEffectiveRoutePolicy(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "reviews", "reviews-v1")
+	require.NoError(err)
+	assert.Equal(&models.EffectiveRoutePolicy{
+		Route:              "reviews-v1",
+		Subset:             "v1",
+		Timeout:            defaultValue("0s"),
+		RetryAttempts:      vsValue("3"),
+		RetryPerTryTimeout: vsValue("2s"),
+		RetryOn:            vsValue("5xx"),
+		MaxRetries:         drValue("10"),
+		ConnectTimeout:     drValue("1s"),
+		IdleTimeout:        defaultValue("1h0m0s"),
+	}, policy)
+
+	// Without a route name the first route to the service applies
+	policy, err = svc.GetEffectiveRoutePolicy(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "reviews", "")
+	require.NoError(err)
+	assert.Equal("reviews-v2", policy.Route)
+
+	_, err = svc.GetEffectiveRoutePolicy(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "reviews", "missing")
+	require.Error(err)
+	assert.True(errors.IsNotFound(err))
+}
+
+func TestGetEffectiveRoutePolicyDefaults(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}})
+	SetupBusinessLayer(t, k8s, *conf)
+	t.Cleanup(func() { kialiCache = nil })
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	svc := NewWithBackends(clients, clients, nil, nil).Svc
+
+	// No VirtualService nor DestinationRule for the service, the Istio defaults apply
+	policy, err := svc.GetEffectiveRoutePolicy(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "reviews", "")
+	require.NoError(err)
+	assert.Empty(policy.Route)
+	assert.Empty(policy.Subset)
+	for _, value := range []models.RoutePolicyValue{policy.Timeout, policy.RetryAttempts, policy.RetryPerTryTimeout, policy.RetryOn, policy.MaxRetries, policy.ConnectTimeout, policy.IdleTimeout} {
+		assert.Equal(models.RoutePolicySourceDefault, value.Source)
+		assert.Empty(value.Resource)
+	}
+	assert.Equal("0s", policy.Timeout.Value)
+	assert.Equal("4294967295", policy.MaxRetries.Value)
+	assert.Equal("10s", policy.ConnectTimeout.Value)
+}
@@ -0,0 +1,168 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// GetSecurityPolicyWorkloads resolves the workloads a PeerAuthentication or a RequestAuthentication applies to.
+// A policy with a selector applies to the workloads of its namespace it selects, a policy without selector to all the
+// workloads of its namespace, or to the workloads of every accessible namespace of the cluster for the root namespace.
+// A PeerAuthentication is overridden for a workload by a more specific one: the ones with a selector override the
+// namespace-wide ones, which override the mesh-wide ones. RequestAuthentications are combined, none is overridden.
+// It uses following parameters:
+// - "cluster":		cluster of the policy
+// - "namespace":	namespace of the policy
+// - "objectType":	"peerauthentications" or "requestauthentications"
+// - "object":		name of the policy
+func (in *IstioConfigService) GetSecurityPolicyWorkloads(ctx context.Context, cluster, namespace, objectType, object string) (*models.SecurityPolicyWorkloads, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetSecurityPolicyWorkloads",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("objectType", objectType),
+		observability.Attribute("object", object),
+	)
+	defer end()
+
+	if objectType != kubernetes.PeerAuthentications && objectType != kubernetes.RequestAuthentications {
+		return nil, fmt.Errorf("object type not supported: %v", objectType)
+	}
+
+	// Check if user has access to the namespace (RBAC) in cache scenarios and/or
+	// if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
+	if _, err := in.businessLayer.Namespace.GetNamespaceByCluster(ctx, namespace, cluster); err != nil {
+		return nil, err
+	}
+
+	// The policies of the other namespaces may override a mesh-wide PeerAuthentication
+	criteria := IstioConfigCriteria{
+		AllNamespaces:                 true,
+		Cluster:                       cluster,
+		IncludePeerAuthentications:    objectType == kubernetes.PeerAuthentications,
+		IncludeRequestAuthentications: objectType == kubernetes.RequestAuthentications,
+	}
+	istioConfigList, err := in.GetIstioConfigList(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	var selector map[string]string
+	var selects func(workloadSelector string) bool
+	found := false
+	switch objectType {
+	case kubernetes.PeerAuthentications:
+		for _, pa := range istioConfigList.PeerAuthentications {
+			if pa.Namespace == namespace && pa.Name == object {
+				found = true
+				if pa.Spec.Selector != nil {
+					selector = pa.Spec.Selector.MatchLabels
+				}
+				selects = func(workloadSelector string) bool {
+					return len(kubernetes.FilterPeerAuthenticationsBySelector(workloadSelector, []*security_v1beta1.PeerAuthentication{pa})) > 0
+				}
+				break
+			}
+		}
+	case kubernetes.RequestAuthentications:
+		for _, ra := range istioConfigList.RequestAuthentications {
+			if ra.Namespace == namespace && ra.Name == object {
+				found = true
+				if ra.Spec.Selector != nil {
+					selector = ra.Spec.Selector.MatchLabels
+				}
+				selects = func(workloadSelector string) bool {
+					return len(kubernetes.FilterRequestAuthenticationsBySelector(workloadSelector, []*security_v1beta1.RequestAuthentication{ra})) > 0
+				}
+				break
+			}
+		}
+	}
+	if !found {
+		return nil, kubernetes.NewNotFound(object, kubernetes.SecurityGroupVersion.Group, objectType)
+	}
+
+	policyWorkloads := &models.SecurityPolicyWorkloads{
+		ObjectType: models.ObjectTypeSingular[objectType],
+		Name:       object,
+		Namespace:  namespace,
+		Scope:      models.SecurityPolicyScopeWorkload,
+		Workloads:  []models.SecurityPolicyWorkload{},
+	}
+	namespaces := []string{namespace}
+	if len(selector) == 0 {
+		policyWorkloads.Scope = models.SecurityPolicyScopeNamespace
+		if namespace == in.config.ExternalServices.Istio.RootNamespace {
+			policyWorkloads.Scope = models.SecurityPolicyScopeMesh
+			clusterNamespaces, err := in.businessLayer.Namespace.GetNamespacesForCluster(ctx, cluster)
+			if err != nil {
+				return nil, err
+			}
+			namespaces = []string{}
+			for _, ns := range clusterNamespaces {
+				namespaces = append(namespaces, ns.Name)
+			}
+		}
+	}
+
+	for _, ns := range namespaces {
+		workloads, err := in.businessLayer.Workload.fetchWorkloadsFromCluster(ctx, cluster, ns, "")
+		if err != nil {
+			return nil, err
+		}
+		for _, wk := range workloads {
+			workloadSelector := labels.Set(wk.Labels).String()
+			if !selects(workloadSelector) {
+				continue
+			}
+			policyWorkload := models.SecurityPolicyWorkload{Name: wk.Name, Namespace: ns}
+			if objectType == kubernetes.PeerAuthentications {
+				policyWorkload.OverriddenBy = overridingPeerAuthentication(istioConfigList.PeerAuthentications, policyWorkloads, ns, workloadSelector)
+			}
+			policyWorkloads.Workloads = append(policyWorkloads.Workloads, policyWorkload)
+		}
+	}
+
+	sort.Slice(policyWorkloads.Workloads, func(i, j int) bool {
+		if policyWorkloads.Workloads[i].Namespace != policyWorkloads.Workloads[j].Namespace {
+			return policyWorkloads.Workloads[i].Namespace < policyWorkloads.Workloads[j].Namespace
+		}
+		return policyWorkloads.Workloads[i].Name < policyWorkloads.Workloads[j].Name
+	})
+	return policyWorkloads, nil
+}
+
+// overridingPeerAuthentication returns the PeerAuthentication of the namespace of a workload more specific than the scope of
+// a policy applied to the workload: one selecting the workload, or a namespace-wide one for a mesh-wide policy.
+// The policy itself, and the other mesh-wide ones for the workloads of the root namespace, don't override it.
+func overridingPeerAuthentication(peerAuthentications []*security_v1beta1.PeerAuthentication, policy *models.SecurityPolicyWorkloads, namespace, workloadSelector string) *models.IstioReference {
+	if policy.Scope == models.SecurityPolicyScopeWorkload {
+		return nil
+	}
+	var namespaceWide *security_v1beta1.PeerAuthentication
+	for _, pa := range kubernetes.FilterPeerAuthenticationsBySelector(workloadSelector, peerAuthentications) {
+		if pa.Namespace != namespace || (pa.Namespace == policy.Namespace && pa.Name == policy.Name) {
+			continue
+		}
+		if pa.Spec.Selector != nil && len(pa.Spec.Selector.MatchLabels) > 0 {
+			return &models.IstioReference{ObjectType: models.ObjectTypeSingular[kubernetes.PeerAuthentications], Name: pa.Name, Namespace: pa.Namespace}
+		}
+		// In the root namespace of a mesh-wide policy the PeerAuthentications without selector are mesh-wide too
+		if policy.Scope == models.SecurityPolicyScopeMesh && namespace != policy.Namespace && namespaceWide == nil {
+			namespaceWide = pa
+		}
+	}
+	if namespaceWide != nil {
+		return &models.IstioReference{ObjectType: models.ObjectTypeSingular[kubernetes.PeerAuthentications], Name: namespaceWide.Name, Namespace: namespaceWide.Namespace}
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_security_v1beta1 "istio.io/api/security/v1beta1"
+	"istio.io/api/type/v1beta1"
+	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+func fakeSecurityPolicyDeployment(name, namespace string, labels map[string]string) *apps_v1.Deployment {
+	return &apps_v1.Deployment{
+		TypeMeta:   meta_v1.TypeMeta{Kind: "Deployment"},
+		ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: apps_v1.DeploymentSpec{
+			Template: core_v1.PodTemplateSpec{ObjectMeta: meta_v1.ObjectMeta{Labels: labels}},
+		},
+	}
+}
+
+func fakeSecurityPolicyPeerAuthentication(name, namespace string, matchLabels map[string]string) *security_v1beta1.PeerAuthentication {
+	pa := &security_v1beta1.PeerAuthentication{ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: namespace}}
+	if matchLabels != nil {
+		pa.Spec.Selector = &v1beta1.WorkloadSelector{MatchLabels: matchLabels}
+	}
+	pa.Spec.Mtls = &api_security_v1beta1.PeerAuthentication_MutualTLS{Mode: api_security_v1beta1.PeerAuthentication_MutualTLS_STRICT}
+	return pa
+}
+
+func setupSecurityPolicyIstioConfigService(t *testing.T, objects ...runtime.Object) (IstioConfigService, *config.Config) {
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	config.Set(conf)
+
+	objects = append(objects,
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "travels"}},
+		fakeSecurityPolicyDeployment("reviews-v1", "bookinfo", map[string]string{"app": "reviews", "version": "v1"}),
+		fakeSecurityPolicyDeployment("reviews-v2", "bookinfo", map[string]string{"app": "reviews", "version": "v2"}),
+		fakeSecurityPolicyDeployment("ratings-v1", "bookinfo", map[string]string{"app": "ratings", "version": "v1"}),
+		fakeSecurityPolicyDeployment("cars-v1", "travels", map[string]string{"app": "cars", "version": "v1"}),
+	)
+	k8s := kubetest.NewFakeK8sClient(objects...)
+	SetupBusinessLayer(t, k8s, *conf)
+	t.Cleanup(func() { kialiCache = nil })
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	return NewWithBackends(clients, clients, nil, nil).IstioConfig, conf
+}
+
+func TestGetSecurityPolicyWorkloadsWorkloadSelector(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	svc, conf := setupSecurityPolicyIstioConfigService(t,
+		fakeSecurityPolicyPeerAuthentication("reviews", "bookinfo", map[string]string{"app": "reviews"}),
+		fakeSecurityPolicyPeerAuthentication("default", "bookinfo", nil),
+	)
+
+	policyWorkloads, err := svc.GetSecurityPolicyWorkloads(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", kubernetes.PeerAuthentications, "reviews")
+	require.NoError(err)
+	assert.Equal("peerauthentication", policyWorkloads.ObjectType)
+	assert.Equal(models.SecurityPolicyScopeWorkload, policyWorkloads.Scope)
+	assert.Equal([]models.SecurityPolicyWorkload{
+		{Name: "reviews-v1", Namespace: "bookinfo"},
+		{Name: "reviews-v2", Namespace: "bookinfo"},
+	}, policyWorkloads.Workloads)
+
+	_, err = svc.GetSecurityPolicyWorkloads(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", kubernetes.PeerAuthentications, "missing")
+	require.Error(err)
+	assert.True(errors.IsNotFound(err))
+
+	_, err = svc.GetSecurityPolicyWorkloads(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", kubernetes.AuthorizationPolicies, "reviews")
+	require.Error(err)
+}
+
+func TestGetSecurityPolicyWorkloadsNamespaceWide(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	svc, conf := setupSecurityPolicyIstioConfigService(t,
+		fakeSecurityPolicyPeerAuthentication("reviews-v2", "bookinfo", map[string]string{"version": "v2"}),
+		fakeSecurityPolicyPeerAuthentication("default", "bookinfo", nil),
+	)
+
+	policyWorkloads, err := svc.GetSecurityPolicyWorkloads(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", kubernetes.PeerAuthentications, "default")
+	require.NoError(err)
+	assert.Equal(models.SecurityPolicyScopeNamespace, policyWorkloads.Scope)
+	assert.Equal([]models.SecurityPolicyWorkload{
+		{Name: "ratings-v1", Namespace: "bookinfo"},
+		{Name: "reviews-v1", Namespace: "bookinfo"},
+		{Name: "reviews-v2", Namespace: "bookinfo", OverriddenBy: &models.IstioReference{ObjectType: "peerauthentication", Name: "reviews-v2", Namespace: "bookinfo"}},
+	}, policyWorkloads.Workloads)
+}
+
+func TestGetSecurityPolicyWorkloadsMeshWide(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	jwt := &security_v1beta1.RequestAuthentication{ObjectMeta: meta_v1.ObjectMeta{Name: "jwt", Namespace: "istio-system"}}
+	svc, conf := setupSecurityPolicyIstioConfigService(t,
+		fakeSecurityPolicyPeerAuthentication("default", "istio-system", nil),
+		fakeSecurityPolicyPeerAuthentication("default", "bookinfo", nil),
+		fakeSecurityPolicyPeerAuthentication("reviews-v1", "bookinfo", map[string]string{"version": "v1", "app": "reviews"}),
+		fakeSecurityPolicyPeerAuthentication("permissive", "istio-system", nil),
+		fakeSecurityPolicyDeployment("istio-ingressgateway", "istio-system", map[string]string{"app": "istio-ingressgateway"}),
+		jwt,
+	)
+
+	policyWorkloads, err := svc.GetSecurityPolicyWorkloads(context.TODO(), conf.KubernetesConfig.ClusterName, "istio-system", kubernetes.PeerAuthentications, "default")
+	require.NoError(err)
+	assert.Equal(models.SecurityPolicyScopeMesh, policyWorkloads.Scope)
+	namespaceWide := &models.IstioReference{ObjectType: "peerauthentication", Name: "default", Namespace: "bookinfo"}
+	assert.Equal([]models.SecurityPolicyWorkload{
+		{Name: "ratings-v1", Namespace: "bookinfo", OverriddenBy: namespaceWide},
+		{Name: "reviews-v1", Namespace: "bookinfo", OverriddenBy: &models.IstioReference{ObjectType: "peerauthentication", Name: "reviews-v1", Namespace: "bookinfo"}},
+		{Name: "reviews-v2", Namespace: "bookinfo", OverriddenBy: namespaceWide},
+		{Name: "istio-ingressgateway", Namespace: "istio-system"},
+		{Name: "cars-v1", Namespace: "travels"},
+	}, policyWorkloads.Workloads)
+
+	// RequestAuthentications are never overridden
+	policyWorkloads, err = svc.GetSecurityPolicyWorkloads(context.TODO(), conf.KubernetesConfig.ClusterName, "istio-system", kubernetes.RequestAuthentications, "jwt")
+	require.NoError(err)
+	assert.Equal("requestauthentication", policyWorkloads.ObjectType)
+	assert.Equal(models.SecurityPolicyScopeMesh, policyWorkloads.Scope)
+	assert.Equal([]models.SecurityPolicyWorkload{
+		{Name: "ratings-v1", Namespace: "bookinfo"},
+		{Name: "reviews-v1", Namespace: "bookinfo"},
+		{Name: "reviews-v2", Namespace: "bookinfo"},
+		{Name: "istio-ingressgateway", Namespace: "istio-system"},
+		{Name: "cars-v1", Namespace: "travels"},
+	}, policyWorkloads.Workloads)
+}
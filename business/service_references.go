@@ -0,0 +1,153 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+
+	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// GetServiceReferences returns the Istio objects referencing a service, across the namespaces visible to the user:
+// - the VirtualServices and DestinationRules using the service as host or as route destination.
+// - the Gateways bound to those VirtualServices.
+// - the ServiceEntries defining the host of the service.
+// - the Sidecars naming the service in the hosts of their egress listeners.
+// - the AuthorizationPolicies selecting the workloads of the service or matching its host in their operations.
+// The namespaces excluded from the references in the config are skipped.
+func (in *SvcService) GetServiceReferences(ctx context.Context, cluster, namespace, service string) ([]models.IstioReference, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetServiceReferences",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("service", service),
+	)
+	defer end()
+
+	svc, err := in.GetService(ctx, cluster, namespace, service)
+	if err != nil {
+		return nil, err
+	}
+
+	criteria := IstioConfigCriteria{
+		AllNamespaces:                true,
+		Cluster:                      cluster,
+		Namespace:                    namespace,
+		ExcludedNamespaces:           in.config.ExternalServices.Istio.ReferencesExcludedNamespacesRegexps(),
+		IncludeAuthorizationPolicies: true,
+		IncludeDestinationRules:      true,
+		IncludeGateways:              true,
+		IncludeServiceEntries:        true,
+		IncludeSidecars:              true,
+		IncludeVirtualServices:       true,
+	}
+	istioConfigList, err := in.businessLayer.IstioConfig.GetIstioConfigList(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	references := []models.IstioReference{}
+	addReference := func(objectType, name, ns string) {
+		references = append(references, models.IstioReference{ObjectType: models.ObjectTypeSingular[objectType], Name: name, Namespace: ns})
+	}
+
+	virtualServices := kubernetes.FilterAutogeneratedVirtualServices(kubernetes.FilterVirtualServicesByService(istioConfigList.VirtualServices, namespace, service))
+	for _, vs := range virtualServices {
+		addReference(kubernetes.VirtualServices, vs.Name, vs.Namespace)
+	}
+	for _, dr := range kubernetes.FilterDestinationRulesByService(istioConfigList.DestinationRules, namespace, service) {
+		addReference(kubernetes.DestinationRules, dr.Name, dr.Namespace)
+	}
+	for _, gw := range kubernetes.FilterGatewaysByVirtualServices(istioConfigList.Gateways, virtualServices) {
+		addReference(kubernetes.Gateways, gw.Name, gw.Namespace)
+	}
+
+	hostname := fmt.Sprintf("%s.%s.%s", service, namespace, in.config.ExternalServices.Istio.IstioIdentityDomain)
+	if svc.Type == "External" || svc.Type == "Federation" {
+		// On ServiceEntries cases the Service name is the hostname
+		hostname = service
+	}
+	for _, se := range kubernetes.FilterServiceEntriesByHostname(istioConfigList.ServiceEntries, hostname) {
+		addReference(kubernetes.ServiceEntries, se.Name, se.Namespace)
+	}
+
+	for _, sc := range kubernetes.FilterSidecarsByEgressHost(istioConfigList.Sidecars, namespace, service) {
+		addReference(kubernetes.Sidecars, sc.Name, sc.Namespace)
+	}
+
+	for _, ap := range in.filterAuthorizationPoliciesByService(istioConfigList.AuthorizationPolicies, svc) {
+		addReference(kubernetes.AuthorizationPolicies, ap.Name, ap.Namespace)
+	}
+
+	sort.Slice(references, func(i, j int) bool {
+		if references[i].ObjectType != references[j].ObjectType {
+			return references[i].ObjectType < references[j].ObjectType
+		}
+		if references[i].Namespace != references[j].Namespace {
+			return references[i].Namespace < references[j].Namespace
+		}
+		return references[i].Name < references[j].Name
+	})
+	return references, nil
+}
+
+// filterAuthorizationPoliciesByService returns the AuthorizationPolicies referencing a service:
+// the ones whose selector matches the workloads of the service, in its namespace or in the root namespace,
+// and the ones with an operation on the host of the service.
+// The AuthorizationPolicies without selector apply to any workload, they are not considered references.
+func (in *SvcService) filterAuthorizationPoliciesByService(authorizationPolicies []*security_v1beta1.AuthorizationPolicy, svc models.Service) []*security_v1beta1.AuthorizationPolicy {
+	namespace := svc.Namespace.Name
+	rootNamespace := in.config.ExternalServices.Istio.RootNamespace
+
+	selected := map[*security_v1beta1.AuthorizationPolicy]bool{}
+	if len(svc.Selectors) > 0 {
+		candidates := []*security_v1beta1.AuthorizationPolicy{}
+		for _, ap := range authorizationPolicies {
+			if (ap.Namespace == namespace || ap.Namespace == rootNamespace) && ap.Spec.Selector != nil && len(ap.Spec.Selector.MatchLabels) > 0 {
+				candidates = append(candidates, ap)
+			}
+		}
+		for _, ap := range kubernetes.FilterAuthorizationPoliciesBySelector(labels.Set(svc.Selectors).String(), candidates) {
+			selected[ap] = true
+		}
+	}
+
+	filtered := []*security_v1beta1.AuthorizationPolicy{}
+	for _, ap := range authorizationPolicies {
+		if selected[ap] || authorizationPolicyOperatesOnHost(ap, svc.Name, namespace) {
+			filtered = append(filtered, ap)
+		}
+	}
+	return filtered
+}
+
+// authorizationPolicyOperatesOnHost tells whether an operation of the rules of an AuthorizationPolicy targets the host of a service
+func authorizationPolicyOperatesOnHost(ap *security_v1beta1.AuthorizationPolicy, service, namespace string) bool {
+	for _, rule := range ap.Spec.Rules {
+		if rule == nil {
+			continue
+		}
+		for _, to := range rule.To {
+			if to == nil || to.Operation == nil {
+				continue
+			}
+			for _, host := range to.Operation.Hosts {
+				// The hosts are matched against the Host header, which can include the port
+				if h, _, err := net.SplitHostPort(host); err == nil {
+					host = h
+				}
+				if kubernetes.FilterByHost(host, ap.Namespace, service, namespace) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package business
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestGetServiceReferences(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Service{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"},
+			Spec:       core_v1.ServiceSpec{Selector: map[string]string{"app": "reviews"}},
+		},
+	)
+	cache := SetupBusinessLayer(t, k8s, *conf)
+
+	routedVirtualService := data.AddHttpRoutesToVirtualService(data.CreateHttpRouteDestination("reviews.bookinfo.svc.cluster.local", "", -1),
+		data.CreateEmptyVirtualService("reviews-ingress", "bookinfo", []string{"bookinfo.example.com"}))
+	routedVirtualService.Spec.Gateways = []string{"bookinfo-gateway"}
+
+	selectorPolicy := data.CreateAuthorizationPolicyWithMetaAndSelector("reviews-selector", "bookinfo", map[string]string{"app": "reviews"})
+	hostPolicy := data.CreateAuthorizationPolicy(nil, []string{"GET"}, []string{"reviews.bookinfo.svc.cluster.local:9080"}, nil)
+	hostPolicy.Name = "reviews-host"
+	hostPolicy.Namespace = "other"
+	otherSelectorPolicy := data.CreateAuthorizationPolicyWithMetaAndSelector("reviews-selector", "other", map[string]string{"app": "reviews"})
+
+	cache.SetRegistryStatus(&kubernetes.RegistryStatus{
+		Configuration: &kubernetes.RegistryConfiguration{
+			VirtualServices: []*networking_v1beta1.VirtualService{
+				routedVirtualService,
+				data.CreateEmptyVirtualService("ratings", "bookinfo", []string{"ratings"}),
+			},
+			DestinationRules: []*networking_v1beta1.DestinationRule{
+				data.CreateEmptyDestinationRule("bookinfo", "reviews", "reviews"),
+				data.CreateEmptyDestinationRule("other", "reviews-other", "reviews.bookinfo.svc.cluster.local"),
+				data.CreateEmptyDestinationRule("bookinfo", "ratings", "ratings"),
+			},
+			Gateways: []*networking_v1beta1.Gateway{
+				data.CreateEmptyGateway("bookinfo-gateway", "bookinfo", map[string]string{"istio": "ingressgateway"}),
+				data.CreateEmptyGateway("other-gateway", "bookinfo", map[string]string{"istio": "ingressgateway"}),
+			},
+			ServiceEntries: []*networking_v1beta1.ServiceEntry{
+				data.CreateEmptyMeshInternalServiceEntry("reviews-entry", "other", []string{"reviews.bookinfo.svc.cluster.local"}),
+				data.CreateEmptyMeshExternalServiceEntry("external", "bookinfo", []string{"api.example.com"}),
+			},
+			Sidecars: []*networking_v1beta1.Sidecar{
+				fakeEgressSidecar("reviews-egress", "other", time.Hour, nil, "bookinfo/reviews.bookinfo.svc.cluster.local"),
+				fakeEgressSidecar("all-egress", "bookinfo", time.Hour, nil, "*/*"),
+			},
+			AuthorizationPolicies: []*security_v1beta1.AuthorizationPolicy{
+				selectorPolicy,
+				hostPolicy,
+				// The selectors only apply to the workloads of the same namespace
+				otherSelectorPolicy,
+				data.CreateEmptyAuthorizationPolicy("allow-nothing", "bookinfo"),
+			},
+		},
+	})
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	svc := NewWithBackends(clients, clients, nil, nil).Svc
+	references, err := svc.GetServiceReferences(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "reviews")
+	require.NoError(err)
+
+	assert.Equal([]models.IstioReference{
+		{ObjectType: "authorizationpolicy", Name: "reviews-selector", Namespace: "bookinfo"},
+		{ObjectType: "authorizationpolicy", Name: "reviews-host", Namespace: "other"},
+		{ObjectType: "destinationrule", Name: "reviews", Namespace: "bookinfo"},
+		{ObjectType: "destinationrule", Name: "reviews-other", Namespace: "other"},
+		{ObjectType: "gateway", Name: "bookinfo-gateway", Namespace: "bookinfo"},
+		{ObjectType: "serviceentry", Name: "reviews-entry", Namespace: "other"},
+		{ObjectType: "sidecar", Name: "reviews-egress", Namespace: "other"},
+		{ObjectType: "virtualservice", Name: "reviews-ingress", Namespace: "bookinfo"},
+	}, references)
+}
@@ -0,0 +1,62 @@
+package business
+
+import (
+	"context"
+	"sort"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// GetServicelessWorkloads returns the workloads of the namespace whose labels match no Service selector.
+// Jobs and CronJobs are flagged as batch workloads, which run to completion and legitimately have no Service,
+// the other workloads returned are likely unreachable, as a missing Service is often a misconfiguration.
+// The Jobs and CronJobs excluded by the KubernetesConfig.ExcludeWorkloads config are not scanned, so not returned.
+func (in *WorkloadService) GetServicelessWorkloads(ctx context.Context, cluster, namespace string) ([]models.ServicelessWorkload, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetServicelessWorkloads",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+	)
+	defer end()
+
+	// Check if user has access to the namespace (RBAC) in cache scenarios and/or
+	// if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
+	if _, err := in.businessLayer.Namespace.GetNamespaceByCluster(ctx, namespace, cluster); err != nil {
+		return nil, err
+	}
+
+	kubeCache, err := in.cache.GetKubeCache(cluster)
+	if err != nil {
+		return nil, err
+	}
+	ws, err := in.fetchWorkloadsFromCluster(ctx, cluster, namespace, "")
+	if err != nil {
+		return nil, err
+	}
+	// The services of the namespace are fetched once and their selectors are matched to the labels of each workload
+	services, err := kubeCache.GetServices(namespace, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	workloads := []models.ServicelessWorkload{}
+	for _, w := range ws {
+		if len(w.Labels) > 0 && len(kubernetes.FilterServicesBySelectedLabels(w.Labels, services)) > 0 {
+			continue
+		}
+		workloads = append(workloads, models.ServicelessWorkload{
+			Name:    w.Name,
+			Cluster: cluster,
+			Type:    w.Type,
+			Batch:   w.Type == kubernetes.JobType || w.Type == kubernetes.CronJobType,
+		})
+	}
+	sort.Slice(workloads, func(i, j int) bool {
+		return workloads[i].Name < workloads[j].Name
+	})
+
+	return workloads, nil
+}
@@ -0,0 +1,67 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batch_v1 "k8s.io/api/batch/v1"
+	core_v1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+func TestGetServicelessWorkloads(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	controller := true
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "Namespace"}},
+		fakeComponentDeployment("reviews-v1", map[string]string{"app": "reviews", "version": "v1"}),
+		&core_v1.Service{
+			ObjectMeta: v1.ObjectMeta{Name: "reviews", Namespace: "Namespace"},
+			Spec:       core_v1.ServiceSpec{Selector: map[string]string{"app": "reviews"}},
+		},
+		// The Service of the ratings app is missing
+		fakeComponentDeployment("ratings-v1", map[string]string{"app": "ratings", "version": "v1"}),
+		// A Service without selector doesn't front any workload
+		&core_v1.Service{ObjectMeta: v1.ObjectMeta{Name: "ratings", Namespace: "Namespace", Labels: map[string]string{"app": "ratings"}}},
+		&batch_v1.Job{
+			TypeMeta:   v1.TypeMeta{Kind: "Job"},
+			ObjectMeta: v1.ObjectMeta{Name: "reviews-migration", Namespace: "Namespace"},
+			Spec: batch_v1.JobSpec{
+				Template: core_v1.PodTemplateSpec{ObjectMeta: v1.ObjectMeta{Labels: map[string]string{"job": "reviews-migration"}}},
+			},
+		},
+		&core_v1.Pod{
+			ObjectMeta: v1.ObjectMeta{
+				Name:            "reviews-migration-x7k2p",
+				Namespace:       "Namespace",
+				Labels:          map[string]string{"job": "reviews-migration"},
+				OwnerReferences: []v1.OwnerReference{{Kind: "Job", Name: "reviews-migration", Controller: &controller}},
+			},
+		},
+	)
+	k8s.OpenShift = false
+	SetupBusinessLayer(t, k8s, *conf)
+	t.Cleanup(func() { kialiCache = nil })
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	svc := NewWithBackends(clients, clients, nil, nil).Workload
+	workloads, err := svc.GetServicelessWorkloads(context.TODO(), conf.KubernetesConfig.ClusterName, "Namespace")
+	require.NoError(err)
+
+	assert.Equal([]models.ServicelessWorkload{
+		{Name: "ratings-v1", Cluster: conf.KubernetesConfig.ClusterName, Type: kubernetes.DeploymentType, Batch: false},
+		{Name: "reviews-migration", Cluster: conf.KubernetesConfig.ClusterName, Type: kubernetes.JobType, Batch: true},
+	}, workloads)
+}
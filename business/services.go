@@ -3,9 +3,11 @@ package business
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
 	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	apps_v1 "k8s.io/api/apps/v1"
 	core_v1 "k8s.io/api/core/v1"
@@ -24,11 +26,12 @@ import (
 
 // SvcService deals with fetching istio/kubernetes services related content and convert to kiali model
 type SvcService struct {
-	config        config.Config
-	kialiCache    cache.KialiCache
-	businessLayer *Layer
-	prom          prometheus.ClientInterface
-	userClients   map[string]kubernetes.ClientInterface
+	config         config.Config
+	kialiCache     cache.KialiCache
+	businessLayer  *Layer
+	prom           prometheus.ClientInterface
+	userClients    map[string]kubernetes.ClientInterface
+	kialiSAClients map[string]kubernetes.ClientInterface
 }
 
 type ServiceCriteria struct {
@@ -63,6 +66,9 @@ func (in *SvcService) GetServiceList(ctx context.Context, criteria ServiceCriter
 		Services:    []models.ServiceOverview{},
 		Validations: models.IstioValidations{},
 	}
+	// Errors of the clusters failing to list their services, which don't fail the whole list
+	clusterErrors := map[string]error{}
+	listedClusters := 0
 	// Check if user has access to the namespace (RBAC) in cache scenarios and/or
 	// if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
 	for cluster := range in.userClients {
@@ -84,25 +90,47 @@ func (in *SvcService) GetServiceList(ctx context.Context, criteria ServiceCriter
 				continue
 			}
 
-			// On any other error, abort and return the error.
-			return nil, err
+			// On any other error, report the cluster as failed and keep listing the other clusters
+			clusterErrors[cluster] = err
+			continue
 		}
 
 		singleClusterSVCList, err := in.getServiceListForCluster(ctx, criteria, cluster)
 		if err != nil {
-			if cluster == conf.KubernetesConfig.ClusterName {
+			if len(in.userClients) == 1 {
 				return nil, err
 			}
 
 			log.Errorf("Unable to get services list from cluster: %s. Err: %s. Skipping", cluster, err)
+			clusterErrors[cluster] = err
 			continue
 		}
 
+		listedClusters++
 		serviceList.Services = append(serviceList.Services, singleClusterSVCList.Services...)
 		serviceList.Namespace = singleClusterSVCList.Namespace
 		serviceList.Validations = serviceList.Validations.MergeValidations(singleClusterSVCList.Validations)
 	}
 
+	if len(clusterErrors) > 0 && listedClusters == 0 {
+		// Without any cluster listed, there is nothing to return but the error, the one of the home cluster preferably
+		if err, ok := clusterErrors[conf.KubernetesConfig.ClusterName]; ok {
+			return nil, err
+		}
+		failedClusters := make([]string, 0, len(clusterErrors))
+		for cluster := range clusterErrors {
+			failedClusters = append(failedClusters, cluster)
+		}
+		sort.Strings(failedClusters)
+		return nil, clusterErrors[failedClusters[0]]
+	}
+	if len(clusterErrors) > 0 {
+		serviceList.ClusterErrors = make(map[string]string, len(clusterErrors))
+		for cluster, err := range clusterErrors {
+			serviceList.ClusterErrors[cluster] = err.Error()
+		}
+	}
+
 	return &serviceList, nil
 }
 
@@ -203,6 +231,7 @@ func (in *SvcService) getServiceListForCluster(ctx context.Context, criteria Ser
 			AllNamespaces:           true,
 			Cluster:                 cluster,
 			Namespace:               criteria.Namespace,
+			ExcludedNamespaces:      in.config.ExternalServices.Istio.ReferencesExcludedNamespacesRegexps(),
 			IncludeDestinationRules: true,
 			IncludeGateways:         true,
 			IncludeK8sGateways:      true,
@@ -245,24 +274,31 @@ func (in *SvcService) getServiceListForCluster(ctx context.Context, criteria Ser
 	return services, nil
 }
 
+// kialiWizardLabel is set by the Kiali wizards on the objects they create, with the scenario of the wizard as value
+const kialiWizardLabel = "kiali_wizard"
+
+// getKialiScenario returns the scenario of the Kiali wizard which created an object, false if it was not created by a wizard
+func getKialiScenario(labels map[string]string) (string, bool) {
+	scenario, ok := labels[kialiWizardLabel]
+	return scenario, ok
+}
+
 func getVSKialiScenario(vs []*networking_v1beta1.VirtualService) string {
-	scenario := ""
 	for _, v := range vs {
-		if scenario, ok := v.Labels["kiali_wizard"]; ok {
+		if scenario, ok := getKialiScenario(v.Labels); ok {
 			return scenario
 		}
 	}
-	return scenario
+	return ""
 }
 
 func getDRKialiScenario(dr []*networking_v1beta1.DestinationRule) string {
-	scenario := ""
 	for _, d := range dr {
-		if scenario, ok := d.Labels["kiali_wizard"]; ok {
+		if scenario, ok := getKialiScenario(d.Labels); ok {
 			return scenario
 		}
 	}
-	return scenario
+	return ""
 }
 
 func (in *SvcService) buildServiceList(cluster string, namespace models.Namespace, svcs []core_v1.Service, rSvcs []*kubernetes.RegistryService, pods []core_v1.Pod, deployments []apps_v1.Deployment, istioConfigList models.IstioConfigList, criteria ServiceCriteria) *models.ServiceList {
@@ -272,23 +308,19 @@ func (in *SvcService) buildServiceList(cluster string, namespace models.Namespac
 		validations = in.getServiceValidations(svcs, deployments, pods)
 	}
 
-	kubernetesServices := in.buildKubernetesServices(svcs, pods, istioConfigList, criteria.IncludeOnlyDefinitions)
+	kubernetesServices := in.buildKubernetesServices(cluster, svcs, pods, istioConfigList, criteria.IncludeOnlyDefinitions)
 	services = append(services, kubernetesServices...)
-	// Add cluster to each kube service
-	for i := range services {
-		services[i].Cluster = cluster
-	}
 
 	// Add Istio Registry Services that are not present in the Kubernetes list
 	// TODO: Registry services are not associated to a cluster. They can have multiple clusters under
-	// "clusterVIPs". We need to decide how to handle this.
+	// "clusterVIPs". They are only fetched from the home cluster registry, so they are attributed to it.
 	rSvcs = kubernetes.FilterRegistryServicesByServices(rSvcs, svcs)
-	registryServices := in.buildRegistryServices(rSvcs, istioConfigList)
+	registryServices := in.buildRegistryServices(cluster, rSvcs, istioConfigList)
 	services = append(services, registryServices...)
 	return &models.ServiceList{Namespace: namespace, Services: services, Validations: validations}
 }
 
-func (in *SvcService) buildKubernetesServices(svcs []core_v1.Service, pods []core_v1.Pod, istioConfigList models.IstioConfigList, onlyDefinitions bool) []models.ServiceOverview {
+func (in *SvcService) buildKubernetesServices(cluster string, svcs []core_v1.Service, pods []core_v1.Pod, istioConfigList models.IstioConfigList, onlyDefinitions bool) []models.ServiceOverview {
 	services := make([]models.ServiceOverview, len(svcs))
 	conf := in.config
 
@@ -347,6 +379,7 @@ func (in *SvcService) buildKubernetesServices(svcs []core_v1.Service, pods []cor
 		services[i] = models.ServiceOverview{
 			Name:                   item.Name,
 			Namespace:              item.Namespace,
+			Cluster:                cluster,
 			IstioSidecar:           hasSidecar,
 			IstioAmbient:           hasAmbient,
 			AppLabel:               appLabel,
@@ -355,6 +388,7 @@ func (in *SvcService) buildKubernetesServices(svcs []core_v1.Service, pods []cor
 			HealthAnnotations:      models.GetHealthAnnotation(item.Annotations, models.GetHealthConfigAnnotation()),
 			Labels:                 item.Labels,
 			Selector:               item.Spec.Selector,
+			Protocols:              servicePortsProtocols(item.Spec.Ports),
 			IstioReferences:        svcReferences,
 			KialiWizard:            kialiWizard,
 			ServiceRegistry:        "Kubernetes",
@@ -363,6 +397,20 @@ func (in *SvcService) buildKubernetesServices(svcs []core_v1.Service, pods []cor
 	return services
 }
 
+// servicePortsProtocols returns the distinct protocols of the service ports, in the order of the ports
+func servicePortsProtocols(ports []core_v1.ServicePort) []string {
+	protocols := []string{}
+	seen := make(map[string]bool)
+	for _, port := range ports {
+		protocol := kubernetes.ServicePortProtocol(port)
+		if !seen[protocol] {
+			seen[protocol] = true
+			protocols = append(protocols, protocol)
+		}
+	}
+	return protocols
+}
+
 // The istiod registry doesn't have a explicit flag when a service is deployed in a different control plane.
 // The only way to identify it is to check that the service has an address in the current cluster.
 // To avoid side effects, Kiali will process only services that belongs to the current cluster.
@@ -423,7 +471,7 @@ func filterIstioServiceByClusterId(clusterId string, item *kubernetes.RegistrySe
 	return false
 }
 
-func (in *SvcService) buildRegistryServices(rSvcs []*kubernetes.RegistryService, istioConfigList models.IstioConfigList) []models.ServiceOverview {
+func (in *SvcService) buildRegistryServices(cluster string, rSvcs []*kubernetes.RegistryService, istioConfigList models.IstioConfigList) []models.ServiceOverview {
 	services := []models.ServiceOverview{}
 	conf := in.config
 
@@ -440,6 +488,14 @@ func (in *SvcService) buildRegistryServices(rSvcs []*kubernetes.RegistryService,
 		}
 		// TODO wildcards may force additional checks on hostnames ?
 		svcServiceEntries := kubernetes.FilterServiceEntriesByHostname(istioConfigList.ServiceEntries, item.Hostname)
+		location, resolution := "", ""
+		if item.Attributes.ServiceRegistry == "External" && len(svcServiceEntries) > 0 {
+			// A MESH_INTERNAL ServiceEntry declares workloads that are part of the mesh,
+			// a MESH_EXTERNAL one (the Istio default) declares a service outside of the mesh
+			location = svcServiceEntries[0].Spec.Location.String()
+			resolution = svcServiceEntries[0].Spec.Resolution.String()
+			hasSidecar = svcServiceEntries[0].Spec.Location == api_networking_v1beta1.ServiceEntry_MESH_INTERNAL
+		}
 		svcDestinationRules := kubernetes.FilterDestinationRulesByHostname(istioConfigList.DestinationRules, item.Hostname)
 		svcVirtualServices := kubernetes.FilterVirtualServicesByHostname(istioConfigList.VirtualServices, item.Hostname)
 		svcGateways := kubernetes.FilterGatewaysByVirtualServices(istioConfigList.Gateways, svcVirtualServices)
@@ -465,6 +521,7 @@ func (in *SvcService) buildRegistryServices(rSvcs []*kubernetes.RegistryService,
 		service := models.ServiceOverview{
 			Name:              item.Attributes.Name,
 			Namespace:         item.Attributes.Namespace,
+			Cluster:           cluster,
 			IstioSidecar:      hasSidecar,
 			AppLabel:          appLabel,
 			Health:            models.EmptyServiceHealth(),
@@ -473,6 +530,8 @@ func (in *SvcService) buildRegistryServices(rSvcs []*kubernetes.RegistryService,
 			Selector:          item.Attributes.LabelSelectors,
 			IstioReferences:   svcReferences,
 			ServiceRegistry:   item.Attributes.ServiceRegistry,
+			Location:          location,
+			Resolution:        resolution,
 		}
 		services = append(services, service)
 	}
@@ -615,6 +674,7 @@ func (in *SvcService) GetServiceDetails(ctx context.Context, cluster, namespace,
 			AllNamespaces:           true,
 			Cluster:                 cluster,
 			Namespace:               namespace,
+			ExcludedNamespaces:      in.config.ExternalServices.Istio.ReferencesExcludedNamespacesRegexps(),
 			IncludeDestinationRules: true,
 			// TODO the frontend is merging the Gateways per ServiceDetails but it would be a clean design to locate it here
 			IncludeGateways:        true,
@@ -661,25 +721,7 @@ func (in *SvcService) GetServiceDetails(ctx context.Context, cluster, namespace,
 		wo = append(wo, wi)
 	}
 
-	serviceOverviews := make([]*models.ServiceOverview, 0)
-	// Convert filtered k8sClients services into ServiceOverview, only several attributes are needed
-	for _, item := range rSvcs {
-		// app label selector of services should match, loading all versions
-		if selector, err3 := labels.ConvertSelectorToLabelsMap(labelsSelector); err3 == nil {
-			if appSelector, ok := item.Attributes.LabelSelectors["app"]; ok && selector.Has("app") && appSelector == selector.Get("app") {
-				if _, ok1 := item.Attributes.LabelSelectors["version"]; ok1 {
-					ports := map[string]int{}
-					for _, port := range item.Ports {
-						ports[port.Name] = port.Port
-					}
-					serviceOverviews = append(serviceOverviews, &models.ServiceOverview{
-						Name:  item.Attributes.Name,
-						Ports: ports,
-					})
-				}
-			}
-		}
-	}
+	serviceOverviews := in.getVersionedSubServices(cluster, labelsSelector, rSvcs)
 	// loading the single service if no versions
 	if len(serviceOverviews) == 0 {
 		ports := map[string]int{}
@@ -687,14 +729,16 @@ func (in *SvcService) GetServiceDetails(ctx context.Context, cluster, namespace,
 			ports[port.Name] = int(port.Port)
 		}
 		serviceOverviews = append(serviceOverviews, &models.ServiceOverview{
-			Name:  svc.Name,
-			Ports: ports,
+			Name:    svc.Name,
+			Cluster: cluster,
+			Ports:   ports,
 		})
 	}
 
 	s := models.ServiceDetails{Workloads: wo, Health: hth, NamespaceMTLS: nsmtls, SubServices: serviceOverviews}
 	s.Service = svc
-	s.SetPods(kubernetes.FilterPodsByEndpoints(eps, pods))
+	endpointPods := kubernetes.FilterPodsByEndpoints(eps, pods)
+	s.SetPods(endpointPods)
 	// ServiceDetail will consider if the Service is a External/Federation entry
 	if s.Service.Type == "External" || s.Service.Type == "Federation" {
 		s.IstioSidecar = true
@@ -702,24 +746,122 @@ func (in *SvcService) GetServiceDetails(ctx context.Context, cluster, namespace,
 		s.SetIstioSidecar(wo)
 	}
 	s.SetEndpoints(eps)
+	s.SetEndpointsLocality(endpointPods, in.getPodsNodes(cluster, endpointPods))
 	s.SetRegistryEndpoints(rEps)
 	s.IstioPermissions = models.ResourcePermissions{
 		Create: vsCreate,
 		Update: vsUpdate,
 		Delete: vsDelete,
 	}
-	s.VirtualServices = kubernetes.FilterAutogeneratedVirtualServices(kubernetes.FilterVirtualServicesByService(istioConfigList.VirtualServices, namespace, service))
+	// The VirtualServices delegating routes to the service are part of its effective routing
+	s.VirtualServices = kubernetes.FilterAutogeneratedVirtualServices(kubernetes.FilterVirtualServicesByEffectiveRoutes(istioConfigList.VirtualServices, namespace, service))
 	s.DestinationRules = kubernetes.FilterDestinationRulesByService(istioConfigList.DestinationRules, namespace, service)
+	s.SetDestinationRuleSubsets(namespace, service)
 	s.K8sHTTPRoutes = kubernetes.FilterK8sHTTPRoutesByService(istioConfigList.K8sHTTPRoutes, namespace, service)
 	if s.Service.Type == "External" || s.Service.Type == "Federation" {
 		// On ServiceEntries cases the Service name is the hostname
 		s.ServiceEntries = kubernetes.FilterServiceEntriesByHostname(istioConfigList.ServiceEntries, s.Service.Name)
 	}
 	s.Cluster = cluster
+	if in.config.KialiFeatureFlags.MultiClusterServiceReplicas {
+		s.Replicas = in.getServiceReplicas(ctx, cluster, namespace, service)
+	}
 
 	return &s, nil
 }
 
+// getServiceReplicas returns the services with the same name and namespace on the other clusters, sorted by cluster.
+// The clusters where the namespace isn't accessible to the user, or where the service can't be read, are left out.
+func (in *SvcService) getServiceReplicas(ctx context.Context, cluster, namespace, service string) []models.ServiceReplica {
+	kubeCaches := in.kialiCache.GetKubeCaches()
+	clusters := make([]string, 0, len(kubeCaches))
+	for otherCluster := range kubeCaches {
+		if otherCluster != cluster {
+			clusters = append(clusters, otherCluster)
+		}
+	}
+	sort.Strings(clusters)
+
+	replicas := []models.ServiceReplica{}
+	for _, otherCluster := range clusters {
+		if _, err := in.businessLayer.Namespace.GetNamespaceByCluster(ctx, namespace, otherCluster); err != nil {
+			log.Debugf("Skipping the replicas of service [%s] in namespace [%s] of cluster [%s]: %s", service, namespace, otherCluster, err)
+			continue
+		}
+		kubeCache := kubeCaches[otherCluster]
+		if _, err := kubeCache.GetService(namespace, service); err != nil {
+			if !errors.IsNotFound(err) {
+				log.Debugf("Unable to get the replica of service [%s] in namespace [%s] of cluster [%s]: %s", service, namespace, otherCluster, err)
+			}
+			continue
+		}
+		replica := models.ServiceReplica{Cluster: otherCluster, Namespace: namespace, Name: service}
+		eps, err := kubeCache.GetEndpoints(namespace, service)
+		if err != nil && !errors.IsNotFound(err) {
+			log.Debugf("Unable to get the endpoints of the replica of service [%s] in namespace [%s] of cluster [%s]: %s", service, namespace, otherCluster, err)
+		}
+		(&replica.Endpoints).Parse(eps)
+		replicas = append(replicas, replica)
+	}
+	return replicas
+}
+
+// getPodsNodes returns the nodes running the pods, indexed by name. The nodes are cluster scoped, they are read
+// with the Kiali SA as only their locality is exposed: the nodes that can't be fetched are left out.
+func (in *SvcService) getPodsNodes(cluster string, pods []core_v1.Pod) map[string]*core_v1.Node {
+	nodes := make(map[string]*core_v1.Node)
+	saClient, ok := in.kialiSAClients[cluster]
+	if !ok {
+		return nodes
+	}
+	fetched := make(map[string]bool)
+	for _, pod := range pods {
+		nodeName := pod.Spec.NodeName
+		if nodeName == "" || fetched[nodeName] {
+			continue
+		}
+		fetched[nodeName] = true
+		node, err := saClient.GetNode(nodeName)
+		if err != nil {
+			log.Debugf("Unable to get the locality of node [%s] in cluster [%s]: %s", nodeName, cluster, err)
+			continue
+		}
+		nodes[nodeName] = node
+	}
+	return nodes
+}
+
+// getVersionedSubServices returns the registry services selecting the same app as the labels selector and a version,
+// using the app and version labels of the IstioLabels config.
+func (in *SvcService) getVersionedSubServices(cluster, labelsSelector string, rSvcs []*kubernetes.RegistryService) []*models.ServiceOverview {
+	appLabelName := in.config.IstioLabels.AppLabelName
+	versionLabelName := in.config.IstioLabels.VersionLabelName
+
+	serviceOverviews := make([]*models.ServiceOverview, 0)
+	selector, err := labels.ConvertSelectorToLabelsMap(labelsSelector)
+	if err != nil || !selector.Has(appLabelName) {
+		return serviceOverviews
+	}
+	// Convert filtered k8sClients services into ServiceOverview, only several attributes are needed
+	for _, item := range rSvcs {
+		// app label selector of services should match, loading all versions
+		if appSelector, ok := item.Attributes.LabelSelectors[appLabelName]; ok && appSelector == selector.Get(appLabelName) {
+			if _, ok1 := item.Attributes.LabelSelectors[versionLabelName]; ok1 {
+				ports := map[string]int{}
+				for _, port := range item.Ports {
+					ports[port.Name] = port.Port
+				}
+				serviceOverviews = append(serviceOverviews, &models.ServiceOverview{
+					Name:    item.Attributes.Name,
+					Cluster: cluster,
+					Ports:   ports,
+				})
+			}
+		}
+	}
+	return serviceOverviews
+}
+
 func (in *SvcService) UpdateService(ctx context.Context, cluster, namespace, service string, interval string, queryTime time.Time, jsonPatch string, patchType string) (*models.ServiceDetails, error) {
 	var end observability.EndFunc
 	ctx, end = observability.StartSpan(ctx, "UpdateService",
@@ -769,7 +911,11 @@ func (in *SvcService) GetService(ctx context.Context, cluster, namespace, servic
 
 	// Check if user has access to the namespace (RBAC) in cache scenarios and/or
 	// if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
+	// Not being able to see the namespace is reported as Forbidden, so callers can tell it apart from a missing service
 	if _, err := in.businessLayer.Namespace.GetNamespaceByCluster(ctx, namespace, cluster); err != nil {
+		if IsAccessibleError(err) || errors.IsForbidden(err) {
+			return models.Service{}, kubernetes.NewForbidden(service, "Kiali", "Service", err)
+		}
 		return models.Service{}, err
 	}
 
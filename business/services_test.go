@@ -2,6 +2,7 @@ package business
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"sort"
 	"testing"
@@ -10,9 +11,13 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
@@ -20,6 +25,7 @@ import (
 	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/prometheus"
 	"github.com/kiali/kiali/prometheus/prometheustest"
+	"github.com/kiali/kiali/tests/data"
 )
 
 func TestServiceListParsing(t *testing.T) {
@@ -53,6 +59,9 @@ func TestServiceListParsing(t *testing.T) {
 
 	assert.Contains(serviceNames, "reviews")
 	assert.Contains(serviceNames, "httpbin")
+	for _, service := range serviceList.Services {
+		assert.Equal(conf.KubernetesConfig.ClusterName, service.Cluster)
+	}
 }
 
 func TestParseRegistryServices(t *testing.T) {
@@ -95,11 +104,117 @@ func TestParseRegistryServices(t *testing.T) {
 		ServiceEntries: registryConfig.ServiceEntries,
 	}
 
-	parsedServices := svc.buildRegistryServices(registryServices, istioConfigList)
+	parsedServices := svc.buildRegistryServices(conf.KubernetesConfig.ClusterName, registryServices, istioConfigList)
 	assert.Equal(3, len(parsedServices))
 	assert.Equal(1, len(parsedServices[0].IstioReferences))
 	assert.Equal(1, len(parsedServices[1].IstioReferences))
 	assert.Equal(0, len(parsedServices[2].IstioReferences))
+	for _, service := range parsedServices {
+		assert.Equal(conf.KubernetesConfig.ClusterName, service.Cluster)
+	}
+}
+
+func TestBuildRegistryServicesServiceEntryLocation(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	k8s := new(kubetest.K8SClientMock)
+	k8s.On("IsOpenShift").Return(false)
+	k8s.On("IsGatewayAPI").Return(false)
+	setupGlobalMeshConfig()
+	k8sclients := make(map[string]kubernetes.ClientInterface)
+	k8sclients[conf.KubernetesConfig.ClusterName] = k8s
+	svc := NewWithBackends(k8sclients, k8sclients, nil, nil).Svc
+
+	fakeServiceEntry := func(name, host string, location api_networking_v1beta1.ServiceEntry_Location, resolution api_networking_v1beta1.ServiceEntry_Resolution) *networking_v1beta1.ServiceEntry {
+		se := &networking_v1beta1.ServiceEntry{}
+		se.Kind = "ServiceEntry"
+		se.Name = name
+		se.Namespace = "bookinfo"
+		se.Spec.Hosts = []string{host}
+		se.Spec.Location = location
+		se.Spec.Resolution = resolution
+		return se
+	}
+	fakeRegistryService := func(host, registry string) *kubernetes.RegistryService {
+		rSvc := &kubernetes.RegistryService{}
+		rSvc.Hostname = host
+		rSvc.Attributes.Name = host
+		rSvc.Attributes.Namespace = "bookinfo"
+		rSvc.Attributes.ServiceRegistry = registry
+		return rSvc
+	}
+
+	istioConfigList := models.IstioConfigList{
+		ServiceEntries: []*networking_v1beta1.ServiceEntry{
+			fakeServiceEntry("internal", "ratings.mesh.internal", api_networking_v1beta1.ServiceEntry_MESH_INTERNAL, api_networking_v1beta1.ServiceEntry_STATIC),
+			fakeServiceEntry("external", "api.example.com", api_networking_v1beta1.ServiceEntry_MESH_EXTERNAL, api_networking_v1beta1.ServiceEntry_DNS),
+		},
+	}
+	rSvcs := []*kubernetes.RegistryService{
+		fakeRegistryService("ratings.mesh.internal", "External"),
+		fakeRegistryService("api.example.com", "External"),
+		fakeRegistryService("reviews.bookinfo.svc.cluster.local", "Kubernetes"),
+	}
+
+	services := svc.buildRegistryServices("east", rSvcs, istioConfigList)
+	require.Len(services, 3)
+	for _, service := range services {
+		assert.Equal("east", service.Cluster)
+	}
+
+	assert.True(services[0].IstioSidecar)
+	assert.Equal("MESH_INTERNAL", services[0].Location)
+	assert.Equal("STATIC", services[0].Resolution)
+
+	assert.False(services[1].IstioSidecar)
+	assert.Equal("MESH_EXTERNAL", services[1].Location)
+	assert.Equal("DNS", services[1].Resolution)
+
+	// Not declared by a ServiceEntry
+	assert.False(services[2].IstioSidecar)
+	assert.Empty(services[2].Location)
+	assert.Empty(services[2].Resolution)
+}
+
+func TestGetVersionedSubServicesCustomVersionLabel(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	conf.IstioLabels.VersionLabelName = "app.kubernetes.io/version"
+	config.Set(conf)
+
+	k8s := new(kubetest.K8SClientMock)
+	k8s.On("IsOpenShift").Return(false)
+	k8s.On("IsGatewayAPI").Return(false)
+	setupGlobalMeshConfig()
+	k8sclients := make(map[string]kubernetes.ClientInterface)
+	k8sclients[conf.KubernetesConfig.ClusterName] = k8s
+	svc := NewWithBackends(k8sclients, k8sclients, nil, nil).Svc
+
+	fakeRegistryService := func(name string, selectors map[string]string) *kubernetes.RegistryService {
+		rSvc := &kubernetes.RegistryService{}
+		rSvc.Attributes.Name = name
+		rSvc.Attributes.Namespace = "bookinfo"
+		rSvc.Attributes.LabelSelectors = selectors
+		return rSvc
+	}
+	rSvcs := []*kubernetes.RegistryService{
+		fakeRegistryService("reviews", map[string]string{"app": "reviews"}),
+		fakeRegistryService("reviews-v1", map[string]string{"app": "reviews", "app.kubernetes.io/version": "v1"}),
+		fakeRegistryService("reviews-v2", map[string]string{"app": "reviews", "version": "v2"}),
+		fakeRegistryService("ratings-v1", map[string]string{"app": "ratings", "app.kubernetes.io/version": "v1"}),
+	}
+
+	subServices := svc.getVersionedSubServices("east", "app=reviews", rSvcs)
+	assert.Len(subServices, 1)
+	assert.Equal("reviews-v1", subServices[0].Name)
+	assert.Equal("east", subServices[0].Cluster)
+
+	assert.Empty(svc.getVersionedSubServices("east", "foo=bar", rSvcs))
 }
 
 func TestFilterLocalIstioRegistry(t *testing.T) {
@@ -156,6 +271,53 @@ func TestGetServiceListFromMultipleClusters(t *testing.T) {
 	assert.Equal(svcs.Services[1].Cluster, "west")
 }
 
+func TestGetServiceListFromMultipleClustersPartialFailure(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	conf.KubernetesConfig.ClusterName = "east"
+	config.Set(conf)
+
+	clientFactory := kubetest.NewK8SClientFactoryMock(nil)
+	clients := map[string]kubernetes.ClientInterface{
+		conf.KubernetesConfig.ClusterName: kubetest.NewFakeK8sClient(
+			&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+			&core_v1.Service{ObjectMeta: meta_v1.ObjectMeta{Name: "ratings-home-cluster", Namespace: "bookinfo"}},
+		),
+	}
+	clientFactory.SetClients(clients)
+	cache := newTestingCache(t, clientFactory, *conf)
+	kialiCache = cache
+
+	// The cache doesn't know the west cluster, listing its services fails
+	userClients := map[string]kubernetes.ClientInterface{
+		conf.KubernetesConfig.ClusterName: clients[conf.KubernetesConfig.ClusterName],
+		"west": kubetest.NewFakeK8sClient(
+			&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+			&core_v1.Service{ObjectMeta: meta_v1.ObjectMeta{Name: "ratings-west-cluster", Namespace: "bookinfo"}},
+		),
+	}
+	svc := NewWithBackends(userClients, userClients, nil, nil).Svc
+	svcs, err := svc.GetServiceList(context.TODO(), ServiceCriteria{Namespace: "bookinfo"})
+	require.NoError(err)
+	require.Len(svcs.Services, 1)
+	assert.Equal("ratings-home-cluster", svcs.Services[0].Name)
+	require.Len(svcs.ClusterErrors, 1)
+	assert.Contains(svcs.ClusterErrors, "west")
+
+	// Without any cluster listed, the error is returned
+	_, err = svc.GetServiceList(context.TODO(), ServiceCriteria{Namespace: "bookinfo", Cluster: "west"})
+	require.Error(err)
+
+	// No error reported when all the clusters are listed
+	svcs, err = svc.GetServiceList(context.TODO(), ServiceCriteria{Namespace: "bookinfo", Cluster: conf.KubernetesConfig.ClusterName})
+	require.NoError(err)
+	assert.Len(svcs.Services, 1)
+	assert.Nil(svcs.ClusterErrors)
+}
+
 func TestMultiClusterGetService(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -269,6 +431,189 @@ func TestMultiClusterGetServiceDetails(t *testing.T) {
 	assert.Equal(s.Service.Name, "ratings-west-cluster")
 }
 
+func TestGetServiceDetailsReplicas(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	conf.KialiFeatureFlags.MultiClusterServiceReplicas = true
+	config.Set(conf)
+
+	reviews := func() *core_v1.Service {
+		return &core_v1.Service{ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"}}
+	}
+	clientFactory := kubetest.NewK8SClientFactoryMock(nil)
+	clients := map[string]kubernetes.ClientInterface{
+		conf.KubernetesConfig.ClusterName: kubetest.NewFakeK8sClient(
+			&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+			reviews(),
+		),
+		"west": kubetest.NewFakeK8sClient(
+			&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+			reviews(),
+			&core_v1.Endpoints{
+				ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"},
+				Subsets: []core_v1.EndpointSubset{{
+					Addresses: []core_v1.EndpointAddress{{IP: "10.0.0.1"}},
+					Ports:     []core_v1.EndpointPort{{Name: "http", Port: 9080}},
+				}},
+			},
+		),
+		// The service doesn't exist on this cluster
+		"east": kubetest.NewFakeK8sClient(
+			&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		),
+	}
+	clientFactory.SetClients(clients)
+	cache := newTestingCache(t, clientFactory, *conf)
+	kialiCache = cache
+	t.Cleanup(func() { kialiCache = nil })
+
+	prom, err := prometheus.NewClient()
+	require.NoError(err)
+
+	promMock := new(prometheustest.PromAPIMock)
+	promMock.SpyArgumentsAndReturnEmpty(func(mock.Arguments) {})
+	prom.Inject(promMock)
+	svc := NewWithBackends(clients, clients, prom, nil).Svc
+	s, err := svc.GetServiceDetails(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "reviews", "60s", time.Now())
+	require.NoError(err)
+
+	require.Len(s.Replicas, 1)
+	replica := s.Replicas[0]
+	assert.Equal("west", replica.Cluster)
+	assert.Equal("bookinfo", replica.Namespace)
+	assert.Equal("reviews", replica.Name)
+	require.Len(replica.Endpoints, 1)
+	require.Len(replica.Endpoints[0].Addresses, 1)
+	assert.Equal("10.0.0.1", replica.Endpoints[0].Addresses[0].IP)
+
+	// The replicas are only correlated when enabled
+	conf.KialiFeatureFlags.MultiClusterServiceReplicas = false
+	config.Set(conf)
+	svc = NewWithBackends(clients, clients, prom, nil).Svc
+	s, err = svc.GetServiceDetails(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "reviews", "60s", time.Now())
+	require.NoError(err)
+	assert.Empty(s.Replicas)
+}
+
+func TestGetServiceDetailsDestinationRuleSubsets(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	config.Set(conf)
+
+	dr := data.AddSubsetToDestinationRule(data.CreateSubset("v1", "v1"),
+		data.AddSubsetToDestinationRule(data.CreateSubset("v2", "v2"),
+			data.CreateEmptyDestinationRule("bookinfo", "reviews", "reviews")))
+	vs := data.AddHttpRoutesToVirtualService(data.CreateHttpRouteDestination("reviews", "v1", 100),
+		data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"}))
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Service{ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"}},
+		dr,
+		vs,
+	)
+	SetupBusinessLayer(t, k8s, *conf)
+	t.Cleanup(func() { kialiCache = nil })
+
+	prom, err := prometheus.NewClient()
+	require.NoError(err)
+	promMock := new(prometheustest.PromAPIMock)
+	promMock.SpyArgumentsAndReturnEmpty(func(mock.Arguments) {})
+	prom.Inject(promMock)
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	svc := NewWithBackends(clients, clients, prom, nil).Svc
+	s, err := svc.GetServiceDetails(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "reviews", "60s", time.Now())
+	require.NoError(err)
+
+	require.Len(s.DestinationRuleSubsets, 1)
+	assert.Equal("reviews", s.DestinationRuleSubsets[0].Name)
+	assert.ElementsMatch([]models.SubsetUsage{{Name: "v1", Used: true}, {Name: "v2", Used: false}}, s.DestinationRuleSubsets[0].Subsets)
+}
+
+type forbiddenNodesClient struct{ kubernetes.ClientInterface }
+
+func (c *forbiddenNodesClient) GetNode(name string) (*core_v1.Node, error) {
+	return nil, fmt.Errorf("nodes %q is forbidden: User \"alice\" cannot get resource \"nodes\" in API group \"\" at the cluster scope", name)
+}
+
+func TestGetServiceDetailsEndpointsLocality(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	config.Set(conf)
+
+	fakePod := func(name, nodeName string) *core_v1.Pod {
+		return &core_v1.Pod{
+			ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: "bookinfo", Labels: map[string]string{"app": "reviews"}},
+			Spec:       core_v1.PodSpec{NodeName: nodeName},
+		}
+	}
+	fakeNode := func(name string, labels map[string]string) *core_v1.Node {
+		return &core_v1.Node{ObjectMeta: meta_v1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	podAddress := func(ip, name string) core_v1.EndpointAddress {
+		return core_v1.EndpointAddress{IP: ip, TargetRef: &core_v1.ObjectReference{Kind: "Pod", Name: name}}
+	}
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Service{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"},
+			Spec:       core_v1.ServiceSpec{Selector: map[string]string{"app": "reviews"}},
+		},
+		&core_v1.Endpoints{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"},
+			Subsets: []core_v1.EndpointSubset{{
+				Addresses: []core_v1.EndpointAddress{
+					podAddress("10.0.0.1", "reviews-a"),
+					podAddress("10.0.0.2", "reviews-b"),
+					podAddress("10.0.0.3", "reviews-unlabeled"),
+					podAddress("10.0.0.4", "reviews-unknown-node"),
+					{IP: "10.0.0.5"},
+				},
+			}},
+		},
+		fakePod("reviews-a", "node-a"),
+		fakePod("reviews-b", "node-b"),
+		fakePod("reviews-unlabeled", "node-unlabeled"),
+		fakePod("reviews-unknown-node", "node-unknown"),
+		fakeNode("node-a", map[string]string{core_v1.LabelTopologyRegion: "us-east", core_v1.LabelTopologyZone: "us-east-1a"}),
+		fakeNode("node-b", map[string]string{core_v1.LabelTopologyRegion: "us-east", core_v1.LabelTopologyZone: "us-east-1b"}),
+		fakeNode("node-unlabeled", nil),
+	)
+	SetupBusinessLayer(t, k8s, *conf)
+	t.Cleanup(func() { kialiCache = nil })
+
+	prom, err := prometheus.NewClient()
+	require.NoError(err)
+	promMock := new(prometheustest.PromAPIMock)
+	promMock.SpyArgumentsAndReturnEmpty(func(mock.Arguments) {})
+	prom.Inject(promMock)
+
+	// The user isn't allowed to get the nodes, they are read with the Kiali SA
+	userClients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: &forbiddenNodesClient{k8s}}
+	saClients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	svc := NewWithBackends(userClients, saClients, prom, nil).Svc
+	s, err := svc.GetServiceDetails(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "reviews", "60s", time.Now())
+	require.NoError(err)
+
+	require.Len(s.Endpoints, 1)
+	assert.Equal(models.Addresses{
+		{Kind: "Pod", Name: "reviews-a", IP: "10.0.0.1", Region: "us-east", Zone: "us-east-1a"},
+		{Kind: "Pod", Name: "reviews-b", IP: "10.0.0.2", Region: "us-east", Zone: "us-east-1b"},
+		{Kind: "Pod", Name: "reviews-unlabeled", IP: "10.0.0.3"},
+		{Kind: "Pod", Name: "reviews-unknown-node", IP: "10.0.0.4"},
+		{IP: "10.0.0.5"},
+	}, s.Endpoints[0].Addresses)
+}
+
 func TestMultiClusterGetServiceAppName(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -307,3 +652,160 @@ func TestMultiClusterGetServiceAppName(t *testing.T) {
 
 	assert.Equal("ratings", s)
 }
+
+// forbiddenNamespaceClient rejects access to any namespace as the API server does when RBAC denies it.
+type forbiddenNamespaceClient struct {
+	kubernetes.ClientInterface
+}
+
+func (f *forbiddenNamespaceClient) GetNamespace(namespace string) (*core_v1.Namespace, error) {
+	return nil, errors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, namespace, fmt.Errorf("user cannot get namespaces"))
+}
+
+func TestGetServiceForbiddenNamespace(t *testing.T) {
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	config.Set(conf)
+
+	k8s := &forbiddenNamespaceClient{ClientInterface: kubetest.NewFakeK8sClient(
+		&core_v1.Service{ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"}},
+	)}
+	SetupBusinessLayer(t, k8s, *conf)
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	svc := NewWithBackends(clients, clients, nil, nil).Svc
+	_, err := svc.GetService(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "reviews")
+	require.Error(err)
+	require.True(errors.IsForbidden(err))
+	require.False(errors.IsNotFound(err))
+}
+
+func TestGetServiceNotAccessibleNamespace(t *testing.T) {
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	conf.Deployment.AccessibleNamespaces = []string{"other"}
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Service{ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"}},
+	)
+	SetupBusinessLayer(t, k8s, *conf)
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	svc := NewWithBackends(clients, clients, nil, nil).Svc
+	_, err := svc.GetService(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "reviews")
+	require.Error(err)
+	require.True(errors.IsForbidden(err))
+}
+
+func TestGetServiceNotFound(t *testing.T) {
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Service{ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"}},
+	)
+	SetupBusinessLayer(t, k8s, *conf)
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	svc := NewWithBackends(clients, clients, nil, nil).Svc
+	_, err := svc.GetService(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "ratings")
+	require.Error(err)
+	require.True(errors.IsNotFound(err))
+	require.False(errors.IsForbidden(err))
+}
+
+func TestGetServiceListReferencesExcludedNamespaces(t *testing.T) {
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.ReferencesExcludedNamespaces = []string{"istio-.*"}
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Service{ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"}},
+	)
+	cache := SetupBusinessLayer(t, k8s, *conf)
+	cache.SetRegistryStatus(&kubernetes.RegistryStatus{
+		Configuration: &kubernetes.RegistryConfiguration{
+			VirtualServices: []*networking_v1beta1.VirtualService{
+				data.AddHttpRoutesToVirtualService(data.CreateHttpRouteDestination("reviews.bookinfo.svc.cluster.local", "", -1),
+					data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews.bookinfo.svc.cluster.local"})),
+				data.AddHttpRoutesToVirtualService(data.CreateHttpRouteDestination("reviews.bookinfo.svc.cluster.local", "", -1),
+					data.CreateEmptyVirtualService("reviews-mesh", "istio-system", []string{"reviews.bookinfo.svc.cluster.local"})),
+				data.AddHttpRoutesToVirtualService(data.CreateHttpRouteDestination("reviews.bookinfo.svc.cluster.local", "", -1),
+					data.CreateEmptyVirtualService("reviews-other", "other", []string{"reviews.bookinfo.svc.cluster.local"})),
+			},
+		},
+	})
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	svc := NewWithBackends(clients, clients, nil, nil).Svc
+	svcs, err := svc.GetServiceList(context.TODO(), ServiceCriteria{Namespace: "bookinfo", IncludeIstioResources: true})
+	require.NoError(err)
+	require.Len(svcs.Services, 1)
+
+	references := []string{}
+	for _, ref := range svcs.Services[0].IstioReferences {
+		references = append(references, ref.Name+"."+ref.Namespace)
+	}
+	require.ElementsMatch([]string{"reviews.bookinfo", "reviews-other.other"}, references)
+}
+
+func TestServiceListProtocols(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	grpc := "grpc"
+	objects := []runtime.Object{
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Service{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"},
+			Spec: core_v1.ServiceSpec{Ports: []core_v1.ServicePort{
+				{Name: "http", Port: 9080},
+				{Name: "grpc-api", Port: 9090},
+				{Name: "tcp-metrics", Port: 9091},
+				{Name: "http-admin", Port: 9092},
+			}},
+		},
+		&core_v1.Service{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "ratings", Namespace: "bookinfo"},
+			Spec: core_v1.ServiceSpec{Ports: []core_v1.ServicePort{
+				{Name: "api", Port: 9080, AppProtocol: &grpc},
+				{Port: 9081},
+			}},
+		},
+	}
+	conf := config.NewConfig()
+	config.Set(conf)
+	k8s := kubetest.NewFakeK8sClient(objects...)
+	setupGlobalMeshConfig()
+	SetupBusinessLayer(t, k8s, *conf)
+	k8sclients := make(map[string]kubernetes.ClientInterface)
+	k8sclients[conf.KubernetesConfig.ClusterName] = k8s
+	svc := NewWithBackends(k8sclients, k8sclients, nil, nil).Svc
+
+	criteria := ServiceCriteria{Namespace: "bookinfo", IncludeIstioResources: false, IncludeHealth: false}
+	serviceList, err := svc.GetServiceList(context.TODO(), criteria)
+	require.NoError(err)
+	require.Len(serviceList.Services, 2)
+
+	protocols := map[string][]string{}
+	for _, service := range serviceList.Services {
+		protocols[service.Name] = service.Protocols
+	}
+	// The protocols are distinct, in the order of the ports
+	assert.Equal([]string{"http", "grpc", "tcp"}, protocols["reviews"])
+	// The appProtocol wins over the port name, the unnamed ports are tcp
+	assert.Equal([]string{"grpc", "tcp"}, protocols["ratings"])
+}
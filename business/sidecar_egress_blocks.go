@@ -0,0 +1,169 @@
+package business
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/business/checkers"
+	"github.com/kiali/kiali/business/checkers/sidecars"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// GetBlockingSidecars finds the Sidecars applied to the workloads of a namespace whose egress hosts block the access of
+// those workloads to the control plane, or to the hosts they called in the rate interval. The called hosts come from
+// the telemetry, only the control plane is checked when Prometheus is not available.
+// It uses following parameters:
+// - "cluster":		cluster of the namespace
+// - "namespace":	namespace of the workloads
+// - "rateInterval":	interval of the requests, like "10m"
+// - "queryTime":	end of the interval
+func (in *WorkloadService) GetBlockingSidecars(ctx context.Context, cluster, namespace, rateInterval string, queryTime time.Time) ([]models.SidecarEgressBlock, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetBlockingSidecars",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("rateInterval", rateInterval),
+		observability.Attribute("queryTime", queryTime),
+	)
+	defer end()
+
+	namespaces := []string{namespace}
+	// Sidecars of the root namespace apply to the workloads of every namespace
+	rootNamespace := in.config.ExternalServices.Istio.RootNamespace
+	if rootNamespace != "" && rootNamespace != namespace {
+		namespaces = append(namespaces, rootNamespace)
+	}
+	var namespaceSidecars, rootSidecars []*networking_v1beta1.Sidecar
+	serviceEntries := []*networking_v1beta1.ServiceEntry{}
+	for _, ns := range namespaces {
+		istioConfigList, err := in.businessLayer.IstioConfig.GetIstioConfigList(ctx, IstioConfigCriteria{
+			Namespace:             ns,
+			Cluster:               cluster,
+			IncludeServiceEntries: true,
+			IncludeSidecars:       true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if ns == namespace {
+			namespaceSidecars = istioConfigList.Sidecars
+		} else {
+			rootSidecars = istioConfigList.Sidecars
+		}
+		serviceEntries = append(serviceEntries, istioConfigList.ServiceEntries...)
+	}
+
+	workloads, err := in.fetchWorkloadsFromCluster(ctx, cluster, namespace, "")
+	if err != nil {
+		return nil, err
+	}
+	calledHosts := in.getCalledHosts(cluster, namespace, rateInterval, queryTime)
+
+	// The workloads and their called hosts, by the Sidecar applied to them
+	sidecarsByKey := map[string]*networking_v1beta1.Sidecar{}
+	workloadsByKey := map[string][]string{}
+	hostsByKey := map[string][]string{}
+	for _, wk := range workloads {
+		sidecar := effectiveSidecar(labels.Set(wk.Labels).String(), namespaceSidecars, rootSidecars)
+		if sidecar == nil {
+			continue
+		}
+		key := sidecar.Namespace + "/" + sidecar.Name
+		sidecarsByKey[key] = sidecar
+		workloadsByKey[key] = append(workloadsByKey[key], wk.Name)
+		hostsByKey[key] = append(hostsByKey[key], calledHosts[wk.Name]...)
+	}
+	keys := make([]string, 0, len(sidecarsByKey))
+	for key := range sidecarsByKey {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	cpHost := in.controlPlaneHost(cluster)
+	blocks := []models.SidecarEgressBlock{}
+	for _, key := range keys {
+		sidecar := sidecarsByKey[key]
+		sort.Strings(hostsByKey[key])
+		checker := sidecars.EgressBlockingChecker{Sidecar: sidecar, ControlPlaneHost: cpHost, CalledHosts: hostsByKey[key], ServiceEntries: serviceEntries}
+		controlPlaneBlocked, blockedHosts := checker.BlockedHosts()
+		if !controlPlaneBlocked && len(blockedHosts) == 0 {
+			continue
+		}
+
+		block := models.SidecarEgressBlock{
+			Sidecar:      models.IstioValidationKey{ObjectType: checkers.SidecarCheckerType, Name: sidecar.Name, Namespace: sidecar.Namespace, Cluster: cluster},
+			Workloads:    workloadsByKey[key],
+			BlockedHosts: blockedHosts,
+		}
+		sort.Strings(block.Workloads)
+		if controlPlaneBlocked {
+			block.ControlPlaneHost = cpHost
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+// getCalledHosts returns the services called by the workloads of a namespace in the rate interval, by workload.
+// The telemetry is optional, nothing is returned when it is not available.
+func (in *WorkloadService) getCalledHosts(cluster, namespace, rateInterval string, queryTime time.Time) map[string][]string {
+	calledHosts := map[string][]string{}
+	if in.prom == nil {
+		return calledHosts
+	}
+	rates, err := in.prom.GetAllRequestRates(namespace, cluster, rateInterval, queryTime)
+	if err != nil {
+		log.Debugf("Cannot get the requests of namespace [%s], only the control plane is checked: %v", namespace, err)
+		return calledHosts
+	}
+	for _, sample := range rates {
+		if string(sample.Metric["source_workload_namespace"]) != namespace {
+			continue
+		}
+		workload, host := string(sample.Metric["source_workload"]), string(sample.Metric["destination_service"])
+		if workload == "" || host == "" || host == "unknown" || containsString(calledHosts[workload], host) {
+			continue
+		}
+		calledHosts[workload] = append(calledHosts[workload], host)
+	}
+	return calledHosts
+}
+
+// controlPlaneHost returns the host of the istiod service the proxies of the cluster connect to. The service is the
+// one selecting the pods of the istiod deployment, the one named like the deployment when several of them do.
+// Revisioned installs name the deployment after the revision, so its name is only used when the service can't be resolved.
+func (in *WorkloadService) controlPlaneHost(cluster string) string {
+	istio := in.config.ExternalServices.Istio
+	service := istio.IstiodDeploymentName
+	if kubeCache, err := in.cache.GetKubeCache(cluster); err != nil {
+		log.Debugf("Cannot resolve the istiod service of cluster [%s], the deployment name is used: %v", cluster, err)
+	} else if deployment, err := kubeCache.GetDeployment(in.config.IstioNamespace, istio.IstiodDeploymentName); err != nil {
+		log.Debugf("Cannot resolve the istiod service of cluster [%s], the deployment name is used: %v", cluster, err)
+	} else if allSvcs, err := kubeCache.GetServices(in.config.IstioNamespace, nil); err != nil {
+		log.Debugf("Cannot resolve the istiod service of cluster [%s], the deployment name is used: %v", cluster, err)
+	} else if svcs := kubernetes.FilterServicesBySelectedLabels(deployment.Spec.Template.Labels, allSvcs); len(svcs) == 0 {
+		log.Debugf("No service selects the istiod pods of cluster [%s], the deployment name is used", cluster)
+	} else {
+		sort.Slice(svcs, func(i, j int) bool { return svcs[i].Name < svcs[j].Name })
+		service = svcs[0].Name
+		for _, svc := range svcs {
+			if svc.Name == istio.IstiodDeploymentName {
+				service = svc.Name
+			}
+		}
+	}
+	return kubernetes.Host{
+		Service:       service,
+		Namespace:     in.config.IstioNamespace,
+		Cluster:       istio.IstioIdentityDomain,
+		CompleteInput: true,
+	}.String()
+}
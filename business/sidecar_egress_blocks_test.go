@@ -0,0 +1,135 @@
+package business
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus"
+	"github.com/kiali/kiali/prometheus/prometheustest"
+	"github.com/kiali/kiali/tests/data"
+)
+
+var reviewsV1CalledHosts = model.Vector{
+	{Metric: model.Metric{"source_workload": "reviews-v1", "source_workload_namespace": "bookinfo", "destination_service": "ratings.bookinfo.svc.cluster.local"}, Value: 5},
+	{Metric: model.Metric{"source_workload": "reviews-v1", "source_workload_namespace": "bookinfo", "destination_service": "details.travels.svc.cluster.local"}, Value: 2},
+	// Requests received by the namespace
+	{Metric: model.Metric{"source_workload": "productpage-v1", "source_workload_namespace": "front", "destination_service": "reviews.bookinfo.svc.cluster.local"}, Value: 3},
+}
+
+func setupSidecarEgressBlocks(t *testing.T, prom prometheus.ClientInterface, objects ...runtime.Object) (WorkloadService, string) {
+	conf := config.NewConfig()
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	config.Set(conf)
+
+	objects = append(objects,
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		fakeInjectionPod("reviews-v1-1", "docker.io/istio/proxyv2:1.18.0", nil, nil),
+	)
+	objects = append(objects, fakeReviewsV1Controllers()...)
+	k8s := kubetest.NewFakeK8sClient(objects...)
+	SetupBusinessLayer(t, k8s, *conf)
+	t.Cleanup(func() { kialiCache = nil })
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	return NewWithBackends(clients, clients, prom, nil).Workload, conf.KubernetesConfig.ClusterName
+}
+
+func TestGetBlockingSidecarsOverRestrictive(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	prom := new(prometheustest.PromClientMock)
+	prom.On("GetAllRequestRates", "bookinfo", mock.AnythingOfType("string"), "10m", mock.AnythingOfType("time.Time")).Return(reviewsV1CalledHosts, nil)
+	svc, cluster := setupSidecarEgressBlocks(t, prom,
+		data.AddHostsToSidecar([]string{"./*"}, data.CreateSidecar("default", "bookinfo")),
+		// Doesn't select the workload
+		data.AddSelectorToSidecar(map[string]string{"app": "ratings"}, data.AddHostsToSidecar([]string{"~/*"}, data.CreateSidecar("ratings", "bookinfo"))),
+	)
+
+	blocks, err := svc.GetBlockingSidecars(context.TODO(), cluster, "bookinfo", "10m", time.Now())
+	require.NoError(err)
+	assert.Equal([]models.SidecarEgressBlock{{
+		Sidecar:          models.IstioValidationKey{ObjectType: "sidecar", Name: "default", Namespace: "bookinfo", Cluster: cluster},
+		Workloads:        []string{"reviews-v1"},
+		ControlPlaneHost: "istiod.istio-system.svc.cluster.local",
+		BlockedHosts:     []string{"details.travels.svc.cluster.local"},
+	}}, blocks)
+}
+
+func TestGetBlockingSidecarsPermissive(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	prom := new(prometheustest.PromClientMock)
+	prom.On("GetAllRequestRates", "bookinfo", mock.AnythingOfType("string"), "10m", mock.AnythingOfType("time.Time")).Return(reviewsV1CalledHosts, nil)
+	svc, cluster := setupSidecarEgressBlocks(t, prom,
+		data.AddSelectorToSidecar(map[string]string{"app": "reviews"},
+			data.AddHostsToSidecar([]string{"./*", "istio-system/*", "travels/details.travels.svc.cluster.local"}, data.CreateSidecar("reviews", "bookinfo"))),
+		// Overridden by the Sidecar selecting the workload
+		data.AddHostsToSidecar([]string{"./*"}, data.CreateSidecar("default", "bookinfo")),
+	)
+
+	blocks, err := svc.GetBlockingSidecars(context.TODO(), cluster, "bookinfo", "10m", time.Now())
+	require.NoError(err)
+	assert.Empty(blocks)
+}
+
+func TestGetBlockingSidecarsWithoutTelemetry(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	prom := new(prometheustest.PromClientMock)
+	prom.On("GetAllRequestRates", "bookinfo", mock.AnythingOfType("string"), "10m", mock.AnythingOfType("time.Time")).Return(model.Vector{}, errors.New("prometheus unavailable"))
+	svc, cluster := setupSidecarEgressBlocks(t, prom,
+		data.AddHostsToSidecar([]string{"./*", "travels/*"}, data.CreateSidecar("default", "bookinfo")),
+	)
+
+	// Only the control plane is checked
+	blocks, err := svc.GetBlockingSidecars(context.TODO(), cluster, "bookinfo", "10m", time.Now())
+	require.NoError(err)
+	require.Len(blocks, 1)
+	assert.Equal("istiod.istio-system.svc.cluster.local", blocks[0].ControlPlaneHost)
+	assert.Empty(blocks[0].BlockedHosts)
+}
+
+func TestGetBlockingSidecarsRevisionedControlPlane(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	istiodLabels := map[string]string{"app": "istiod", "istio.io/rev": "1-18"}
+	prom := new(prometheustest.PromClientMock)
+	prom.On("GetAllRequestRates", "bookinfo", mock.AnythingOfType("string"), "10m", mock.AnythingOfType("time.Time")).Return(model.Vector{}, nil)
+	svc, cluster := setupSidecarEgressBlocks(t, prom,
+		data.AddHostsToSidecar([]string{"./*"}, data.CreateSidecar("default", "bookinfo")),
+		&apps_v1.Deployment{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "istiod", Namespace: "istio-system"},
+			Spec:       apps_v1.DeploymentSpec{Template: core_v1.PodTemplateSpec{ObjectMeta: meta_v1.ObjectMeta{Labels: istiodLabels}}},
+		},
+		&core_v1.Service{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "istiod-1-18", Namespace: "istio-system"},
+			Spec:       core_v1.ServiceSpec{Selector: istiodLabels},
+		},
+	)
+
+	// The host is the service selecting the istiod pods, not the deployment
+	blocks, err := svc.GetBlockingSidecars(context.TODO(), cluster, "bookinfo", "10m", time.Now())
+	require.NoError(err)
+	require.Len(blocks, 1)
+	assert.Equal("istiod-1-18.istio-system.svc.cluster.local", blocks[0].ControlPlaneHost)
+}
@@ -0,0 +1,126 @@
+package business
+
+import (
+	"context"
+	"sort"
+
+	api_telemetry_v1alpha1 "istio.io/api/telemetry/v1alpha1"
+	"istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// GetEffectiveTelemetry resolves the Telemetry configuration applied to a workload.
+// It follows the Istio precedence: the Telemetry of the root namespace without selector applies to the whole mesh,
+// it is overridden by the Telemetry of the namespace without selector, which is overridden by the Telemetry selecting the workload.
+// Overrides are resolved per section (metrics, tracing and access logging): a section left empty is inherited from the upper level.
+// When several Telemetries are defined at the same level Istio only applies the oldest one, so the same is done here.
+// It uses following parameters:
+// - "cluster":			cluster of the workload
+// - "namespace": 		namespace of the workload
+// - "workloadSelector":	labels of the workload, like "app=reviews,version=v1"
+func (in *IstioConfigService) GetEffectiveTelemetry(ctx context.Context, cluster, namespace, workloadSelector string) (models.EffectiveTelemetry, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetEffectiveTelemetry",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("workloadSelector", workloadSelector),
+	)
+	defer end()
+
+	fetchTelemetries := func(ns string) ([]*v1alpha1.Telemetry, error) {
+		criteria := IstioConfigCriteria{
+			Namespace:        ns,
+			Cluster:          cluster,
+			IncludeTelemetry: true,
+		}
+		istioConfigList, err := in.GetIstioConfigList(ctx, criteria)
+		if err != nil {
+			return nil, err
+		}
+		return istioConfigList.Telemetries, nil
+	}
+
+	telemetries, err := fetchTelemetries(namespace)
+	if err != nil {
+		return models.EffectiveTelemetry{}, err
+	}
+	namespaceLevel, workloadLevel := splitTelemetriesBySelector(telemetries)
+
+	// Levels sorted from the least to the most specific
+	levels := [][]*v1alpha1.Telemetry{}
+	if rootNamespace := in.config.ExternalServices.Istio.RootNamespace; rootNamespace != "" && rootNamespace != namespace {
+		rootTelemetries, err := fetchTelemetries(rootNamespace)
+		if err != nil {
+			return models.EffectiveTelemetry{}, err
+		}
+		// Telemetries with a selector in the root namespace only apply to the workloads of the root namespace
+		meshLevel, _ := splitTelemetriesBySelector(rootTelemetries)
+		levels = append(levels, meshLevel)
+	}
+	levels = append(levels, namespaceLevel, kubernetes.FilterTelemetriesBySelector(workloadSelector, workloadLevel))
+
+	selected := []*v1alpha1.Telemetry{}
+	for _, level := range levels {
+		if oldest := oldestTelemetry(level); oldest != nil {
+			selected = append(selected, oldest)
+		}
+	}
+	return mergeTelemetries(selected), nil
+}
+
+// splitTelemetriesBySelector separates the namespace wide Telemetries from the ones selecting workloads
+func splitTelemetriesBySelector(telemetries []*v1alpha1.Telemetry) (namespaceWide []*v1alpha1.Telemetry, withSelector []*v1alpha1.Telemetry) {
+	for _, tm := range telemetries {
+		if tm.Spec.Selector == nil || len(tm.Spec.Selector.MatchLabels) == 0 {
+			namespaceWide = append(namespaceWide, tm)
+		} else {
+			withSelector = append(withSelector, tm)
+		}
+	}
+	return namespaceWide, withSelector
+}
+
+// oldestTelemetry returns the Telemetry applied by Istio when several of them are defined at the same level.
+// The name breaks the ties, to be deterministic.
+func oldestTelemetry(telemetries []*v1alpha1.Telemetry) *v1alpha1.Telemetry {
+	if len(telemetries) == 0 {
+		return nil
+	}
+	sorted := make([]*v1alpha1.Telemetry, len(telemetries))
+	copy(sorted, telemetries)
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].CreationTimestamp.Equal(&sorted[j].CreationTimestamp) {
+			return sorted[i].CreationTimestamp.Before(&sorted[j].CreationTimestamp)
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted[0]
+}
+
+// mergeTelemetries overrides each section with the one of the next Telemetry, when it is set
+func mergeTelemetries(telemetries []*v1alpha1.Telemetry) models.EffectiveTelemetry {
+	effective := models.EffectiveTelemetry{
+		Spec:    &api_telemetry_v1alpha1.Telemetry{},
+		Sources: map[string]string{},
+	}
+	for _, tm := range telemetries {
+		source := tm.Namespace + "/" + tm.Name
+		if len(tm.Spec.Metrics) > 0 {
+			effective.Spec.Metrics = tm.Spec.Metrics
+			effective.Sources["metrics"] = source
+		}
+		if len(tm.Spec.Tracing) > 0 {
+			effective.Spec.Tracing = tm.Spec.Tracing
+			effective.Sources["tracing"] = source
+		}
+		if len(tm.Spec.AccessLogging) > 0 {
+			effective.Spec.AccessLogging = tm.Spec.AccessLogging
+			effective.Sources["accessLogging"] = source
+		}
+	}
+	return effective
+}
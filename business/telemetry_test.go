@@ -0,0 +1,108 @@
+package business
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_telemetry_v1alpha1 "istio.io/api/telemetry/v1alpha1"
+	api_v1beta1 "istio.io/api/type/v1beta1"
+	"istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+)
+
+func fakeTelemetry(name, namespace string, age time.Duration, selector map[string]string, spec *api_telemetry_v1alpha1.Telemetry) *v1alpha1.Telemetry {
+	tm := &v1alpha1.Telemetry{}
+	tm.Name = name
+	tm.Namespace = namespace
+	tm.CreationTimestamp = meta_v1.NewTime(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC).Add(-age))
+	tm.Spec.Metrics = spec.Metrics
+	tm.Spec.Tracing = spec.Tracing
+	tm.Spec.AccessLogging = spec.AccessLogging
+	if selector != nil {
+		tm.Spec.Selector = &api_v1beta1.WorkloadSelector{MatchLabels: selector}
+	}
+	return tm
+}
+
+func telemetryProviders(names ...string) []*api_telemetry_v1alpha1.ProviderRef {
+	providers := []*api_telemetry_v1alpha1.ProviderRef{}
+	for _, name := range names {
+		providers = append(providers, &api_telemetry_v1alpha1.ProviderRef{Name: name})
+	}
+	return providers
+}
+
+func TestGetEffectiveTelemetry(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		// Mesh default
+		fakeTelemetry("mesh-default", "istio-system", time.Hour, nil, &api_telemetry_v1alpha1.Telemetry{
+			Metrics:       []*api_telemetry_v1alpha1.Metrics{{Providers: telemetryProviders("prometheus")}},
+			Tracing:       []*api_telemetry_v1alpha1.Tracing{{Providers: telemetryProviders("zipkin")}},
+			AccessLogging: []*api_telemetry_v1alpha1.AccessLogging{{Providers: telemetryProviders("envoy")}},
+		}),
+		// Only applies to the workloads of the root namespace
+		fakeTelemetry("istiod-metrics", "istio-system", time.Hour, map[string]string{"app": "reviews"}, &api_telemetry_v1alpha1.Telemetry{
+			Metrics: []*api_telemetry_v1alpha1.Metrics{{Providers: telemetryProviders("stackdriver")}},
+		}),
+		// Namespace level, the newest one is ignored
+		fakeTelemetry("bookinfo-tracing", "bookinfo", 2*time.Hour, nil, &api_telemetry_v1alpha1.Telemetry{
+			Tracing: []*api_telemetry_v1alpha1.Tracing{{Providers: telemetryProviders("jaeger")}},
+		}),
+		fakeTelemetry("bookinfo-newer", "bookinfo", time.Minute, nil, &api_telemetry_v1alpha1.Telemetry{
+			Tracing:       []*api_telemetry_v1alpha1.Tracing{{Providers: telemetryProviders("lightstep")}},
+			AccessLogging: []*api_telemetry_v1alpha1.AccessLogging{{Disabled: &wrappers.BoolValue{Value: true}}},
+		}),
+		// Workload level
+		fakeTelemetry("reviews-logs", "bookinfo", time.Hour, map[string]string{"app": "reviews"}, &api_telemetry_v1alpha1.Telemetry{
+			AccessLogging: []*api_telemetry_v1alpha1.AccessLogging{{Providers: telemetryProviders("otel")}},
+		}),
+		fakeTelemetry("ratings-metrics", "bookinfo", time.Hour, map[string]string{"app": "ratings"}, &api_telemetry_v1alpha1.Telemetry{
+			Metrics: []*api_telemetry_v1alpha1.Metrics{{Providers: telemetryProviders("stackdriver")}},
+		}),
+	)
+	SetupBusinessLayer(t, k8s, *conf)
+
+	k8sclients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	configService := NewWithBackends(k8sclients, k8sclients, nil, nil).IstioConfig
+
+	effective, err := configService.GetEffectiveTelemetry(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "app=reviews,version=v1")
+	require.NoError(err)
+	assert.Nil(effective.Spec.Selector)
+	assert.Equal(map[string]string{
+		"metrics":       "istio-system/mesh-default",
+		"tracing":       "bookinfo/bookinfo-tracing",
+		"accessLogging": "bookinfo/reviews-logs",
+	}, effective.Sources)
+	require.Len(effective.Spec.Metrics, 1)
+	assert.Equal("prometheus", effective.Spec.Metrics[0].Providers[0].Name)
+	require.Len(effective.Spec.Tracing, 1)
+	assert.Equal("jaeger", effective.Spec.Tracing[0].Providers[0].Name)
+	require.Len(effective.Spec.AccessLogging, 1)
+	assert.Equal("otel", effective.Spec.AccessLogging[0].Providers[0].Name)
+
+	effective, err = configService.GetEffectiveTelemetry(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "app=details")
+	require.NoError(err)
+	assert.Equal(map[string]string{
+		"metrics":       "istio-system/mesh-default",
+		"tracing":       "bookinfo/bookinfo-tracing",
+		"accessLogging": "istio-system/mesh-default",
+	}, effective.Sources)
+}
@@ -2,10 +2,14 @@ package business
 
 import (
 	"context"
+	"sort"
+	"sync"
 
 	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
-	core_v1 "k8s.io/api/core/v1"
 
+	"github.com/kiali/kiali/business/checkers"
+	"github.com/kiali/kiali/business/checkers/destinationrules"
+	"github.com/kiali/kiali/business/checkers/peerauthentications"
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/kubernetes/cache"
@@ -119,6 +123,173 @@ func (in *TLSService) NamespaceWidemTLSStatus(ctx context.Context, namespace, cl
 	}, nil
 }
 
+// MeshmTLSSummary summarizes the mTLS posture of all the namespaces accessible by the user, with a breakdown by cluster.
+// The clusters are summarized concurrently. A remote cluster failing is skipped, the home cluster failing is an error.
+func (in *TLSService) MeshmTLSSummary(ctx context.Context) (models.MTLSSummary, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "MeshmTLSSummary",
+		observability.Attribute("package", "business"),
+	)
+	defer end()
+
+	clusters := make([]string, 0, len(in.userClients))
+	for cluster := range in.userClients {
+		clusters = append(clusters, cluster)
+	}
+	sort.Strings(clusters)
+
+	clusterSummaries := make([]*models.ClusterMTLSSummary, len(clusters))
+	errs := make([]error, len(clusters))
+	wg := sync.WaitGroup{}
+	for i, cluster := range clusters {
+		// hasAutoMTLSEnabled caches its result, so it is not called by the goroutines
+		autoMtls := in.hasAutoMTLSEnabled(cluster)
+		wg.Add(1)
+		go func(i int, cluster string, autoMtls bool) {
+			defer wg.Done()
+			clusterSummaries[i], errs[i] = in.clusterMTLSSummary(ctx, cluster, autoMtls)
+		}(i, cluster, autoMtls)
+	}
+	wg.Wait()
+
+	summary := models.MTLSSummary{Clusters: []models.ClusterMTLSSummary{}}
+	homeCluster := config.Get().KubernetesConfig.ClusterName
+	for i, cluster := range clusters {
+		if errs[i] != nil {
+			if cluster == homeCluster {
+				return models.MTLSSummary{}, errs[i]
+			}
+			log.Errorf("Unable to get the mTLS summary of cluster [%s]: %s. Skipping", cluster, errs[i])
+			continue
+		}
+		clusterSummary := clusterSummaries[i]
+		summary.Strict += clusterSummary.Strict
+		summary.Permissive += clusterSummary.Permissive
+		summary.Disable += clusterSummary.Disable
+		summary.Conflicting += clusterSummary.Conflicting
+		summary.Clusters = append(summary.Clusters, *clusterSummary)
+	}
+	return summary, nil
+}
+
+// clusterMTLSSummary evaluates each namespace of a cluster with the namespace-wide mTLS status and the mTLS checkers of the validations
+func (in *TLSService) clusterMTLSSummary(ctx context.Context, cluster string, autoMtls bool) (*models.ClusterMTLSSummary, error) {
+	nss, err := in.getNamespaces(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(nss)
+
+	criteria := IstioConfigCriteria{
+		AllNamespaces:              true,
+		Cluster:                    cluster,
+		IncludeDestinationRules:    true,
+		IncludePeerAuthentications: true,
+	}
+	istioConfigList, err := in.businessLayer.IstioConfig.GetIstioConfigList(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	meshPas := kubernetes.FilterPeerAuthenticationByNamespace(config.Get().ExternalServices.Istio.RootNamespace, istioConfigList.PeerAuthentications)
+	drs := kubernetes.FilterDestinationRulesByNamespaces(nss, istioConfigList.DestinationRules)
+	// Without any PeerAuthentication, the workloads accept both plain text and mTLS traffic
+	meshMode := peerAuthnMode(meshPas, "PERMISSIVE")
+
+	summary := &models.ClusterMTLSSummary{
+		Cluster:         cluster,
+		AutoMTLSEnabled: autoMtls,
+		Namespaces:      []models.NamespaceMTLSSummary{},
+	}
+	for _, namespace := range nss {
+		pas := kubernetes.FilterPeerAuthenticationByNamespace(namespace, istioConfigList.PeerAuthentications)
+		mtlsDetails := kubernetes.MTLSDetails{
+			DestinationRules:        drs,
+			MeshPeerAuthentications: meshPas,
+			PeerAuthentications:     pas,
+			EnabledAutoMtls:         autoMtls,
+		}
+		if config.IsRootNamespace(namespace) {
+			pas = []*security_v1beta1.PeerAuthentication{}
+		}
+		mtlsStatus := mtls.MtlsStatus{
+			PeerAuthentications: pas,
+			DestinationRules:    drs,
+			AutoMtlsEnabled:     autoMtls,
+			AllowPermissive:     false,
+		}
+
+		nsSummary := models.NamespaceMTLSSummary{
+			Namespace: namespace,
+			Mode:      peerAuthnMode(pas, meshMode),
+			Status:    mtlsStatus.NamespaceMtlsStatus(namespace).OverallStatus,
+			Conflicts: mtlsConflicts(namespace, mtlsDetails),
+		}
+		switch nsSummary.Mode {
+		case "STRICT":
+			summary.Strict++
+		case "DISABLE":
+			summary.Disable++
+		default:
+			summary.Permissive++
+		}
+		if len(nsSummary.Conflicts) > 0 {
+			summary.Conflicting++
+		}
+		summary.Namespaces = append(summary.Namespaces, nsSummary)
+	}
+	return summary, nil
+}
+
+// peerAuthnMode returns the mode of the first PeerAuthentication without selector setting it, or the inherited mode
+func peerAuthnMode(pas []*security_v1beta1.PeerAuthentication, inherited string) string {
+	for _, pa := range pas {
+		if _, mode := kubernetes.PeerAuthnHasMTLSEnabled(pa); mode == "STRICT" || mode == "PERMISSIVE" || mode == "DISABLE" {
+			return mode
+		}
+	}
+	return inherited
+}
+
+// mtlsConflicts runs the mTLS checkers of the validations on the PeerAuthentications and DestinationRules of a namespace.
+// The objects of the root namespace are checked as mesh-wide objects.
+func mtlsConflicts(namespace string, mtlsDetails kubernetes.MTLSDetails) []*models.IstioCheck {
+	var enabledCheckers []checkers.Checker
+	isRootNamespace := config.IsRootNamespace(namespace)
+	for _, pa := range mtlsDetails.PeerAuthentications {
+		if isRootNamespace {
+			enabledCheckers = append(enabledCheckers,
+				peerauthentications.DisabledMeshWideChecker{PeerAuthn: pa, DestinationRules: mtlsDetails.DestinationRules},
+				peerauthentications.MeshMtlsChecker{MeshPolicy: pa, MTLSDetails: mtlsDetails, IsServiceMesh: false})
+		} else {
+			enabledCheckers = append(enabledCheckers,
+				peerauthentications.DisabledNamespaceWideChecker{PeerAuthn: pa, DestinationRules: mtlsDetails.DestinationRules},
+				peerauthentications.NamespaceMtlsChecker{PeerAuthn: pa, MTLSDetails: mtlsDetails})
+		}
+	}
+	for _, dr := range mtlsDetails.DestinationRules {
+		if dr.Namespace != namespace {
+			continue
+		}
+		if isRootNamespace {
+			enabledCheckers = append(enabledCheckers,
+				destinationrules.DisabledMeshWideMTLSChecker{DestinationRule: dr, MeshPeerAuthns: mtlsDetails.MeshPeerAuthentications},
+				destinationrules.MeshWideMTLSChecker{DestinationRule: dr, MTLSDetails: mtlsDetails})
+		} else {
+			enabledCheckers = append(enabledCheckers,
+				destinationrules.DisabledNamespaceWideMTLSChecker{DestinationRule: dr, MTLSDetails: mtlsDetails},
+				destinationrules.NamespaceWideMTLSChecker{DestinationRule: dr, MTLSDetails: mtlsDetails})
+		}
+	}
+
+	var conflicts []*models.IstioCheck
+	for _, checker := range enabledCheckers {
+		checks, _ := checker.Check()
+		conflicts = append(conflicts, checks...)
+	}
+	return conflicts
+}
+
 func (in *TLSService) getNamespaces(ctx context.Context, cluster string) ([]string, error) {
 	nss, nssErr := in.businessLayer.Namespace.GetNamespacesForCluster(ctx, cluster)
 	if nssErr != nil {
@@ -147,13 +318,7 @@ func (in *TLSService) hasAutoMTLSEnabled(cluster string) bool {
 	}
 
 	cfg := config.Get()
-	var istioConfig *core_v1.ConfigMap
-	var err error
-	if IsNamespaceCached(cfg.IstioNamespace) {
-		istioConfig, err = kubeCache.GetConfigMap(cfg.IstioNamespace, cfg.ExternalServices.Istio.ConfigMapName)
-	} else {
-		istioConfig, err = userClient.GetConfigMap(cfg.IstioNamespace, cfg.ExternalServices.Istio.ConfigMapName)
-	}
+	istioConfig, err := newReadThrough(kubeCache, userClient).getConfigMap(cfg.IstioNamespace, cfg.ExternalServices.Istio.ConfigMapName)
 	if err != nil {
 		return true
 	}
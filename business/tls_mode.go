@@ -0,0 +1,261 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/cache"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// namespacePeerAuthenticationName is the name of the namespace-wide PeerAuthentication created when the namespace has none
+const namespacePeerAuthenticationName = "default"
+
+// conflictingTLSModes maps a PeerAuthentication mode to the DestinationRule TLS mode conflicting with it, and the mode it is
+// reconciled to: the clients can't send plain text to STRICT servers, nor Istio mTLS to servers where mTLS is disabled
+var conflictingTLSModes = map[string][2]string{
+	"STRICT":  {"DISABLE", "ISTIO_MUTUAL"},
+	"DISABLE": {"ISTIO_MUTUAL", "DISABLE"},
+}
+
+// ApplyMtlsMode sets the mTLS mode of a namespace in one operation: the namespace-wide PeerAuthentication is created,
+// or patched when it exists, and the DestinationRules for the services of the namespace whose TLS settings conflict with
+// the mode are reconciled. These are the DestinationRules of the namespace, and the ones of the root namespace or of the
+// client namespaces accessible to the user whose host is a service of the namespace. The ones for external hosts or the
+// services of other namespaces configure other servers. The user needs the permissions to create and patch
+// PeerAuthentications in the namespace and to patch the DestinationRules in their namespaces, they are checked before
+// any change. The DestinationRules are changed first, so the clients are ready when the servers switch mode, and the
+// changes already applied are rolled back when one fails.
+// It returns the resulting PeerAuthentication and the reconciled DestinationRules.
+// It uses following parameters:
+// - "cluster":		cluster of the namespace
+// - "namespace":	namespace to configure
+// - "mode":		"STRICT", "PERMISSIVE" or "DISABLE"
+func (in *TLSService) ApplyMtlsMode(ctx context.Context, cluster, namespace, mode string) (models.IstioConfigList, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "ApplyMtlsMode",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("mode", mode),
+	)
+	defer end()
+
+	if mode != "STRICT" && mode != "PERMISSIVE" && mode != "DISABLE" {
+		return models.IstioConfigList{}, api_errors.NewBadRequest(fmt.Sprintf("invalid mTLS mode [%s], expected STRICT, PERMISSIVE or DISABLE", mode))
+	}
+
+	ns, err := in.businessLayer.Namespace.GetNamespaceByCluster(ctx, namespace, cluster)
+	if err != nil {
+		return models.IstioConfigList{}, err
+	}
+
+	userClient, ok := in.userClients[cluster]
+	if !ok {
+		return models.IstioConfigList{}, fmt.Errorf("cluster [%s] is not found or is not accessible for Kiali", cluster)
+	}
+
+	istioConfigList, err := in.businessLayer.IstioConfig.GetIstioConfigList(ctx, IstioConfigCriteria{
+		Cluster:                    cluster,
+		Namespace:                  namespace,
+		IncludeDestinationRules:    true,
+		IncludePeerAuthentications: true,
+	})
+	if err != nil {
+		return models.IstioConfigList{}, err
+	}
+
+	var namespacePA *security_v1beta1.PeerAuthentication
+	for _, pa := range istioConfigList.PeerAuthentications {
+		if pa.Spec.Selector == nil || len(pa.Spec.Selector.MatchLabels) == 0 {
+			namespacePA = pa
+			break
+		}
+	}
+	// The DestinationRules to reconcile, in the order they are changed
+	var conflictingDRs []*networking_v1beta1.DestinationRule
+	drPatches := map[*networking_v1beta1.DestinationRule][]tlsModePatch{}
+	if modes, found := conflictingTLSModes[mode]; found {
+		kubeCache, err := in.kialiCache.GetKubeCache(cluster)
+		if err != nil {
+			return models.IstioConfigList{}, err
+		}
+		destinationRules := append([]*networking_v1beta1.DestinationRule{}, istioConfigList.DestinationRules...)
+		destinationRules = append(destinationRules, in.getClientDestinationRules(ctx, cluster, namespace)...)
+		for _, dr := range destinationRules {
+			if !isNamespaceServiceHost(kubeCache, dr.Spec.Host, dr.Namespace, namespace) {
+				continue
+			}
+			if patches := destinationRuleTLSModePatches(dr, modes[0], modes[1]); len(patches) > 0 {
+				conflictingDRs = append(conflictingDRs, dr)
+				drPatches[dr] = patches
+			}
+		}
+	}
+
+	// Nothing is changed unless all the changes are allowed
+	canCreate, canPatch, _ := getPermissions(ctx, userClient, cluster, namespace, kubernetes.PeerAuthentications)
+	if (namespacePA == nil && !canCreate) || (namespacePA != nil && !canPatch) {
+		return models.IstioConfigList{}, kubernetes.NewForbidden(namespacePeerAuthenticationName, kubernetes.SecurityGroupVersion.Group, kubernetes.PeerAuthentications,
+			fmt.Errorf("user is not allowed to set the PeerAuthentication of namespace [%s]", namespace))
+	}
+	checkedNamespaces := map[string]bool{}
+	for _, dr := range conflictingDRs {
+		if checkedNamespaces[dr.Namespace] {
+			continue
+		}
+		if _, canPatch, _ := getPermissions(ctx, userClient, cluster, dr.Namespace, kubernetes.DestinationRules); !canPatch {
+			return models.IstioConfigList{}, kubernetes.NewForbidden(dr.Namespace, kubernetes.NetworkingGroupVersionV1Beta1.Group, kubernetes.DestinationRules,
+				fmt.Errorf("user is not allowed to reconcile the DestinationRules of namespace [%s]", dr.Namespace))
+		}
+		checkedNamespaces[dr.Namespace] = true
+	}
+
+	result := models.IstioConfigList{
+		Namespace:           *ns,
+		DestinationRules:    []*networking_v1beta1.DestinationRule{},
+		PeerAuthentications: []*security_v1beta1.PeerAuthentication{},
+	}
+	var rollbacks []func() error
+	rollback := func(cause error) error {
+		for i := len(rollbacks) - 1; i >= 0; i-- {
+			if err := rollbacks[i](); err != nil {
+				log.Errorf("Error rolling back the mTLS mode of namespace [%s]: %s", namespace, err)
+			}
+		}
+		return cause
+	}
+
+	for _, dr := range conflictingDRs {
+		patches := drPatches[dr]
+		detail, err := in.businessLayer.IstioConfig.UpdateIstioConfigDetail(cluster, dr.Namespace, kubernetes.DestinationRules, dr.Name, jsonTLSModePatch(patches, false), dr.ResourceVersion)
+		if err != nil {
+			return models.IstioConfigList{}, rollback(err)
+		}
+		drNamespace, name := dr.Namespace, dr.Name
+		rollbacks = append(rollbacks, func() error {
+			_, err := in.businessLayer.IstioConfig.UpdateIstioConfigDetail(cluster, drNamespace, kubernetes.DestinationRules, name, jsonTLSModePatch(patches, true), "")
+			return err
+		})
+		result.DestinationRules = append(result.DestinationRules, detail.DestinationRule)
+	}
+
+	var detail models.IstioConfigDetails
+	if namespacePA == nil {
+		body := fmt.Sprintf(`{"apiVersion":%q,"kind":"PeerAuthentication","metadata":{"name":%q,"namespace":%q},"spec":{"mtls":{"mode":%q}}}`,
+			kubernetes.SecurityGroupVersion.String(), namespacePeerAuthenticationName, namespace, mode)
+		if detail, err = in.businessLayer.IstioConfig.CreateIstioConfigDetail(cluster, namespace, kubernetes.PeerAuthentications, []byte(body)); err != nil {
+			return models.IstioConfigList{}, rollback(err)
+		}
+	} else {
+		patch := fmt.Sprintf(`{"spec":{"mtls":{"mode":%q}}}`, mode)
+		if detail, err = in.businessLayer.IstioConfig.UpdateIstioConfigDetail(cluster, namespace, kubernetes.PeerAuthentications, namespacePA.Name, patch, namespacePA.ResourceVersion); err != nil {
+			return models.IstioConfigList{}, rollback(err)
+		}
+	}
+	result.PeerAuthentications = append(result.PeerAuthentications, detail.PeerAuthentication)
+	return result, nil
+}
+
+// getClientDestinationRules returns the DestinationRules of the other namespaces accessible to the user, like the root
+// namespace or the namespaces of the clients, which may configure the clients of the services of the namespace.
+// They are fetched from the registry, the ones that can't be fetched are not reconciled.
+func (in *TLSService) getClientDestinationRules(ctx context.Context, cluster, namespace string) []*networking_v1beta1.DestinationRule {
+	namespaces, err := in.businessLayer.Namespace.GetNamespacesForCluster(ctx, cluster)
+	if err != nil {
+		log.Warningf("DestinationRules of the other namespaces not reconciled with the mTLS mode of namespace [%s]: %s", namespace, err)
+		return nil
+	}
+	accessible := make(map[string]bool, len(namespaces))
+	for _, ns := range namespaces {
+		accessible[ns.Name] = true
+	}
+
+	istioConfigList, err := in.businessLayer.IstioConfig.GetIstioConfigList(ctx, IstioConfigCriteria{
+		AllNamespaces:           true,
+		Cluster:                 cluster,
+		IncludeDestinationRules: true,
+	})
+	if err != nil {
+		log.Warningf("DestinationRules of the other namespaces not reconciled with the mTLS mode of namespace [%s]: %s", namespace, err)
+		return nil
+	}
+
+	var destinationRules []*networking_v1beta1.DestinationRule
+	for _, dr := range istioConfigList.DestinationRules {
+		if dr.Namespace != namespace && accessible[dr.Namespace] {
+			destinationRules = append(destinationRules, dr)
+		}
+	}
+	return destinationRules
+}
+
+// isNamespaceServiceHost tells if the host of a DestinationRule of drNamespace resolves to a service of the namespace,
+// or to all of them with a wildcard
+func isNamespaceServiceHost(kubeCache cache.KubeCache, host, drNamespace, namespace string) bool {
+	parsedHost := kubernetes.GetHost(host, drNamespace, []string{namespace})
+	if !parsedHost.CompleteInput || parsedHost.Namespace != namespace {
+		return false
+	}
+	if parsedHost.IsWildcard() {
+		return parsedHost.Service == "*"
+	}
+	_, err := kubeCache.GetService(namespace, parsedHost.Service)
+	return err == nil
+}
+
+// tlsModePatch is the change of the TLS mode of a traffic policy of a DestinationRule
+type tlsModePatch struct {
+	path string
+	from string
+	to   string
+}
+
+// destinationRuleTLSModePatches returns the changes of the traffic policies of a DestinationRule set to the conflicting TLS mode:
+// the top level one, the ones of the subsets, and their port level settings
+func destinationRuleTLSModePatches(dr *networking_v1beta1.DestinationRule, conflicting, reconciled string) []tlsModePatch {
+	var patches []tlsModePatch
+	appendPolicy := func(path string, policy *api_networking_v1beta1.TrafficPolicy) {
+		if policy == nil {
+			return
+		}
+		if policy.Tls != nil && policy.Tls.Mode.String() == conflicting {
+			patches = append(patches, tlsModePatch{path: path + "/tls/mode", from: conflicting, to: reconciled})
+		}
+		for i, portPolicy := range policy.PortLevelSettings {
+			if portPolicy != nil && portPolicy.Tls != nil && portPolicy.Tls.Mode.String() == conflicting {
+				patches = append(patches, tlsModePatch{path: fmt.Sprintf("%s/portLevelSettings/%d/tls/mode", path, i), from: conflicting, to: reconciled})
+			}
+		}
+	}
+	appendPolicy("/spec/trafficPolicy", dr.Spec.TrafficPolicy)
+	for i, subset := range dr.Spec.Subsets {
+		if subset != nil {
+			appendPolicy(fmt.Sprintf("/spec/subsets/%d/trafficPolicy", i), subset.TrafficPolicy)
+		}
+	}
+	return patches
+}
+
+// jsonTLSModePatch returns the JSON patch applying the changes of TLS mode, or reverting them
+func jsonTLSModePatch(patches []tlsModePatch, revert bool) string {
+	operations := make([]map[string]string, 0, len(patches))
+	for _, patch := range patches {
+		value := patch.to
+		if revert {
+			value = patch.from
+		}
+		operations = append(operations, map[string]string{"op": "replace", "path": patch.path, "value": value})
+	}
+	bytePatch, _ := json.Marshal(operations)
+	return string(bytePatch)
+}
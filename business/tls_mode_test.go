@@ -0,0 +1,222 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	core_v1 "k8s.io/api/core/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/tests/data"
+)
+
+// fakePlainTextDestinationRule disables TLS for the reviews host and for the port 9080 of its v2 subset
+func fakePlainTextDestinationRule() *networking_v1beta1.DestinationRule {
+	dr := data.AddTrafficPolicyToDestinationRule(data.CreateDisabledMTLSTrafficPolicyForDestinationRules(),
+		data.CreateEmptyDestinationRule("bookinfo", "reviews", "reviews.bookinfo.svc.cluster.local"))
+	dr.Spec.Subsets = []*api_networking_v1beta1.Subset{
+		{Name: "v1", Labels: map[string]string{"version": "v1"}},
+		{Name: "v2", Labels: map[string]string{"version": "v2"}, TrafficPolicy: &api_networking_v1beta1.TrafficPolicy{
+			PortLevelSettings: []*api_networking_v1beta1.TrafficPolicy_PortTrafficPolicy{{
+				Port: &api_networking_v1beta1.PortSelector{Number: 9080},
+				Tls:  &api_networking_v1beta1.ClientTLSSettings{Mode: api_networking_v1beta1.ClientTLSSettings_DISABLE},
+			}},
+		}},
+	}
+	return dr
+}
+
+func setupMtlsModeTLSService(t *testing.T, forbidden bool, objects ...runtime.Object) (*TLSService, kubernetes.ClientInterface, *config.Config) {
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	config.Set(conf)
+
+	objects = append(objects,
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Service{ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"}},
+		&core_v1.Service{ObjectMeta: meta_v1.ObjectMeta{Name: "ratings", Namespace: "bookinfo"}},
+	)
+	k8s := kubetest.NewFakeK8sClient(objects...)
+	SetupBusinessLayer(t, k8s, *conf)
+	t.Cleanup(func() { kialiCache = nil })
+
+	client := &namespaceAccessReview{ClientInterface: k8s, forbidden: map[string]bool{"bookinfo": forbidden}}
+	k8sclients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: client}
+	layer := NewWithBackends(k8sclients, k8sclients, nil, nil)
+	return &layer.TLS, client, conf
+}
+
+func TestApplyMtlsModeStrictWithConflictingDestinationRule(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	mtlsDR := data.AddTrafficPolicyToDestinationRule(data.CreateMTLSTrafficPolicyForDestinationRules(),
+		data.CreateEmptyDestinationRule("bookinfo", "ratings", "ratings.bookinfo.svc.cluster.local"))
+	tlsService, client, conf := setupMtlsModeTLSService(t, false, fakePlainTextDestinationRule(), mtlsDR)
+
+	result, err := tlsService.ApplyMtlsMode(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "STRICT")
+	require.NoError(err)
+
+	require.Len(result.PeerAuthentications, 1)
+	assert.Equal("default", result.PeerAuthentications[0].Name)
+	assert.Equal("STRICT", result.PeerAuthentications[0].Spec.Mtls.Mode.String())
+	// Only the conflicting DestinationRule is changed
+	require.Len(result.DestinationRules, 1)
+	assert.Equal("reviews", result.DestinationRules[0].Name)
+
+	pa, err := client.Istio().SecurityV1beta1().PeerAuthentications("bookinfo").Get(context.TODO(), "default", meta_v1.GetOptions{})
+	require.NoError(err)
+	assert.Equal("STRICT", pa.Spec.Mtls.Mode.String())
+
+	dr, err := client.Istio().NetworkingV1beta1().DestinationRules("bookinfo").Get(context.TODO(), "reviews", meta_v1.GetOptions{})
+	require.NoError(err)
+	assert.Equal("ISTIO_MUTUAL", dr.Spec.TrafficPolicy.Tls.Mode.String())
+	assert.Nil(dr.Spec.Subsets[0].TrafficPolicy)
+	assert.Equal("ISTIO_MUTUAL", dr.Spec.Subsets[1].TrafficPolicy.PortLevelSettings[0].Tls.Mode.String())
+	assert.Equal(uint32(9080), dr.Spec.Subsets[1].TrafficPolicy.PortLevelSettings[0].Port.Number)
+}
+
+func TestApplyMtlsModePatchesNamespacePeerAuthentication(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// The PeerAuthentication with a selector is left as is
+	tlsService, client, conf := setupMtlsModeTLSService(t, false,
+		fakePeerAuthnWithSelector("reviews", "bookinfo", "reviews")[0],
+		fakePermissivePeerAuthn("namespace-wide", "bookinfo")[0],
+	)
+
+	result, err := tlsService.ApplyMtlsMode(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "STRICT")
+	require.NoError(err)
+	require.Len(result.PeerAuthentications, 1)
+	assert.Equal("namespace-wide", result.PeerAuthentications[0].Name)
+	assert.Empty(result.DestinationRules)
+
+	pas, err := client.Istio().SecurityV1beta1().PeerAuthentications("bookinfo").List(context.TODO(), meta_v1.ListOptions{})
+	require.NoError(err)
+	assert.Len(pas.Items, 2)
+	pa, err := client.Istio().SecurityV1beta1().PeerAuthentications("bookinfo").Get(context.TODO(), "namespace-wide", meta_v1.GetOptions{})
+	require.NoError(err)
+	assert.Equal("STRICT", pa.Spec.Mtls.Mode.String())
+}
+
+func TestApplyMtlsModeRollback(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// The namespace has no namespace-wide PeerAuthentication, but the name of the one to create is taken
+	tlsService, client, conf := setupMtlsModeTLSService(t, false,
+		fakePeerAuthnWithSelector("default", "bookinfo", "reviews")[0],
+		fakePlainTextDestinationRule(),
+	)
+
+	_, err := tlsService.ApplyMtlsMode(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "STRICT")
+	require.Error(err)
+	assert.True(api_errors.IsAlreadyExists(err))
+
+	dr, err := client.Istio().NetworkingV1beta1().DestinationRules("bookinfo").Get(context.TODO(), "reviews", meta_v1.GetOptions{})
+	require.NoError(err)
+	assert.Equal("DISABLE", dr.Spec.TrafficPolicy.Tls.Mode.String())
+	assert.Equal("DISABLE", dr.Spec.Subsets[1].TrafficPolicy.PortLevelSettings[0].Tls.Mode.String())
+}
+
+func TestApplyMtlsModeForbidden(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	tlsService, client, conf := setupMtlsModeTLSService(t, true, fakePlainTextDestinationRule())
+
+	_, err := tlsService.ApplyMtlsMode(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "STRICT")
+	require.Error(err)
+	assert.True(api_errors.IsForbidden(err))
+
+	pas, err := client.Istio().SecurityV1beta1().PeerAuthentications("bookinfo").List(context.TODO(), meta_v1.ListOptions{})
+	require.NoError(err)
+	assert.Empty(pas.Items)
+	dr, err := client.Istio().NetworkingV1beta1().DestinationRules("bookinfo").Get(context.TODO(), "reviews", meta_v1.GetOptions{})
+	require.NoError(err)
+	assert.Equal("DISABLE", dr.Spec.TrafficPolicy.Tls.Mode.String())
+
+	_, err = tlsService.ApplyMtlsMode(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "MUTUAL")
+	require.Error(err)
+	assert.True(api_errors.IsBadRequest(err))
+}
+
+func TestApplyMtlsModeSkipsDestinationRulesOfOtherHosts(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	plainTextDR := func(name, host string) *networking_v1beta1.DestinationRule {
+		return data.AddTrafficPolicyToDestinationRule(data.CreateDisabledMTLSTrafficPolicyForDestinationRules(),
+			data.CreateEmptyDestinationRule("bookinfo", name, host))
+	}
+	// The DestinationRules of the namespace for a ServiceEntry host and for the services of another namespace are left as is
+	tlsService, client, conf := setupMtlsModeTLSService(t, false,
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "travels"}},
+		&core_v1.Service{ObjectMeta: meta_v1.ObjectMeta{Name: "cars", Namespace: "travels"}},
+		plainTextDR("ratings", "ratings"),
+		plainTextDR("external", "api.example.com"),
+		plainTextDR("cars", "cars.travels.svc.cluster.local"),
+		plainTextDR("cars-short", "cars.travels"),
+	)
+
+	result, err := tlsService.ApplyMtlsMode(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "STRICT")
+	require.NoError(err)
+	require.Len(result.DestinationRules, 1)
+	assert.Equal("ratings", result.DestinationRules[0].Name)
+
+	for _, name := range []string{"external", "cars", "cars-short"} {
+		dr, err := client.Istio().NetworkingV1beta1().DestinationRules("bookinfo").Get(context.TODO(), name, meta_v1.GetOptions{})
+		require.NoError(err)
+		assert.Equal("DISABLE", dr.Spec.TrafficPolicy.Tls.Mode.String())
+	}
+}
+
+func TestApplyMtlsModeReconcilesDestinationRulesOfOtherNamespaces(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	plainTextDR := func(namespace, name, host string) *networking_v1beta1.DestinationRule {
+		return data.AddTrafficPolicyToDestinationRule(data.CreateDisabledMTLSTrafficPolicyForDestinationRules(),
+			data.CreateEmptyDestinationRule(namespace, name, host))
+	}
+	// The DestinationRules of the root namespace and of a client namespace for the services of the namespace are reconciled,
+	// the ones of the namespaces not accessible to the user and for the services of other namespaces are left as is
+	tlsService, client, conf := setupMtlsModeTLSService(t, false,
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "travels"}},
+		&core_v1.Service{ObjectMeta: meta_v1.ObjectMeta{Name: "cars", Namespace: "travels"}},
+		plainTextDR("istio-system", "reviews", "reviews.bookinfo.svc.cluster.local"),
+		plainTextDR("travels", "ratings", "ratings.bookinfo"),
+		plainTextDR("travels", "cars", "cars"),
+		plainTextDR("hidden", "reviews", "reviews.bookinfo.svc.cluster.local"),
+	)
+
+	result, err := tlsService.ApplyMtlsMode(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "STRICT")
+	require.NoError(err)
+	reconciled := []string{}
+	for _, dr := range result.DestinationRules {
+		reconciled = append(reconciled, dr.Namespace+"/"+dr.Name)
+	}
+	assert.ElementsMatch([]string{"istio-system/reviews", "travels/ratings"}, reconciled)
+
+	for namespace, name := range map[string]string{"istio-system": "reviews", "travels": "ratings"} {
+		dr, err := client.Istio().NetworkingV1beta1().DestinationRules(namespace).Get(context.TODO(), name, meta_v1.GetOptions{})
+		require.NoError(err)
+		assert.Equal("ISTIO_MUTUAL", dr.Spec.TrafficPolicy.Tls.Mode.String())
+	}
+	for namespace, name := range map[string]string{"travels": "cars", "hidden": "reviews"} {
+		dr, err := client.Istio().NetworkingV1beta1().DestinationRules(namespace).Get(context.TODO(), name, meta_v1.GetOptions{})
+		require.NoError(err)
+		assert.Equal("DISABLE", dr.Spec.TrafficPolicy.Tls.Mode.String())
+	}
+}
@@ -2,21 +2,25 @@ package business
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	osproject_v1 "github.com/openshift/api/project/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	api_security_v1beta1 "istio.io/api/security/v1beta1"
 	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
 	core_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/kubernetes/cache"
 	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/tests/data"
 )
 
@@ -422,3 +426,76 @@ func fakeMeshPeerAuthentication(name string, mtls *api_security_v1beta1.PeerAuth
 func cleanTestGlobals() {
 	kialiCache = nil
 }
+
+func TestMeshmTLSSummary(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	config.Set(conf)
+
+	mtlsDR := func(namespace string) *networking_v1beta1.DestinationRule {
+		return data.AddTrafficPolicyToDestinationRule(data.CreateMTLSTrafficPolicyForDestinationRules(),
+			data.CreateEmptyDestinationRule(namespace, "mtls", fmt.Sprintf("*.%s.svc.cluster.local", namespace)))
+	}
+	objects := []runtime.Object{
+		// STRICT with a DestinationRule enabling mTLS
+		fakePeerAuthnWithMtlsMode("default", "bookinfo", "STRICT")[0],
+		mtlsDR("bookinfo"),
+		// DISABLE conflicting with a DestinationRule enabling mTLS
+		fakePeerAuthnWithMtlsMode("default", "foo", "DISABLE")[0],
+		mtlsDR("foo"),
+		// STRICT without DestinationRule and auto mTLS disabled
+		fakePeerAuthnWithMtlsMode("default", "baz", "STRICT")[0],
+	}
+	for _, ns := range []string{"bar", "baz", "bookinfo", "foo", "istio-system"} {
+		objects = append(objects, &core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: ns}})
+	}
+	k8s := kubetest.NewFakeK8sClient(objects...)
+	SetupBusinessLayer(t, k8s, *conf)
+
+	k8sclients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	autoMtls := false
+	tlsService := &TLSService{userClients: k8sclients, kialiCache: kialiCache, businessLayer: NewWithBackends(k8sclients, k8sclients, nil, nil), enabledAutoMtls: &autoMtls}
+
+	summary, err := tlsService.MeshmTLSSummary(context.TODO())
+	require.NoError(err)
+
+	assert.Equal(2, summary.Strict)
+	assert.Equal(2, summary.Permissive)
+	assert.Equal(1, summary.Disable)
+	assert.Equal(2, summary.Conflicting)
+	require.Len(summary.Clusters, 1)
+
+	clusterSummary := summary.Clusters[0]
+	assert.Equal(conf.KubernetesConfig.ClusterName, clusterSummary.Cluster)
+	assert.False(clusterSummary.AutoMTLSEnabled)
+	assert.Equal(2, clusterSummary.Conflicting)
+	require.Len(clusterSummary.Namespaces, 5)
+
+	namespaces := map[string]models.NamespaceMTLSSummary{}
+	for _, ns := range clusterSummary.Namespaces {
+		namespaces[ns.Namespace] = ns
+	}
+	assert.Equal("PERMISSIVE", namespaces["bar"].Mode)
+	assert.Equal(MTLSNotEnabled, namespaces["bar"].Status)
+	assert.Empty(namespaces["bar"].Conflicts)
+
+	assert.Equal("STRICT", namespaces["baz"].Mode)
+	require.Len(namespaces["baz"].Conflicts, 1)
+	assert.Equal(models.Build("peerauthentications.mtls.destinationrulemissing", "spec/mtls"), *namespaces["baz"].Conflicts[0])
+
+	assert.Equal("STRICT", namespaces["bookinfo"].Mode)
+	assert.Equal(MTLSEnabled, namespaces["bookinfo"].Status)
+	assert.Empty(namespaces["bookinfo"].Conflicts)
+
+	assert.Equal("DISABLE", namespaces["foo"].Mode)
+	assert.Equal(MTLSPartiallyEnabled, namespaces["foo"].Status)
+	require.Len(namespaces["foo"].Conflicts, 2)
+	assert.Equal(models.Build("peerauthentications.mtls.disabledestinationrulemissing", "spec/mtls"), *namespaces["foo"].Conflicts[0])
+	assert.Equal(models.Build("destinationrules.mtls.nspolicymissing", "spec/trafficPolicy/tls/mode"), *namespaces["foo"].Conflicts[1])
+
+	assert.Equal("PERMISSIVE", namespaces["istio-system"].Mode)
+	assert.Empty(namespaces["istio-system"].Conflicts)
+}
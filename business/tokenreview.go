@@ -1,6 +1,7 @@
 package business
 
 import (
+	authentication_v1 "k8s.io/api/authentication/v1"
 	"k8s.io/client-go/tools/clientcmd/api"
 
 	"github.com/kiali/kiali/kubernetes"
@@ -29,3 +30,7 @@ func NewTokenReview(k8s kubernetes.ClientInterface) TokenReviewService {
 func (in *TokenReviewService) GetTokenSubject(authInfo *api.AuthInfo) (string, error) {
 	return in.k8s.GetTokenSubject(authInfo)
 }
+
+func (in *TokenReviewService) GetTokenUser(authInfo *api.AuthInfo) (*authentication_v1.UserInfo, error) {
+	return in.k8s.GetTokenUser(authInfo)
+}
@@ -0,0 +1,89 @@
+package business
+
+import (
+	"context"
+
+	extentions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// GetEffectiveWasmPlugins resolves the WasmPlugins applied by the proxies of a workload, in the order they are applied.
+// Unlike the Telemetries, the WasmPlugins of every scope apply: the ones of the root namespace without selector to the
+// whole mesh, the ones of the namespace without selector to the namespace, and the ones selecting the workload.
+// They are sorted by phase, then by descending priority. The WasmPlugins sharing the same phase and priority are flagged,
+// as their order only depends on their names.
+// It uses following parameters:
+// - "cluster":			cluster of the workload
+// - "namespace": 		namespace of the workload
+// - "workloadSelector":	labels of the workload, like "app=reviews,version=v1"
+func (in *IstioConfigService) GetEffectiveWasmPlugins(ctx context.Context, cluster, namespace, workloadSelector string) ([]models.EffectiveWasmPlugin, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetEffectiveWasmPlugins",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("workloadSelector", workloadSelector),
+	)
+	defer end()
+
+	fetchWasmPlugins := func(ns string) ([]*extentions_v1alpha1.WasmPlugin, error) {
+		criteria := IstioConfigCriteria{
+			Namespace:          ns,
+			Cluster:            cluster,
+			IncludeWasmPlugins: true,
+		}
+		istioConfigList, err := in.GetIstioConfigList(ctx, criteria)
+		if err != nil {
+			return nil, err
+		}
+		return istioConfigList.WasmPlugins, nil
+	}
+
+	wasmPlugins, err := fetchWasmPlugins(namespace)
+	if err != nil {
+		return nil, err
+	}
+	rootNamespace := in.config.ExternalServices.Istio.RootNamespace
+	if rootNamespace != "" && rootNamespace != namespace {
+		rootWasmPlugins, err := fetchWasmPlugins(rootNamespace)
+		if err != nil {
+			return nil, err
+		}
+		wasmPlugins = append(wasmPlugins, rootWasmPlugins...)
+	}
+
+	return toEffectiveWasmPlugins(kubernetes.FilterWasmPluginsByWorkload(wasmPlugins, rootNamespace, namespace, workloadSelector), rootNamespace, namespace), nil
+}
+
+// toEffectiveWasmPlugins converts the sorted WasmPlugins applying to a workload, flagging the ones sharing their phase and priority
+func toEffectiveWasmPlugins(wasmPlugins []*extentions_v1alpha1.WasmPlugin, rootNamespace, namespace string) []models.EffectiveWasmPlugin {
+	effective := make([]models.EffectiveWasmPlugin, 0, len(wasmPlugins))
+	for _, wp := range wasmPlugins {
+		scope := models.WasmPluginScopeWorkload
+		if wp.Spec.Selector == nil || len(wp.Spec.Selector.MatchLabels) == 0 {
+			scope = models.WasmPluginScopeNamespace
+			if wp.Namespace == rootNamespace && rootNamespace != namespace {
+				scope = models.WasmPluginScopeMesh
+			}
+		}
+		effective = append(effective, models.EffectiveWasmPlugin{
+			Name:      wp.Name,
+			Namespace: wp.Namespace,
+			Scope:     scope,
+			Phase:     wp.Spec.Phase.String(),
+			Priority:  kubernetes.WasmPluginPriority(wp),
+			Url:       wp.Spec.Url,
+		})
+	}
+	// The WasmPlugins are sorted, so the collisions are adjacent
+	for i := 1; i < len(effective); i++ {
+		if effective[i].Phase == effective[i-1].Phase && effective[i].Priority == effective[i-1].Priority {
+			effective[i].Collision = true
+			effective[i-1].Collision = true
+		}
+	}
+	return effective
+}
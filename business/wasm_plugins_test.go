@@ -0,0 +1,80 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_extensions_v1alpha1 "istio.io/api/extensions/v1alpha1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestGetEffectiveWasmPlugins(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		// Mesh level
+		data.CreateWasmPlugin("mesh-stats", "istio-system", nil, api_extensions_v1alpha1.PluginPhase_STATS, 0),
+		// Only applies to the workloads of the root namespace
+		data.CreateWasmPlugin("istiod-authn", "istio-system", map[string]string{"app": "reviews"}, api_extensions_v1alpha1.PluginPhase_AUTHN, 0),
+		// Namespace level
+		data.CreateWasmPlugin("bookinfo-authz", "bookinfo", nil, api_extensions_v1alpha1.PluginPhase_AUTHZ, 10),
+		// Workload level
+		data.CreateWasmPlugin("reviews-authn", "bookinfo", map[string]string{"app": "reviews"}, api_extensions_v1alpha1.PluginPhase_AUTHN, 0),
+		data.CreateWasmPlugin("reviews-authz", "bookinfo", map[string]string{"app": "reviews"}, api_extensions_v1alpha1.PluginPhase_AUTHZ, 20),
+		data.CreateWasmPlugin("ratings-authz", "bookinfo", map[string]string{"app": "ratings"}, api_extensions_v1alpha1.PluginPhase_AUTHZ, 10),
+	)
+	SetupBusinessLayer(t, k8s, *conf)
+	t.Cleanup(func() { kialiCache = nil })
+
+	k8sclients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	configService := NewWithBackends(k8sclients, k8sclients, nil, nil).IstioConfig
+
+	// Sorted by phase, then by descending priority
+	wasmPlugins, err := configService.GetEffectiveWasmPlugins(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "app=reviews,version=v1")
+	require.NoError(err)
+	require.Len(wasmPlugins, 4)
+	assert.Equal(models.EffectiveWasmPlugin{
+		Name:      "reviews-authn",
+		Namespace: "bookinfo",
+		Scope:     models.WasmPluginScopeWorkload,
+		Phase:     "AUTHN",
+		Priority:  0,
+		Url:       "oci://ghcr.io/istio-ecosystem/wasm-extensions/reviews-authn:1.0.0",
+	}, wasmPlugins[0])
+	assert.Equal("reviews-authz", wasmPlugins[1].Name)
+	assert.Equal(int64(20), wasmPlugins[1].Priority)
+	assert.Equal("bookinfo-authz", wasmPlugins[2].Name)
+	assert.Equal(models.WasmPluginScopeNamespace, wasmPlugins[2].Scope)
+	assert.Equal("mesh-stats", wasmPlugins[3].Name)
+	assert.Equal(models.WasmPluginScopeMesh, wasmPlugins[3].Scope)
+	for _, wp := range wasmPlugins {
+		assert.False(wp.Collision)
+	}
+
+	// ratings-authz collides with the namespace level WasmPlugin
+	wasmPlugins, err = configService.GetEffectiveWasmPlugins(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "app=ratings,version=v1")
+	require.NoError(err)
+	require.Len(wasmPlugins, 3)
+	assert.Equal("bookinfo-authz", wasmPlugins[0].Name)
+	assert.True(wasmPlugins[0].Collision)
+	assert.Equal("ratings-authz", wasmPlugins[1].Name)
+	assert.True(wasmPlugins[1].Collision)
+	assert.Equal("mesh-stats", wasmPlugins[2].Name)
+	assert.False(wasmPlugins[2].Collision)
+}
@@ -0,0 +1,114 @@
+package business
+
+import (
+	"context"
+
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+const (
+	accessLoggingSourceTelemetry = "telemetry"
+	accessLoggingSourceMesh      = "mesh"
+	accessLoggingSourceNone      = "none"
+
+	// envoyAccessLogProvider is the built-in provider writing the access logs to the mesh accessLogFile
+	envoyAccessLogProvider = "envoy"
+)
+
+// GetAccessLogging resolves whether the proxies of a workload write access logs and with which providers.
+// The access logging section of the effective Telemetry of the workload wins, a Telemetry entry without providers uses
+// the mesh default providers. Without Telemetry the mesh config applies: its default providers, or the built-in
+// "envoy" provider when the accessLogFile is set.
+// Istio doesn't offer a Pod annotation to configure the access logs, so the Pods annotations are not involved.
+func (in *WorkloadService) GetAccessLogging(ctx context.Context, cluster, namespace, workload string) (*models.AccessLogging, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetAccessLogging",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("workload", workload),
+	)
+	defer end()
+
+	wk, err := in.fetchWorkload(ctx, WorkloadCriteria{Cluster: cluster, Namespace: namespace, WorkloadName: workload, WorkloadType: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	meshConfig, err := in.getMeshConfig(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	telemetry, err := in.businessLayer.IstioConfig.GetEffectiveTelemetry(ctx, cluster, namespace, labels.Set(wk.Labels).String())
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveAccessLogging(meshConfig, telemetry), nil
+}
+
+// getMeshConfig returns the mesh config of the cluster, empty when the Istio ConfigMap doesn't exist
+func (in *WorkloadService) getMeshConfig(cluster string) (*kubernetes.IstioMeshConfig, error) {
+	kubeCache, err := in.cache.GetKubeCache(cluster)
+	if err != nil {
+		return nil, err
+	}
+	istioConfig, err := kubeCache.GetConfigMap(in.config.IstioNamespace, in.config.ExternalServices.Istio.ConfigMapName)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return &kubernetes.IstioMeshConfig{}, nil
+		}
+		return nil, err
+	}
+	return kubernetes.GetIstioConfigMap(istioConfig)
+}
+
+func resolveAccessLogging(meshConfig *kubernetes.IstioMeshConfig, telemetry models.EffectiveTelemetry) *models.AccessLogging {
+	defaultProviders := []string{}
+	if meshConfig.DefaultProviders != nil {
+		defaultProviders = meshConfig.DefaultProviders.AccessLogging
+	}
+
+	accessLogging := &models.AccessLogging{Providers: []string{}}
+	if source, ok := telemetry.Sources["accessLogging"]; ok {
+		accessLogging.Source = accessLoggingSourceTelemetry
+		accessLogging.Telemetry = source
+		seen := map[string]bool{}
+		for _, entry := range telemetry.Spec.AccessLogging {
+			if entry.Disabled != nil && entry.Disabled.Value {
+				continue
+			}
+			providers := defaultProviders
+			if len(entry.Providers) > 0 {
+				providers = []string{}
+				for _, provider := range entry.Providers {
+					providers = append(providers, provider.Name)
+				}
+			}
+			for _, provider := range providers {
+				if !seen[provider] {
+					seen[provider] = true
+					accessLogging.Providers = append(accessLogging.Providers, provider)
+				}
+			}
+		}
+	} else {
+		accessLogging.Source = accessLoggingSourceMesh
+		if len(defaultProviders) > 0 {
+			accessLogging.Providers = append(accessLogging.Providers, defaultProviders...)
+		} else if meshConfig.AccessLogFile != "" {
+			accessLogging.Providers = append(accessLogging.Providers, envoyAccessLogProvider)
+		} else {
+			accessLogging.Source = accessLoggingSourceNone
+		}
+	}
+
+	accessLogging.Enabled = len(accessLogging.Providers) > 0
+	return accessLogging
+}
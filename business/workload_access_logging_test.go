@@ -0,0 +1,112 @@
+package business
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_telemetry_v1alpha1 "istio.io/api/telemetry/v1alpha1"
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+func fakeAccessLoggingDeployment(name string, labels map[string]string) *apps_v1.Deployment {
+	return &apps_v1.Deployment{
+		TypeMeta:   meta_v1.TypeMeta{Kind: "Deployment"},
+		ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: "bookinfo"},
+		Spec: apps_v1.DeploymentSpec{
+			Template: core_v1.PodTemplateSpec{ObjectMeta: meta_v1.ObjectMeta{Labels: labels}},
+		},
+	}
+}
+
+func TestGetAccessLogging(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		&core_v1.ConfigMap{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "istio", Namespace: "istio-system"},
+			Data:       map[string]string{"mesh": "defaultProviders:\n  metrics:\n  - prometheus\n"},
+		},
+		fakeAccessLoggingDeployment("reviews-v1", map[string]string{"app": "reviews", "version": "v1"}),
+		fakeAccessLoggingDeployment("details-v1", map[string]string{"app": "details", "version": "v1"}),
+		fakeTelemetry("reviews-logs", "bookinfo", time.Hour, map[string]string{"app": "reviews"}, &api_telemetry_v1alpha1.Telemetry{
+			AccessLogging: []*api_telemetry_v1alpha1.AccessLogging{{Providers: telemetryProviders("otel")}},
+		}),
+	)
+	SetupBusinessLayer(t, k8s, *conf)
+	svc := setupWorkloadService(k8s, conf)
+
+	accessLogging, err := svc.GetAccessLogging(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "reviews-v1")
+	require.NoError(err)
+	assert.Equal(&models.AccessLogging{Enabled: true, Providers: []string{"otel"}, Source: "telemetry", Telemetry: "bookinfo/reviews-logs"}, accessLogging)
+
+	accessLogging, err = svc.GetAccessLogging(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "details-v1")
+	require.NoError(err)
+	assert.Equal(&models.AccessLogging{Enabled: false, Providers: []string{}, Source: "none"}, accessLogging)
+}
+
+func TestResolveAccessLogging(t *testing.T) {
+	telemetry := func(accessLogging ...*api_telemetry_v1alpha1.AccessLogging) models.EffectiveTelemetry {
+		return models.EffectiveTelemetry{
+			Spec:    &api_telemetry_v1alpha1.Telemetry{AccessLogging: accessLogging},
+			Sources: map[string]string{"accessLogging": "istio-system/mesh-default"},
+		}
+	}
+	noTelemetry := models.EffectiveTelemetry{Spec: &api_telemetry_v1alpha1.Telemetry{}, Sources: map[string]string{}}
+	defaultProviders := &kubernetes.IstioMeshDefaultProviders{AccessLogging: []string{"otel"}}
+
+	cases := map[string]struct {
+		meshConfig kubernetes.IstioMeshConfig
+		telemetry  models.EffectiveTelemetry
+		enabled    bool
+		providers  []string
+		source     string
+	}{
+		"Mesh access log file": {
+			meshConfig: kubernetes.IstioMeshConfig{AccessLogFile: "/dev/stdout"},
+			telemetry:  noTelemetry,
+			enabled:    true, providers: []string{"envoy"}, source: "mesh",
+		},
+		"Mesh default providers": {
+			meshConfig: kubernetes.IstioMeshConfig{AccessLogFile: "/dev/stdout", DefaultProviders: defaultProviders},
+			telemetry:  noTelemetry,
+			enabled:    true, providers: []string{"otel"}, source: "mesh",
+		},
+		"Telemetry without providers uses the mesh default providers": {
+			meshConfig: kubernetes.IstioMeshConfig{DefaultProviders: defaultProviders},
+			telemetry:  telemetry(&api_telemetry_v1alpha1.AccessLogging{}),
+			enabled:    true, providers: []string{"otel"}, source: "telemetry",
+		},
+		"Telemetry disables the mesh access log file": {
+			meshConfig: kubernetes.IstioMeshConfig{AccessLogFile: "/dev/stdout"},
+			telemetry:  telemetry(&api_telemetry_v1alpha1.AccessLogging{Providers: telemetryProviders("envoy"), Disabled: &wrappers.BoolValue{Value: true}}),
+			enabled:    false, providers: []string{}, source: "telemetry",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			meshConfig := tc.meshConfig
+			accessLogging := resolveAccessLogging(&meshConfig, tc.telemetry)
+			assert.Equal(t, tc.enabled, accessLogging.Enabled)
+			assert.Equal(t, tc.providers, accessLogging.Providers)
+			assert.Equal(t, tc.source, accessLogging.Source)
+		})
+	}
+}
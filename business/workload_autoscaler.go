@@ -0,0 +1,200 @@
+package business
+
+import (
+	"context"
+	"fmt"
+
+	autoscaling_v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// rescaleEventReason is the reason of the events reported by a HorizontalPodAutoscaler when it changes the replicas
+const rescaleEventReason = "SuccessfulRescale"
+
+// GetWorkloadAutoscaler returns the status of the HorizontalPodAutoscaler targeting a workload: its current and desired
+// replicas, its metrics with their targets and its last scaling event. nil is returned when no HorizontalPodAutoscaler
+// targets the workload.
+func (in *WorkloadService) GetWorkloadAutoscaler(ctx context.Context, cluster, namespace, workload string) (*models.WorkloadAutoscaler, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetWorkloadAutoscaler",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("workload", workload),
+	)
+	defer end()
+
+	wk, err := in.fetchWorkload(ctx, WorkloadCriteria{Cluster: cluster, Namespace: namespace, WorkloadName: workload, WorkloadType: ""})
+	if err != nil {
+		return nil, err
+	}
+	return in.getAutoscaler(cluster, namespace, wk)
+}
+
+// setAutoscaler sets the status of the HorizontalPodAutoscaler targeting the workload. The autoscaler is optional
+// in the workload details, when it can't be read (Kiali may not be granted to) the workload is returned without it.
+func (in *WorkloadService) setAutoscaler(criteria WorkloadCriteria, workload *models.Workload) {
+	autoscaler, err := in.getAutoscaler(criteria.Cluster, criteria.Namespace, workload)
+	if err != nil {
+		if errors.IsForbidden(err) {
+			log.Debugf("Cannot read the HorizontalPodAutoscalers of namespace [%s]: %v", criteria.Namespace, err)
+		} else {
+			log.Warningf("Cannot get the HorizontalPodAutoscaler of workload [%s] in namespace [%s]: %v", workload.Name, criteria.Namespace, err)
+		}
+		return
+	}
+	workload.Autoscaler = autoscaler
+}
+
+func (in *WorkloadService) getAutoscaler(cluster, namespace string, workload *models.Workload) (*models.WorkloadAutoscaler, error) {
+	client, ok := in.userClients[cluster]
+	if !ok {
+		return nil, fmt.Errorf("Cluster [%s] is not found or is not accessible for Kiali", cluster)
+	}
+
+	hpas, err := client.GetHorizontalPodAutoscalers(namespace)
+	if err != nil {
+		return nil, err
+	}
+	var hpa *autoscaling_v2.HorizontalPodAutoscaler
+	for i := range hpas {
+		target := hpas[i].Spec.ScaleTargetRef
+		if target.Kind == workload.Type && target.Name == workload.Name {
+			hpa = &hpas[i]
+			break
+		}
+	}
+	if hpa == nil {
+		return nil, nil
+	}
+
+	autoscaler := &models.WorkloadAutoscaler{
+		Name:            hpa.Name,
+		MinReplicas:     1,
+		MaxReplicas:     hpa.Spec.MaxReplicas,
+		CurrentReplicas: hpa.Status.CurrentReplicas,
+		DesiredReplicas: hpa.Status.DesiredReplicas,
+		Metrics:         make([]models.AutoscalerMetric, 0, len(hpa.Spec.Metrics)),
+	}
+	if hpa.Spec.MinReplicas != nil {
+		autoscaler.MinReplicas = *hpa.Spec.MinReplicas
+	}
+	if hpa.Status.LastScaleTime != nil {
+		lastScaleTime := hpa.Status.LastScaleTime.Time
+		autoscaler.LastScaleTime = &lastScaleTime
+	}
+	for _, metric := range hpa.Spec.Metrics {
+		autoscaler.Metrics = append(autoscaler.Metrics, autoscalerMetric(metric, hpa.Status.CurrentMetrics))
+	}
+
+	// The field selector is not honored by every client, so the events are filtered again
+	events, err := client.GetEvents(namespace, "involvedObject.kind=HorizontalPodAutoscaler,involvedObject.name="+hpa.Name)
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		if event.InvolvedObject.Kind != "HorizontalPodAutoscaler" || event.InvolvedObject.Name != hpa.Name || event.Reason != rescaleEventReason {
+			continue
+		}
+		lastSeen := eventLastSeen(event)
+		if autoscaler.LastScaleEvent == nil || lastSeen.After(autoscaler.LastScaleEvent.LastSeen) {
+			autoscaler.LastScaleEvent = &models.AutoscalerEvent{Reason: event.Reason, Message: event.Message, LastSeen: lastSeen}
+		}
+	}
+
+	return autoscaler, nil
+}
+
+// autoscalerMetric converts a metric of a HorizontalPodAutoscaler, with its current value taken from the metrics of the status
+func autoscalerMetric(spec autoscaling_v2.MetricSpec, current []autoscaling_v2.MetricStatus) models.AutoscalerMetric {
+	metric := models.AutoscalerMetric{Type: string(spec.Type)}
+	var target autoscaling_v2.MetricTarget
+	var matches func(status autoscaling_v2.MetricStatus) *autoscaling_v2.MetricValueStatus
+
+	switch {
+	case spec.Type == autoscaling_v2.ResourceMetricSourceType && spec.Resource != nil:
+		metric.Name = spec.Resource.Name.String()
+		target = spec.Resource.Target
+		matches = func(status autoscaling_v2.MetricStatus) *autoscaling_v2.MetricValueStatus {
+			if status.Resource != nil && status.Resource.Name == spec.Resource.Name {
+				return &status.Resource.Current
+			}
+			return nil
+		}
+	case spec.Type == autoscaling_v2.ContainerResourceMetricSourceType && spec.ContainerResource != nil:
+		metric.Name = spec.ContainerResource.Container + "/" + spec.ContainerResource.Name.String()
+		target = spec.ContainerResource.Target
+		matches = func(status autoscaling_v2.MetricStatus) *autoscaling_v2.MetricValueStatus {
+			if status.ContainerResource != nil && status.ContainerResource.Name == spec.ContainerResource.Name &&
+				status.ContainerResource.Container == spec.ContainerResource.Container {
+				return &status.ContainerResource.Current
+			}
+			return nil
+		}
+	case spec.Type == autoscaling_v2.PodsMetricSourceType && spec.Pods != nil:
+		metric.Name = spec.Pods.Metric.Name
+		target = spec.Pods.Target
+		matches = func(status autoscaling_v2.MetricStatus) *autoscaling_v2.MetricValueStatus {
+			if status.Pods != nil && status.Pods.Metric.Name == spec.Pods.Metric.Name {
+				return &status.Pods.Current
+			}
+			return nil
+		}
+	case spec.Type == autoscaling_v2.ObjectMetricSourceType && spec.Object != nil:
+		metric.Name = spec.Object.Metric.Name
+		target = spec.Object.Target
+		matches = func(status autoscaling_v2.MetricStatus) *autoscaling_v2.MetricValueStatus {
+			if status.Object != nil && status.Object.Metric.Name == spec.Object.Metric.Name {
+				return &status.Object.Current
+			}
+			return nil
+		}
+	case spec.Type == autoscaling_v2.ExternalMetricSourceType && spec.External != nil:
+		metric.Name = spec.External.Metric.Name
+		target = spec.External.Target
+		matches = func(status autoscaling_v2.MetricStatus) *autoscaling_v2.MetricValueStatus {
+			if status.External != nil && status.External.Metric.Name == spec.External.Metric.Name {
+				return &status.External.Current
+			}
+			return nil
+		}
+	default:
+		return metric
+	}
+
+	metric.Target = formatMetricValue(target.Type, target.AverageUtilization, target.AverageValue, target.Value)
+	for _, status := range current {
+		if status.Type != spec.Type {
+			continue
+		}
+		if value := matches(status); value != nil {
+			metric.Current = formatMetricValue(target.Type, value.AverageUtilization, value.AverageValue, value.Value)
+			break
+		}
+	}
+	return metric
+}
+
+// formatMetricValue formats the value of a metric of a HorizontalPodAutoscaler of the given target type, like "80%" for a utilization
+func formatMetricValue(targetType autoscaling_v2.MetricTargetType, utilization *int32, averageValue, value *resource.Quantity) string {
+	switch targetType {
+	case autoscaling_v2.UtilizationMetricType:
+		if utilization != nil {
+			return fmt.Sprintf("%d%%", *utilization)
+		}
+	case autoscaling_v2.AverageValueMetricType:
+		if averageValue != nil {
+			return averageValue.String()
+		}
+	case autoscaling_v2.ValueMetricType:
+		if value != nil {
+			return value.String()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,192 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apps_v1 "k8s.io/api/apps/v1"
+	autoscaling_v2 "k8s.io/api/autoscaling/v2"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+func fakeAutoscaledDeployment(name string) *apps_v1.Deployment {
+	return &apps_v1.Deployment{
+		ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: "bookinfo", Labels: map[string]string{"app": "reviews"}},
+		Spec: apps_v1.DeploymentSpec{
+			Selector: &meta_v1.LabelSelector{MatchLabels: map[string]string{"app": "reviews"}},
+			Template: core_v1.PodTemplateSpec{ObjectMeta: meta_v1.ObjectMeta{Labels: map[string]string{"app": "reviews"}}},
+		},
+	}
+}
+
+func fakeRescaleEvent(hpa, reason, message string, lastSeen time.Time) *core_v1.Event {
+	return &core_v1.Event{
+		ObjectMeta:     meta_v1.ObjectMeta{Name: hpa + "." + reason + lastSeen.Format("150405"), Namespace: "bookinfo"},
+		InvolvedObject: core_v1.ObjectReference{Kind: "HorizontalPodAutoscaler", Name: hpa, Namespace: "bookinfo"},
+		Reason:         reason,
+		Message:        message,
+		LastTimestamp:  meta_v1.NewTime(lastSeen),
+	}
+}
+
+func setupAutoscalerWorkloadService(t *testing.T, objects ...runtime.Object) (WorkloadService, string) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	objects = append(objects, &core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}})
+	k8s := kubetest.NewFakeK8sClient(objects...)
+	SetupBusinessLayer(t, k8s, *conf)
+	t.Cleanup(func() { kialiCache = nil })
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	return NewWithBackends(clients, clients, nil, nil).Workload, conf.KubernetesConfig.ClusterName
+}
+
+func TestGetWorkloadAutoscaler(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	lastScaleTime := time.Date(2023, 5, 10, 12, 30, 0, 0, time.UTC)
+	minReplicas := int32(2)
+	utilization := int32(80)
+	currentUtilization := int32(95)
+	hpa := &autoscaling_v2.HorizontalPodAutoscaler{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "reviews-v1", Namespace: "bookinfo"},
+		Spec: autoscaling_v2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscaling_v2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "reviews-v1"},
+			MinReplicas:    &minReplicas,
+			MaxReplicas:    10,
+			Metrics: []autoscaling_v2.MetricSpec{
+				{
+					Type: autoscaling_v2.ResourceMetricSourceType,
+					Resource: &autoscaling_v2.ResourceMetricSource{
+						Name:   core_v1.ResourceCPU,
+						Target: autoscaling_v2.MetricTarget{Type: autoscaling_v2.UtilizationMetricType, AverageUtilization: &utilization},
+					},
+				},
+				{
+					Type: autoscaling_v2.PodsMetricSourceType,
+					Pods: &autoscaling_v2.PodsMetricSource{
+						Metric: autoscaling_v2.MetricIdentifier{Name: "istio_requests_per_second"},
+						Target: autoscaling_v2.MetricTarget{Type: autoscaling_v2.AverageValueMetricType, AverageValue: resource.NewQuantity(10, resource.DecimalSI)},
+					},
+				},
+			},
+		},
+		Status: autoscaling_v2.HorizontalPodAutoscalerStatus{
+			CurrentReplicas: 3,
+			DesiredReplicas: 4,
+			LastScaleTime:   &meta_v1.Time{Time: lastScaleTime},
+			CurrentMetrics: []autoscaling_v2.MetricStatus{
+				{
+					Type: autoscaling_v2.ResourceMetricSourceType,
+					Resource: &autoscaling_v2.ResourceMetricStatus{
+						Name:    core_v1.ResourceCPU,
+						Current: autoscaling_v2.MetricValueStatus{AverageUtilization: &currentUtilization, AverageValue: resource.NewMilliQuantity(95, resource.DecimalSI)},
+					},
+				},
+			},
+		},
+	}
+	// Same name, another kind of workload
+	statefulSetHPA := &autoscaling_v2.HorizontalPodAutoscaler{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "reviews-v2", Namespace: "bookinfo"},
+		Spec: autoscaling_v2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscaling_v2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "reviews-v2"},
+			MaxReplicas:    5,
+		},
+	}
+
+	svc, cluster := setupAutoscalerWorkloadService(t,
+		fakeAutoscaledDeployment("reviews-v1"),
+		fakeAutoscaledDeployment("reviews-v2"),
+		hpa,
+		statefulSetHPA,
+		fakeRescaleEvent("reviews-v1", "SuccessfulRescale", "New size: 3; reason: cpu resource utilization (percentage of request) above target", lastScaleTime.Add(-time.Hour)),
+		fakeRescaleEvent("reviews-v1", "SuccessfulRescale", "New size: 4; reason: cpu resource utilization (percentage of request) above target", lastScaleTime),
+		fakeRescaleEvent("reviews-v1", "FailedGetPodsMetric", "unable to get metric istio_requests_per_second", lastScaleTime.Add(time.Minute)),
+		fakeRescaleEvent("reviews-v2", "SuccessfulRescale", "New size: 2; reason: All metrics below target", lastScaleTime.Add(time.Hour)),
+	)
+
+	autoscaler, err := svc.GetWorkloadAutoscaler(context.TODO(), cluster, "bookinfo", "reviews-v1")
+	require.NoError(err)
+	assert.Equal(&models.WorkloadAutoscaler{
+		Name:            "reviews-v1",
+		MinReplicas:     2,
+		MaxReplicas:     10,
+		CurrentReplicas: 3,
+		DesiredReplicas: 4,
+		Metrics: []models.AutoscalerMetric{
+			{Type: "Resource", Name: "cpu", Target: "80%", Current: "95%"},
+			{Type: "Pods", Name: "istio_requests_per_second", Target: "10"},
+		},
+		LastScaleTime: &lastScaleTime,
+		LastScaleEvent: &models.AutoscalerEvent{
+			Reason:   "SuccessfulRescale",
+			Message:  "New size: 4; reason: cpu resource utilization (percentage of request) above target",
+			LastSeen: lastScaleTime,
+		},
+	}, autoscaler)
+
+	// Attached to the workload details
+	workload, err := svc.GetWorkload(context.TODO(), WorkloadCriteria{Cluster: cluster, Namespace: "bookinfo", WorkloadName: "reviews-v1", IncludeAutoscaler: true})
+	require.NoError(err)
+	assert.Equal(autoscaler, workload.Autoscaler)
+}
+
+func TestGetWorkloadAutoscalerWithoutHPA(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	svc, cluster := setupAutoscalerWorkloadService(t, fakeAutoscaledDeployment("reviews-v1"))
+
+	autoscaler, err := svc.GetWorkloadAutoscaler(context.TODO(), cluster, "bookinfo", "reviews-v1")
+	require.NoError(err)
+	assert.Nil(autoscaler)
+
+	workload, err := svc.GetWorkload(context.TODO(), WorkloadCriteria{Cluster: cluster, Namespace: "bookinfo", WorkloadName: "reviews-v1", IncludeAutoscaler: true})
+	require.NoError(err)
+	assert.Nil(workload.Autoscaler)
+}
+
+// failingAutoscalersClient fails to list the HorizontalPodAutoscalers, as an API server that is unavailable
+type failingAutoscalersClient struct {
+	kubernetes.ClientInterface
+}
+
+func (c *failingAutoscalersClient) GetHorizontalPodAutoscalers(namespace string) ([]autoscaling_v2.HorizontalPodAutoscaler, error) {
+	return nil, fmt.Errorf("the server is currently unable to handle the request")
+}
+
+func TestGetWorkloadAutoscalerError(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}}, fakeAutoscaledDeployment("reviews-v1"))
+	SetupBusinessLayer(t, k8s, *conf)
+	t.Cleanup(func() { kialiCache = nil })
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: &failingAutoscalersClient{ClientInterface: k8s}}
+	svc := NewWithBackends(clients, clients, nil, nil).Workload
+
+	// The autoscaler is optional in the workload details
+	workload, err := svc.GetWorkload(context.TODO(), WorkloadCriteria{Cluster: conf.KubernetesConfig.ClusterName, Namespace: "bookinfo", WorkloadName: "reviews-v1", IncludeAutoscaler: true})
+	require.NoError(err)
+	assert.Equal("reviews-v1", workload.Name)
+	assert.Nil(workload.Autoscaler)
+}
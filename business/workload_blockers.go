@@ -0,0 +1,154 @@
+package business
+
+import (
+	"context"
+	"sort"
+
+	api_security_v1beta1 "istio.io/api/security/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// GetWorkloadBlockers finds the Istio objects that would break the traffic of a workload before it is deployed:
+// - the Sidecar applied to the workload, when some of the hosts it intends to reach are out of its egress scope.
+// - the DENY AuthorizationPolicies applied to the workload matching any request, regardless of its attributes.
+// It uses following parameters:
+// - "cluster":			cluster where the workload would be deployed
+// - "namespace": 		namespace where the workload would be deployed
+// - "workload":		labels and egress hosts of the workload
+func (in *IstioConfigService) GetWorkloadBlockers(ctx context.Context, cluster, namespace string, workload models.ProposedWorkload) ([]models.WorkloadBlocker, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetWorkloadBlockers",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+	)
+	defer end()
+
+	namespaces := []string{namespace}
+	// Sidecars and policies of the root namespace apply to the workloads of every namespace
+	rootNamespace := in.config.ExternalServices.Istio.RootNamespace
+	if rootNamespace != "" && rootNamespace != namespace {
+		namespaces = append(namespaces, rootNamespace)
+	}
+
+	istioConfigLists := make(map[string]models.IstioConfigList, len(namespaces))
+	for _, ns := range namespaces {
+		criteria := IstioConfigCriteria{
+			Namespace:                    ns,
+			Cluster:                      cluster,
+			IncludeAuthorizationPolicies: true,
+			IncludeServiceEntries:        true,
+			IncludeSidecars:              true,
+		}
+		istioConfigList, err := in.GetIstioConfigList(ctx, criteria)
+		if err != nil {
+			return nil, err
+		}
+		istioConfigLists[ns] = istioConfigList
+	}
+
+	workloadSelector := labels.Set(workload.Labels).String()
+	blockers := []models.WorkloadBlocker{}
+
+	var rootSidecars []*networking_v1beta1.Sidecar
+	// The ServiceEntries are filtered into a new slice, the lists of the namespaces are left untouched
+	serviceEntries := visibleServiceEntries(namespace, istioConfigLists[namespace].ServiceEntries)
+	if len(namespaces) > 1 {
+		rootSidecars = istioConfigLists[rootNamespace].Sidecars
+		serviceEntries = append(serviceEntries, visibleServiceEntries(namespace, istioConfigLists[rootNamespace].ServiceEntries)...)
+	}
+	if sidecar := effectiveSidecar(workloadSelector, istioConfigLists[namespace].Sidecars, rootSidecars); sidecar != nil {
+		for _, host := range workload.EgressHosts {
+			if !kubernetes.SidecarEgressAllows(sidecar, host, namespace, serviceEntries) {
+				blockers = append(blockers, models.WorkloadBlocker{
+					ObjectType: models.ObjectTypeSingular[kubernetes.Sidecars],
+					Name:       sidecar.Name,
+					Namespace:  sidecar.Namespace,
+					Host:       host,
+					Reason:     "The host is out of the egress scope of the Sidecar applied to the workload",
+				})
+			}
+		}
+	}
+
+	authorizationPolicies := []*security_v1beta1.AuthorizationPolicy{}
+	for _, ns := range namespaces {
+		authorizationPolicies = append(authorizationPolicies, kubernetes.FilterAuthorizationPoliciesBySelector(workloadSelector, istioConfigLists[ns].AuthorizationPolicies)...)
+	}
+	blockers = append(blockers, denyAllBlockers(authorizationPolicies)...)
+
+	return blockers, nil
+}
+
+// effectiveSidecar returns the Sidecar Istio applies to a workload: the one selecting the workload, otherwise the one
+// of the namespace without selector, otherwise the one of the root namespace without selector.
+// When several Sidecars are defined at the same level the oldest one is picked.
+func effectiveSidecar(workloadSelector string, namespaceSidecars, rootSidecars []*networking_v1beta1.Sidecar) *networking_v1beta1.Sidecar {
+	namespaceWide, withSelector := splitSidecarsBySelector(namespaceSidecars)
+	if sidecar := oldestSidecar(kubernetes.FilterSidecarsBySelector(workloadSelector, withSelector)); sidecar != nil {
+		return sidecar
+	}
+	if sidecar := oldestSidecar(namespaceWide); sidecar != nil {
+		return sidecar
+	}
+	// Sidecars with a selector in the root namespace only apply to the workloads of the root namespace
+	rootWide, _ := splitSidecarsBySelector(rootSidecars)
+	return oldestSidecar(rootWide)
+}
+
+// splitSidecarsBySelector separates the namespace wide Sidecars from the ones selecting workloads
+func splitSidecarsBySelector(sidecars []*networking_v1beta1.Sidecar) (namespaceWide []*networking_v1beta1.Sidecar, withSelector []*networking_v1beta1.Sidecar) {
+	for _, sc := range sidecars {
+		if sc.Spec.WorkloadSelector == nil || len(sc.Spec.WorkloadSelector.Labels) == 0 {
+			namespaceWide = append(namespaceWide, sc)
+		} else {
+			withSelector = append(withSelector, sc)
+		}
+	}
+	return namespaceWide, withSelector
+}
+
+// oldestSidecar returns the oldest Sidecar, the name breaks the ties to be deterministic
+func oldestSidecar(sidecars []*networking_v1beta1.Sidecar) *networking_v1beta1.Sidecar {
+	var oldest *networking_v1beta1.Sidecar
+	for _, sc := range sidecars {
+		if oldest == nil || sc.CreationTimestamp.Before(&oldest.CreationTimestamp) ||
+			(sc.CreationTimestamp.Equal(&oldest.CreationTimestamp) && sc.Name < oldest.Name) {
+			oldest = sc
+		}
+	}
+	return oldest
+}
+
+// denyAllBlockers reports the DENY policies with a rule matching a request without any attribute,
+// so they match any request to the workload. The unknown conditions don't match there, they may not match every request.
+func denyAllBlockers(authorizationPolicies []*security_v1beta1.AuthorizationPolicy) []models.WorkloadBlocker {
+	sort.Slice(authorizationPolicies, func(i, j int) bool {
+		if authorizationPolicies[i].Namespace != authorizationPolicies[j].Namespace {
+			return authorizationPolicies[i].Namespace < authorizationPolicies[j].Namespace
+		}
+		return authorizationPolicies[i].Name < authorizationPolicies[j].Name
+	})
+
+	blockers := []models.WorkloadBlocker{}
+	for _, ap := range authorizationPolicies {
+		if ap.Spec.Action != api_security_v1beta1.AuthorizationPolicy_DENY {
+			continue
+		}
+		if matchingAuthorizationRule(ap.Spec.Rules, models.AuthorizationRequest{}, false) >= 0 {
+			blockers = append(blockers, models.WorkloadBlocker{
+				ObjectType: models.ObjectTypeSingular[kubernetes.AuthorizationPolicies],
+				Name:       ap.Name,
+				Namespace:  ap.Namespace,
+				Reason:     "The DENY policy applied to the workload matches any request",
+			})
+		}
+	}
+	return blockers
+}
@@ -0,0 +1,115 @@
+package business
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	api_security_v1beta1 "istio.io/api/security/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+)
+
+func fakeEgressSidecar(name, namespace string, age time.Duration, selector map[string]string, hosts ...string) *networking_v1beta1.Sidecar {
+	sc := &networking_v1beta1.Sidecar{}
+	sc.Name = name
+	sc.Namespace = namespace
+	sc.CreationTimestamp = meta_v1.NewTime(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC).Add(-age))
+	if selector != nil {
+		sc.Spec.WorkloadSelector = &api_networking_v1beta1.WorkloadSelector{Labels: selector}
+	}
+	sc.Spec.Egress = []*api_networking_v1beta1.IstioEgressListener{{Hosts: hosts}}
+	return sc
+}
+
+func TestGetWorkloadBlockers(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	configService := mockEvaluateAuthorization(t,
+		fakeEgressSidecar("bookinfo-default", "bookinfo", time.Hour, nil, "./*", "istio-system/*"),
+		fakeEgressSidecar("bookinfo-newer", "bookinfo", time.Minute, nil, "*/*"),
+		fakeEgressSidecar("reviews", "bookinfo", time.Hour, map[string]string{"app": "reviews"}, "*/*"),
+		fakeEgressSidecar("mesh-default", "istio-system", time.Hour, nil, "*/*"),
+		fakeAuthorizationPolicy("deny-all", "bookinfo", api_security_v1beta1.AuthorizationPolicy_DENY, map[string]string{"app": "productpage"}, &api_security_v1beta1.Rule{}),
+		fakeAuthorizationPolicy("deny-get", "bookinfo", api_security_v1beta1.AuthorizationPolicy_DENY, nil, &api_security_v1beta1.Rule{
+			To: []*api_security_v1beta1.Rule_To{{Operation: &api_security_v1beta1.Operation{Methods: []string{"GET"}}}},
+		}),
+		fakeAuthorizationPolicy("deny-reviews", "bookinfo", api_security_v1beta1.AuthorizationPolicy_DENY, map[string]string{"app": "reviews"}, &api_security_v1beta1.Rule{}),
+		fakeAuthorizationPolicy("allow-nothing", "bookinfo", api_security_v1beta1.AuthorizationPolicy_ALLOW, nil),
+	)
+
+	workload := models.ProposedWorkload{
+		Labels:      map[string]string{"app": "productpage", "version": "v1"},
+		EgressHosts: []string{"details", "ratings.bookinfo.svc.cluster.local", "istiod.istio-system.svc.cluster.local", "httpbin.other.svc.cluster.local", "api.example.com"},
+	}
+	blockers, err := configService.GetWorkloadBlockers(context.TODO(), config.Get().KubernetesConfig.ClusterName, "bookinfo", workload)
+	require.NoError(err)
+	require.Len(blockers, 3)
+
+	// The oldest namespace wide Sidecar restricts the egress to the namespace and to istio-system
+	assert.Equal(models.WorkloadBlocker{ObjectType: "sidecar", Name: "bookinfo-default", Namespace: "bookinfo", Host: "httpbin.other.svc.cluster.local", Reason: blockers[0].Reason}, blockers[0])
+	assert.Equal("api.example.com", blockers[1].Host)
+	assert.Equal("bookinfo-default", blockers[1].Name)
+
+	// Only the DENY policy matching any request blocks the workload
+	assert.Equal("authorizationpolicy", blockers[2].ObjectType)
+	assert.Equal("deny-all", blockers[2].Name)
+	assert.Equal("bookinfo", blockers[2].Namespace)
+	assert.Empty(blockers[2].Host)
+
+	// The Sidecar selecting the workload wins
+	workload.Labels = map[string]string{"app": "reviews"}
+	blockers, err = configService.GetWorkloadBlockers(context.TODO(), config.Get().KubernetesConfig.ClusterName, "bookinfo", workload)
+	require.NoError(err)
+	require.Len(blockers, 1)
+	assert.Equal("deny-reviews", blockers[0].Name)
+}
+
+func TestGetWorkloadBlockersRootNamespaceSidecar(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	se := &networking_v1beta1.ServiceEntry{}
+	se.Name = "example"
+	se.Namespace = "istio-system"
+	se.Spec.Hosts = []string{"*.example.com"}
+	privateSE := &networking_v1beta1.ServiceEntry{}
+	privateSE.Name = "private"
+	privateSE.Namespace = "istio-system"
+	privateSE.Spec.Hosts = []string{"*.private.com"}
+	privateSE.Spec.ExportTo = []string{"."}
+
+	configService := mockEvaluateAuthorization(t,
+		fakeEgressSidecar("mesh-default", "istio-system", time.Hour, nil, "./*", "istio-system/*"),
+		fakeEgressSidecar("istiod", "istio-system", time.Hour, map[string]string{"app": "productpage"}, "*/*"),
+		se,
+		privateSE,
+	)
+
+	workload := models.ProposedWorkload{
+		Labels:      map[string]string{"app": "productpage"},
+		EgressHosts: []string{"details.bookinfo.svc.cluster.local", "api.example.com", "api.other.com", "api.private.com"},
+	}
+	blockers, err := configService.GetWorkloadBlockers(context.TODO(), config.Get().KubernetesConfig.ClusterName, "bookinfo", workload)
+	require.NoError(err)
+
+	// The mesh default Sidecar applies, "." is its own namespace. The ServiceEntry declares the external host in istio-system,
+	// the private one is not exported to the namespace of the workload.
+	require.Len(blockers, 3)
+	assert.Equal("mesh-default", blockers[0].Name)
+	assert.Equal("details.bookinfo.svc.cluster.local", blockers[0].Host)
+	assert.Equal("api.other.com", blockers[1].Host)
+	assert.Equal("api.private.com", blockers[2].Host)
+
+	// Without egress hosts nothing is blocked
+	blockers, err = configService.GetWorkloadBlockers(context.TODO(), config.Get().KubernetesConfig.ClusterName, "bookinfo", models.ProposedWorkload{Labels: workload.Labels})
+	require.NoError(err)
+	assert.Empty(blockers)
+}
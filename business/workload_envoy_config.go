@@ -0,0 +1,79 @@
+package business
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// GetWorkloadEnvoyConfig returns the clusters, listeners and routes of the proxy of a pod of a workload, parsed from
+// its Envoy config dump so the UI doesn't need to know Envoy's structure. Both the sidecars and the gateways are supported.
+// The proxy of the first running pod is preferred as all the pods of a workload get the same config.
+func (in *ProxyStatusService) GetWorkloadEnvoyConfig(ctx context.Context, cluster, namespace, workload string) (*models.WorkloadEnvoyConfig, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetWorkloadEnvoyConfig",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("workload", workload),
+	)
+	defer end()
+
+	kialiSAClient, ok := in.kialiSAClients[cluster]
+	if !ok {
+		return nil, fmt.Errorf("cluster [%s] not found", cluster)
+	}
+
+	wk, err := in.businessLayer.Workload.fetchWorkload(ctx, WorkloadCriteria{Cluster: cluster, Namespace: namespace, WorkloadName: workload, WorkloadType: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	pod := proxiedPod(wk.Pods)
+	if pod == nil {
+		return nil, fmt.Errorf("workload [%s] in namespace [%s] has no pod with an Istio proxy", workload, namespace)
+	}
+
+	dump, err := kialiSAClient.GetConfigDump(namespace, pod.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces, err := in.businessLayer.Namespace.GetNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nss := make([]string, 0, len(namespaces))
+	for _, ns := range namespaces {
+		nss = append(nss, ns.Name)
+	}
+
+	envoyConfig := &models.WorkloadEnvoyConfig{Pod: pod.Name}
+	if err := envoyConfig.Parse(dump, nss); err != nil {
+		return nil, err
+	}
+	return envoyConfig, nil
+}
+
+// proxiedPod returns the first running pod with an Istio proxy, either a sidecar or the proxy of a gateway
+func proxiedPod(pods models.Pods) *models.Pod {
+	var proxied *models.Pod
+	for _, pod := range pods {
+		hasProxy := pod.HasIstioSidecar()
+		for _, container := range pod.Containers {
+			hasProxy = hasProxy || container.IsProxy
+		}
+		if !hasProxy {
+			continue
+		}
+		if pod.Status == "Running" {
+			return pod
+		}
+		if proxied == nil {
+			proxied = pod
+		}
+	}
+	return proxied
+}
@@ -0,0 +1,80 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+)
+
+func setupEnvoyConfigProxyStatusService(t *testing.T, pods ...*core_v1.Pod) (ProxyStatusService, *kubetest.K8SClientMock) {
+	t.Helper()
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	kubeObjs := []runtime.Object{&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}}}
+	for _, pod := range pods {
+		kubeObjs = append(kubeObjs, pod)
+	}
+	kubeObjs = append(kubeObjs, fakeReviewsV1Controllers()...)
+	k8s := kubetest.NewFakeK8sClient(kubeObjs...)
+	SetupBusinessLayer(t, k8s, *conf)
+
+	saClient := new(kubetest.K8SClientMock)
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	return ProxyStatusService{
+		kialiSAClients: map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: saClient},
+		businessLayer:  NewWithBackends(clients, clients, nil, nil),
+	}, saClient
+}
+
+func TestGetWorkloadEnvoyConfig(t *testing.T) {
+	require := require.New(t)
+
+	pending := fakeInjectionPod("reviews-v1-pending", "docker.io/istio/proxyv2:1.18.0", nil, nil)
+	pending.Status.Phase = core_v1.PodPending
+	running := fakeInjectionPod("reviews-v1-running", "docker.io/istio/proxyv2:1.18.0", nil, nil)
+	running.Status.Phase = core_v1.PodRunning
+	noProxy := fakeInjectionPod("reviews-v1-no-proxy", "", nil, nil)
+	noProxy.Status.Phase = core_v1.PodRunning
+	svc, saClient := setupEnvoyConfigProxyStatusService(t, pending, noProxy, running)
+
+	var dump kubernetes.ConfigDump
+	require.NoError(json.Unmarshal([]byte(endpointsConfigDumpJSON), &dump))
+	saClient.On("GetConfigDump", "bookinfo", "reviews-v1-running").Return(&dump, nil)
+
+	envoyConfig, err := svc.GetWorkloadEnvoyConfig(context.TODO(), config.Get().KubernetesConfig.ClusterName, "bookinfo", "reviews-v1")
+	require.NoError(err)
+	saClient.AssertExpectations(t)
+
+	assert.Equal(t, "reviews-v1-running", envoyConfig.Pod)
+	// The dump only has endpoints, they are listed under the clusters of the dump
+	assert.Empty(t, envoyConfig.Clusters)
+	assert.Empty(t, envoyConfig.Listeners)
+}
+
+func TestGetWorkloadEnvoyConfigWithoutProxy(t *testing.T) {
+	noProxy := fakeInjectionPod("reviews-v1-no-proxy", "", nil, nil)
+	svc, saClient := setupEnvoyConfigProxyStatusService(t, noProxy)
+
+	_, err := svc.GetWorkloadEnvoyConfig(context.TODO(), config.Get().KubernetesConfig.ClusterName, "bookinfo", "reviews-v1")
+	require.ErrorContains(t, err, "has no pod with an Istio proxy")
+	saClient.AssertNumberOfCalls(t, "GetConfigDump", 0)
+}
+
+func TestGetWorkloadEnvoyConfigUnknownCluster(t *testing.T) {
+	svc, _ := setupEnvoyConfigProxyStatusService(t)
+
+	_, err := svc.GetWorkloadEnvoyConfig(context.TODO(), "unknown", "bookinfo", "reviews-v1")
+	require.ErrorContains(t, err, "cluster [unknown] not found")
+}
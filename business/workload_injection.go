@@ -0,0 +1,124 @@
+package business
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+	"github.com/kiali/kiali/status"
+)
+
+const (
+	injectionSourceNamespace = "namespace"
+	injectionSourcePod       = "pod"
+	injectionSourceNone      = "none"
+)
+
+// GetInjectionStatus consolidates the sidecar injection information of a workload in a single call:
+// the namespace injection labels, and per Pod the injection label/annotation, whether the sidecar is injected
+// and whether the proxy version matches the control plane version.
+// The expected injection follows the Istio precedence: if either the namespace or the Pod disables the injection the Pod is not injected,
+// otherwise if either of them enables it the Pod is injected.
+func (in *WorkloadService) GetInjectionStatus(ctx context.Context, cluster, namespace, workload string) (*models.InjectionStatus, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetInjectionStatus",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("workload", workload),
+	)
+	defer end()
+
+	ns, err := in.businessLayer.Namespace.GetNamespaceByCluster(ctx, namespace, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	wk, err := in.fetchWorkload(ctx, WorkloadCriteria{Cluster: cluster, Namespace: namespace, WorkloadName: workload, WorkloadType: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	controlPlaneVersion, _ := status.GetStatus(status.MeshVersion)
+	if controlPlaneVersion == "Unknown" {
+		controlPlaneVersion = ""
+	}
+
+	injectionStatus := &models.InjectionStatus{
+		Workload:            wk.Name,
+		Namespace:           namespace,
+		ControlPlaneVersion: controlPlaneVersion,
+		NamespaceInjection:  ns.Labels[in.config.IstioLabels.InjectionLabelName],
+		NamespaceRevision:   ns.Labels[in.config.IstioLabels.InjectionLabelRev],
+		Pods:                []models.PodInjectionStatus{},
+	}
+
+	for _, pod := range wk.Pods {
+		podStatus := models.PodInjectionStatus{
+			Name:         pod.Name,
+			PodInjection: in.podInjection(pod),
+			Injected:     pod.HasIstioSidecar(),
+		}
+		podStatus.InjectionExpected, podStatus.InjectionSource = injectionPolicy(injectionStatus.NamespaceInjection, injectionStatus.NamespaceRevision, podStatus.PodInjection)
+		if len(pod.IstioContainers) > 0 {
+			podStatus.ProxyImage = pod.IstioContainers[0].Image
+			podStatus.ProxyVersion = proxyVersionFromImage(podStatus.ProxyImage)
+		}
+		if podStatus.ProxyVersion != "" && controlPlaneVersion != "" {
+			versionMatch := podStatus.ProxyVersion == controlPlaneVersion
+			podStatus.VersionMatch = &versionMatch
+		}
+		injectionStatus.Pods = append(injectionStatus.Pods, podStatus)
+	}
+
+	return injectionStatus, nil
+}
+
+// podInjection returns the injection defined in the Pod, the label overrides the annotation
+func (in *WorkloadService) podInjection(pod *models.Pod) *bool {
+	injectionKey := in.config.ExternalServices.Istio.IstioInjectionAnnotation
+	for _, values := range []map[string]string{pod.Labels, pod.Annotations} {
+		if value, ok := values[injectionKey]; ok {
+			if inject, err := strconv.ParseBool(value); err == nil {
+				return &inject
+			}
+		}
+	}
+	return nil
+}
+
+// injectionPolicy resolves if Istio injects the sidecar and which configuration decides it
+func injectionPolicy(namespaceInjection, namespaceRevision string, podInjection *bool) (bool, string) {
+	if namespaceInjection == "disabled" {
+		return false, injectionSourceNamespace
+	}
+	if podInjection != nil && !*podInjection {
+		return false, injectionSourcePod
+	}
+	if namespaceInjection == "enabled" || namespaceRevision != "" {
+		return true, injectionSourceNamespace
+	}
+	if podInjection != nil && *podInjection {
+		return true, injectionSourcePod
+	}
+	return false, injectionSourceNone
+}
+
+// proxyVersionFromImage returns the tag of the proxy image, like 1.18.0 for docker.io/istio/proxyv2:1.18.0-distroless
+func proxyVersionFromImage(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	i := strings.LastIndex(image, ":")
+	// A colon before the last slash is the port of the registry
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return ""
+	}
+	tag := image[i+1:]
+	for _, variant := range []string{"-distroless", "-debug"} {
+		tag = strings.TrimSuffix(tag, variant)
+	}
+	return tag
+}
@@ -0,0 +1,181 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/status"
+)
+
+func fakeInjectionPod(name, proxyImage string, labels, annotations map[string]string) *core_v1.Pod {
+	controller := true
+	pod := &core_v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:        name,
+			Namespace:   "bookinfo",
+			Labels:      map[string]string{"app": "reviews", "version": "v1"},
+			Annotations: map[string]string{},
+			OwnerReferences: []meta_v1.OwnerReference{{
+				Controller: &controller,
+				Kind:       "ReplicaSet",
+				Name:       "reviews-v1-5f7d8c6b4",
+			}},
+		},
+		Spec: core_v1.PodSpec{
+			Containers: []core_v1.Container{{Name: "reviews", Image: "docker.io/istio/examples-bookinfo-reviews-v1:1.17.0"}},
+		},
+	}
+	for k, v := range labels {
+		pod.Labels[k] = v
+	}
+	for k, v := range annotations {
+		pod.Annotations[k] = v
+	}
+	if proxyImage != "" {
+		for k, v := range kubetest.FakeIstioAnnotations() {
+			pod.Annotations[k] = v
+		}
+		pod.Spec.Containers = append(pod.Spec.Containers, core_v1.Container{Name: "istio-proxy", Image: proxyImage})
+	}
+	return pod
+}
+
+// fakeReviewsV1Controllers returns the Deployment and the ReplicaSet of the reviews-v1 workload owning the fake injection Pods
+func fakeReviewsV1Controllers() []runtime.Object {
+	controller := true
+	return []runtime.Object{
+		&apps_v1.Deployment{
+			TypeMeta:   meta_v1.TypeMeta{Kind: "Deployment"},
+			ObjectMeta: meta_v1.ObjectMeta{Name: "reviews-v1", Namespace: "bookinfo"},
+			Spec: apps_v1.DeploymentSpec{
+				Template: core_v1.PodTemplateSpec{ObjectMeta: meta_v1.ObjectMeta{Labels: map[string]string{"app": "reviews", "version": "v1"}}},
+			},
+		},
+		&apps_v1.ReplicaSet{
+			TypeMeta: meta_v1.TypeMeta{Kind: "ReplicaSet"},
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "reviews-v1-5f7d8c6b4",
+				Namespace: "bookinfo",
+				OwnerReferences: []meta_v1.OwnerReference{{
+					Controller: &controller,
+					Kind:       "Deployment",
+					Name:       "reviews-v1",
+				}},
+			},
+			Spec: apps_v1.ReplicaSetSpec{
+				Template: core_v1.PodTemplateSpec{ObjectMeta: meta_v1.ObjectMeta{Labels: map[string]string{"app": "reviews", "version": "v1"}}},
+			},
+		},
+	}
+}
+
+func podInjectionStatus(injectionStatus *models.InjectionStatus, name string) *models.PodInjectionStatus {
+	for i := range injectionStatus.Pods {
+		if injectionStatus.Pods[i].Name == name {
+			return &injectionStatus.Pods[i]
+		}
+	}
+	return nil
+}
+
+func TestGetInjectionStatus(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	previousVersion, _ := status.GetStatus(status.MeshVersion)
+	status.Put(status.MeshVersion, "1.18.0")
+	t.Cleanup(func() { status.Put(status.MeshVersion, previousVersion) })
+
+	conf := config.NewConfig()
+	kubeObjs := []runtime.Object{
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo", Labels: map[string]string{"istio-injection": "enabled"}}},
+		fakeInjectionPod("reviews-v1-injected", "docker.io/istio/proxyv2:1.18.0", nil, nil),
+		fakeInjectionPod("reviews-v1-outdated", "docker.io/istio/proxyv2:1.17.2-distroless", nil, nil),
+		// The label overrides the annotation
+		fakeInjectionPod("reviews-v1-opt-out", "", map[string]string{"sidecar.istio.io/inject": "false"}, map[string]string{"sidecar.istio.io/inject": "true"}),
+	}
+	kubeObjs = append(kubeObjs, fakeReviewsV1Controllers()...)
+	k8s := kubetest.NewFakeK8sClient(kubeObjs...)
+	SetupBusinessLayer(t, k8s, *conf)
+	svc := setupWorkloadService(k8s, conf)
+
+	injectionStatus, err := svc.GetInjectionStatus(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "reviews-v1")
+	require.NoError(err)
+
+	assert.Equal("reviews-v1", injectionStatus.Workload)
+	assert.Equal("1.18.0", injectionStatus.ControlPlaneVersion)
+	assert.Equal("enabled", injectionStatus.NamespaceInjection)
+	assert.Empty(injectionStatus.NamespaceRevision)
+	require.Len(injectionStatus.Pods, 3)
+
+	injected := podInjectionStatus(injectionStatus, "reviews-v1-injected")
+	require.NotNil(injected)
+	assert.True(injected.Injected)
+	assert.True(injected.InjectionExpected)
+	assert.Equal("namespace", injected.InjectionSource)
+	assert.Nil(injected.PodInjection)
+	assert.Equal("docker.io/istio/proxyv2:1.18.0", injected.ProxyImage)
+	assert.Equal("1.18.0", injected.ProxyVersion)
+	require.NotNil(injected.VersionMatch)
+	assert.True(*injected.VersionMatch)
+
+	outdated := podInjectionStatus(injectionStatus, "reviews-v1-outdated")
+	require.NotNil(outdated)
+	assert.True(outdated.Injected)
+	assert.Equal("1.17.2", outdated.ProxyVersion)
+	require.NotNil(outdated.VersionMatch)
+	assert.False(*outdated.VersionMatch)
+
+	optOut := podInjectionStatus(injectionStatus, "reviews-v1-opt-out")
+	require.NotNil(optOut)
+	assert.False(optOut.Injected)
+	assert.False(optOut.InjectionExpected)
+	assert.Equal("pod", optOut.InjectionSource)
+	require.NotNil(optOut.PodInjection)
+	assert.False(*optOut.PodInjection)
+	assert.Empty(optOut.ProxyImage)
+	assert.Nil(optOut.VersionMatch)
+}
+
+func TestInjectionPolicy(t *testing.T) {
+	enabled, disabled := true, false
+	cases := map[string]struct {
+		namespaceInjection string
+		namespaceRevision  string
+		podInjection       *bool
+		expected           bool
+		source             string
+	}{
+		"Nothing configured":                {expected: false, source: "none"},
+		"Namespace enabled":                 {namespaceInjection: "enabled", expected: true, source: "namespace"},
+		"Namespace revision":                {namespaceRevision: "canary", expected: true, source: "namespace"},
+		"Pod enabled":                       {podInjection: &enabled, expected: true, source: "pod"},
+		"Pod disables an enabled namespace": {namespaceInjection: "enabled", podInjection: &disabled, expected: false, source: "pod"},
+		"Namespace disables an enabled pod": {namespaceInjection: "disabled", podInjection: &enabled, expected: false, source: "namespace"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			expected, source := injectionPolicy(tc.namespaceInjection, tc.namespaceRevision, tc.podInjection)
+			assert.Equal(t, tc.expected, expected)
+			assert.Equal(t, tc.source, source)
+		})
+	}
+}
+
+func TestProxyVersionFromImage(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("1.18.0", proxyVersionFromImage("docker.io/istio/proxyv2:1.18.0"))
+	assert.Equal("1.18.0", proxyVersionFromImage("docker.io/istio/proxyv2:1.18.0-distroless"))
+	assert.Equal("1.18.0", proxyVersionFromImage("registry.local:5000/istio/proxyv2:1.18.0@sha256:abcdef"))
+	assert.Empty(proxyVersionFromImage("registry.local:5000/istio/proxyv2"))
+}
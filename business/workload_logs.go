@@ -0,0 +1,252 @@
+package business
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/nitishm/engarde/pkg/parser"
+
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// WorkloadLogEntry is a log entry of a container of one of the pods of a workload
+type WorkloadLogEntry struct {
+	LogEntry
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+}
+
+// WorkloadLogError reports a container of one of the pods of a workload whose logs could not be read
+type WorkloadLogError struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Message   string `json:"message"`
+}
+
+// WorkloadLog reports the log entries of all the pods of a workload
+type WorkloadLog struct {
+	Entries        []WorkloadLogEntry `json:"entries,omitempty"`
+	LinesTruncated bool               `json:"linesTruncated,omitempty"`
+	Errors         []WorkloadLogError `json:"errors,omitempty"`
+}
+
+// workloadLogStream reads the parsed entries of the logs of a container
+type workloadLogStream struct {
+	pod       string
+	container string
+	isProxy   bool
+	reader    *bufio.Reader
+	closer    io.Closer
+	// next entry to merge, nil when the stream is exhausted
+	next *LogEntry
+	// err stopped the stream before its end
+	err error
+}
+
+// advance reads the next entry of the stream, skipping the lines that can't be parsed
+func (s *workloadLogStream) advance(engardeParser *parser.Parser) {
+	s.next = nil
+	for {
+		line, err := s.reader.ReadString('\n')
+		if len(line) > 0 {
+			if entry := parseLogLine(line, s.isProxy, engardeParser); entry != nil {
+				s.next = entry
+				return
+			}
+		}
+		if err != nil {
+			if err != io.EOF {
+				log.Debugf("Error reading the logs of container [%s] of pod [%s]: %s", s.container, s.pod, err.Error())
+				s.err = err
+			}
+			return
+		}
+	}
+}
+
+// StreamWorkloadLogs streams the logs of all the containers, including the istio-proxy, of all the pods of a workload
+// to an HTTP Response. The logs of each container are already sorted by time, so they are merged chronologically
+// while they are read and each entry is tagged with its pod and container.
+// The streams are closed when the context is cancelled, i.e. when the client goes away.
+// The containers whose logs can't be read are reported in the errors of the document, the logs of the others are
+// still streamed. An error is only returned when no log could be read at all.
+func (in *WorkloadService) StreamWorkloadLogs(ctx context.Context, cluster, namespace, workload string, opts *LogOptions, w http.ResponseWriter) error {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "StreamWorkloadLogs",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("workload", workload),
+	)
+	defer end()
+
+	userClient, ok := in.userClients[cluster]
+	if !ok {
+		return fmt.Errorf("user client for cluster [%s] not found", cluster)
+	}
+
+	wk, err := in.fetchWorkload(ctx, WorkloadCriteria{Cluster: cluster, Namespace: namespace, WorkloadName: workload, WorkloadType: ""})
+	if err != nil {
+		return err
+	}
+
+	// The streams are closed when done or as soon as the context is cancelled, to unblock a pending read
+	var (
+		streams   []*workloadLogStream
+		streamsMu sync.Mutex
+		closed    bool
+	)
+	closeStreams := func() {
+		streamsMu.Lock()
+		defer streamsMu.Unlock()
+		if closed {
+			return
+		}
+		closed = true
+		for _, s := range streams {
+			if e := s.closer.Close(); e != nil {
+				log.Errorf("Error when closing the connection streaming logs of a pod: %s", e.Error())
+			}
+		}
+	}
+	done := make(chan struct{})
+	defer close(done)
+	defer closeStreams()
+	go func() {
+		select {
+		case <-ctx.Done():
+			closeStreams()
+		case <-done:
+		}
+	}()
+
+	engardeParser := parser.New(parser.IstioProxyAccessLogsPattern)
+	logErrors := []WorkloadLogError{}
+	var firstErr error
+	for _, pod := range wk.Pods {
+		containers := make([]*models.ContainerInfo, 0, len(pod.Containers)+len(pod.IstioContainers))
+		containers = append(containers, pod.Containers...)
+		containers = append(containers, pod.IstioContainers...)
+		for _, container := range containers {
+			k8sOpts := opts.PodLogOptions
+			k8sOpts.Container = container.Name
+			logsReader, err := userClient.StreamPodLogs(namespace, pod.Name, &k8sOpts)
+			if err != nil {
+				log.Debugf("Error opening the logs of container [%s] of pod [%s]: %s", container.Name, pod.Name, err.Error())
+				logErrors = append(logErrors, WorkloadLogError{Pod: pod.Name, Container: container.Name, Message: err.Error()})
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+			s := &workloadLogStream{
+				pod:       pod.Name,
+				container: container.Name,
+				isProxy:   container.IsProxy,
+				reader:    bufio.NewReader(logsReader),
+				closer:    logsReader,
+			}
+			streamsMu.Lock()
+			if closed {
+				streamsMu.Unlock()
+				logsReader.Close()
+				return ctx.Err()
+			}
+			streams = append(streams, s)
+			streamsMu.Unlock()
+			s.advance(engardeParser)
+		}
+	}
+
+	if len(streams) == 0 && firstErr != nil {
+		return firstErr
+	}
+
+	// the k8s API does not support "endTime/beforeTime". So for bounded time ranges we need to
+	// discard the logs after sinceTime+duration
+	var endTime *time.Time
+	if opts.SinceTime != nil && opts.Duration != nil {
+		end := opts.SinceTime.Time.Add(*opts.Duration)
+		endTime = &end
+	}
+
+	// As in streamParsedLogs, the JSON is written as the entries are merged,
+	// so errors in the middle of the processing truncate the document.
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write([]byte("{\"entries\":[")); err != nil {
+		return err
+	}
+	flusher, _ := w.(http.Flusher)
+
+	linesWritten := 0
+	truncated := false
+	for {
+		if ctx.Err() != nil {
+			log.Debugf("Stopped streaming the logs of workload [%s]: %s", workload, ctx.Err().Error())
+			return nil
+		}
+
+		// Pick the oldest pending entry
+		var oldest *workloadLogStream
+		for _, s := range streams {
+			if s.next != nil && (oldest == nil || s.next.OriginalTime.Before(oldest.next.OriginalTime)) {
+				oldest = s
+			}
+		}
+		if oldest == nil || (endTime != nil && oldest.next.OriginalTime.After(*endTime)) {
+			break
+		}
+		if opts.MaxLines != nil && linesWritten >= *opts.MaxLines {
+			truncated = true
+			break
+		}
+
+		response, err := json.Marshal(WorkloadLogEntry{LogEntry: *oldest.next, Pod: oldest.pod, Container: oldest.container})
+		if err != nil {
+			log.Errorf("Error when marshalling JSON while streaming workload logs: %s", err.Error())
+			return nil
+		}
+		if linesWritten > 0 {
+			response = append([]byte{','}, response...)
+		}
+		if _, err := w.Write(response); err != nil {
+			log.Errorf("Error when writing a processed log entry while streaming workload logs: %s", err.Error())
+			return nil
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		linesWritten++
+		oldest.advance(engardeParser)
+	}
+
+	outro := "]"
+	if truncated {
+		outro += ", \"linesTruncated\": true"
+	}
+	for _, s := range streams {
+		if s.err != nil {
+			logErrors = append(logErrors, WorkloadLogError{Pod: s.pod, Container: s.container, Message: s.err.Error()})
+		}
+	}
+	if len(logErrors) > 0 {
+		errorsJSON, err := json.Marshal(logErrors)
+		if err != nil {
+			log.Errorf("Error when marshalling JSON while streaming workload logs: %s", err.Error())
+			return nil
+		}
+		outro += ", \"errors\": " + string(errorsJSON)
+	}
+	if _, err := w.Write([]byte(outro + "}")); err != nil {
+		log.Errorf("Error when writing the outro of the JSON document while streaming workload logs: %s", err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+)
+
+// podLogsStreamer returns the logs of each pod, keyed by pod name
+type podLogsStreamer struct {
+	logs map[string]string
+	errs map[string]error
+	kubernetes.ClientInterface
+}
+
+func (l *podLogsStreamer) StreamPodLogs(namespace, name string, opts *core_v1.PodLogOptions) (io.ReadCloser, error) {
+	if err, ok := l.errs[name]; ok {
+		return nil, err
+	}
+	return io.NopCloser(strings.NewReader(l.logs[name])), nil
+}
+
+func fakeWorkloadLogsPod(name string) *core_v1.Pod {
+	return &core_v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      name,
+			Namespace: "bookinfo",
+			Labels:    map[string]string{"app": "reviews", "version": "v1"},
+		},
+		Spec: core_v1.PodSpec{Containers: []core_v1.Container{{Name: "reviews"}}},
+	}
+}
+
+func callStreamWorkloadLogs(t *testing.T, svc WorkloadService, opts *LogOptions) WorkloadLog {
+	w := httptest.NewRecorder()
+	require.NoError(t, svc.StreamWorkloadLogs(context.TODO(), svc.config.KubernetesConfig.ClusterName, "bookinfo", "reviews-v1", opts, w))
+
+	var workloadLog WorkloadLog
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &workloadLog))
+	return workloadLog
+}
+
+func TestStreamWorkloadLogs(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	k8s := &podLogsStreamer{
+		logs: map[string]string{
+			"reviews-v1-a": "2018-01-02T03:34:28+00:00 INFO #1 a\n2018-01-02T05:34:28+00:00 WARN #3 a\n",
+			"reviews-v1-b": "2018-01-02T04:34:28+00:00 INFO #2 b\nnot a log line\n2018-01-02T06:34:28+00:00 ERROR #4 b\n",
+		},
+		ClientInterface: kubetest.NewFakeK8sClient(
+			&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+			&apps_v1.Deployment{
+				TypeMeta:   meta_v1.TypeMeta{Kind: "Deployment"},
+				ObjectMeta: meta_v1.ObjectMeta{Name: "reviews-v1", Namespace: "bookinfo"},
+				Spec: apps_v1.DeploymentSpec{
+					Selector: &meta_v1.LabelSelector{MatchLabels: map[string]string{"app": "reviews", "version": "v1"}},
+					Template: core_v1.PodTemplateSpec{ObjectMeta: meta_v1.ObjectMeta{Labels: map[string]string{"app": "reviews", "version": "v1"}}},
+				},
+			},
+			fakeWorkloadLogsPod("reviews-v1-a"),
+			fakeWorkloadLogsPod("reviews-v1-b"),
+		),
+	}
+	SetupBusinessLayer(t, k8s, *conf)
+	svc := setupWorkloadService(k8s, conf)
+
+	workloadLog := callStreamWorkloadLogs(t, svc, &LogOptions{})
+	require.Len(workloadLog.Entries, 4)
+	assert.False(workloadLog.LinesTruncated)
+	for i, expected := range []struct{ message, severity, pod string }{
+		{"INFO #1 a", "INFO", "reviews-v1-a"},
+		{"INFO #2 b", "INFO", "reviews-v1-b"},
+		{"WARN #3 a", "WARN", "reviews-v1-a"},
+		{"ERROR #4 b", "ERROR", "reviews-v1-b"},
+	} {
+		assert.Equal(expected.message, workloadLog.Entries[i].Message)
+		assert.Equal(expected.severity, workloadLog.Entries[i].Severity)
+		assert.Equal(expected.pod, workloadLog.Entries[i].Pod)
+		assert.Equal("reviews", workloadLog.Entries[i].Container)
+	}
+
+	maxLines := 2
+	workloadLog = callStreamWorkloadLogs(t, svc, &LogOptions{MaxLines: &maxLines})
+	require.Len(workloadLog.Entries, 2)
+	assert.True(workloadLog.LinesTruncated)
+	assert.Equal("reviews-v1-b", workloadLog.Entries[1].Pod)
+
+	// The entries after sinceTime+duration are discarded
+	sinceTime := meta_v1.NewTime(time.Date(2018, 1, 2, 3, 0, 0, 0, time.UTC))
+	duration := 2 * time.Hour
+	workloadLog = callStreamWorkloadLogs(t, svc, &LogOptions{PodLogOptions: core_v1.PodLogOptions{SinceTime: &sinceTime}, Duration: &duration})
+	require.Len(workloadLog.Entries, 2)
+	assert.False(workloadLog.LinesTruncated)
+	assert.Equal("reviews-v1-a", workloadLog.Entries[0].Pod)
+	assert.Equal("reviews-v1-b", workloadLog.Entries[1].Pod)
+	assert.Empty(workloadLog.Errors)
+
+	// The logs of the other pods are still streamed when a pod fails
+	k8s.errs = map[string]error{"reviews-v1-b": fmt.Errorf("container \"reviews\" in pod \"reviews-v1-b\" is waiting to start")}
+	workloadLog = callStreamWorkloadLogs(t, svc, &LogOptions{})
+	require.Len(workloadLog.Entries, 2)
+	assert.Equal("reviews-v1-a", workloadLog.Entries[0].Pod)
+	assert.Equal("reviews-v1-a", workloadLog.Entries[1].Pod)
+	assert.Equal([]WorkloadLogError{{Pod: "reviews-v1-b", Container: "reviews", Message: k8s.errs["reviews-v1-b"].Error()}}, workloadLog.Errors)
+
+	// An error is returned when no log can be read
+	k8s.errs["reviews-v1-a"] = fmt.Errorf("forbidden")
+	err := svc.StreamWorkloadLogs(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "reviews-v1", &LogOptions{}, httptest.NewRecorder())
+	assert.Error(err)
+}
@@ -0,0 +1,88 @@
+package business
+
+import (
+	"context"
+
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+const (
+	outboundTrafficPolicySourceSidecar = "sidecar"
+	outboundTrafficPolicySourceMesh    = "mesh"
+	outboundTrafficPolicySourceDefault = "default"
+)
+
+// GetOutboundTrafficPolicy resolves whether the proxies of a workload let the traffic reach the hosts unknown to the mesh.
+// The outbound traffic policy of the Sidecar applied to the workload wins, otherwise the one of the mesh config applies,
+// otherwise the Istio default ALLOW_ANY. It helps to tell why the egress of a workload is blocked.
+// It uses following parameters:
+// - "cluster":		cluster of the workload
+// - "namespace":	namespace of the workload
+// - "workload":	name of the workload. When empty the policy of the namespace is resolved, ignoring the Sidecars with a selector
+func (in *WorkloadService) GetOutboundTrafficPolicy(ctx context.Context, cluster, namespace, workload string) (*models.OutboundTrafficPolicy, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetOutboundTrafficPolicy",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("workload", workload),
+	)
+	defer end()
+
+	workloadSelector := ""
+	if workload != "" {
+		wk, err := in.fetchWorkload(ctx, WorkloadCriteria{Cluster: cluster, Namespace: namespace, WorkloadName: workload, WorkloadType: ""})
+		if err != nil {
+			return nil, err
+		}
+		workloadSelector = labels.Set(wk.Labels).String()
+	}
+
+	meshConfig, err := in.getMeshConfig(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	criteria := IstioConfigCriteria{Namespace: namespace, Cluster: cluster, IncludeSidecars: true}
+	istioConfigList, err := in.businessLayer.IstioConfig.GetIstioConfigList(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+	namespaceSidecars := istioConfigList.Sidecars
+	if workload == "" {
+		namespaceSidecars, _ = splitSidecarsBySelector(namespaceSidecars)
+	}
+
+	var rootSidecars []*networking_v1beta1.Sidecar
+	// Sidecars of the root namespace apply to the workloads of every namespace
+	rootNamespace := in.config.ExternalServices.Istio.RootNamespace
+	if rootNamespace != "" && rootNamespace != namespace {
+		criteria.Namespace = rootNamespace
+		rootConfigList, err := in.businessLayer.IstioConfig.GetIstioConfigList(ctx, criteria)
+		if err != nil {
+			return nil, err
+		}
+		rootSidecars = rootConfigList.Sidecars
+	}
+
+	return resolveOutboundTrafficPolicy(meshConfig, effectiveSidecar(workloadSelector, namespaceSidecars, rootSidecars)), nil
+}
+
+func resolveOutboundTrafficPolicy(meshConfig *kubernetes.IstioMeshConfig, sidecar *networking_v1beta1.Sidecar) *models.OutboundTrafficPolicy {
+	if sidecar != nil && sidecar.Spec.OutboundTrafficPolicy != nil {
+		return &models.OutboundTrafficPolicy{
+			Mode:    sidecar.Spec.OutboundTrafficPolicy.Mode.String(),
+			Source:  outboundTrafficPolicySourceSidecar,
+			Sidecar: sidecar.Namespace + "/" + sidecar.Name,
+		}
+	}
+	if meshConfig.OutboundTrafficPolicy != nil && meshConfig.OutboundTrafficPolicy.Mode != "" {
+		return &models.OutboundTrafficPolicy{Mode: meshConfig.OutboundTrafficPolicy.Mode, Source: outboundTrafficPolicySourceMesh}
+	}
+	return &models.OutboundTrafficPolicy{Mode: models.OutboundTrafficPolicyAllowAny, Source: outboundTrafficPolicySourceDefault}
+}
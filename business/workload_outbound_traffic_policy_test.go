@@ -0,0 +1,99 @@
+package business
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+func TestGetOutboundTrafficPolicy(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	config.Set(conf)
+
+	reviewsSidecar := fakeEgressSidecar("reviews", "bookinfo", time.Hour, map[string]string{"app": "reviews"}, "*/*")
+	reviewsSidecar.Spec.OutboundTrafficPolicy = &api_networking_v1beta1.OutboundTrafficPolicy{Mode: api_networking_v1beta1.OutboundTrafficPolicy_ALLOW_ANY}
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		&core_v1.ConfigMap{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "istio", Namespace: "istio-system"},
+			Data:       map[string]string{"mesh": "outboundTrafficPolicy:\n  mode: REGISTRY_ONLY\n"},
+		},
+		fakeAccessLoggingDeployment("reviews-v1", map[string]string{"app": "reviews", "version": "v1"}),
+		fakeAccessLoggingDeployment("details-v1", map[string]string{"app": "details", "version": "v1"}),
+		reviewsSidecar,
+		fakeEgressSidecar("bookinfo-default", "bookinfo", time.Hour, nil, "./*"),
+	)
+	SetupBusinessLayer(t, k8s, *conf)
+	svc := setupWorkloadService(k8s, conf)
+
+	// The Sidecar selecting the workload overrides the mesh default
+	policy, err := svc.GetOutboundTrafficPolicy(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "reviews-v1")
+	require.NoError(err)
+	assert.Equal(&models.OutboundTrafficPolicy{Mode: "ALLOW_ANY", Source: "sidecar", Sidecar: "bookinfo/reviews"}, policy)
+
+	// The namespace wide Sidecar doesn't set a policy, the mesh one applies
+	policy, err = svc.GetOutboundTrafficPolicy(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "details-v1")
+	require.NoError(err)
+	assert.Equal(&models.OutboundTrafficPolicy{Mode: "REGISTRY_ONLY", Source: "mesh"}, policy)
+
+	// The Sidecars with a selector don't apply to the namespace
+	policy, err = svc.GetOutboundTrafficPolicy(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "")
+	require.NoError(err)
+	assert.Equal(&models.OutboundTrafficPolicy{Mode: "REGISTRY_ONLY", Source: "mesh"}, policy)
+}
+
+func TestGetOutboundTrafficPolicyRootNamespaceSidecar(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	config.Set(conf)
+
+	meshSidecar := fakeEgressSidecar("mesh-default", "istio-system", time.Hour, nil, "*/*")
+	meshSidecar.Spec.OutboundTrafficPolicy = &api_networking_v1beta1.OutboundTrafficPolicy{Mode: api_networking_v1beta1.OutboundTrafficPolicy_REGISTRY_ONLY}
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		fakeAccessLoggingDeployment("reviews-v1", map[string]string{"app": "reviews", "version": "v1"}),
+		meshSidecar,
+	)
+	SetupBusinessLayer(t, k8s, *conf)
+	svc := setupWorkloadService(k8s, conf)
+
+	// Without mesh config the root namespace Sidecar overrides the Istio default
+	policy, err := svc.GetOutboundTrafficPolicy(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "reviews-v1")
+	require.NoError(err)
+	assert.Equal(&models.OutboundTrafficPolicy{Mode: "REGISTRY_ONLY", Source: "sidecar", Sidecar: "istio-system/mesh-default"}, policy)
+}
+
+func TestResolveOutboundTrafficPolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(&models.OutboundTrafficPolicy{Mode: "ALLOW_ANY", Source: "default"}, resolveOutboundTrafficPolicy(&kubernetes.IstioMeshConfig{}, nil))
+
+	meshConfig := &kubernetes.IstioMeshConfig{OutboundTrafficPolicy: &kubernetes.IstioMeshOutboundTrafficPolicy{Mode: "REGISTRY_ONLY"}}
+	sidecar := fakeEgressSidecar("default", "bookinfo", time.Hour, nil, "*/*")
+	assert.Equal(&models.OutboundTrafficPolicy{Mode: "REGISTRY_ONLY", Source: "mesh"}, resolveOutboundTrafficPolicy(meshConfig, sidecar))
+
+	sidecar.Spec.OutboundTrafficPolicy = &api_networking_v1beta1.OutboundTrafficPolicy{Mode: api_networking_v1beta1.OutboundTrafficPolicy_ALLOW_ANY}
+	assert.Equal(&models.OutboundTrafficPolicy{Mode: "ALLOW_ANY", Source: "sidecar", Sidecar: "bookinfo/default"}, resolveOutboundTrafficPolicy(meshConfig, sidecar))
+}
@@ -0,0 +1,153 @@
+package business
+
+import (
+	"strings"
+
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/cache"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+// maxOwnerChainDepth bounds the walk of the owners, in case of a loop in the ownerReferences
+const maxOwnerChainDepth = 10
+
+// setOwnerChains sets on the pods of a workload the chain of the controllers owning them, from the direct owner of the pod up.
+// The chain is walked through the controller types Kiali knows. It ends at the first controller of another type,
+// like an Argo Rollout owning a ReplicaSet, which is returned as is since its owners can't be fetched.
+// The owner chain is optional in the workload details, the pods whose chain can't be walked are returned without it.
+func (in *WorkloadService) setOwnerChains(cluster, namespace string, workload *models.Workload) {
+	if len(workload.Pods) == 0 {
+		return
+	}
+
+	kubeCache, err := in.cache.GetKubeCache(cluster)
+	if err != nil {
+		log.Warningf("Cannot get the owner chains of workload [%s] in namespace [%s]: %v", workload.Name, namespace, err)
+		return
+	}
+	client, ok := in.userClients[cluster]
+	if !ok {
+		log.Warningf("Cannot get the owner chains of workload [%s] in namespace [%s]: client for cluster [%s] not found", workload.Name, namespace, cluster)
+		return
+	}
+	walker := ownerChainWalker{kubeCache: kubeCache, client: client, namespace: namespace, chains: map[string][]models.Reference{}}
+
+	pods, err := kubeCache.GetPods(namespace, "")
+	if err != nil {
+		log.Warningf("Cannot get the owner chains of workload [%s] in namespace [%s]: %v", workload.Name, namespace, err)
+		return
+	}
+	podsByName := make(map[string]int, len(pods))
+	for i, pod := range pods {
+		podsByName[pod.Name] = i
+	}
+
+	for _, pod := range workload.Pods {
+		pod.OwnerChain = []models.Reference{}
+		if i, found := podsByName[pod.Name]; found {
+			chain, err := walker.chain(pods[i].OwnerReferences)
+			if err != nil {
+				log.Warningf("Cannot get the owner chain of pod [%s] in namespace [%s]: %v", pod.Name, namespace, err)
+				continue
+			}
+			pod.OwnerChain = chain
+		}
+	}
+}
+
+type ownerChainWalker struct {
+	kubeCache cache.KubeCache
+	client    kubernetes.ClientInterface
+	namespace string
+	// Chains already walked, by the first owner. The pods of a workload usually share their owners.
+	chains map[string][]models.Reference
+}
+
+func (w *ownerChainWalker) chain(ownerReferences []meta_v1.OwnerReference) ([]models.Reference, error) {
+	owner := controllerReference(ownerReferences)
+	if owner == nil {
+		return []models.Reference{}, nil
+	}
+	key := owner.Kind + "/" + owner.Name
+	if chain, ok := w.chains[key]; ok {
+		return chain, nil
+	}
+
+	chain := []models.Reference{}
+	for owner != nil && len(chain) < maxOwnerChainDepth {
+		chain = append(chain, models.Reference{Name: owner.Name, Kind: owner.Kind, APIVersion: owner.APIVersion})
+		owners, known, err := w.ownerReferences(owner)
+		if err != nil {
+			return nil, err
+		}
+		if !known {
+			break
+		}
+		owner = controllerReference(owners)
+	}
+	w.chains[key] = chain
+	return chain, nil
+}
+
+// ownerReferences returns the ownerReferences of a controller, false when the type of the controller is not known by Kiali.
+// A controller not found ends the chain, it may have been deleted in between.
+func (w *ownerChainWalker) ownerReferences(owner *meta_v1.OwnerReference) ([]meta_v1.OwnerReference, bool, error) {
+	group := strings.Split(owner.APIVersion, "/")[0]
+	if strings.Contains(owner.APIVersion, "/") && group != "apps" && group != "batch" && group != "apps.openshift.io" {
+		return nil, false, nil
+	}
+
+	switch owner.Kind {
+	case kubernetes.ReplicaSetType:
+		replicaSets, err := w.kubeCache.GetReplicaSets(w.namespace)
+		if err != nil {
+			return nil, true, err
+		}
+		for _, rs := range replicaSets {
+			if rs.Name == owner.Name {
+				return rs.OwnerReferences, true, nil
+			}
+		}
+	case kubernetes.ReplicationControllerType:
+		return w.objectOwnerReferences(w.client.GetReplicationController(w.namespace, owner.Name))
+	case kubernetes.JobType:
+		return w.objectOwnerReferences(w.client.GetJob(w.namespace, owner.Name))
+	case kubernetes.CronJobType:
+		return w.objectOwnerReferences(w.client.GetCronJob(w.namespace, owner.Name))
+	case kubernetes.DeploymentType:
+		return w.objectOwnerReferences(w.kubeCache.GetDeployment(w.namespace, owner.Name))
+	case kubernetes.StatefulSetType:
+		return w.objectOwnerReferences(w.kubeCache.GetStatefulSet(w.namespace, owner.Name))
+	case kubernetes.DaemonSetType:
+		return w.objectOwnerReferences(w.kubeCache.GetDaemonSet(w.namespace, owner.Name))
+	case kubernetes.DeploymentConfigType:
+		return w.objectOwnerReferences(w.client.GetDeploymentConfig(w.namespace, owner.Name))
+	default:
+		return nil, false, nil
+	}
+	return nil, true, nil
+}
+
+func (w *ownerChainWalker) objectOwnerReferences(object meta_v1.Object, err error) ([]meta_v1.OwnerReference, bool, error) {
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return nil, true, nil
+		}
+		return nil, true, err
+	}
+	return object.GetOwnerReferences(), true, nil
+}
+
+// controllerReference returns the managing controller among the ownerReferences
+func controllerReference(ownerReferences []meta_v1.OwnerReference) *meta_v1.OwnerReference {
+	for i := range ownerReferences {
+		if ownerReferences[i].Controller != nil && *ownerReferences[i].Controller {
+			return &ownerReferences[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	osproject_v1 "github.com/openshift/api/project/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apps_v1 "k8s.io/api/apps/v1"
+	batch_v1 "k8s.io/api/batch/v1"
+	core_v1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+func fakeOwnerReference(apiVersion, kind, name string) []meta_v1.OwnerReference {
+	controller := true
+	return []meta_v1.OwnerReference{{APIVersion: apiVersion, Kind: kind, Name: name, Controller: &controller}}
+}
+
+func TestGetWorkloadOwnerChain(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	config.Set(conf)
+
+	labels := map[string]string{"app": "reviews", "version": "v1"}
+	k8s := kubetest.NewFakeK8sClient(
+		&osproject_v1.Project{ObjectMeta: meta_v1.ObjectMeta{Name: "Namespace"}},
+		// An Argo Rollout manages the ReplicaSet, Kiali doesn't know the Rollout type
+		&apps_v1.ReplicaSet{
+			TypeMeta: meta_v1.TypeMeta{Kind: "ReplicaSet"},
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:            "reviews-7d9f8c6b4",
+				Namespace:       "Namespace",
+				OwnerReferences: fakeOwnerReference("argoproj.io/v1alpha1", "Rollout", "reviews"),
+			},
+			Spec: apps_v1.ReplicaSetSpec{
+				Selector: &meta_v1.LabelSelector{MatchLabels: labels},
+				Template: core_v1.PodTemplateSpec{ObjectMeta: meta_v1.ObjectMeta{Labels: labels}},
+			},
+		},
+		&core_v1.Pod{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:            "reviews-7d9f8c6b4-xk2lp",
+				Namespace:       "Namespace",
+				Labels:          labels,
+				OwnerReferences: fakeOwnerReference("apps/v1", "ReplicaSet", "reviews-7d9f8c6b4"),
+			},
+		},
+	)
+	k8s.OpenShift = true
+	SetupBusinessLayer(t, k8s, *conf)
+	svc := setupWorkloadService(k8s, conf)
+
+	criteria := WorkloadCriteria{Cluster: conf.KubernetesConfig.ClusterName, Namespace: "Namespace", WorkloadName: "reviews-7d9f8c6b4"}
+	workload, err := svc.GetWorkload(context.TODO(), criteria)
+	require.NoError(err)
+	require.Len(workload.Pods, 1)
+	assert.Nil(workload.Pods[0].OwnerChain)
+
+	criteria.IncludeOwnerChain = true
+	workload, err = svc.GetWorkload(context.TODO(), criteria)
+	require.NoError(err)
+	require.Len(workload.Pods, 1)
+	assert.Equal([]models.Reference{
+		{Name: "reviews-7d9f8c6b4", Kind: "ReplicaSet", APIVersion: "apps/v1"},
+		{Name: "reviews", Kind: "Rollout", APIVersion: "argoproj.io/v1alpha1"},
+	}, workload.Pods[0].OwnerChain)
+}
+
+func TestGetWorkloadOwnerChainFromDeployment(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	config.Set(conf)
+
+	labels := map[string]string{"app": "details", "version": "v1"}
+	k8s := kubetest.NewFakeK8sClient(
+		&osproject_v1.Project{ObjectMeta: meta_v1.ObjectMeta{Name: "Namespace"}},
+		&apps_v1.Deployment{
+			TypeMeta:   meta_v1.TypeMeta{Kind: "Deployment"},
+			ObjectMeta: meta_v1.ObjectMeta{Name: "details-v1", Namespace: "Namespace"},
+			Spec: apps_v1.DeploymentSpec{
+				Selector: &meta_v1.LabelSelector{MatchLabels: labels},
+				Template: core_v1.PodTemplateSpec{ObjectMeta: meta_v1.ObjectMeta{Labels: labels}},
+			},
+		},
+		&apps_v1.ReplicaSet{
+			TypeMeta: meta_v1.TypeMeta{Kind: "ReplicaSet"},
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:            "details-v1-5f7d8c6b4",
+				Namespace:       "Namespace",
+				OwnerReferences: fakeOwnerReference("apps/v1", "Deployment", "details-v1"),
+			},
+			Spec: apps_v1.ReplicaSetSpec{
+				Selector: &meta_v1.LabelSelector{MatchLabels: labels},
+				Template: core_v1.PodTemplateSpec{ObjectMeta: meta_v1.ObjectMeta{Labels: labels}},
+			},
+		},
+		&core_v1.Pod{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:            "details-v1-5f7d8c6b4-abcde",
+				Namespace:       "Namespace",
+				Labels:          labels,
+				OwnerReferences: fakeOwnerReference("apps/v1", "ReplicaSet", "details-v1-5f7d8c6b4"),
+			},
+		},
+	)
+	k8s.OpenShift = true
+	SetupBusinessLayer(t, k8s, *conf)
+	svc := setupWorkloadService(k8s, conf)
+
+	criteria := WorkloadCriteria{Cluster: conf.KubernetesConfig.ClusterName, Namespace: "Namespace", WorkloadName: "details-v1", IncludeOwnerChain: true}
+	workload, err := svc.GetWorkload(context.TODO(), criteria)
+	require.NoError(err)
+	require.Len(workload.Pods, 1)
+	assert.Equal([]models.Reference{
+		{Name: "details-v1-5f7d8c6b4", Kind: "ReplicaSet", APIVersion: "apps/v1"},
+		{Name: "details-v1", Kind: "Deployment", APIVersion: "apps/v1"},
+	}, workload.Pods[0].OwnerChain)
+}
+
+// forbiddenCronJobsClient can't read the CronJobs
+type forbiddenCronJobsClient struct {
+	kubernetes.ClientInterface
+}
+
+func (c *forbiddenCronJobsClient) GetCronJob(namespace string, name string) (*batch_v1.CronJob, error) {
+	return nil, k8s_errors.NewForbidden(batch_v1.Resource("cronjobs"), name, fmt.Errorf("forbidden"))
+}
+
+func TestGetWorkloadOwnerChainFromCronJob(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	config.Set(conf)
+
+	labels := map[string]string{"app": "reports", "version": "v1"}
+	k8s := kubetest.NewFakeK8sClient(
+		&osproject_v1.Project{ObjectMeta: meta_v1.ObjectMeta{Name: "Namespace"}},
+		&batch_v1.CronJob{ObjectMeta: meta_v1.ObjectMeta{Name: "reports", Namespace: "Namespace"}},
+		// Another CronJob of the namespace, only the owner is fetched
+		&batch_v1.CronJob{ObjectMeta: meta_v1.ObjectMeta{Name: "cleanup", Namespace: "Namespace"}},
+		&apps_v1.ReplicaSet{
+			TypeMeta: meta_v1.TypeMeta{Kind: "ReplicaSet"},
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:            "reports-6c8d9f7b5",
+				Namespace:       "Namespace",
+				OwnerReferences: fakeOwnerReference("batch/v1", "CronJob", "reports"),
+			},
+			Spec: apps_v1.ReplicaSetSpec{
+				Selector: &meta_v1.LabelSelector{MatchLabels: labels},
+				Template: core_v1.PodTemplateSpec{ObjectMeta: meta_v1.ObjectMeta{Labels: labels}},
+			},
+		},
+		&core_v1.Pod{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:            "reports-6c8d9f7b5-qx7pz",
+				Namespace:       "Namespace",
+				Labels:          labels,
+				OwnerReferences: fakeOwnerReference("apps/v1", "ReplicaSet", "reports-6c8d9f7b5"),
+			},
+		},
+	)
+	k8s.OpenShift = true
+	SetupBusinessLayer(t, k8s, *conf)
+
+	criteria := WorkloadCriteria{Cluster: conf.KubernetesConfig.ClusterName, Namespace: "Namespace", WorkloadName: "reports-6c8d9f7b5", IncludeOwnerChain: true}
+	svc := setupWorkloadService(k8s, conf)
+	workload, err := svc.GetWorkload(context.TODO(), criteria)
+	require.NoError(err)
+	require.Len(workload.Pods, 1)
+	assert.Equal([]models.Reference{
+		{Name: "reports-6c8d9f7b5", Kind: "ReplicaSet", APIVersion: "apps/v1"},
+		{Name: "reports", Kind: "CronJob", APIVersion: "batch/v1"},
+	}, workload.Pods[0].OwnerChain)
+
+	// The owner chain is optional, the workload is returned without it when it can't be walked
+	svc = setupWorkloadService(&forbiddenCronJobsClient{ClientInterface: k8s}, conf)
+	workload, err = svc.GetWorkload(context.TODO(), criteria)
+	require.NoError(err)
+	require.Len(workload.Pods, 1)
+	assert.Empty(workload.Pods[0].OwnerChain)
+}
@@ -0,0 +1,158 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	prom_v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+	"github.com/kiali/kiali/prometheus"
+)
+
+// proxyContainerName is the name of the container of the Istio sidecar, injected as a container or as a native sidecar init container
+const proxyContainerName = "istio-proxy"
+
+// setProxyResources sets on the pods of a workload the resources requested by their istio-proxy container and its limits,
+// to show the overhead of the sidecar on each pod. When Prometheus is available, the CPU and memory used by the proxies
+// are added from the cAdvisor metrics. The usage is optional: a failing query is logged and leaves it unset.
+func (in *WorkloadService) setProxyResources(ctx context.Context, criteria WorkloadCriteria, workload *models.Workload) error {
+	if len(workload.Pods) == 0 {
+		return nil
+	}
+	var end observability.EndFunc
+	_, end = observability.StartSpan(ctx, "setProxyResources",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", criteria.Cluster),
+		observability.Attribute("namespace", criteria.Namespace),
+		observability.Attribute("workload", workload.Name),
+	)
+	defer end()
+
+	kubeCache, err := in.cache.GetKubeCache(criteria.Cluster)
+	if err != nil {
+		return err
+	}
+	// Only the pods of the workload, selected by its labels, are fetched
+	pods, err := kubeCache.GetPods(criteria.Namespace, labels.Set(workload.Labels).String())
+	if err != nil {
+		return err
+	}
+	podsByName := make(map[string]int, len(pods))
+	for i, pod := range pods {
+		podsByName[pod.Name] = i
+	}
+
+	proxyPods := []string{}
+	for _, pod := range workload.Pods {
+		pod.ProxyResources = nil
+		if i, found := podsByName[pod.Name]; found {
+			if container := proxyContainer(&pods[i]); container != nil {
+				pod.ProxyResources = parseProxyResources(container)
+				proxyPods = append(proxyPods, pod.Name)
+			}
+		}
+	}
+
+	if in.prom == nil || len(proxyPods) == 0 {
+		return nil
+	}
+	cpuUsage, memoryUsage := in.fetchProxyUsage(criteria, proxyPods)
+	for _, pod := range workload.Pods {
+		if pod.ProxyResources == nil {
+			continue
+		}
+		if usage, ok := cpuUsage[pod.Name]; ok {
+			pod.ProxyResources.CPUUsage = &usage
+		}
+		if usage, ok := memoryUsage[pod.Name]; ok {
+			pod.ProxyResources.MemoryUsage = &usage
+		}
+	}
+	return nil
+}
+
+// proxyContainer returns the istio-proxy container of a pod, nil when the pod has no sidecar
+func proxyContainer(pod *core_v1.Pod) *core_v1.Container {
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == proxyContainerName {
+			return &pod.Spec.Containers[i]
+		}
+	}
+	// With native sidecars, the proxy is injected as an init container
+	for i := range pod.Spec.InitContainers {
+		if pod.Spec.InitContainers[i].Name == proxyContainerName {
+			return &pod.Spec.InitContainers[i]
+		}
+	}
+	return nil
+}
+
+func parseProxyResources(container *core_v1.Container) *models.ProxyResources {
+	quantity := func(resources core_v1.ResourceList, name core_v1.ResourceName) string {
+		if q, ok := resources[name]; ok {
+			return q.String()
+		}
+		return ""
+	}
+	return &models.ProxyResources{
+		CPURequest:    quantity(container.Resources.Requests, core_v1.ResourceCPU),
+		CPULimit:      quantity(container.Resources.Limits, core_v1.ResourceCPU),
+		MemoryRequest: quantity(container.Resources.Requests, core_v1.ResourceMemory),
+		MemoryLimit:   quantity(container.Resources.Limits, core_v1.ResourceMemory),
+	}
+}
+
+// fetchProxyUsage returns the last CPU (cores) and memory (bytes) usage of the istio-proxy container of the pods, by pod name
+func (in *WorkloadService) fetchProxyUsage(criteria WorkloadCriteria, podNames []string) (map[string]float64, map[string]float64) {
+	queryTime := criteria.QueryTime
+	if queryTime.IsZero() {
+		queryTime = time.Now()
+	}
+	rateInterval := criteria.RateInterval
+	if rateInterval == "" {
+		rateInterval = in.config.Server.MetricsQueryDefaults.RateInterval
+	}
+	q := prometheus.RangeQuery{
+		Range:        prom_v1.Range{Start: queryTime, End: queryTime, Step: time.Minute},
+		RateInterval: rateInterval,
+		RateFunc:     "rate",
+	}
+
+	quotedNames := make([]string, 0, len(podNames))
+	for _, name := range podNames {
+		quotedNames = append(quotedNames, regexp.QuoteMeta(name))
+	}
+	promLabels := fmt.Sprintf(`{namespace="%s",pod=~"%s",container="%s"}`, criteria.Namespace, strings.Join(quotedNames, "|"), proxyContainerName)
+
+	cpu := in.prom.FetchRateRange("container_cpu_usage_seconds_total", []string{promLabels}, "pod", &q)
+	memory := in.prom.FetchRange("container_memory_working_set_bytes", promLabels, "pod", "sum", &q)
+	return lastValuesByPod(cpu, criteria.Namespace), lastValuesByPod(memory, criteria.Namespace)
+}
+
+func lastValuesByPod(metric prometheus.Metric, namespace string) map[string]float64 {
+	values := map[string]float64{}
+	if metric.Err != nil {
+		log.Debugf("Proxy usage of the pods of namespace [%s] is not available: %s", namespace, metric.Err)
+		return values
+	}
+	for _, series := range metric.Matrix {
+		if len(series.Values) == 0 {
+			continue
+		}
+		value := float64(series.Values[len(series.Values)-1].Value)
+		if math.IsNaN(value) {
+			continue
+		}
+		values[string(series.Metric["pod"])] = value
+	}
+	return values
+}
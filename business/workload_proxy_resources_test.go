@@ -0,0 +1,146 @@
+package business
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pmodel "github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus"
+	"github.com/kiali/kiali/prometheus/prometheustest"
+)
+
+func fakeProxyResourcesPod(name string) *core_v1.Pod {
+	pod := fakeInjectionPod(name, "docker.io/istio/proxyv2:1.18.0", nil, nil)
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == "istio-proxy" {
+			pod.Spec.Containers[i].Resources = core_v1.ResourceRequirements{
+				Requests: core_v1.ResourceList{
+					core_v1.ResourceCPU:    resource.MustParse("100m"),
+					core_v1.ResourceMemory: resource.MustParse("128Mi"),
+				},
+				Limits: core_v1.ResourceList{
+					core_v1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			}
+		}
+	}
+	// The resources of the application container are not the ones of the proxy
+	pod.Spec.Containers[0].Resources.Requests = core_v1.ResourceList{core_v1.ResourceCPU: resource.MustParse("2")}
+	return pod
+}
+
+func setupProxyResourcesWorkloadService(t *testing.T, prom prometheus.ClientInterface) (WorkloadService, *config.Config) {
+	conf := config.NewConfig()
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	config.Set(conf)
+
+	kubeObjs := []runtime.Object{
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		fakeProxyResourcesPod("reviews-v1-proxy"),
+		fakeInjectionPod("reviews-v1-no-proxy", "", nil, nil),
+	}
+	kubeObjs = append(kubeObjs, fakeReviewsV1Controllers()...)
+	k8s := kubetest.NewFakeK8sClient(kubeObjs...)
+	SetupBusinessLayer(t, k8s, *conf)
+	t.Cleanup(func() { kialiCache = nil })
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	return NewWithBackends(clients, clients, prom, nil).Workload, conf
+}
+
+func workloadPod(workload *models.Workload, name string) *models.Pod {
+	for _, pod := range workload.Pods {
+		if pod.Name == name {
+			return pod
+		}
+	}
+	return nil
+}
+
+func TestGetWorkloadProxyResources(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	prom := new(prometheustest.PromClientMock)
+	prom.On("FetchRateRange", "container_cpu_usage_seconds_total", []string{`{namespace="bookinfo",pod=~"reviews-v1-proxy",container="istio-proxy"}`}, "pod", mock.Anything).
+		Return(prometheus.Metric{Matrix: pmodel.Matrix{{
+			Metric: pmodel.Metric{"pod": "reviews-v1-proxy"},
+			Values: []pmodel.SamplePair{{Timestamp: 0, Value: 0.01}, {Timestamp: 60000, Value: 0.02}},
+		}}})
+	// The memory usage is not available, it is left unset
+	prom.On("FetchRange", "container_memory_working_set_bytes", `{namespace="bookinfo",pod=~"reviews-v1-proxy",container="istio-proxy"}`, "pod", "sum", mock.Anything).
+		Return(prometheus.Metric{Err: errors.New("unavailable")})
+	svc, conf := setupProxyResourcesWorkloadService(t, prom)
+
+	criteria := WorkloadCriteria{Cluster: conf.KubernetesConfig.ClusterName, Namespace: "bookinfo", WorkloadName: "reviews-v1"}
+	workload, err := svc.GetWorkload(context.TODO(), criteria)
+	require.NoError(err)
+	require.Len(workload.Pods, 2)
+	for _, pod := range workload.Pods {
+		assert.Nil(pod.ProxyResources)
+	}
+
+	criteria.IncludeProxyResources = true
+	workload, err = svc.GetWorkload(context.TODO(), criteria)
+	require.NoError(err)
+	require.Len(workload.Pods, 2)
+
+	cpuUsage := 0.02
+	assert.Equal(&models.ProxyResources{
+		CPURequest:    "100m",
+		MemoryRequest: "128Mi",
+		MemoryLimit:   "1Gi",
+		CPUUsage:      &cpuUsage,
+	}, workloadPod(workload, "reviews-v1-proxy").ProxyResources)
+	assert.Nil(workloadPod(workload, "reviews-v1-no-proxy").ProxyResources)
+	prom.AssertExpectations(t)
+}
+
+func TestGetWorkloadProxyResourcesWithoutPrometheus(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	svc, conf := setupProxyResourcesWorkloadService(t, nil)
+
+	criteria := WorkloadCriteria{Cluster: conf.KubernetesConfig.ClusterName, Namespace: "bookinfo", WorkloadName: "reviews-v1", IncludeProxyResources: true}
+	workload, err := svc.GetWorkload(context.TODO(), criteria)
+	require.NoError(err)
+	require.Len(workload.Pods, 2)
+
+	assert.Equal(&models.ProxyResources{
+		CPURequest:    "100m",
+		MemoryRequest: "128Mi",
+		MemoryLimit:   "1Gi",
+	}, workloadPod(workload, "reviews-v1-proxy").ProxyResources)
+	assert.Nil(workloadPod(workload, "reviews-v1-no-proxy").ProxyResources)
+}
+
+func TestProxyContainerNativeSidecar(t *testing.T) {
+	assert := assert.New(t)
+
+	pod := &core_v1.Pod{Spec: core_v1.PodSpec{
+		InitContainers: []core_v1.Container{{
+			Name:      "istio-proxy",
+			Resources: core_v1.ResourceRequirements{Limits: core_v1.ResourceList{core_v1.ResourceCPU: resource.MustParse("2")}},
+		}},
+		Containers: []core_v1.Container{{Name: "reviews"}},
+	}}
+	container := proxyContainer(pod)
+	assert.NotNil(container)
+	assert.Equal(&models.ProxyResources{CPULimit: "2"}, parseProxyResources(container))
+
+	assert.Nil(proxyContainer(&core_v1.Pod{Spec: core_v1.PodSpec{Containers: []core_v1.Container{{Name: "reviews"}}}}))
+}
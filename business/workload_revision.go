@@ -0,0 +1,121 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// defaultRevision is the revision tag used by the namespaces and the Pods without a revision
+const defaultRevision = "default"
+
+// revisionTagLabel labels the injection webhooks of the revision tags with the name of the tag
+const revisionTagLabel = "istio.io/tag"
+
+// revisionTags returns the revision each revision tag of a cluster points to, by tag. The tags are the injection
+// webhooks labeled with the tag and the revision, like the ones created by "istioctl tag set".
+// The tags are not resolved when the webhooks can't be read, the caller then compares the tags as revisions.
+func revisionTags(client kubernetes.ClientInterface, conf *config.Config) map[string]string {
+	tags := map[string]string{}
+	if client == nil {
+		return tags
+	}
+	webhooks, err := client.GetMutatingWebhookConfigurations(revisionTagLabel)
+	if err != nil {
+		if errors.IsForbidden(err) {
+			log.Debugf("Cannot read the revision tags: %v", err)
+		} else {
+			log.Warningf("Cannot read the revision tags: %v", err)
+		}
+		return tags
+	}
+	for _, webhook := range webhooks {
+		if revision := webhook.Labels[conf.IstioLabels.InjectionLabelRev]; revision != "" {
+			tags[webhook.Labels[revisionTagLabel]] = revision
+		}
+	}
+	return tags
+}
+
+// resolveRevision returns the revision a revision tag points to, the revision itself when it isn't a tag
+func resolveRevision(tags map[string]string, revision string) string {
+	if tagRevision, ok := tags[revision]; ok {
+		return tagRevision
+	}
+	return revision
+}
+
+// GetSidecarRevisions resolves which control plane revision injected the proxy of each Pod of a workload,
+// and whether it matches the revision configured in the namespace, to debug canary control plane upgrades.
+// The revision of a Pod is taken from its injection status annotation (sidecar.istio.io/status), written by the
+// injector, falling back to the revision label (istio.io/rev).
+// A namespace without revision label is injected by the default revision tag. The revision tags are resolved to the
+// revisions they point to before comparing, as the injection status of the Pods holds the revision.
+func (in *WorkloadService) GetSidecarRevisions(ctx context.Context, cluster, namespace, workload string) (*models.WorkloadRevision, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetSidecarRevisions",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("workload", workload),
+	)
+	defer end()
+
+	ns, err := in.businessLayer.Namespace.GetNamespaceByCluster(ctx, namespace, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	wk, err := in.fetchWorkload(ctx, WorkloadCriteria{Cluster: cluster, Namespace: namespace, WorkloadName: workload, WorkloadType: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	tags := revisionTags(in.businessLayer.Namespace.kialiSAClients[cluster], in.config)
+	namespaceRevision := ns.Labels[in.config.IstioLabels.InjectionLabelRev]
+	if namespaceRevision == "" {
+		namespaceRevision = defaultRevision
+	}
+	namespaceRevision = resolveRevision(tags, namespaceRevision)
+
+	workloadRevision := &models.WorkloadRevision{
+		Workload:          wk.Name,
+		Namespace:         namespace,
+		NamespaceRevision: namespaceRevision,
+		Pods:              []models.PodRevision{},
+	}
+	for _, pod := range wk.Pods {
+		podRevision := models.PodRevision{Name: pod.Name}
+		if pod.HasIstioSidecar() {
+			podRevision.Revision = resolveRevision(tags, in.podRevision(pod))
+			revisionMatch := podRevision.Revision == namespaceRevision
+			podRevision.RevisionMatch = &revisionMatch
+		}
+		workloadRevision.Pods = append(workloadRevision.Pods, podRevision)
+	}
+
+	return workloadRevision, nil
+}
+
+// podRevision returns the revision that injected the proxy of a Pod, the injection status annotation overrides the label
+func (in *WorkloadService) podRevision(pod *models.Pod) string {
+	if sidecarStatus, ok := pod.Annotations[in.config.ExternalServices.Istio.IstioSidecarAnnotation]; ok {
+		var injection struct {
+			Revision string `json:"revision"`
+		}
+		if err := json.Unmarshal([]byte(sidecarStatus), &injection); err == nil && injection.Revision != "" {
+			return injection.Revision
+		}
+	}
+	if revision := pod.Labels[in.config.IstioLabels.InjectionLabelRev]; revision != "" {
+		return revision
+	}
+	return defaultRevision
+}
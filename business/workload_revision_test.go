@@ -0,0 +1,158 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admission_v1 "k8s.io/api/admissionregistration/v1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+func fakeRevisionPod(name, revisionLabel, statusRevision string) *core_v1.Pod {
+	pod := fakeInjectionPod(name, "docker.io/istio/proxyv2:1.18.0", nil, nil)
+	if revisionLabel != "" {
+		pod.Labels["istio.io/rev"] = revisionLabel
+	}
+	if statusRevision != "" {
+		pod.Annotations["sidecar.istio.io/status"] = `{"initContainers":["istio-init"],"containers":["istio-proxy"],"revision":"` + statusRevision + `"}`
+	}
+	return pod
+}
+
+func podRevision(workloadRevision *models.WorkloadRevision, name string) *models.PodRevision {
+	for i := range workloadRevision.Pods {
+		if workloadRevision.Pods[i].Name == name {
+			return &workloadRevision.Pods[i]
+		}
+	}
+	return nil
+}
+
+func TestGetSidecarRevisions(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	kubeObjs := []runtime.Object{
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo", Labels: map[string]string{"istio.io/rev": "canary"}}},
+		fakeRevisionPod("reviews-v1-canary", "canary", "canary"),
+		// The injection status written by the injector wins over the label
+		fakeRevisionPod("reviews-v1-stable", "canary", "stable"),
+		fakeRevisionPod("reviews-v1-label", "canary", ""),
+		fakeRevisionPod("reviews-v1-default", "", ""),
+		fakeInjectionPod("reviews-v1-no-proxy", "", nil, nil),
+	}
+	kubeObjs = append(kubeObjs, fakeReviewsV1Controllers()...)
+	k8s := kubetest.NewFakeK8sClient(kubeObjs...)
+	SetupBusinessLayer(t, k8s, *conf)
+	svc := setupWorkloadService(k8s, conf)
+
+	workloadRevision, err := svc.GetSidecarRevisions(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "reviews-v1")
+	require.NoError(err)
+
+	assert.Equal("reviews-v1", workloadRevision.Workload)
+	assert.Equal("canary", workloadRevision.NamespaceRevision)
+	require.Len(workloadRevision.Pods, 5)
+
+	expected := map[string]string{
+		"reviews-v1-canary":  "canary",
+		"reviews-v1-stable":  "stable",
+		"reviews-v1-label":   "canary",
+		"reviews-v1-default": "default",
+	}
+	for name, revision := range expected {
+		pod := podRevision(workloadRevision, name)
+		require.NotNil(pod, name)
+		assert.Equal(revision, pod.Revision, name)
+		require.NotNil(pod.RevisionMatch, name)
+		assert.Equal(revision == "canary", *pod.RevisionMatch, name)
+	}
+
+	noProxy := podRevision(workloadRevision, "reviews-v1-no-proxy")
+	require.NotNil(noProxy)
+	assert.Empty(noProxy.Revision)
+	assert.Nil(noProxy.RevisionMatch)
+}
+
+func TestGetSidecarRevisionsDefaultTag(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	kubeObjs := []runtime.Object{
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo", Labels: map[string]string{"istio-injection": "enabled"}}},
+		fakeRevisionPod("reviews-v1-default", "default", ""),
+		fakeRevisionPod("reviews-v1-canary", "canary", "canary"),
+	}
+	kubeObjs = append(kubeObjs, fakeReviewsV1Controllers()...)
+	k8s := kubetest.NewFakeK8sClient(kubeObjs...)
+	SetupBusinessLayer(t, k8s, *conf)
+	svc := setupWorkloadService(k8s, conf)
+
+	workloadRevision, err := svc.GetSidecarRevisions(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "reviews-v1")
+	require.NoError(err)
+	assert.Equal("default", workloadRevision.NamespaceRevision)
+
+	defaultPod := podRevision(workloadRevision, "reviews-v1-default")
+	require.NotNil(defaultPod)
+	require.NotNil(defaultPod.RevisionMatch)
+	assert.True(*defaultPod.RevisionMatch)
+
+	// Injected by a revision the namespace is not configured with
+	canaryPod := podRevision(workloadRevision, "reviews-v1-canary")
+	require.NotNil(canaryPod)
+	require.NotNil(canaryPod.RevisionMatch)
+	assert.False(*canaryPod.RevisionMatch)
+}
+
+func fakeRevisionTagWebhook(tag, revision string) *admission_v1.MutatingWebhookConfiguration {
+	return &admission_v1.MutatingWebhookConfiguration{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:   "istio-revision-tag-" + tag,
+			Labels: map[string]string{"istio.io/tag": tag, "istio.io/rev": revision},
+		},
+	}
+}
+
+func TestGetSidecarRevisionsResolvesTags(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	kubeObjs := []runtime.Object{
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		fakeRevisionTagWebhook("default", "1-18-0"),
+		fakeRevisionTagWebhook("canary", "1-19-0"),
+		// Injected through the default tag, the injection status holds the revision
+		fakeRevisionPod("reviews-v1-stable", "", "1-18-0"),
+		// Only labeled with the canary tag
+		fakeRevisionPod("reviews-v1-canary", "canary", ""),
+	}
+	kubeObjs = append(kubeObjs, fakeReviewsV1Controllers()...)
+	k8s := kubetest.NewFakeK8sClient(kubeObjs...)
+	SetupBusinessLayer(t, k8s, *conf)
+	svc := setupWorkloadService(k8s, conf)
+
+	workloadRevision, err := svc.GetSidecarRevisions(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "reviews-v1")
+	require.NoError(err)
+	assert.Equal("1-18-0", workloadRevision.NamespaceRevision)
+
+	stablePod := podRevision(workloadRevision, "reviews-v1-stable")
+	require.NotNil(stablePod)
+	require.NotNil(stablePod.RevisionMatch)
+	assert.True(*stablePod.RevisionMatch)
+
+	canaryPod := podRevision(workloadRevision, "reviews-v1-canary")
+	require.NotNil(canaryPod)
+	assert.Equal("1-19-0", canaryPod.Revision)
+	require.NotNil(canaryPod.RevisionMatch)
+	assert.False(*canaryPod.RevisionMatch)
+}
@@ -0,0 +1,153 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+
+	"github.com/kiali/kiali/business/checkers"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// GetWorkloadServiceAccounts returns the ServiceAccounts the pods of a workload run as, with the source principals of the
+// AuthorizationPolicies of the cluster matching their identity, "cluster.local/ns/<namespace>/sa/<name>" with the
+// default identity domain. The principals are matched as Istio does: exact, prefix "abc*", suffix "*abc" and presence "*".
+// A source whose notPrincipals also match the identity doesn't reference it.
+func (in *WorkloadService) GetWorkloadServiceAccounts(ctx context.Context, cluster, namespace, workload string) ([]models.WorkloadServiceAccount, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetWorkloadServiceAccounts",
+		observability.Attribute("package", "business"),
+		observability.Attribute("cluster", cluster),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("workload", workload),
+	)
+	defer end()
+
+	wk, err := in.fetchWorkload(ctx, WorkloadCriteria{Cluster: cluster, Namespace: namespace, WorkloadName: workload, WorkloadType: ""})
+	if err != nil {
+		return nil, err
+	}
+	return in.getServiceAccounts(ctx, cluster, namespace, wk)
+}
+
+// setServiceAccounts sets the ServiceAccounts of the workload with the AuthorizationPolicies referencing them
+func (in *WorkloadService) setServiceAccounts(ctx context.Context, criteria WorkloadCriteria, workload *models.Workload) error {
+	serviceAccounts, err := in.getServiceAccounts(ctx, criteria.Cluster, criteria.Namespace, workload)
+	if err != nil {
+		return err
+	}
+	workload.ServiceAccounts = serviceAccounts
+	return nil
+}
+
+func (in *WorkloadService) getServiceAccounts(ctx context.Context, cluster, namespace string, workload *models.Workload) ([]models.WorkloadServiceAccount, error) {
+	names := []string{}
+	for _, name := range workload.Pods.ServiceAccounts() {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	workload.ServiceAccountNames = names
+
+	serviceAccounts := make([]models.WorkloadServiceAccount, 0, len(names))
+	if len(names) == 0 {
+		return serviceAccounts, nil
+	}
+
+	// Any namespace can have policies on the requests of the workload, the ServiceAccounts are still returned when
+	// they can't be fetched
+	authorizationPolicies, err := in.getAccessibleAuthorizationPolicies(ctx, cluster)
+	if err != nil {
+		log.Errorf("Error fetching the AuthorizationPolicies referencing the ServiceAccounts of workload [%s] in namespace [%s]: %s", workload.Name, namespace, err)
+	}
+	sort.Slice(authorizationPolicies, func(i, j int) bool {
+		if authorizationPolicies[i].Namespace != authorizationPolicies[j].Namespace {
+			return authorizationPolicies[i].Namespace < authorizationPolicies[j].Namespace
+		}
+		return authorizationPolicies[i].Name < authorizationPolicies[j].Name
+	})
+
+	// Same trust domain as the one the principals are validated with
+	trustDomain := strings.Replace(in.config.ExternalServices.Istio.IstioIdentityDomain, "svc.", "", 1)
+	for _, name := range names {
+		principal := fmt.Sprintf("%s/ns/%s/sa/%s", trustDomain, namespace, name)
+		serviceAccount := models.WorkloadServiceAccount{Name: name, Principal: principal, AuthorizationPolicies: []models.AuthorizationPrincipalMatch{}}
+		for _, ap := range authorizationPolicies {
+			serviceAccount.AuthorizationPolicies = append(serviceAccount.AuthorizationPolicies, authorizationPrincipalMatches(cluster, ap, principal)...)
+		}
+		serviceAccounts = append(serviceAccounts, serviceAccount)
+	}
+	return serviceAccounts, nil
+}
+
+// getAccessibleAuthorizationPolicies returns the AuthorizationPolicies of the cluster in the namespaces accessible to the user.
+// The list of all the namespaces is shared, a new list is returned.
+func (in *WorkloadService) getAccessibleAuthorizationPolicies(ctx context.Context, cluster string) ([]*security_v1beta1.AuthorizationPolicy, error) {
+	authorizationPolicies := []*security_v1beta1.AuthorizationPolicy{}
+	namespaces, err := in.businessLayer.Namespace.GetNamespacesForCluster(ctx, cluster)
+	if err != nil {
+		return authorizationPolicies, err
+	}
+	istioConfigList, err := in.businessLayer.IstioConfig.GetIstioConfigList(ctx, IstioConfigCriteria{
+		AllNamespaces:                true,
+		Cluster:                      cluster,
+		IncludeAuthorizationPolicies: true,
+	})
+	if err != nil {
+		return authorizationPolicies, err
+	}
+
+	accessible := make(map[string]bool, len(namespaces))
+	for _, ns := range namespaces {
+		accessible[ns.Name] = true
+	}
+	for _, ap := range istioConfigList.AuthorizationPolicies {
+		if accessible[ap.Namespace] {
+			authorizationPolicies = append(authorizationPolicies, ap)
+		}
+	}
+	return authorizationPolicies, nil
+}
+
+// authorizationPrincipalMatches returns the source principals of an AuthorizationPolicy matching a principal
+func authorizationPrincipalMatches(cluster string, ap *security_v1beta1.AuthorizationPolicy, principal string) []models.AuthorizationPrincipalMatch {
+	matches := []models.AuthorizationPrincipalMatch{}
+	for ruleIdx, rule := range ap.Spec.Rules {
+		if rule == nil {
+			continue
+		}
+		for fromIdx, from := range rule.From {
+			if from == nil || from.Source == nil {
+				continue
+			}
+			excluded := false
+			for _, notPrincipal := range from.Source.NotPrincipals {
+				if matchAuthorizationString(notPrincipal, principal) {
+					excluded = true
+					break
+				}
+			}
+			if excluded {
+				continue
+			}
+			for i, p := range from.Source.Principals {
+				if !matchAuthorizationString(p, principal) {
+					continue
+				}
+				matches = append(matches, models.AuthorizationPrincipalMatch{
+					AuthorizationPolicy: models.IstioValidationKey{ObjectType: checkers.AuthorizationPolicyCheckerType, Name: ap.Name, Namespace: ap.Namespace, Cluster: cluster},
+					Action:              ap.Spec.Action.String(),
+					Principal:           p,
+					Path:                fmt.Sprintf("spec/rules[%d]/from[%d]/source/principals[%d]", ruleIdx, fromIdx, i),
+				})
+			}
+		}
+	}
+	return matches
+}
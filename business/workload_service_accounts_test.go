@@ -0,0 +1,140 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_security_v1beta1 "istio.io/api/security/v1beta1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func setupServiceAccountsWorkloadService(t *testing.T, objects ...runtime.Object) (WorkloadService, string) {
+	conf := config.NewConfig()
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	config.Set(conf)
+
+	objects = append(objects,
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "travels"}},
+	)
+	objects = append(objects, fakeReviewsV1Controllers()...)
+	k8s := kubetest.NewFakeK8sClient(objects...)
+	SetupBusinessLayer(t, k8s, *conf)
+	t.Cleanup(func() { kialiCache = nil })
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	return NewWithBackends(clients, clients, nil, nil).Workload, conf.KubernetesConfig.ClusterName
+}
+
+func fakeServiceAccountPod(name, serviceAccount string) *core_v1.Pod {
+	pod := fakeInjectionPod(name, "docker.io/istio/proxyv2:1.18.0", nil, nil)
+	pod.Spec.ServiceAccountName = serviceAccount
+	return pod
+}
+
+func TestGetWorkloadServiceAccounts(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	denyPolicy := data.CreateAuthorizationPolicyWithPrincipals("deny-bookinfo", "travels", []string{"cluster.local/ns/travels/sa/travels", "cluster.local/ns/bookinfo/*"})
+	denyPolicy.Spec.Action = api_security_v1beta1.AuthorizationPolicy_DENY
+	// The reviews identity is excluded from the source
+	excludingPolicy := data.CreateAuthorizationPolicyWithPrincipals("all-but-reviews", "bookinfo", []string{"*"})
+	excludingPolicy.Spec.Rules[0].From[0].Source.NotPrincipals = []string{"cluster.local/ns/bookinfo/sa/bookinfo-reviews"}
+
+	svc, cluster := setupServiceAccountsWorkloadService(t,
+		fakeServiceAccountPod("reviews-v1-1", "bookinfo-reviews"),
+		fakeServiceAccountPod("reviews-v1-2", "bookinfo-reviews"),
+		data.CreateAuthorizationPolicyWithPrincipals("allow-reviews", "bookinfo", []string{"cluster.local/ns/bookinfo/sa/bookinfo-reviews"}),
+		data.CreateAuthorizationPolicyWithPrincipals("allow-ratings", "bookinfo", []string{"cluster.local/ns/bookinfo/sa/bookinfo-ratings"}),
+		denyPolicy,
+		excludingPolicy,
+		// The policies of the namespaces the user can't access are not shown
+		data.CreateAuthorizationPolicyWithPrincipals("allow-reviews", "hidden", []string{"cluster.local/ns/bookinfo/sa/bookinfo-reviews"}),
+	)
+
+	serviceAccounts, err := svc.GetWorkloadServiceAccounts(context.TODO(), cluster, "bookinfo", "reviews-v1")
+	require.NoError(err)
+	assert.Equal([]models.WorkloadServiceAccount{{
+		Name:      "bookinfo-reviews",
+		Principal: "cluster.local/ns/bookinfo/sa/bookinfo-reviews",
+		AuthorizationPolicies: []models.AuthorizationPrincipalMatch{
+			{
+				AuthorizationPolicy: models.IstioValidationKey{ObjectType: "authorizationpolicy", Name: "allow-reviews", Namespace: "bookinfo", Cluster: cluster},
+				Action:              "ALLOW",
+				Principal:           "cluster.local/ns/bookinfo/sa/bookinfo-reviews",
+				Path:                "spec/rules[0]/from[0]/source/principals[0]",
+			},
+			{
+				AuthorizationPolicy: models.IstioValidationKey{ObjectType: "authorizationpolicy", Name: "deny-bookinfo", Namespace: "travels", Cluster: cluster},
+				Action:              "DENY",
+				Principal:           "cluster.local/ns/bookinfo/*",
+				Path:                "spec/rules[0]/from[0]/source/principals[1]",
+			},
+		},
+	}}, serviceAccounts)
+
+	// Attached to the workload details
+	workload, err := svc.GetWorkload(context.TODO(), WorkloadCriteria{Cluster: cluster, Namespace: "bookinfo", WorkloadName: "reviews-v1", IncludeServiceAccounts: true})
+	require.NoError(err)
+	assert.Equal([]string{"bookinfo-reviews"}, workload.ServiceAccountNames)
+	assert.Equal(serviceAccounts, workload.ServiceAccounts)
+}
+
+func TestGetWorkloadServiceAccountsWithoutPolicies(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	svc, cluster := setupServiceAccountsWorkloadService(t,
+		fakeServiceAccountPod("reviews-v1-1", "bookinfo-reviews"),
+		fakeServiceAccountPod("reviews-v1-2", "default"),
+	)
+
+	serviceAccounts, err := svc.GetWorkloadServiceAccounts(context.TODO(), cluster, "bookinfo", "reviews-v1")
+	require.NoError(err)
+	require.Len(serviceAccounts, 2)
+	assert.Equal("bookinfo-reviews", serviceAccounts[0].Name)
+	assert.Empty(serviceAccounts[0].AuthorizationPolicies)
+	assert.Equal("default", serviceAccounts[1].Name)
+	assert.Equal("cluster.local/ns/bookinfo/sa/default", serviceAccounts[1].Principal)
+	assert.Empty(serviceAccounts[1].AuthorizationPolicies)
+}
+
+func TestGetWorkloadServiceAccountsPoliciesError(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	config.Set(conf)
+
+	objects := []runtime.Object{
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		fakeServiceAccountPod("reviews-v1-1", "bookinfo-reviews"),
+		data.CreateAuthorizationPolicyWithPrincipals("allow-reviews", "bookinfo", []string{"cluster.local/ns/bookinfo/sa/bookinfo-reviews"}),
+	}
+	k8s := kubetest.NewFakeK8sClient(append(objects, fakeReviewsV1Controllers()...)...)
+	cache := SetupBusinessLayer(t, k8s, *conf)
+	t.Cleanup(func() { kialiCache = nil })
+	// The AuthorizationPolicies of all the namespaces are read from the Istio registry, which can't be fetched
+	cache.RefreshRegistryStatus()
+
+	clients := map[string]kubernetes.ClientInterface{conf.KubernetesConfig.ClusterName: k8s}
+	svc := NewWithBackends(clients, clients, nil, nil).Workload
+	serviceAccounts, err := svc.GetWorkloadServiceAccounts(context.TODO(), conf.KubernetesConfig.ClusterName, "bookinfo", "reviews-v1")
+	require.NoError(err)
+	require.Len(serviceAccounts, 1)
+	assert.Equal("cluster.local/ns/bookinfo/sa/bookinfo-reviews", serviceAccounts[0].Principal)
+	assert.Empty(serviceAccounts[0].AuthorizationPolicies)
+}
@@ -64,8 +64,16 @@ type WorkloadCriteria struct {
 	IncludeIstioResources bool
 	IncludeServices       bool
 	IncludeHealth         bool
-	RateInterval          string
-	QueryTime             time.Time
+	// IncludeOwnerChain sets on the pods the chain of the controllers owning them, including the types not modeled by Kiali
+	IncludeOwnerChain bool
+	// IncludeProxyResources sets on the pods the resources of their istio-proxy container, with its usage when Prometheus is available
+	IncludeProxyResources bool
+	// IncludeAutoscaler sets the status of the HorizontalPodAutoscaler targeting the workload
+	IncludeAutoscaler bool
+	// IncludeServiceAccounts sets the ServiceAccounts of the pods with the AuthorizationPolicies referencing them
+	IncludeServiceAccounts bool
+	RateInterval           string
+	QueryTime              time.Time
 }
 
 // PodLog reports log entries
@@ -212,7 +220,7 @@ func (in *WorkloadService) GetWorkloadList(ctx context.Context, criteria Workloa
 			wItem.IstioReferences = FilterUniqueIstioReferences(FilterWorkloadReferences(wSelector, istioConfigList))
 		}
 		if criteria.IncludeHealth {
-			wItem.Health, err = in.businessLayer.Health.GetWorkloadHealth(ctx, criteria.Namespace, w.Cluster, wItem.Name, criteria.RateInterval, criteria.QueryTime, w)
+			wItem.Health, err = in.businessLayer.Health.GetWorkloadHealth(ctx, criteria.Namespace, w.Cluster, wItem.Name, criteria.RateInterval, criteria.QueryTime, w, false)
 			if err != nil {
 				log.Errorf("Error fetching Health in namespace %s for workload %s: %s", criteria.Namespace, wItem.Name, err)
 			}
@@ -349,6 +357,32 @@ func (in *WorkloadService) GetWorkload(ctx context.Context, criteria WorkloadCri
 		return nil, err2
 	}
 
+	if criteria.IncludeOwnerChain {
+		in.setOwnerChains(criteria.Cluster, criteria.Namespace, workload)
+	}
+
+	if criteria.IncludeProxyResources {
+		if err := in.setProxyResources(ctx, criteria, workload); err != nil {
+			return nil, err
+		}
+	}
+
+	if criteria.IncludeIstioResources {
+		if err := in.setIngressGateway(ctx, criteria, workload); err != nil {
+			return nil, err
+		}
+	}
+
+	if criteria.IncludeAutoscaler {
+		in.setAutoscaler(criteria, workload)
+	}
+
+	if criteria.IncludeServiceAccounts {
+		if err := in.setServiceAccounts(ctx, criteria, workload); err != nil {
+			return nil, err
+		}
+	}
+
 	var runtimes []models.Runtime
 	wg := sync.WaitGroup{}
 	wg.Add(1)
@@ -633,9 +667,11 @@ func (in *WorkloadService) fetchWorkloadsFromCluster(ctx context.Context, cluste
 
 	// Check if user has access to the namespace (RBAC) in cache scenarios and/or
 	// if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
-	if _, err := in.businessLayer.Namespace.GetNamespaceByCluster(ctx, namespace, cluster); err != nil {
+	ns, err := in.businessLayer.Namespace.GetNamespaceByCluster(ctx, namespace, cluster)
+	if err != nil {
 		return nil, err
 	}
+	istioLabels := ns.GetIstioLabels(config.Get().IstioLabels)
 
 	userClient, ok := in.userClients[cluster]
 	if !ok {
@@ -1164,6 +1200,7 @@ func (in *WorkloadService) fetchWorkloadsFromCluster(ctx context.Context, cluste
 		}
 
 		if cnFound {
+			w.SetIstioLabels(istioLabels)
 			ws = append(ws, w)
 		}
 	}
@@ -1194,9 +1231,11 @@ func (in *WorkloadService) fetchWorkload(ctx context.Context, criteria WorkloadC
 
 	// Check if user has access to the namespace (RBAC) in cache scenarios and/or
 	// if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
-	if _, err := in.businessLayer.Namespace.GetNamespaceByCluster(ctx, criteria.Namespace, criteria.Cluster); err != nil {
+	ns, err := in.businessLayer.Namespace.GetNamespaceByCluster(ctx, criteria.Namespace, criteria.Cluster)
+	if err != nil {
 		return nil, err
 	}
+	istioLabels := ns.GetIstioLabels(config.Get().IstioLabels)
 
 	// Flag used for custom controllers
 	// i.e. a third party framework creates its own "Deployment" controller with extra features
@@ -1753,6 +1792,7 @@ func (in *WorkloadService) fetchWorkload(ctx context.Context, criteria WorkloadC
 		}
 
 		if cnFound {
+			w.SetIstioLabels(istioLabels)
 			return &w, nil
 		}
 	}
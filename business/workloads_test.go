@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	apps_v1 "k8s.io/api/apps/v1"
+	batch_v1 "k8s.io/api/batch/v1"
 	core_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -89,6 +90,64 @@ func TestGetWorkloadListFromDeployments(t *testing.T) {
 	assert.Equal("Deployment", workloads[2].Type)
 }
 
+func TestGetWorkloadListExcludedJobs(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	controller := true
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "Namespace"}},
+		&osproject_v1.Project{ObjectMeta: v1.ObjectMeta{Name: "Namespace"}},
+		fakeComponentDeployment("httpbin-v1", map[string]string{"app": "httpbin", "version": "v1"}),
+		&batch_v1.Job{
+			TypeMeta:   v1.TypeMeta{Kind: "Job"},
+			ObjectMeta: v1.ObjectMeta{Name: "httpbin-migration", Namespace: "Namespace"},
+			Spec: batch_v1.JobSpec{
+				Template: core_v1.PodTemplateSpec{ObjectMeta: v1.ObjectMeta{Labels: map[string]string{"app": "httpbin", "version": "migration"}}},
+			},
+		},
+		&core_v1.Pod{
+			ObjectMeta: v1.ObjectMeta{
+				Name:            "httpbin-migration-x7k2p",
+				Namespace:       "Namespace",
+				Labels:          map[string]string{"app": "httpbin", "version": "migration"},
+				OwnerReferences: []v1.OwnerReference{{Kind: "Job", Name: "httpbin-migration", Controller: &controller}},
+			},
+		},
+	)
+	k8s.OpenShift = true
+	SetupBusinessLayer(t, k8s, *conf)
+	svc := setupWorkloadService(k8s, conf)
+	t.Cleanup(func() { excludedWorkloads = map[string]bool{} })
+
+	criteria := WorkloadCriteria{Namespace: "Namespace", IncludeIstioResources: false, IncludeHealth: false, Cluster: conf.KubernetesConfig.ClusterName}
+
+	// Every workload type is scanned by default
+	require.Empty(conf.KubernetesConfig.ExcludeWorkloads)
+	excludedWorkloads = map[string]bool{}
+	workloadList, err := svc.GetWorkloadList(context.TODO(), criteria)
+	require.NoError(err)
+	require.Len(workloadList.Workloads, 2)
+	assert.ElementsMatch([]string{"Deployment", "Job"}, []string{workloadList.Workloads[0].Type, workloadList.Workloads[1].Type})
+
+	// Neither the Jobs nor their pods are scanned when the Jobs are excluded
+	excludedWorkloads = map[string]bool{kubernetes.JobType: true, kubernetes.CronJobType: true}
+	workloadList, err = svc.GetWorkloadList(context.TODO(), criteria)
+	require.NoError(err)
+	require.Len(workloadList.Workloads, 1)
+	assert.Equal("httpbin-v1", workloadList.Workloads[0].Name)
+	assert.Equal("Deployment", workloadList.Workloads[0].Type)
+
+	// The included workloads are still grouped by app
+	appList, err := svc.businessLayer.App.GetAppList(context.TODO(), AppCriteria{Namespace: "Namespace", Cluster: conf.KubernetesConfig.ClusterName})
+	require.NoError(err)
+	require.Len(appList.Apps, 1)
+	assert.Equal("httpbin", appList.Apps[0].Name)
+}
+
 func TestGetWorkloadListFromReplicaSets(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
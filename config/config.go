@@ -4,10 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"regexp"
 	"sort"
 	"strings"
 	"sync"
 
+	"github.com/prometheus/common/model"
 	"gopkg.in/yaml.v2"
 
 	"github.com/kiali/kiali/config/dashboards"
@@ -106,18 +108,43 @@ type Observability struct {
 
 // Server configuration
 type Server struct {
-	Address                    string        `yaml:",omitempty"`
-	AuditLog                   bool          `yaml:"audit_log,omitempty"` // When true, allows additional audit logging on Write operations
-	CORSAllowAll               bool          `yaml:"cors_allow_all,omitempty"`
-	GzipEnabled                bool          `yaml:"gzip_enabled,omitempty"`
-	Observability              Observability `yaml:"observability,omitempty"`
-	Port                       int           `yaml:",omitempty"`
-	StaticContentRootDirectory string        `yaml:"static_content_root_directory,omitempty"`
-	WebFQDN                    string        `yaml:"web_fqdn,omitempty"`
-	WebPort                    string        `yaml:"web_port,omitempty"`
-	WebRoot                    string        `yaml:"web_root,omitempty"`
-	WebHistoryMode             string        `yaml:"web_history_mode,omitempty"`
-	WebSchema                  string        `yaml:"web_schema,omitempty"`
+	Address                    string               `yaml:",omitempty"`
+	AuditLog                   bool                 `yaml:"audit_log,omitempty"` // When true, allows additional audit logging on Write operations
+	CORSAllowAll               bool                 `yaml:"cors_allow_all,omitempty"`
+	GzipEnabled                bool                 `yaml:"gzip_enabled,omitempty"`
+	MetricsQueryDefaults       MetricsQueryDefaults `yaml:"metrics_query_defaults,omitempty"`
+	Observability              Observability        `yaml:"observability,omitempty"`
+	Port                       int                  `yaml:",omitempty"`
+	StaticContentRootDirectory string               `yaml:"static_content_root_directory,omitempty"`
+	WebFQDN                    string               `yaml:"web_fqdn,omitempty"`
+	WebPort                    string               `yaml:"web_port,omitempty"`
+	WebRoot                    string               `yaml:"web_root,omitempty"`
+	WebHistoryMode             string               `yaml:"web_history_mode,omitempty"`
+	WebSchema                  string               `yaml:"web_schema,omitempty"`
+}
+
+// MetricsQueryDefaults are the query parameters used by the metrics endpoints when the request doesn't set them
+type MetricsQueryDefaults struct {
+	Duration     int    `yaml:"duration,omitempty"`      // Time range of the query expressed in seconds
+	RateInterval string `yaml:"rate_interval,omitempty"` // Prometheus duration e.g. "1m"
+	Step         int    `yaml:"step,omitempty"`          // Query resolution expressed in seconds
+}
+
+// Validate returns an error if any of the metrics defaults cannot be used in a query.
+func (m MetricsQueryDefaults) Validate() error {
+	if m.Duration <= 0 {
+		return fmt.Errorf("metrics default duration must be positive: %v", m.Duration)
+	}
+	if m.Step <= 0 {
+		return fmt.Errorf("metrics default step must be positive: %v", m.Step)
+	}
+	if m.Step > m.Duration {
+		return fmt.Errorf("metrics default step [%v] must not exceed the default duration [%v]", m.Step, m.Duration)
+	}
+	if d, err := model.ParseDuration(m.RateInterval); err != nil || d <= 0 {
+		return fmt.Errorf("metrics default rate interval is not a valid duration: %v", m.RateInterval)
+	}
+	return nil
 }
 
 // Auth provides authentication data for external services
@@ -145,18 +172,31 @@ type ThanosProxy struct {
 	ScrapeInterval  string `yaml:"scrape_interval,omitempty"`
 }
 
+// AddonClusterConfig overrides the URLs of an addon for a cluster, when the cluster runs its own instance of the addon
+type AddonClusterConfig struct {
+	HealthCheckUrl string `yaml:"health_check_url,omitempty"`
+	// URL used by Kiali to reach the addon of the cluster
+	URL string `yaml:"url,omitempty"`
+}
+
 // PrometheusConfig describes configuration of the Prometheus component
 type PrometheusConfig struct {
-	Auth            Auth              `yaml:"auth,omitempty"`
-	CacheDuration   int               `yaml:"cache_duration,omitempty"`   // Cache duration per query expressed in seconds
-	CacheEnabled    bool              `yaml:"cache_enabled,omitempty"`    // Enable cache for Prometheus queries
-	CacheExpiration int               `yaml:"cache_expiration,omitempty"` // Global cache expiration expressed in seconds
-	CustomHeaders   map[string]string `yaml:"custom_headers,omitempty"`
-	HealthCheckUrl  string            `yaml:"health_check_url,omitempty"`
-	IsCore          bool              `yaml:"is_core,omitempty"`
-	QueryScope      map[string]string `yaml:"query_scope,omitempty"`
-	ThanosProxy     ThanosProxy       `yaml:"thanos_proxy,omitempty"`
-	URL             string            `yaml:"url,omitempty"`
+	Auth             Auth                          `yaml:"auth,omitempty"`
+	CacheDuration    int                           `yaml:"cache_duration,omitempty"`   // Cache duration per query expressed in seconds
+	CacheEnabled     bool                          `yaml:"cache_enabled,omitempty"`    // Enable cache for Prometheus queries
+	CacheExpiration  int                           `yaml:"cache_expiration,omitempty"` // Global cache expiration expressed in seconds
+	Clusters         map[string]AddonClusterConfig `yaml:"clusters,omitempty"`         // Per cluster URLs, by cluster name
+	CustomHeaders    map[string]string             `yaml:"custom_headers,omitempty"`
+	HealthCheckUrl   string                        `yaml:"health_check_url,omitempty"`
+	IsCore           bool                          `yaml:"is_core,omitempty"`
+	MaxQueryDuration int                           `yaml:"max_query_duration,omitempty"` // Maximum time range of the metrics queries expressed in seconds, 0 for no limit
+	MaxRateInterval  int                           `yaml:"max_rate_interval,omitempty"`  // Maximum rate interval of the metrics queries expressed in seconds, 0 for no limit
+	MaxSamples       int                           `yaml:"max_samples,omitempty"`        // Maximum number of samples of the health queries results, the extra samples are dropped and the health flagged as partial, 0 for no limit
+	PartialResponse  string                        `yaml:"partial_response,omitempty"`   // Value of the partial_response parameter of the queries for Thanos or Cortex, "true" or "false", empty to keep the default of the backend
+	QueryExemplars   bool                          `yaml:"query_exemplars,omitempty"`    // Enable the exemplars of the metrics queries, linking the series to traces, for the backends storing exemplars
+	QueryScope       map[string]string             `yaml:"query_scope,omitempty"`
+	ThanosProxy      ThanosProxy                   `yaml:"thanos_proxy,omitempty"`
+	URL              string                        `yaml:"url,omitempty"`
 }
 
 // CustomDashboardsConfig describes configuration specific to Custom Dashboards
@@ -171,13 +211,14 @@ type CustomDashboardsConfig struct {
 
 // GrafanaConfig describes configuration used for Grafana links
 type GrafanaConfig struct {
-	Auth           Auth                     `yaml:"auth"`
-	Dashboards     []GrafanaDashboardConfig `yaml:"dashboards"`
-	Enabled        bool                     `yaml:"enabled"` // Enable or disable Grafana support in Kiali
-	HealthCheckUrl string                   `yaml:"health_check_url,omitempty"`
-	InClusterURL   string                   `yaml:"in_cluster_url"`
-	IsCore         bool                     `yaml:"is_core,omitempty"`
-	URL            string                   `yaml:"url"`
+	Auth           Auth                          `yaml:"auth"`
+	Clusters       map[string]AddonClusterConfig `yaml:"clusters,omitempty"` // Per cluster in-cluster URLs, by cluster name
+	Dashboards     []GrafanaDashboardConfig      `yaml:"dashboards"`
+	Enabled        bool                          `yaml:"enabled"` // Enable or disable Grafana support in Kiali
+	HealthCheckUrl string                        `yaml:"health_check_url,omitempty"`
+	InClusterURL   string                        `yaml:"in_cluster_url"`
+	IsCore         bool                          `yaml:"is_core,omitempty"`
+	URL            string                        `yaml:"url"`
 }
 
 type GrafanaDashboardConfig struct {
@@ -195,16 +236,17 @@ type GrafanaVariablesConfig struct {
 
 // TracingConfig describes configuration used for tracing links
 type TracingConfig struct {
-	Auth                 Auth              `yaml:"auth"`
-	Enabled              bool              `yaml:"enabled"` // Enable Jaeger in Kiali
-	InClusterURL         string            `yaml:"in_cluster_url"`
-	IsCore               bool              `yaml:"is_core,omitempty"`
-	NamespaceSelector    bool              `yaml:"namespace_selector"`
-	QueryScope           map[string]string `yaml:"query_scope,omitempty"`
-	QueryTimeout         int               `yaml:"query_timeout,omitempty"`
-	URL                  string            `yaml:"url"`
-	UseGRPC              bool              `yaml:"use_grpc"`
-	WhiteListIstioSystem []string          `yaml:"whitelist_istio_system"`
+	Auth                 Auth                          `yaml:"auth"`
+	Clusters             map[string]AddonClusterConfig `yaml:"clusters,omitempty"` // Per cluster in-cluster URLs, by cluster name
+	Enabled              bool                          `yaml:"enabled"`            // Enable Jaeger in Kiali
+	InClusterURL         string                        `yaml:"in_cluster_url"`
+	IsCore               bool                          `yaml:"is_core,omitempty"`
+	NamespaceSelector    bool                          `yaml:"namespace_selector"`
+	QueryScope           map[string]string             `yaml:"query_scope,omitempty"`
+	QueryTimeout         int                           `yaml:"query_timeout,omitempty"`
+	URL                  string                        `yaml:"url"`
+	UseGRPC              bool                          `yaml:"use_grpc"`
+	WhiteListIstioSystem []string                      `yaml:"whitelist_istio_system"`
 }
 
 // RegistryConfig contains configuration for connecting to an external istiod.
@@ -220,6 +262,7 @@ type IstioConfig struct {
 	ConfigMapName                     string              `yaml:"config_map_name,omitempty"`
 	EnvoyAdminLocalPort               int                 `yaml:"envoy_admin_local_port,omitempty"`
 	GatewayAPIClassName               string              `yaml:"gateway_api_class_name,omitempty"`
+	InjectedContainerNames            []string            `yaml:"injected_container_names,omitempty"`
 	IstioAPIEnabled                   bool                `yaml:"istio_api_enabled"`
 	IstioCanaryRevision               IstioCanaryRevision `yaml:"istio_canary_revision,omitempty"`
 	IstioIdentityDomain               string              `yaml:"istio_identity_domain,omitempty"`
@@ -228,9 +271,31 @@ type IstioConfig struct {
 	IstioSidecarAnnotation            string              `yaml:"istio_sidecar_annotation,omitempty"`
 	IstiodDeploymentName              string              `yaml:"istiod_deployment_name,omitempty"`
 	IstiodPodMonitoringPort           int                 `yaml:"istiod_pod_monitoring_port,omitempty"`
+	ReferencesExcludedNamespaces      []string            `yaml:"references_excluded_namespaces,omitempty"`
 	Registry                          *RegistryConfig     `yaml:"registry,omitempty"`
+	RegistryMaxObjects                int                 `yaml:"registry_max_objects,omitempty"`
+	RegistryServeStale                bool                `yaml:"registry_serve_stale,omitempty"`
+	RegistryStaleThreshold            int                 `yaml:"registry_stale_threshold,omitempty"`
 	RootNamespace                     string              `yaml:"root_namespace,omitempty"`
 	UrlServiceVersion                 string              `yaml:"url_service_version"`
+
+	// referencesExcludedNamespaces are the compiled ReferencesExcludedNamespaces
+	referencesExcludedNamespaces []*regexp.Regexp
+}
+
+// ValidateReferencesExcludedNamespaces returns an error if any of the namespaces excluded from the references is not a valid expression.
+func (ic IstioConfig) ValidateReferencesExcludedNamespaces() error {
+	for _, expr := range ic.ReferencesExcludedNamespaces {
+		if _, err := regexp.Compile(expr); err != nil {
+			return fmt.Errorf("invalid references_excluded_namespaces expression [%s]: %v", expr, err)
+		}
+	}
+	return nil
+}
+
+// ReferencesExcludedNamespacesRegexps returns the compiled expressions of the namespaces excluded from the references
+func (ic IstioConfig) ReferencesExcludedNamespacesRegexps() []*regexp.Regexp {
+	return ic.referencesExcludedNamespaces
 }
 
 type IstioCanaryRevision struct {
@@ -293,6 +358,10 @@ type KubernetesConfig struct {
 	// Enable cache for kubernetes and istio resources
 	// TODO: Remove once all services are migrated to use the cache.
 	CacheEnabled bool `yaml:"-,omitempty"`
+	// Cache duration expressed in seconds
+	// Kiali cache the Istio config permissions computed for a set of namespaces per user, so browsing the overview and
+	// the Istio config pages doesn't check again every namespace. 0 disables the cache.
+	CacheIstioConfigPermissionsDuration int `yaml:"cache_istio_config_permissions_duration,omitempty"`
 	// Kiali can cache VirtualService,DestinationRule,Gateway and ServiceEntry Istio resources if they are present
 	// on this list of Istio types. Other Istio types are not yet supported.
 	CacheIstioTypes []string `yaml:"cache_istio_types,omitempty"`
@@ -302,6 +371,11 @@ type KubernetesConfig struct {
 	// Kiali cache list of namespaces per user, this is typically short lived cache compared with the duration of the
 	// namespace cache defined by previous CacheDuration parameter
 	CacheTokenNamespaceDuration int `yaml:"cache_token_namespace_duration,omitempty"`
+	// Cache duration expressed in seconds
+	// Kiali cache the RBAC permissions of the Istio config per user, to avoid a SelfSubjectAccessReview per request
+	CacheTokenPermissionsDuration int `yaml:"cache_token_permissions_duration,omitempty"`
+	// Cache duration expressed in seconds of the permissions with a denied verb, shorter so granted access is picked up soon
+	CacheTokenPermissionsDeniedDuration int `yaml:"cache_token_permissions_denied_duration,omitempty"`
 	// ClusterName is the name of the kubernetes cluster that Kiali is running in.
 	// If empty, then it will default to 'Kubernetes'.
 	ClusterName string `yaml:"cluster_name,omitempty"`
@@ -310,12 +384,33 @@ type KubernetesConfig struct {
 	// Deployment and ReplicaSet will be always queried, but ReplicationController,DeploymentConfig,StatefulSet,Job and CronJobs
 	// can be skipped from Kiali workloads query if they are present in this list
 	ExcludeWorkloads []string `yaml:"excluded_workloads,omitempty"`
-	QPS              float32  `yaml:"qps,omitempty"`
+	// Timeout expressed in seconds to list the Istio config of a namespace
+	// When it expires, the types fetched so far are returned as a partial list. It only applies to the Istio config list API,
+	// the internal lists need every type. 0 disables the timeout.
+	ListTimeout int `yaml:"list_timeout,omitempty"`
+	// Maximum number of concurrent SelfSubjectAccessReviews sent to check the Istio config permissions of the namespaces.
+	// 0 means unbounded.
+	PermissionsConcurrency int     `yaml:"permissions_concurrency,omitempty"`
+	QPS                    float32 `yaml:"qps,omitempty"`
+	// TLS settings enforced on the connections to all the remote clusters
+	RemoteClusterTLS RemoteClusterTLSConfig `yaml:"remote_cluster_tls,omitempty"`
+}
+
+// RemoteClusterTLSConfig centrally controls how the TLS connections to remote clusters are verified,
+// regardless of what the remote cluster secrets contain.
+type RemoteClusterTLSConfig struct {
+	// Path to a PEM encoded CA bundle trusted for all remote clusters in addition to the CA of each remote cluster secret
+	CAFile string `yaml:"ca_file,omitempty"`
+	// When true, the remote clusters whose secrets set insecure-skip-tls-verify are rejected and ignored
+	RequireTLSVerify bool `yaml:"require_tls_verify,omitempty"`
 }
 
 // ApiConfig contains API specific configuration.
 type ApiConfig struct {
-	Namespaces ApiNamespacesConfig
+	// IstioConfigTypes are the types of Istio config listed when a request doesn't specify them, like "virtualservices".
+	// All the types are listed when empty.
+	IstioConfigTypes []string `yaml:"istio_config_types,omitempty"`
+	Namespaces       ApiNamespacesConfig
 }
 
 // ApiNamespacesConfig provides a list of regex strings defining namespaces to include or exclude.
@@ -328,9 +423,16 @@ type ApiNamespacesConfig struct {
 
 // AuthConfig provides details on how users are to authenticate
 type AuthConfig struct {
-	OpenId    OpenIdConfig    `yaml:"openid,omitempty"`
-	OpenShift OpenShiftConfig `yaml:"openshift,omitempty"`
-	Strategy  string          `yaml:"strategy,omitempty"`
+	Impersonation ImpersonationConfig `yaml:"impersonation,omitempty"`
+	OpenId        OpenIdConfig        `yaml:"openid,omitempty"`
+	OpenShift     OpenShiftConfig     `yaml:"openshift,omitempty"`
+	Strategy      string              `yaml:"strategy,omitempty"`
+}
+
+// ImpersonationConfig contains the configuration for accessing the cluster API as the Kiali service account
+// impersonating the logged in user, rather than with the user's own token
+type ImpersonationConfig struct {
+	Enabled bool `yaml:"enabled,omitempty"`
 }
 
 // OpenShiftConfig contains specific configuration for authentication when on OpenShift
@@ -427,13 +529,14 @@ type MetricsDefaults struct {
 
 // UIDefaults defines default settings configured for the UI
 type UIDefaults struct {
-	Graph             GraphUIDefaults `yaml:"graph,omitempty" json:"graph,omitempty"`
-	List              ListUIDefaults  `yaml:"list,omitempty" json:"list,omitempty"`
-	MetricsPerRefresh string          `yaml:"metrics_per_refresh,omitempty" json:"metricsPerRefresh,omitempty"`
-	MetricsInbound    MetricsDefaults `yaml:"metrics_inbound,omitempty" json:"metricsInbound,omitempty"`
-	MetricsOutbound   MetricsDefaults `yaml:"metrics_outbound,omitempty" json:"metricsOutbound,omitempty"`
-	Namespaces        []string        `yaml:"namespaces,omitempty" json:"namespaces,omitempty"`
-	RefreshInterval   string          `yaml:"refresh_interval,omitempty" json:"refreshInterval,omitempty"`
+	Graph                  GraphUIDefaults `yaml:"graph,omitempty" json:"graph,omitempty"`
+	HideInjectedContainers bool            `yaml:"hide_injected_containers,omitempty" json:"hideInjectedContainers"`
+	List                   ListUIDefaults  `yaml:"list,omitempty" json:"list,omitempty"`
+	MetricsPerRefresh      string          `yaml:"metrics_per_refresh,omitempty" json:"metricsPerRefresh,omitempty"`
+	MetricsInbound         MetricsDefaults `yaml:"metrics_inbound,omitempty" json:"metricsInbound,omitempty"`
+	MetricsOutbound        MetricsDefaults `yaml:"metrics_outbound,omitempty" json:"metricsOutbound,omitempty"`
+	Namespaces             []string        `yaml:"namespaces,omitempty" json:"namespaces,omitempty"`
+	RefreshInterval        string          `yaml:"refresh_interval,omitempty" json:"refreshInterval,omitempty"`
 }
 
 // Validations defines default settings configured for the Validations subsystem
@@ -455,6 +558,7 @@ type KialiFeatureFlags struct {
 	IstioAnnotationAction             bool                              `yaml:"istio_annotation_action,omitempty" json:"istioAnnotationAction"`
 	IstioInjectionAction              bool                              `yaml:"istio_injection_action,omitempty" json:"istioInjectionAction"`
 	IstioUpgradeAction                bool                              `yaml:"istio_upgrade_action,omitempty" json:"istioUpgradeAction"`
+	MultiClusterServiceReplicas       bool                              `yaml:"multi_cluster_service_replicas,omitempty" json:"multiClusterServiceReplicas"`
 	UIDefaults                        UIDefaults                        `yaml:"ui_defaults,omitempty" json:"uiDefaults,omitempty"`
 	Validations                       Validations                       `yaml:"validations,omitempty" json:"validations,omitempty"`
 }
@@ -478,7 +582,24 @@ type Rate struct {
 
 // HealthConfig rates
 type HealthConfig struct {
-	Rate []Rate `yaml:"rate,omitempty" json:"rate,omitempty"`
+	// EventsLookbackDuration is how far back, in seconds, the Warning events of the pods of a workload are looked up
+	// when its health includes the events.
+	EventsLookbackDuration int `yaml:"events_lookback_duration,omitempty" json:"-"`
+	// IncludeResponseFlags breaks down the request rates of the health by the Envoy response flags (UO, UF, NR, UH...),
+	// to tell which proportion of the requests fail with each flag.
+	IncludeResponseFlags bool `yaml:"include_response_flags,omitempty" json:"-"`
+	// MinRequests is the minimum number of requests over the rate interval to compute the error ratios,
+	// below it the requests health reports insufficient traffic.
+	MinRequests      int                   `yaml:"min_requests,omitempty" json:"minRequests"`
+	Rate             []Rate                `yaml:"rate,omitempty" json:"rate,omitempty"`
+	ExternalServices ExternalServiceHealth `yaml:"external_services,omitempty" json:"-"`
+}
+
+// ExternalServiceHealth defines the values recorded by the telemetry for the External services (ServiceEntries),
+// used when the telemetry doesn't carry their namespace or cluster.
+type ExternalServiceHealth struct {
+	Cluster   string `yaml:"cluster,omitempty"`
+	Namespace string `yaml:"namespace,omitempty"`
 }
 
 // Config defines full YAML configuration.
@@ -595,6 +716,7 @@ func NewConfig() (c *Config) {
 				},
 				ConfigMapName:                     "istio",
 				EnvoyAdminLocalPort:               15000,
+				InjectedContainerNames:            []string{"istio-proxy", "istio-init", "istio-validation"},
 				IstioAPIEnabled:                   true,
 				IstioIdentityDomain:               "svc.cluster.local",
 				IstioInjectionAnnotation:          "sidecar.istio.io/inject",
@@ -602,6 +724,7 @@ func NewConfig() (c *Config) {
 				IstioSidecarAnnotation:            "sidecar.istio.io/status",
 				IstiodDeploymentName:              "istiod",
 				IstiodPodMonitoringPort:           15014,
+				RegistryStaleThreshold:            10 * 60,
 				RootNamespace:                     "istio-system",
 				UrlServiceVersion:                 "http://istiod.istio-system:15014/version",
 				GatewayAPIClassName:               "istio",
@@ -616,7 +739,10 @@ func NewConfig() (c *Config) {
 				// Prom Cache expires and it forces to repopulate cache
 				CacheExpiration: 300,
 				CustomHeaders:   map[string]string{},
-				QueryScope:      map[string]string{},
+				// The longest duration offered by the UI
+				MaxQueryDuration: 30 * 24 * 3600,
+				MaxRateInterval:  24 * 3600,
+				QueryScope:       map[string]string{},
 				ThanosProxy: ThanosProxy{
 					Enabled:         false,
 					RetentionPeriod: "7d",
@@ -639,6 +765,14 @@ func NewConfig() (c *Config) {
 				WhiteListIstioSystem: []string{"jaeger-query", "istio-ingressgateway"},
 			},
 		},
+		HealthConfig: HealthConfig{
+			EventsLookbackDuration: 600,
+			MinRequests:            1,
+			ExternalServices: ExternalServiceHealth{
+				Cluster:   "unknown",
+				Namespace: "unknown",
+			},
+		},
 		IstioLabels: IstioLabels{
 			AppLabelName:       "app",
 			InjectionLabelName: "istio-injection",
@@ -716,15 +850,20 @@ func NewConfig() (c *Config) {
 			},
 		},
 		KubernetesConfig: KubernetesConfig{
-			Burst:                       200,
-			CacheDuration:               5 * 60,
-			CacheEnabled:                true,
-			CacheIstioTypes:             []string{"AuthorizationPolicy", "DestinationRule", "EnvoyFilter", "Gateway", "PeerAuthentication", "RequestAuthentication", "ServiceEntry", "Sidecar", "VirtualService", "WorkloadEntry", "WorkloadGroup", "WasmPlugin", "Telemetry", "K8sGateway", "K8sHTTPRoute"},
-			CacheNamespaces:             []string{".*"},
-			CacheTokenNamespaceDuration: 10,
-			ClusterName:                 "", // leave this unset as a flag that we need to fetch the information
-			ExcludeWorkloads:            []string{"CronJob", "DeploymentConfig", "Job", "ReplicationController"},
-			QPS:                         175,
+			Burst:                               200,
+			CacheDuration:                       5 * 60,
+			CacheEnabled:                        true,
+			CacheIstioConfigPermissionsDuration: 10,
+			CacheIstioTypes:                     []string{"AuthorizationPolicy", "DestinationRule", "EnvoyFilter", "Gateway", "PeerAuthentication", "RequestAuthentication", "ServiceEntry", "Sidecar", "VirtualService", "WorkloadEntry", "WorkloadGroup", "WasmPlugin", "Telemetry", "K8sGateway", "K8sHTTPRoute"},
+			CacheNamespaces:                     []string{".*"},
+			CacheTokenNamespaceDuration:         10,
+			CacheTokenPermissionsDuration:       60,
+			CacheTokenPermissionsDeniedDuration: 10,
+			ClusterName:                         "", // leave this unset as a flag that we need to fetch the information
+			ExcludeWorkloads:                    []string{},
+			ListTimeout:                         30,
+			PermissionsConcurrency:              10,
+			QPS:                                 175,
 		},
 		LoginToken: LoginToken{
 			ExpirationSeconds: 24 * 3600,
@@ -733,6 +872,11 @@ func NewConfig() (c *Config) {
 		Server: Server{
 			AuditLog:    true,
 			GzipEnabled: true,
+			MetricsQueryDefaults: MetricsQueryDefaults{
+				Duration:     1800,
+				RateInterval: "1m",
+				Step:         15,
+			},
 			Observability: Observability{
 				Metrics: Metrics{
 					Enabled: true,
@@ -812,6 +956,17 @@ func (conf *Config) AllNamespacesAccessible() bool {
 	return conf.Deployment.ClusterWideAccess
 }
 
+// IsInjectedContainer determines if a container is injected by Istio in the pods, according to its name.
+// It identifies the injected containers of the pods lacking the sidecar status annotation.
+func (conf *Config) IsInjectedContainer(name string) bool {
+	for _, injected := range conf.ExternalServices.Istio.InjectedContainerNames {
+		if injected == name {
+			return true
+		}
+	}
+	return false
+}
+
 // Get the global Config
 func Get() (conf *Config) {
 	rwMutex.RLock()
@@ -827,6 +982,8 @@ func Set(conf *Config) {
 	rwMutex.Lock()
 	defer rwMutex.Unlock()
 	conf.AddHealthDefault()
+	// An invalid expression excludes no namespace here, the configuration is rejected by its validation at startup
+	_ = conf.prepareReferencesExcludedNamespaces()
 	configuration = *conf
 }
 
@@ -872,6 +1029,21 @@ func (conf *Config) prepareDashboards() {
 	}
 }
 
+// prepareReferencesExcludedNamespaces compiles the expressions of the namespaces excluded from the references once,
+// an invalid expression is a configuration error
+func (conf *Config) prepareReferencesExcludedNamespaces() error {
+	if err := conf.ExternalServices.Istio.ValidateReferencesExcludedNamespaces(); err != nil {
+		conf.ExternalServices.Istio.referencesExcludedNamespaces = nil
+		return err
+	}
+	var compiled []*regexp.Regexp
+	for _, expr := range conf.ExternalServices.Istio.ReferencesExcludedNamespaces {
+		compiled = append(compiled, regexp.MustCompile(expr))
+	}
+	conf.ExternalServices.Istio.referencesExcludedNamespaces = compiled
+	return nil
+}
+
 // Unmarshal parses the given YAML string and returns its Config object representation.
 func Unmarshal(yamlString string) (conf *Config, err error) {
 	conf = NewConfig()
@@ -881,6 +1053,9 @@ func Unmarshal(yamlString string) (conf *Config, err error) {
 	}
 
 	conf.prepareDashboards()
+	if err = conf.prepareReferencesExcludedNamespaces(); err != nil {
+		return nil, err
+	}
 
 	// Some config settings (such as sensitive settings like passwords) are overrideable
 	// via secrets mounted on the file system rather than storing them directly in the config map itself.
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSecretFileOverrides(t *testing.T) {
@@ -226,6 +227,19 @@ func TestError(t *testing.T) {
 	}
 }
 
+func TestReferencesExcludedNamespaces(t *testing.T) {
+	require := require.New(t)
+
+	conf, err := Unmarshal("external_services:\n  istio:\n    references_excluded_namespaces: [\"istio-.*\", \"kube-system\"]\n")
+	require.NoError(err)
+	excluded := conf.ExternalServices.Istio.ReferencesExcludedNamespacesRegexps()
+	require.Len(excluded, 2)
+	require.True(excluded[0].MatchString("istio-system"))
+
+	_, err = Unmarshal("external_services:\n  istio:\n    references_excluded_namespaces: [\"istio-(\"]\n")
+	require.ErrorContains(err, "invalid references_excluded_namespaces expression [istio-(]")
+}
+
 func TestRaces(t *testing.T) {
 	wg := sync.WaitGroup{}
 	wg.Add(10)
@@ -3,7 +3,9 @@ package main
 import (
 	jaegerModels "github.com/kiali/kiali/jaeger/model/json"
 
+	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/business/authentication"
+	"github.com/kiali/kiali/graph/api"
 	"github.com/kiali/kiali/graph/config/cytoscape"
 	"github.com/kiali/kiali/jaeger"
 	"github.com/kiali/kiali/kubernetes"
@@ -79,7 +81,7 @@ type LoggingParam struct {
 	Level ProxyLogLevel `json:"level"`
 }
 
-// swagger:parameters istioConfigList workloadList workloadDetails workloadUpdate serviceDetails serviceUpdate appSpans serviceSpans workloadSpans appTraces serviceTraces workloadTraces errorTraces workloadValidations appList serviceMetrics aggregateMetrics appMetrics workloadMetrics istioConfigDetails istioConfigDetailsSubtype istioConfigDelete istioConfigDeleteSubtype istioConfigUpdate istioConfigUpdateSubtype serviceList appDetails graphAggregate graphAggregateByService graphApp graphAppVersion graphNamespace graphService graphWorkload namespaceMetrics customDashboard appDashboard serviceDashboard workloadDashboard istioConfigCreate istioConfigCreateSubtype namespaceUpdate namespaceTls podDetails podLogs namespaceValidations podProxyDump podProxyResource podProxyLogging
+// swagger:parameters istioConfigList workloadList workloadDetails workloadUpdate serviceDetails serviceUpdate appSpans serviceSpans workloadSpans appTraces serviceTraces workloadTraces errorTraces workloadValidations appList serviceMetrics serviceMetricsByWorkload aggregateMetrics appMetrics workloadMetrics workloadsMetrics istioConfigDetails istioConfigDetailsSubtype istioConfigDelete istioConfigDeleteSubtype istioConfigUpdate istioConfigUpdateSubtype serviceList appDetails graphAggregate graphAggregateByService graphApp graphAppVersion graphNamespace graphService graphWorkload namespaceMetrics customDashboard appDashboard serviceDashboard workloadDashboard istioConfigCreate istioConfigCreateSubtype namespaceUpdate namespaceTls podDetails podLogs namespaceValidations podProxyDump podProxyResource podProxyLogging serviceEndpointsHealth workloadLogs workloadDebugBundle
 type NamespaceParam struct {
 	// The namespace name.
 	//
@@ -134,7 +136,7 @@ type ResourceParam struct {
 	Name string `json:"resource"`
 }
 
-// swagger:parameters serviceDetails serviceUpdate serviceMetrics graphService graphAggregateByService serviceDashboard serviceSpans serviceTraces
+// swagger:parameters serviceDetails serviceUpdate serviceMetrics serviceMetricsByWorkload graphService graphAggregateByService serviceDashboard serviceSpans serviceTraces serviceEndpointsHealth
 type ServiceParam struct {
 	// The service name.
 	//
@@ -143,7 +145,115 @@ type ServiceParam struct {
 	Name string `json:"service"`
 }
 
-// swagger:parameters podLogs
+// swagger:parameters serviceEndpointsHealth
+type ProxyPodParam struct {
+	// The pod whose proxy reports the health of the endpoints.
+	//
+	// in: query
+	// required: true
+	Name string `json:"proxyPod"`
+}
+
+// swagger:parameters serviceEndpointsHealth
+type ProxyNamespaceParam struct {
+	// The namespace of the proxy pod. Default is the namespace of the service.
+	//
+	// in: query
+	// required: false
+	Name string `json:"proxyNamespace"`
+}
+
+// swagger:parameters proxyStatuses
+type ProxyStatusesClusterParam struct {
+	// The cluster of the proxies. Default is all the clusters.
+	//
+	// in: query
+	// required: false
+	Name string `json:"cluster"`
+}
+
+// swagger:parameters proxyStatuses
+type ProxyStatusesNamespaceParam struct {
+	// The namespace of the proxies. Default is all the namespaces.
+	//
+	// in: query
+	// required: false
+	Name string `json:"namespace"`
+}
+
+// swagger:parameters proxyStatuses
+type ProxyStatusesPageParam struct {
+	// The page to return, starting at 1. Default is 1.
+	//
+	// in: query
+	// required: false
+	Name string `json:"page"`
+}
+
+// swagger:parameters proxyStatuses
+type ProxyStatusesPageSizeParam struct {
+	// The number of proxies of a page, up to 500. Default is 20.
+	//
+	// in: query
+	// required: false
+	Name string `json:"pageSize"`
+}
+
+// swagger:parameters meshConfig
+type MeshConfigClusterParam struct {
+	// The cluster of the control plane. Default is the Kiali home cluster.
+	//
+	// in: query
+	// required: false
+	Name string `json:"cluster"`
+}
+
+// swagger:parameters meshConfig
+type MeshConfigRevisionParam struct {
+	// The revision of the control plane. Default is the default revision.
+	//
+	// in: query
+	// required: false
+	Name string `json:"revision"`
+}
+
+// swagger:parameters workloadsMetrics
+type WorkloadsMetricsWorkloadsParam struct {
+	// The comma separated list of workloads, up to 20. Either the workloads or the app must be set.
+	//
+	// in: query
+	// required: false
+	Name string `json:"workloads"`
+}
+
+// swagger:parameters workloadsMetrics
+type WorkloadsMetricsAppParam struct {
+	// The app whose workloads are fetched. Either the workloads or the app must be set.
+	//
+	// in: query
+	// required: false
+	Name string `json:"app"`
+}
+
+// swagger:parameters namespaceList
+type NamespaceMeshMemberParam struct {
+	// Classify the namespaces by their mesh membership. Default is false.
+	//
+	// in: query
+	// required: false
+	Name bool `json:"meshMember"`
+}
+
+// swagger:parameters workloadLogs
+type TailLinesParam struct {
+	// The number of lines from the end of the logs of each container. Default is all logs.
+	//
+	// in: query
+	// required: false
+	Name string `json:"tailLines"`
+}
+
+// swagger:parameters podLogs workloadLogs
 type SinceTimeParam struct {
 	// The start time for fetching logs. UNIX time in seconds. Default is all logs.
 	//
@@ -152,7 +262,7 @@ type SinceTimeParam struct {
 	Name string `json:"sinceTime"`
 }
 
-// swagger:parameters podLogs
+// swagger:parameters podLogs workloadLogs
 type DurationLogParam struct {
 	// Query time-range duration (Golang string duration). Duration starts on
 	// `sinceTime` if set, or the time for the first log message if not set.
@@ -180,7 +290,7 @@ type DashboardParam struct {
 	Name string `json:"dashboard"`
 }
 
-// swagger:parameters workloadDetails workloadUpdate workloadValidations workloadMetrics graphWorkload workloadDashboard workloadSpans workloadTraces
+// swagger:parameters workloadDetails workloadUpdate workloadValidations workloadMetrics graphWorkload workloadDashboard workloadSpans workloadTraces workloadLogs workloadDebugBundle
 type WorkloadParam struct {
 	// The workload name.
 	//
@@ -345,7 +455,7 @@ type AdditionalLabelsParam struct {
 	Name string `json:"additionalLabels"`
 }
 
-// swagger:parameters serviceMetrics aggregateMetrics appMetrics workloadMetrics customDashboard appDashboard serviceDashboard workloadDashboard
+// swagger:parameters serviceMetrics serviceMetricsByWorkload aggregateMetrics appMetrics workloadMetrics workloadsMetrics customDashboard appDashboard serviceDashboard workloadDashboard
 type AvgParam struct {
 	// Flag for fetching histogram average. Default is true.
 	//
@@ -355,7 +465,7 @@ type AvgParam struct {
 	Name bool `json:"avg"`
 }
 
-// swagger:parameters serviceMetrics aggregateMetrics appMetrics workloadMetrics customDashboard appDashboard serviceDashboard workloadDashboard
+// swagger:parameters serviceMetrics serviceMetricsByWorkload aggregateMetrics appMetrics workloadMetrics workloadsMetrics customDashboard appDashboard serviceDashboard workloadDashboard
 type ByLabelsParam struct {
 	// List of labels to use for grouping metrics (via Prometheus 'by' clause).
 	//
@@ -365,7 +475,7 @@ type ByLabelsParam struct {
 	Name []string `json:"byLabels[]"`
 }
 
-// swagger:parameters serviceMetrics aggregateMetrics appMetrics workloadMetrics appDashboard serviceDashboard workloadDashboard
+// swagger:parameters serviceMetrics serviceMetricsByWorkload aggregateMetrics appMetrics workloadMetrics workloadsMetrics appDashboard serviceDashboard workloadDashboard
 type DirectionParam struct {
 	// Traffic direction: 'inbound' or 'outbound'.
 	//
@@ -375,7 +485,7 @@ type DirectionParam struct {
 	Name string `json:"direction"`
 }
 
-// swagger:parameters serviceMetrics aggregateMetrics appMetrics workloadMetrics customDashboard appDashboard serviceDashboard workloadDashboard
+// swagger:parameters serviceMetrics serviceMetricsByWorkload aggregateMetrics appMetrics workloadMetrics workloadsMetrics customDashboard appDashboard serviceDashboard workloadDashboard
 type DurationParam struct {
 	// Duration of the query period, in seconds.
 	//
@@ -385,7 +495,17 @@ type DurationParam struct {
 	Name int `json:"duration"`
 }
 
-// swagger:parameters serviceMetrics aggregateMetrics appMetrics workloadMetrics
+// swagger:parameters serviceMetrics serviceMetricsByWorkload aggregateMetrics appMetrics workloadMetrics workloadsMetrics
+type ExemplarsParam struct {
+	// Flag for fetching the exemplars of the series, linking them to traces. Ignored unless the exemplars are enabled in the Prometheus config.
+	//
+	// in: query
+	// required: false
+	// default: false
+	Name bool `json:"exemplars"`
+}
+
+// swagger:parameters serviceMetrics serviceMetricsByWorkload aggregateMetrics appMetrics workloadMetrics workloadsMetrics
 type FiltersParam struct {
 	// List of metrics to fetch. Fetch all metrics when empty. List entries are Kiali internal metric names.
 	//
@@ -405,7 +525,7 @@ type LabelsFiltersParam struct {
 	Name string `json:"labelsFilters"`
 }
 
-// swagger:parameters serviceMetrics aggregateMetrics appMetrics workloadMetrics customDashboard appDashboard serviceDashboard workloadDashboard
+// swagger:parameters serviceMetrics serviceMetricsByWorkload aggregateMetrics appMetrics workloadMetrics workloadsMetrics customDashboard appDashboard serviceDashboard workloadDashboard
 type QuantilesParam struct {
 	// List of quantiles to fetch. Fetch no quantiles when empty. Ex: [0.5, 0.95, 0.99].
 	//
@@ -415,7 +535,7 @@ type QuantilesParam struct {
 	Name []string `json:"quantiles[]"`
 }
 
-// swagger:parameters serviceMetrics aggregateMetrics appMetrics workloadMetrics customDashboard appDashboard serviceDashboard workloadDashboard
+// swagger:parameters serviceMetrics serviceMetricsByWorkload aggregateMetrics appMetrics workloadMetrics workloadsMetrics customDashboard appDashboard serviceDashboard workloadDashboard
 type RateFuncParam struct {
 	// Prometheus function used to calculate rate: 'rate' or 'irate'.
 	//
@@ -425,7 +545,7 @@ type RateFuncParam struct {
 	Name string `json:"rateFunc"`
 }
 
-// swagger:parameters serviceMetrics aggregateMetrics appMetrics workloadMetrics customDashboard appDashboard serviceDashboard workloadDashboard
+// swagger:parameters serviceMetrics serviceMetricsByWorkload aggregateMetrics appMetrics workloadMetrics workloadsMetrics customDashboard appDashboard serviceDashboard workloadDashboard
 type RateIntervalParam struct {
 	// Interval used for rate and histogram calculation.
 	//
@@ -435,7 +555,7 @@ type RateIntervalParam struct {
 	Name string `json:"rateInterval"`
 }
 
-// swagger:parameters serviceMetrics aggregateMetrics appMetrics workloadMetrics appDashboard serviceDashboard workloadDashboard
+// swagger:parameters serviceMetrics serviceMetricsByWorkload aggregateMetrics appMetrics workloadMetrics workloadsMetrics appDashboard serviceDashboard workloadDashboard
 type RequestProtocolParam struct {
 	// Desired request protocol for the telemetry: For example, 'http' or 'grpc'.
 	//
@@ -445,7 +565,7 @@ type RequestProtocolParam struct {
 	Name string `json:"requestProtocol"`
 }
 
-// swagger:parameters serviceMetrics aggregateMetrics appMetrics workloadMetrics appDashboard serviceDashboard workloadDashboard
+// swagger:parameters serviceMetrics serviceMetricsByWorkload aggregateMetrics appMetrics workloadMetrics workloadsMetrics appDashboard serviceDashboard workloadDashboard
 type ReporterParam struct {
 	// Istio telemetry reporter: 'source' or 'destination'.
 	//
@@ -455,7 +575,7 @@ type ReporterParam struct {
 	Name string `json:"reporter"`
 }
 
-// swagger:parameters serviceMetrics aggregateMetrics appMetrics workloadMetrics customDashboard appDashboard serviceDashboard workloadDashboard
+// swagger:parameters serviceMetrics serviceMetricsByWorkload aggregateMetrics appMetrics workloadMetrics workloadsMetrics customDashboard appDashboard serviceDashboard workloadDashboard
 type StepParam struct {
 	// Step between [graph] datapoints, in seconds.
 	//
@@ -465,7 +585,7 @@ type StepParam struct {
 	Name int `json:"step"`
 }
 
-// swagger:parameters serviceMetrics aggregateMetrics appMetrics workloadMetrics
+// swagger:parameters serviceMetrics serviceMetricsByWorkload aggregateMetrics appMetrics workloadMetrics workloadsMetrics
 type VersionParam struct {
 	// Filters metrics by the specified version.
 	//
@@ -533,6 +653,11 @@ type NotAcceptableError struct {
 	} `json:"body"`
 }
 
+// A NotModified means the response didn't change since the one identified by the If-None-Match header
+//
+// swagger:response notModified
+type NotModified struct{}
+
 // A Internal is the error message that means something has gone wrong
 //
 // swagger:response internalError
@@ -582,6 +707,13 @@ type GraphResponse struct {
 	Body cytoscape.Config
 }
 
+// HTTP status code 200 and snapshot of a namespaces graph in data
+// swagger:response graphSnapshotResponse
+type GraphSnapshotResponse struct {
+	// in:body
+	Body api.Snapshot
+}
+
 // HTTP status code 200 and IstioConfigList model in data
 // swagger:response istioConfigList
 type IstioConfigResponse struct {
@@ -694,6 +826,20 @@ type NamespaceListResponse struct {
 	Body []models.Namespace
 }
 
+// Namespaces the user can access, grouped by cluster
+// swagger:response namespaceAccessResponse
+type NamespaceAccessResponse struct {
+	// in:body
+	Body []models.ClusterAccess
+}
+
+// The resources the Kiali service account can't list in each accessible namespace
+// swagger:response accessibleNamespacesRBACResponse
+type AccessibleNamespacesRBACResponse struct {
+	// in:body
+	Body []models.AccessibleNamespaceRBAC
+}
+
 // Return all the descriptor data related to Grafana
 // swagger:response grafanaInfoResponse
 type GrafanaInfoResponse struct {
@@ -736,6 +882,13 @@ type NamespaceValidationSummaryResponse struct {
 	Body models.IstioValidationSummary
 }
 
+// Return the validations of the objects of an Istio config bundle
+// swagger:response istioValidationsResponse
+type IstioValidationsResponse struct {
+	// in:body
+	Body models.IstioValidations
+}
+
 // Return a dump of the configuration of a given envoy proxy
 // swagger:response configDump
 type ConfigDumpResponse struct {
@@ -750,6 +903,42 @@ type ConfigDumpResourceResponse struct {
 	Body map[string]interface{}
 }
 
+// Return the health of the endpoints of a service as seen by a proxy
+// swagger:response endpointsHealthResponse
+type EndpointsHealthResponse struct {
+	// in:body
+	Body []models.EndpointHealth
+}
+
+// Return a page of the sync status of the proxies of the mesh
+// swagger:response proxyStatusesResponse
+type ProxyStatusesResponse struct {
+	// in:body
+	Body models.ProxyStatusList
+}
+
+// Return the mesh config of a control plane revision
+// swagger:response meshConfigResponse
+type MeshConfigResponse struct {
+	// in:body
+	Body models.MeshConfig
+}
+
+// Logs of all the pods of a workload, merged chronologically
+// swagger:response workloadLogs
+type WorkloadLogsResponse struct {
+	// in:body
+	Body business.WorkloadLog
+}
+
+// Zip archive with the pods, logs, proxy config dumps, proxy statuses and Istio config of a workload
+// swagger:response workloadDebugBundle
+type WorkloadDebugBundleResponse struct {
+	// in:body
+	// swagger:file
+	Body []byte
+}
+
 //////////////////
 // SWAGGER MODELS
 //////////////////
@@ -178,6 +178,7 @@ const conf = {
         `api/namespaces/${namespace}/workloads/${workload}/health`,
       workloadMetrics: (namespace: string, workload: string) =>
         `api/namespaces/${namespace}/workloads/${workload}/metrics`,
+      workloadsMetrics: (namespace: string) => `api/namespaces/${namespace}/metrics/workloads`,
       workloadDashboard: (namespace: string, workload: string) =>
         `api/namespaces/${namespace}/workloads/${workload}/dashboard`
     }
@@ -267,15 +267,19 @@ class IstioConfigDetailsPageComponent extends React.Component<IstioConfigDetails
 
   onUpdate = () => {
     jsYaml.safeLoadAll(this.state.yamlModified, (objectModified: object) => {
-      const jsonPatch = JSON.stringify(
-        mergeJsonPatch(objectModified, getIstioObject(this.state.istioObjectDetails))
-      ).replace(new RegExp('"(,null)+]', 'g'), '"]');
+      const istioObject = getIstioObject(this.state.istioObjectDetails);
+      const jsonPatch = JSON.stringify(mergeJsonPatch(objectModified, istioObject)).replace(
+        new RegExp('"(,null)+]', 'g'),
+        '"]'
+      );
+      // The update is rejected when the object has been modified since it was loaded in the editor
       API.updateIstioConfigDetail(
         this.props.match.params.namespace,
         this.props.match.params.objectType,
         this.props.match.params.object,
         jsonPatch,
-        this.state.cluster
+        this.state.cluster,
+        istioObject?.metadata.resourceVersion
       )
         .then(() => {
           const targetMessage =
@@ -288,6 +292,15 @@ class IstioConfigDetailsPageComponent extends React.Component<IstioConfigDetails
           this.fetchIstioObjectDetails();
         })
         .catch(error => {
+          if (error.response && error.response.status === 409) {
+            AlertUtils.addWarning(
+              'The object has been modified since it was loaded. Reload it and apply your changes again.',
+              true,
+              undefined,
+              API.getErrorString(error)
+            );
+            return;
+          }
           AlertUtils.addError('Could not update IstioConfig details.', error);
           this.setState({
             yamlValidations: this.injectGalleyError(error)
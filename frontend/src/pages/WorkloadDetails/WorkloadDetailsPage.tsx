@@ -103,7 +103,8 @@ class WorkloadDetails extends React.Component<WorkloadDetailsPageProps, Workload
     const params: { [key: string]: string } = {
       validate: 'true',
       rateInterval: String(this.props.duration) + 's',
-      health: 'true'
+      health: 'true',
+      events: 'true'
     };
     await API.getWorkload(
       this.props.match.params.namespace,
@@ -267,12 +267,16 @@ export const updateIstioConfigDetail = (
   objectType: string,
   object: string,
   jsonPatch: string,
-  cluster?: string
+  cluster?: string,
+  resourceVersion?: string
 ): Promise<Response<string>> => {
   const queryParams: any = {};
   if (cluster) {
     queryParams.cluster = cluster;
   }
+  if (resourceVersion) {
+    queryParams.resourceVersion = resourceVersion;
+  }
   return newRequest(HTTP_VERBS.PATCH, urls.istioConfigUpdate(namespace, objectType, object), queryParams, jsonPatch);
 };
 
@@ -382,6 +386,25 @@ export const getWorkloadMetrics = (
   return newRequest<IstioMetricsMap>(HTTP_VERBS.GET, urls.workloadMetrics(namespace, workload), queryParams, {});
 };
 
+// Metrics of several workloads, or of the workloads of an app, the series are tagged by workload
+export const getWorkloadsMetrics = (
+  namespace: string,
+  target: { workloads: string[] } | { app: string },
+  params: IstioMetricsOptions,
+  cluster?: string
+) => {
+  const queryParams: any = { ...params };
+  if ('workloads' in target) {
+    queryParams.workloads = target.workloads.join(',');
+  } else {
+    queryParams.app = target.app;
+  }
+  if (cluster) {
+    queryParams.cluster = cluster;
+  }
+  return newRequest<IstioMetricsMap>(HTTP_VERBS.GET, urls.workloadsMetrics(namespace), queryParams, {});
+};
+
 export const getWorkloadDashboard = (
   namespace: string,
   workload: string,
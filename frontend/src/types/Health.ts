@@ -52,9 +52,24 @@ export interface AppHealthResponse {
   requests: RequestHealth;
 }
 
+export interface WorkloadEvent {
+  pod: string;
+  reason: string;
+  message: string;
+  count: number;
+  lastSeen: string;
+  critical: boolean;
+}
+
+export interface WorkloadEvents {
+  critical: boolean;
+  events: WorkloadEvent[];
+}
+
 export interface WorkloadHealthResponse {
   workloadStatus: WorkloadStatus;
   requests: RequestHealth;
+  events?: WorkloadEvents;
 }
 
 export const TRAFFICSTATUS = 'Traffic Status';
@@ -76,6 +91,15 @@ export interface RequestHealth {
   inbound: RequestType;
   outbound: RequestType;
   healthAnnotations: HealthAnnotationType;
+  insufficientTraffic?: boolean;
+  partialData?: boolean;
+  responseFlags?: ResponseFlagsHealth;
+}
+
+// Request rates by protocol and Envoy response flag, '-' when no flag is set
+export interface ResponseFlagsHealth {
+  inbound: RequestType;
+  outbound: RequestType;
 }
 
 export interface Status {
@@ -142,6 +166,7 @@ export interface ThresholdStatus {
 }
 
 export const POD_STATUS = 'Pod Status';
+export const EVENTS_STATUS = 'Events';
 
 // Use -1 rather than NaN to allow straigthforward comparison
 export const RATIO_NA = -1;
@@ -443,14 +468,15 @@ export class AppHealth extends Health {
 
 export class WorkloadHealth extends Health {
   public static fromJson = (ns: string, workload: string, json: any, ctx: HealthContext) =>
-    new WorkloadHealth(ns, workload, json.workloadStatus, json.requests, ctx);
+    new WorkloadHealth(ns, workload, json.workloadStatus, json.requests, ctx, json.events);
 
   private static computeItems(
     ns: string,
     workload: string,
     workloadStatus: WorkloadStatus,
     requests: RequestHealth,
-    ctx: HealthContext
+    ctx: HealthContext,
+    events?: WorkloadEvents
   ): HealthConfig {
     const items: HealthItem[] = [];
     let statusConfig: HealthItemConfig | undefined = undefined;
@@ -506,6 +532,18 @@ export class WorkloadHealth extends Health {
       }
       items.push(item);
     }
+    // Recent Warning events, critical ones degrade the health before the metrics reflect the problem
+    if (events && events.events.length > 0) {
+      const eventsStatus = events.critical ? DEGRADED : HEALTHY;
+      items.push({
+        title: EVENTS_STATUS,
+        status: eventsStatus,
+        children: events.events.map(e => ({
+          status: e.critical ? DEGRADED : HEALTHY,
+          text: e.pod + ': ' + e.reason + (e.count > 1 ? ' (x' + e.count + ')' : '')
+        }))
+      });
+    }
     // Request errors
     if (ctx.hasSidecar) {
       const reqError = calculateErrorRate(ns, workload, 'workload', requests);
@@ -534,9 +572,10 @@ export class WorkloadHealth extends Health {
     workload: string,
     workloadStatus: WorkloadStatus,
     public requests: RequestHealth,
-    ctx: HealthContext
+    ctx: HealthContext,
+    public events?: WorkloadEvents
   ) {
-    super(WorkloadHealth.computeItems(ns, workload, workloadStatus, requests, ctx));
+    super(WorkloadHealth.computeItems(ns, workload, workloadStatus, requests, ctx, events));
   }
 }
 
@@ -116,6 +116,7 @@ export interface ObjectReference {
 export interface PodReference {
   name: string;
   kind: string;
+  apiVersion?: string;
 }
 
 export interface References {
@@ -145,6 +146,8 @@ export interface ContainerInfo {
   image: string;
   isProxy: boolean;
   isReady: boolean;
+  // Injected by Istio, as opposed to the application containers
+  isInjected?: boolean;
 }
 
 // 1.6
@@ -171,6 +174,21 @@ export interface Pod {
   appLabel: boolean;
   versionLabel: boolean;
   proxyStatus?: ProxyStatus;
+  // Controllers owning the pod, from its direct owner up. Only returned when requested
+  ownerChain?: PodReference[];
+  // Resources of the istio-proxy container of the pod. Only returned when requested
+  proxyResources?: ProxyResources;
+}
+
+export interface ProxyResources {
+  cpuRequest?: string;
+  cpuLimit?: string;
+  memoryRequest?: string;
+  memoryLimit?: string;
+  // Cores used by the proxy, when the metrics are available
+  cpuUsage?: number;
+  // Bytes of memory used by the proxy, when the metrics are available
+  memoryUsage?: number;
 }
 
 // models Engarde Istio proxy AccessLog
@@ -10,6 +10,7 @@ export interface ComponentStatus {
   name: string;
   status: Status;
   is_core: boolean;
+  cluster?: string;
 }
 
 export interface IstiodResourceThresholds {
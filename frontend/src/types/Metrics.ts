@@ -4,10 +4,19 @@ export type Datapoint = [number, number, number?];
 export interface Metric {
   labels: Labels;
   datapoints: Datapoint[];
+  exemplars?: Exemplar[];
   name: string;
   stat?: string;
 }
 
+// Timestamp in milliseconds
+export interface Exemplar {
+  labels: Labels;
+  traceId?: string;
+  timestamp: number;
+  value: number;
+}
+
 export type ControlPlaneMetricsMap = {
   istiod_proxy_time?: Metric[];
   istiod_cpu?: Metric[];
@@ -28,6 +28,7 @@ export interface IstioMetricsOptions extends MetricsQuery {
   requestProtocol?: string;
   reporter: Reporter;
   cluster?: string;
+  exemplars?: boolean;
 }
 
 export type Reporter = 'source' | 'destination' | 'both';
@@ -2,8 +2,11 @@ export default interface Namespace {
   name: string;
   cluster?: string;
   labels?: { [key: string]: string };
+  meshMember?: MeshMembership;
 }
 
+export type MeshMembership = 'injection' | 'ambient' | 'workloads' | 'none';
+
 export const namespaceFromString = (namespace: string) => ({ name: namespace });
 
 export const namespacesFromString = (namespaces: string) => {
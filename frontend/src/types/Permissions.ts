@@ -4,6 +4,7 @@ export interface ResourcePermissions {
   create: boolean;
   update: boolean;
   delete: boolean;
+  error?: string;
 }
 
 export function canCreate(privs?: ResourcePermissions) {
@@ -50,6 +50,7 @@ interface ListUIDefaults {
 
 interface UIDefaults {
   graph: GraphUIDefaults;
+  hideInjectedContainers?: boolean;
   list: ListUIDefaults;
   metricsPerRefresh?: string;
   namespaces?: string[];
@@ -66,6 +67,7 @@ interface KialiFeatureFlags {
   istioInjectionAction: boolean;
   istioAnnotationAction: boolean;
   istioUpgradeAction: boolean;
+  multiClusterServiceReplicas?: boolean;
   uiDefaults: UIDefaults;
 }
 
@@ -94,6 +96,7 @@ interface IstioCanaryRevision {
 export type RegexConfig = string | RegExp;
 
 export interface HealthConfig {
+  minRequests?: number;
   rate: RateHealthConfig[];
 }
 
@@ -63,6 +63,24 @@ export interface Service {
   cluster?: string;
 }
 
+export interface SubsetUsage {
+  name: string;
+  used: boolean;
+}
+
+export interface DestinationRuleSubsets {
+  name: string;
+  namespace: string;
+  subsets: SubsetUsage[];
+}
+
+export interface ServiceReplica {
+  cluster: string;
+  namespace: string;
+  name: string;
+  endpoints?: Endpoints[];
+}
+
 export interface ServiceDetailsInfo {
   service: Service;
   endpoints?: Endpoints[];
@@ -71,6 +89,7 @@ export interface ServiceDetailsInfo {
   virtualServices: VirtualService[];
   k8sHTTPRoutes: K8sHTTPRoute[];
   destinationRules: DestinationRule[];
+  destinationRuleSubsets?: DestinationRuleSubsets[];
   serviceEntries: ServiceEntry[];
   istioPermissions: ResourcePermissions;
   health?: ServiceHealth;
@@ -80,6 +99,7 @@ export interface ServiceDetailsInfo {
   validations: Validations;
   additionalDetails: AdditionalItem[];
   cluster?: string;
+  replicas?: ServiceReplica[];
 }
 
 export function getServiceDetailsUpdateLabel(serviceDetails: ServiceDetailsInfo | null) {
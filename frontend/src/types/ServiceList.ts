@@ -7,6 +7,8 @@ export interface ServiceList {
   namespace: Namespace;
   services: ServiceOverview[];
   validations: Validations;
+  // Errors of the clusters whose services couldn't be listed, by cluster name
+  clusterErrors?: { [cluster: string]: string };
 }
 
 export interface ServiceOverview {
@@ -17,9 +19,12 @@ export interface ServiceOverview {
   additionalDetailSample?: AdditionalItem;
   labels: { [key: string]: string };
   ports: { [key: string]: number };
+  protocols?: string[];
   istioReferences: ObjectReference[];
   kialiWizard: string;
   serviceRegistry: string;
+  location?: string;
+  resolution?: string;
   health: ServiceHealth;
 }
 
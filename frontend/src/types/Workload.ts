@@ -29,6 +29,47 @@ export interface Workload {
   additionalDetails: AdditionalItem[];
   validations?: Validations;
   waypointWorkloads: Workload[];
+  isIngressGateway?: boolean;
+  ingressHostnames?: string[];
+  autoscaler?: WorkloadAutoscaler;
+  serviceAccounts?: WorkloadServiceAccount[];
+}
+
+export interface WorkloadAutoscaler {
+  name: string;
+  minReplicas: number;
+  maxReplicas: number;
+  currentReplicas: number;
+  desiredReplicas: number;
+  metrics: AutoscalerMetric[];
+  lastScaleTime?: string;
+  lastScaleEvent?: AutoscalerEvent;
+}
+
+export interface AutoscalerMetric {
+  type: string;
+  name: string;
+  target: string;
+  current?: string;
+}
+
+export interface AutoscalerEvent {
+  reason: string;
+  message: string;
+  lastSeen: string;
+}
+
+export interface WorkloadServiceAccount {
+  name: string;
+  principal: string;
+  authorizationPolicies: AuthorizationPrincipalMatch[];
+}
+
+export interface AuthorizationPrincipalMatch {
+  authorizationPolicy: ObjectReference;
+  action: string;
+  principal: string;
+  path: string;
 }
 
 export const emptyWorkload: Workload = {
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/graph/config/cytoscape"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// SnapshotVersion is the version of the snapshot format. It's increased by the changes breaking the
+// snapshots saved before, the snapshots of other versions are rejected when loaded.
+const SnapshotVersion = 1
+
+// Snapshot is a point-in-time capture of the graph of some namespaces, with their Istio config and health.
+// It's saved as a single JSON artifact, to be attached to a bug report and loaded back for a read-only view.
+type Snapshot struct {
+	Version    int                 `json:"version"`
+	Timestamp  int64               `json:"timestamp"`
+	Cluster    string              `json:"cluster"`
+	Graph      cytoscape.Config    `json:"graph"`
+	Namespaces []NamespaceSnapshot `json:"namespaces"`
+}
+
+// NamespaceSnapshot is the Istio config and the health of a namespace of a snapshot
+type NamespaceSnapshot struct {
+	Name           string                         `json:"name"`
+	IstioConfig    models.IstioConfigList         `json:"istioConfig"`
+	AppHealth      models.NamespaceAppHealth      `json:"appHealth"`
+	ServiceHealth  models.NamespaceServiceHealth  `json:"serviceHealth"`
+	WorkloadHealth models.NamespaceWorkloadHealth `json:"workloadHealth"`
+}
+
+// CaptureSnapshot captures a snapshot of the namespaces graph, generated with the options like GraphNamespaces,
+// with the Istio config and the health of the namespaces in the cluster. Only the cytoscape config is supported.
+func CaptureSnapshot(ctx context.Context, business *business.Layer, o graph.Options, cluster string) *Snapshot {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "CaptureSnapshot",
+		observability.Attribute("package", "api"),
+		observability.Attribute("cluster", cluster),
+	)
+	defer end()
+
+	if o.ConfigVendor != graph.VendorCytoscape {
+		graph.Error(fmt.Sprintf("ConfigVendor [%s] not supported by snapshots", o.ConfigVendor))
+	}
+
+	_, config := GraphNamespaces(ctx, business, o)
+	return captureSnapshot(ctx, business, o, cluster, config.(cytoscape.Config))
+}
+
+// captureSnapshot provides a test hook that accepts a generated graph
+func captureSnapshot(ctx context.Context, business *business.Layer, o graph.Options, cluster string, config cytoscape.Config) *Snapshot {
+	snapshot := &Snapshot{
+		Version:    SnapshotVersion,
+		Timestamp:  o.TelemetryOptions.QueryTime,
+		Cluster:    cluster,
+		Graph:      config,
+		Namespaces: []NamespaceSnapshot{},
+	}
+
+	namespaces := make([]string, 0, len(o.Namespaces))
+	for namespace := range o.Namespaces {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+
+	for _, namespace := range namespaces {
+		istioConfig, err := business.IstioConfig.GetIstioConfigList(ctx, snapshotIstioConfigCriteria(cluster, namespace))
+		graph.CheckError(err)
+
+		criteria := snapshotHealthCriteria(cluster, namespace, o)
+		appHealth, err := business.Health.GetNamespaceAppHealth(ctx, criteria)
+		graph.CheckError(err)
+		serviceHealth, err := business.Health.GetNamespaceServiceHealth(ctx, criteria)
+		graph.CheckError(err)
+		workloadHealth, err := business.Health.GetNamespaceWorkloadHealth(ctx, criteria)
+		graph.CheckError(err)
+
+		snapshot.Namespaces = append(snapshot.Namespaces, NamespaceSnapshot{
+			Name:           namespace,
+			IstioConfig:    istioConfig,
+			AppHealth:      appHealth,
+			ServiceHealth:  serviceHealth,
+			WorkloadHealth: workloadHealth,
+		})
+	}
+
+	return snapshot
+}
+
+func snapshotIstioConfigCriteria(cluster, namespace string) business.IstioConfigCriteria {
+	return business.IstioConfigCriteria{
+		Cluster:                       cluster,
+		Namespace:                     namespace,
+		IncludeGateways:               true,
+		IncludeVirtualServices:        true,
+		IncludeDestinationRules:       true,
+		IncludeServiceEntries:         true,
+		IncludeSidecars:               true,
+		IncludeAuthorizationPolicies:  true,
+		IncludePeerAuthentications:    true,
+		IncludeWorkloadEntries:        true,
+		IncludeWorkloadGroups:         true,
+		IncludeRequestAuthentications: true,
+		IncludeEnvoyFilters:           true,
+	}
+}
+
+func snapshotHealthCriteria(cluster, namespace string, o graph.Options) business.NamespaceHealthCriteria {
+	// The health is computed over the duration of the graph
+	return business.NamespaceHealthCriteria{
+		Cluster:        cluster,
+		Namespace:      namespace,
+		IncludeMetrics: true,
+		QueryTime:      time.Unix(o.TelemetryOptions.QueryTime, 0),
+		RateInterval:   fmt.Sprintf("%ds", int64(o.TelemetryOptions.Duration.Seconds())),
+	}
+}
+
+// Save writes the snapshot as JSON
+func (s *Snapshot) Save(w io.Writer) error {
+	return json.NewEncoder(w).Encode(s)
+}
+
+// LoadSnapshot reads a snapshot written by Save, for a read-only view: nothing of the snapshot is applied.
+// The snapshots of another version of the format are rejected.
+func LoadSnapshot(r io.Reader) (*Snapshot, error) {
+	snapshot := &Snapshot{}
+	if err := json.NewDecoder(r).Decode(snapshot); err != nil {
+		return nil, fmt.Errorf("invalid snapshot: %w", err)
+	}
+	if snapshot.Version != SnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version [%d], the supported version is [%d]", snapshot.Version, SnapshotVersion)
+	}
+	return snapshot, nil
+}
@@ -23,6 +23,11 @@ type appParams struct {
 	// Optional
 	IncludeHealth         bool `json:"health"`
 	IncludeIstioResources bool `json:"istioResources"`
+	// Label used to group the workloads into applications. Defaults to the app label.
+	//
+	// in: query
+	// required: false
+	GroupLabel string `json:"groupLabel"`
 }
 
 func (p *appParams) extract(r *http.Request) {
@@ -41,6 +46,7 @@ func (p *appParams) extract(r *http.Request) {
 	if err != nil {
 		p.IncludeIstioResources = true
 	}
+	p.GroupLabel = query.Get("groupLabel")
 }
 
 // AppList is the API handler to fetch all the apps to be displayed, related to a single namespace
@@ -49,7 +55,7 @@ func AppList(w http.ResponseWriter, r *http.Request) {
 	p.extract(r)
 
 	criteria := business.AppCriteria{Namespace: p.Namespace, IncludeIstioResources: p.IncludeIstioResources,
-		IncludeHealth: p.IncludeHealth, RateInterval: p.RateInterval, QueryTime: p.QueryTime}
+		IncludeHealth: p.IncludeHealth, RateInterval: p.RateInterval, QueryTime: p.QueryTime, GroupLabel: p.GroupLabel}
 
 	// Get business layer
 	business, err := getBusiness(r)
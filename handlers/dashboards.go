@@ -171,7 +171,13 @@ func ServiceDashboard(w http.ResponseWriter, r *http.Request) {
 
 	svc, err := b.Svc.GetService(r.Context(), cluster, namespace, service)
 	if err != nil {
-		RespondWithError(w, http.StatusServiceUnavailable, err.Error())
+		if errors.IsForbidden(err) {
+			RespondWithError(w, http.StatusForbidden, err.Error())
+		} else if errors.IsNotFound(err) {
+			RespondWithError(w, http.StatusNotFound, err.Error())
+		} else {
+			RespondWithError(w, http.StatusServiceUnavailable, err.Error())
+		}
 		return
 	}
 
@@ -21,6 +21,12 @@ func handleErrorResponse(w http.ResponseWriter, err error, extraMesg ...string)
 	log.Error(errorMsg)
 	if business.IsAccessibleError(err) {
 		RespondWithError(w, http.StatusForbidden, errorMsg)
+	} else if business.IsIstioConfigConflictError(err) {
+		RespondWithError(w, http.StatusConflict, errorMsg)
+	} else if errors.IsBadRequest(err) {
+		RespondWithError(w, http.StatusBadRequest, errorMsg)
+	} else if errors.IsForbidden(err) {
+		RespondWithError(w, http.StatusForbidden, errorMsg)
 	} else if errors.IsNotFound(err) {
 		RespondWithError(w, http.StatusNotFound, errorMsg)
 	} else if errors.IsServiceUnavailable(err) {
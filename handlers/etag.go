@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// etagResponseWriter buffers a response, so its ETag can be computed before it is sent
+type etagResponseWriter struct {
+	header     http.Header
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (erw *etagResponseWriter) Header() http.Header {
+	return erw.header
+}
+
+func (erw *etagResponseWriter) Write(b []byte) (int, error) {
+	if erw.statusCode == 0 {
+		erw.statusCode = http.StatusOK
+	}
+	return erw.body.Write(b)
+}
+
+func (erw *etagResponseWriter) WriteHeader(statusCode int) {
+	if erw.statusCode == 0 {
+		erw.statusCode = statusCode
+	}
+}
+
+// WithETag sets a strong ETag, the hash of the body, on the successful responses of a handler.
+// When the ETag matches the If-None-Match header of the request, the body is not sent again and
+// the response is a 304 Not Modified. Meant for the endpoints polled by the UI, whose response rarely changes.
+func WithETag(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		erw := &etagResponseWriter{header: http.Header{}}
+		next(erw, r)
+		if erw.statusCode == 0 {
+			erw.statusCode = http.StatusOK
+		}
+
+		for key, values := range erw.header {
+			w.Header()[key] = values
+		}
+		if erw.statusCode != http.StatusOK {
+			w.WriteHeader(erw.statusCode)
+			_, _ = w.Write(erw.body.Bytes())
+			return
+		}
+
+		hash := sha256.Sum256(erw.body.Bytes())
+		etag := `"` + hex.EncodeToString(hash[:]) + `"`
+		w.Header().Set("ETag", etag)
+		if etagMatches(r.Header.Get("If-None-Match"), etag) {
+			w.Header().Del("Content-Type")
+			w.Header().Del("Content-Length")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(erw.body.Bytes())
+	}
+}
+
+// etagMatches tells whether an If-None-Match header matches an ETag, using the weak comparison of RFC 7232
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -15,6 +15,7 @@ package handlers
 // The current Handlers:
 //   GraphNamespaces: Generate a graph for one or more requested namespaces.
 //   GraphNode:       Generate a graph for a specific node, detailing the immediate incoming and outgoing traffic.
+//   GraphSnapshot:   Capture a snapshot of a namespaces graph with the Istio config and health of the namespaces.
 //
 // The handlers accept the following query parameters (see notes below)
 //   appenders:       Comma-separated list of TelemetryVendor-specific appenders to run. (default: all)
@@ -30,6 +31,7 @@ package handlers
 //  Note: vendors may support additional, vendor-specific query parameters.
 //
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"runtime/debug"
@@ -65,6 +67,39 @@ func GraphNode(w http.ResponseWriter, r *http.Request) {
 	respond(w, code, payload)
 }
 
+// GraphSnapshot is a REST http.HandlerFunc capturing a snapshot of the graph of 1 or more namespaces,
+// with their Istio config and health, downloaded as a single JSON artifact
+func GraphSnapshot(w http.ResponseWriter, r *http.Request) {
+	defer handlePanic(w)
+
+	o := graph.NewOptions(r)
+
+	business, err := getBusiness(r)
+	graph.CheckError(err)
+
+	snapshot := api.CaptureSnapshot(r.Context(), business, o, clusterNameFromQuery(r.URL.Query()))
+	w.Header().Set("Content-Disposition", "attachment; filename=\"kiali-snapshot.json\"")
+	respond(w, http.StatusOK, snapshot)
+}
+
+// maxSnapshotSize is the maximum size, in bytes, of a snapshot loaded by GraphSnapshotLoad
+const maxSnapshotSize = 50 << 20
+
+// GraphSnapshotLoad is a REST http.HandlerFunc loading back a snapshot captured by GraphSnapshot, for a read-only view
+func GraphSnapshotLoad(w http.ResponseWriter, r *http.Request) {
+	snapshot, err := api.LoadSnapshot(http.MaxBytesReader(w, r.Body, maxSnapshotSize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			RespondWithError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("the snapshot is larger than the limit of %d bytes", maxBytesErr.Limit))
+			return
+		}
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	respond(w, http.StatusOK, snapshot)
+}
+
 func handlePanic(w http.ResponseWriter) {
 	code := http.StatusInternalServerError
 	if r := recover(); r != nil {
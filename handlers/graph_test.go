@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/graph/api"
+)
+
+func TestGraphSnapshotLoad(t *testing.T) {
+	require := require.New(t)
+
+	load := func(body []byte) int {
+		w := httptest.NewRecorder()
+		GraphSnapshotLoad(w, httptest.NewRequest(http.MethodPost, "/api/namespaces/graph/snapshot", bytes.NewReader(body)))
+		return w.Code
+	}
+
+	require.Equal(http.StatusOK, load([]byte(fmt.Sprintf(`{"version": %d}`, api.SnapshotVersion))))
+	require.Equal(http.StatusBadRequest, load([]byte(`{"graph": [`)))
+
+	tooLarge := append([]byte(`{"cluster": "`), bytes.Repeat([]byte("a"), maxSnapshotSize)...)
+	require.Equal(http.StatusRequestEntityTooLarge, load(append(tooLarge, []byte(`"}`)...)))
+}
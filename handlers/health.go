@@ -38,7 +38,7 @@ func NamespaceHealth(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	healthCriteria := business.NamespaceHealthCriteria{Namespace: p.Namespace, Cluster: p.Cluster, RateInterval: rateInterval, QueryTime: p.QueryTime, IncludeMetrics: true}
+	healthCriteria := business.NamespaceHealthCriteria{Namespace: p.Namespace, Cluster: p.Cluster, RateInterval: rateInterval, QueryTime: p.QueryTime, IncludeMetrics: true, OnlyUnhealthy: p.OnlyUnhealthy}
 	switch p.Type {
 	case "app":
 		health, err := businessLayer.Health.GetNamespaceAppHealth(r.Context(), healthCriteria)
@@ -108,6 +108,11 @@ type namespaceHealthParams struct {
 	// pattern: ^(app|service|workload)$
 	// default: app
 	Type string `json:"type"`
+	// Return only the degraded or failing workloads. Only for the "workload" type.
+	//
+	// in: query
+	// default: false
+	OnlyUnhealthy bool `json:"onlyUnhealthy"`
 }
 
 func (p *namespaceHealthParams) extract(r *http.Request) (bool, string) {
@@ -121,6 +126,13 @@ func (p *namespaceHealthParams) extract(r *http.Request) (bool, string) {
 		}
 		p.Type = healthType
 	}
+	if onlyUnhealthy := queryParams.Get("onlyUnhealthy"); onlyUnhealthy != "" {
+		value, err := strconv.ParseBool(onlyUnhealthy)
+		if err != nil {
+			return false, "Bad request, query parameter 'onlyUnhealthy' must be a boolean"
+		}
+		p.OnlyUnhealthy = value
+	}
 	return true, ""
 }
 
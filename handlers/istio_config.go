@@ -2,12 +2,14 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"net/http"
 	"strings"
 	"sync"
 
 	"github.com/gorilla/mux"
+	"k8s.io/apimachinery/pkg/api/errors"
 
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/config"
@@ -48,6 +50,15 @@ func IstioConfigList(w http.ResponseWriter, r *http.Request) {
 		labelSelector = query.Get("labelSelector")
 	}
 
+	annotationSelector := ""
+	if _, found := query["annotationSelector"]; found {
+		annotationSelector = query.Get("annotationSelector")
+		if _, err := business.ParseAnnotationSelector(annotationSelector); err != nil {
+			RespondWithError(w, http.StatusBadRequest, "Invalid annotationSelector: "+err.Error())
+			return
+		}
+	}
+
 	workloadSelector := ""
 	if _, found := query["workloadSelector"]; found {
 		workloadSelector = query.Get("workloadSelector")
@@ -58,7 +69,9 @@ func IstioConfigList(w http.ResponseWriter, r *http.Request) {
 		includeValidations = false
 	}
 
-	criteria := business.ParseIstioConfigCriteria(cluster, namespace, objects, labelSelector, workloadSelector, allNamespaces)
+	criteria := business.ParseIstioConfigCriteria(cluster, namespace, objects, labelSelector, annotationSelector, workloadSelector, allNamespaces)
+	// The response reports the types that timed out
+	criteria.AllowPartial = true
 
 	// Get business layer
 	business, err := getBusiness(r)
@@ -119,12 +132,31 @@ func IstioConfigList(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Sorted, so the ETag of the response only changes with the config
+	istioConfig.Sort()
+
+	// All the namespaces are fetched from the registry
+	if criteria.AllNamespaces {
+		setRegistryFreshnessHeaders(w, business)
+	}
+
+	var response interface{} = istioConfig
+	count := istioConfig.Count()
 	if len(nss) > 0 {
 		// From allNamespaces load only requested ones
-		RespondWithJSON(w, http.StatusOK, istioConfig.FilterIstioConfigs(nss))
-	} else {
-		RespondWithJSON(w, http.StatusOK, istioConfig)
+		istioConfigs := istioConfig.FilterIstioConfigs(nss)
+		response = istioConfigs
+		count = istioConfigs.Count()
 	}
+
+	// Checked before marshalling, serializing the whole registry can exhaust the memory of the UI
+	if maxObjects := config.Get().ExternalServices.Istio.RegistryMaxObjects; criteria.AllNamespaces && maxObjects > 0 && count > maxObjects {
+		RespondWithError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf(
+			"the Istio config of all namespaces has %d objects, more than the limit of %d. Narrow the query by namespace, object type or annotation selector", count, maxObjects))
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, response)
 }
 
 func IstioConfigDetails(w http.ResponseWriter, r *http.Request) {
@@ -192,6 +224,7 @@ func IstioConfigDetails(w http.ResponseWriter, r *http.Request) {
 			istioConfigDetails = istioConfigDetailsReg
 			istioConfigDetails.IstioConfigHelpFields = models.IstioConfigHelpMessages["internal"]
 			err = nil
+			setRegistryFreshnessHeaders(w, business)
 		}
 	}
 
@@ -270,7 +303,9 @@ func IstioConfigUpdate(w http.ResponseWriter, r *http.Request) {
 		RespondWithError(w, http.StatusBadRequest, "Update request with bad update patch: "+err.Error())
 	}
 	jsonPatch := string(body)
-	updatedConfigDetails, err := business.IstioConfig.UpdateIstioConfigDetail(cluster, namespace, objectType, object, jsonPatch)
+	// The expected resourceVersion of the object, to reject the update when the object has been modified since
+	resourceVersion := query.Get("resourceVersion")
+	updatedConfigDetails, err := business.IstioConfig.UpdateIstioConfigDetail(cluster, namespace, objectType, object, jsonPatch, resourceVersion)
 
 	if err != nil {
 		handleErrorResponse(w, err)
@@ -317,6 +352,35 @@ func IstioConfigCreate(w http.ResponseWriter, r *http.Request) {
 	RespondWithJSON(w, http.StatusOK, createdConfigDetails)
 }
 
+// IstioConfigBundleValidate validates a multi-document YAML bundle of Istio objects before it is applied to the namespace
+func IstioConfigBundleValidate(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	namespace := params["namespace"]
+
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Bundle could not be read: "+err.Error())
+		return
+	}
+
+	validations, err := business.IstioConfig.ValidateBundle(r.Context(), namespace, body)
+	if err != nil {
+		if errors.IsBadRequest(err) {
+			RespondWithError(w, http.StatusBadRequest, "Invalid bundle: "+err.Error())
+			return
+		}
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, validations)
+}
+
 func checkObjectType(objectType string) bool {
 	return business.GetIstioAPI(objectType)
 }
@@ -348,7 +412,11 @@ func IstioConfigPermissions(w http.ResponseWriter, r *http.Request) {
 	istioConfigPermissions := models.IstioConfigPermissions{}
 	if len(namespaces) > 0 {
 		ns := strings.Split(namespaces, ",")
-		istioConfigPermissions = business.IstioConfig.GetIstioConfigPermissions(r.Context(), ns, cluster)
+		istioConfigPermissions, err = business.IstioConfig.GetIstioConfigPermissions(r.Context(), ns, cluster)
+		if err != nil {
+			handleErrorResponse(w, err)
+			return
+		}
 	}
 	RespondWithJSON(w, http.StatusOK, istioConfigPermissions)
 }
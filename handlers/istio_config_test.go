@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/business/authentication"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+)
+
+func TestIstioConfigListNotModified(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&networking_v1beta1.VirtualService{ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"}},
+	)
+	business.SetupBusinessLayer(t, k8s, *conf)
+
+	mr := mux.NewRouter()
+	mr.HandleFunc("/api/namespaces/{namespace}/istio", WithETag(
+		func(w http.ResponseWriter, r *http.Request) {
+			context := authentication.SetAuthInfoContext(r.Context(), &api.AuthInfo{Token: "test"})
+			IstioConfigList(w, r.WithContext(context))
+		}))
+	ts := httptest.NewServer(mr)
+	t.Cleanup(ts.Close)
+
+	poll := func(etag string) *http.Response {
+		req, err := http.NewRequest("GET", ts.URL+"/api/namespaces/bookinfo/istio", nil)
+		require.NoError(err)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		resp, err := ts.Client().Do(req)
+		require.NoError(err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	resp := poll("")
+	body, _ := io.ReadAll(resp.Body)
+	require.Equal(http.StatusOK, resp.StatusCode, string(body))
+	assert.Contains(string(body), `"reviews"`)
+	etag := resp.Header.Get("ETag")
+	require.NotEmpty(etag)
+
+	// Nothing changed: the body is not sent again
+	resp = poll(etag)
+	body, _ = io.ReadAll(resp.Body)
+	assert.Equal(http.StatusNotModified, resp.StatusCode)
+	assert.Empty(body)
+	assert.Equal(etag, resp.Header.Get("ETag"))
+
+	_, err := k8s.Istio().NetworkingV1beta1().VirtualServices("bookinfo").Create(context.TODO(),
+		&networking_v1beta1.VirtualService{ObjectMeta: meta_v1.ObjectMeta{Name: "ratings", Namespace: "bookinfo"}}, meta_v1.CreateOptions{})
+	require.NoError(err)
+
+	// The cache is updated asynchronously
+	require.Eventually(func() bool {
+		resp = poll(etag)
+		return resp.StatusCode == http.StatusOK
+	}, 5*time.Second, 50*time.Millisecond)
+	body, _ = io.ReadAll(resp.Body)
+	assert.Contains(string(body), `"ratings"`)
+	assert.NotEqual(etag, resp.Header.Get("ETag"))
+}
+
+func TestIstioConfigUpdateBadPatch(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&networking_v1beta1.VirtualService{ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo", ResourceVersion: "100"}},
+	)
+	business.SetupBusinessLayer(t, k8s, *conf)
+
+	mr := mux.NewRouter()
+	mr.HandleFunc("/api/namespaces/{namespace}/istio/{object_type}/{object}", func(w http.ResponseWriter, r *http.Request) {
+		context := authentication.SetAuthInfoContext(r.Context(), &api.AuthInfo{Token: "test"})
+		IstioConfigUpdate(w, r.WithContext(context))
+	})
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest("PATCH", "/api/namespaces/bookinfo/istio/virtualservices/reviews?resourceVersion=100",
+		strings.NewReader(`[{"op": "remove"`))
+	mr.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+	assert.Contains(t, rr.Body.String(), "invalid JSON patch")
+}
+
+func TestWithETagSkipsErrors(t *testing.T) {
+	handler := WithETag(func(w http.ResponseWriter, r *http.Request) {
+		RespondWithError(w, http.StatusInternalServerError, "boom")
+	})
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/istio/config", nil)
+	req.Header.Set("If-None-Match", "*")
+	handler(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Empty(t, rr.Header().Get("ETag"))
+	assert.Contains(t, rr.Body.String(), "boom")
+}
+
+func TestIstioConfigListRegistryAge(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.RegistryStaleThreshold = 5 * 60
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}})
+	cache := business.SetupBusinessLayer(t, k8s, *conf)
+
+	mr := mux.NewRouter()
+	mr.HandleFunc("/api/istio/config", func(w http.ResponseWriter, r *http.Request) {
+		context := authentication.SetAuthInfoContext(r.Context(), &api.AuthInfo{Token: "test"})
+		IstioConfigList(w, r.WithContext(context))
+	})
+	ts := httptest.NewServer(mr)
+	t.Cleanup(ts.Close)
+
+	get := func() *http.Response {
+		resp, err := ts.Client().Get(ts.URL + "/api/istio/config")
+		require.NoError(err)
+		t.Cleanup(func() { resp.Body.Close() })
+		body, _ := io.ReadAll(resp.Body)
+		require.Equal(http.StatusOK, resp.StatusCode, string(body))
+		return resp
+	}
+
+	cache.SetRegistryStatus(&kubernetes.RegistryStatus{
+		Configuration: &kubernetes.RegistryConfiguration{
+			VirtualServices: []*networking_v1beta1.VirtualService{{ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"}}},
+		},
+		FetchedAt: time.Now().Add(-time.Minute),
+	})
+	resp := get()
+	age, err := strconv.Atoi(resp.Header.Get("Age"))
+	require.NoError(err)
+	assert.GreaterOrEqual(age, 60)
+	assert.Empty(resp.Header.Get("Warning"))
+
+	// istiod could not be reached for a while
+	cache.SetRegistryStatus(&kubernetes.RegistryStatus{
+		Configuration: &kubernetes.RegistryConfiguration{},
+		FetchedAt:     time.Now().Add(-10 * time.Minute),
+	})
+	resp = get()
+	age, err = strconv.Atoi(resp.Header.Get("Age"))
+	require.NoError(err)
+	assert.GreaterOrEqual(age, 600)
+	assert.Equal(`110 - "Response is Stale"`, resp.Header.Get("Warning"))
+}
+
+func TestIstioConfigListRegistryMaxObjects(t *testing.T) {
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.RegistryMaxObjects = 2
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}})
+	cache := business.SetupBusinessLayer(t, k8s, *conf)
+	cache.SetRegistryStatus(&kubernetes.RegistryStatus{
+		Configuration: &kubernetes.RegistryConfiguration{
+			VirtualServices: []*networking_v1beta1.VirtualService{
+				{ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"}},
+				{ObjectMeta: meta_v1.ObjectMeta{Name: "ratings", Namespace: "bookinfo"}},
+			},
+			DestinationRules: []*networking_v1beta1.DestinationRule{
+				{ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"}},
+			},
+		},
+		FetchedAt: time.Now(),
+	})
+
+	mr := mux.NewRouter()
+	mr.HandleFunc("/api/istio/config", func(w http.ResponseWriter, r *http.Request) {
+		context := authentication.SetAuthInfoContext(r.Context(), &api.AuthInfo{Token: "test"})
+		IstioConfigList(w, r.WithContext(context))
+	})
+	ts := httptest.NewServer(mr)
+	t.Cleanup(ts.Close)
+
+	get := func(query string) int {
+		resp, err := ts.Client().Get(ts.URL + "/api/istio/config" + query)
+		require.NoError(err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	require.Equal(http.StatusRequestEntityTooLarge, get(""))
+	require.Equal(http.StatusRequestEntityTooLarge, get("?namespaces=bookinfo"))
+	// Narrowing the query by type keeps the list under the limit
+	require.Equal(http.StatusOK, get("?objects=virtualservices"))
+}
@@ -22,6 +22,24 @@ func GetClusters(w http.ResponseWriter, r *http.Request) {
 	RespondWithJSON(w, http.StatusOK, meshClusters)
 }
 
+// RefreshClusters rediscovers the Kiali instances of the clusters that are part of the mesh
+// and writes the refreshed list of clusters to the HTTP response.
+func RefreshClusters(w http.ResponseWriter, r *http.Request) {
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Business layer initialization error: "+err.Error())
+		return
+	}
+
+	meshClusters, err := business.Mesh.RefreshClusters(r.Context())
+	if err != nil {
+		RespondWithError(w, http.StatusServiceUnavailable, "Cannot refresh mesh clusters: "+err.Error())
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, meshClusters)
+}
+
 func OutboundTrafficPolicyMode(w http.ResponseWriter, r *http.Request) {
 	business, err := getBusiness(r)
 	if err != nil {
@@ -54,3 +72,23 @@ func IstiodCanariesStatus(w http.ResponseWriter, r *http.Request) {
 	irt, _ := business.Mesh.CanaryUpgradeStatus()
 	RespondWithJSON(w, http.StatusOK, irt)
 }
+
+// MeshConfig writes to the HTTP response the mesh config of a control plane revision, the default
+// revision when the "revision" query param is not set.
+func MeshConfig(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Business layer initialization error: "+err.Error())
+		return
+	}
+
+	meshConfig, err := business.Mesh.GetMeshConfig(r.Context(), clusterNameFromQuery(queryParams), queryParams.Get("revision"))
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, meshConfig)
+}
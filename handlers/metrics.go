@@ -8,9 +8,12 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/prometheus/common/model"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
 
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/config"
@@ -84,6 +87,70 @@ func getWorkloadMetrics(w http.ResponseWriter, r *http.Request, promSupplier pro
 	RespondWithJSON(w, http.StatusOK, metrics)
 }
 
+// WorkloadsMetrics is the API handler to fetch the metrics of several workloads of a namespace in a single request,
+// either the workloads listed in the query or the workloads of an app
+func WorkloadsMetrics(w http.ResponseWriter, r *http.Request) {
+	getWorkloadsMetrics(w, r, defaultPromClientSupplier)
+}
+
+// getWorkloadsMetrics (mock-friendly version)
+func getWorkloadsMetrics(w http.ResponseWriter, r *http.Request, promSupplier promClientSupplier) {
+	vars := mux.Vars(r)
+	namespace := vars["namespace"]
+	query := r.URL.Query()
+	cluster := clusterNameFromQuery(query)
+
+	workloads := []string{}
+	if wks := query.Get("workloads"); wks != "" {
+		workloads = strings.Split(wks, ",")
+	}
+	app := query.Get("app")
+	if (len(workloads) == 0) == (app == "") {
+		RespondWithError(w, http.StatusBadRequest, "bad request, either the query parameter 'workloads' or 'app' must be set")
+		return
+	}
+
+	metricsService, namespaceInfo := createMetricsServiceForNamespace(w, r, promSupplier, namespace)
+	if metricsService == nil {
+		// any returned value nil means error & response already written
+		return
+	}
+
+	params := models.IstioMetricsQuery{Cluster: cluster, Namespace: namespace}
+	err := extractIstioMetricsQueryParams(r, &params, namespaceInfo)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if app != "" {
+		layer, err := getBusiness(r)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		appDetails, err := layer.App.GetAppDetails(r.Context(), business.AppCriteria{Namespace: namespace, Cluster: cluster, AppName: app})
+		if err != nil {
+			handleErrorResponse(w, err)
+			return
+		}
+		for _, wk := range appDetails.Workloads {
+			workloads = append(workloads, wk.WorkloadName)
+		}
+	}
+
+	metrics, err := metricsService.GetWorkloadsMetrics(params, workloads, nil)
+	if err != nil {
+		if api_errors.IsBadRequest(err) {
+			RespondWithError(w, http.StatusBadRequest, err.Error())
+		} else {
+			RespondWithError(w, http.StatusServiceUnavailable, err.Error())
+		}
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, metrics)
+}
+
 // ServiceMetrics is the API handler to fetch metrics to be displayed, related to a single service
 func ServiceMetrics(w http.ResponseWriter, r *http.Request) {
 	getServiceMetrics(w, r, defaultPromClientSupplier)
@@ -116,6 +183,47 @@ func getServiceMetrics(w http.ResponseWriter, r *http.Request, promSupplier prom
 	RespondWithJSON(w, http.StatusOK, metrics)
 }
 
+// ServiceMetricsByWorkload is the API handler to fetch metrics of a single service broken down by the
+// destination workloads that served the requests
+func ServiceMetricsByWorkload(w http.ResponseWriter, r *http.Request) {
+	getServiceMetricsByWorkload(w, r, defaultPromClientSupplier)
+}
+
+// getServiceMetricsByWorkload (mock-friendly version)
+func getServiceMetricsByWorkload(w http.ResponseWriter, r *http.Request, promSupplier promClientSupplier) {
+	vars := mux.Vars(r)
+	namespace := vars["namespace"]
+	service := vars["service"]
+
+	metricsService, namespaceInfo := createMetricsServiceForNamespace(w, r, promSupplier, namespace)
+	if metricsService == nil {
+		// any returned value nil means error & response already written
+		return
+	}
+
+	params := models.IstioMetricsQuery{Cluster: clusterNameFromQuery(r.URL.Query()), Namespace: namespace, Service: service}
+	err := extractIstioMetricsQueryParams(r, &params, namespaceInfo)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	// Traffic served by the destination workloads is inbound to the service.
+	if r.URL.Query().Get("direction") == "" {
+		params.Direction = "inbound"
+	}
+
+	metrics, err := metricsService.GetServiceMetricsByWorkload(params, nil)
+	if err != nil {
+		if api_errors.IsBadRequest(err) {
+			RespondWithError(w, http.StatusBadRequest, err.Error())
+		} else {
+			RespondWithError(w, http.StatusServiceUnavailable, err.Error())
+		}
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, metrics)
+}
+
 // AggregateMetrics is the API handler to fetch metrics to be displayed, related to a single aggregate
 func AggregateMetrics(w http.ResponseWriter, r *http.Request) {
 	getAggregateMetrics(w, r, defaultPromClientSupplier)
@@ -237,7 +345,15 @@ func extractIstioMetricsQueryParams(r *http.Request, q *models.IstioMetricsQuery
 }
 
 func extractBaseMetricsQueryParams(queryParams url.Values, q *prometheus.RangeQuery, namespaceInfo *models.Namespace) error {
+	promConfig := config.Get().ExternalServices.Prometheus
 	if ri := queryParams.Get("rateInterval"); ri != "" {
+		interval, err := model.ParseDuration(ri)
+		if err != nil {
+			return errors.New("bad request, cannot parse query parameter 'rateInterval'")
+		}
+		if maxInterval := time.Duration(promConfig.MaxRateInterval) * time.Second; maxInterval > 0 && time.Duration(interval) > maxInterval {
+			return fmt.Errorf("bad request, query parameter 'rateInterval' [%s] exceeds the maximum rate interval [%s]", ri, model.Duration(maxInterval))
+		}
 		q.RateInterval = ri
 	}
 	if rf := queryParams.Get("rateFunc"); rf != "" {
@@ -256,6 +372,9 @@ func extractBaseMetricsQueryParams(queryParams url.Values, q *prometheus.RangeQu
 	if dur := queryParams.Get("duration"); dur != "" {
 		if num, err := strconv.ParseInt(dur, 10, 64); err == nil {
 			duration := time.Duration(num) * time.Second
+			if maxDuration := time.Duration(promConfig.MaxQueryDuration) * time.Second; maxDuration > 0 && duration > maxDuration {
+				return fmt.Errorf("bad request, query parameter 'duration' [%ds] exceeds the maximum query duration [%s]", num, model.Duration(maxDuration))
+			}
 			q.Start = q.End.Add(-duration)
 		} else {
 			return errors.New("bad request, cannot parse query parameter 'duration'")
@@ -291,6 +410,14 @@ func extractBaseMetricsQueryParams(queryParams url.Values, q *prometheus.RangeQu
 	if lbls, ok := queryParams["byLabels[]"]; ok && len(lbls) > 0 {
 		q.ByLabels = lbls
 	}
+	if exemplarsStr := queryParams.Get("exemplars"); exemplarsStr != "" {
+		exemplars, err := strconv.ParseBool(exemplarsStr)
+		if err != nil {
+			return errors.New("bad request, cannot parse query parameter 'exemplars'")
+		}
+		// Silently ignored when disabled, not all the backends store exemplars
+		q.Exemplars = exemplars && promConfig.QueryExemplars
+	}
 
 	// If needed, adjust interval -- Make sure query won't fetch data before the namespace creation
 	intervalStartTime, err := util.GetStartTimeForRateInterval(q.End, q.RateInterval)
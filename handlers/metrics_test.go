@@ -140,6 +140,39 @@ func TestExtractMetricsQueryStartTimeBoundary(t *testing.T) {
 	assert.Equal(t, namespaceTimestamp.Add(1*time.Minute).UTC(), mq.Start.UTC())
 }
 
+func TestExtractMetricsQueryExemplars(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	extract := func(exemplars string) (models.IstioMetricsQuery, error) {
+		req, err := http.NewRequest("GET", "http://host/api/namespaces/ns/workloads/wk/metrics?exemplars="+exemplars, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mq := models.IstioMetricsQuery{Namespace: "ns"}
+		err = extractIstioMetricsQueryParams(req, &mq, buildNamespace("ns", time.Time{}))
+		return mq, err
+	}
+
+	// Ignored when the exemplars are disabled
+	mq, err := extract("true")
+	assert.NoError(t, err)
+	assert.False(t, mq.Exemplars)
+
+	conf.ExternalServices.Prometheus.QueryExemplars = true
+	config.Set(conf)
+	mq, err = extract("true")
+	assert.NoError(t, err)
+	assert.True(t, mq.Exemplars)
+
+	mq, err = extract("false")
+	assert.NoError(t, err)
+	assert.False(t, mq.Exemplars)
+
+	_, err = extract("maybe")
+	assert.Error(t, err)
+}
+
 func buildNamespace(name string, creationTime time.Time) *models.Namespace {
 	return &models.Namespace{
 		Name:              name,
@@ -3,6 +3,7 @@ package handlers
 import (
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gorilla/mux"
@@ -20,7 +21,12 @@ func NamespaceList(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	namespaces, err := business.Namespace.GetNamespaces(r.Context())
+	var namespaces []models.Namespace
+	if meshMember, _ := strconv.ParseBool(r.URL.Query().Get("meshMember")); meshMember {
+		namespaces, err = business.Namespace.GetNamespacesMeshMembership(r.Context())
+	} else {
+		namespaces, err = business.Namespace.GetNamespaces(r.Context())
+	}
 	if err != nil {
 		log.Error(err)
 		RespondWithError(w, http.StatusInternalServerError, err.Error())
@@ -30,6 +36,26 @@ func NamespaceList(w http.ResponseWriter, r *http.Request) {
 	RespondWithJSON(w, http.StatusOK, namespaces)
 }
 
+// NamespaceAccess is the API handler to fetch, per cluster, the namespaces the user can access
+// along with a coarse summary of the user's capabilities in each of them.
+func NamespaceAccess(w http.ResponseWriter, r *http.Request) {
+	business, err := getBusiness(r)
+	if err != nil {
+		log.Error(err)
+		RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	access, err := business.Namespace.GetNamespaceAccess(r.Context())
+	if err != nil {
+		log.Error(err)
+		RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, access)
+}
+
 // NamespaceValidationSummary is the API handler to fetch validations summary to be displayed.
 // It is related to all the Istio Objects within the namespace
 func NamespaceValidationSummary(w http.ResponseWriter, r *http.Request) {
@@ -142,3 +168,21 @@ func NamespaceUpdate(w http.ResponseWriter, r *http.Request) {
 	audit(r, "UPDATE on Namespace: "+namespace+" Patch: "+jsonPatch)
 	RespondWithJSON(w, http.StatusOK, ns)
 }
+
+// AccessibleNamespacesRBAC is the diagnostics API handler reporting, for each accessible namespace of the
+// configuration the user can access, the resources the Kiali service account isn't allowed to list
+func AccessibleNamespacesRBAC(w http.ResponseWriter, r *http.Request) {
+	business, err := getBusiness(r)
+	if err != nil {
+		log.Error(err)
+		RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	checks, err := business.Namespace.ValidateAccessibleNamespacesRBAC(r.Context())
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, checks)
+}
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -14,6 +16,8 @@ import (
 	prom_v1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	auth_v1 "k8s.io/api/authorization/v1"
 	core_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/clientcmd/api"
@@ -21,7 +25,9 @@ import (
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/business/authentication"
 	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/prometheus"
 	"github.com/kiali/kiali/prometheus/prometheustest"
 )
@@ -133,6 +139,108 @@ func TestNamespaceMetricsInaccessibleNamespace(t *testing.T) {
 	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
 }
 
+func TestNamespaceAccess(t *testing.T) {
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.Deployment.AccessibleNamespaces = []string{"bookinfo", "tutorial"}
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "tutorial"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "ns"}},
+	)
+	k8s.ResourceRules = map[string][]auth_v1.ResourceRule{
+		"bookinfo": {{Verbs: []string{"*"}, APIGroups: []string{"security.istio.io"}, Resources: []string{"*"}}},
+		"tutorial": {{Verbs: []string{"get", "list"}, APIGroups: []string{"*"}, Resources: []string{"*"}}},
+	}
+	business.SetupBusinessLayer(t, k8s, *conf)
+
+	mr := mux.NewRouter()
+	mr.HandleFunc("/api/namespaces/access", http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			context := authentication.SetAuthInfoContext(r.Context(), &api.AuthInfo{Token: "test"})
+			NamespaceAccess(w, r.WithContext(context))
+		}))
+	ts := httptest.NewServer(mr)
+	t.Cleanup(ts.Close)
+
+	resp, err := http.Get(ts.URL + "/api/namespaces/access")
+	require.NoError(err)
+	defer resp.Body.Close()
+	require.Equal(http.StatusOK, resp.StatusCode)
+
+	var access []models.ClusterAccess
+	require.NoError(json.NewDecoder(resp.Body).Decode(&access))
+	require.Equal([]models.ClusterAccess{
+		{
+			Cluster: conf.KubernetesConfig.ClusterName,
+			Namespaces: []models.NamespaceAccess{
+				{Name: "bookinfo", CanWriteIstioConfig: true},
+				{Name: "tutorial", CanWriteIstioConfig: false},
+			},
+		},
+	}, access)
+}
+
+// Allows the service account to list everything but the resources of the forbidden namespace
+type ssarForbidden struct {
+	kubernetes.ClientInterface
+	forbiddenNamespace string
+}
+
+func (s *ssarForbidden) GetSelfSubjectAccessReview(ctx context.Context, namespace, api, resourceType string, verbs []string) ([]*auth_v1.SelfSubjectAccessReview, error) {
+	ssars := []*auth_v1.SelfSubjectAccessReview{}
+	for _, verb := range verbs {
+		ssars = append(ssars, &auth_v1.SelfSubjectAccessReview{
+			Spec: auth_v1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &auth_v1.ResourceAttributes{Namespace: namespace, Verb: verb, Group: api, Resource: resourceType},
+			},
+			Status: auth_v1.SubjectAccessReviewStatus{Allowed: namespace != s.forbiddenNamespace},
+		})
+	}
+	return ssars, nil
+}
+
+func TestAccessibleNamespacesRBAC(t *testing.T) {
+	require := require.New(t)
+
+	conf := config.NewConfig()
+	conf.Deployment.AccessibleNamespaces = []string{"bookinfo", "tutorial"}
+	conf.ExternalServices.Istio.IstioAPIEnabled = false
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "tutorial"}},
+	)
+	business.SetupBusinessLayer(t, &ssarForbidden{k8s, "tutorial"}, *conf)
+
+	mr := mux.NewRouter()
+	mr.HandleFunc("/api/diagnostics/accessible_namespaces", http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			context := authentication.SetAuthInfoContext(r.Context(), &api.AuthInfo{Token: "test"})
+			AccessibleNamespacesRBAC(w, r.WithContext(context))
+		}))
+	ts := httptest.NewServer(mr)
+	t.Cleanup(ts.Close)
+
+	resp, err := http.Get(ts.URL + "/api/diagnostics/accessible_namespaces")
+	require.NoError(err)
+	defer resp.Body.Close()
+	require.Equal(http.StatusOK, resp.StatusCode)
+
+	var checks []models.AccessibleNamespaceRBAC
+	require.NoError(json.NewDecoder(resp.Body).Decode(&checks))
+	require.Len(checks, 2)
+	require.Equal("bookinfo", checks[0].Namespace)
+	require.Empty(checks[0].MissingResources)
+	require.Equal("tutorial", checks[1].Namespace)
+	require.Contains(checks[1].MissingResources, "pods")
+	require.Contains(checks[1].MissingResources, "deployments.apps")
+}
+
 func setupNamespaceMetricsEndpoint(t *testing.T) (*httptest.Server, *prometheustest.PromAPIMock) {
 	client, xapi := setupMocked(t)
 
@@ -2,8 +2,11 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
+
+	"github.com/kiali/kiali/business"
 )
 
 func ConfigDump(w http.ResponseWriter, r *http.Request) {
@@ -50,3 +53,86 @@ func ConfigDumpResourceEntries(w http.ResponseWriter, r *http.Request) {
 
 	RespondWithJSON(w, http.StatusOK, dump)
 }
+
+func ServiceEndpointsHealth(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	queryParams := r.URL.Query()
+
+	proxyPod := queryParams.Get("proxyPod")
+	if proxyPod == "" {
+		RespondWithError(w, http.StatusBadRequest, "proxyPod query param is required")
+		return
+	}
+
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	cluster := clusterNameFromQuery(queryParams)
+	namespace := params["namespace"]
+	service := params["service"]
+	proxyNamespace := queryParams.Get("proxyNamespace")
+	if proxyNamespace == "" {
+		proxyNamespace = namespace
+	}
+
+	endpointsHealth, err := business.ProxyStatus.GetRegistryEndpointsHealth(cluster, namespace, service, proxyNamespace, proxyPod)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, endpointsHealth)
+}
+
+const (
+	defaultProxyStatusPageSize = 20
+	maxProxyStatusPageSize     = 500
+)
+
+// ProxyStatuses returns a page of the sync status of the proxies of the mesh,
+// optionally filtered by the cluster and namespace query params.
+func ProxyStatuses(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+
+	page := 1
+	if p := queryParams.Get("page"); p != "" {
+		num, err := strconv.Atoi(p)
+		if err != nil || num < 1 {
+			RespondWithError(w, http.StatusBadRequest, "Invalid page: "+p)
+			return
+		}
+		page = num
+	}
+	pageSize := defaultProxyStatusPageSize
+	if ps := queryParams.Get("pageSize"); ps != "" {
+		num, err := strconv.Atoi(ps)
+		if err != nil || num < 1 || num > maxProxyStatusPageSize {
+			RespondWithError(w, http.StatusBadRequest, "Invalid pageSize: "+ps)
+			return
+		}
+		pageSize = num
+	}
+
+	businessLayer, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	criteria := business.ProxyStatusCriteria{
+		Cluster:   queryParams.Get("cluster"),
+		Namespace: queryParams.Get("namespace"),
+		Page:      page,
+		PageSize:  pageSize,
+	}
+	proxyStatuses, err := businessLayer.ProxyStatus.GetProxyStatuses(r.Context(), criteria)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, proxyStatuses)
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/business/authentication"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/cache"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+func TestServiceEndpointsHealthRequiresProxyPod(t *testing.T) {
+	mr := mux.NewRouter()
+	mr.HandleFunc("/api/namespaces/{namespace}/services/{service}/endpoints/health", ServiceEndpointsHealth)
+
+	ts := httptest.NewServer(mr)
+	t.Cleanup(ts.Close)
+
+	resp, err := ts.Client().Get(ts.URL + "/api/namespaces/bookinfo/services/reviews/endpoints/health")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// proxyStatusCache returns fixed proxy statuses instead of the ones polled from istiod.
+type proxyStatusCache struct {
+	cache.KialiCache
+	proxyStatuses []*kubernetes.ProxyStatus
+}
+
+func (c *proxyStatusCache) GetProxyStatuses(cluster, namespace string) []*kubernetes.ProxyStatus {
+	proxyStatuses := []*kubernetes.ProxyStatus{}
+	for _, ps := range c.proxyStatuses {
+		if (cluster == "" || cluster == ps.ClusterID) && (namespace == "" || strings.HasSuffix(ps.ProxyID, "."+namespace)) {
+			proxyStatuses = append(proxyStatuses, ps)
+		}
+	}
+	return proxyStatuses
+}
+
+func setupProxyStatuses(t *testing.T) *httptest.Server {
+	conf := config.NewConfig()
+	config.Set(conf)
+	cluster := conf.KubernetesConfig.ClusterName
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "travels"}},
+	)
+	cache := business.SetupBusinessLayer(t, k8s, *conf)
+	proxyStatuses := []*kubernetes.ProxyStatus{}
+	for _, proxyID := range []string{"reviews-v1.bookinfo", "details-v1.bookinfo", "productpage-v1.bookinfo", "cars-v1.travels"} {
+		proxyStatuses = append(proxyStatuses, &kubernetes.ProxyStatus{SyncStatus: kubernetes.SyncStatus{ClusterID: cluster, ProxyID: proxyID}})
+	}
+	business.WithKialiCache(&proxyStatusCache{KialiCache: cache, proxyStatuses: proxyStatuses})
+
+	mr := mux.NewRouter()
+	mr.HandleFunc("/api/mesh/proxy_status", http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			context := authentication.SetAuthInfoContext(r.Context(), &api.AuthInfo{Token: "test"})
+			ProxyStatuses(w, r.WithContext(context))
+		}))
+
+	ts := httptest.NewServer(mr)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestProxyStatusesEndpoint(t *testing.T) {
+	ts := setupProxyStatuses(t)
+
+	resp, err := http.Get(ts.URL + "/api/mesh/proxy_status?namespace=bookinfo&page=2&pageSize=2")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	actual, _ := io.ReadAll(resp.Body)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(actual))
+
+	var proxyStatuses models.ProxyStatusList
+	require.NoError(t, json.Unmarshal(actual, &proxyStatuses))
+	assert.Equal(t, 3, proxyStatuses.Total)
+	assert.Equal(t, 2, proxyStatuses.Page)
+	assert.Equal(t, 2, proxyStatuses.PageSize)
+	require.Len(t, proxyStatuses.Items, 1)
+	assert.Equal(t, "reviews-v1", proxyStatuses.Items[0].Pod)
+}
+
+func TestProxyStatusesEndpointDefaultPage(t *testing.T) {
+	ts := setupProxyStatuses(t)
+
+	resp, err := http.Get(ts.URL + "/api/mesh/proxy_status")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	actual, _ := io.ReadAll(resp.Body)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(actual))
+
+	var proxyStatuses models.ProxyStatusList
+	require.NoError(t, json.Unmarshal(actual, &proxyStatuses))
+	assert.Equal(t, 4, proxyStatuses.Total)
+	assert.Equal(t, 1, proxyStatuses.Page)
+	assert.Equal(t, defaultProxyStatusPageSize, proxyStatuses.PageSize)
+	assert.Len(t, proxyStatuses.Items, 4)
+}
+
+func TestProxyStatusesEndpointBadParams(t *testing.T) {
+	ts := setupProxyStatuses(t)
+
+	for _, query := range []string{"page=0", "page=abc", "pageSize=0", "pageSize=501"} {
+		resp, err := http.Get(ts.URL + "/api/mesh/proxy_status?" + query)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+	}
+}
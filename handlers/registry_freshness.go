@@ -0,0 +1,21 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/kiali/kiali/business"
+)
+
+// setRegistryFreshnessHeaders sets the age of the registry data on a response built from it, with the Age header.
+// When the registry is older than the stale threshold, a Warning header flags the response as stale.
+func setRegistryFreshnessHeaders(w http.ResponseWriter, layer *business.Layer) {
+	freshness := layer.RegistryStatus.GetRegistryFreshness()
+	if freshness == nil {
+		return
+	}
+	w.Header().Set("Age", strconv.FormatInt(int64(freshness.Age.Seconds()), 10))
+	if freshness.Stale {
+		w.Header().Set("Warning", `110 - "Response is Stale"`)
+	}
+}
@@ -78,6 +78,7 @@ func ServiceList(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	setRegistryFreshnessHeaders(w, business)
 	RespondWithJSON(w, http.StatusOK, serviceList)
 }
 
@@ -138,6 +139,7 @@ func ServiceDetails(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	setRegistryFreshnessHeaders(w, business)
 	RespondWithJSON(w, http.StatusOK, serviceDetails)
 }
 
@@ -325,6 +325,95 @@ func TestServiceMetricsInaccessibleNamespace(t *testing.T) {
 	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
 }
 
+func TestServiceMetricsByWorkload(t *testing.T) {
+	ts, api := setupServiceMetricsEndpoint(t)
+
+	req, err := http.NewRequest("GET", ts.URL+"/api/namespaces/ns/services/svc/metrics/workloads", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := req.URL.Query()
+	q.Add("byLabels[]", "destination_canonical_revision")
+	q.Add("filters[]", "request_count")
+	req.URL.RawQuery = q.Encode()
+
+	var gaugeSentinel uint32
+
+	api.SpyArgumentsAndReturnEmpty(func(args mock.Arguments) {
+		query := args[1].(string)
+		assert.Contains(t, query, "destination_service_name=\"svc\"")
+		assert.Contains(t, query, "destination_service_namespace=\"ns\"")
+		assert.Contains(t, query, " by (destination_workload,destination_version,destination_canonical_revision)")
+		atomic.AddUint32(&gaugeSentinel, 1)
+	})
+
+	httpclient := &http.Client{}
+	resp, err := httpclient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, _ := io.ReadAll(resp.Body)
+
+	assert.NotEmpty(t, actual)
+	assert.Equal(t, 200, resp.StatusCode, string(actual))
+	// Assert branch coverage
+	assert.NotZero(t, gaugeSentinel)
+}
+
+func TestServiceMetricsByWorkloadBadGrouping(t *testing.T) {
+	ts, api := setupServiceMetricsEndpoint(t)
+
+	req, err := http.NewRequest("GET", ts.URL+"/api/namespaces/ns/services/svc/metrics/workloads", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := req.URL.Query()
+	q.Add("byLabels[]", "source_workload")
+	req.URL.RawQuery = q.Encode()
+
+	api.SpyArgumentsAndReturnEmpty(func(args mock.Arguments) {
+		// Make sure there's no client call and we fail fast
+		t.Error("Unexpected call to client while having bad request")
+	})
+
+	httpclient := &http.Client{}
+	resp, err := httpclient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, _ := io.ReadAll(resp.Body)
+
+	assert.Equal(t, 400, resp.StatusCode)
+	assert.Contains(t, string(actual), "cannot be grouped by 'source_workload'")
+}
+
+func TestServiceMetricsByWorkloadBadDirection(t *testing.T) {
+	ts, api := setupServiceMetricsEndpoint(t)
+
+	req, err := http.NewRequest("GET", ts.URL+"/api/namespaces/ns/services/svc/metrics/workloads", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := req.URL.Query()
+	q.Add("direction", "outbound")
+	req.URL.RawQuery = q.Encode()
+
+	api.SpyArgumentsAndReturnEmpty(func(args mock.Arguments) {
+		// Make sure there's no client call and we fail fast
+		t.Error("Unexpected call to client while having bad request")
+	})
+
+	httpclient := &http.Client{}
+	resp, err := httpclient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, _ := io.ReadAll(resp.Body)
+
+	assert.Equal(t, 400, resp.StatusCode)
+	assert.Contains(t, string(actual), "'inbound' direction")
+}
+
 func setupServiceMetricsEndpoint(t *testing.T) (*httptest.Server, *prometheustest.PromAPIMock) {
 	conf := config.NewConfig()
 	config.Set(conf)
@@ -346,6 +435,13 @@ func setupServiceMetricsEndpoint(t *testing.T) (*httptest.Server, *prometheustes
 				return prom, nil
 			})
 		}))
+	mr.HandleFunc("/api/namespaces/{namespace}/services/{service}/metrics/workloads", http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			context := authentication.SetAuthInfoContext(r.Context(), &api.AuthInfo{Token: "test"})
+			getServiceMetricsByWorkload(w, r.WithContext(context), func() (*prometheus.Client, error) {
+				return prom, nil
+			})
+		}))
 
 	ts := httptest.NewServer(mr)
 	t.Cleanup(ts.Close)
@@ -31,6 +31,12 @@ type workloadParams struct {
 	Cluster               string `json:"cluster,omitempty"`
 	IncludeHealth         bool   `json:"health"`
 	IncludeIstioResources bool   `json:"istioResources"`
+	// Include the recent Warning events of the pods in the health of the workload details
+	IncludeEvents bool `json:"events"`
+	// Include in the pods the chain of the controllers owning them
+	IncludeOwnerChain bool `json:"ownerChain"`
+	// Include in the pods the resources of their istio-proxy container
+	IncludeProxyResources bool `json:"proxyResources"`
 }
 
 func (p *workloadParams) extract(r *http.Request) {
@@ -51,6 +57,9 @@ func (p *workloadParams) extract(r *http.Request) {
 	if err != nil {
 		p.IncludeIstioResources = true
 	}
+	p.IncludeEvents, _ = strconv.ParseBool(query.Get("events"))
+	p.IncludeOwnerChain, _ = strconv.ParseBool(query.Get("ownerChain"))
+	p.IncludeProxyResources, _ = strconv.ParseBool(query.Get("proxyResources"))
 }
 
 // WorkloadList is the API handler to fetch all the workloads to be displayed, related to a single namespace
@@ -91,7 +100,7 @@ func WorkloadDetails(w http.ResponseWriter, r *http.Request) {
 	p := workloadParams{}
 	p.extract(r)
 
-	criteria := business.WorkloadCriteria{Namespace: p.Namespace, WorkloadName: p.WorkloadName, WorkloadType: p.WorkloadType, IncludeIstioResources: true, IncludeServices: true, IncludeHealth: p.IncludeHealth, RateInterval: p.RateInterval, QueryTime: p.QueryTime, Cluster: p.Cluster}
+	criteria := business.WorkloadCriteria{Namespace: p.Namespace, WorkloadName: p.WorkloadName, WorkloadType: p.WorkloadType, IncludeIstioResources: true, IncludeServices: true, IncludeHealth: p.IncludeHealth, IncludeOwnerChain: p.IncludeOwnerChain, IncludeProxyResources: p.IncludeProxyResources, IncludeAutoscaler: true, IncludeServiceAccounts: true, RateInterval: p.RateInterval, QueryTime: p.QueryTime, Cluster: p.Cluster}
 
 	// Get business layer
 	business, err := getBusiness(r)
@@ -126,7 +135,7 @@ func WorkloadDetails(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if criteria.IncludeHealth && err == nil {
-		workloadDetails.Health, err = business.Health.GetWorkloadHealth(r.Context(), criteria.Namespace, criteria.Cluster, criteria.WorkloadName, criteria.RateInterval, criteria.QueryTime, workloadDetails)
+		workloadDetails.Health, err = business.Health.GetWorkloadHealth(r.Context(), criteria.Namespace, criteria.Cluster, criteria.WorkloadName, criteria.RateInterval, criteria.QueryTime, workloadDetails, p.IncludeEvents)
 		if err != nil {
 			handleErrorResponse(w, err)
 		}
@@ -264,3 +273,87 @@ func PodLogs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// WorkloadLogs streams the logs of all the pods of a workload, merged chronologically
+func WorkloadLogs(w http.ResponseWriter, r *http.Request) {
+	if config.IsFeatureDisabled(config.FeatureLogView) {
+		RespondWithError(w, http.StatusForbidden, "Pod Logs access is disabled")
+		return
+	}
+	vars := mux.Vars(r)
+	queryParams := r.URL.Query()
+
+	// Get business layer
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Workload Logs initialization error: "+err.Error())
+		return
+	}
+	cluster := clusterNameFromQuery(queryParams)
+	namespace := vars["namespace"]
+	workload := vars["workload"]
+
+	// Get log options, the containers are all the containers of the pods
+	opts, err := business.Workload.BuildLogOptionsCriteria(
+		"",
+		queryParams.Get("duration"),
+		"",
+		queryParams.Get("sinceTime"),
+		queryParams.Get("maxLines"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if tailLines := queryParams.Get("tailLines"); tailLines != "" {
+		numLines, err := strconv.ParseInt(tailLines, 10, 64)
+		if err != nil || numLines <= 0 {
+			RespondWithError(w, http.StatusBadRequest, "Invalid tailLines: "+tailLines)
+			return
+		}
+		opts.TailLines = &numLines
+	}
+
+	// Fetch workload logs
+	err = business.Workload.StreamWorkloadLogs(r.Context(), cluster, namespace, workload, opts, w)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+}
+
+// WorkloadDebugBundle streams a zip archive gathering the information needed to debug a workload
+func WorkloadDebugBundle(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	query := r.URL.Query()
+
+	// Get business layer
+	businessLayer, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Workload Debug Bundle initialization error: "+err.Error())
+		return
+	}
+	cluster := clusterNameFromQuery(query)
+	namespace := vars["namespace"]
+	workloadName := vars["workload"]
+
+	// The bundle includes the logs of the pods, check the access to the namespace before gathering anything
+	if _, err := businessLayer.Namespace.GetNamespaceByCluster(r.Context(), namespace, cluster); err != nil {
+		RespondWithError(w, http.StatusForbidden, "Cannot access namespace data: "+err.Error())
+		return
+	}
+
+	// The workload is fetched before streaming the archive, so its errors are still reported
+	criteria := business.WorkloadCriteria{Cluster: cluster, Namespace: namespace, WorkloadName: workloadName, WorkloadType: query.Get("type"), IncludeServices: true}
+	workload, err := businessLayer.Workload.GetWorkload(r.Context(), criteria)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s-%s-debug-bundle.zip\"", namespace, workloadName))
+	if err := businessLayer.Workload.WriteWorkloadDebugBundle(r.Context(), cluster, namespace, workload, w); err != nil {
+		// The response is already started, the archive is left incomplete
+		log.Errorf("Error writing the debug bundle of workload [%s] in namespace [%s]: %s", workloadName, namespace, err)
+	}
+}
@@ -1,6 +1,9 @@
 package handlers
 
 import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -13,6 +16,7 @@ import (
 	prom_v1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	core_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -21,9 +25,12 @@ import (
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/business/authentication"
 	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/prometheus"
 	"github.com/kiali/kiali/prometheus/prometheustest"
+	"github.com/kiali/kiali/tests/data"
 )
 
 func setupWorkloadList(t *testing.T, k8s *kubetest.FakeK8sClient) (*httptest.Server, *prometheustest.PromClientMock) {
@@ -113,6 +120,85 @@ func TestWorkloadMetricsDefault(t *testing.T) {
 	assert.NotZero(t, gaugeSentinel)
 }
 
+func TestWorkloadMetricsConfiguredDefaults(t *testing.T) {
+	ts, api := setupWorkloadMetricsEndpoint(t)
+	conf := config.Get()
+	conf.Server.MetricsQueryDefaults = config.MetricsQueryDefaults{Duration: 3600, RateInterval: "5m", Step: 60}
+	config.Set(conf)
+
+	url := ts.URL + "/api/namespaces/ns/workloads/my_workload/metrics"
+	now := time.Now()
+	// Range bounds get aligned to the step
+	delta := 60 * time.Second
+	var gaugeSentinel uint32
+
+	api.SpyArgumentsAndReturnEmpty(func(args mock.Arguments) {
+		query := args[1].(string)
+		r := args[2].(prom_v1.Range)
+		assert.Contains(t, query, "[5m]")
+		atomic.AddUint32(&gaugeSentinel, 1)
+		assert.Equal(t, 60*time.Second, r.Step)
+		assert.WithinDuration(t, now, r.End, delta)
+		assert.WithinDuration(t, now.Add(-1*time.Hour), r.Start, delta)
+	})
+
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual, _ := io.ReadAll(resp.Body)
+
+	assert.NotEmpty(t, actual)
+	assert.Equal(t, 200, resp.StatusCode, string(actual))
+	// Assert branch coverage
+	assert.NotZero(t, gaugeSentinel)
+}
+
+func TestWorkloadMetricsParamsOverrideConfiguredDefaults(t *testing.T) {
+	ts, api := setupWorkloadMetricsEndpoint(t)
+	conf := config.Get()
+	conf.Server.MetricsQueryDefaults = config.MetricsQueryDefaults{Duration: 3600, RateInterval: "5m", Step: 60}
+	config.Set(conf)
+
+	req, err := http.NewRequest("GET", ts.URL+"/api/namespaces/ns/workloads/my_workload/metrics", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := req.URL.Query()
+	q.Add("rateInterval", "30s")
+	q.Add("step", "5")
+	q.Add("duration", "600")
+	req.URL.RawQuery = q.Encode()
+
+	now := time.Now()
+	delta := 15 * time.Second
+	var gaugeSentinel uint32
+
+	api.SpyArgumentsAndReturnEmpty(func(args mock.Arguments) {
+		query := args[1].(string)
+		r := args[2].(prom_v1.Range)
+		assert.Contains(t, query, "[30s]")
+		assert.NotContains(t, query, "[5m]")
+		atomic.AddUint32(&gaugeSentinel, 1)
+		assert.Equal(t, 5*time.Second, r.Step)
+		assert.WithinDuration(t, now, r.End, delta)
+		assert.WithinDuration(t, now.Add(-10*time.Minute), r.Start, delta)
+	})
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual, _ := io.ReadAll(resp.Body)
+
+	assert.NotEmpty(t, actual)
+	assert.Equal(t, 200, resp.StatusCode, string(actual))
+	// Assert branch coverage
+	assert.NotZero(t, gaugeSentinel)
+}
+
 func TestWorkloadMetricsWithParams(t *testing.T) {
 	ts, api := setupWorkloadMetricsEndpoint(t)
 
@@ -230,6 +316,57 @@ func TestWorkloadMetricsBadDuration(t *testing.T) {
 	assert.Contains(t, string(actual), "cannot parse query parameter 'duration'")
 }
 
+func TestWorkloadMetricsDurationLimit(t *testing.T) {
+	ts, api := setupWorkloadMetricsEndpoint(t)
+
+	api.SpyArgumentsAndReturnEmpty(func(args mock.Arguments) {})
+
+	getMetrics := func(duration, rateInterval string) (int, string) {
+		req, err := http.NewRequest("GET", ts.URL+"/api/namespaces/ns/workloads/my-workload/metrics", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		q := req.URL.Query()
+		q.Add("duration", duration)
+		q.Add("rateInterval", rateInterval)
+		q.Add("filters[]", "request_count")
+		req.URL.RawQuery = q.Encode()
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(actual)
+	}
+
+	// 30 days, the longest duration offered by the UI, is allowed by default
+	code, body := getMetrics("2592000", "12h")
+	assert.Equal(t, 200, code, body)
+
+	// 90 days
+	code, body = getMetrics("7776000", "12h")
+	assert.Equal(t, 400, code)
+	assert.Contains(t, body, "query parameter 'duration' [7776000s] exceeds the maximum query duration [30d]")
+
+	code, body = getMetrics("3600", "2d")
+	assert.Equal(t, 400, code)
+	assert.Contains(t, body, "query parameter 'rateInterval' [2d] exceeds the maximum rate interval [1d]")
+
+	// The limits are configurable
+	conf := config.Get()
+	conf.ExternalServices.Prometheus.MaxQueryDuration = 3600
+	conf.ExternalServices.Prometheus.MaxRateInterval = 0
+	config.Set(conf)
+
+	code, body = getMetrics("7200", "1m")
+	assert.Equal(t, 400, code)
+	assert.Contains(t, body, "exceeds the maximum query duration [1h]")
+
+	code, body = getMetrics("3600", "2d")
+	assert.Equal(t, 200, code, body)
+}
+
 func TestWorkloadMetricsBadStep(t *testing.T) {
 	ts, api := setupWorkloadMetricsEndpoint(t)
 
@@ -320,6 +457,13 @@ func setupWorkloadMetricsEndpoint(t *testing.T) (*httptest.Server, *prometheuste
 				return prom, nil
 			})
 		}))
+	mr.HandleFunc("/api/namespaces/{namespace}/metrics/workloads", http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			context := authentication.SetAuthInfoContext(r.Context(), &api.AuthInfo{Token: "test"})
+			getWorkloadsMetrics(w, r.WithContext(context), func() (*prometheus.Client, error) {
+				return prom, nil
+			})
+		}))
 
 	ts := httptest.NewServer(mr)
 	t.Cleanup(ts.Close)
@@ -330,3 +474,189 @@ func setupWorkloadMetricsEndpoint(t *testing.T) (*httptest.Server, *prometheuste
 
 	return ts, xapi
 }
+
+func TestWorkloadsMetrics(t *testing.T) {
+	ts, api := setupWorkloadMetricsEndpoint(t)
+
+	req, err := http.NewRequest("GET", ts.URL+"/api/namespaces/ns/metrics/workloads", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := req.URL.Query()
+	q.Add("workloads", "my_workload,other_workload")
+	q.Add("filters[]", "request_count")
+	req.URL.RawQuery = q.Encode()
+
+	var myWorkloadSentinel, otherWorkloadSentinel uint32
+	api.SpyArgumentsAndReturnEmpty(func(args mock.Arguments) {
+		query := args[1].(string)
+		assert.Contains(t, query, `source_workload_namespace="ns"`)
+		if strings.Contains(query, `source_workload="my_workload"`) {
+			atomic.AddUint32(&myWorkloadSentinel, 1)
+		} else if strings.Contains(query, `source_workload="other_workload"`) {
+			atomic.AddUint32(&otherWorkloadSentinel, 1)
+		}
+	})
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, _ := io.ReadAll(resp.Body)
+
+	assert.Equal(t, 200, resp.StatusCode, string(actual))
+	assert.NotZero(t, myWorkloadSentinel)
+	assert.NotZero(t, otherWorkloadSentinel)
+}
+
+func TestWorkloadsMetricsMissingWorkloads(t *testing.T) {
+	ts, api := setupWorkloadMetricsEndpoint(t)
+
+	api.SpyArgumentsAndReturnEmpty(func(args mock.Arguments) {
+		// Make sure there's no client call and we fail fast
+		t.Error("Unexpected call to client while having bad request")
+	})
+
+	resp, err := http.Get(ts.URL + "/api/namespaces/ns/metrics/workloads")
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, _ := io.ReadAll(resp.Body)
+
+	assert.Equal(t, 400, resp.StatusCode)
+	assert.Contains(t, string(actual), "'workloads' or 'app'")
+}
+
+// debugBundleClient returns fixed logs and config dumps, the fake client can't stream logs nor port-forward to the proxies
+type debugBundleClient struct {
+	kubernetes.ClientInterface
+}
+
+func (c *debugBundleClient) StreamPodLogs(namespace, name string, opts *core_v1.PodLogOptions) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader("2023-01-01T00:00:00Z " + opts.Container + " started\n")), nil
+}
+
+func (c *debugBundleClient) GetConfigDump(namespace, podName string) (*kubernetes.ConfigDump, error) {
+	return &kubernetes.ConfigDump{Configs: []interface{}{map[string]interface{}{"@type": "type.googleapis.com/envoy.admin.v3.BootstrapConfigDump"}}}, nil
+}
+
+func setupWorkloadDebugBundle(t *testing.T, k8s kubernetes.ClientInterface) *httptest.Server {
+	business.SetupBusinessLayer(t, k8s, *config.Get())
+
+	mr := mux.NewRouter()
+	mr.HandleFunc("/api/namespaces/{namespace}/workloads/{workload}/debug_bundle", http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			context := authentication.SetAuthInfoContext(r.Context(), &api.AuthInfo{Token: "test"})
+			WorkloadDebugBundle(w, r.WithContext(context))
+		}))
+
+	ts := httptest.NewServer(mr)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestWorkloadDebugBundle(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.CacheEnabled = false
+	config.Set(conf)
+
+	kubeObjects := []runtime.Object{
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "Namespace"}},
+		&core_v1.Service{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "details", Namespace: "Namespace"},
+			Spec:       core_v1.ServiceSpec{Selector: map[string]string{"app": "details"}},
+		},
+		data.AddHttpRoutesToVirtualService(data.CreateHttpRouteDestination("details", "v1", 100),
+			data.CreateEmptyVirtualService("details", "Namespace", []string{"details"})),
+		data.CreateEmptyDestinationRule("Namespace", "details", "details"),
+		// Routing to another service, it's not part of the bundle
+		data.CreateEmptyDestinationRule("Namespace", "reviews", "reviews"),
+	}
+	for _, obj := range business.FakeDepSyncedWithRS() {
+		o := obj
+		kubeObjects = append(kubeObjects, &o)
+	}
+	for _, obj := range business.FakeRSSyncedWithPods() {
+		o := obj
+		kubeObjects = append(kubeObjects, &o)
+	}
+	for _, obj := range business.FakePodsSyncedWithDeployments() {
+		o := obj
+		// Labeled like the template of the deployment, to be one of its pods
+		o.Labels = map[string]string{"app": "details", "version": "v1"}
+		kubeObjects = append(kubeObjects, &o)
+	}
+	k8s := kubetest.NewFakeK8sClient(kubeObjects...)
+	k8s.OpenShift = false
+	ts := setupWorkloadDebugBundle(t, &debugBundleClient{k8s})
+
+	resp, err := http.Get(ts.URL + "/api/namespaces/Namespace/workloads/details-v1/debug_bundle")
+	require.NoError(err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(err)
+	require.Equal(http.StatusOK, resp.StatusCode, string(body))
+	assert.Equal("application/zip", resp.Header.Get("Content-Type"))
+	assert.Equal(`attachment; filename="Namespace-details-v1-debug-bundle.zip"`, resp.Header.Get("Content-Disposition"))
+
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	require.NoError(err)
+	entries := map[string]*zip.File{}
+	for _, f := range archive.File {
+		entries[f.Name] = f
+	}
+	pod := "details-v1-3618568057-dnkjp"
+	for _, name := range []string{
+		"workload.json",
+		"pods/" + pod + ".json",
+		"logs/" + pod + "/details.log",
+		"logs/" + pod + "/istio-proxy.log",
+		"config_dump/" + pod + ".json",
+		"istio_config.json",
+	} {
+		assert.Contains(entries, name)
+	}
+	// Every entry was fetched
+	assert.NotContains(entries, "errors.txt")
+
+	require.Contains(entries, "logs/"+pod+"/details.log")
+	f, err := entries["logs/"+pod+"/details.log"].Open()
+	require.NoError(err)
+	logs, err := io.ReadAll(f)
+	require.NoError(err)
+	require.NoError(f.Close())
+	assert.Equal("2023-01-01T00:00:00Z details started\n", string(logs))
+
+	require.Contains(entries, "istio_config.json")
+	f, err = entries["istio_config.json"].Open()
+	require.NoError(err)
+	defer f.Close()
+	istioConfig := models.IstioConfigList{}
+	require.NoError(json.NewDecoder(f).Decode(&istioConfig))
+	require.Len(istioConfig.VirtualServices, 1)
+	assert.Equal("details", istioConfig.VirtualServices[0].Name)
+	require.Len(istioConfig.DestinationRules, 1)
+	assert.Equal("details", istioConfig.DestinationRules[0].Name)
+}
+
+func TestWorkloadDebugBundleInaccessibleNamespace(t *testing.T) {
+	conf := config.NewConfig()
+	conf.KubernetesConfig.CacheEnabled = false
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "ns"}})
+	ts := setupWorkloadDebugBundle(t, &nsForbidden{k8s, "my_namespace"})
+
+	resp, err := http.Get(ts.URL + "/api/namespaces/my_namespace/workloads/my_workload/debug_bundle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode, string(body))
+	assert.NotEqual(t, "application/zip", resp.Header.Get("Content-Type"))
+}
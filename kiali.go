@@ -159,6 +159,14 @@ func validateConfig() error {
 		return fmt.Errorf("for security purposes, web root must not contain '/../': %v", webRoot)
 	}
 
+	if err := cfg.Server.MetricsQueryDefaults.Validate(); err != nil {
+		return err
+	}
+
+	if err := cfg.ExternalServices.Istio.ValidateReferencesExcludedNamespaces(); err != nil {
+		return err
+	}
+
 	// log some messages to let the administrator know when credentials are configured certain ways
 	auth := cfg.Auth
 	log.Infof("Using authentication strategy [%v]", auth.Strategy)
@@ -88,3 +88,62 @@ func TestValidateAuthStrategy(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateMetricsQueryDefaults(t *testing.T) {
+	// create a base config that we know is valid
+	conf := config.NewConfig()
+	conf.LoginToken.SigningKey = util.RandomString(16)
+	conf.Server.StaticContentRootDirectory = "."
+	conf.Auth.Strategy = "anonymous"
+
+	validDefaults := []config.MetricsQueryDefaults{
+		{Duration: 1800, RateInterval: "1m", Step: 15},
+		{Duration: 3600, RateInterval: "5m", Step: 3600},
+		{Duration: 86400, RateInterval: "1d", Step: 60},
+	}
+	invalidDefaults := []config.MetricsQueryDefaults{
+		{Duration: 0, RateInterval: "1m", Step: 15},
+		{Duration: 1800, RateInterval: "1m", Step: 0},
+		{Duration: 1800, RateInterval: "1m", Step: -15},
+		{Duration: 60, RateInterval: "1m", Step: 120},
+		{Duration: 1800, RateInterval: "", Step: 15},
+		{Duration: 1800, RateInterval: "0s", Step: 15},
+		{Duration: 1800, RateInterval: "one minute", Step: 15},
+	}
+
+	for _, defaults := range validDefaults {
+		conf.Server.MetricsQueryDefaults = defaults
+		config.Set(conf)
+		if err := validateConfig(); err != nil {
+			t.Errorf("Metrics query defaults validation should have succeeded for [%+v]: %v", defaults, err)
+		}
+	}
+
+	for _, defaults := range invalidDefaults {
+		conf.Server.MetricsQueryDefaults = defaults
+		config.Set(conf)
+		if err := validateConfig(); err == nil {
+			t.Errorf("Metrics query defaults validation should have failed [%+v]", defaults)
+		}
+	}
+}
+
+func TestValidateReferencesExcludedNamespaces(t *testing.T) {
+	// create a base config that we know is valid
+	conf := config.NewConfig()
+	conf.LoginToken.SigningKey = util.RandomString(16)
+	conf.Server.StaticContentRootDirectory = "."
+	conf.Auth.Strategy = "anonymous"
+
+	conf.ExternalServices.Istio.ReferencesExcludedNamespaces = []string{"istio-.*", "kube-system"}
+	config.Set(conf)
+	if err := validateConfig(); err != nil {
+		t.Errorf("References excluded namespaces validation should have succeeded: %v", err)
+	}
+
+	conf.ExternalServices.Istio.ReferencesExcludedNamespaces = []string{"kube-system", "istio-("}
+	config.Set(conf)
+	if err := validateConfig(); err == nil {
+		t.Errorf("References excluded namespaces validation should have failed")
+	}
+}
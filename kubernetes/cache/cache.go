@@ -30,11 +30,20 @@ type KialiCache interface {
 	// Embedded for backward compatibility for business methods that just use one cluster.
 	// All business methods should eventually use the multi-cluster cache.
 	KubeCache
+	ClustersCache
 	NamespacesCache
+	PermissionsCache
 	ProxyStatusCache
 	RegistryStatusCache
 }
 
+const (
+	// registryRefreshMinDelay is the window coalescing the registry refreshes triggered by the informer events
+	registryRefreshMinDelay = 100 * time.Millisecond
+	// registryRefreshMaxDelay bounds the backoff of the registry refresh while the events keep coming
+	registryRefreshMaxDelay = 2 * time.Second
+)
+
 // namespaceCache caches namespaces according to their token.
 type namespaceCache struct {
 	created          time.Time
@@ -60,32 +69,54 @@ type kialiCacheImpl struct {
 	clientFactory kubernetes.ClientFactory
 	// How often the cache will check for kiali SA client changes.
 	clientRefreshPollingPeriod time.Duration
+	clusters                   []kubernetes.Cluster
+	clustersCreated            time.Time
+	clustersDuration           time.Duration
+	clustersLock               sync.RWMutex
 	// Maps a cluster name to a KubeCache
 	kubeCache              map[string]KubeCache
 	refreshDuration        time.Duration
 	tokenLock              sync.RWMutex
 	tokenNamespaces        map[string]namespaceCache // TODO: Another option can be define here the namespaces by token/cluster
 	tokenNamespaceDuration time.Duration
-	proxyStatusLock        sync.RWMutex
-	proxyStatusNamespaces  map[string]map[string]map[string]podProxyStatus
-	registryStatusLock     sync.RWMutex
-	registryStatusCreated  *time.Time
-	registryStatus         *kubernetes.RegistryStatus
+	// RBAC permissions of the Istio config by token hash and resource
+	tokenPermissionsLock           sync.RWMutex
+	tokenPermissions               map[permissionsKey]permissionsCache
+	tokenPermissionsDuration       time.Duration
+	tokenPermissionsDeniedDuration time.Duration
+	tokenPermissionsSwept          time.Time
+	// Istio config permissions by token hash and namespace set
+	istioConfigPermissionsLock     sync.RWMutex
+	istioConfigPermissions         map[istioConfigPermissionsKey]istioConfigPermissionsCache
+	istioConfigPermissionsDuration time.Duration
+	proxyStatusLock                sync.RWMutex
+	proxyStatusNamespaces          map[string]map[string]map[string]podProxyStatus
+	registryStatusLock             sync.RWMutex
+	registryStatusCreated          *time.Time
+	registryStatus                 *kubernetes.RegistryStatus
 }
 
 func NewKialiCache(clientFactory kubernetes.ClientFactory, cfg config.Config, namespaceSeedList ...string) (KialiCache, error) {
 	kialiCacheImpl := kialiCacheImpl{
-		clientFactory:              clientFactory,
-		clientRefreshPollingPeriod: time.Duration(time.Second * 60),
-		kubeCache:                  make(map[string]KubeCache),
-		proxyStatusNamespaces:      make(map[string]map[string]map[string]podProxyStatus),
-		refreshDuration:            time.Duration(cfg.KubernetesConfig.CacheDuration) * time.Second,
-		tokenNamespaces:            make(map[string]namespaceCache),
-		tokenNamespaceDuration:     time.Duration(cfg.KubernetesConfig.CacheTokenNamespaceDuration) * time.Second,
+		clientFactory:                  clientFactory,
+		clientRefreshPollingPeriod:     time.Duration(time.Second * 60),
+		clustersDuration:               defaultClustersDuration,
+		kubeCache:                      make(map[string]KubeCache),
+		proxyStatusNamespaces:          make(map[string]map[string]map[string]podProxyStatus),
+		refreshDuration:                time.Duration(cfg.KubernetesConfig.CacheDuration) * time.Second,
+		tokenNamespaces:                make(map[string]namespaceCache),
+		tokenNamespaceDuration:         time.Duration(cfg.KubernetesConfig.CacheTokenNamespaceDuration) * time.Second,
+		tokenPermissions:               make(map[permissionsKey]permissionsCache),
+		tokenPermissionsDuration:       time.Duration(cfg.KubernetesConfig.CacheTokenPermissionsDuration) * time.Second,
+		tokenPermissionsDeniedDuration: time.Duration(cfg.KubernetesConfig.CacheTokenPermissionsDeniedDuration) * time.Second,
+		istioConfigPermissions:         make(map[istioConfigPermissionsKey]istioConfigPermissionsCache),
+		istioConfigPermissionsDuration: time.Duration(cfg.KubernetesConfig.CacheIstioConfigPermissionsDuration) * time.Second,
 	}
 
+	// The informers of every cluster trigger a refresh on each event, a burst of them refreshes the registry once
+	registryRefresh := newRefreshCoalescer(kialiCacheImpl.RefreshRegistryStatus, registryRefreshMinDelay, registryRefreshMaxDelay)
 	for cluster, client := range clientFactory.GetSAClients() {
-		cache, err := NewKubeCache(client, cfg, NewRegistryHandler(kialiCacheImpl.RefreshRegistryStatus), namespaceSeedList...)
+		cache, err := NewKubeCache(client, cfg, NewRegistryHandler(registryRefresh.trigger), namespaceSeedList...)
 		if err != nil {
 			log.Errorf("[Kiali Cache] Error creating kube cache for cluster: [%s]. Err: %v", cluster, err)
 			return nil, err
@@ -100,6 +131,9 @@ func NewKialiCache(clientFactory kubernetes.ClientFactory, cfg config.Config, na
 		}
 	}
 
+	// The informers have listed the existing objects, the registry is not fetched yet so it doesn't need a refresh
+	registryRefresh.cancel()
+
 	// TODO: Treat all clusters the same way.
 	// Ensure home client got set.
 	if kialiCacheImpl.KubeCache == nil {
@@ -1,6 +1,9 @@
 package cache
 
 import (
+	"sync"
+	"time"
+
 	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -44,3 +47,91 @@ func (sh RegistryRefreshHandler) OnUpdate(oldObj, newObj interface{}) {
 func (sh RegistryRefreshHandler) OnDelete(obj interface{}) {
 	sh.refresh()
 }
+
+// refreshCoalescer coalesces the refreshes triggered by a burst of events into a single refresh.
+// The refresh is delayed by a short window, doubled every time another event of the burst backs it off,
+// but never further than the max delay after the first event, so a storm of events still refreshes.
+type refreshCoalescer struct {
+	refresh  func()
+	minDelay time.Duration
+	maxDelay time.Duration
+	// now and afterFunc are time.Now and time.AfterFunc, replaced by a fake clock in the tests
+	now       func() time.Time
+	afterFunc func(d time.Duration, f func()) refreshTimer
+
+	lock       sync.Mutex
+	pending    bool
+	delay      time.Duration
+	deadline   time.Time
+	generation uint64
+	timer      refreshTimer
+}
+
+// refreshTimer is the part of time.Timer used by the refreshCoalescer
+type refreshTimer interface {
+	Stop() bool
+}
+
+func newRefreshCoalescer(refresh func(), minDelay, maxDelay time.Duration) *refreshCoalescer {
+	return &refreshCoalescer{
+		refresh:  refresh,
+		minDelay: minDelay,
+		maxDelay: maxDelay,
+		now:      time.Now,
+		afterFunc: func(d time.Duration, f func()) refreshTimer {
+			return time.AfterFunc(d, f)
+		},
+	}
+}
+
+// trigger schedules a refresh, or backs off the refresh already scheduled
+func (c *refreshCoalescer) trigger() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := c.now()
+	if c.pending {
+		c.timer.Stop()
+		// Capped, the doubled delay would overflow over a long burst
+		if c.delay < c.maxDelay {
+			c.delay *= 2
+		}
+	} else {
+		c.pending = true
+		c.delay = c.minDelay
+		c.deadline = now.Add(c.maxDelay)
+	}
+
+	wait := c.delay
+	if untilDeadline := c.deadline.Sub(now); wait > untilDeadline {
+		wait = untilDeadline
+	}
+	// A stopped timer may be already firing, only the last scheduled one refreshes
+	c.generation++
+	generation := c.generation
+	c.timer = c.afterFunc(wait, func() { c.fire(generation) })
+}
+
+// cancel drops the refresh scheduled, if any
+func (c *refreshCoalescer) cancel() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.pending {
+		c.timer.Stop()
+		c.pending = false
+		c.generation++
+	}
+}
+
+func (c *refreshCoalescer) fire(generation uint64) {
+	c.lock.Lock()
+	if generation != c.generation {
+		c.lock.Unlock()
+		return
+	}
+	c.pending = false
+	c.lock.Unlock()
+
+	c.refresh()
+}
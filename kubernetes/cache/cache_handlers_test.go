@@ -1,11 +1,15 @@
 package cache
 
 import (
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/kubernetes"
 )
 
 type fakeRegistryStatus struct {
@@ -87,3 +91,123 @@ func TestRegistryStatusUpdatedOnUpdate(t *testing.T) {
 		})
 	}
 }
+
+// fakeClock runs the timers of a refreshCoalescer synchronously when it is advanced
+type fakeClock struct {
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	at      time.Time
+	f       func()
+	stopped bool
+}
+
+func (t *fakeTimer) Stop() bool {
+	wasActive := !t.stopped
+	t.stopped = true
+	return wasActive
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) AfterFunc(d time.Duration, f func()) refreshTimer {
+	timer := &fakeTimer{at: c.now.Add(d), f: f}
+	c.timers = append(c.timers, timer)
+	return timer
+}
+
+// Advance moves the clock forward, firing the timers expiring meanwhile
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+	for _, timer := range c.timers {
+		if !timer.stopped && !timer.at.After(c.now) {
+			timer.stopped = true
+			timer.f()
+		}
+	}
+}
+
+func newFakeClockCoalescer(refresh func(), minDelay, maxDelay time.Duration) (*refreshCoalescer, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	coalescer := newRefreshCoalescer(refresh, minDelay, maxDelay)
+	coalescer.now = clock.Now
+	coalescer.afterFunc = clock.AfterFunc
+	return coalescer, clock
+}
+
+func TestRegistryRefreshCoalescedOnEventBurst(t *testing.T) {
+	assert := assert.New(t)
+	refreshes := 0
+	coalescer, clock := newFakeClockCoalescer(func() { refreshes++ }, 20*time.Millisecond, time.Second)
+	handler := NewRegistryHandler(coalescer.trigger)
+
+	for i := 0; i < 1000; i++ {
+		svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "svc" + strconv.Itoa(i), ResourceVersion: strconv.Itoa(i)}}
+		handler.OnAdd(svc)
+		handler.OnUpdate(svc, &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: svc.Name, ResourceVersion: strconv.Itoa(i + 1)}})
+		handler.OnDelete(svc)
+	}
+	clock.Advance(time.Second - time.Millisecond)
+	assert.Equal(0, refreshes)
+	// The backoff doesn't delay the refresh past the max delay
+	clock.Advance(time.Millisecond)
+	assert.Equal(1, refreshes)
+	// No other refresh is left behind
+	clock.Advance(time.Hour)
+	assert.Equal(1, refreshes)
+
+	// A later burst refreshes again
+	handler.OnAdd(&corev1.Service{})
+	clock.Advance(20 * time.Millisecond)
+	assert.Equal(2, refreshes)
+}
+
+func TestRegistryRefreshNotDelayedPastMaxDelay(t *testing.T) {
+	refreshes := 0
+	coalescer, clock := newFakeClockCoalescer(func() { refreshes++ }, 10*time.Millisecond, 50*time.Millisecond)
+
+	// The events keep coming for longer than the max delay, the registry is refreshed every max delay meanwhile
+	for i := 0; i < 60; i++ {
+		coalescer.trigger()
+		clock.Advance(5 * time.Millisecond)
+	}
+	assert.Equal(t, 6, refreshes)
+}
+
+func TestRegistryRefreshCanceled(t *testing.T) {
+	assert := assert.New(t)
+	refreshes := 0
+	coalescer, clock := newFakeClockCoalescer(func() { refreshes++ }, 20*time.Millisecond, time.Second)
+
+	coalescer.trigger()
+	coalescer.cancel()
+	clock.Advance(time.Hour)
+	assert.Equal(0, refreshes)
+
+	// The events after the cancel refresh again
+	coalescer.trigger()
+	clock.Advance(20 * time.Millisecond)
+	assert.Equal(1, refreshes)
+}
+
+func TestRegistryStatusRefreshedWhenCoalescedRefreshFires(t *testing.T) {
+	assert := assert.New(t)
+	cache := &kialiCacheImpl{refreshDuration: time.Minute}
+	coalescer, clock := newFakeClockCoalescer(cache.RefreshRegistryStatus, 20*time.Millisecond, time.Second)
+
+	cache.SetRegistryStatus(&kubernetes.RegistryStatus{})
+	coalescer.trigger()
+	clock.Advance(20 * time.Millisecond)
+	assert.Nil(cache.GetRegistryStatus())
+
+	// A status set meanwhile the refresh is coalesced may have missed the events, it is refreshed too
+	coalescer.trigger()
+	cache.SetRegistryStatus(&kubernetes.RegistryStatus{})
+	clock.Advance(20 * time.Millisecond)
+	assert.Nil(cache.GetRegistryStatus())
+	assert.False(cache.CheckRegistryStatus())
+}
@@ -0,0 +1,38 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/kiali/kiali/kubernetes"
+)
+
+// defaultClustersDuration is how long the clusters are kept in the cache. It's longer than the period the
+// clusters are refreshed at, they expire only when the refresh keeps failing.
+const defaultClustersDuration = 10 * time.Minute
+
+type (
+	ClustersCache interface {
+		// GetClusters returns the clusters of the mesh or nil when they haven't been set yet or they have expired.
+		GetClusters() []kubernetes.Cluster
+		SetClusters(clusters []kubernetes.Cluster)
+	}
+)
+
+func (c *kialiCacheImpl) GetClusters() []kubernetes.Cluster {
+	defer c.clustersLock.RUnlock()
+	c.clustersLock.RLock()
+	if c.clusters == nil || time.Since(c.clustersCreated) >= c.clustersDuration {
+		return nil
+	}
+	clusters := make([]kubernetes.Cluster, len(c.clusters))
+	copy(clusters, c.clusters)
+	return clusters
+}
+
+func (c *kialiCacheImpl) SetClusters(clusters []kubernetes.Cluster) {
+	defer c.clustersLock.Unlock()
+	c.clustersLock.Lock()
+	c.clusters = make([]kubernetes.Cluster, len(clusters))
+	copy(c.clusters, clusters)
+	c.clustersCreated = time.Now()
+}
@@ -0,0 +1,24 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/kubernetes"
+)
+
+func TestClustersExpire(t *testing.T) {
+	require := require.New(t)
+
+	kialiCache := &kialiCacheImpl{clustersDuration: time.Minute}
+	require.Nil(kialiCache.GetClusters())
+
+	kialiCache.SetClusters([]kubernetes.Cluster{{Name: "east"}})
+	require.Equal([]kubernetes.Cluster{{Name: "east"}}, kialiCache.GetClusters())
+
+	// The clusters are resolved again once expired
+	kialiCache.clustersCreated = time.Now().Add(-time.Minute)
+	require.Nil(kialiCache.GetClusters())
+}
@@ -67,6 +67,8 @@ type KubeCache interface {
 	GetEndpoints(namespace, name string) (*core_v1.Endpoints, error)
 	GetStatefulSets(namespace string) ([]apps_v1.StatefulSet, error)
 	GetStatefulSet(namespace, name string) (*apps_v1.StatefulSet, error)
+	// GetServices returns the services of the namespace. Like the client, when the labels of a workload are given as
+	// selectorLabels, only the services whose selector matches them are returned.
 	GetServices(namespace string, selectorLabels map[string]string) ([]core_v1.Service, error)
 	GetService(namespace string, name string) (*core_v1.Service, error)
 	GetPods(namespace, labelSelector string) ([]core_v1.Pod, error)
@@ -470,7 +472,7 @@ func (c *kubeCache) createGatewayInformers(namespace string) gateway.SharedInfor
 		if c.CheckIstioResource(kubernetes.K8sHTTPRoutes) {
 			lister.k8shttprouteLister = sharedInformers.Gateway().V1beta1().HTTPRoutes().Lister()
 			lister.cachesSynced = append(lister.cachesSynced, sharedInformers.Gateway().V1beta1().HTTPRoutes().Informer().HasSynced)
-			sharedInformers.Gateway().V1beta1().Gateways().Informer().AddEventHandler(c.registryRefreshHandler)
+			sharedInformers.Gateway().V1beta1().HTTPRoutes().Informer().AddEventHandler(c.registryRefreshHandler)
 		}
 	}
 	return sharedInformers
@@ -679,7 +681,7 @@ func (c *kubeCache) GetServices(namespace string, selectorLabels map[string]stri
 	// but it won't prevent other routines from reading from the lister.
 	defer c.cacheLock.RUnlock()
 	c.cacheLock.RLock()
-	services, err := c.getCacheLister(namespace).serviceLister.Services(namespace).List(labels.Set(selectorLabels).AsSelector())
+	services, err := c.getCacheLister(namespace).serviceLister.Services(namespace).List(labels.Everything())
 	if err != nil {
 		return nil, err
 	}
@@ -687,6 +689,13 @@ func (c *kubeCache) GetServices(namespace string, selectorLabels map[string]stri
 
 	retServices := []core_v1.Service{}
 	for _, service := range services {
+		// Like the client, selectorLabels are the labels of a workload, the services selecting it are returned
+		if selectorLabels != nil {
+			svcSelector := labels.Set(service.Spec.Selector).AsSelector()
+			if svcSelector.Empty() || !svcSelector.Matches(labels.Set(selectorLabels)) {
+				continue
+			}
+		}
 		// Do not modify what is returned by the lister since that is shared and will cause data races.
 		svc := service.DeepCopy()
 		svc.Kind = kubernetes.ServiceType
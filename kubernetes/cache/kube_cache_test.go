@@ -147,6 +147,39 @@ func TestCheckNamespaceIsIncluded(t *testing.T) {
 	assert.True(kialiCache.CheckNamespace("ns1"))
 }
 
+func TestGetServicesBySelectorLabels(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	ns := &core_v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+	reviews := &core_v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "reviews", Namespace: "test", Labels: map[string]string{"app": "reviews"}},
+		Spec:       core_v1.ServiceSpec{Selector: map[string]string{"app": "reviews"}},
+	}
+	reviewsV2 := &core_v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "reviews-v2", Namespace: "test"},
+		Spec:       core_v1.ServiceSpec{Selector: map[string]string{"app": "reviews", "version": "v2"}},
+	}
+	// A service without selector doesn't select any workload
+	external := &core_v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: "test", Labels: map[string]string{"app": "reviews", "version": "v1"}}}
+	kialiCache := newTestingKubeCache(t, config.NewConfig(), ns, reviews, reviewsV2, external)
+	kialiCache.Refresh("test")
+
+	services, err := kialiCache.GetServices("test", nil)
+	require.NoError(err)
+	assert.Len(services, 3)
+
+	// The selector labels are the labels of a workload, the services whose selector matches them are returned
+	services, err = kialiCache.GetServices("test", map[string]string{"app": "reviews", "version": "v1"})
+	require.NoError(err)
+	require.Len(services, 1)
+	assert.Equal("reviews", services[0].Name)
+
+	services, err = kialiCache.GetServices("test", map[string]string{"app": "ratings"})
+	require.NoError(err)
+	assert.Empty(services)
+}
+
 // Other parts of the codebase assume that this kind field is present so it's important
 // that the cache sets it.
 func TestKubeGetAndListReturnKindInfo(t *testing.T) {
@@ -18,17 +18,20 @@ type (
 func (c *kialiCacheImpl) SetNamespaces(token string, namespaces []models.Namespace) {
 	defer c.tokenLock.Unlock()
 	c.tokenLock.Lock()
+	// The namespaces are copied, the caller can keep using them while the cache is read concurrently
+	nsList := make([]models.Namespace, 0, len(namespaces))
 	clusterNamespace := make(map[string]map[string]models.Namespace)
 	for _, ns := range namespaces {
+		nsList = append(nsList, copyNamespace(ns))
 		if clusterNamespace[ns.Cluster] == nil {
 			clusterNamespace[ns.Cluster] = make(map[string]models.Namespace)
 		}
-		clusterNamespace[ns.Cluster][ns.Name] = ns
+		clusterNamespace[ns.Cluster][ns.Name] = copyNamespace(ns)
 	}
 
 	c.tokenNamespaces[token] = namespaceCache{
 		created:          time.Now(),
-		namespaces:       namespaces,
+		namespaces:       nsList,
 		clusterNamespace: clusterNamespace,
 	}
 }
@@ -41,7 +44,9 @@ func (c *kialiCacheImpl) GetNamespaces(token string) []models.Namespace {
 	} else {
 		var nsList []models.Namespace
 		if time.Since(nsToken.created) < c.tokenNamespaceDuration {
-			nsList = append(nsList, nsToken.namespaces...)
+			for _, ns := range nsToken.namespaces {
+				nsList = append(nsList, copyNamespace(ns))
+			}
 		}
 		return nsList
 	}
@@ -55,7 +60,8 @@ func (c *kialiCacheImpl) GetNamespace(token string, namespace string, cluster st
 		if time.Since(nsToken.created) <= c.tokenNamespaceDuration {
 			// And there's a namespace for the given cluster.
 			if nsFound, ok := nsToken.clusterNamespace[cluster][namespace]; ok {
-				return &nsFound
+				nsCopy := copyNamespace(nsFound)
+				return &nsCopy
 			}
 		}
 	}
@@ -67,3 +73,23 @@ func (c *kialiCacheImpl) RefreshTokenNamespaces() {
 	c.tokenLock.Lock()
 	c.tokenNamespaces = make(map[string]namespaceCache)
 }
+
+// copyNamespace returns a copy of the namespace not sharing its labels and annotations,
+// so the namespaces returned by the cache can be modified without locking it.
+func copyNamespace(ns models.Namespace) models.Namespace {
+	nsCopy := ns
+	nsCopy.Labels = copyStringMap(ns.Labels)
+	nsCopy.Annotations = copyStringMap(ns.Annotations)
+	return nsCopy
+}
+
+func copyStringMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	mCopy := make(map[string]string, len(m))
+	for k, v := range m {
+		mCopy[k] = v
+	}
+	return mCopy
+}
@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/models"
+)
+
+func newNamespacesTestCache(duration time.Duration) *kialiCacheImpl {
+	return &kialiCacheImpl{
+		tokenNamespaces:        make(map[string]namespaceCache),
+		tokenNamespaceDuration: duration,
+	}
+}
+
+func TestNamespacesCachedPerToken(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	kialiCache := newNamespacesTestCache(time.Minute)
+	namespaces := []models.Namespace{
+		{Name: "bookinfo", Cluster: "east", Labels: map[string]string{"istio-injection": "enabled"}},
+		{Name: "bookinfo", Cluster: "west"},
+	}
+	kialiCache.SetNamespaces("token-a", namespaces)
+
+	// The cache is not modified through the namespaces given or returned
+	namespaces[0].Labels["istio-injection"] = "disabled"
+	namespaces[1].Name = "travels"
+
+	cached := kialiCache.GetNamespaces("token-a")
+	require.Len(cached, 2)
+	assert.Equal("enabled", cached[0].Labels["istio-injection"])
+	assert.Equal("bookinfo", cached[1].Name)
+	cached[0].Labels["istio-injection"] = "disabled"
+
+	ns := kialiCache.GetNamespace("token-a", "bookinfo", "east")
+	require.NotNil(ns)
+	assert.Equal("enabled", ns.Labels["istio-injection"])
+	ns.Labels["istio-injection"] = "disabled"
+	assert.Equal("enabled", kialiCache.GetNamespace("token-a", "bookinfo", "east").Labels["istio-injection"])
+
+	assert.Nil(kialiCache.GetNamespaces("token-b"))
+	assert.Nil(kialiCache.GetNamespace("token-a", "bookinfo", "north"))
+
+	kialiCache.RefreshTokenNamespaces()
+	assert.Nil(kialiCache.GetNamespaces("token-a"))
+}
+
+// Meant to be run with the race detector
+func TestNamespacesConcurrentAccess(t *testing.T) {
+	kialiCache := newNamespacesTestCache(time.Minute)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(4)
+		go func(i int) {
+			defer wg.Done()
+			namespaces := []models.Namespace{{Name: "bookinfo", Cluster: "east", Labels: map[string]string{"version": fmt.Sprint(i)}}}
+			kialiCache.SetNamespaces("token", namespaces)
+			// The caller keeps using its namespaces
+			namespaces[0].Labels["version"] = "updated"
+		}(i)
+		go func() {
+			defer wg.Done()
+			for _, ns := range kialiCache.GetNamespaces("token") {
+				ns.Labels["read"] = "true"
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if ns := kialiCache.GetNamespace("token", "bookinfo", "east"); ns != nil {
+				ns.Labels["read"] = "true"
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			kialiCache.RefreshTokenNamespaces()
+		}()
+	}
+	wg.Wait()
+}
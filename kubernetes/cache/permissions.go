@@ -0,0 +1,176 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kiali/kiali/models"
+)
+
+type (
+	PermissionsCache interface {
+		SetPermissions(token, cluster, namespace, api, resourceType string, permissions models.ResourcePermissions)
+		GetPermissions(token, cluster, namespace, api, resourceType string) (*models.ResourcePermissions, bool)
+		SetIstioConfigPermissions(token, cluster string, namespaces []string, permissions models.IstioConfigPermissions)
+		GetIstioConfigPermissions(token, cluster string, namespaces []string) (models.IstioConfigPermissions, bool)
+	}
+)
+
+// permissionsKey identifies the permissions of a user on a resource type of a namespace.
+// The token is hashed so the cache doesn't keep the user tokens.
+type permissionsKey struct {
+	tokenHash    string
+	cluster      string
+	namespace    string
+	api          string
+	resourceType string
+}
+
+// permissionsCache caches the result of the SelfSubjectAccessReviews of a resource type.
+type permissionsCache struct {
+	created     time.Time
+	permissions models.ResourcePermissions
+}
+
+// istioConfigPermissionsKey identifies the Istio config permissions of a user on a set of namespaces.
+type istioConfigPermissionsKey struct {
+	tokenHash  string
+	cluster    string
+	namespaces string
+}
+
+// istioConfigPermissionsCache caches the Istio config permissions computed for a set of namespaces.
+type istioConfigPermissionsCache struct {
+	created     time.Time
+	permissions models.IstioConfigPermissions
+}
+
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func newPermissionsKey(token, cluster, namespace, api, resourceType string) permissionsKey {
+	return permissionsKey{
+		tokenHash:    hashToken(token),
+		cluster:      cluster,
+		namespace:    namespace,
+		api:          api,
+		resourceType: resourceType,
+	}
+}
+
+// SetPermissions caches the permissions of a user on a resource type of a namespace.
+// Nothing is cached when their duration is 0.
+func (c *kialiCacheImpl) SetPermissions(token, cluster, namespace, api, resourceType string, permissions models.ResourcePermissions) {
+	if c.permissionsDuration(permissions) <= 0 {
+		return
+	}
+	defer c.tokenPermissionsLock.Unlock()
+	c.tokenPermissionsLock.Lock()
+
+	// Expired entries of the users gone are dropped here, the keys would grow otherwise.
+	// Sweeping once per the shortest duration is enough, the entries expire no sooner.
+	if time.Since(c.tokenPermissionsSwept) >= c.tokenPermissionsDeniedDuration {
+		for key, cached := range c.tokenPermissions {
+			if time.Since(cached.created) >= c.permissionsDuration(cached.permissions) {
+				delete(c.tokenPermissions, key)
+			}
+		}
+		c.tokenPermissionsSwept = time.Now()
+	}
+	c.tokenPermissions[newPermissionsKey(token, cluster, namespace, api, resourceType)] = permissionsCache{
+		created:     time.Now(),
+		permissions: permissions,
+	}
+}
+
+// GetPermissions returns the cached permissions of a user on a resource type of a namespace, if not expired.
+// Permissions with a denied verb expire sooner, so newly granted access is picked up quickly.
+func (c *kialiCacheImpl) GetPermissions(token, cluster, namespace, api, resourceType string) (*models.ResourcePermissions, bool) {
+	defer c.tokenPermissionsLock.RUnlock()
+	c.tokenPermissionsLock.RLock()
+	cached, found := c.tokenPermissions[newPermissionsKey(token, cluster, namespace, api, resourceType)]
+	if !found || time.Since(cached.created) >= c.permissionsDuration(cached.permissions) {
+		return nil, false
+	}
+	permissions := cached.permissions
+	return &permissions, true
+}
+
+// permissionsDuration returns how long the permissions are cached, shorter when a verb is denied
+func (c *kialiCacheImpl) permissionsDuration(permissions models.ResourcePermissions) time.Duration {
+	if !permissions.Create || !permissions.Update || !permissions.Delete {
+		return c.tokenPermissionsDeniedDuration
+	}
+	return c.tokenPermissionsDuration
+}
+
+// newIstioConfigPermissionsKey builds the key of a set of namespaces, whatever the order they are given in.
+func newIstioConfigPermissionsKey(token, cluster string, namespaces []string) istioConfigPermissionsKey {
+	sorted := make([]string, len(namespaces))
+	copy(sorted, namespaces)
+	sort.Strings(sorted)
+	return istioConfigPermissionsKey{
+		tokenHash:  hashToken(token),
+		cluster:    cluster,
+		namespaces: strings.Join(sorted, ","),
+	}
+}
+
+// SetIstioConfigPermissions caches the Istio config permissions of a user on a set of namespaces.
+// Nothing is cached when the duration is 0.
+func (c *kialiCacheImpl) SetIstioConfigPermissions(token, cluster string, namespaces []string, permissions models.IstioConfigPermissions) {
+	if c.istioConfigPermissionsDuration <= 0 {
+		return
+	}
+	defer c.istioConfigPermissionsLock.Unlock()
+	c.istioConfigPermissionsLock.Lock()
+
+	// Expired entries of the users gone are dropped here, the keys of the namespace sets would grow otherwise
+	for key, cached := range c.istioConfigPermissions {
+		if time.Since(cached.created) >= c.istioConfigPermissionsDuration {
+			delete(c.istioConfigPermissions, key)
+		}
+	}
+	c.istioConfigPermissions[newIstioConfigPermissionsKey(token, cluster, namespaces)] = istioConfigPermissionsCache{
+		created:     time.Now(),
+		permissions: copyIstioConfigPermissions(permissions),
+	}
+}
+
+// GetIstioConfigPermissions returns the cached Istio config permissions of a user on a set of namespaces, if not expired.
+// A copy is returned, the callers are free to modify it.
+func (c *kialiCacheImpl) GetIstioConfigPermissions(token, cluster string, namespaces []string) (models.IstioConfigPermissions, bool) {
+	defer c.istioConfigPermissionsLock.RUnlock()
+	c.istioConfigPermissionsLock.RLock()
+	cached, found := c.istioConfigPermissions[newIstioConfigPermissionsKey(token, cluster, namespaces)]
+	if !found || time.Since(cached.created) >= c.istioConfigPermissionsDuration {
+		return nil, false
+	}
+	return copyIstioConfigPermissions(cached.permissions), true
+}
+
+func copyIstioConfigPermissions(permissions models.IstioConfigPermissions) models.IstioConfigPermissions {
+	copied := make(models.IstioConfigPermissions, len(permissions))
+	for ns, resources := range permissions {
+		if resources == nil {
+			copied[ns] = nil
+			continue
+		}
+		copiedResources := make(models.ResourcesPermissions, len(*resources))
+		for resourceType, rp := range *resources {
+			if rp == nil {
+				copiedResources[resourceType] = nil
+				continue
+			}
+			copiedRP := *rp
+			copiedResources[resourceType] = &copiedRP
+		}
+		copied[ns] = &copiedResources
+	}
+	return copied
+}
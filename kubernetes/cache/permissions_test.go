@@ -0,0 +1,154 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/models"
+)
+
+func newPermissionsTestCache(duration, deniedDuration time.Duration) *kialiCacheImpl {
+	return &kialiCacheImpl{
+		tokenPermissions:               make(map[permissionsKey]permissionsCache),
+		tokenPermissionsDuration:       duration,
+		tokenPermissionsDeniedDuration: deniedDuration,
+	}
+}
+
+func newIstioConfigPermissionsTestCache(duration time.Duration) *kialiCacheImpl {
+	return &kialiCacheImpl{
+		istioConfigPermissions:         make(map[istioConfigPermissionsKey]istioConfigPermissionsCache),
+		istioConfigPermissionsDuration: duration,
+	}
+}
+
+func fakeIstioConfigPermissions(namespaces ...string) models.IstioConfigPermissions {
+	permissions := models.IstioConfigPermissions{}
+	for _, ns := range namespaces {
+		permissions[ns] = &models.ResourcesPermissions{"gateways": &models.ResourcePermissions{Create: true, Update: true}}
+	}
+	return permissions
+}
+
+func TestPermissionsCachedPerToken(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	kialiCache := newPermissionsTestCache(time.Minute, time.Minute)
+	allowed := models.ResourcePermissions{Create: true, Update: true, Delete: true}
+	kialiCache.SetPermissions("token-a", "east", "bookinfo", "networking.istio.io", "virtualservices", allowed)
+
+	permissions, found := kialiCache.GetPermissions("token-a", "east", "bookinfo", "networking.istio.io", "virtualservices")
+	require.True(found)
+	assert.Equal(allowed, *permissions)
+
+	_, found = kialiCache.GetPermissions("token-b", "east", "bookinfo", "networking.istio.io", "virtualservices")
+	assert.False(found)
+	_, found = kialiCache.GetPermissions("token-a", "west", "bookinfo", "networking.istio.io", "virtualservices")
+	assert.False(found)
+	_, found = kialiCache.GetPermissions("token-a", "east", "travels", "networking.istio.io", "virtualservices")
+	assert.False(found)
+	_, found = kialiCache.GetPermissions("token-a", "east", "bookinfo", "networking.istio.io", "destinationrules")
+	assert.False(found)
+
+	// The tokens are not kept in the cache
+	for key := range kialiCache.tokenPermissions {
+		assert.NotContains(key.tokenHash, "token-a")
+	}
+}
+
+func TestPermissionsExpire(t *testing.T) {
+	assert := assert.New(t)
+
+	kialiCache := newPermissionsTestCache(time.Minute, 10*time.Millisecond)
+	allowed := models.ResourcePermissions{Create: true, Update: true, Delete: true}
+	denied := models.ResourcePermissions{Create: true, Update: true, Delete: false}
+	kialiCache.SetPermissions("token", "east", "bookinfo", "networking.istio.io", "virtualservices", allowed)
+	kialiCache.SetPermissions("token", "east", "bookinfo", "networking.istio.io", "destinationrules", denied)
+
+	_, found := kialiCache.GetPermissions("token", "east", "bookinfo", "networking.istio.io", "destinationrules")
+	assert.True(found)
+
+	time.Sleep(20 * time.Millisecond)
+
+	// The denied permissions expire first
+	_, found = kialiCache.GetPermissions("token", "east", "bookinfo", "networking.istio.io", "virtualservices")
+	assert.True(found)
+	_, found = kialiCache.GetPermissions("token", "east", "bookinfo", "networking.istio.io", "destinationrules")
+	assert.False(found)
+
+	kialiCache.tokenPermissionsDuration = 10 * time.Millisecond
+	_, found = kialiCache.GetPermissions("token", "east", "bookinfo", "networking.istio.io", "virtualservices")
+	assert.False(found)
+}
+
+func TestPermissionsExpiredEvicted(t *testing.T) {
+	assert := assert.New(t)
+
+	kialiCache := newPermissionsTestCache(10*time.Millisecond, 10*time.Millisecond)
+	allowed := models.ResourcePermissions{Create: true, Update: true, Delete: true}
+	kialiCache.SetPermissions("token-a", "east", "bookinfo", "networking.istio.io", "virtualservices", allowed)
+	kialiCache.SetPermissions("token-a", "east", "travels", "networking.istio.io", "virtualservices", allowed)
+	assert.Len(kialiCache.tokenPermissions, 2)
+
+	time.Sleep(20 * time.Millisecond)
+
+	// The entries of the users gone are dropped on the next update
+	kialiCache.SetPermissions("token-b", "east", "bookinfo", "networking.istio.io", "virtualservices", allowed)
+	assert.Len(kialiCache.tokenPermissions, 1)
+
+	// Disabled
+	kialiCache = newPermissionsTestCache(0, 0)
+	kialiCache.SetPermissions("token", "east", "bookinfo", "networking.istio.io", "virtualservices", allowed)
+	assert.Empty(kialiCache.tokenPermissions)
+}
+
+func TestIstioConfigPermissionsCachedPerNamespaces(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	kialiCache := newIstioConfigPermissionsTestCache(time.Minute)
+	kialiCache.SetIstioConfigPermissions("token-a", "east", []string{"bookinfo", "travels"}, fakeIstioConfigPermissions("bookinfo", "travels"))
+
+	permissions, found := kialiCache.GetIstioConfigPermissions("token-a", "east", []string{"travels", "bookinfo"})
+	require.True(found)
+	assert.Equal(fakeIstioConfigPermissions("bookinfo", "travels"), permissions)
+
+	_, found = kialiCache.GetIstioConfigPermissions("token-b", "east", []string{"bookinfo", "travels"})
+	assert.False(found)
+	_, found = kialiCache.GetIstioConfigPermissions("token-a", "west", []string{"bookinfo", "travels"})
+	assert.False(found)
+	_, found = kialiCache.GetIstioConfigPermissions("token-a", "east", []string{"bookinfo"})
+	assert.False(found)
+
+	// The tokens are not kept
+	for key := range kialiCache.istioConfigPermissions {
+		assert.NotContains(key.tokenHash, "token-a")
+	}
+}
+
+func TestIstioConfigPermissionsExpire(t *testing.T) {
+	assert := assert.New(t)
+
+	kialiCache := newIstioConfigPermissionsTestCache(10 * time.Millisecond)
+	kialiCache.SetIstioConfigPermissions("token", "east", []string{"bookinfo"}, fakeIstioConfigPermissions("bookinfo"))
+	_, found := kialiCache.GetIstioConfigPermissions("token", "east", []string{"bookinfo"})
+	assert.True(found)
+
+	time.Sleep(20 * time.Millisecond)
+	_, found = kialiCache.GetIstioConfigPermissions("token", "east", []string{"bookinfo"})
+	assert.False(found)
+
+	// The expired entries are dropped on the next update
+	kialiCache.SetIstioConfigPermissions("token", "east", []string{"travels"}, fakeIstioConfigPermissions("travels"))
+	assert.Len(kialiCache.istioConfigPermissions, 1)
+
+	// Disabled
+	kialiCache = newIstioConfigPermissionsTestCache(0)
+	kialiCache.SetIstioConfigPermissions("token", "east", []string{"bookinfo"}, fakeIstioConfigPermissions("bookinfo"))
+	_, found = kialiCache.GetIstioConfigPermissions("token", "east", []string{"bookinfo"})
+	assert.False(found)
+}
@@ -13,6 +13,7 @@ import (
 
 type ProxyStatusCache interface {
 	GetPodProxyStatus(cluster, namespace, pod string) *kubernetes.ProxyStatus
+	GetProxyStatuses(cluster, namespace string) []*kubernetes.ProxyStatus
 }
 
 // pollIstiodForProxyStatus is a long running goroutine that will periodically poll istiod for proxy status.
@@ -73,6 +74,28 @@ func (c *kialiCacheImpl) GetPodProxyStatus(cluster, namespace, pod string) *kube
 	return nil
 }
 
+// GetProxyStatuses returns the proxy status of the pods of a cluster and namespace.
+// An empty cluster or namespace matches all of them.
+func (c *kialiCacheImpl) GetProxyStatuses(cluster, namespace string) []*kubernetes.ProxyStatus {
+	defer c.proxyStatusLock.RUnlock()
+	c.proxyStatusLock.RLock()
+	proxyStatuses := []*kubernetes.ProxyStatus{}
+	for clusterName, clusterProxyStatus := range c.proxyStatusNamespaces {
+		if cluster != "" && cluster != clusterName {
+			continue
+		}
+		for ns, nsProxyStatus := range clusterProxyStatus {
+			if namespace != "" && namespace != ns {
+				continue
+			}
+			for _, podProxyStatus := range nsProxyStatus {
+				proxyStatuses = append(proxyStatuses, podProxyStatus.proxyStatus)
+			}
+		}
+	}
+	return proxyStatuses
+}
+
 func (c *kialiCacheImpl) setProxyStatus(proxyStatus []*kubernetes.ProxyStatus) {
 	defer c.proxyStatusLock.Unlock()
 	c.proxyStatusLock.Lock()
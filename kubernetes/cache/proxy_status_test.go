@@ -0,0 +1,33 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/kubernetes"
+)
+
+func TestGetProxyStatuses(t *testing.T) {
+	require := require.New(t)
+
+	kialiCache := &kialiCacheImpl{proxyStatusNamespaces: make(map[string]map[string]map[string]podProxyStatus)}
+	kialiCache.setProxyStatus([]*kubernetes.ProxyStatus{
+		{SyncStatus: kubernetes.SyncStatus{ClusterID: "east", ProxyID: "reviews-v1.bookinfo"}},
+		{SyncStatus: kubernetes.SyncStatus{ClusterID: "east", ProxyID: "cars-v1.travels"}},
+		{SyncStatus: kubernetes.SyncStatus{ClusterID: "west", ProxyID: "reviews-v2.bookinfo"}},
+		// Not in the <pod-name>.<namespace> format
+		{SyncStatus: kubernetes.SyncStatus{ClusterID: "west", ProxyID: "router"}},
+	})
+
+	require.Len(kialiCache.GetProxyStatuses("", ""), 3)
+	require.Len(kialiCache.GetProxyStatuses("east", ""), 2)
+	require.Len(kialiCache.GetProxyStatuses("", "bookinfo"), 2)
+
+	proxyStatuses := kialiCache.GetProxyStatuses("west", "bookinfo")
+	require.Len(proxyStatuses, 1)
+	require.Equal("reviews-v2.bookinfo", proxyStatuses[0].ProxyID)
+
+	require.Empty(kialiCache.GetProxyStatuses("west", "travels"))
+	require.NotNil(kialiCache.GetPodProxyStatus("east", "travels", "cars-v1"))
+}
@@ -3,9 +3,11 @@ package kubernetes
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"net"
 	"os"
+	"sort"
 	"strings"
 
 	istio "istio.io/client-go/pkg/clientset/versioned"
@@ -39,6 +41,7 @@ type PodLogs struct {
 type ClientInterface interface {
 	GetServerVersion() (*version.Info, error)
 	GetToken() string
+	GetImpersonatedUser() rest.ImpersonationConfig
 	GetAuthInfo() *api.AuthInfo
 	IsOpenShift() bool
 	IsGatewayAPI() bool
@@ -77,6 +80,38 @@ func (client *K8SClient) GetToken() string {
 	return client.token
 }
 
+// GetImpersonatedUser returns the user, groups and extra fields impersonated by the client, if any
+func (client *K8SClient) GetImpersonatedUser() rest.ImpersonationConfig {
+	if client.restConfig == nil {
+		return rest.ImpersonationConfig{}
+	}
+	return client.restConfig.Impersonate
+}
+
+// UserCacheKey returns the key of the data cached for the user of the client. Impersonating clients share
+// the token of the Kiali SA, so they are keyed on the impersonated user, groups and extra fields instead.
+func UserCacheKey(client ClientInterface) string {
+	impersonate := client.GetImpersonatedUser()
+	if impersonate.UserName == "" {
+		return client.GetToken()
+	}
+	return "impersonate:" + impersonate.UserName + ":" + strings.Join(impersonate.Groups, ",") + ":" + joinUserExtra(impersonate.Extra)
+}
+
+// joinUserExtra returns the extra fields of a user sorted by key, the same fields always give the same string
+func joinUserExtra(extra map[string][]string) string {
+	keys := make([]string, 0, len(extra))
+	for key := range extra {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	fields := make([]string, 0, len(keys))
+	for _, key := range keys {
+		fields = append(fields, key+"="+strings.Join(extra[key], ","))
+	}
+	return strings.Join(fields, ";")
+}
+
 // GetConfigForRemoteClusterInfo points the returned k8s client config to a remote cluster's API server.
 // The returned config will have the user's token associated with it.
 func GetConfigForRemoteClusterInfo(cluster RemoteClusterInfo) (*rest.Config, error) {
@@ -103,8 +138,9 @@ func GetConfigForRemoteCluster(cluster RemoteSecretClusterListItem) (*rest.Confi
 		return nil, err
 	}
 	// Basically implement rest.InClusterConfig() with the remote creds
-	tlsClientConfig := rest.TLSClientConfig{
-		CAData: []byte(rootCaDecoded),
+	tlsClientConfig, err := getTLSConfigForRemoteCluster(cluster, rootCaDecoded)
+	if err != nil {
+		return nil, err
 	}
 
 	serverParse := strings.Split(cluster.Cluster.Server, ":")
@@ -132,6 +168,40 @@ func GetConfigForRemoteCluster(cluster RemoteSecretClusterListItem) (*rest.Confi
 	}, nil
 }
 
+// errTLSVerifyRequired is returned for the remote clusters skipping TLS verification when it is required
+var errTLSVerifyRequired = errors.New("skipping TLS verification is not allowed")
+
+// getTLSConfigForRemoteCluster returns the TLS settings of the remote cluster secret after applying
+// the remote cluster TLS configuration: secrets skipping TLS verification are rejected when verification
+// is required, and the configured CA bundle is trusted in addition to the secret's own CA.
+func getTLSConfigForRemoteCluster(cluster RemoteSecretClusterListItem, caData []byte) (rest.TLSClientConfig, error) {
+	tlsConf := kialiConfig.Get().KubernetesConfig.RemoteClusterTLS
+
+	if cluster.Cluster.InsecureSkipTLSVerify {
+		if tlsConf.RequireTLSVerify {
+			return rest.TLSClientConfig{}, fmt.Errorf("remote cluster [%s] skips TLS verification: %w", cluster.Name, errTLSVerifyRequired)
+		}
+		log.Warningf("TLS verification is disabled for remote cluster [%s]", cluster.Name)
+		// The CA can't be set along with the insecure flag.
+		return rest.TLSClientConfig{Insecure: true}, nil
+	}
+
+	if tlsConf.CAFile != "" {
+		caBundle, err := os.ReadFile(tlsConf.CAFile)
+		if err != nil {
+			return rest.TLSClientConfig{}, fmt.Errorf("unable to read the remote cluster CA bundle [%s]: %v", tlsConf.CAFile, err)
+		}
+		if len(caData) > 0 && !strings.HasSuffix(string(caData), "\n") {
+			caData = append(caData, '\n')
+		}
+		caData = append(caData, caBundle...)
+	}
+
+	return rest.TLSClientConfig{
+		CAData: caData,
+	}, nil
+}
+
 // GetConfigForLocalCluster return a client with the correct configuration
 // Returns configuration if Kiali is in Cluster when InCluster is true
 // Returns configuration if Kiali is not in Cluster when InCluster is false
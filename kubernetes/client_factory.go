@@ -1,6 +1,7 @@
 package kubernetes
 
 import (
+	"context"
 	"crypto/md5"
 	"errors"
 	"fmt"
@@ -24,6 +25,15 @@ var once sync.Once
 // defaultExpirationTime set the default expired time of a client
 const defaultExpirationTime = time.Minute * 15
 
+// remoteClientAttempts is how many times the API server of a remote cluster is probed at startup
+// before giving up on the cluster. The wait between the attempts starts at remoteClientBackoff and doubles.
+// The remote clusters are probed in parallel, all of them are given up on after remoteClientTimeout.
+var (
+	remoteClientAttempts = 5
+	remoteClientBackoff  = time.Second
+	remoteClientTimeout  = 30 * time.Second
+)
+
 // ClientFactory interface for the clientFactory object
 type ClientFactory interface {
 	GetClient(authInfo *api.AuthInfo) (ClientInterface, error) // TODO: Make private
@@ -119,19 +129,60 @@ func newClientFactory(restConfig *rest.Config) (*clientFactory, error) {
 
 	f.saClientEntries[f.homeCluster] = homeClient
 
-	for _, clusterInfo := range remoteClusterInfos {
-		client, err := f.newSAClient(&clusterInfo)
+	// An invalid remote cluster secret is a configuration error and is fatal like the home cluster client failure,
+	// but a cluster rejected by the TLS policy is only ignored so the other clusters keep working.
+	// A remote API server can be briefly unavailable, so it is probed again before giving up on the cluster.
+	remoteClients := make(map[string]*K8SClient, len(remoteClusterInfos))
+	for name, clusterInfo := range remoteClusterInfos {
+		config, err := GetConfigForRemoteClusterInfo(clusterInfo)
+		if errors.Is(err, errTLSVerifyRequired) {
+			log.Errorf("Remote cluster [%s] is ignored: %v", name, err)
+			delete(f.remoteClusterInfos, name)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		client, err := NewClientFromConfig(config)
 		if err != nil {
 			return nil, err
 		}
-		f.saClientEntries[clusterInfo.Cluster.Name] = client
+		remoteClients[name] = client
 	}
 
+	// The remote clusters are probed in parallel with a shared deadline, so the startup waits for the slowest one at most
+	ctx, cancel := context.WithTimeout(context.Background(), remoteClientTimeout)
+	defer cancel()
+	wg := sync.WaitGroup{}
+	wg.Add(len(remoteClients))
+	for name, client := range remoteClients {
+		go func(name string, client *K8SClient) {
+			defer wg.Done()
+			err := waitForRemoteServer(ctx, name, client)
+
+			f.mutex.Lock()
+			defer f.mutex.Unlock()
+			if err != nil {
+				log.Errorf("Giving up on remote cluster [%s], it is ignored until Kiali restarts: %v", name, err)
+				delete(f.remoteClusterInfos, name)
+				return
+			}
+			f.saClientEntries[f.remoteClusterInfos[name].Cluster.Name] = client
+		}(name, client)
+	}
+	wg.Wait()
+
 	return f, nil
 }
 
 // newClient creates a new ClientInterface based on a users k8s token
 func (cf *clientFactory) newClient(authInfo *api.AuthInfo, expirationTime time.Duration, cluster string) (ClientInterface, error) {
+	cfg := kialiConfig.Get()
+	// The Kiali SA token is used without a user, in which case there is no one to impersonate
+	if cfg.Auth.Impersonation.Enabled && cfg.Auth.Strategy != kialiConfig.AuthStrategyAnonymous && (authInfo.Impersonate != "" || authInfo.Username != "") {
+		return cf.newImpersonatingClient(authInfo, expirationTime, cluster)
+	}
+
 	config := *cf.baseRestConfig
 
 	config.BearerToken = authInfo.Token
@@ -148,7 +199,6 @@ func (cf *clientFactory) newClient(authInfo *api.AuthInfo, expirationTime time.D
 	// So, if OpenID strategy is active, check if a proxy is configured.
 	// If there is, use it UNLESS the token is the one of the Kiali SA. If
 	// the token is the one of the Kiali SA, the proxy can be bypassed.
-	cfg := kialiConfig.Get()
 	if cfg.Auth.Strategy == kialiConfig.AuthStrategyOpenId && cfg.Auth.OpenId.ApiProxy != "" && cfg.Auth.OpenId.ApiProxyCAData != "" {
 
 		var kialiToken string
@@ -228,17 +278,71 @@ func (cf *clientFactory) newClient(authInfo *api.AuthInfo, expirationTime time.D
 		}
 	}
 
-	// check if client is created correctly
-	// if it is true, run to recycle client
-	// if it is not, the token should not be added to recycleChan
+	cf.recycleAfter(getTokenHash(authInfo), expirationTime, err)
+
+	return newClient, err
+}
+
+// newImpersonatingClient creates a new ClientInterface that authenticates with the Kiali SA credentials
+// of the cluster and impersonates the logged in user through the Impersonate-User and Impersonate-Group headers.
+// The user is the authInfo's Impersonate field or, when it isn't set, its Username.
+func (cf *clientFactory) newImpersonatingClient(authInfo *api.AuthInfo, expirationTime time.Duration, cluster string) (ClientInterface, error) {
+	userName := authInfo.Impersonate
+	if userName == "" {
+		userName = authInfo.Username
+	}
+
+	var config *rest.Config
+	if cluster == cf.homeCluster {
+		homeConfig := *cf.baseRestConfig
+		if kialiConfig.Get().InCluster {
+			kialiToken, err := GetKialiTokenForHomeCluster()
+			if err != nil {
+				return nil, err
+			}
+			homeConfig.BearerToken = kialiToken
+		}
+		config = &homeConfig
+	} else {
+		cf.mutex.RLock()
+		clusterInfo, ok := cf.remoteClusterInfos[cluster]
+		cf.mutex.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf("cannot impersonate user in unknown cluster [%s]", cluster)
+		}
+		remoteConfig, err := GetConfigForRemoteClusterInfo(clusterInfo)
+		if err != nil {
+			return nil, err
+		}
+		config = remoteConfig
+	}
+
+	config.Impersonate = rest.ImpersonationConfig{
+		UserName: userName,
+		Groups:   authInfo.ImpersonateGroups,
+		Extra:    authInfo.ImpersonateUserExtra,
+	}
+
+	newClient, err := NewClientFromConfig(config)
+	if err != nil {
+		log.Errorf("Error creating impersonating client for cluster %s: %s", cluster, err.Error())
+		return nil, err
+	}
+
+	cf.recycleAfter(getTokenHash(authInfo), expirationTime, nil)
+
+	return newClient, nil
+}
+
+// recycleAfter sends the token hash to recycleChan once the expiration time has passed.
+// Clients that weren't created correctly are not recycled.
+func (cf *clientFactory) recycleAfter(tokenHash string, expirationTime time.Duration, err error) {
 	go func(token string, err error) {
 		if err == nil {
 			<-time.After(expirationTime)
 			cf.recycleChan <- token
 		}
-	}(getTokenHash(authInfo), err)
-
-	return newClient, err
+	}(tokenHash, err)
 }
 
 // newSAClient returns a new client for the given cluster. If clusterInfo is nil then a client for the local cluster is returned.
@@ -263,6 +367,28 @@ func (cf *clientFactory) newSAClient(clusterInfo *RemoteClusterInfo) (*K8SClient
 	}
 }
 
+// waitForRemoteServer probes the API server of a remote cluster until it answers, retrying with an exponential backoff
+// until the context is done. Creating the client doesn't reach the server, an unavailable one is only noticed here.
+func waitForRemoteServer(ctx context.Context, cluster string, client *K8SClient) error {
+	backoff := remoteClientBackoff
+	var err error
+	for attempt := 1; attempt <= remoteClientAttempts; attempt++ {
+		if err = client.k8s.Discovery().RESTClient().Get().AbsPath("/version").Do(ctx).Error(); err == nil {
+			return nil
+		}
+		if attempt < remoteClientAttempts {
+			log.Warningf("Attempt %d of %d to reach the API server of remote cluster [%s] failed, retrying in %v: %v", attempt, remoteClientAttempts, cluster, backoff, err)
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("no answer after %v: %w", remoteClientTimeout, err)
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+	}
+	return err
+}
+
 // getClient returns a client for the specified token. Creating one if necessary.
 func (cf *clientFactory) GetSAClients() map[string]ClientInterface {
 	cf.mutex.RLock()
@@ -362,6 +488,11 @@ func (cf *clientFactory) deleteClient(token string) {
 func getTokenHash(authInfo *api.AuthInfo) string {
 	tokenData := authInfo.Token
 
+	// With impersonation enabled the token may be empty so the identity has to be part of the hash
+	if authInfo.Username != "" {
+		tokenData += authInfo.Username
+	}
+
 	if authInfo.Impersonate != "" {
 		tokenData += authInfo.Impersonate
 	}
@@ -373,11 +504,7 @@ func getTokenHash(authInfo *api.AuthInfo) string {
 	}
 
 	if authInfo.ImpersonateUserExtra != nil {
-		for key, element := range authInfo.ImpersonateUserExtra {
-			for _, userExtra := range element {
-				tokenData += key + userExtra
-			}
-		}
+		tokenData += joinUserExtra(authInfo.ImpersonateUserExtra)
 	}
 
 	h := md5.New()
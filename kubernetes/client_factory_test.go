@@ -169,3 +169,113 @@ func TestSAClientsUpdateWhenKialiTokenChanges(t *testing.T) {
 	client = clientFactory.GetSAClient(conf.KubernetesConfig.ClusterName)
 	require.Equal(KialiTokenForHomeCluster, client.GetToken())
 }
+
+func TestImpersonatingClientSetsImpersonateFields(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	conf.Auth.Strategy = config.AuthStrategyToken
+	conf.Auth.Impersonation.Enabled = true
+	config.Set(conf)
+	t.Cleanup(func() {
+		// Other tests use this global var so we need to reset it.
+		KialiTokenForHomeCluster = ""
+	})
+
+	tokenRead = time.Now()
+	KialiTokenForHomeCluster = "kiali-token"
+
+	restConfig := rest.Config{}
+	clientFactory, err := newClientFactory(&restConfig)
+	require.NoError(err)
+
+	authInfo := api.NewAuthInfo()
+	authInfo.Token = "user-token"
+	authInfo.Username = "alice"
+	authInfo.ImpersonateGroups = []string{"developers", "system:authenticated"}
+	authInfo.ImpersonateUserExtra = map[string][]string{"scopes.authorization.openshift.io": {"user:info", "user:check-access"}}
+	client, err := clientFactory.getRecycleClient(authInfo, defaultExpirationTime, conf.KubernetesConfig.ClusterName)
+	require.NoError(err)
+
+	clientConfig := client.(*K8SClient).restConfig
+	require.Equal("kiali-token", clientConfig.BearerToken)
+	require.Equal("alice", clientConfig.Impersonate.UserName)
+	require.Equal([]string{"developers", "system:authenticated"}, clientConfig.Impersonate.Groups)
+	require.Equal(map[string][]string{"scopes.authorization.openshift.io": {"user:info", "user:check-access"}}, clientConfig.Impersonate.Extra)
+
+	// The impersonated user, not the token, identifies the client.
+	otherAuthInfo := api.NewAuthInfo()
+	otherAuthInfo.Token = "user-token"
+	otherAuthInfo.Username = "bob"
+	otherClient, err := clientFactory.getRecycleClient(otherAuthInfo, defaultExpirationTime, conf.KubernetesConfig.ClusterName)
+	require.NoError(err)
+
+	require.Equal("bob", otherClient.(*K8SClient).restConfig.Impersonate.UserName)
+	require.Equal(2, clientFactory.getClientsLength())
+	require.NotEqual(UserCacheKey(client), UserCacheKey(otherClient))
+
+	// The same user with other extra fields doesn't share the client
+	scopedAuthInfo := api.NewAuthInfo()
+	scopedAuthInfo.Token = "user-token"
+	scopedAuthInfo.Username = "alice"
+	scopedAuthInfo.ImpersonateGroups = []string{"developers", "system:authenticated"}
+	scopedAuthInfo.ImpersonateUserExtra = map[string][]string{"scopes.authorization.openshift.io": {"user:info"}}
+	scopedClient, err := clientFactory.getRecycleClient(scopedAuthInfo, defaultExpirationTime, conf.KubernetesConfig.ClusterName)
+	require.NoError(err)
+
+	require.Equal(3, clientFactory.getClientsLength())
+	require.NotEqual(UserCacheKey(client), UserCacheKey(scopedClient))
+
+	// The same identity shares the client
+	sameClient, err := clientFactory.getRecycleClient(authInfo, defaultExpirationTime, conf.KubernetesConfig.ClusterName)
+	require.NoError(err)
+	require.Equal(3, clientFactory.getClientsLength())
+	require.Equal(UserCacheKey(client), UserCacheKey(sameClient))
+}
+
+func TestImpersonatingClientWithoutUserUsesToken(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	conf.Auth.Strategy = config.AuthStrategyToken
+	conf.Auth.Impersonation.Enabled = true
+	// Not in cluster so that there is no need for a Kiali SA token.
+	conf.InCluster = false
+	config.Set(conf)
+
+	restConfig := rest.Config{}
+	clientFactory, err := newClientFactory(&restConfig)
+	require.NoError(err)
+
+	// Like the Kiali SA token, a token without a user is used as is.
+	authInfo := api.NewAuthInfo()
+	authInfo.Token = "kiali-token"
+	client, err := clientFactory.getRecycleClient(authInfo, defaultExpirationTime, conf.KubernetesConfig.ClusterName)
+	require.NoError(err)
+
+	clientConfig := client.(*K8SClient).restConfig
+	require.Equal("kiali-token", clientConfig.BearerToken)
+	require.Empty(clientConfig.Impersonate.UserName)
+	require.Equal("kiali-token", UserCacheKey(client))
+}
+
+func TestClientWithoutImpersonationUsesUserToken(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	conf.Auth.Strategy = config.AuthStrategyToken
+	// Not in cluster so that there is no need for a Kiali SA token.
+	conf.InCluster = false
+	config.Set(conf)
+
+	restConfig := rest.Config{}
+	clientFactory, err := newClientFactory(&restConfig)
+	require.NoError(err)
+
+	authInfo := api.NewAuthInfo()
+	authInfo.Token = "user-token"
+	authInfo.Username = "alice"
+	client, err := clientFactory.getRecycleClient(authInfo, defaultExpirationTime, conf.KubernetesConfig.ClusterName)
+	require.NoError(err)
+
+	clientConfig := client.(*K8SClient).restConfig
+	require.Equal("user-token", clientConfig.BearerToken)
+	require.Empty(clientConfig.Impersonate.UserName)
+}
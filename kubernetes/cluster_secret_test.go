@@ -1,11 +1,18 @@
 package kubernetes
 
 import (
+	"encoding/base64"
+	"encoding/pem"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v2"
 	"k8s.io/client-go/rest"
 
@@ -30,13 +37,15 @@ func TestReloadRemoteClusterSecret(t *testing.T) {
 
 	check := assert.New(t)
 
+	serverURL, serverCA, _ := newTestRemoteServer(t, 0)
 	testClusterName := "TestRemoteCluster"
 	remoteSecretData := RemoteSecret{
 		Clusters: []RemoteSecretClusterListItem{
 			{
 				Name: testClusterName,
 				Cluster: RemoteSecretCluster{
-					Server: "https://192.168.1.2:1234",
+					CertificateAuthorityData: serverCA,
+					Server:                   serverURL,
 				},
 			},
 		},
@@ -80,6 +89,109 @@ func TestReloadRemoteClusterSecret(t *testing.T) {
 	check.Equal(reloadedObj.User.User.Token, "CHANGED TOKEN")
 }
 
+// setupInsecureRemoteClusterSecret creates a remote cluster secret that skips TLS verification
+// and returns the name of its cluster.
+func setupInsecureRemoteClusterSecret(t *testing.T, conf *config.Config) string {
+	originalRemoteClusterSecretsDir := RemoteClusterSecretsDir
+	t.Cleanup(func() {
+		RemoteClusterSecretsDir = originalRemoteClusterSecretsDir
+	})
+	RemoteClusterSecretsDir = t.TempDir()
+
+	// need to turn off in-cluster so the factory doesn't look for the home cluster SA token on the file system
+	conf.InCluster = false
+	config.Set(conf)
+
+	serverURL, _, _ := newTestRemoteServer(t, 0)
+	testClusterName := "TestInsecureRemoteCluster"
+	remoteSecretData := RemoteSecret{
+		Clusters: []RemoteSecretClusterListItem{
+			{
+				Name: testClusterName,
+				Cluster: RemoteSecretCluster{
+					InsecureSkipTLSVerify: true,
+					Server:                serverURL,
+				},
+			},
+		},
+		Users: []RemoteSecretUser{
+			{
+				Name: "remoteuser1",
+				User: RemoteSecretUserToken{
+					Token: "remotetoken1",
+				},
+			},
+		},
+	}
+	marshalledRemoteSecretData, err := yaml.Marshal(remoteSecretData)
+	require.NoError(t, err)
+	createTestRemoteClusterSecretFile(t, RemoteClusterSecretsDir, testClusterName, string(marshalledRemoteSecretData))
+
+	return testClusterName
+}
+
+func TestRemoteClusterSecretSkippingTLSVerifyRejected(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	conf.KubernetesConfig.RemoteClusterTLS.RequireTLSVerify = true
+	testClusterName := setupInsecureRemoteClusterSecret(t, conf)
+
+	// The rejected cluster is ignored, the factory still serves the other clusters
+	restConfig := rest.Config{}
+	clientFactory, err := newClientFactory(&restConfig)
+	require.NoError(err)
+	require.NotContains(clientFactory.GetSAClients(), testClusterName)
+	require.Contains(clientFactory.GetSAClients(), conf.KubernetesConfig.ClusterName)
+	require.NotContains(clientFactory.remoteClusterInfos, testClusterName)
+}
+
+func TestRemoteClusterSecretSkippingTLSVerifyAllowed(t *testing.T) {
+	require := require.New(t)
+	// Skipping TLS verification is allowed by default
+	conf := config.NewConfig()
+	testClusterName := setupInsecureRemoteClusterSecret(t, conf)
+
+	restConfig := rest.Config{}
+	clientFactory, err := newClientFactory(&restConfig)
+	require.NoError(err)
+
+	client := clientFactory.GetSAClient(testClusterName).(*K8SClient)
+	require.True(client.restConfig.TLSClientConfig.Insecure)
+	require.Empty(client.restConfig.TLSClientConfig.CAData)
+}
+
+func TestRemoteClusterCABundle(t *testing.T) {
+	require := require.New(t)
+
+	secretCA := "-----BEGIN CERTIFICATE-----\nsecret\n-----END CERTIFICATE-----"
+	bundleCA := "-----BEGIN CERTIFICATE-----\nbundle\n-----END CERTIFICATE-----\n"
+	caFile := t.TempDir() + "/ca-bundle.pem"
+	require.NoError(os.WriteFile(caFile, []byte(bundleCA), 0o600))
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.RemoteClusterTLS.CAFile = caFile
+	config.Set(conf)
+
+	restConfig, err := GetConfigForRemoteCluster(RemoteSecretClusterListItem{
+		Name: "east",
+		Cluster: RemoteSecretCluster{
+			CertificateAuthorityData: base64.StdEncoding.EncodeToString([]byte(secretCA)),
+			Server:                   "https://192.168.1.2:1234",
+		},
+	})
+	require.NoError(err)
+	require.Equal(secretCA+"\n"+bundleCA, string(restConfig.TLSClientConfig.CAData))
+
+	// A missing bundle is an error rather than silently trusting fewer CAs.
+	conf.KubernetesConfig.RemoteClusterTLS.CAFile = caFile + ".missing"
+	config.Set(conf)
+	_, err = GetConfigForRemoteCluster(RemoteSecretClusterListItem{
+		Name:    "east",
+		Cluster: RemoteSecretCluster{Server: "https://192.168.1.2:1234"},
+	})
+	require.Error(err)
+}
+
 func createTestRemoteClusterSecretFile(t *testing.T, parentDir string, name string, content string) {
 	childDir := fmt.Sprintf("%s/%s", parentDir, name)
 	filename := fmt.Sprintf("%s/%s", childDir, name)
@@ -95,3 +207,131 @@ func createTestRemoteClusterSecretFile(t *testing.T, parentDir string, name stri
 		t.Fatalf("Failed to write tmp remote cluster secret file [%v]: %v", filename, err2)
 	}
 }
+
+// newTestRemoteServer starts a fake remote API server failing the given number of version requests.
+// Returns its URL, its CA encoded like in a remote secret and the number of version requests received.
+func newTestRemoteServer(t *testing.T, failures int32) (string, string, *int32) {
+	var requests int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/version" {
+			http.NotFound(w, r)
+			return
+		}
+		if atomic.AddInt32(&requests, 1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"major":"1","minor":"24","gitVersion":"v1.24.2"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	return server.URL, base64.StdEncoding.EncodeToString(ca), &requests
+}
+
+// setupFlakyRemoteCluster creates a remote cluster secret of a remote API server failing the given number of times.
+// Returns the name of the cluster and the number of version requests received.
+func setupFlakyRemoteCluster(t *testing.T, failures int32, caData string) (string, *int32) {
+	originalRemoteClusterSecretsDir := RemoteClusterSecretsDir
+	originalBackoff := remoteClientBackoff
+	t.Cleanup(func() {
+		RemoteClusterSecretsDir = originalRemoteClusterSecretsDir
+		remoteClientBackoff = originalBackoff
+	})
+	RemoteClusterSecretsDir = t.TempDir()
+	remoteClientBackoff = time.Millisecond
+
+	// need to turn off in-cluster so the factory doesn't look for the home cluster SA token on the file system
+	conf := config.NewConfig()
+	conf.InCluster = false
+	config.Set(conf)
+
+	serverURL, serverCA, requests := newTestRemoteServer(t, failures)
+	if caData == "" {
+		caData = serverCA
+	}
+	testClusterName := "TestFlakyRemoteCluster"
+	remoteSecretData := RemoteSecret{
+		Clusters: []RemoteSecretClusterListItem{
+			{
+				Name: testClusterName,
+				Cluster: RemoteSecretCluster{
+					CertificateAuthorityData: caData,
+					Server:                   serverURL,
+				},
+			},
+		},
+		Users: []RemoteSecretUser{
+			{
+				Name: "remoteuser1",
+				User: RemoteSecretUserToken{
+					Token: "remotetoken1",
+				},
+			},
+		},
+	}
+	marshalledRemoteSecretData, err := yaml.Marshal(remoteSecretData)
+	require.NoError(t, err)
+	createTestRemoteClusterSecretFile(t, RemoteClusterSecretsDir, testClusterName, string(marshalledRemoteSecretData))
+
+	return testClusterName, requests
+}
+
+func TestRemoteClusterClientRetriedAfterTransientFailures(t *testing.T) {
+	require := require.New(t)
+	testClusterName, requests := setupFlakyRemoteCluster(t, 2, "")
+
+	restConfig := rest.Config{}
+	clientFactory, err := newClientFactory(&restConfig)
+	require.NoError(err)
+
+	require.Equal(int32(3), atomic.LoadInt32(requests))
+	require.NotNil(clientFactory.GetSAClient(testClusterName))
+	require.Contains(clientFactory.remoteClusterInfos, testClusterName)
+}
+
+func TestRemoteClusterGivenUpAfterRetries(t *testing.T) {
+	require := require.New(t)
+	testClusterName, requests := setupFlakyRemoteCluster(t, int32(remoteClientAttempts), "")
+
+	restConfig := rest.Config{}
+	clientFactory, err := newClientFactory(&restConfig)
+	require.NoError(err, "A remote cluster failure should not be fatal")
+
+	require.Equal(int32(remoteClientAttempts), atomic.LoadInt32(requests))
+	require.Nil(clientFactory.GetSAClient(testClusterName))
+	require.NotContains(clientFactory.remoteClusterInfos, testClusterName)
+	require.NotNil(clientFactory.GetSAHomeClusterClient())
+}
+
+func TestRemoteClusterGivenUpAfterTimeout(t *testing.T) {
+	require := require.New(t)
+	testClusterName, requests := setupFlakyRemoteCluster(t, 1000, "")
+
+	originalTimeout := remoteClientTimeout
+	t.Cleanup(func() { remoteClientTimeout = originalTimeout })
+	remoteClientTimeout = 50 * time.Millisecond
+	remoteClientBackoff = time.Hour
+
+	restConfig := rest.Config{}
+	start := time.Now()
+	clientFactory, err := newClientFactory(&restConfig)
+	require.NoError(err, "A remote cluster failure should not be fatal")
+
+	// The backoff is not waited for past the deadline
+	require.Less(time.Since(start), time.Minute)
+	require.Equal(int32(1), atomic.LoadInt32(requests))
+	require.Nil(clientFactory.GetSAClient(testClusterName))
+	require.NotContains(clientFactory.remoteClusterInfos, testClusterName)
+}
+
+func TestRemoteClusterConfigErrorNotRetried(t *testing.T) {
+	require := require.New(t)
+	_, requests := setupFlakyRemoteCluster(t, 0, base64.StdEncoding.EncodeToString([]byte("not a certificate")))
+
+	restConfig := rest.Config{}
+	_, err := newClientFactory(&restConfig)
+	require.ErrorContains(err, "unable to load root certificates")
+	require.Zero(atomic.LoadInt32(requests))
+}
@@ -0,0 +1,91 @@
+package kubernetes
+
+import (
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+)
+
+// EffectiveHTTPRoute is an HTTP route applied by a VirtualService, defined by the VirtualService itself or
+// by one of its delegates. The namespace is the one of the VirtualService defining the route, where the
+// short hosts of its destinations are resolved.
+type EffectiveHTTPRoute struct {
+	Route     *api_networking_v1beta1.HTTPRoute
+	Namespace string
+}
+
+// FindVirtualServiceDelegate returns the VirtualService targeted by the delegate of an HTTP route, or nil when it
+// doesn't exist. The namespace of the delegate defaults to the namespace of the VirtualService delegating the route.
+func FindVirtualServiceDelegate(delegate *api_networking_v1beta1.Delegate, namespace string, allVs []*networking_v1beta1.VirtualService) *networking_v1beta1.VirtualService {
+	if delegate == nil {
+		return nil
+	}
+	if delegate.Namespace != "" {
+		namespace = delegate.Namespace
+	}
+	for _, vs := range allVs {
+		if vs.Name == delegate.Name && vs.Namespace == namespace {
+			return vs
+		}
+	}
+	return nil
+}
+
+// GetEffectiveHTTPRoutes returns the HTTP routes applied by the VirtualService, where the routes delegated to other
+// VirtualServices are replaced by the routes of the delegates, following the delegate chain.
+// The delegates not found are skipped and every delegate is resolved once, so a cycle is not followed.
+func GetEffectiveHTTPRoutes(vs *networking_v1beta1.VirtualService, allVs []*networking_v1beta1.VirtualService) []EffectiveHTTPRoute {
+	routes := []EffectiveHTTPRoute{}
+	if vs == nil {
+		return routes
+	}
+	visited := map[string]bool{vs.Namespace + "/" + vs.Name: true}
+	return appendEffectiveHTTPRoutes(routes, vs, allVs, visited)
+}
+
+func appendEffectiveHTTPRoutes(routes []EffectiveHTTPRoute, vs *networking_v1beta1.VirtualService, allVs []*networking_v1beta1.VirtualService, visited map[string]bool) []EffectiveHTTPRoute {
+	for _, httpRoute := range vs.Spec.Http {
+		if httpRoute == nil {
+			continue
+		}
+		if httpRoute.Delegate == nil {
+			routes = append(routes, EffectiveHTTPRoute{Route: httpRoute, Namespace: vs.Namespace})
+			continue
+		}
+		delegateVs := FindVirtualServiceDelegate(httpRoute.Delegate, vs.Namespace, allVs)
+		if delegateVs == nil {
+			continue
+		}
+		key := delegateVs.Namespace + "/" + delegateVs.Name
+		if visited[key] {
+			continue
+		}
+		visited[key] = true
+		routes = appendEffectiveHTTPRoutes(routes, delegateVs, allVs, visited)
+	}
+	return routes
+}
+
+// FilterVirtualServicesByEffectiveRoutes returns the VirtualServices routing to the service, like
+// FilterVirtualServicesByService, including the VirtualServices delegating the routes to the service.
+func FilterVirtualServicesByEffectiveRoutes(allVs []*networking_v1beta1.VirtualService, namespace string, serviceName string) []*networking_v1beta1.VirtualService {
+	filtered := []*networking_v1beta1.VirtualService{}
+	routing := FilterVirtualServicesByService(allVs, namespace, serviceName)
+VirtualServices:
+	for _, vs := range allVs {
+		for _, routingVs := range routing {
+			if routingVs == vs {
+				filtered = append(filtered, vs)
+				continue VirtualServices
+			}
+		}
+		for _, route := range GetEffectiveHTTPRoutes(vs, allVs) {
+			for _, dest := range route.Route.Route {
+				if dest != nil && dest.Destination != nil && FilterByHost(dest.Destination.Host, route.Namespace, serviceName, namespace) {
+					filtered = append(filtered, vs)
+					continue VirtualServices
+				}
+			}
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,82 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+)
+
+func fakeVirtualService(name, namespace string, routes ...*api_networking_v1beta1.HTTPRoute) *networking_v1beta1.VirtualService {
+	return &networking_v1beta1.VirtualService{
+		ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       api_networking_v1beta1.VirtualService{Http: routes},
+	}
+}
+
+func fakeDelegateRoute(name, namespace string) *api_networking_v1beta1.HTTPRoute {
+	return &api_networking_v1beta1.HTTPRoute{Delegate: &api_networking_v1beta1.Delegate{Name: name, Namespace: namespace}}
+}
+
+func fakeDestinationRoute(host string) *api_networking_v1beta1.HTTPRoute {
+	return &api_networking_v1beta1.HTTPRoute{
+		Route: []*api_networking_v1beta1.HTTPRouteDestination{{Destination: &api_networking_v1beta1.Destination{Host: host}}},
+	}
+}
+
+func TestGetEffectiveHTTPRoutesDelegateChain(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	config.Set(config.NewConfig())
+
+	ratingsRoute := fakeDestinationRoute("ratings")
+	reviewsRoute := fakeDestinationRoute("reviews")
+	root := fakeVirtualService("bookinfo", "istio-system", fakeDelegateRoute("bookinfo-routes", "bookinfo"), ratingsRoute)
+	// The namespace of the delegate defaults to the namespace of the VirtualService
+	routes := fakeVirtualService("bookinfo-routes", "bookinfo", fakeDelegateRoute("reviews", ""))
+	reviews := fakeVirtualService("reviews", "bookinfo", reviewsRoute)
+	allVs := []*networking_v1beta1.VirtualService{root, routes, reviews}
+
+	assert.Equal(reviews, FindVirtualServiceDelegate(routes.Spec.Http[0].Delegate, routes.Namespace, allVs))
+
+	effectiveRoutes := GetEffectiveHTTPRoutes(root, allVs)
+	require.Len(effectiveRoutes, 2)
+	assert.Equal(EffectiveHTTPRoute{Route: reviewsRoute, Namespace: "bookinfo"}, effectiveRoutes[0])
+	assert.Equal(EffectiveHTTPRoute{Route: ratingsRoute, Namespace: "istio-system"}, effectiveRoutes[1])
+
+	// The root is delegating the reviews routes, the short host of the delegate resolves in its namespace
+	assert.Equal([]*networking_v1beta1.VirtualService{root, routes, reviews}, FilterVirtualServicesByEffectiveRoutes(allVs, "bookinfo", "reviews"))
+	assert.Equal([]*networking_v1beta1.VirtualService{root}, FilterVirtualServicesByEffectiveRoutes(allVs, "istio-system", "ratings"))
+	assert.Empty(FilterVirtualServicesByEffectiveRoutes(allVs, "istio-system", "reviews"))
+}
+
+func TestGetEffectiveHTTPRoutesDanglingDelegate(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	reviewsRoute := fakeDestinationRoute("reviews")
+	vs := fakeVirtualService("bookinfo", "bookinfo", fakeDelegateRoute("missing", "bookinfo"), reviewsRoute)
+	allVs := []*networking_v1beta1.VirtualService{vs}
+
+	assert.Nil(FindVirtualServiceDelegate(vs.Spec.Http[0].Delegate, vs.Namespace, allVs))
+	assert.Equal([]EffectiveHTTPRoute{{Route: reviewsRoute, Namespace: "bookinfo"}}, GetEffectiveHTTPRoutes(vs, allVs))
+}
+
+func TestGetEffectiveHTTPRoutesDelegateCycle(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	reviewsRoute := fakeDestinationRoute("reviews")
+	first := fakeVirtualService("first", "bookinfo", fakeDelegateRoute("second", ""), reviewsRoute)
+	second := fakeVirtualService("second", "bookinfo", fakeDelegateRoute("first", ""))
+	allVs := []*networking_v1beta1.VirtualService{first, second}
+
+	assert.Equal([]EffectiveHTTPRoute{{Route: reviewsRoute, Namespace: "bookinfo"}}, GetEffectiveHTTPRoutes(first, allVs))
+	assert.Equal([]EffectiveHTTPRoute{{Route: reviewsRoute, Namespace: "bookinfo"}}, GetEffectiveHTTPRoutes(second, allVs))
+	assert.Equal([]*networking_v1beta1.VirtualService{first, second}, FilterVirtualServicesByEffectiveRoutes(allVs, "bookinfo", "reviews"))
+}
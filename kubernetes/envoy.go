@@ -30,6 +30,35 @@ type EnvoyMetadata struct {
 	} `mapstructure:"filter_metadata,omitempty"`
 }
 
+type EndpointDump struct {
+	DynamicEndpointConfigs []EnvoyEndpointConfig `mapstructure:"dynamic_endpoint_configs"`
+	StaticEndpointConfigs  []EnvoyEndpointConfig `mapstructure:"static_endpoint_configs"`
+}
+
+type EnvoyEndpointConfig struct {
+	EndpointConfig EnvoyClusterLoadAssignment `mapstructure:"endpoint_config"`
+}
+
+type EnvoyClusterLoadAssignment struct {
+	ClusterName string `mapstructure:"cluster_name"`
+	Endpoints   []struct {
+		LbEndpoints []EnvoyLbEndpoint `mapstructure:"lb_endpoints"`
+	} `mapstructure:"endpoints"`
+}
+
+type EnvoyLbEndpoint struct {
+	Endpoint struct {
+		Address struct {
+			SocketAddress struct {
+				Address   string  `mapstructure:"address"`
+				PortValue float64 `mapstructure:"port_value"`
+			} `mapstructure:"socket_address"`
+		} `mapstructure:"address"`
+	} `mapstructure:"endpoint"`
+	// HealthStatus is omitted by Envoy when it is UNKNOWN
+	HealthStatus string `mapstructure:"health_status,omitempty"`
+}
+
 type RouteDump struct {
 	DynamicRouteConfigs []EnvoyRouteConfig `mapstructure:"dynamic_route_configs"`
 	StaticRouteConfigs  []EnvoyRouteConfig `mapstructure:"static_route_configs"`
@@ -56,6 +85,7 @@ type StaticListener struct {
 }
 
 type EnvoyListener struct {
+	Name    string `mapstructure:"name"`
 	Address struct {
 		SocketAddress struct {
 			Address   string  `mapstructure:"address"`
@@ -124,6 +154,14 @@ func (cd *ConfigDump) GetClusters() (*ClusterDump, error) {
 	return &clusterDump, mapstructure.Decode(clusterDumpRaw, &clusterDump)
 }
 
+// GetEndpoints returns the endpoints of the clusters. They are only part of the dump
+// when it has been requested with the include_eds parameter.
+func (cd *ConfigDump) GetEndpoints() (*EndpointDump, error) {
+	endpointDumpRaw := cd.GetConfig("type.googleapis.com/envoy.admin.v3.EndpointsConfigDump")
+	var endpointDump EndpointDump
+	return &endpointDump, mapstructure.Decode(endpointDumpRaw, &endpointDump)
+}
+
 func (cd *ConfigDump) GetRoutes() (*RouteDump, error) {
 	routeDumpRaw := cd.GetConfig("type.googleapis.com/envoy.admin.v3.RoutesConfigDump")
 	var routeDump RouteDump
@@ -2,11 +2,15 @@ package kubernetes
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
+	api_extensions_v1alpha1 "istio.io/api/extensions/v1alpha1"
+	extentions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
 	networking_v1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
 	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	"istio.io/client-go/pkg/apis/telemetry/v1alpha1"
 	core_v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
@@ -424,6 +428,19 @@ func FilterServicesByLabels(selector labels.Selector, allServices []core_v1.Serv
 	return services
 }
 
+// FilterServicesBySelectedLabels returns the services whose selector matches the labels of a workload.
+// The services without selector don't select any workload.
+func FilterServicesBySelectedLabels(workloadLabels map[string]string, allServices []core_v1.Service) []core_v1.Service {
+	var services []core_v1.Service
+	for _, svc := range allServices {
+		svcSelector := labels.Set(svc.Spec.Selector).AsSelector()
+		if !svcSelector.Empty() && svcSelector.Matches(labels.Set(workloadLabels)) {
+			services = append(services, svc)
+		}
+	}
+	return services
+}
+
 func FilterServiceEntriesByHostname(serviceEntries []*networking_v1beta1.ServiceEntry, hostname string) []*networking_v1beta1.ServiceEntry {
 	filtered := []*networking_v1beta1.ServiceEntry{}
 	for _, se := range serviceEntries {
@@ -457,6 +474,117 @@ func FilterSidecarsBySelector(workloadSelector string, sidecars []*networking_v1
 	return filtered
 }
 
+// FilterSidecarsByEgressHost returns the Sidecars naming a service in the hosts of their egress listeners.
+// The namespace part of an egress host must be the namespace of the service, "*", or "." for the Sidecars of the same namespace.
+// The wildcard hosts, like "*/*" or "./*", don't name a service so they are not matched.
+func FilterSidecarsByEgressHost(sidecars []*networking_v1beta1.Sidecar, namespace string, serviceName string) []*networking_v1beta1.Sidecar {
+	filtered := []*networking_v1beta1.Sidecar{}
+	for _, sc := range sidecars {
+		if sidecarEgressNamesService(sc, namespace, serviceName) {
+			filtered = append(filtered, sc)
+		}
+	}
+	return filtered
+}
+
+func sidecarEgressNamesService(sc *networking_v1beta1.Sidecar, namespace string, serviceName string) bool {
+	for _, ei := range sc.Spec.Egress {
+		if ei == nil {
+			continue
+		}
+		for _, egressHost := range ei.Hosts {
+			egressNs, dnsName, ok := strings.Cut(egressHost, "/")
+			if !ok || strings.HasPrefix(dnsName, "*") {
+				continue
+			}
+			if egressNs == "." {
+				egressNs = sc.Namespace
+			}
+			if egressNs != "*" && egressNs != namespace {
+				continue
+			}
+			if FilterByHost(dnsName, namespace, serviceName, namespace) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SidecarEgressAllows checks that a host is in the egress scope of a Sidecar, defined by "namespace/dnsName" hosts.
+// The namespace of a service host is part of its name, the namespaces of an external host are the ones of the
+// ServiceEntries declaring it. A Sidecar without egress listeners doesn't restrict the egress.
+func SidecarEgressAllows(sidecar *networking_v1beta1.Sidecar, host, namespace string, serviceEntries []*networking_v1beta1.ServiceEntry) bool {
+	if len(sidecar.Spec.Egress) == 0 {
+		return true
+	}
+
+	fqdn := host
+	hostNamespaces := []string{}
+	if parsedHost := GetHost(host, namespace, nil); parsedHost.CompleteInput {
+		fqdn = parsedHost.String()
+		hostNamespaces = append(hostNamespaces, parsedHost.Namespace)
+	} else {
+		for _, se := range serviceEntries {
+			for _, seHost := range se.Spec.Hosts {
+				if seHost == host || HostWithinWildcardHost(host, seHost) {
+					hostNamespaces = append(hostNamespaces, se.Namespace)
+					break
+				}
+			}
+		}
+	}
+
+	for _, ei := range sidecar.Spec.Egress {
+		if ei == nil {
+			continue
+		}
+		for _, egressHost := range ei.Hosts {
+			egressNs, dnsName, ok := strings.Cut(egressHost, "/")
+			if !ok {
+				continue
+			}
+			if dnsName != "*" && dnsName != fqdn && dnsName != host && !HostWithinWildcardHost(fqdn, dnsName) {
+				continue
+			}
+			switch egressNs {
+			case "*":
+				return true
+			case "~":
+				continue
+			case ".":
+				egressNs = sidecar.Namespace
+			}
+			for _, hostNs := range hostNamespaces {
+				if hostNs == egressNs {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func FilterTelemetriesBySelector(workloadSelector string, telemetries []*v1alpha1.Telemetry) []*v1alpha1.Telemetry {
+	filtered := []*v1alpha1.Telemetry{}
+	workloadLabels := mapWorkloadSelector(workloadSelector)
+	for _, tm := range telemetries {
+		wkLabelsS := []string{}
+		if tm.Spec.Selector != nil {
+			tmSelector := tm.Spec.Selector.MatchLabels
+			for k, v := range tmSelector {
+				wkLabelsS = append(wkLabelsS, k+"="+v)
+			}
+		}
+		if resourceSelector, err := labels.Parse(strings.Join(wkLabelsS, ",")); err == nil {
+			if resourceSelector.Matches(labels.Set(workloadLabels)) {
+				filtered = append(filtered, tm)
+			}
+		}
+	}
+	return filtered
+}
+
 func FilterVirtualServicesByHostname(allVs []*networking_v1beta1.VirtualService, hostname string) []*networking_v1beta1.VirtualService {
 	filtered := []*networking_v1beta1.VirtualService{}
 	for _, vs := range allVs {
@@ -606,6 +734,77 @@ func FilterVirtualServiceByRoute(vs *networking_v1beta1.VirtualService, service
 	return false
 }
 
+func FilterWasmPluginsBySelector(workloadSelector string, wasmplugins []*extentions_v1alpha1.WasmPlugin) []*extentions_v1alpha1.WasmPlugin {
+	filtered := []*extentions_v1alpha1.WasmPlugin{}
+	workloadLabels := mapWorkloadSelector(workloadSelector)
+	for _, wp := range wasmplugins {
+		wkLabelsS := []string{}
+		if wp.Spec.Selector != nil {
+			wpSelector := wp.Spec.Selector.MatchLabels
+			for k, v := range wpSelector {
+				wkLabelsS = append(wkLabelsS, k+"="+v)
+			}
+		}
+		if resourceSelector, err := labels.Parse(strings.Join(wkLabelsS, ",")); err == nil {
+			if resourceSelector.Matches(labels.Set(workloadLabels)) {
+				filtered = append(filtered, wp)
+			}
+		}
+	}
+	return filtered
+}
+
+// FilterWasmPluginsByWorkload returns the WasmPlugins applying to a workload of the namespace: the WasmPlugins of the root namespace
+// without selector apply to the whole mesh, the other ones only apply to the workloads of their namespace they select.
+// The WasmPlugins are returned in the order the proxies apply them, see SortWasmPlugins.
+func FilterWasmPluginsByWorkload(wasmplugins []*extentions_v1alpha1.WasmPlugin, rootNamespace, namespace, workloadSelector string) []*extentions_v1alpha1.WasmPlugin {
+	candidates := []*extentions_v1alpha1.WasmPlugin{}
+	for _, wp := range wasmplugins {
+		meshWide := wp.Namespace == rootNamespace && (wp.Spec.Selector == nil || len(wp.Spec.Selector.MatchLabels) == 0)
+		if wp.Namespace == namespace || meshWide {
+			candidates = append(candidates, wp)
+		}
+	}
+	filtered := FilterWasmPluginsBySelector(workloadSelector, candidates)
+	SortWasmPlugins(filtered)
+	return filtered
+}
+
+// wasmPluginPhaseOrder is the position of each phase in the filter chain. The plugins without phase are inserted
+// at the end of the chain, right before the router.
+var wasmPluginPhaseOrder = map[api_extensions_v1alpha1.PluginPhase]int{
+	api_extensions_v1alpha1.PluginPhase_AUTHN:             0,
+	api_extensions_v1alpha1.PluginPhase_AUTHZ:             1,
+	api_extensions_v1alpha1.PluginPhase_STATS:             2,
+	api_extensions_v1alpha1.PluginPhase_UNSPECIFIED_PHASE: 3,
+}
+
+// WasmPluginPriority returns the priority of the WasmPlugin, which defaults to 0
+func WasmPluginPriority(wp *extentions_v1alpha1.WasmPlugin) int64 {
+	if wp.Spec.Priority == nil {
+		return 0
+	}
+	return wp.Spec.Priority.Value
+}
+
+// SortWasmPlugins sorts the WasmPlugins in the order the proxies apply them: by phase, then by descending priority.
+// Istio breaks the ties with the name and the namespace of the WasmPlugins.
+func SortWasmPlugins(wasmplugins []*extentions_v1alpha1.WasmPlugin) {
+	sort.SliceStable(wasmplugins, func(i, j int) bool {
+		wi, wj := wasmplugins[i], wasmplugins[j]
+		if pi, pj := wasmPluginPhaseOrder[wi.Spec.Phase], wasmPluginPhaseOrder[wj.Spec.Phase]; pi != pj {
+			return pi < pj
+		}
+		if pi, pj := WasmPluginPriority(wi), WasmPluginPriority(wj); pi != pj {
+			return pi > pj
+		}
+		if wi.Name != wj.Name {
+			return wi.Name < wj.Name
+		}
+		return wi.Namespace < wj.Namespace
+	})
+}
+
 func mapWorkloadSelector(workloadSelector string) map[string]string {
 	// workloadSelector is a representation of the template labels of a workload
 	workloadLabels := map[string]string{}
@@ -3,7 +3,12 @@ package kubernetes
 import (
 	"testing"
 
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/stretchr/testify/assert"
+	api_extensions_v1alpha1 "istio.io/api/extensions/v1alpha1"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	api_v1beta1 "istio.io/api/type/v1beta1"
+	extentions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
 	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	apps_v1 "k8s.io/api/apps/v1"
 	core_v1 "k8s.io/api/core/v1"
@@ -12,6 +17,8 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kiali/kiali/config"
 )
 
 func TestFilterPodsForEndpoints(t *testing.T) {
@@ -226,6 +233,54 @@ func TestFilterRegistryServicesBySelector(t *testing.T) {
 	assert.Equal(rs8, filtered[2])
 }
 
+func TestFilterServicesBySelectedLabels(t *testing.T) {
+	assert := assert.New(t)
+
+	service := func(name string, selector map[string]string) core_v1.Service {
+		return core_v1.Service{ObjectMeta: meta_v1.ObjectMeta{Name: name, Labels: map[string]string{"app": "reviews", "version": "v1"}}, Spec: core_v1.ServiceSpec{Selector: selector}}
+	}
+	services := []core_v1.Service{
+		service("reviews", map[string]string{"app": "reviews"}),
+		service("reviews-v2", map[string]string{"app": "reviews", "version": "v2"}),
+		// Selects nothing, whatever its labels
+		service("external", nil),
+	}
+
+	filtered := FilterServicesBySelectedLabels(map[string]string{"app": "reviews", "version": "v1"}, services)
+	assert.Len(filtered, 1)
+	assert.Equal("reviews", filtered[0].Name)
+	assert.Empty(FilterServicesBySelectedLabels(map[string]string{"app": "ratings"}, services))
+}
+
+func TestFilterSidecarsByEgressHost(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	sidecar := func(name, namespace string, hosts ...string) *networking_v1beta1.Sidecar {
+		sc := &networking_v1beta1.Sidecar{}
+		sc.Name = name
+		sc.Namespace = namespace
+		sc.Spec.Egress = []*api_networking_v1beta1.IstioEgressListener{{Hosts: hosts}}
+		return sc
+	}
+	sidecars := []*networking_v1beta1.Sidecar{
+		sidecar("fqdn", "other", "bookinfo/reviews.bookinfo.svc.cluster.local"),
+		sidecar("any-namespace", "other", "*/reviews.bookinfo"),
+		sidecar("same-namespace", "bookinfo", "./reviews.bookinfo.svc.cluster.local"),
+		sidecar("dot-other-namespace", "other", "./reviews.bookinfo.svc.cluster.local"),
+		sidecar("wildcard", "bookinfo", "*/*", "./*", "bookinfo/*.bookinfo.svc.cluster.local"),
+		sidecar("other-service", "bookinfo", "bookinfo/ratings.bookinfo.svc.cluster.local"),
+		sidecar("no-namespace", "bookinfo", "reviews.bookinfo.svc.cluster.local"),
+	}
+
+	filtered := FilterSidecarsByEgressHost(sidecars, "bookinfo", "reviews")
+	names := []string{}
+	for _, sc := range filtered {
+		names = append(names, sc.Name)
+	}
+	assert.Equal([]string{"fqdn", "any-namespace", "same-namespace"}, names)
+}
+
 func CreateFakeRegistryService(host string, namespace string, exportToNamespace string, labels map[string]string) *RegistryService {
 	registryService := RegistryService{}
 	registryService.Hostname = host
@@ -236,3 +291,46 @@ func CreateFakeRegistryService(host string, namespace string, exportToNamespace
 
 	return &registryService
 }
+
+func TestFilterWasmPluginsByWorkload(t *testing.T) {
+	assert := assert.New(t)
+
+	wasmPlugin := func(name, namespace string, selector map[string]string, phase api_extensions_v1alpha1.PluginPhase, priority *int64) *extentions_v1alpha1.WasmPlugin {
+		wp := &extentions_v1alpha1.WasmPlugin{}
+		wp.Name = name
+		wp.Namespace = namespace
+		wp.Spec.Phase = phase
+		if priority != nil {
+			wp.Spec.Priority = &wrappers.Int64Value{Value: *priority}
+		}
+		if selector != nil {
+			wp.Spec.Selector = &api_v1beta1.WorkloadSelector{MatchLabels: selector}
+		}
+		return wp
+	}
+	high := int64(10)
+	low := int64(-5)
+
+	wasmPlugins := []*extentions_v1alpha1.WasmPlugin{
+		wasmPlugin("unspecified", "bookinfo", nil, api_extensions_v1alpha1.PluginPhase_UNSPECIFIED_PHASE, &high),
+		wasmPlugin("stats", "bookinfo", map[string]string{"app": "reviews"}, api_extensions_v1alpha1.PluginPhase_STATS, nil),
+		wasmPlugin("authn-low", "istio-system", nil, api_extensions_v1alpha1.PluginPhase_AUTHN, &low),
+		wasmPlugin("authn-default", "bookinfo", nil, api_extensions_v1alpha1.PluginPhase_AUTHN, nil),
+		wasmPlugin("authn-high", "bookinfo", map[string]string{"app": "reviews"}, api_extensions_v1alpha1.PluginPhase_AUTHN, &high),
+		wasmPlugin("authz", "bookinfo", nil, api_extensions_v1alpha1.PluginPhase_AUTHZ, nil),
+		// Not applied to reviews
+		wasmPlugin("ratings", "bookinfo", map[string]string{"app": "ratings"}, api_extensions_v1alpha1.PluginPhase_AUTHN, nil),
+		wasmPlugin("other", "travels", nil, api_extensions_v1alpha1.PluginPhase_AUTHN, nil),
+		wasmPlugin("istiod", "istio-system", map[string]string{"app": "reviews"}, api_extensions_v1alpha1.PluginPhase_AUTHN, nil),
+	}
+
+	// The selector doesn't consider the namespaces
+	assert.Len(FilterWasmPluginsBySelector("app=reviews,version=v1", wasmPlugins), 8)
+
+	names := []string{}
+	for _, wp := range FilterWasmPluginsByWorkload(wasmPlugins, "istio-system", "bookinfo", "app=reviews,version=v1") {
+		names = append(names, wp.Name)
+	}
+	// Sorted by phase, then by descending priority
+	assert.Equal([]string{"authn-high", "authn-default", "authn-low", "authz", "stats", "unspecified"}, names)
+}
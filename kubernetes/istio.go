@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -61,6 +62,11 @@ type ComponentStatus struct {
 	// example:  true
 	// required: true
 	IsCore bool `json:"is_core"`
+
+	// The cluster of the component
+	//
+	// example:  east
+	Cluster string `json:"cluster,omitempty"`
 }
 
 type IstioComponentStatus []ComponentStatus
@@ -70,6 +76,19 @@ func (ics *IstioComponentStatus) Merge(cs IstioComponentStatus) IstioComponentSt
 	return *ics
 }
 
+// Sort orders the components to be stable between calls: the core components first, then by name and status
+func (ics IstioComponentStatus) Sort() {
+	sort.SliceStable(ics, func(i, j int) bool {
+		if ics[i].IsCore != ics[j].IsCore {
+			return ics[i].IsCore
+		}
+		if ics[i].Name != ics[j].Name {
+			return ics[i].Name < ics[j].Name
+		}
+		return ics[i].Status < ics[j].Status
+	})
+}
+
 const (
 	envoyAdminPort = 15000
 )
@@ -88,6 +107,7 @@ type IstioClientInterface interface {
 	CanConnectToIstiod() (IstioComponentStatus, error)
 	GetProxyStatus() ([]*ProxyStatus, error)
 	GetConfigDump(namespace, podName string) (*ConfigDump, error)
+	GetConfigDumpWithEndpoints(namespace, podName string) (*ConfigDump, error)
 	SetProxyLogLevel(namespace, podName, level string) error
 	GetRegistryConfiguration() (*RegistryConfiguration, error)
 	GetRegistryEndpoints() ([]*RegistryEndpoint, error)
@@ -613,12 +633,22 @@ func ParseRegistryConfig(config map[string][]byte) (*RegistryConfiguration, erro
 }
 
 func (in *K8SClient) GetConfigDump(namespace, podName string) (*ConfigDump, error) {
+	return in.getConfigDump(namespace, podName, "/config_dump")
+}
+
+// GetConfigDumpWithEndpoints fetches the config dump including the endpoints, and so their health as
+// seen by the proxy. The endpoints make the dump much larger, so they are only fetched when needed.
+func (in *K8SClient) GetConfigDumpWithEndpoints(namespace, podName string) (*ConfigDump, error) {
+	return in.getConfigDump(namespace, podName, "/config_dump?include_eds")
+}
+
+func (in *K8SClient) getConfigDump(namespace, podName, path string) (*ConfigDump, error) {
 	// Fetching the Config Dump from the pod's Envoy.
 	// The port 15000 is open on each Envoy Sidecar (managed by Istio) to serve the Envoy Admin  interface.
 	// This port can only be accessed by inside the pod.
 	// See the Istio's doc page about its port usage:
 	// https://istio.io/latest/docs/ops/deployment/requirements/#ports-used-by-istio
-	resp, err := in.forwardGetRequest(namespace, podName, 15000, "/config_dump")
+	resp, err := in.forwardGetRequest(namespace, podName, 15000, path)
 	if err != nil {
 		log.Errorf("Error forwarding the /config_dump request: %v", err)
 		return nil, err
@@ -781,6 +811,27 @@ func MatchPortAppProtocolWithValidProtocols(appProtocol *string) bool {
 	return false
 }
 
+// ServicePortProtocol returns the protocol of a service port following the Istio conventions: the appProtocol of the port
+// when Istio supports it, else the protocol prefixing the port name, as "<protocol>" or "<protocol>-<suffix>".
+// The UDP ports are udp, and the ports declaring neither of them default to tcp.
+func ServicePortProtocol(port core_v1.ServicePort) string {
+	if port.Protocol == core_v1.ProtocolUDP {
+		return "udp"
+	}
+	if MatchPortAppProtocolWithValidProtocols(port.AppProtocol) {
+		return strings.ToLower(*port.AppProtocol)
+	}
+	portName := strings.ToLower(port.Name)
+	protocol := "tcp"
+	// The longest prefix wins, so a grpc-web port isn't grpc
+	for _, candidate := range portProtocols {
+		if (portName == candidate || strings.HasPrefix(portName, candidate+"-")) && len(candidate) > len(protocol) {
+			protocol = candidate
+		}
+	}
+	return protocol
+}
+
 // GatewayNames extracts the gateway names for easier matching
 func GatewayNames(gateways []*networking_v1beta1.Gateway) map[string]struct{} {
 	var empty struct{}
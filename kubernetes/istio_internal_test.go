@@ -155,6 +155,31 @@ func TestTCPAndUDPMatcher(t *testing.T) {
 	assert.True(t, MatchPortNameRule("everythingisvalid", "TCP"))
 }
 
+func TestServicePortProtocol(t *testing.T) {
+	grpc := "GRPC"
+	unknown := "kubernetes.io/h2c"
+	cases := map[string]struct {
+		port     core_v1.ServicePort
+		expected string
+	}{
+		"http named port":         {port: core_v1.ServicePort{Name: "http-web", Protocol: core_v1.ProtocolTCP}, expected: "http"},
+		"grpc named port":         {port: core_v1.ServicePort{Name: "grpc", Protocol: core_v1.ProtocolTCP}, expected: "grpc"},
+		"grpc-web named port":     {port: core_v1.ServicePort{Name: "grpc-web-api", Protocol: core_v1.ProtocolTCP}, expected: "grpc-web"},
+		"tcp named port":          {port: core_v1.ServicePort{Name: "tcp-db", Protocol: core_v1.ProtocolTCP}, expected: "tcp"},
+		"unnamed port":            {port: core_v1.ServicePort{Protocol: core_v1.ProtocolTCP}, expected: "tcp"},
+		"name without protocol":   {port: core_v1.ServicePort{Name: "httpbin", Protocol: core_v1.ProtocolTCP}, expected: "tcp"},
+		"appProtocol over name":   {port: core_v1.ServicePort{Name: "http", Protocol: core_v1.ProtocolTCP, AppProtocol: &grpc}, expected: "grpc"},
+		"unsupported appProtocol": {port: core_v1.ServicePort{Name: "http2", Protocol: core_v1.ProtocolTCP, AppProtocol: &unknown}, expected: "http2"},
+		"udp port":                {port: core_v1.ServicePort{Name: "dns", Protocol: core_v1.ProtocolUDP}, expected: "udp"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ServicePortProtocol(tc.port))
+		})
+	}
+}
+
 func TestValidProtocolNameMatcher(t *testing.T) {
 	assert.True(t, MatchPortNameRule("http-name", "http"))
 	assert.True(t, MatchPortNameRule("http2-name", "http2"))
@@ -14,9 +14,11 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	admission_v1 "k8s.io/api/admissionregistration/v1"
 	apps_v1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/authentication/v1"
 	auth_v1 "k8s.io/api/authorization/v1"
+	autoscaling_v2 "k8s.io/api/autoscaling/v2"
 	batch_v1 "k8s.io/api/batch/v1"
 	core_v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -40,6 +42,7 @@ type K8SClientInterface interface {
 	Kube() kubernetes.Interface
 	GetClusterServicesByLabels(labelsSelector string) ([]core_v1.Service, error)
 	GetConfigMap(namespace, name string) (*core_v1.ConfigMap, error)
+	GetCronJob(namespace string, name string) (*batch_v1.CronJob, error)
 	GetCronJobs(namespace string) ([]batch_v1.CronJob, error)
 	GetDaemonSet(namespace string, name string) (*apps_v1.DaemonSet, error)
 	GetDaemonSets(namespace string) ([]apps_v1.DaemonSet, error)
@@ -48,21 +51,29 @@ type K8SClientInterface interface {
 	GetDeploymentConfig(namespace string, name string) (*osapps_v1.DeploymentConfig, error)
 	GetDeploymentConfigs(namespace string) ([]osapps_v1.DeploymentConfig, error)
 	GetEndpoints(namespace string, name string) (*core_v1.Endpoints, error)
+	GetEvents(namespace, fieldSelector string) ([]core_v1.Event, error)
+	GetHorizontalPodAutoscalers(namespace string) ([]autoscaling_v2.HorizontalPodAutoscaler, error)
+	GetJob(namespace string, name string) (*batch_v1.Job, error)
+	GetMutatingWebhookConfigurations(labelSelector string) ([]admission_v1.MutatingWebhookConfiguration, error)
 	GetJobs(namespace string) ([]batch_v1.Job, error)
 	GetNamespace(namespace string) (*core_v1.Namespace, error)
 	GetNamespaces(labelSelector string) ([]core_v1.Namespace, error)
+	GetNode(name string) (*core_v1.Node, error)
 	GetPod(namespace, name string) (*core_v1.Pod, error)
 	GetPods(namespace, labelSelector string) ([]core_v1.Pod, error)
+	GetReplicationController(namespace string, name string) (*core_v1.ReplicationController, error)
 	GetReplicationControllers(namespace string) ([]core_v1.ReplicationController, error)
 	GetReplicaSets(namespace string) ([]apps_v1.ReplicaSet, error)
 	GetSecret(namespace, name string) (*core_v1.Secret, error)
 	GetSelfSubjectAccessReview(ctx context.Context, namespace, api, resourceType string, verbs []string) ([]*auth_v1.SelfSubjectAccessReview, error)
+	GetSelfSubjectRulesReview(ctx context.Context, namespace string) (*auth_v1.SelfSubjectRulesReview, error)
 	GetService(namespace string, name string) (*core_v1.Service, error)
 	GetServices(namespace string, selectorLabels map[string]string) ([]core_v1.Service, error)
 	GetServicesByLabels(namespace string, labelsSelector string) ([]core_v1.Service, error)
 	GetStatefulSet(namespace string, name string) (*apps_v1.StatefulSet, error)
 	GetStatefulSets(namespace string) ([]apps_v1.StatefulSet, error)
 	GetTokenSubject(authInfo *api.AuthInfo) (string, error)
+	GetTokenUser(authInfo *api.AuthInfo) (*v1.UserInfo, error)
 	StreamPodLogs(namespace, name string, opts *core_v1.PodLogOptions) (io.ReadCloser, error)
 	UpdateNamespace(namespace string, jsonPatch string) (*core_v1.Namespace, error)
 	UpdateService(namespace string, name string, jsonPatch string, patchType string) error
@@ -141,6 +152,12 @@ func (in *K8SClient) GetNamespace(namespace string) (*core_v1.Namespace, error)
 	return ns, nil
 }
 
+// GetNode fetches and returns the specified node definition
+// from the cluster
+func (in *K8SClient) GetNode(name string) (*core_v1.Node, error) {
+	return in.k8s.CoreV1().Nodes().Get(in.ctx, name, emptyGetOptions)
+}
+
 // GetServerVersion fetches and returns information about the version Kubernetes that is running
 func (in *K8SClient) GetServerVersion() (*version.Info, error) {
 	return in.k8s.Discovery().ServerVersion()
@@ -149,6 +166,15 @@ func (in *K8SClient) GetServerVersion() (*version.Info, error) {
 // GetNamespaces returns a list of all namespaces of the cluster.
 // It returns a list of all namespaces of the cluster.
 // It returns an error on any problem.
+// GetMutatingWebhookConfigurations returns the MutatingWebhookConfigurations matching the labelSelector
+func (in *K8SClient) GetMutatingWebhookConfigurations(labelSelector string) ([]admission_v1.MutatingWebhookConfiguration, error) {
+	webhookList, err := in.k8s.AdmissionregistrationV1().MutatingWebhookConfigurations().List(in.ctx, meta_v1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, err
+	}
+	return webhookList.Items, nil
+}
+
 func (in *K8SClient) GetNamespaces(labelSelector string) ([]core_v1.Namespace, error) {
 	var listOptions meta_v1.ListOptions
 
@@ -398,6 +424,10 @@ func (in *K8SClient) GetStatefulSets(namespace string) ([]apps_v1.StatefulSet, e
 	}
 }
 
+func (in *K8SClient) GetReplicationController(namespace string, name string) (*core_v1.ReplicationController, error) {
+	return in.k8s.CoreV1().ReplicationControllers(namespace).Get(in.ctx, name, emptyGetOptions)
+}
+
 func (in *K8SClient) GetReplicationControllers(namespace string) ([]core_v1.ReplicationController, error) {
 	if rcList, err := in.k8s.CoreV1().ReplicationControllers(namespace).List(in.ctx, emptyListOptions); err == nil {
 		return rcList.Items, nil
@@ -418,6 +448,25 @@ func (in *K8SClient) GetEndpoints(namespace, name string) (*core_v1.Endpoints, e
 	return in.k8s.CoreV1().Endpoints(namespace).Get(in.ctx, name, emptyGetOptions)
 }
 
+// GetEvents returns the events of a namespace matching the fieldSelector, like "type=Warning".
+// An empty fieldSelector will fetch all the events of the namespace.
+func (in *K8SClient) GetEvents(namespace, fieldSelector string) ([]core_v1.Event, error) {
+	if events, err := in.k8s.CoreV1().Events(namespace).List(in.ctx, meta_v1.ListOptions{FieldSelector: fieldSelector}); err == nil {
+		return events.Items, nil
+	} else {
+		return []core_v1.Event{}, err
+	}
+}
+
+// GetHorizontalPodAutoscalers returns the HorizontalPodAutoscalers of a namespace
+func (in *K8SClient) GetHorizontalPodAutoscalers(namespace string) ([]autoscaling_v2.HorizontalPodAutoscaler, error) {
+	if hpaList, err := in.k8s.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(in.ctx, emptyListOptions); err == nil {
+		return hpaList.Items, nil
+	} else {
+		return []autoscaling_v2.HorizontalPodAutoscaler{}, err
+	}
+}
+
 // GetPods returns the pods definitions for a given set of labels.
 // An empty labelSelector will fetch all pods found per a namespace.
 // It returns an error on any problem.
@@ -481,6 +530,10 @@ func (in *K8SClient) StreamPodLogs(namespace, name string, opts *core_v1.PodLogO
 	return req.Stream(in.ctx)
 }
 
+func (in *K8SClient) GetCronJob(namespace string, name string) (*batch_v1.CronJob, error) {
+	return in.k8s.BatchV1().CronJobs(namespace).Get(in.ctx, name, emptyGetOptions)
+}
+
 func (in *K8SClient) GetCronJobs(namespace string) ([]batch_v1.CronJob, error) {
 	if cjList, err := in.k8s.BatchV1().CronJobs(namespace).List(in.ctx, emptyListOptions); err == nil {
 		return cjList.Items, nil
@@ -489,6 +542,10 @@ func (in *K8SClient) GetCronJobs(namespace string) ([]batch_v1.CronJob, error) {
 	}
 }
 
+func (in *K8SClient) GetJob(namespace string, name string) (*batch_v1.Job, error) {
+	return in.k8s.BatchV1().Jobs(namespace).Get(in.ctx, name, emptyGetOptions)
+}
+
 func (in *K8SClient) GetJobs(namespace string) ([]batch_v1.Job, error) {
 	if jList, err := in.k8s.BatchV1().Jobs(namespace).List(in.ctx, emptyListOptions); err == nil {
 		return jList.Items, nil
@@ -503,6 +560,12 @@ func NewNotFound(name, group, resource string) error {
 	return errors.NewNotFound(schema.GroupResource{Group: group, Resource: resource}, name)
 }
 
+// NewForbidden is a helper method to create a Forbidden error similar as used by the kubernetes client.
+// This method helps upper layers to send a explicit Forbidden error when the access is denied before querying the resource.
+func NewForbidden(name, group, resource string, err error) error {
+	return errors.NewForbidden(schema.GroupResource{Group: group, Resource: resource}, name, err)
+}
+
 // GetSelfSubjectAccessReview provides information on Kiali permissions
 func (in *K8SClient) GetSelfSubjectAccessReview(ctx context.Context, namespace, api, resourceType string, verbs []string) ([]*auth_v1.SelfSubjectAccessReview, error) {
 	if config.Get().Server.Observability.Tracing.Enabled {
@@ -555,6 +618,27 @@ func (in *K8SClient) GetSelfSubjectAccessReview(ctx context.Context, namespace,
 	return result, err
 }
 
+// GetSelfSubjectRulesReview returns the set of actions the user can perform in the namespace.
+// Unlike GetSelfSubjectAccessReview, a single call covers every resource and verb.
+func (in *K8SClient) GetSelfSubjectRulesReview(ctx context.Context, namespace string) (*auth_v1.SelfSubjectRulesReview, error) {
+	if config.Get().Server.Observability.Tracing.Enabled {
+		var span trace.Span
+		ctx, span = otel.Tracer(observability.TracerName()).Start(ctx, "GetSelfSubjectRulesReview",
+			trace.WithAttributes(
+				attribute.String("package", "kubernetes"),
+				attribute.String("namespace", namespace),
+			),
+		)
+		defer span.End()
+	}
+
+	return in.k8s.AuthorizationV1().SelfSubjectRulesReviews().Create(ctx, &auth_v1.SelfSubjectRulesReview{
+		Spec: auth_v1.SelfSubjectRulesReviewSpec{
+			Namespace: namespace,
+		},
+	}, meta_v1.CreateOptions{})
+}
+
 func (in *K8SClient) UpdateWorkload(namespace string, workloadName string, workloadType string, jsonPatch string, patchType string) error {
 	emptyPatchOptions := meta_v1.PatchOptions{}
 	bytePatch := []byte(jsonPatch)
@@ -636,3 +720,22 @@ func (in *K8SClient) GetTokenSubject(authInfo *api.AuthInfo) (string, error) {
 		return result.Status.User.Username, nil
 	}
 }
+
+// GetTokenUser returns the user authenticated by the token of the authInfo using
+// the TokenReview api
+func (in *K8SClient) GetTokenUser(authInfo *api.AuthInfo) (*v1.UserInfo, error) {
+	tokenReview := &v1.TokenReview{}
+	tokenReview.Spec.Token = authInfo.Token
+
+	result, err := in.k8s.AuthenticationV1().TokenReviews().Create(in.ctx, tokenReview, meta_v1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if result.Status.Error != "" {
+		return nil, goerrors.New(result.Status.Error)
+	}
+	if !result.Status.Authenticated || result.Status.User.Username == "" {
+		return nil, goerrors.New("the token is not authenticated")
+	}
+	return &result.Status.User, nil
+}
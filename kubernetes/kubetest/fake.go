@@ -9,12 +9,14 @@ import (
 	istio "istio.io/client-go/pkg/clientset/versioned"
 	istiofake "istio.io/client-go/pkg/clientset/versioned/fake"
 	istioscheme "istio.io/client-go/pkg/clientset/versioned/scheme"
+	auth_v1 "k8s.io/api/authorization/v1"
 	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	kubefake "k8s.io/client-go/kubernetes/fake"
 	kubescheme "k8s.io/client-go/kubernetes/scheme"
+	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 	gatewayapi "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
 	gatewayapifake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
 	gatewayapischeme "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/scheme"
@@ -63,6 +65,8 @@ func NewFakeK8sClient(objects ...runtime.Object) *FakeK8sClient {
 		istioObjects      []runtime.Object
 		gatewayapiObjects []runtime.Object
 		istioGateways     []*networking_v1beta1.Gateway
+		k8sGateways       []*k8s_networking_v1beta1.Gateway
+		k8sHTTPRoutes     []*k8s_networking_v1beta1.HTTPRoute
 		deploymentConfigs = make(map[string][]osapps_v1.DeploymentConfig)
 		projects          = []osproject_v1.Project{}
 	)
@@ -79,7 +83,14 @@ func NewFakeK8sClient(objects ...runtime.Object) *FakeK8sClient {
 				istioObjects = append(istioObjects, o)
 			}
 		case isGatewayAPIResource(o):
-			gatewayapiObjects = append(gatewayapiObjects, o)
+			switch gwo := o.(type) {
+			case *k8s_networking_v1beta1.Gateway:
+				k8sGateways = append(k8sGateways, gwo)
+			case *k8s_networking_v1beta1.HTTPRoute:
+				k8sHTTPRoutes = append(k8sHTTPRoutes, gwo)
+			default:
+				gatewayapiObjects = append(gatewayapiObjects, o)
+			}
 		case isOpenShiftResource(o):
 			if dc, ok := o.(*osapps_v1.DeploymentConfig); ok {
 				if _, exists := deploymentConfigs[dc.Namespace]; !exists {
@@ -102,6 +113,17 @@ func NewFakeK8sClient(objects ...runtime.Object) *FakeK8sClient {
 			panic(err)
 		}
 	}
+	// The kinds of the Gateway API are registered in several versions, the tracker would guess the wrong one.
+	for _, gw := range k8sGateways {
+		if _, err := gatewayAPIClient.GatewayV1beta1().Gateways(gw.Namespace).Create(context.TODO(), gw, metav1.CreateOptions{}); err != nil {
+			panic(err)
+		}
+	}
+	for _, route := range k8sHTTPRoutes {
+		if _, err := gatewayAPIClient.GatewayV1beta1().HTTPRoutes(route.Namespace).Create(context.TODO(), route, metav1.CreateOptions{}); err != nil {
+			panic(err)
+		}
+	}
 
 	return &FakeK8sClient{
 		ClientInterface:   kialikube.NewClient(kubeClient, istioClient, gatewayAPIClient),
@@ -124,6 +146,9 @@ type FakeK8sClient struct {
 	// This is a map of namespace: []objects e.g. DeploymentConfig: []DeploymentConfig.
 	deploymentConfigs map[string][]osapps_v1.DeploymentConfig
 	projects          []osproject_v1.Project
+	// ResourceRules are the rules returned by a SelfSubjectRulesReview, keyed by namespace.
+	// The fake clientset has no authorizer so the reviews are stubbed out.
+	ResourceRules map[string][]auth_v1.ResourceRule
 	// Underlying kubernetes clientset.
 	KubeClientset kubernetes.Interface
 	// Underlying istio clientset.
@@ -156,6 +181,16 @@ func (c *FakeK8sClient) GetProjects(labelSelector string) ([]osproject_v1.Projec
 	return c.projects, nil
 }
 
+// GetSelfSubjectRulesReview returns the ResourceRules set for the namespace.
+func (c *FakeK8sClient) GetSelfSubjectRulesReview(ctx context.Context, namespace string) (*auth_v1.SelfSubjectRulesReview, error) {
+	return &auth_v1.SelfSubjectRulesReview{
+		Spec: auth_v1.SelfSubjectRulesReviewSpec{Namespace: namespace},
+		Status: auth_v1.SubjectRulesReviewStatus{
+			ResourceRules: c.ResourceRules[namespace],
+		},
+	}, nil
+}
+
 func (c *FakeK8sClient) GetDeploymentConfig(namespace string, name string) (*osapps_v1.DeploymentConfig, error) {
 	for _, dc := range c.deploymentConfigs[namespace] {
 		if dc.Name == name {
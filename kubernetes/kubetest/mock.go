@@ -8,12 +8,14 @@ import (
 	"gopkg.in/square/go-jose.v2/jwt"
 	istio_fake "istio.io/client-go/pkg/clientset/versioned/fake"
 	apps_v1 "k8s.io/api/apps/v1"
+	authentication_v1 "k8s.io/api/authentication/v1"
 	batch_v1 "k8s.io/api/batch/v1"
 	core_v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd/api"
 	gatewayapifake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
 
@@ -154,6 +156,11 @@ func (o *K8SClientMock) GetToken() string {
 	return args.Get(0).(string)
 }
 
+// GetImpersonatedUser returns no user, the mocked clients don't impersonate
+func (o *K8SClientMock) GetImpersonatedUser() rest.ImpersonationConfig {
+	return rest.ImpersonationConfig{}
+}
+
 // GetAuthInfo returns the AuthInfo struct for the client
 func (o *K8SClientMock) GetAuthInfo() *api.AuthInfo {
 	args := o.Called()
@@ -181,6 +188,16 @@ func (o *K8SClientMock) GetTokenSubject(authInfo *api.AuthInfo) (string, error)
 	return authInfo.Token, nil
 }
 
+// GetTokenUser returns the user of the authInfo, whose name is
+// the subject returned by GetTokenSubject
+func (o *K8SClientMock) GetTokenUser(authInfo *api.AuthInfo) (*authentication_v1.UserInfo, error) {
+	subject, err := o.GetTokenSubject(authInfo)
+	if err != nil {
+		return nil, err
+	}
+	return &authentication_v1.UserInfo{Username: subject}, nil
+}
+
 func (o *K8SClientMock) MockService(namespace, name string) {
 	s := FakeService(namespace, name)
 	o.On("GetService", namespace, name).Return(&s, nil)
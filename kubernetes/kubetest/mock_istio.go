@@ -57,6 +57,11 @@ func (o *K8SClientMock) GetConfigDump(namespace string, podName string) (*kubern
 	return args.Get(0).(*kubernetes.ConfigDump), args.Error(1)
 }
 
+func (o *K8SClientMock) GetConfigDumpWithEndpoints(namespace string, podName string) (*kubernetes.ConfigDump, error) {
+	args := o.Called(namespace, podName)
+	return args.Get(0).(*kubernetes.ConfigDump), args.Error(1)
+}
+
 func (o *K8SClientMock) GetRegistryConfiguration() (*kubernetes.RegistryConfiguration, error) {
 	args := o.Called()
 	return args.Get(0).(*kubernetes.RegistryConfiguration), args.Error(1)
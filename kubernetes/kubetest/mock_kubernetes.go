@@ -4,8 +4,10 @@ import (
 	"context"
 	"io"
 
+	admission_v1 "k8s.io/api/admissionregistration/v1"
 	apps_v1 "k8s.io/api/apps/v1"
 	auth_v1 "k8s.io/api/authorization/v1"
+	autoscaling_v2 "k8s.io/api/autoscaling/v2"
 	batch_v1 "k8s.io/api/batch/v1"
 	core_v1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
@@ -25,6 +27,11 @@ func (o *K8SClientMock) GetConfigMap(namespace, name string) (*core_v1.ConfigMap
 	return args.Get(0).(*core_v1.ConfigMap), args.Error(1)
 }
 
+func (o *K8SClientMock) GetCronJob(namespace string, name string) (*batch_v1.CronJob, error) {
+	args := o.Called(namespace, name)
+	return args.Get(0).(*batch_v1.CronJob), args.Error(1)
+}
+
 func (o *K8SClientMock) GetCronJobs(namespace string) ([]batch_v1.CronJob, error) {
 	args := o.Called(namespace)
 	return args.Get(0).([]batch_v1.CronJob), args.Error(1)
@@ -60,16 +67,41 @@ func (o *K8SClientMock) GetEndpoints(namespace string, name string) (*core_v1.En
 	return args.Get(0).(*core_v1.Endpoints), args.Error(1)
 }
 
+func (o *K8SClientMock) GetEvents(namespace, fieldSelector string) ([]core_v1.Event, error) {
+	args := o.Called(namespace, fieldSelector)
+	return args.Get(0).([]core_v1.Event), args.Error(1)
+}
+
+func (o *K8SClientMock) GetHorizontalPodAutoscalers(namespace string) ([]autoscaling_v2.HorizontalPodAutoscaler, error) {
+	args := o.Called(namespace)
+	return args.Get(0).([]autoscaling_v2.HorizontalPodAutoscaler), args.Error(1)
+}
+
+func (o *K8SClientMock) GetJob(namespace string, name string) (*batch_v1.Job, error) {
+	args := o.Called(namespace, name)
+	return args.Get(0).(*batch_v1.Job), args.Error(1)
+}
+
 func (o *K8SClientMock) GetJobs(namespace string) ([]batch_v1.Job, error) {
 	args := o.Called(namespace)
 	return args.Get(0).([]batch_v1.Job), args.Error(1)
 }
 
+func (o *K8SClientMock) GetMutatingWebhookConfigurations(labelSelector string) ([]admission_v1.MutatingWebhookConfiguration, error) {
+	args := o.Called(labelSelector)
+	return args.Get(0).([]admission_v1.MutatingWebhookConfiguration), args.Error(1)
+}
+
 func (o *K8SClientMock) GetNamespace(namespace string) (*core_v1.Namespace, error) {
 	args := o.Called(namespace)
 	return args.Get(0).(*core_v1.Namespace), args.Error(1)
 }
 
+func (o *K8SClientMock) GetNode(name string) (*core_v1.Node, error) {
+	args := o.Called(name)
+	return args.Get(0).(*core_v1.Node), args.Error(1)
+}
+
 func (o *K8SClientMock) GetNamespaces(labelSelector string) ([]core_v1.Namespace, error) {
 	args := o.Called(labelSelector)
 	return args.Get(0).([]core_v1.Namespace), args.Error(1)
@@ -85,6 +117,11 @@ func (o *K8SClientMock) GetPod(namespace, name string) (*core_v1.Pod, error) {
 	return args.Get(0).(*core_v1.Pod), args.Error(1)
 }
 
+func (o *K8SClientMock) GetReplicationController(namespace string, name string) (*core_v1.ReplicationController, error) {
+	args := o.Called(namespace, name)
+	return args.Get(0).(*core_v1.ReplicationController), args.Error(1)
+}
+
 func (o *K8SClientMock) GetReplicationControllers(namespace string) ([]core_v1.ReplicationController, error) {
 	args := o.Called(namespace)
 	return args.Get(0).([]core_v1.ReplicationController), args.Error(1)
@@ -110,6 +147,11 @@ func (o *K8SClientMock) GetSelfSubjectAccessReview(ctx context.Context, namespac
 	return args.Get(0).([]*auth_v1.SelfSubjectAccessReview), args.Error(1)
 }
 
+func (o *K8SClientMock) GetSelfSubjectRulesReview(ctx context.Context, namespace string) (*auth_v1.SelfSubjectRulesReview, error) {
+	args := o.Called(ctx, namespace)
+	return args.Get(0).(*auth_v1.SelfSubjectRulesReview), args.Error(1)
+}
+
 func (o *K8SClientMock) GetService(namespace string, name string) (*core_v1.Service, error) {
 	args := o.Called(namespace, name)
 	return args.Get(0).(*core_v1.Service), args.Error(1)
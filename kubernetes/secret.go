@@ -9,6 +9,7 @@ import (
 
 type RemoteSecretCluster struct {
 	CertificateAuthorityData string `yaml:"certificate-authority-data"`
+	InsecureSkipTLSVerify    bool   `yaml:"insecure-skip-tls-verify,omitempty"`
 	Server                   string `yaml:"server"`
 }
 
@@ -174,9 +174,28 @@ var (
 )
 
 type IstioMeshConfig struct {
-	DisableMixerHttpReports bool                    `yaml:"disableMixerHttpReports,omitempty"`
-	DiscoverySelectors      []*metav1.LabelSelector `yaml:"discoverySelectors,omitempty"`
-	EnableAutoMtls          *bool                   `yaml:"enableAutoMtls,omitempty"`
+	AccessLogFile           string                          `yaml:"accessLogFile,omitempty"`
+	DefaultProviders        *IstioMeshDefaultProviders      `yaml:"defaultProviders,omitempty"`
+	DisableMixerHttpReports bool                            `yaml:"disableMixerHttpReports,omitempty"`
+	DiscoverySelectors      []*metav1.LabelSelector         `yaml:"discoverySelectors,omitempty"`
+	EnableAutoMtls          *bool                           `yaml:"enableAutoMtls,omitempty"`
+	ExtensionProviders      []*IstioMeshExtensionProvider   `yaml:"extensionProviders,omitempty"`
+	OutboundTrafficPolicy   *IstioMeshOutboundTrafficPolicy `yaml:"outboundTrafficPolicy,omitempty"`
+}
+
+// IstioMeshExtensionProvider is an external provider, like an ext_authz authorizer, referenced by name from the Istio resources
+type IstioMeshExtensionProvider struct {
+	Name string `yaml:"name"`
+}
+
+// IstioMeshOutboundTrafficPolicy tells whether the proxies allow the traffic to the hosts unknown to the mesh
+type IstioMeshOutboundTrafficPolicy struct {
+	Mode string `yaml:"mode,omitempty"`
+}
+
+// IstioMeshDefaultProviders are the providers used by the Telemetry API when a Telemetry doesn't set them
+type IstioMeshDefaultProviders struct {
+	AccessLogging []string `yaml:"accessLogging,omitempty"`
 }
 
 // MTLSDetails is a wrapper to group all Istio objects related to non-local mTLS configurations
@@ -270,6 +289,11 @@ type IstioEndpoint struct {
 			WorkloadName string `json:"WorkloadName,omitempty"`
 			HostName     string `json:"HostName,omitempty"`
 			SubDomain    string `json:"SubDomain,omitempty"`
+			// HealthStatus values, as the debug endpoint doesn't perform a conversion
+			// 0:	not reported (Istio versions not tracking the endpoint health)
+			// 1:	Healthy
+			// 2:	UnHealthy
+			HealthStatus int32 `json:"HealthStatus,omitempty"`
 			// TunnelAbility and DiscoverabilityPolicy are not mapped into the model
 		} `json:"endpoint"`
 	} `json:"ep"`
@@ -336,6 +360,8 @@ type RegistryStatus struct {
 	Configuration *RegistryConfiguration
 	Endpoints     []*RegistryEndpoint
 	Services      []*RegistryService
+	// FetchedAt is the time when the registry was fetched from istiod
+	FetchedAt time.Time
 }
 
 func (imc IstioMeshConfig) GetEnableAutoMtls() bool {
@@ -345,6 +371,17 @@ func (imc IstioMeshConfig) GetEnableAutoMtls() bool {
 	return *imc.EnableAutoMtls
 }
 
+// GetExtensionProviderNames returns the names of the extension providers of the mesh
+func (imc IstioMeshConfig) GetExtensionProviderNames() []string {
+	names := make([]string, 0, len(imc.ExtensionProviders))
+	for _, provider := range imc.ExtensionProviders {
+		if provider != nil {
+			names = append(names, provider.Name)
+		}
+	}
+	return names
+}
+
 func GetPatchType(patchType string) types.PatchType {
 	switch patchType {
 	case "json":
@@ -359,3 +396,51 @@ func GetPatchType(patchType string) types.PatchType {
 		return types.MergePatchType
 	}
 }
+
+// Cluster holds some metadata about a cluster that is
+// part of the mesh.
+type Cluster struct {
+	// ApiEndpoint is the URL where the Kubernetes/Cluster API Server can be contacted
+	ApiEndpoint string `json:"apiEndpoint"`
+
+	// IsKialiHome specifies if this cluster is hosting this Kiali instance (and the observed Mesh Control Plane)
+	IsKialiHome bool `json:"isKialiHome"`
+
+	// IsGatewayToNamespace specifies the PILOT_SCOPE_GATEWAY_TO_NAMESPACE environment variable in Control PLane
+	IsGatewayToNamespace bool `json:"isGatewayToNamespace"`
+
+	// KialiInstances is the list of Kialis discovered in the cluster.
+	KialiInstances []KialiInstance `json:"kialiInstances"`
+
+	// Name specifies the CLUSTER_ID as known by the Control Plane
+	Name string `json:"name"`
+
+	// Network specifies the logical NETWORK_ID as known by the Control Plane
+	Network string `json:"network"`
+
+	// SecretName is the name of the kubernetes "remote cluster secret" that was mounted to the file system and where data of this cluster was resolved
+	SecretName string `json:"secretName"`
+}
+
+// KialiInstance represents a Kiali installation. It holds some data about
+// where and how Kiali was deployed.
+type KialiInstance struct {
+	// ServiceName is the name of the Kubernetes service associated to the Kiali installation. The Kiali Service is the
+	// entity that is looked for in order to determine if a Kiali instance is available.
+	ServiceName string `json:"serviceName"`
+
+	// Namespace is the name of the namespace where is Kiali installed on.
+	Namespace string `json:"namespace"`
+
+	// OperatorResource contains the namespace and the name of the Kiali CR that the user
+	// created to install Kiali via the operator. This can be blank if the operator wasn't used
+	// to install Kiali. This resource is populated from annotations in the Service. It has
+	// the format "namespace/resource_name".
+	OperatorResource string `json:"operatorResource"`
+
+	// Url is the URI that can be used to access Kiali.
+	Url string `json:"url"`
+
+	// Version is the Kiali version as reported by annotations in the Service.
+	Version string `json:"version"`
+}
@@ -8,6 +8,10 @@ type Address struct {
 	Name string `json:"name"`
 	IP   string `json:"ip"`
 	Port uint32 `json:"port"`
+	// Region of the node running the pod of the address, from its topology labels
+	Region string `json:"region,omitempty"`
+	// Zone of the node running the pod of the address, from its topology labels
+	Zone string `json:"zone,omitempty"`
 }
 
 func (addresses *Addresses) Parse(as []core_v1.EndpointAddress) {
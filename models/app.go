@@ -25,9 +25,14 @@ type AppListItem struct {
 
 	// Cluster of the application
 	// required: true
-	// example: reviews
+	// example: east
 	Cluster string `json:"cluster"`
 
+	// Label used to group the workloads of the application, the name is its value
+	// required: true
+	// example: app
+	GroupKey string `json:"groupKey"`
+
 	// Define if all Pods related to the Workloads of this app has an IstioSidecar deployed
 	// required: true
 	// example: true
@@ -0,0 +1,53 @@
+package models
+
+const (
+	// AuthorizationAllow means the request is allowed by the Istio authorization policies
+	AuthorizationAllow = "ALLOW"
+	// AuthorizationDeny means the request is denied by the Istio authorization policies
+	AuthorizationDeny = "DENY"
+	// AuthorizationCustom means the request is allowed by the native policies but a CUSTOM policy
+	// delegates the final decision to an extension provider
+	AuthorizationCustom = "CUSTOM"
+)
+
+// AuthorizationRequest holds the attributes of a synthetic request evaluated against the authorization policies
+type AuthorizationRequest struct {
+	Source    AuthorizationSource    `json:"source"`
+	Operation AuthorizationOperation `json:"operation"`
+}
+
+// AuthorizationSource holds the attributes of the peer sending the request
+type AuthorizationSource struct {
+	// Peer identity, like "cluster.local/ns/bookinfo/sa/productpage"
+	Principal string `json:"principal,omitempty"`
+	// Request identity taken from the JWT, like "issuer/subject"
+	RequestPrincipal string `json:"requestPrincipal,omitempty"`
+	Namespace        string `json:"namespace,omitempty"`
+	IP               string `json:"ip,omitempty"`
+}
+
+// AuthorizationOperation holds the attributes of the operation requested
+type AuthorizationOperation struct {
+	Host   string `json:"host,omitempty"`
+	Port   string `json:"port,omitempty"`
+	Method string `json:"method,omitempty"`
+	Path   string `json:"path,omitempty"`
+}
+
+// AuthorizationDecision is the result of simulating the authorization policies on a request
+type AuthorizationDecision struct {
+	// ALLOW, DENY or CUSTOM
+	Decision string `json:"decision"`
+	Reason   string `json:"reason"`
+	// Policy that decided the request. Empty when the decision is the default one.
+	Policy *AuthorizationPolicyMatch `json:"policy,omitempty"`
+}
+
+// AuthorizationPolicyMatch identifies the policy rule matching a request
+type AuthorizationPolicyMatch struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Action    string `json:"action"`
+	// Index of the matching rule in the policy
+	Rule int `json:"rule"`
+}
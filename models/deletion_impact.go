@@ -0,0 +1,31 @@
+package models
+
+// DeletionImpact holds the objects referencing an Istio object, which are affected by its deletion
+type DeletionImpact struct {
+	// Type of the Istio object
+	// required: true
+	// example: gateway
+	ObjectType string `json:"objectType"`
+
+	// Name of the Istio object
+	// required: true
+	// example: bookinfo-gateway
+	Name string `json:"name"`
+
+	// Namespace of the Istio object
+	// required: true
+	// example: bookinfo
+	Namespace string `json:"namespace"`
+
+	// Istio objects referencing the object, like the VirtualServices bound to a Gateway
+	// required: true
+	ObjectReferences []IstioReference `json:"objectReferences"`
+
+	// Services referenced by the object, like the service of the host of a DestinationRule
+	// required: true
+	ServiceReferences []ServiceReference `json:"serviceReferences"`
+
+	// Workloads the object applies to
+	// required: true
+	WorkloadReferences []WorkloadReference `json:"workloadReferences"`
+}
@@ -12,6 +12,37 @@ type Endpoint struct {
 	Ports     Ports     `json:"ports"`
 }
 
+// Health statuses of an endpoint as seen by a proxy
+const (
+	EndpointHealthy   = "Healthy"
+	EndpointDegraded  = "Degraded"
+	EndpointUnhealthy = "Unhealthy"
+	EndpointDraining  = "Draining"
+	// EndpointEjected is an endpoint healthy for the Istio registry but unhealthy for the proxy,
+	// which is what happens when the proxy ejects it by outlier detection.
+	EndpointEjected = "Ejected"
+	// EndpointMissing is an endpoint of the Istio registry unknown by the proxy.
+	EndpointMissing = "Missing"
+)
+
+// EndpointHealth is the health of a registry endpoint in the proxy's load balancing pool
+type EndpointHealth struct {
+	// Address of the endpoint. It is a hostname for DNS resolved ServiceEntries
+	Address string `json:"address"`
+	// Port of the endpoint
+	Port uint32 `json:"port"`
+	// Name of the service port the endpoint serves
+	PortName string `json:"portName"`
+	// Workload of the endpoint, if any
+	Workload string `json:"workload,omitempty"`
+	// Namespace of the workload of the endpoint, if any
+	Namespace string `json:"namespace,omitempty"`
+	// HealthStatus is one of Healthy, Degraded, Unhealthy, Draining, Ejected or Missing
+	HealthStatus string `json:"healthStatus"`
+	// Serving is true when the proxy can send traffic to the endpoint
+	Serving bool `json:"serving"`
+}
+
 func (endpoints *Endpoints) Parse(es *core_v1.Endpoints) {
 	if es == nil {
 		return
@@ -1,6 +1,9 @@
 package models
 
 import (
+	"math"
+	"time"
+
 	"github.com/prometheus/common/model"
 
 	"github.com/kiali/kiali/log"
@@ -63,6 +66,26 @@ func EmptyWorkloadHealth() *WorkloadHealth {
 type WorkloadHealth struct {
 	WorkloadStatus *WorkloadStatus `json:"workloadStatus"`
 	Requests       RequestHealth   `json:"requests"`
+	// Events is only set when the health is requested with the events of the workload
+	Events *WorkloadEvents `json:"events,omitempty"`
+}
+
+// WorkloadEvents summarizes the recent Warning events of the pods of a workload
+type WorkloadEvents struct {
+	// Critical is set when an event reports a problem keeping the pods from running, like a FailedScheduling.
+	// The health of the workload is degraded then, even before the metrics reflect the problem.
+	Critical bool            `json:"critical"`
+	Events   []WorkloadEvent `json:"events"`
+}
+
+// WorkloadEvent is a recent Warning event of a pod of a workload
+type WorkloadEvent struct {
+	Pod      string    `json:"pod"`
+	Reason   string    `json:"reason"`
+	Message  string    `json:"message"`
+	Count    int32     `json:"count"`
+	LastSeen time.Time `json:"lastSeen"`
+	Critical bool      `json:"critical"`
 }
 
 // WorkloadStatus gives
@@ -101,6 +124,29 @@ type RequestHealth struct {
 	HealthAnnotations  map[string]string             `json:"healthAnnotations"`
 	inboundSource      map[string]map[string]float64
 	inboundDestination map[string]map[string]float64
+
+	// InsufficientTraffic is set when the rates were discarded because of a number of requests below the minimum
+	InsufficientTraffic bool `json:"insufficientTraffic,omitempty"`
+
+	// PartialData is set when the rates may be incomplete: the metrics backend reported a partial response, or the results
+	// exceeded the max samples of the health queries
+	PartialData bool `json:"partialData,omitempty"`
+
+	// ResponseFlags breaks down the rates by Envoy response flags, only set when enabled in the health config
+	ResponseFlags *ResponseFlagsHealth `json:"responseFlags,omitempty"`
+
+	// Rates by protocol and response_flags, aggregated like the rates by status code
+	inboundFlags            map[string]map[string]float64
+	outboundFlags           map[string]map[string]float64
+	inboundSourceFlags      map[string]map[string]float64
+	inboundDestinationFlags map[string]map[string]float64
+}
+
+// ResponseFlagsHealth holds the rates of requests by protocol and Envoy response flags, "-" being the requests without flag.
+// Example:   Inbound: { "http": {"-": 1.5, "UO": 0.2, "NR": 0.1} }
+type ResponseFlagsHealth struct {
+	Inbound  map[string]map[string]float64 `json:"inbound"`
+	Outbound map[string]map[string]float64 `json:"outbound"`
 }
 
 // AggregateInbound adds the provided metric sample to internal inbound counters and updates error ratios
@@ -110,11 +156,14 @@ func (in *RequestHealth) AggregateInbound(sample *model.Sample) {
 	switch reporter {
 	case "source":
 		aggregate(sample, in.inboundSource)
+		aggregateResponseFlags(sample, &in.inboundSourceFlags)
 	case "destination":
 		aggregate(sample, in.inboundDestination)
+		aggregateResponseFlags(sample, &in.inboundDestinationFlags)
 	default:
 		log.Tracef("Inbound metric without reporter %v ", sample)
 		aggregate(sample, in.Inbound)
+		aggregateResponseFlags(sample, &in.inboundFlags)
 	}
 }
 
@@ -124,6 +173,7 @@ func (in *RequestHealth) AggregateOutbound(sample *model.Sample) {
 	reporter := string(sample.Metric[model.LabelName("reporter")])
 	if reporter == "source" {
 		aggregate(sample, in.Outbound)
+		aggregateResponseFlags(sample, &in.outboundFlags)
 	}
 }
 
@@ -132,36 +182,86 @@ func (in *RequestHealth) AggregateOutbound(sample *model.Sample) {
 // but there may exist values that only are present in one or another reporter,
 // those should be consolidated into a single result
 func (in *RequestHealth) CombineReporters() {
-	// Inbound
+	combineReporters(in.Inbound, in.inboundSource, in.inboundDestination)
+	if in.inboundFlags == nil {
+		in.inboundFlags = make(map[string]map[string]float64)
+	}
+	combineReporters(in.inboundFlags, in.inboundSourceFlags, in.inboundDestinationFlags)
+}
+
+func combineReporters(inbound, inboundSource, inboundDestination map[string]map[string]float64) {
 	// Init Inbound with data from source reporter
-	for isProtocol, isCodes := range in.inboundSource {
-		if _, ok := in.Inbound[isProtocol]; !ok {
-			in.Inbound[isProtocol] = make(map[string]float64)
+	for isProtocol, isCodes := range inboundSource {
+		if _, ok := inbound[isProtocol]; !ok {
+			inbound[isProtocol] = make(map[string]float64)
 		}
 		for isCode, isValue := range isCodes {
-			in.Inbound[isProtocol][isCode] = isValue
+			inbound[isProtocol][isCode] = isValue
 		}
 	}
 	// Combine data from destination and source reporters for Inbound rate
-	for idProtocol, idCodes := range in.inboundDestination {
-		if _, ok := in.Inbound[idProtocol]; !ok {
-			in.Inbound[idProtocol] = make(map[string]float64)
+	for idProtocol, idCodes := range inboundDestination {
+		if _, ok := inbound[idProtocol]; !ok {
+			inbound[idProtocol] = make(map[string]float64)
 		}
 		for idCode, idValue := range idCodes {
 			// If an Inbound -> protocol -> value is reported by destination but not by source reporter, we add it
-			if _, ok := in.Inbound[idProtocol][idCode]; !ok {
-				in.Inbound[idProtocol][idCode] = idValue
+			if _, ok := inbound[idProtocol][idCode]; !ok {
+				inbound[idProtocol][idCode] = idValue
 			} else {
 				// If the value provided by destination is higher than the source we replace it
 				// i.e. destination reports errors but not from source
-				if idValue > in.Inbound[idProtocol][idCode] {
-					in.Inbound[idProtocol][idCode] = idValue
+				if idValue > inbound[idProtocol][idCode] {
+					inbound[idProtocol][idCode] = idValue
 				}
 			}
 		}
 	}
 }
 
+// SetResponseFlags exposes the rates by response flags, unless the rates were discarded for insufficient traffic.
+// It must be called once the reporters are combined.
+func (in *RequestHealth) SetResponseFlags() {
+	if in.InsufficientTraffic {
+		return
+	}
+	in.ResponseFlags = &ResponseFlagsHealth{Inbound: in.inboundFlags, Outbound: in.outboundFlags}
+	if in.ResponseFlags.Inbound == nil {
+		in.ResponseFlags.Inbound = make(map[string]map[string]float64)
+	}
+	if in.ResponseFlags.Outbound == nil {
+		in.ResponseFlags.Outbound = make(map[string]map[string]float64)
+	}
+}
+
+// DiscardInsufficientTraffic empties the rates when the number of inbound and outbound requests over the interval is
+// below minRequests: with very low traffic a single error gives a high error ratio and makes the health flap.
+// It must be called once the reporters are combined. Nothing is discarded with a minRequests of 1 or less, any traffic is enough.
+func (in *RequestHealth) DiscardInsufficientTraffic(minRequests int, interval time.Duration) {
+	// A trickle of traffic rounds to 0 requests, it would be discarded otherwise
+	if minRequests <= 1 {
+		return
+	}
+	totalRate := 0.0
+	for _, requests := range []map[string]map[string]float64{in.Inbound, in.Outbound} {
+		for _, codes := range requests {
+			for _, rate := range codes {
+				totalRate += rate
+			}
+		}
+	}
+	if totalRate == 0 {
+		return
+	}
+	// The rates are per second and extrapolated by Prometheus, so the number of requests is an estimate
+	if math.Round(totalRate*interval.Seconds()) >= float64(minRequests) {
+		return
+	}
+	in.Inbound = make(map[string]map[string]float64)
+	in.Outbound = make(map[string]map[string]float64)
+	in.InsufficientTraffic = true
+}
+
 func aggregate(sample *model.Sample, requests map[string]map[string]float64) {
 	code := string(sample.Metric["response_code"])
 	protocol := string(sample.Metric["request_protocol"])
@@ -181,6 +281,23 @@ func aggregate(sample *model.Sample, requests map[string]map[string]float64) {
 	requests[protocol][code] += float64(sample.Value)
 }
 
+// aggregateResponseFlags adds the rate of a sample to the rates by protocol and response flags.
+// The requests map is created when needed, for the RequestHealth not built by NewEmptyRequestHealth.
+func aggregateResponseFlags(sample *model.Sample, requests *map[string]map[string]float64) {
+	flags := string(sample.Metric["response_flags"])
+	if flags == "" {
+		flags = "-"
+	}
+	protocol := string(sample.Metric["request_protocol"])
+	if *requests == nil {
+		*requests = make(map[string]map[string]float64)
+	}
+	if _, ok := (*requests)[protocol]; !ok {
+		(*requests)[protocol] = make(map[string]float64)
+	}
+	(*requests)[protocol][flags] += float64(sample.Value)
+}
+
 // CastWorkloadStatus returns a WorkloadStatus out of a given Workload
 func (w Workload) CastWorkloadStatus() *WorkloadStatus {
 	syncedProxies := int32(-1)
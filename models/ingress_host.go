@@ -0,0 +1,79 @@
+package models
+
+// IngressHost is a host reachable from outside of the mesh through an ingress Gateway or a K8sGateway
+type IngressHost struct {
+	// Hostname, a wildcard when it can't be expanded to the hosts of the routes
+	// required: true
+	// example: bookinfo.example.com
+	Hostname string `json:"hostname"`
+
+	// TLS modes of the servers and listeners exposing the host, NONE when the traffic is not encrypted
+	// required: true
+	// example: ["SIMPLE"]
+	TLSModes []string `json:"tlsModes"`
+
+	// Gateways and K8sGateways exposing the host
+	// required: true
+	Gateways []IstioValidationKey `json:"gateways"`
+
+	// VirtualServices and HTTPRoutes routing the host
+	// required: true
+	Routes []IstioValidationKey `json:"routes"`
+}
+
+// IngressHostResolution is the chain handling the requests to a hostname from outside of the mesh
+type IngressHostResolution struct {
+	// Hostname of the requests
+	// required: true
+	// example: shop.example.com
+	Hostname string `json:"hostname"`
+
+	// Port of the requests, 0 when any port
+	// example: 443
+	Port uint32 `json:"port"`
+
+	// Gateway servers handling the hostname with the VirtualServices routing it, empty when none matches
+	// required: true
+	Matches []IngressHostMatch `json:"matches"`
+}
+
+// IngressHostMatch is a Gateway server handling a hostname and the VirtualService routing it
+type IngressHostMatch struct {
+	// Gateway of the server
+	// required: true
+	Gateway IstioValidationKey `json:"gateway"`
+
+	// Host of the server matching the hostname, may be a wildcard
+	// required: true
+	// example: *.example.com
+	ServerHost string `json:"serverHost"`
+
+	// Port of the server
+	// required: true
+	// example: 443
+	Port uint32 `json:"port"`
+
+	// Protocol of the server
+	// required: true
+	// example: HTTPS
+	Protocol string `json:"protocol"`
+
+	// TLS mode of the server, NONE when the traffic is not encrypted
+	// required: true
+	// example: SIMPLE
+	TLSMode string `json:"tlsMode"`
+
+	// Secret holding the certificates of the server
+	// example: shop-cert
+	CredentialName string `json:"credentialName,omitempty"`
+
+	// Whether the server redirects the requests to HTTPS
+	HttpsRedirect bool `json:"httpsRedirect,omitempty"`
+
+	// VirtualService routing the hostname, nil when none does
+	VirtualService *IstioValidationKey `json:"virtualService"`
+
+	// Host of the VirtualService matching the hostname, may be a wildcard
+	// example: shop.example.com
+	VirtualServiceHost string `json:"virtualServiceHost,omitempty"`
+}
@@ -0,0 +1,116 @@
+package models
+
+// InjectionStatus consolidates the sidecar injection information of a workload
+type InjectionStatus struct {
+	// Name of the workload
+	// required: true
+	// example: reviews-v1
+	Workload string `json:"workload"`
+
+	// Namespace of the workload
+	// required: true
+	// example: bookinfo
+	Namespace string `json:"namespace"`
+
+	// Version of the control plane, empty when it is unknown
+	// example: 1.18.0
+	ControlPlaneVersion string `json:"controlPlaneVersion"`
+
+	// Value of the injection label of the namespace (istio-injection), if any
+	// example: enabled
+	NamespaceInjection string `json:"namespaceInjection"`
+
+	// Value of the revision label of the namespace (istio.io/rev), if any
+	// example: canary
+	NamespaceRevision string `json:"namespaceRevision"`
+
+	// Injection status of each Pod of the workload
+	// required: true
+	Pods []PodInjectionStatus `json:"pods"`
+}
+
+// PodInjectionStatus holds the sidecar injection information of a Pod
+type PodInjectionStatus struct {
+	// Name of the Pod
+	// required: true
+	// example: reviews-v1-5f7d8c6b4-x2k9p
+	Name string `json:"name"`
+
+	// Injection defined in the Pod by the sidecar.istio.io/inject label, or annotation.
+	// It's mapped as a pointer to show three values nil, true, false
+	PodInjection *bool `json:"podInjection,omitempty"`
+
+	// Define if Istio is expected to inject the sidecar, according to the namespace and Pod configuration
+	// required: true
+	InjectionExpected bool `json:"injectionExpected"`
+
+	// Configuration deciding the injection: "namespace", "pod" or "none" when nothing is configured
+	// required: true
+	// example: namespace
+	InjectionSource string `json:"injectionSource"`
+
+	// Define if the Pod has an Istio sidecar
+	// required: true
+	Injected bool `json:"injected"`
+
+	// Image of the Istio proxy
+	// example: docker.io/istio/proxyv2:1.18.0
+	ProxyImage string `json:"proxyImage,omitempty"`
+
+	// Version of the Istio proxy, taken from its image tag
+	// example: 1.18.0
+	ProxyVersion string `json:"proxyVersion,omitempty"`
+
+	// Define if the proxy version matches the control plane version, nil when any of them is unknown
+	VersionMatch *bool `json:"versionMatch,omitempty"`
+}
+
+// WorkloadRevision holds the control plane revisions that injected the proxies of a workload
+type WorkloadRevision struct {
+	// Name of the workload
+	// required: true
+	// example: reviews-v1
+	Workload string `json:"workload"`
+
+	// Namespace of the workload
+	// required: true
+	// example: bookinfo
+	Namespace string `json:"namespace"`
+
+	// Revision configured in the namespace by the revision label (istio.io/rev), "default" for the default revision tag
+	// required: true
+	// example: canary
+	NamespaceRevision string `json:"namespaceRevision"`
+
+	// Revision of each Pod of the workload
+	// required: true
+	Pods []PodRevision `json:"pods"`
+}
+
+// PodRevision holds the control plane revision that injected the proxy of a Pod
+type PodRevision struct {
+	// Name of the Pod
+	// required: true
+	// example: reviews-v1-5f7d8c6b4-x2k9p
+	Name string `json:"name"`
+
+	// Revision of the control plane that injected the proxy, empty when the Pod has no proxy
+	// example: canary
+	Revision string `json:"revision,omitempty"`
+
+	// Define if the revision matches the revision of the namespace, nil when the Pod has no proxy
+	RevisionMatch *bool `json:"revisionMatch,omitempty"`
+}
+
+// RevisionNamespaces holds the namespaces injected by a control plane revision
+type RevisionNamespaces struct {
+	// Revision of the control plane, "default" for the default revision tag
+	// required: true
+	// example: canary
+	Revision string `json:"revision"`
+
+	// Names of the namespaces injected by the revision
+	// required: true
+	// example: ["bookinfo","travels"]
+	Namespaces []string `json:"namespaces"`
+}
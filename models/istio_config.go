@@ -1,11 +1,14 @@
 package models
 
 import (
+	"sort"
+
 	extentions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
 	networking_v1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
 	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
 	"istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
@@ -36,11 +39,29 @@ type IstioConfigList struct {
 	PeerAuthentications    []*security_v1beta.PeerAuthentication    `json:"peerAuthentications"`
 	RequestAuthentications []*security_v1beta.RequestAuthentication `json:"requestAuthentications"`
 	IstioValidations       IstioValidations                         `json:"validations"`
+
+	// TimedOut lists the object types that could not be fetched before the list timeout.
+	// When it is not empty the list is partial and those types are left empty.
+	TimedOut []string `json:"timedOut,omitempty"`
 }
 
 // IstioConfigMap holds a map of IstioConfigList per cluster
 type IstioConfigMap map[string]IstioConfigList
 
+// IstioConfigByApp holds the Istio config of a namespace grouped by the application it applies to
+type IstioConfigByApp struct {
+	// Apps maps the application name to the objects targeting its workloads or services
+	Apps map[string]IstioConfigList `json:"apps"`
+	// Unscoped holds the namespace-wide objects and the ones not targeting any application
+	Unscoped IstioConfigList `json:"unscoped"`
+}
+
+// IstioConfigByWizard holds the Istio config of a namespace created by the Kiali wizards
+type IstioConfigByWizard struct {
+	// Scenarios maps the scenario of the wizard, the value of the kiali_wizard label, to the objects it created
+	Scenarios map[string]IstioConfigList `json:"scenarios"`
+}
+
 type IstioConfigDetails struct {
 	Namespace  Namespace `json:"namespace"`
 	ObjectType string    `json:"objectType"`
@@ -62,6 +83,9 @@ type IstioConfigDetails struct {
 	K8sGateway   *k8s_networking_v1beta1.Gateway   `json:"k8sGateway"`
 	K8sHTTPRoute *k8s_networking_v1beta1.HTTPRoute `json:"k8sHTTPRoute"`
 
+	// Status holds the normalized status conditions of the object, when it publishes any
+	Status *IstioConfigStatus `json:"status,omitempty"`
+
 	Permissions           ResourcePermissions `json:"permissions"`
 	IstioValidation       *IstioValidation    `json:"validation"`
 	IstioReferences       *IstioReferences    `json:"references"`
@@ -177,11 +201,12 @@ var IstioConfigHelpMessages = map[string][]IstioConfigHelp{
 }
 
 // ResourcePermissions holds permission flags for an object type
-// True means allowed.
+// True means allowed. Error is set when the permissions couldn't be checked, they are all denied then.
 type ResourcePermissions struct {
-	Create bool `json:"create"`
-	Update bool `json:"update"`
-	Delete bool `json:"delete"`
+	Create bool   `json:"create"`
+	Update bool   `json:"update"`
+	Delete bool   `json:"delete"`
+	Error  string `json:"error,omitempty"`
 }
 
 // ResourcesPermissions holds a map of permission flags per resource
@@ -338,3 +363,54 @@ func (configList IstioConfigList) MergeConfigs(ns IstioConfigList) IstioConfigLi
 
 	return configList
 }
+
+// Sort orders the Istio config objects of each type by namespace and name, so the list doesn't depend on the order they were fetched
+func (configList *IstioConfigList) Sort() {
+	sortObjects(configList.DestinationRules)
+	sortObjects(configList.EnvoyFilters)
+	sortObjects(configList.Gateways)
+	sortObjects(configList.ServiceEntries)
+	sortObjects(configList.Sidecars)
+	sortObjects(configList.VirtualServices)
+	sortObjects(configList.WorkloadEntries)
+	sortObjects(configList.WorkloadGroups)
+	sortObjects(configList.WasmPlugins)
+	sortObjects(configList.Telemetries)
+	sortObjects(configList.K8sGateways)
+	sortObjects(configList.K8sHTTPRoutes)
+	sortObjects(configList.AuthorizationPolicies)
+	sortObjects(configList.PeerAuthentications)
+	sortObjects(configList.RequestAuthentications)
+	sort.Strings(configList.TimedOut)
+}
+
+func sortObjects[T meta_v1.Object](objects []T) {
+	sort.SliceStable(objects, func(i, j int) bool {
+		return lessObject(objects[i], objects[j])
+	})
+}
+
+func lessObject(a, b meta_v1.Object) bool {
+	if a.GetNamespace() != b.GetNamespace() {
+		return a.GetNamespace() < b.GetNamespace()
+	}
+	return a.GetName() < b.GetName()
+}
+
+// Count returns the number of Istio config objects held by the list
+func (configList IstioConfigList) Count() int {
+	return len(configList.DestinationRules) + len(configList.EnvoyFilters) + len(configList.Gateways) +
+		len(configList.AuthorizationPolicies) + len(configList.K8sGateways) + len(configList.K8sHTTPRoutes) +
+		len(configList.PeerAuthentications) + len(configList.RequestAuthentications) + len(configList.ServiceEntries) +
+		len(configList.Sidecars) + len(configList.Telemetries) + len(configList.VirtualServices) +
+		len(configList.WasmPlugins) + len(configList.WorkloadEntries) + len(configList.WorkloadGroups)
+}
+
+// Count returns the number of Istio config objects held by the lists of all the namespaces
+func (configs IstioConfigs) Count() int {
+	count := 0
+	for _, configList := range configs {
+		count += configList.Count()
+	}
+	return count
+}
@@ -0,0 +1,43 @@
+package models
+
+// IstioConfigDrift reports the differences between the live Istio config of a namespace and the Istio config known by the Istio registry.
+// A drift is expected for a short time while istiod propagates the changes, a permanent drift usually means a rejected object.
+type IstioConfigDrift struct {
+	// Namespace of the compared config
+	// required: true
+	// example: bookinfo
+	Namespace string `json:"namespace"`
+
+	// Objects present in the cluster but not in the Istio registry
+	// required: true
+	LiveOnly []IstioConfigDriftItem `json:"liveOnly"`
+
+	// Objects present in the Istio registry but not in the cluster
+	// required: true
+	RegistryOnly []IstioConfigDriftItem `json:"registryOnly"`
+
+	// Objects present in both with a different spec
+	// required: true
+	SpecMismatch []IstioConfigDriftItem `json:"specMismatch"`
+}
+
+// IstioConfigDriftItem identifies an object drifting between the cluster and the Istio registry
+type IstioConfigDriftItem struct {
+	// Type of the object
+	// required: true
+	// example: virtualservices
+	ObjectType string `json:"objectType"`
+
+	// Name of the object
+	// required: true
+	// example: reviews
+	Name string `json:"name"`
+
+	// ResourceVersion of the object in the cluster
+	// example: 192892127
+	LiveResourceVersion string `json:"liveResourceVersion,omitempty"`
+
+	// ResourceVersion of the object in the Istio registry
+	// example: 192892120
+	RegistryResourceVersion string `json:"registryResourceVersion,omitempty"`
+}
@@ -0,0 +1,174 @@
+package models
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"istio.io/api/meta/v1alpha1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// IstioConfigStatus holds the status conditions published by an Istio or Gateway API object
+// normalized to a single shape, so they can be rendered without parsing the raw object.
+type IstioConfigStatus struct {
+	Conditions         []IstioConfigCondition         `json:"conditions"`
+	ValidationMessages []IstioConfigValidationMessage `json:"validationMessages,omitempty"`
+}
+
+// IstioConfigCondition represents a single status condition, like "Programmed: True"
+type IstioConfigCondition struct {
+	Type               string `json:"type"`
+	Status             string `json:"status"`
+	Reason             string `json:"reason,omitempty"`
+	Message            string `json:"message,omitempty"`
+	LastTransitionTime string `json:"lastTransitionTime,omitempty"`
+	// Scope identifies the part of the object the condition refers to,
+	// like a Gateway listener or the parent of a route. Empty for the whole object.
+	Scope string `json:"scope,omitempty"`
+}
+
+// IstioConfigValidationMessage represents a message reported by the Istio analyzers in the object status
+type IstioConfigValidationMessage struct {
+	Code             string `json:"code"`
+	Name             string `json:"name"`
+	Level            string `json:"level"`
+	DocumentationUrl string `json:"documentationUrl,omitempty"`
+}
+
+// SetStatus extracts the status of the object held by the details.
+// It is left nil when the object doesn't publish any status.
+func (icd *IstioConfigDetails) SetStatus() {
+	switch {
+	case icd.K8sGateway != nil:
+		icd.Status = NewK8sGatewayStatus(icd.K8sGateway)
+	case icd.K8sHTTPRoute != nil:
+		icd.Status = NewK8sHTTPRouteStatus(icd.K8sHTTPRoute)
+	case icd.AuthorizationPolicy != nil:
+		icd.Status = NewIstioStatus(&icd.AuthorizationPolicy.Status)
+	case icd.DestinationRule != nil:
+		icd.Status = NewIstioStatus(&icd.DestinationRule.Status)
+	case icd.EnvoyFilter != nil:
+		icd.Status = NewIstioStatus(&icd.EnvoyFilter.Status)
+	case icd.Gateway != nil:
+		icd.Status = NewIstioStatus(&icd.Gateway.Status)
+	case icd.PeerAuthentication != nil:
+		icd.Status = NewIstioStatus(&icd.PeerAuthentication.Status)
+	case icd.RequestAuthentication != nil:
+		icd.Status = NewIstioStatus(&icd.RequestAuthentication.Status)
+	case icd.ServiceEntry != nil:
+		icd.Status = NewIstioStatus(&icd.ServiceEntry.Status)
+	case icd.Sidecar != nil:
+		icd.Status = NewIstioStatus(&icd.Sidecar.Status)
+	case icd.VirtualService != nil:
+		icd.Status = NewIstioStatus(&icd.VirtualService.Status)
+	case icd.WorkloadEntry != nil:
+		icd.Status = NewIstioStatus(&icd.WorkloadEntry.Status)
+	case icd.WorkloadGroup != nil:
+		icd.Status = NewIstioStatus(&icd.WorkloadGroup.Status)
+	case icd.WasmPlugin != nil:
+		icd.Status = NewIstioStatus(&icd.WasmPlugin.Status)
+	case icd.Telemetry != nil:
+		icd.Status = NewIstioStatus(&icd.Telemetry.Status)
+	}
+}
+
+// NewIstioStatus normalizes the conditions and the analysis messages of an Istio object status.
+func NewIstioStatus(status *v1alpha1.IstioStatus) *IstioConfigStatus {
+	if status == nil || (len(status.Conditions) == 0 && len(status.ValidationMessages) == 0) {
+		return nil
+	}
+
+	configStatus := &IstioConfigStatus{Conditions: []IstioConfigCondition{}}
+	for _, c := range status.Conditions {
+		if c == nil {
+			continue
+		}
+		configStatus.Conditions = append(configStatus.Conditions, IstioConfigCondition{
+			Type:               c.Type,
+			Status:             c.Status,
+			Reason:             c.Reason,
+			Message:            c.Message,
+			LastTransitionTime: formatProtoTime(c.LastTransitionTime),
+		})
+	}
+	for _, m := range status.ValidationMessages {
+		if m == nil {
+			continue
+		}
+		message := IstioConfigValidationMessage{
+			Level:            m.Level.String(),
+			DocumentationUrl: m.DocumentationUrl,
+		}
+		if m.Type != nil {
+			message.Code = m.Type.Code
+			message.Name = m.Type.Name
+		}
+		configStatus.ValidationMessages = append(configStatus.ValidationMessages, message)
+	}
+	return configStatus
+}
+
+// NewK8sGatewayStatus normalizes the conditions of a Gateway API Gateway and of its listeners.
+func NewK8sGatewayStatus(gw *k8s_networking_v1beta1.Gateway) *IstioConfigStatus {
+	if len(gw.Status.Conditions) == 0 && len(gw.Status.Listeners) == 0 {
+		return nil
+	}
+
+	configStatus := &IstioConfigStatus{Conditions: parseMetaConditions(gw.Status.Conditions, "")}
+	for _, listener := range gw.Status.Listeners {
+		configStatus.Conditions = append(configStatus.Conditions, parseMetaConditions(listener.Conditions, "listener/"+string(listener.Name))...)
+	}
+	return configStatus
+}
+
+// NewK8sHTTPRouteStatus normalizes the conditions reported by every parent of a Gateway API HTTPRoute.
+func NewK8sHTTPRouteStatus(route *k8s_networking_v1beta1.HTTPRoute) *IstioConfigStatus {
+	if len(route.Status.Parents) == 0 {
+		return nil
+	}
+
+	configStatus := &IstioConfigStatus{Conditions: []IstioConfigCondition{}}
+	for _, parent := range route.Status.Parents {
+		configStatus.Conditions = append(configStatus.Conditions, parseMetaConditions(parent.Conditions, parentRefScope(parent.ParentRef, route.Namespace))...)
+	}
+	return configStatus
+}
+
+// parentRefScope returns the parent as "parent/<namespace>/<name>[/<section>]"
+func parentRefScope(ref k8s_networking_v1beta1.ParentReference, routeNamespace string) string {
+	namespace := routeNamespace
+	if ref.Namespace != nil {
+		namespace = string(*ref.Namespace)
+	}
+	scope := "parent/" + namespace + "/" + string(ref.Name)
+	if ref.SectionName != nil {
+		scope += "/" + string(*ref.SectionName)
+	}
+	return scope
+}
+
+func parseMetaConditions(conditions []meta_v1.Condition, scope string) []IstioConfigCondition {
+	parsed := make([]IstioConfigCondition, 0, len(conditions))
+	for _, c := range conditions {
+		condition := IstioConfigCondition{
+			Type:    c.Type,
+			Status:  string(c.Status),
+			Reason:  c.Reason,
+			Message: c.Message,
+			Scope:   scope,
+		}
+		if !c.LastTransitionTime.IsZero() {
+			condition.LastTransitionTime = c.LastTransitionTime.UTC().Format(time.RFC3339)
+		}
+		parsed = append(parsed, condition)
+	}
+	return parsed
+}
+
+func formatProtoTime(ts *timestamp.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,103 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	api_analysis_v1alpha1 "istio.io/api/analysis/v1alpha1"
+	api_meta_v1alpha1 "istio.io/api/meta/v1alpha1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kiali/kiali/models"
+)
+
+func TestK8sGatewayProgrammedStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	transition := meta_v1.NewTime(time.Date(2023, 5, 4, 10, 0, 0, 0, time.UTC))
+	gw := &k8s_networking_v1beta1.Gateway{}
+	gw.Status.Conditions = []meta_v1.Condition{
+		{Type: "Accepted", Status: meta_v1.ConditionTrue, Reason: "Accepted", LastTransitionTime: transition},
+		{Type: "Programmed", Status: meta_v1.ConditionFalse, Reason: "AddressNotAssigned", Message: "No addresses have been assigned", LastTransitionTime: transition},
+	}
+	gw.Status.Listeners = []k8s_networking_v1beta1.ListenerStatus{
+		{Name: "http", Conditions: []meta_v1.Condition{{Type: "Programmed", Status: meta_v1.ConditionTrue, Reason: "Programmed"}}},
+	}
+
+	details := models.IstioConfigDetails{K8sGateway: gw}
+	details.SetStatus()
+
+	assert.Equal(&models.IstioConfigStatus{
+		Conditions: []models.IstioConfigCondition{
+			{Type: "Accepted", Status: "True", Reason: "Accepted", LastTransitionTime: "2023-05-04T10:00:00Z"},
+			{Type: "Programmed", Status: "False", Reason: "AddressNotAssigned", Message: "No addresses have been assigned", LastTransitionTime: "2023-05-04T10:00:00Z"},
+			{Type: "Programmed", Status: "True", Reason: "Programmed", Scope: "listener/http"},
+		},
+	}, details.Status)
+}
+
+func TestK8sGatewayWithoutStatus(t *testing.T) {
+	details := models.IstioConfigDetails{K8sGateway: &k8s_networking_v1beta1.Gateway{}}
+	details.SetStatus()
+
+	assert.Nil(t, details.Status)
+}
+
+func TestK8sHTTPRouteParentsStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	otherNamespace := k8s_networking_v1beta1.Namespace("istio-system")
+	section := k8s_networking_v1beta1.SectionName("https")
+	route := &k8s_networking_v1beta1.HTTPRoute{ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"}}
+	route.Status.Parents = []k8s_networking_v1beta1.RouteParentStatus{
+		{
+			ParentRef:  k8s_networking_v1beta1.ParentReference{Name: "gateway"},
+			Conditions: []meta_v1.Condition{{Type: "Accepted", Status: meta_v1.ConditionTrue, Reason: "Accepted"}},
+		},
+		{
+			ParentRef:  k8s_networking_v1beta1.ParentReference{Name: "shared", Namespace: &otherNamespace, SectionName: &section},
+			Conditions: []meta_v1.Condition{{Type: "Accepted", Status: meta_v1.ConditionFalse, Reason: "NotAllowedByListeners"}},
+		},
+	}
+
+	details := models.IstioConfigDetails{K8sHTTPRoute: route}
+	details.SetStatus()
+
+	assert.Equal([]models.IstioConfigCondition{
+		{Type: "Accepted", Status: "True", Reason: "Accepted", Scope: "parent/bookinfo/gateway"},
+		{Type: "Accepted", Status: "False", Reason: "NotAllowedByListeners", Scope: "parent/istio-system/shared/https"},
+	}, details.Status.Conditions)
+}
+
+func TestIstioAnalysisStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	vs := &networking_v1beta1.VirtualService{}
+	vs.Status = api_meta_v1alpha1.IstioStatus{
+		Conditions: []*api_meta_v1alpha1.IstioCondition{{Type: "Reconciled", Status: "True"}},
+		ValidationMessages: []*api_analysis_v1alpha1.AnalysisMessageBase{
+			{
+				Type:             &api_analysis_v1alpha1.AnalysisMessageBase_Type{Name: "ReferencedResourceNotFound", Code: "IST0101"},
+				Level:            api_analysis_v1alpha1.AnalysisMessageBase_ERROR,
+				DocumentationUrl: "https://istio.io/latest/docs/reference/config/analysis/ist0101/",
+			},
+		},
+	}
+
+	details := models.IstioConfigDetails{VirtualService: vs}
+	details.SetStatus()
+
+	assert.Equal(&models.IstioConfigStatus{
+		Conditions: []models.IstioConfigCondition{{Type: "Reconciled", Status: "True"}},
+		ValidationMessages: []models.IstioConfigValidationMessage{
+			{Code: "IST0101", Name: "ReferencedResourceNotFound", Level: "ERROR", DocumentationUrl: "https://istio.io/latest/docs/reference/config/analysis/ist0101/"},
+		},
+	}, details.Status)
+
+	details = models.IstioConfigDetails{VirtualService: &networking_v1beta1.VirtualService{}}
+	details.SetStatus()
+	assert.Nil(details.Status)
+}
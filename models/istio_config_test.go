@@ -0,0 +1,33 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	security_v1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/models"
+)
+
+func TestIstioConfigListSort(t *testing.T) {
+	assert := assert.New(t)
+
+	vs := func(namespace, name string) *networking_v1beta1.VirtualService {
+		return &networking_v1beta1.VirtualService{ObjectMeta: meta_v1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+	ap := func(namespace, name string) *security_v1beta1.AuthorizationPolicy {
+		return &security_v1beta1.AuthorizationPolicy{ObjectMeta: meta_v1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+	configList := models.IstioConfigList{
+		VirtualServices:       []*networking_v1beta1.VirtualService{vs("travels", "cars"), vs("bookinfo", "reviews"), vs("bookinfo", "details")},
+		AuthorizationPolicies: []*security_v1beta1.AuthorizationPolicy{ap("travels", "deny"), ap("bookinfo", "allow")},
+		TimedOut:              []string{"sidecars", "gateways"},
+	}
+
+	configList.Sort()
+	assert.Equal([]*networking_v1beta1.VirtualService{vs("bookinfo", "details"), vs("bookinfo", "reviews"), vs("travels", "cars")}, configList.VirtualServices)
+	assert.Equal([]*security_v1beta1.AuthorizationPolicy{ap("bookinfo", "allow"), ap("travels", "deny")}, configList.AuthorizationPolicies)
+	assert.Equal([]string{"gateways", "sidecars"}, configList.TimedOut)
+}
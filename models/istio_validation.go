@@ -2,6 +2,8 @@ package models
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
 
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/log"
@@ -145,6 +147,11 @@ var checkDescriptors = map[string]IstioCheck{
 		Message:  "This field requires mTLS to be enabled",
 		Severity: ErrorSeverity,
 	},
+	"authorizationpolicy.provider.notfound": {
+		Code:     "KIA0107",
+		Message:  "Extension provider not found in the mesh config",
+		Severity: ErrorSeverity,
+	},
 	"destinationrules.multimatch": {
 		Code:     "KIA0201",
 		Message:  "More than one DestinationRules for the same host subset combination",
@@ -205,6 +212,16 @@ var checkDescriptors = map[string]IstioCheck{
 		Message:  "No matching namespace found or namespace is not accessible",
 		Severity: ErrorSeverity,
 	},
+	"generic.healthannotation.malformed": {
+		Code:     "KIA0006",
+		Message:  "Health annotation can't be parsed, expected <code>,<degraded>,<failure>,<protocol>,<direction>",
+		Severity: ErrorSeverity,
+	},
+	"generic.healthannotation.outofrange": {
+		Code:     "KIA0007",
+		Message:  "Health annotation thresholds must be between 0 and 100 and degraded can't be greater than failure",
+		Severity: WarningSeverity,
+	},
 	"generic.multimatch.selectorless": {
 		Code:     "KIA0002",
 		Message:  "More than one selector-less object in the same namespace",
@@ -250,6 +267,16 @@ var checkDescriptors = map[string]IstioCheck{
 		Message:  "Destination Rule disabling mesh-wide mTLS is missing",
 		Severity: ErrorSeverity,
 	},
+	"peerauthentications.mtls.portnotfound": {
+		Code:     "KIA0507",
+		Message:  "Port-level mTLS is set on a port not exposed by the selected workloads",
+		Severity: WarningSeverity,
+	},
+	"peerauthentications.mtls.portdowngrade": {
+		Code:     "KIA0508",
+		Message:  "Port-level mTLS downgrades the STRICT mesh-wide mTLS",
+		Severity: WarningSeverity,
+	},
 	"port.appprotocol.mismatch": {
 		Code:     "KIA0602",
 		Message:  "Port appProtocol must follow <protocol> form",
@@ -270,6 +297,21 @@ var checkDescriptors = map[string]IstioCheck{
 		Message:  "Missing one or more addresses from matching WorkloadEntries",
 		Severity: WarningSeverity,
 	},
+	"serviceentries.hosts.overlap": {
+		Code:     "KIA1202",
+		Message:  "More than one ServiceEntry for the same host",
+		Severity: WarningSeverity,
+	},
+	"sidecar.egress.calledhostblocked": {
+		Code:     "KIA1009",
+		Message:  "The egress hosts of this Sidecar block hosts called by its workloads",
+		Severity: WarningSeverity,
+	},
+	"sidecar.egress.controlplaneblocked": {
+		Code:     "KIA1008",
+		Message:  "The egress hosts of this Sidecar block the access of its workloads to the control plane",
+		Severity: WarningSeverity,
+	},
 	"sidecar.egress.servicenotfound": {
 		Code:     "KIA1004",
 		Message:  "This host has no matching entry in the service registry",
@@ -285,6 +327,11 @@ var checkDescriptors = map[string]IstioCheck{
 		Message:  "OutboundTrafficPolicy with empty mode value is ambiguous due to an Istio limitation. This may indicate ALLOW_ANY or REGISTRY_ONLY. Inspect the value using other means.",
 		Severity: Unknown,
 	},
+	"virtualservices.delegate.notfound": {
+		Code:     "KIA1109",
+		Message:  "VirtualService is delegating to a non-existent VirtualService",
+		Severity: ErrorSeverity,
+	},
 	"virtualservices.gateway.oldnomenclature": {
 		Code:     "KIA1108",
 		Message:  "Preferred nomenclature: <gateway namespace>/<gateway name>",
@@ -310,6 +357,11 @@ var checkDescriptors = map[string]IstioCheck{
 		Message:  "This host subset combination is already referenced in another route destination",
 		Severity: WarningSeverity,
 	},
+	"virtualservices.route.unreachable": {
+		Code:     "KIA1110",
+		Message:  "This route is unreachable, an earlier route matches all its requests",
+		Severity: WarningSeverity,
+	},
 	"virtualservices.singlehost": {
 		Code:     "KIA1106",
 		Message:  "More than one Virtual Service for same host",
@@ -340,6 +392,11 @@ var checkDescriptors = map[string]IstioCheck{
 		Message:  "Each listener must have a unique combination of Hostname, Port, and Protocol",
 		Severity: ErrorSeverity,
 	},
+	"wasmplugins.priority.collision": {
+		Code:     "KIA1601",
+		Message:  "More than one WasmPlugin applies to a workload with the same phase and priority",
+		Severity: WarningSeverity,
+	},
 }
 
 func Build(checkId string, path string) IstioCheck {
@@ -487,6 +544,28 @@ func (iv IstioValidations) MarshalJSON() ([]byte, error) {
 	return json.Marshal(out)
 }
 
+// UnmarshalJSON implements the json.Unmarshaler interface, reading the format written by MarshalJSON.
+// The cluster of the keys is not marshalled, so it's left empty.
+func (iv *IstioValidations) UnmarshalJSON(data []byte) error {
+	in := make(map[string]map[string]*IstioValidation)
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	*iv = make(IstioValidations)
+	for objectType, validations := range in {
+		for nameNamespace, v := range validations {
+			// Namespaces can't contain dots, the name is everything before the last one
+			i := strings.LastIndex(nameNamespace, ".")
+			if i < 0 {
+				return fmt.Errorf("invalid validation key [%s]", nameNamespace)
+			}
+			key := IstioValidationKey{ObjectType: objectType, Name: nameNamespace[:i], Namespace: nameNamespace[i+1:]}
+			(*iv)[key] = v
+		}
+	}
+	return nil
+}
+
 func (iv *IstioValidations) StripIgnoredChecks() {
 	// strip away codes that are to be ignored
 	codesToIgnore := config.Get().KialiFeatureFlags.Validations.Ignore
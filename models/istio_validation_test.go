@@ -29,6 +29,33 @@ func TestIstioValidationsMarshal(t *testing.T) {
 	assert.Equal(string(b), `{"virtualservice":{"bar.test2":{"name":"bar","objectType":"virtualservice","valid":false,"checks":null,"references":null},"foo.test":{"name":"foo","objectType":"virtualservice","valid":true,"checks":null,"references":null}}}`)
 }
 
+func TestIstioValidationsUnmarshal(t *testing.T) {
+	assert := assert.New(t)
+
+	validations := IstioValidations{
+		IstioValidationKey{ObjectType: "virtualservice", Name: "foo.example.com", Namespace: "test"}: &IstioValidation{
+			Name:       "foo.example.com",
+			ObjectType: "virtualservice",
+			Valid:      true,
+		},
+		IstioValidationKey{ObjectType: "destinationrule", Name: "bar", Namespace: "test2"}: &IstioValidation{
+			Name:       "bar",
+			ObjectType: "destinationrule",
+			Valid:      false,
+			Checks:     []*IstioCheck{{Code: "KIA0203", Severity: ErrorSeverity, Path: "spec/subsets[0]"}},
+		},
+	}
+	b, err := json.Marshal(validations)
+	assert.NoError(err)
+
+	unmarshalled := IstioValidations{}
+	assert.NoError(json.Unmarshal(b, &unmarshalled))
+	// The dots of the name are kept, the namespace follows the last one
+	assert.Equal(validations, unmarshalled)
+
+	assert.Error(json.Unmarshal([]byte(`{"virtualservice":{"foo":{}}}`), &unmarshalled))
+}
+
 func TestIstioValidationKeyMarshal(t *testing.T) {
 	assert := assert.New(t)
 
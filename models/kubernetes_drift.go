@@ -0,0 +1,34 @@
+package models
+
+import "github.com/kiali/kiali/util/jsondiff"
+
+// KubernetesDrift holds the differences between the configuration last applied with kubectl to a Kubernetes object and its live state
+type KubernetesDrift struct {
+	// Kind of the object
+	// required: true
+	// example: Deployment
+	Kind string `json:"kind"`
+
+	// Name of the object
+	// required: true
+	// example: reviews-v1
+	Name string `json:"name"`
+
+	// Namespace of the object
+	// required: true
+	// example: bookinfo
+	Namespace string `json:"namespace"`
+
+	// Define if the object has a last applied configuration (kubectl.kubernetes.io/last-applied-configuration).
+	// Without it, the drift can't be computed.
+	// required: true
+	LastApplied bool `json:"lastApplied"`
+
+	// Define if the live object matches its last applied configuration
+	// required: true
+	InSync bool `json:"inSync"`
+
+	// Values of the last applied configuration differing from the live object. The "from" values are the applied ones.
+	// required: true
+	Differences []jsondiff.Difference `json:"differences"`
+}
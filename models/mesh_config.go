@@ -0,0 +1,13 @@
+package models
+
+// MeshConfig is the mesh config of a control plane revision, as set in its Istio ConfigMap
+type MeshConfig struct {
+	// Cluster of the control plane
+	Cluster string `json:"cluster"`
+	// Revision of the control plane, "default" for the control plane without revision
+	Revision string `json:"revision"`
+	// ConfigMap holding the mesh config, in the Istio namespace
+	ConfigMap string `json:"configMap"`
+	// Config is the parsed "mesh" entry of the ConfigMap. The settings not present take the Istio defaults
+	Config map[string]interface{} `json:"config"`
+}
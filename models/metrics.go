@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"time"
 
+	prom_v1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	pmod "github.com/prometheus/common/model"
 
 	"github.com/kiali/kiali/prometheus"
@@ -130,6 +131,7 @@ func (q *ControlPlaneMetricsQuery) FillDefaults() {
 type Metric struct {
 	Labels     map[string]string `json:"labels"`
 	Datapoints []Datapoint       `json:"datapoints"`
+	Exemplars  []Exemplar        `json:"exemplars,omitempty"`
 	Stat       string            `json:"stat,omitempty"`
 	Name       string            `json:"name"`
 }
@@ -139,6 +141,19 @@ type Datapoint struct {
 	Value     float64
 }
 
+// Exemplar is a sample of a series linked to the trace of one of the requests it counts
+type Exemplar struct {
+	Labels map[string]string `json:"labels"`
+	// TraceID is the ID of the trace of the exemplar, taken from its labels
+	TraceID string `json:"traceId,omitempty"`
+	// Timestamp in milliseconds
+	Timestamp int64   `json:"timestamp"`
+	Value     float64 `json:"value"`
+}
+
+// exemplarTraceIDLabels are the labels holding the trace ID of an exemplar, depending on the instrumentation
+var exemplarTraceIDLabels = []string{"trace_id", "traceID", "traceId"}
+
 // MetricsMap contains all simple metrics and histograms data for standard timeseries queries
 type MetricsMap = map[string][]Metric
 
@@ -182,7 +197,7 @@ func ConvertHistogram(name string, from prometheus.Histogram, conversionParams C
 		if promMetric.Err != nil {
 			return nil, fmt.Errorf("error in metric %s/%s: %v", name, stat, promMetric.Err)
 		}
-		metric := convertMatrix(promMetric.Matrix, name, stat, conversionParams)
+		metric := convertMatrix(promMetric.Matrix, promMetric.Exemplars, name, stat, conversionParams)
 		out = append(out, metric...)
 	}
 	return out, nil
@@ -192,10 +207,10 @@ func ConvertMetric(name string, from prometheus.Metric, conversionParams Convers
 	if from.Err != nil {
 		return nil, fmt.Errorf("error in metric %s: %v", name, from.Err)
 	}
-	return convertMatrix(from.Matrix, name, "", conversionParams), nil
+	return convertMatrix(from.Matrix, from.Exemplars, name, "", conversionParams), nil
 }
 
-func convertMatrix(from pmod.Matrix, name, stat string, conversionParams ConversionParams) []Metric {
+func convertMatrix(from pmod.Matrix, exemplars []prom_v1.ExemplarQueryResult, name, stat string, conversionParams ConversionParams) []Metric {
 	series := make([]Metric, len(from))
 	if len(conversionParams.SortLabel) > 0 {
 		sort.Slice(from, func(i, j int) bool {
@@ -211,12 +226,12 @@ func convertMatrix(from pmod.Matrix, name, stat string, conversionParams Convers
 		})
 	}
 	for i, s := range from {
-		series[i] = convertSampleStream(s, name, stat, conversionParams)
+		series[i] = convertSampleStream(s, exemplars, name, stat, conversionParams)
 	}
 	return series
 }
 
-func convertSampleStream(from *pmod.SampleStream, name, stat string, conversionParams ConversionParams) Metric {
+func convertSampleStream(from *pmod.SampleStream, exemplars []prom_v1.ExemplarQueryResult, name, stat string, conversionParams ConversionParams) Metric {
 	labelSet := make(map[string]string, len(from.Metric))
 	for k, v := range from.Metric {
 		if conversionParams.SortLabel == string(k) && conversionParams.RemoveSortLabel {
@@ -232,11 +247,49 @@ func convertSampleStream(from *pmod.SampleStream, name, stat string, conversionP
 	return Metric{
 		Labels:     labelSet,
 		Datapoints: values,
+		Exemplars:  convertExemplars(from.Metric, exemplars, conversionParams.Scale),
 		Name:       name,
 		Stat:       stat,
 	}
 }
 
+// convertExemplars returns the exemplars of the raw series aggregated by a series, the raw series having all the labels of the series
+func convertExemplars(seriesLabels pmod.Metric, from []prom_v1.ExemplarQueryResult, scale float64) []Exemplar {
+	var exemplars []Exemplar
+	for _, result := range from {
+		if !hasLabels(result.SeriesLabels, seriesLabels) {
+			continue
+		}
+		for _, e := range result.Exemplars {
+			exemplar := Exemplar{
+				Labels:    make(map[string]string, len(e.Labels)),
+				Timestamp: int64(e.Timestamp),
+				Value:     scale * float64(e.Value),
+			}
+			for k, v := range e.Labels {
+				exemplar.Labels[string(k)] = string(v)
+			}
+			for _, traceIDLabel := range exemplarTraceIDLabels {
+				if traceID, found := exemplar.Labels[traceIDLabel]; found {
+					exemplar.TraceID = traceID
+					break
+				}
+			}
+			exemplars = append(exemplars, exemplar)
+		}
+	}
+	return exemplars
+}
+
+func hasLabels(labelSet pmod.LabelSet, labels pmod.Metric) bool {
+	for k, v := range labels {
+		if labelSet[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
 // MarshalJSON implements json.Marshaler.
 func (s Datapoint) MarshalJSON() ([]byte, error) {
 	return pmod.SamplePair{
@@ -9,3 +9,69 @@ type MTLSStatus struct {
 	AutoMTLSEnabled bool   `json:"autoMTLSEnabled"`
 	MinTLS          string `json:"minTLS"`
 }
+
+// MTLSSummary summarizes the mTLS posture of the namespaces of all the clusters of the mesh
+type MTLSSummary struct {
+	// Number of namespaces enforcing mTLS
+	// required: true
+	// example: 3
+	Strict int `json:"strict"`
+
+	// Number of namespaces accepting plain text and mTLS traffic
+	// required: true
+	// example: 5
+	Permissive int `json:"permissive"`
+
+	// Number of namespaces with mTLS disabled
+	// required: true
+	// example: 1
+	Disable int `json:"disable"`
+
+	// Number of namespaces with conflicting PeerAuthentications and DestinationRules
+	// required: true
+	// example: 1
+	Conflicting int `json:"conflicting"`
+
+	// Breakdown by cluster
+	// required: true
+	Clusters []ClusterMTLSSummary `json:"clusters"`
+}
+
+// ClusterMTLSSummary summarizes the mTLS posture of the namespaces of a cluster
+type ClusterMTLSSummary struct {
+	// Name of the cluster
+	// required: true
+	// example: east
+	Cluster string `json:"cluster"`
+
+	AutoMTLSEnabled bool `json:"autoMTLSEnabled"`
+	Strict          int  `json:"strict"`
+	Permissive      int  `json:"permissive"`
+	Disable         int  `json:"disable"`
+	Conflicting     int  `json:"conflicting"`
+
+	// mTLS posture of each namespace of the cluster
+	// required: true
+	Namespaces []NamespaceMTLSSummary `json:"namespaces"`
+}
+
+// NamespaceMTLSSummary describes the mTLS posture of a namespace
+type NamespaceMTLSSummary struct {
+	// Name of the namespace
+	// required: true
+	// example: bookinfo
+	Namespace string `json:"namespace"`
+
+	// Effective PeerAuthentication mode: STRICT, PERMISSIVE or DISABLE
+	// required: true
+	// example: STRICT
+	Mode string `json:"mode"`
+
+	// mTLS status: MTLS_ENABLED, MTLS_PARTIALLY_ENABLED, MTLS_NOT_ENABLED, MTLS_DISABLED
+	// required: true
+	// example: MTLS_ENABLED
+	Status string `json:"status"`
+
+	// Checks reporting the conflicts between the PeerAuthentications and the DestinationRules of the namespace
+	Conflicts []*IstioCheck `json:"conflicts,omitempty"`
+}
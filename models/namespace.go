@@ -6,6 +6,7 @@ import (
 	osproject_v1 "github.com/openshift/api/project/v1"
 	core_v1 "k8s.io/api/core/v1"
 
+	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/config/dashboards"
 )
 
@@ -38,13 +39,108 @@ type Namespace struct {
 
 	// Specific annotations used in Kiali
 	Annotations map[string]string `json:"annotations"`
+
+	// Why the namespace is part of the mesh, or "none" when it is not.
+	// Only set when the mesh membership is requested.
+	//
+	// example: injection
+	MeshMember MeshMembership `json:"meshMember,omitempty"`
 }
 
+// MeshMembership classifies how a namespace is part of the mesh
+type MeshMembership string
+
+const (
+	// The namespace enables the sidecar injection, by the injection or the revision label
+	MeshMemberInjection MeshMembership = "injection"
+	// The namespace is labeled for the ambient dataplane mode
+	MeshMemberAmbient MeshMembership = "ambient"
+	// The namespace isn't labeled but has workloads with a sidecar or captured by ambient
+	MeshMemberWorkloads MeshMembership = "workloads"
+	// The namespace is not part of the mesh
+	MeshMemberNone MeshMembership = "none"
+)
+
+// AmbientDataplaneModeLabel is the namespace label enabling the ambient dataplane mode
+const AmbientDataplaneModeLabel = "istio.io/dataplane-mode"
+
+const (
+	// AppLabelNameAnnotation is the namespace annotation overriding the app label name of the IstioLabels config
+	AppLabelNameAnnotation = "kiali.io/app-label-name"
+	// VersionLabelNameAnnotation is the namespace annotation overriding the version label name of the IstioLabels config
+	VersionLabelNameAnnotation = "kiali.io/version-label-name"
+)
+
+// kialiAnnotations are the namespace annotations used by Kiali
+var kialiAnnotations = []string{dashboards.DashboardTemplateAnnotation, AppLabelNameAnnotation, VersionLabelNameAnnotation}
+
 type (
 	Namespaces     []Namespace
 	NamespaceNames []string
 )
 
+// ClusterAccess lists the namespaces of a cluster the user can access
+// along with a summary of what the user can do in each of them.
+//
+// swagger:model clusterAccess
+type ClusterAccess struct {
+	// The name of the cluster
+	//
+	// example:  east
+	// required: true
+	Cluster string `json:"cluster"`
+
+	// The namespaces of the cluster the user can access
+	//
+	// required: true
+	Namespaces []NamespaceAccess `json:"namespaces"`
+}
+
+// NamespaceAccess is a coarse summary of the user's capabilities in a namespace.
+//
+// swagger:model namespaceAccess
+type NamespaceAccess struct {
+	// The name of the namespace
+	//
+	// example:  bookinfo
+	// required: true
+	Name string `json:"name"`
+
+	// Whether the user can create, update or delete Istio config in the namespace
+	//
+	// required: true
+	CanWriteIstioConfig bool `json:"canWriteIstioConfig"`
+}
+
+// AccessibleNamespaceRBAC reports whether the Kiali service account can list the resources Kiali
+// needs in one of the accessible namespaces of the configuration.
+//
+// swagger:model accessibleNamespaceRBAC
+type AccessibleNamespaceRBAC struct {
+	// The name of the cluster
+	//
+	// example:  east
+	// required: true
+	Cluster string `json:"cluster"`
+
+	// The name of the namespace, empty when every namespace is accessible and the check is cluster wide
+	//
+	// example:  bookinfo
+	// required: true
+	Namespace string `json:"namespace"`
+
+	// The resources, as resource.group, the Kiali service account can't list in the namespace
+	//
+	// example:  ["pods", "virtualservices.networking.istio.io"]
+	// required: true
+	MissingResources []string `json:"missingResources"`
+
+	// The error checking the permissions, if any
+	//
+	// required: false
+	Error string `json:"error,omitempty"`
+}
+
 func CastNamespaceCollection(ns []core_v1.Namespace, cluster string) []Namespace {
 	namespaces := make([]Namespace, len(ns))
 	for i, item := range ns {
@@ -62,8 +158,10 @@ func CastNamespace(ns core_v1.Namespace, cluster string) Namespace {
 	namespace.Labels = ns.Labels
 	namespace.Annotations = make(map[string]string)
 	// Parse only annotations used by Kiali
-	if da, ok := ns.Annotations[dashboards.DashboardTemplateAnnotation]; ok {
-		namespace.Annotations[dashboards.DashboardTemplateAnnotation] = da
+	for _, annotation := range kialiAnnotations {
+		if value, ok := ns.Annotations[annotation]; ok {
+			namespace.Annotations[annotation] = value
+		}
 	}
 	return namespace
 }
@@ -85,12 +183,26 @@ func CastProject(p osproject_v1.Project, cluster string) Namespace {
 	namespace.Labels = p.Labels
 	namespace.Annotations = make(map[string]string)
 	// Parse only annotations used by Kiali
-	if da, ok := p.Annotations[dashboards.DashboardTemplateAnnotation]; ok {
-		namespace.Annotations[dashboards.DashboardTemplateAnnotation] = da
+	for _, annotation := range kialiAnnotations {
+		if value, ok := p.Annotations[annotation]; ok {
+			namespace.Annotations[annotation] = value
+		}
 	}
 	return namespace
 }
 
+// GetIstioLabels returns the IstioLabels used by the workloads of the namespace. Teams can follow their own labeling
+// conventions, setting the app and version label names with the namespace annotations, otherwise the given ones apply.
+func (ns Namespace) GetIstioLabels(istioLabels config.IstioLabels) config.IstioLabels {
+	if appLabelName := ns.Annotations[AppLabelNameAnnotation]; appLabelName != "" {
+		istioLabels.AppLabelName = appLabelName
+	}
+	if versionLabelName := ns.Annotations[VersionLabelNameAnnotation]; versionLabelName != "" {
+		istioLabels.VersionLabelName = versionLabelName
+	}
+	return istioLabels
+}
+
 func (nss Namespaces) Includes(namespace string) bool {
 	for _, ns := range nss {
 		if ns.Name == namespace {
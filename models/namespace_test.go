@@ -0,0 +1,36 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+)
+
+func TestNamespaceGetIstioLabels(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+
+	ns := CastNamespace(core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{
+		Name: "bookinfo",
+		Annotations: map[string]string{
+			AppLabelNameAnnotation:     "team-app",
+			VersionLabelNameAnnotation: "team-version",
+			"team":                     "bookinfo",
+		},
+	}}, conf.KubernetesConfig.ClusterName)
+
+	// Only the annotations used by Kiali are kept
+	assert.Equal(map[string]string{AppLabelNameAnnotation: "team-app", VersionLabelNameAnnotation: "team-version"}, ns.Annotations)
+
+	istioLabels := ns.GetIstioLabels(conf.IstioLabels)
+	assert.Equal("team-app", istioLabels.AppLabelName)
+	assert.Equal("team-version", istioLabels.VersionLabelName)
+	assert.Equal(conf.IstioLabels.InjectionLabelName, istioLabels.InjectionLabelName)
+
+	// The IstioLabels of the config apply to the namespaces without annotations
+	assert.Equal(conf.IstioLabels, Namespace{Name: "travels"}.GetIstioLabels(conf.IstioLabels))
+}
@@ -0,0 +1,20 @@
+package models
+
+const (
+	OutboundTrafficPolicyAllowAny     = "ALLOW_ANY"
+	OutboundTrafficPolicyRegistryOnly = "REGISTRY_ONLY"
+)
+
+// OutboundTrafficPolicy is the effective outbound traffic policy of the proxies of a workload or of a namespace
+type OutboundTrafficPolicy struct {
+	// Mode of the policy: "ALLOW_ANY" lets the traffic reach the hosts unknown to the mesh, "REGISTRY_ONLY" blocks it
+	// required: true
+	// example: REGISTRY_ONLY
+	Mode string `json:"mode"`
+	// Source of the decision: "sidecar", "mesh" or "default"
+	// required: true
+	// example: sidecar
+	Source string `json:"source"`
+	// Sidecar providing the policy, as "<namespace>/<name>", when the source is "sidecar"
+	Sidecar string `json:"sidecar,omitempty"`
+}
@@ -32,12 +32,17 @@ type Pod struct {
 	Annotations         map[string]string `json:"annotations"`
 	ProxyStatus         *ProxyStatus      `json:"proxyStatus"`
 	ServiceAccountName  string            `json:"serviceAccountName"`
+	// Controllers owning the pod, from its direct owner up. Only set when requested.
+	OwnerChain []Reference `json:"ownerChain,omitempty"`
+	// Resources of the istio-proxy container of the pod. Only set when requested.
+	ProxyResources *ProxyResources `json:"proxyResources,omitempty"`
 }
 
 // Reference holds some information on the pod creator
 type Reference struct {
-	Name string `json:"name"`
-	Kind string `json:"kind"`
+	Name       string `json:"name"`
+	Kind       string `json:"kind"`
+	APIVersion string `json:"apiVersion,omitempty"`
 }
 
 // ContainerInfo holds container name and image
@@ -47,6 +52,28 @@ type ContainerInfo struct {
 	IsProxy   bool   `json:"isProxy"`
 	IsReady   bool   `json:"isReady"`
 	IsAmbient bool   `json:"isAmbient"`
+	// Define if the container is injected by Istio, as opposed to the application containers
+	IsInjected bool `json:"isInjected"`
+}
+
+// ProxyResources holds the resources reserved and used by the istio-proxy container of a pod
+type ProxyResources struct {
+	// CPU requested by the proxy, empty when not set
+	// example: 100m
+	CPURequest string `json:"cpuRequest,omitempty"`
+	// CPU limit of the proxy, empty when not set
+	// example: 2
+	CPULimit string `json:"cpuLimit,omitempty"`
+	// Memory requested by the proxy, empty when not set
+	// example: 128Mi
+	MemoryRequest string `json:"memoryRequest,omitempty"`
+	// Memory limit of the proxy, empty when not set
+	// example: 1Gi
+	MemoryLimit string `json:"memoryLimit,omitempty"`
+	// CPU used by the proxy, in cores. Nil when the metrics are not available
+	CPUUsage *float64 `json:"cpuUsage,omitempty"`
+	// Memory used by the proxy (working set), in bytes. Nil when the metrics are not available
+	MemoryUsage *float64 `json:"memoryUsage,omitempty"`
 }
 
 // Parse extracts desired information from k8s []Pod info
@@ -89,20 +116,22 @@ func (pod *Pod) Parse(p *core_v1.Pod) {
 		if err == nil {
 			for _, name := range scs.InitContainers {
 				container := ContainerInfo{
-					Name:    name,
-					Image:   lookupImage(name, p.Spec.InitContainers),
-					IsProxy: true,
-					IsReady: lookupReady(name, p.Status.InitContainerStatuses),
+					Name:       name,
+					Image:      lookupImage(name, p.Spec.InitContainers),
+					IsProxy:    true,
+					IsReady:    lookupReady(name, p.Status.InitContainerStatuses),
+					IsInjected: true,
 				}
 				pod.IstioInitContainers = append(pod.IstioInitContainers, &container)
 				istioContainerNames[name] = true
 			}
 			for _, name := range scs.Containers {
 				container := ContainerInfo{
-					Name:    name,
-					Image:   lookupImage(name, p.Spec.Containers),
-					IsProxy: true,
-					IsReady: lookupReady(name, p.Status.ContainerStatuses),
+					Name:       name,
+					Image:      lookupImage(name, p.Spec.Containers),
+					IsProxy:    true,
+					IsReady:    lookupReady(name, p.Status.ContainerStatuses),
+					IsInjected: true,
 				}
 				pod.IstioContainers = append(pod.IstioContainers, &container)
 				istioContainerNames[name] = true
@@ -114,14 +143,29 @@ func (pod *Pod) Parse(p *core_v1.Pod) {
 			continue
 		}
 		container := ContainerInfo{
-			Name:      c.Name,
-			Image:     c.Image,
-			IsProxy:   isIstioProxy(p, &c, conf),
-			IsReady:   lookupReady(c.Name, p.Status.ContainerStatuses),
-			IsAmbient: isIstioAmbient(p),
+			Name:       c.Name,
+			Image:      c.Image,
+			IsProxy:    isIstioProxy(p, &c, conf),
+			IsReady:    lookupReady(c.Name, p.Status.ContainerStatuses),
+			IsAmbient:  isIstioAmbient(p),
+			IsInjected: conf.IsInjectedContainer(c.Name),
 		}
 		pod.Containers = append(pod.Containers, &container)
 	}
+	// Without the sidecar status annotation, the init containers injected by Istio are identified by their names
+	for _, c := range p.Spec.InitContainers {
+		if istioContainerNames[c.Name] || !conf.IsInjectedContainer(c.Name) {
+			continue
+		}
+		container := ContainerInfo{
+			Name:       c.Name,
+			Image:      c.Image,
+			IsProxy:    true,
+			IsReady:    lookupReady(c.Name, p.Status.InitContainerStatuses),
+			IsInjected: true,
+		}
+		pod.IstioInitContainers = append(pod.IstioInitContainers, &container)
+	}
 	pod.Status = string(p.Status.Phase)
 	pod.StatusMessage = string(p.Status.Message)
 	pod.StatusReason = string(p.Status.Reason)
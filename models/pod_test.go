@@ -127,6 +127,70 @@ func TestPodParsingInvalidAnnotations(t *testing.T) {
 	assert.Len(pod.IstioInitContainers, 0)
 }
 
+func TestPodParsingInjectedContainers(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+	k8sPod := core_v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:        "details-v1-3618568057-dnkjp",
+			Annotations: map[string]string{"sidecar.istio.io/status": "{\"initContainers\":[\"istio-init\"],\"containers\":[\"istio-proxy\"]}"}},
+		Spec: core_v1.PodSpec{
+			Containers: []core_v1.Container{
+				{Name: "details", Image: "whatever"},
+				{Name: "istio-proxy", Image: "docker.io/istio/proxyv2:1.18.0"},
+			},
+			InitContainers: []core_v1.Container{
+				{Name: "istio-init", Image: "docker.io/istio/proxyv2:1.18.0"},
+				{Name: "migrate-db", Image: "whatever"},
+			},
+		}}
+
+	pod := Pod{}
+	pod.Parse(&k8sPod)
+	assert.Len(pod.Containers, 1)
+	assert.Equal("details", pod.Containers[0].Name)
+	assert.False(pod.Containers[0].IsInjected)
+	assert.Len(pod.IstioContainers, 1)
+	assert.Equal("istio-proxy", pod.IstioContainers[0].Name)
+	assert.True(pod.IstioContainers[0].IsInjected)
+	assert.Len(pod.IstioInitContainers, 1)
+	assert.Equal("istio-init", pod.IstioInitContainers[0].Name)
+	assert.True(pod.IstioInitContainers[0].IsInjected)
+}
+
+func TestPodParsingInjectedContainersWithoutAnnotation(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.InjectedContainerNames = []string{"custom-proxy", "custom-init"}
+	config.Set(conf)
+	k8sPod := core_v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "details-v1-3618568057-dnkjp"},
+		Spec: core_v1.PodSpec{
+			Containers: []core_v1.Container{
+				{Name: "details", Image: "whatever"},
+				{Name: "custom-proxy", Image: "docker.io/istio/proxyv2:1.18.0"},
+				// Not injected according to the configured names
+				{Name: "istio-proxy", Image: "docker.io/istio/proxyv2:1.18.0"},
+			},
+			InitContainers: []core_v1.Container{
+				{Name: "custom-init", Image: "docker.io/istio/proxyv2:1.18.0"},
+				{Name: "migrate-db", Image: "whatever"},
+			},
+		}}
+
+	pod := Pod{}
+	pod.Parse(&k8sPod)
+	assert.Len(pod.IstioContainers, 0)
+	injected := map[string]bool{}
+	for _, container := range pod.Containers {
+		injected[container.Name] = container.IsInjected
+	}
+	assert.Equal(map[string]bool{"details": false, "custom-proxy": true, "istio-proxy": false}, injected)
+	assert.Len(pod.IstioInitContainers, 1)
+	assert.Equal("custom-init", pod.IstioInitContainers[0].Name)
+	assert.True(pod.IstioInitContainers[0].IsInjected)
+}
+
 func TestSyncedPodProxiesCount(t *testing.T) {
 	assert := assert.New(t)
 	config.Set(config.NewConfig())
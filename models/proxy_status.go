@@ -0,0 +1,53 @@
+package models
+
+// ProxyStatusList is a page of the sync status of the proxies of the mesh
+type ProxyStatusList struct {
+	// Total number of proxies matching the criteria, regardless of the page
+	// required: true
+	// example: 42
+	Total int `json:"total"`
+
+	// Page returned, starting at 1
+	// required: true
+	// example: 1
+	Page int `json:"page"`
+
+	// Maximum number of proxies of a page
+	// required: true
+	// example: 20
+	PageSize int `json:"pageSize"`
+
+	// Proxies of the page
+	// required: true
+	Items []ProxyStatusItem `json:"items"`
+}
+
+// ProxyStatusItem is the sync status of the proxy of a pod
+type ProxyStatusItem struct {
+	// Cluster of the pod
+	// required: true
+	// example: east
+	Cluster string `json:"cluster"`
+
+	// Namespace of the pod
+	// required: true
+	// example: bookinfo
+	Namespace string `json:"namespace"`
+
+	// Name of the pod
+	// required: true
+	// example: reviews-v1-5f7d8c6b4-xk2lp
+	Pod string `json:"pod"`
+
+	// Version of the proxy
+	// example: 1.18.0
+	ProxyVersion string `json:"proxyVersion,omitempty"`
+
+	// Version of istiod serving the proxy
+	// example: 1.18.0
+	IstioVersion string `json:"istioVersion,omitempty"`
+
+	// Sync status of the xDS resources
+	// required: true
+	Status ProxyStatus `json:"status"`
+}
@@ -0,0 +1,51 @@
+package models
+
+const (
+	RoutePolicySourceVirtualService  = "virtualservice"
+	RoutePolicySourceDestinationRule = "destinationrule"
+	RoutePolicySourceDefault         = "default"
+)
+
+// RoutePolicyValue is an effective value of the policy of a route and where it comes from
+type RoutePolicyValue struct {
+	// The effective value
+	// required: true
+	// example: 10s
+	Value string `json:"value"`
+	// Source of the value: "virtualservice", "destinationrule" or "default"
+	// required: true
+	// example: virtualservice
+	Source string `json:"source"`
+	// VirtualService or DestinationRule providing the value, as "<namespace>/<name>", unless the source is "default"
+	Resource string `json:"resource,omitempty"`
+}
+
+// EffectiveRoutePolicy is the timeout and retry policy applied to the requests of an http route to a service,
+// once the VirtualService route, the DestinationRule traffic policy and the Istio defaults are merged
+type EffectiveRoutePolicy struct {
+	// Name of the http route, empty for an unnamed route or when no VirtualService routes to the service
+	Route string `json:"route,omitempty"`
+	// Subset of the service the route sends the requests to, if any
+	Subset string `json:"subset,omitempty"`
+	// Timeout of the requests, "0s" when disabled
+	// required: true
+	Timeout RoutePolicyValue `json:"timeout"`
+	// Number of retries of a request
+	// required: true
+	RetryAttempts RoutePolicyValue `json:"retryAttempts"`
+	// Timeout of each try of a request
+	// required: true
+	RetryPerTryTimeout RoutePolicyValue `json:"retryPerTryTimeout"`
+	// Conditions retrying a request
+	// required: true
+	RetryOn RoutePolicyValue `json:"retryOn"`
+	// Maximum number of concurrent retries to the service
+	// required: true
+	MaxRetries RoutePolicyValue `json:"maxRetries"`
+	// Timeout of the connections to the service
+	// required: true
+	ConnectTimeout RoutePolicyValue `json:"connectTimeout"`
+	// Idle timeout of the connections to the service
+	// required: true
+	IdleTimeout RoutePolicyValue `json:"idleTimeout"`
+}
@@ -0,0 +1,46 @@
+package models
+
+const (
+	SecurityPolicyScopeMesh      = "mesh"
+	SecurityPolicyScopeNamespace = "namespace"
+	SecurityPolicyScopeWorkload  = "workload"
+)
+
+// SecurityPolicyWorkloads holds the workloads selected by a PeerAuthentication or a RequestAuthentication
+type SecurityPolicyWorkloads struct {
+	// Type of the policy
+	// required: true
+	// example: peerauthentication
+	ObjectType string `json:"objectType"`
+	// Name of the policy
+	// required: true
+	// example: default
+	Name string `json:"name"`
+	// Namespace of the policy
+	// required: true
+	// example: bookinfo
+	Namespace string `json:"namespace"`
+	// Scope of the policy: "mesh" for the root namespace ones without selector, "namespace" for the other ones
+	// without selector, "workload" for the ones with a selector
+	// required: true
+	// example: namespace
+	Scope string `json:"scope"`
+	// Workloads selected by the policy
+	// required: true
+	Workloads []SecurityPolicyWorkload `json:"workloads"`
+}
+
+// SecurityPolicyWorkload is a workload selected by a security policy
+type SecurityPolicyWorkload struct {
+	// Name of the workload
+	// required: true
+	// example: reviews-v1
+	Name string `json:"name"`
+	// Namespace of the workload
+	// required: true
+	// example: bookinfo
+	Namespace string `json:"namespace"`
+	// More specific PeerAuthentication applied to the workload instead of the policy, if any.
+	// RequestAuthentications are not overridden, all of them apply.
+	OverriddenBy *IstioReference `json:"overriddenBy,omitempty"`
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
 	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	core_v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -42,6 +43,9 @@ type ServiceOverview struct {
 	HealthAnnotations map[string]string `json:"healthAnnotations"`
 	// Names and Ports of Service
 	Ports map[string]int `json:"ports"`
+	// Protocols of the ports of the Service, following the Istio naming conventions
+	// example: ["http","grpc"]
+	Protocols []string `json:"protocols"`
 	// Labels for Service
 	Labels map[string]string `json:"labels"`
 	// Selector for Service
@@ -55,6 +59,14 @@ type ServiceOverview struct {
 	// External: 	is a service registry for externally provided ServiceEntries
 	// Federation:  special case when registry is provided from a federated environment
 	ServiceRegistry string `json:"serviceRegistry"`
+	// Location of the ServiceEntry declaring an External service
+	// example: MESH_EXTERNAL
+	// required: false
+	Location string `json:"location,omitempty"`
+	// Resolution of the ServiceEntry declaring an External service
+	// example: DNS
+	// required: false
+	Resolution string `json:"resolution,omitempty"`
 
 	// Health
 	Health ServiceHealth `json:"health,omitempty"`
@@ -64,6 +76,9 @@ type ServiceList struct {
 	Namespace   Namespace         `json:"namespace"`
 	Services    []ServiceOverview `json:"services"`
 	Validations IstioValidations  `json:"validations"`
+	// Errors of the clusters whose services couldn't be listed, by cluster name.
+	// The services of the other clusters are still listed.
+	ClusterErrors map[string]string `json:"clusterErrors,omitempty"`
 }
 
 type ServiceDefinitionList struct {
@@ -87,6 +102,51 @@ type ServiceDetails struct {
 	Health        ServiceHealth      `json:"health"`
 	Validations   IstioValidations   `json:"validations"`
 	NamespaceMTLS MTLSStatus         `json:"namespaceMTLS"`
+
+	// Subsets of the DestinationRules, flagged when used by the VirtualServices
+	DestinationRuleSubsets []DestinationRuleSubsets `json:"destinationRuleSubsets"`
+
+	// Services with the same name and namespace on the other clusters of the mesh,
+	// reported when the multi-cluster service replicas are enabled
+	Replicas []ServiceReplica `json:"replicas,omitempty"`
+}
+
+// ServiceReplica is a service with the same name and namespace as a service, on another cluster of the mesh.
+// The mesh merges them into a single service, so the traffic to the service may be sent to the replica.
+type ServiceReplica struct {
+	// Cluster of the replica
+	// required: true
+	// example: east
+	Cluster string `json:"cluster"`
+	// Namespace of the replica
+	// required: true
+	// example: bookinfo
+	Namespace string `json:"namespace"`
+	// Name of the replica
+	// required: true
+	// example: reviews
+	Name string `json:"name"`
+	// Endpoints of the replica on its cluster
+	Endpoints Endpoints `json:"endpoints"`
+}
+
+// DestinationRuleSubsets are the subsets of a DestinationRule of a service
+type DestinationRuleSubsets struct {
+	// Name of the DestinationRule
+	Name string `json:"name"`
+	// Namespace of the DestinationRule
+	Namespace string `json:"namespace"`
+	// Subsets defined by the DestinationRule
+	Subsets []SubsetUsage `json:"subsets"`
+}
+
+// SubsetUsage tells whether a subset is the destination of a route of the VirtualServices of the service.
+// A subset not used by any route is dead configuration.
+type SubsetUsage struct {
+	// Name of the subset
+	Name string `json:"name"`
+	// Used when a route destination references the subset
+	Used bool `json:"used"`
 }
 
 type (
@@ -172,6 +232,28 @@ func (s *ServiceDetails) SetEndpoints(eps *core_v1.Endpoints) {
 	(&s.Endpoints).Parse(eps)
 }
 
+// SetEndpointsLocality sets the region and the zone of the endpoint addresses targeting the pods, from the
+// topology labels of the nodes running them. The nodes are indexed by name. The addresses whose pod or node
+// is unknown, or whose node has no topology labels, are left without locality.
+func (s *ServiceDetails) SetEndpointsLocality(pods []core_v1.Pod, nodes map[string]*core_v1.Node) {
+	podNodes := make(map[string]string, len(pods))
+	for _, pod := range pods {
+		podNodes[pod.Name] = pod.Spec.NodeName
+	}
+	for i := range s.Endpoints {
+		for j := range s.Endpoints[i].Addresses {
+			address := &s.Endpoints[i].Addresses[j]
+			if address.Kind != "Pod" {
+				continue
+			}
+			if node, ok := nodes[podNodes[address.Name]]; ok {
+				address.Region = node.Labels[core_v1.LabelTopologyRegion]
+				address.Zone = node.Labels[core_v1.LabelTopologyZone]
+			}
+		}
+	}
+}
+
 func (s *ServiceDetails) SetRegistryEndpoints(rEps []*kubernetes.RegistryEndpoint) {
 	for i, p := range s.Service.Ports {
 		istioProtocol, istioMtls := filterRegistryEndpointTLSName(rEps, p.Name, uint32(p.Port))
@@ -191,6 +273,89 @@ func (s *ServiceDetails) SetIstioSidecar(workloads WorkloadOverviews) {
 	s.IstioSidecar = workloads.HasIstioSidecar()
 }
 
+// SetDestinationRuleSubsets sets the subsets of the DestinationRules of the service, flagging the ones used
+// by the route destinations of the VirtualServices. The VirtualServices and DestinationRules must be set first.
+// A subset is used when a destination refers to it on the host of its DestinationRule, as the subsets of
+// different hosts can have the same name.
+func (s *ServiceDetails) SetDestinationRuleSubsets(namespace, service string) {
+	usedSubsets := map[hostSubset]bool{}
+	for _, vs := range s.VirtualServices {
+		for _, dest := range routeDestinations(vs) {
+			if dest.Subset != "" && kubernetes.FilterByHost(dest.Host, vs.Namespace, service, namespace) {
+				usedSubsets[hostSubset{host: kubernetes.GetHost(dest.Host, vs.Namespace, nil).String(), subset: dest.Subset}] = true
+			}
+		}
+	}
+
+	s.DestinationRuleSubsets = []DestinationRuleSubsets{}
+	for _, dr := range s.DestinationRules {
+		drHost := kubernetes.GetHost(dr.Spec.Host, dr.Namespace, nil).String()
+		drSubsets := DestinationRuleSubsets{Name: dr.Name, Namespace: dr.Namespace, Subsets: []SubsetUsage{}}
+		for _, subset := range dr.Spec.Subsets {
+			if subset == nil {
+				continue
+			}
+			drSubsets.Subsets = append(drSubsets.Subsets, SubsetUsage{Name: subset.Name, Used: subsetUsed(usedSubsets, drHost, subset.Name)})
+		}
+		s.DestinationRuleSubsets = append(s.DestinationRuleSubsets, drSubsets)
+	}
+}
+
+// hostSubset is a subset of the DestinationRules of a host
+type hostSubset struct {
+	host   string
+	subset string
+}
+
+// subsetUsed tells whether a subset of a DestinationRule host is used, the host can be a wildcard
+func subsetUsed(usedSubsets map[hostSubset]bool, host, subset string) bool {
+	if usedSubsets[hostSubset{host: host, subset: subset}] {
+		return true
+	}
+	for used := range usedSubsets {
+		if used.subset == subset && kubernetes.HostWithinWildcardHost(used.host, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// routeDestinations returns the destinations of the HTTP, TCP and TLS routes of the VirtualService
+func routeDestinations(vs *networking_v1beta1.VirtualService) []*api_networking_v1beta1.Destination {
+	destinations := []*api_networking_v1beta1.Destination{}
+	for _, httpRoute := range vs.Spec.Http {
+		if httpRoute == nil {
+			continue
+		}
+		for _, dest := range httpRoute.Route {
+			if dest != nil && dest.Destination != nil {
+				destinations = append(destinations, dest.Destination)
+			}
+		}
+	}
+	for _, tcpRoute := range vs.Spec.Tcp {
+		if tcpRoute == nil {
+			continue
+		}
+		for _, dest := range tcpRoute.Route {
+			if dest != nil && dest.Destination != nil {
+				destinations = append(destinations, dest.Destination)
+			}
+		}
+	}
+	for _, tlsRoute := range vs.Spec.Tls {
+		if tlsRoute == nil {
+			continue
+		}
+		for _, dest := range tlsRoute.Route {
+			if dest != nil && dest.Destination != nil {
+				destinations = append(destinations, dest.Destination)
+			}
+		}
+	}
+	return destinations
+}
+
 func (s *ServiceList) HasMatchingServices(service string) bool {
 	for _, s := range s.Services {
 		if service == s.Name {
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 
 	core_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -47,6 +49,85 @@ func TestServiceDetailParsing(t *testing.T) {
 			}}})
 }
 
+func TestServiceDetailDestinationRuleSubsets(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	dr := &networking_v1beta1.DestinationRule{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"},
+		Spec: api_networking_v1beta1.DestinationRule{
+			Host: "reviews",
+			Subsets: []*api_networking_v1beta1.Subset{
+				{Name: "v1", Labels: map[string]string{"version": "v1"}},
+				{Name: "v2", Labels: map[string]string{"version": "v2"}},
+			},
+		},
+	}
+	vs := &networking_v1beta1.VirtualService{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"},
+		Spec: api_networking_v1beta1.VirtualService{
+			Hosts: []string{"reviews"},
+			Http: []*api_networking_v1beta1.HTTPRoute{
+				{
+					Route: []*api_networking_v1beta1.HTTPRouteDestination{
+						{Destination: &api_networking_v1beta1.Destination{Host: "reviews.bookinfo.svc.cluster.local", Subset: "v1"}},
+						// A subset with the same name, of another service
+						{Destination: &api_networking_v1beta1.Destination{Host: "ratings", Subset: "v2"}},
+					},
+				},
+			},
+		},
+	}
+
+	// The subsets of the DestinationRules of other hosts are not used, even with the same name
+	otherDR := &networking_v1beta1.DestinationRule{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "travels"},
+		Spec: api_networking_v1beta1.DestinationRule{
+			Host:    "reviews",
+			Subsets: []*api_networking_v1beta1.Subset{{Name: "v1", Labels: map[string]string{"version": "v1"}}},
+		},
+	}
+	wildcardDR := &networking_v1beta1.DestinationRule{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo", Namespace: "bookinfo"},
+		Spec: api_networking_v1beta1.DestinationRule{
+			Host:    "*.bookinfo.svc.cluster.local",
+			Subsets: []*api_networking_v1beta1.Subset{{Name: "v1", Labels: map[string]string{"version": "v1"}}},
+		},
+	}
+
+	service := ServiceDetails{
+		VirtualServices:  []*networking_v1beta1.VirtualService{vs},
+		DestinationRules: []*networking_v1beta1.DestinationRule{dr, otherDR, wildcardDR},
+	}
+	service.SetDestinationRuleSubsets("bookinfo", "reviews")
+
+	assert.Equal([]DestinationRuleSubsets{
+		{
+			Name:      "reviews",
+			Namespace: "bookinfo",
+			Subsets: []SubsetUsage{
+				{Name: "v1", Used: true},
+				{Name: "v2", Used: false},
+			},
+		},
+		{
+			Name:      "reviews",
+			Namespace: "travels",
+			Subsets:   []SubsetUsage{{Name: "v1", Used: false}},
+		},
+		{
+			Name:      "bookinfo",
+			Namespace: "bookinfo",
+			Subsets:   []SubsetUsage{{Name: "v1", Used: true}},
+		},
+	}, service.DestinationRuleSubsets)
+
+	// Without VirtualServices, no subset is used
+	service.VirtualServices = []*networking_v1beta1.VirtualService{}
+	service.SetDestinationRuleSubsets("bookinfo", "reviews")
+	assert.Equal([]SubsetUsage{{Name: "v1"}, {Name: "v2"}}, service.DestinationRuleSubsets[0].Subsets)
+}
+
 func TestServiceParse(t *testing.T) {
 	assert := assert.New(t)
 	config.Set(config.NewConfig())
@@ -0,0 +1,23 @@
+package models
+
+// SidecarEgressBlock is a Sidecar whose egress hosts block the access of the workloads it applies to, to the control
+// plane or to hosts they call
+type SidecarEgressBlock struct {
+	// Sidecar blocking the hosts
+	// required: true
+	Sidecar IstioValidationKey `json:"sidecar"`
+
+	// Workloads of the namespace the Sidecar applies to
+	// required: true
+	// example: ["reviews-v1","reviews-v2"]
+	Workloads []string `json:"workloads"`
+
+	// Host of the control plane, set when it is blocked
+	// example: istiod.istio-system.svc.cluster.local
+	ControlPlaneHost string `json:"controlPlaneHost,omitempty"`
+
+	// Hosts called by the workloads, as reported by the telemetry, that are blocked
+	// required: true
+	// example: ["ratings.bookinfo.svc.cluster.local"]
+	BlockedHosts []string `json:"blockedHosts"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	api_telemetry_v1alpha1 "istio.io/api/telemetry/v1alpha1"
+)
+
+// EffectiveTelemetry is the Telemetry configuration applied to a workload once the mesh, namespace
+// and workload level Telemetry resources are merged.
+type EffectiveTelemetry struct {
+	// Spec holds the merged metrics, tracing and access logging sections. The selector is never set.
+	Spec *api_telemetry_v1alpha1.Telemetry `json:"spec"`
+	// Sources maps each section ("metrics", "tracing", "accessLogging") to the Telemetry providing it,
+	// as "<namespace>/<name>". Sections not configured by any Telemetry are missing.
+	Sources map[string]string `json:"sources"`
+}
+
+// AccessLogging is the access logging applied to the proxies of a workload
+type AccessLogging struct {
+	// Whether the proxies of the workload write access logs
+	// required: true
+	Enabled bool `json:"enabled"`
+	// Providers writing the access logs, "envoy" is the built-in provider of the mesh accessLogFile
+	// required: true
+	Providers []string `json:"providers"`
+	// Source of the setting: "telemetry", "mesh" or "none"
+	// required: true
+	Source string `json:"source"`
+	// Telemetry providing the setting, as "<namespace>/<name>", when the source is "telemetry"
+	Telemetry string `json:"telemetry,omitempty"`
+}
@@ -0,0 +1,40 @@
+package models
+
+const (
+	WasmPluginScopeMesh      = "mesh"
+	WasmPluginScopeNamespace = "namespace"
+	WasmPluginScopeWorkload  = "workload"
+)
+
+// EffectiveWasmPlugin is a WasmPlugin applied by the proxies of a workload, at its position in the filter chain
+type EffectiveWasmPlugin struct {
+	// Name of the WasmPlugin
+	// required: true
+	// example: basic-auth
+	Name string `json:"name"`
+	// Namespace of the WasmPlugin
+	// required: true
+	// example: bookinfo
+	Namespace string `json:"namespace"`
+	// Scope of the WasmPlugin: "mesh" for the root namespace ones without selector, "namespace" for the ones
+	// without selector of the namespace of the workload, "workload" for the ones selecting the workload
+	// required: true
+	// example: workload
+	Scope string `json:"scope"`
+	// Phase of the filter chain where the WasmPlugin is inserted
+	// required: true
+	// example: AUTHN
+	Phase string `json:"phase"`
+	// Priority of the WasmPlugin within its phase, the highest priority is applied first
+	// required: true
+	// example: 10
+	Priority int64 `json:"priority"`
+	// URL of the Wasm module
+	// example: oci://ghcr.io/istio-ecosystem/wasm-extensions/basic_auth:1.12.0
+	Url string `json:"url,omitempty"`
+	// Whether another WasmPlugin applied to the workload has the same phase and priority,
+	// in which case Istio orders them by name and namespace
+	// required: true
+	// example: false
+	Collision bool `json:"collision"`
+}
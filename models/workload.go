@@ -117,6 +117,30 @@ type WorkloadListItem struct {
 
 type WorkloadOverviews []*WorkloadListItem
 
+// ServicelessWorkload is a workload whose labels match no Service selector, so it is not reachable through a Service
+type ServicelessWorkload struct {
+	// Name of the workload
+	// required: true
+	// example: reviews-v1
+	Name string `json:"name"`
+
+	// Cluster name where the workload is located
+	// required: true
+	// example: west-cluster-01
+	Cluster string `json:"cluster"`
+
+	// Type of the workload
+	// required: true
+	// example: Deployment
+	Type string `json:"type"`
+
+	// Define if the workload is a Job or a CronJob, which legitimately have no Service.
+	// Other workloads without a Service are likely unreachable.
+	// required: true
+	// example: false
+	Batch bool `json:"batch"`
+}
+
 // Workload has the details of a workload
 type Workload struct {
 	WorkloadListItem
@@ -153,6 +177,18 @@ type Workload struct {
 	// Ambient waypoint workloads
 	WaypointWorkloads []Workload `json:"waypointWorkloads"`
 
+	// Whether the workload is an ingress gateway, by the Istio ingress gateway labels or by being selected by a Gateway
+	IsIngressGateway bool `json:"isIngressGateway,omitempty"`
+
+	// Hosts of the Gateways bound to the ingress gateway
+	IngressHostnames []string `json:"ingressHostnames,omitempty"`
+
+	// Status of the HorizontalPodAutoscaler scaling the workload, nil when the workload is not autoscaled
+	Autoscaler *WorkloadAutoscaler `json:"autoscaler,omitempty"`
+
+	// ServiceAccounts the pods run as, with the AuthorizationPolicies referencing them as source principals
+	ServiceAccounts []WorkloadServiceAccount `json:"serviceAccounts,omitempty"`
+
 	// Health
 	Health WorkloadHealth `json:"health"`
 }
@@ -160,7 +196,6 @@ type Workload struct {
 type Workloads []*Workload
 
 func (workload *WorkloadListItem) ParseWorkload(w *Workload) {
-	conf := config.Get()
 	workload.Name = w.Name
 	workload.Cluster = w.Cluster
 	workload.Type = w.Type
@@ -180,9 +215,9 @@ func (workload *WorkloadListItem) ParseWorkload(w *Workload) {
 	workload.HealthAnnotations = w.HealthAnnotations
 	workload.IstioReferences = []*IstioValidationKey{}
 
-	/** Check the labels app and version required by Istio in template Pods*/
-	_, workload.AppLabel = w.Labels[conf.IstioLabels.AppLabelName]
-	_, workload.VersionLabel = w.Labels[conf.IstioLabels.VersionLabelName]
+	/** The labels app and version required by Istio are checked when parsing the workload */
+	workload.AppLabel = w.AppLabel
+	workload.VersionLabel = w.VersionLabel
 }
 
 func (workload *Workload) parseObjectMeta(meta *meta_v1.ObjectMeta, tplMeta *meta_v1.ObjectMeta) {
@@ -413,6 +448,12 @@ func (workload *Workload) ParsePods(controllerName string, controllerType string
 	_, workload.VersionLabel = workload.Labels[conf.IstioLabels.VersionLabelName]
 }
 
+// SetIstioLabels checks the labels app and version required by Istio, named by the IstioLabels of the workload namespace
+func (workload *Workload) SetIstioLabels(istioLabels config.IstioLabels) {
+	_, workload.AppLabel = workload.Labels[istioLabels.AppLabelName]
+	_, workload.VersionLabel = workload.Labels[istioLabels.VersionLabelName]
+}
+
 func (workload *Workload) SetPods(pods []core_v1.Pod) {
 	workload.Pods.Parse(pods)
 	workload.IstioSidecar = workload.HasIstioSidecar()
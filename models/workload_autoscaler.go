@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// WorkloadAutoscaler is the status of the HorizontalPodAutoscaler scaling a workload
+type WorkloadAutoscaler struct {
+	// Name of the HorizontalPodAutoscaler
+	// required: true
+	Name string `json:"name"`
+
+	// Lower limit of the replicas, 1 when not set
+	// required: true
+	MinReplicas int32 `json:"minReplicas"`
+
+	// Upper limit of the replicas
+	// required: true
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// Replicas of the workload last seen by the autoscaler
+	// required: true
+	CurrentReplicas int32 `json:"currentReplicas"`
+
+	// Replicas computed by the autoscaler
+	// required: true
+	DesiredReplicas int32 `json:"desiredReplicas"`
+
+	// Metrics the replicas are computed from, with their targets and current values
+	// required: true
+	Metrics []AutoscalerMetric `json:"metrics"`
+
+	// Last time the autoscaler changed the replicas
+	LastScaleTime *time.Time `json:"lastScaleTime,omitempty"`
+
+	// Last rescale event of the autoscaler, nil when the event has expired
+	LastScaleEvent *AutoscalerEvent `json:"lastScaleEvent,omitempty"`
+}
+
+// AutoscalerMetric is a metric of a HorizontalPodAutoscaler
+type AutoscalerMetric struct {
+	// Type of the metric source: Resource, ContainerResource, Pods, Object or External
+	// required: true
+	Type string `json:"type"`
+
+	// Name of the resource or of the metric
+	// required: true
+	// example: cpu
+	Name string `json:"name"`
+
+	// Target of the metric, a utilization percentage, an average value or a value
+	// required: true
+	// example: 80%
+	Target string `json:"target"`
+
+	// Current value of the metric, in the form of its target, empty when unknown
+	// example: 45%
+	Current string `json:"current,omitempty"`
+}
+
+// AutoscalerEvent is an event reported by a HorizontalPodAutoscaler
+type AutoscalerEvent struct {
+	// example: SuccessfulRescale
+	Reason string `json:"reason"`
+	// example: New size: 3; reason: cpu resource utilization (percentage of request) above target
+	Message  string    `json:"message"`
+	LastSeen time.Time `json:"lastSeen"`
+}
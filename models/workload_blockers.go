@@ -0,0 +1,20 @@
+package models
+
+// ProposedWorkload describes a workload not deployed yet
+type ProposedWorkload struct {
+	// Labels of the pods of the workload
+	Labels map[string]string `json:"labels"`
+	// Hosts the workload intends to reach, like "ratings.bookinfo.svc.cluster.local" or "api.example.com"
+	EgressHosts []string `json:"egressHosts"`
+}
+
+// WorkloadBlocker is an Istio object that would break the traffic of a proposed workload
+type WorkloadBlocker struct {
+	// Type of the object, like "sidecar" or "authorizationpolicy"
+	ObjectType string `json:"objectType"`
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace"`
+	// Egress host out of the scope of a Sidecar, empty for the AuthorizationPolicies
+	Host   string `json:"host,omitempty"`
+	Reason string `json:"reason"`
+}
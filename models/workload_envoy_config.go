@@ -0,0 +1,189 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/kiali/kiali/kubernetes"
+)
+
+const (
+	EnvoyProxySidecar = "sidecar"
+	EnvoyProxyGateway = "gateway"
+)
+
+// WorkloadEnvoyConfig is the Envoy configuration of the proxy of a pod of a workload, simplified from its config dump
+type WorkloadEnvoyConfig struct {
+	// Name of the pod whose proxy config dump was parsed
+	// required: true
+	// example: reviews-v1-5f7d8c6b4-xk2lp
+	Pod string `json:"pod"`
+
+	// Type of the proxy, sidecar or gateway. Empty when the config dump doesn't tell
+	// example: sidecar
+	ProxyType string `json:"proxyType"`
+
+	// Clusters of the proxy with their endpoints
+	// required: true
+	Clusters []EnvoyConfigCluster `json:"clusters"`
+
+	// Listeners of the proxy with their filter chains
+	// required: true
+	Listeners []EnvoyConfigListener `json:"listeners"`
+
+	// Routes of the proxy
+	// required: true
+	Routes Routes `json:"routes"`
+}
+
+// EnvoyConfigCluster is an Envoy cluster and the endpoints the proxy knows for it
+type EnvoyConfigCluster struct {
+	Cluster
+
+	// Name of the Envoy cluster
+	// required: true
+	// example: outbound|9080||reviews.bookinfo.svc.cluster.local
+	Name string `json:"name"`
+
+	// Endpoints of the cluster, only known for the EDS clusters
+	// required: true
+	Endpoints []EnvoyConfigEndpoint `json:"endpoints"`
+}
+
+// EnvoyConfigEndpoint is an endpoint of an Envoy cluster with its health as seen by the proxy
+type EnvoyConfigEndpoint struct {
+	// example: 10.0.0.1
+	Address string `json:"address"`
+
+	// example: 9080
+	Port int `json:"port"`
+
+	// Health status reported by Envoy, UNKNOWN when it is not reported
+	// example: HEALTHY
+	HealthStatus string `json:"healthStatus"`
+}
+
+// EnvoyConfigListener is an Envoy listener with its filter chains
+type EnvoyConfigListener struct {
+	// example: 0.0.0.0_9080
+	Name string `json:"name"`
+
+	// example: 0.0.0.0
+	Address string `json:"address"`
+
+	// example: 9080
+	Port int `json:"port"`
+
+	// required: true
+	FilterChains []EnvoyConfigFilterChain `json:"filterChains"`
+}
+
+// EnvoyConfigFilterChain summarizes what a filter chain matches and where the traffic goes
+type EnvoyConfigFilterChain struct {
+	// example: Addr: *:9080
+	Match string `json:"match"`
+
+	// example: Route: 9080
+	Destination string `json:"destination"`
+}
+
+// Parse extracts the clusters, listeners and routes of the config dump of a sidecar or a gateway proxy.
+// The namespaces are used to shorten the domains of the routes.
+func (wec *WorkloadEnvoyConfig) Parse(dump *kubernetes.ConfigDump, namespaces []string) error {
+	wec.ProxyType = proxyType(dump)
+
+	if err := wec.parseClusters(dump); err != nil {
+		return err
+	}
+
+	listenersDump, err := dump.GetListeners()
+	if err != nil {
+		return err
+	}
+	wec.Listeners = []EnvoyConfigListener{}
+	listeners := make([]kubernetes.EnvoyListener, 0, len(listenersDump.DynamicListeners)+len(listenersDump.StaticListeners))
+	for _, dynamicListener := range listenersDump.DynamicListeners {
+		listeners = append(listeners, dynamicListener.ActiveState.Listener)
+	}
+	for _, staticListener := range listenersDump.StaticListeners {
+		listeners = append(listeners, staticListener.Listener)
+	}
+	for _, listener := range listeners {
+		l := EnvoyConfigListener{
+			Name:         listener.Name,
+			Address:      listener.Address.SocketAddress.Address,
+			Port:         int(listener.Address.SocketAddress.PortValue),
+			FilterChains: []EnvoyConfigFilterChain{},
+		}
+		for _, match := range listenerMatches(listener) {
+			l.FilterChains = append(l.FilterChains, EnvoyConfigFilterChain{
+				Match:       match["match"].(string),
+				Destination: match["destination"].(string),
+			})
+		}
+		wec.Listeners = append(wec.Listeners, l)
+	}
+
+	wec.Routes = Routes{}
+	return wec.Routes.Parse(dump, namespaces)
+}
+
+func (wec *WorkloadEnvoyConfig) parseClusters(dump *kubernetes.ConfigDump) error {
+	clusterDump, err := dump.GetClusters()
+	if err != nil {
+		return err
+	}
+	endpointDump, err := dump.GetEndpoints()
+	if err != nil {
+		return err
+	}
+
+	endpoints := map[string][]EnvoyConfigEndpoint{}
+	for _, endpointConfigs := range [][]kubernetes.EnvoyEndpointConfig{endpointDump.DynamicEndpointConfigs, endpointDump.StaticEndpointConfigs} {
+		for _, endpointConfig := range endpointConfigs {
+			clusterName := endpointConfig.EndpointConfig.ClusterName
+			for _, locality := range endpointConfig.EndpointConfig.Endpoints {
+				for _, lbEndpoint := range locality.LbEndpoints {
+					healthStatus := lbEndpoint.HealthStatus
+					if healthStatus == "" {
+						healthStatus = "UNKNOWN"
+					}
+					socketAddress := lbEndpoint.Endpoint.Address.SocketAddress
+					endpoints[clusterName] = append(endpoints[clusterName], EnvoyConfigEndpoint{
+						Address:      socketAddress.Address,
+						Port:         int(socketAddress.PortValue),
+						HealthStatus: healthStatus,
+					})
+				}
+			}
+		}
+	}
+
+	wec.Clusters = []EnvoyConfigCluster{}
+	for _, clusterSet := range [][]kubernetes.EnvoyClusterWrapper{clusterDump.DynamicClusters, clusterDump.StaticClusters} {
+		for _, wrapper := range clusterSet {
+			c := EnvoyConfigCluster{Name: wrapper.Cluster.Name, Endpoints: endpoints[wrapper.Cluster.Name]}
+			c.Cluster.Parse(wrapper.Cluster)
+			if c.Endpoints == nil {
+				c.Endpoints = []EnvoyConfigEndpoint{}
+			}
+			wec.Clusters = append(wec.Clusters, c)
+		}
+	}
+	return nil
+}
+
+// proxyType tells a sidecar from a gateway by the node id of the bootstrap, like "router~10.0.0.1~pod.ns~ns.svc.cluster.local"
+func proxyType(dump *kubernetes.ConfigDump) string {
+	bootstrap := dump.GetConfig("type.googleapis.com/envoy.admin.v3.BootstrapConfigDump")
+	b, _ := bootstrap["bootstrap"].(map[string]interface{})
+	node, _ := b["node"].(map[string]interface{})
+	id, _ := node["id"].(string)
+	switch strings.SplitN(id, "~", 2)[0] {
+	case "sidecar":
+		return EnvoyProxySidecar
+	case "router":
+		return EnvoyProxyGateway
+	default:
+		return ""
+	}
+}
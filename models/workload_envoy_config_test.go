@@ -0,0 +1,273 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+)
+
+const sidecarConfigDumpJSON = `{
+  "configs": [
+    {
+      "@type": "type.googleapis.com/envoy.admin.v3.BootstrapConfigDump",
+      "bootstrap": {"node": {"id": "sidecar~10.0.0.1~productpage-v1-6b746f74dc-9stvs.bookinfo~bookinfo.svc.cluster.local"}}
+    },
+    {
+      "@type": "type.googleapis.com/envoy.admin.v3.ClustersConfigDump",
+      "static_clusters": [
+        {"cluster": {"name": "prometheus_stats", "type": "STATIC"}}
+      ],
+      "dynamic_active_clusters": [
+        {
+          "cluster": {
+            "name": "outbound|9080||reviews.bookinfo.svc.cluster.local",
+            "type": "EDS",
+            "metadata": {"filter_metadata": {"istio": {"config": "/apis/networking.istio.io/v1alpha3/namespaces/bookinfo/destination-rule/reviews"}}}
+          }
+        },
+        {"cluster": {"name": "inbound|9080||", "type": "ORIGINAL_DST"}},
+        {"cluster": {"name": "PassthroughCluster", "type": "ORIGINAL_DST"}}
+      ]
+    },
+    {
+      "@type": "type.googleapis.com/envoy.admin.v3.ListenersConfigDump",
+      "dynamic_listeners": [
+        {
+          "name": "0.0.0.0_9080",
+          "active_state": {
+            "listener": {
+              "name": "0.0.0.0_9080",
+              "address": {"socket_address": {"address": "0.0.0.0", "port_value": 9080}},
+              "filter_chains": [
+                {
+                  "filter_chain_match": {"application_protocols": ["http/1.0", "http/1.1", "h2c"]},
+                  "filters": [
+                    {
+                      "name": "envoy.filters.network.http_connection_manager",
+                      "typed_config": {"rds": {"route_config_name": "9080"}}
+                    }
+                  ]
+                }
+              ],
+              "default_filter_chain": {
+                "filters": [
+                  {
+                    "name": "envoy.filters.network.tcp_proxy",
+                    "typed_config": {"cluster": "PassthroughCluster"}
+                  }
+                ]
+              }
+            }
+          }
+        }
+      ]
+    },
+    {
+      "@type": "type.googleapis.com/envoy.admin.v3.RoutesConfigDump",
+      "dynamic_route_configs": [
+        {
+          "route_config": {
+            "name": "9080",
+            "virtual_hosts": [
+              {
+                "name": "reviews.bookinfo.svc.cluster.local:9080",
+                "domains": ["reviews.bookinfo.svc.cluster.local", "reviews", "reviews.bookinfo.svc", "10.96.0.10"],
+                "routes": [
+                  {
+                    "name": "default",
+                    "match": {"prefix": "/"},
+                    "route": {"cluster": "outbound|9080||reviews.bookinfo.svc.cluster.local"}
+                  }
+                ]
+              }
+            ]
+          }
+        }
+      ]
+    },
+    {
+      "@type": "type.googleapis.com/envoy.admin.v3.EndpointsConfigDump",
+      "dynamic_endpoint_configs": [
+        {
+          "endpoint_config": {
+            "cluster_name": "outbound|9080||reviews.bookinfo.svc.cluster.local",
+            "endpoints": [
+              {
+                "lb_endpoints": [
+                  {"endpoint": {"address": {"socket_address": {"address": "10.0.0.2", "port_value": 9080}}}, "health_status": "HEALTHY"},
+                  {"endpoint": {"address": {"socket_address": {"address": "10.0.0.3", "port_value": 9080}}}}
+                ]
+              }
+            ]
+          }
+        }
+      ]
+    }
+  ]
+}`
+
+const gatewayConfigDumpJSON = `{
+  "configs": [
+    {
+      "@type": "type.googleapis.com/envoy.admin.v3.BootstrapConfigDump",
+      "bootstrap": {"node": {"id": "router~10.0.0.9~istio-ingressgateway-7d8b9c5f4-abcde.istio-system~istio-system.svc.cluster.local"}}
+    },
+    {
+      "@type": "type.googleapis.com/envoy.admin.v3.ClustersConfigDump",
+      "dynamic_active_clusters": [
+        {"cluster": {"name": "outbound|9080||productpage.bookinfo.svc.cluster.local", "type": "EDS"}}
+      ]
+    },
+    {
+      "@type": "type.googleapis.com/envoy.admin.v3.ListenersConfigDump",
+      "dynamic_listeners": [
+        {
+          "name": "0.0.0.0_8080",
+          "active_state": {
+            "listener": {
+              "name": "0.0.0.0_8080",
+              "address": {"socket_address": {"address": "0.0.0.0", "port_value": 8080}},
+              "filter_chains": [
+                {
+                  "filters": [
+                    {
+                      "name": "envoy.filters.network.http_connection_manager",
+                      "typed_config": {"rds": {"route_config_name": "http.8080"}}
+                    }
+                  ]
+                }
+              ]
+            }
+          }
+        }
+      ]
+    },
+    {
+      "@type": "type.googleapis.com/envoy.admin.v3.RoutesConfigDump",
+      "dynamic_route_configs": [
+        {
+          "route_config": {
+            "name": "http.8080",
+            "virtual_hosts": [
+              {
+                "name": "bookinfo.example.com:80",
+                "domains": ["bookinfo.example.com"],
+                "routes": [
+                  {
+                    "match": {"prefix": "/productpage"},
+                    "metadata": {"filter_metadata": {"istio": {"config": "/apis/networking.istio.io/v1alpha3/namespaces/bookinfo/virtual-service/bookinfo"}}},
+                    "route": {"cluster": "outbound|9080||productpage.bookinfo.svc.cluster.local"}
+                  }
+                ]
+              }
+            ]
+          }
+        }
+      ]
+    },
+    {
+      "@type": "type.googleapis.com/envoy.admin.v3.EndpointsConfigDump",
+      "dynamic_endpoint_configs": [
+        {
+          "endpoint_config": {
+            "cluster_name": "outbound|9080||productpage.bookinfo.svc.cluster.local",
+            "endpoints": [
+              {
+                "lb_endpoints": [
+                  {"endpoint": {"address": {"socket_address": {"address": "10.0.0.1", "port_value": 9080}}}, "health_status": "HEALTHY"}
+                ]
+              }
+            ]
+          }
+        }
+      ]
+    }
+  ]
+}`
+
+func parseEnvoyConfig(t *testing.T, dumpJSON string) WorkloadEnvoyConfig {
+	t.Helper()
+	config.Set(config.NewConfig())
+	var dump kubernetes.ConfigDump
+	require.NoError(t, json.Unmarshal([]byte(dumpJSON), &dump))
+	envoyConfig := WorkloadEnvoyConfig{}
+	require.NoError(t, envoyConfig.Parse(&dump, []string{"bookinfo", "istio-system"}))
+	return envoyConfig
+}
+
+func TestParseSidecarEnvoyConfig(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	envoyConfig := parseEnvoyConfig(t, sidecarConfigDumpJSON)
+	assert.Equal(EnvoyProxySidecar, envoyConfig.ProxyType)
+
+	require.Len(envoyConfig.Clusters, 4)
+	reviews := envoyConfig.Clusters[0]
+	assert.Equal("outbound|9080||reviews.bookinfo.svc.cluster.local", reviews.Name)
+	assert.Equal("reviews", reviews.ServiceFQDN.Service)
+	assert.Equal("bookinfo", reviews.ServiceFQDN.Namespace)
+	assert.Equal(9080, reviews.Port)
+	assert.Equal("outbound", reviews.Direction)
+	assert.Equal("EDS", reviews.Type)
+	assert.Equal("reviews.bookinfo", reviews.DestinationRule)
+	assert.Equal([]EnvoyConfigEndpoint{
+		{Address: "10.0.0.2", Port: 9080, HealthStatus: "HEALTHY"},
+		{Address: "10.0.0.3", Port: 9080, HealthStatus: "UNKNOWN"},
+	}, reviews.Endpoints)
+	assert.Equal("inbound", envoyConfig.Clusters[1].Direction)
+	assert.Empty(envoyConfig.Clusters[1].Endpoints)
+	assert.Equal("prometheus_stats", envoyConfig.Clusters[3].Name)
+
+	require.Len(envoyConfig.Listeners, 1)
+	assert.Equal(EnvoyConfigListener{
+		Name:    "0.0.0.0_9080",
+		Address: "0.0.0.0",
+		Port:    9080,
+		FilterChains: []EnvoyConfigFilterChain{
+			{Match: "App: HTTP", Destination: "Route: 9080"},
+			{Match: "ALL", Destination: "PassthroughCluster"},
+		},
+	}, envoyConfig.Listeners[0])
+
+	require.Len(envoyConfig.Routes, 1)
+	assert.Equal("9080", envoyConfig.Routes[0].Name)
+	assert.Equal("reviews", envoyConfig.Routes[0].Domains.Service)
+	assert.Equal("/*", envoyConfig.Routes[0].Match)
+}
+
+func TestParseGatewayEnvoyConfig(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	envoyConfig := parseEnvoyConfig(t, gatewayConfigDumpJSON)
+	assert.Equal(EnvoyProxyGateway, envoyConfig.ProxyType)
+
+	require.Len(envoyConfig.Clusters, 1)
+	assert.Equal("productpage", envoyConfig.Clusters[0].ServiceFQDN.Service)
+	assert.Equal([]EnvoyConfigEndpoint{{Address: "10.0.0.1", Port: 9080, HealthStatus: "HEALTHY"}}, envoyConfig.Clusters[0].Endpoints)
+
+	require.Len(envoyConfig.Listeners, 1)
+	assert.Equal("0.0.0.0_8080", envoyConfig.Listeners[0].Name)
+	assert.Equal(8080, envoyConfig.Listeners[0].Port)
+	assert.Equal([]EnvoyConfigFilterChain{{Match: "ALL", Destination: "Route: http.8080"}}, envoyConfig.Listeners[0].FilterChains)
+
+	require.Len(envoyConfig.Routes, 1)
+	assert.Equal("http.8080", envoyConfig.Routes[0].Name)
+	assert.Equal("bookinfo.example.com", envoyConfig.Routes[0].Domains.Service)
+	assert.Equal("/productpage*", envoyConfig.Routes[0].Match)
+	assert.Equal("bookinfo.bookinfo", envoyConfig.Routes[0].VirtualService)
+}
+
+func TestParseEnvoyConfigUnknownProxyType(t *testing.T) {
+	envoyConfig := parseEnvoyConfig(t, `{"configs": []}`)
+	assert.Empty(t, envoyConfig.ProxyType)
+	assert.Empty(t, envoyConfig.Clusters)
+	assert.Empty(t, envoyConfig.Listeners)
+	assert.Empty(t, envoyConfig.Routes)
+}
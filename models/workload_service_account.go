@@ -0,0 +1,41 @@
+package models
+
+// WorkloadServiceAccount is a ServiceAccount the pods of a workload run as, with the AuthorizationPolicies
+// referencing its identity as source principal
+type WorkloadServiceAccount struct {
+	// Name of the ServiceAccount
+	// required: true
+	// example: bookinfo-reviews
+	Name string `json:"name"`
+
+	// Identity of the workload in the mesh, as matched by the source principals of the AuthorizationPolicies
+	// required: true
+	// example: cluster.local/ns/bookinfo/sa/bookinfo-reviews
+	Principal string `json:"principal"`
+
+	// Source principals of the AuthorizationPolicies matching the identity
+	// required: true
+	AuthorizationPolicies []AuthorizationPrincipalMatch `json:"authorizationPolicies"`
+}
+
+// AuthorizationPrincipalMatch is a source principal of an AuthorizationPolicy matching the identity of a ServiceAccount
+type AuthorizationPrincipalMatch struct {
+	// AuthorizationPolicy of the principal
+	// required: true
+	AuthorizationPolicy IstioValidationKey `json:"authorizationPolicy"`
+
+	// Action of the AuthorizationPolicy on the requests from the identity
+	// required: true
+	// example: ALLOW
+	Action string `json:"action"`
+
+	// Principal matching the identity, possibly a prefix, suffix or presence match
+	// required: true
+	// example: cluster.local/ns/bookinfo/*
+	Principal string `json:"principal"`
+
+	// Path of the principal in the AuthorizationPolicy
+	// required: true
+	// example: spec/rules[0]/from[0]/source/principals[0]
+	Path string `json:"path"`
+}
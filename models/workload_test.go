@@ -50,6 +50,23 @@ func TestParseDeploymentToWorkload(t *testing.T) {
 	assert.Equal("value-annot-1", w.AdditionalDetails[1].Value)
 }
 
+func TestParseDeploymentToWorkloadCustomVersionLabel(t *testing.T) {
+	assert := assert.New(t)
+	cfg := config.NewConfig()
+	cfg.IstioLabels.VersionLabelName = "app.kubernetes.io/version"
+	config.Set(cfg)
+
+	deployment := fakeDeployment()
+	w := Workload{}
+	w.ParseDeployment(deployment)
+	assert.False(w.VersionLabel)
+
+	deployment.Spec.Template.Labels = map[string]string{"foo": "bar", "app.kubernetes.io/version": "v1"}
+	w = Workload{}
+	w.ParseDeployment(deployment)
+	assert.True(w.VersionLabel)
+}
+
 func TestParseReplicaSetToWorkload(t *testing.T) {
 	assert := assert.New(t)
 	cfg := config.NewConfig()
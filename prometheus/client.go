@@ -6,6 +6,7 @@ import (
 	"net"
 	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -106,6 +107,12 @@ func NewClientForConfig(cfg config.PrometheusConfig) (*Client, error) {
 		return nil, err
 	}
 	clientConfig.RoundTripper = transportConfig
+	if cfg.PartialResponse != "" {
+		if _, err := strconv.ParseBool(cfg.PartialResponse); err != nil {
+			return nil, fmt.Errorf("invalid partial_response [%s], expected true or false: %s", cfg.PartialResponse, err)
+		}
+		clientConfig.RoundTripper = &partialResponseRoundTripper{RoundTripper: transportConfig, partialResponse: cfg.PartialResponse}
+	}
 
 	p8s, err := api.NewClient(clientConfig)
 	if err != nil {
@@ -115,6 +122,23 @@ func NewClientForConfig(cfg config.PrometheusConfig) (*Client, error) {
 	return &client, nil
 }
 
+// partialResponseRoundTripper sets the partial_response parameter of the instant and range queries, used by Thanos and Cortex
+// to either return the available data or fail when some of their stores fail
+type partialResponseRoundTripper struct {
+	http.RoundTripper
+	partialResponse string
+}
+
+func (rt *partialResponseRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if strings.HasSuffix(req.URL.Path, "/api/v1/query") || strings.HasSuffix(req.URL.Path, "/api/v1/query_range") {
+		req = req.Clone(req.Context())
+		query := req.URL.Query()
+		query.Set("partial_response", rt.partialResponse)
+		req.URL.RawQuery = query.Encode()
+	}
+	return rt.RoundTripper.RoundTrip(req)
+}
+
 // Inject allows for replacing the API with a mock For testing
 func (in *Client) Inject(api prom_v1.API) {
 	in.api = api
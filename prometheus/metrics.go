@@ -10,6 +10,7 @@ import (
 	"github.com/prometheus/common/model"
 	"k8s.io/apimachinery/pkg/api/errors"
 
+	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/log"
 	"github.com/kiali/kiali/prometheus/internalmetrics"
 )
@@ -30,16 +31,29 @@ func fetchRateRange(ctx context.Context, api prom_v1.API, metricName string, lab
 	if len(labels) > 1 {
 		query = fmt.Sprintf("(%s)", query)
 	}
-	return fetchRange(ctx, api, query, q.Range)
+	metric := fetchRange(ctx, api, query, q.Range)
+	if q.Exemplars && metric.Err == nil {
+		metric.Exemplars = fetchExemplars(ctx, api, query, q.Range)
+	}
+	return metric
 }
 
 func fetchHistogramRange(ctx context.Context, api prom_v1.API, metricName, labels, grouping string, q *RangeQuery) Histogram {
 	// Note: the p8s queries are not run in parallel here, but they are at the caller's place.
 	//	This is because we may not want to create too many threads in the lowest layer
 	queries := buildHistogramQueries(metricName, labels, grouping, q.RateInterval, q.Avg, q.Quantiles)
+	var exemplars []prom_v1.ExemplarQueryResult
+	if q.Exemplars {
+		// The exemplars are attached to the buckets, they are the same for all the statistics
+		exemplars = fetchExemplars(ctx, api, metricName+"_bucket"+labels, q.Range)
+	}
 	histogram := make(Histogram, len(queries))
 	for k, query := range queries {
-		histogram[k] = fetchRange(ctx, api, query, q.Range)
+		metric := fetchRange(ctx, api, query, q.Range)
+		if metric.Err == nil {
+			metric.Exemplars = exemplars
+		}
+		histogram[k] = metric
 	}
 	return histogram
 }
@@ -107,25 +121,37 @@ func fetchRange(ctx context.Context, api prom_v1.API, query string, bounds prom_
 	return Metric{Err: fmt.Errorf("invalid query, matrix expected: %s", query)}
 }
 
+// fetchExemplars fetches the exemplars of the series selected by a query in given range. Not all the backends store exemplars,
+// so the failures are only logged and the series are returned without exemplars.
+func fetchExemplars(ctx context.Context, api prom_v1.API, query string, bounds prom_v1.Range) []prom_v1.ExemplarQueryResult {
+	log.Tracef("[Prom] fetchExemplars: %s", query)
+	exemplars, err := api.QueryExemplars(ctx, query, bounds.Start, bounds.End)
+	if err != nil {
+		log.Debugf("fetchExemplars. Cannot fetch the exemplars of [%s]: %v", query, err)
+		return nil
+	}
+	return exemplars
+}
+
 // getAllRequestRates retrieves traffic rates for requests entering, internal to, or exiting the namespace.
 // Note that it does not discriminate on "reporter", so rates can be inflated due to duplication, and therefore
 // should be used mainly for calculating ratios (e.g total rates / error rates)
 func getAllRequestRates(ctx context.Context, api prom_v1.API, namespace, cluster string, queryTime time.Time, ratesInterval string) (model.Vector, error) {
 	// traffic originating outside the namespace to destinations inside the namespace
 	lbl := fmt.Sprintf(`destination_service_namespace="%s",source_workload_namespace!="%s",destination_cluster="%s"`, namespace, namespace, cluster)
-	fromOutside, err := getRequestRatesForLabel(ctx, api, queryTime, lbl, ratesInterval)
-	if err != nil {
-		return model.Vector{}, err
+	fromOutside, outsideErr := getRequestRatesForLabel(ctx, api, queryTime, lbl, ratesInterval)
+	if outsideErr != nil && !IsPartialData(outsideErr) {
+		return model.Vector{}, outsideErr
 	}
 	// traffic originating inside the namespace to destinations inside or outside the namespace
 	lbl = fmt.Sprintf(`source_workload_namespace="%s",source_cluster="%s"`, namespace, cluster)
-	fromInside, err := getRequestRatesForLabel(ctx, api, queryTime, lbl, ratesInterval)
-	if err != nil {
-		return model.Vector{}, err
+	fromInside, insideErr := getRequestRatesForLabel(ctx, api, queryTime, lbl, ratesInterval)
+	if insideErr != nil && !IsPartialData(insideErr) {
+		return model.Vector{}, insideErr
 	}
 	// Merge results
 	all := append(fromOutside, fromInside...)
-	return all, nil
+	return all, firstPartialData(outsideErr, insideErr)
 }
 
 // getNamespaceServicesRequestRates retrieves traffic rates for requests entering or internal to the namespace.
@@ -135,10 +161,10 @@ func getNamespaceServicesRequestRates(ctx context.Context, api prom_v1.API, name
 	// traffic for the namespace services
 	lblNs := fmt.Sprintf(`destination_service_namespace="%s",destination_cluster="%s"`, namespace, cluster)
 	ns, err := getRequestRatesForLabel(ctx, api, queryTime, lblNs, ratesInterval)
-	if err != nil {
+	if err != nil && !IsPartialData(err) {
 		return model.Vector{}, err
 	}
-	return ns, nil
+	return ns, err
 }
 
 // getServiceRequestRates retrieves traffic rates for requests entering, or internal to the namespace, for a specific service name
@@ -147,10 +173,10 @@ func getNamespaceServicesRequestRates(ctx context.Context, api prom_v1.API, name
 func getServiceRequestRates(ctx context.Context, api prom_v1.API, namespace, cluster, service string, queryTime time.Time, ratesInterval string) (model.Vector, error) {
 	lbl := fmt.Sprintf(`destination_service_name="%s",destination_service_namespace="%s",destination_cluster="%s"`, service, namespace, cluster)
 	in, err := getRequestRatesForLabel(ctx, api, queryTime, lbl, ratesInterval)
-	if err != nil {
+	if err != nil && !IsPartialData(err) {
 		return model.Vector{}, err
 	}
-	return in, nil
+	return in, err
 }
 
 // getItemRequestRates retrieves traffic rates for requests entering, internal to, or exiting the namespace, for a specific destinatation_<itemLabelSuffix> value
@@ -159,15 +185,25 @@ func getServiceRequestRates(ctx context.Context, api prom_v1.API, namespace, clu
 func getItemRequestRates(ctx context.Context, api prom_v1.API, namespace, cluster, item, itemLabelSuffix string, queryTime time.Time, ratesInterval string) (model.Vector, model.Vector, error) {
 	lblIn := fmt.Sprintf(`destination_workload_namespace="%s",destination_%s="%s",destination_cluster="%s"`, namespace, itemLabelSuffix, item, cluster)
 	lblOut := fmt.Sprintf(`source_workload_namespace="%s",source_%s="%s",source_cluster="%s"`, namespace, itemLabelSuffix, item, cluster)
-	in, err := getRequestRatesForLabel(ctx, api, queryTime, lblIn, ratesInterval)
-	if err != nil {
-		return model.Vector{}, model.Vector{}, err
+	in, inErr := getRequestRatesForLabel(ctx, api, queryTime, lblIn, ratesInterval)
+	if inErr != nil && !IsPartialData(inErr) {
+		return model.Vector{}, model.Vector{}, inErr
 	}
-	out, err := getRequestRatesForLabel(ctx, api, queryTime, lblOut, ratesInterval)
-	if err != nil {
-		return model.Vector{}, model.Vector{}, err
+	out, outErr := getRequestRatesForLabel(ctx, api, queryTime, lblOut, ratesInterval)
+	if outErr != nil && !IsPartialData(outErr) {
+		return model.Vector{}, model.Vector{}, outErr
 	}
-	return in, out, nil
+	return in, out, firstPartialData(inErr, outErr)
+}
+
+// firstPartialData returns the first PartialDataError of the queries merged in a result, nil if all the results are complete
+func firstPartialData(errs ...error) error {
+	for _, err := range errs {
+		if IsPartialData(err) {
+			return err
+		}
+	}
+	return nil
 }
 
 func getRequestRatesForLabel(ctx context.Context, api prom_v1.API, time time.Time, labels, ratesInterval string) (model.Vector, error) {
@@ -182,5 +218,32 @@ func getRequestRatesForLabel(ctx context.Context, api prom_v1.API, time time.Tim
 		return model.Vector{}, errors.NewServiceUnavailable(err.Error())
 	}
 	promtimer.ObserveDuration() // notice we only collect metrics for successful prom queries
-	return result.(model.Vector), nil
+	rates := result.(model.Vector)
+	if maxSamples := config.Get().ExternalServices.Prometheus.MaxSamples; maxSamples > 0 && len(rates) > maxSamples {
+		log.Warningf("getRequestRatesForLabel. Results truncated from [%d] to the max samples [%d] for query [%s]", len(rates), maxSamples, query)
+		return rates[:maxSamples], &PartialDataError{Reason: fmt.Sprintf("results truncated to the max samples %d", maxSamples)}
+	}
+	if partial := partialDataWarnings(warnings); len(partial) > 0 {
+		return rates, &PartialDataError{Reason: strings.Join(partial, ",")}
+	}
+	if len(warnings) > 0 {
+		log.Debugf("getRequestRatesForLabel. Warnings for query [%s]: %s", query, strings.Join(warnings, ","))
+	}
+	return rates, nil
+}
+
+// partialDataWarnings returns the warnings reporting results that miss some data. Other warnings, like the PromQL
+// annotations of Prometheus, don't affect the completeness of the results.
+func partialDataWarnings(warnings prom_v1.Warnings) []string {
+	partial := []string{}
+	for _, warning := range warnings {
+		lower := strings.ToLower(warning)
+		for _, fragment := range partialDataWarningFragments {
+			if strings.Contains(lower, fragment) {
+				partial = append(partial, warning)
+				break
+			}
+		}
+	}
+	return partial
 }
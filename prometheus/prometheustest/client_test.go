@@ -1,6 +1,8 @@
 package prometheustest
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -8,6 +10,7 @@ import (
 	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/prometheus"
@@ -111,6 +114,79 @@ func TestGetNamespaceServicesRequestRates(t *testing.T) {
 	assert.Equal(t, vectorQ1[0], rates[0])
 }
 
+func TestGetServiceRequestRatesPartialResponse(t *testing.T) {
+	client, api, err := setupMocked()
+	require.NoError(t, err)
+
+	queryTime := time.Date(2017, 01, 15, 0, 0, 0, 0, time.UTC)
+	vector := model.Vector{
+		&model.Sample{
+			Timestamp: model.Now(),
+			Value:     model.SampleValue(1),
+			Metric:    model.Metric{"foo": "bar"},
+		},
+	}
+	api.OnQueryTimeWithWarnings(`rate(istio_requests_total{destination_service_name="partial",destination_service_namespace="ns",destination_cluster="east"}[5m]) > 0`, queryTime, vector, prom_v1.Warnings{"No StoreAPIs matched for this query"})
+
+	rates, err := client.GetServiceRequestRates("ns", "east", "partial", "5m", queryTime)
+	assert.True(t, prometheus.IsPartialData(err))
+	assert.Equal(t, vector, rates)
+
+	// The warnings that don't report missing data leave the results complete
+	api.OnQueryTimeWithWarnings(`rate(istio_requests_total{destination_service_name="complete",destination_service_namespace="ns",destination_cluster="east"}[5m]) > 0`, queryTime, vector, prom_v1.Warnings{`PromQL info: metric might not be a counter, name does not end in _total/_sum/_count/_bucket: "foo"`})
+
+	rates, err = client.GetServiceRequestRates("ns", "east", "complete", "5m", queryTime)
+	assert.NoError(t, err)
+	assert.Equal(t, vector, rates)
+}
+
+func TestGetAppRequestRatesMaxSamples(t *testing.T) {
+	client, api, err := setupMocked()
+	require.NoError(t, err)
+	conf := config.NewConfig()
+	conf.ExternalServices.Prometheus.MaxSamples = 1
+	config.Set(conf)
+
+	queryTime := time.Date(2017, 01, 15, 0, 0, 0, 0, time.UTC)
+	vector := model.Vector{
+		&model.Sample{Value: model.SampleValue(1), Metric: model.Metric{"foo": "bar"}},
+		&model.Sample{Value: model.SampleValue(2), Metric: model.Metric{"foo": "baz"}},
+	}
+	api.OnQueryTime(`rate(istio_requests_total{destination_workload_namespace="ns",destination_app="truncated",destination_cluster="east"}[5m]) > 0`, &queryTime, vector)
+	api.OnQueryTime(`rate(istio_requests_total{source_workload_namespace="ns",source_app="truncated",source_cluster="east"}[5m]) > 0`, &queryTime, vector[:1])
+
+	in, out, err := client.GetAppRequestRates("ns", "east", "truncated", "5m", queryTime)
+	assert.True(t, prometheus.IsPartialData(err))
+	assert.Equal(t, vector[:1], in)
+	assert.Equal(t, vector[:1], out)
+}
+
+func TestPartialResponseParameter(t *testing.T) {
+	// A Thanos like backend answering with the available data and a warning
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.FormValue("partial_response"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{"foo":"bar"},"value":[1484438400,"1"]}]},"warnings":["No StoreAPIs matched for this query"]}`))
+	}))
+	defer server.Close()
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Prometheus.URL = server.URL
+	conf.ExternalServices.Prometheus.PartialResponse = "true"
+	config.Set(conf)
+	client, err := prometheus.NewClientForConfig(conf.ExternalServices.Prometheus)
+	require.NoError(t, err)
+
+	queryTime := time.Date(2017, 01, 15, 0, 0, 0, 0, time.UTC)
+	rates, err := client.GetNamespaceServicesRequestRates("thanos", "east", "5m", queryTime)
+	assert.True(t, prometheus.IsPartialData(err))
+	assert.Equal(t, 1, rates.Len())
+
+	conf.ExternalServices.Prometheus.PartialResponse = "maybe"
+	_, err = prometheus.NewClientForConfig(conf.ExternalServices.Prometheus)
+	assert.Error(t, err)
+}
+
 func TestConfig(t *testing.T) {
 	client, api, err := setupMocked()
 	if err != nil {
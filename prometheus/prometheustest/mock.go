@@ -68,12 +68,13 @@ func (o *PromAPIMock) Metadata(ctx context.Context, metric string, limit string)
 
 func (o *PromAPIMock) Query(ctx context.Context, query string, ts time.Time) (model.Value, prom_v1.Warnings, error) {
 	args := o.Called(ctx, query, ts)
-	return args.Get(0).(model.Value), nil, nil
+	warnings, _ := args.Get(1).(prom_v1.Warnings)
+	return args.Get(0).(model.Value), warnings, nil
 }
 
 func (o *PromAPIMock) QueryExemplars(ctx context.Context, query string, startTime time.Time, endTime time.Time) ([]prom_v1.ExemplarQueryResult, error) {
 	args := o.Called(ctx, query, startTime, endTime)
-	return args.Get(0).([]prom_v1.ExemplarQueryResult), nil
+	return args.Get(0).([]prom_v1.ExemplarQueryResult), args.Error(1)
 }
 
 func (o *PromAPIMock) QueryRange(ctx context.Context, query string, r prom_v1.Range) (model.Value, prom_v1.Warnings, error) {
@@ -122,6 +123,11 @@ func (o *PromAPIMock) OnQueryTime(query string, t *time.Time, ret model.Vector)
 	}
 }
 
+// OnQueryTimeWithWarnings mocks a query returning warnings along with the results, like Thanos returning a partial response
+func (o *PromAPIMock) OnQueryTimeWithWarnings(query string, t time.Time, ret model.Vector, warnings prom_v1.Warnings) {
+	o.On("Query", mock.AnythingOfType("*context.emptyCtx"), query, t).Return(ret, warnings)
+}
+
 func (o *PromAPIMock) MockTime(query string, ret model.Vector) {
 	o.OnQueryTime(query, nil, ret)
 }
@@ -134,6 +140,10 @@ func (o *PromAPIMock) OnQueryRange(query string, r *prom_v1.Range, ret model.Mat
 	}
 }
 
+func (o *PromAPIMock) OnQueryExemplars(query string, ret []prom_v1.ExemplarQueryResult, err error) {
+	o.On("QueryExemplars", mock.AnythingOfType("*context.emptyCtx"), query, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(ret, err)
+}
+
 func singleValueMatrix(ret model.SampleValue) model.Matrix {
 	return model.Matrix{
 		&model.SampleStream{
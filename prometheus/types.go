@@ -1,12 +1,42 @@
 package prometheus
 
 import (
+	"errors"
 	"time"
 
 	prom_v1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	"github.com/prometheus/common/model"
+
+	"github.com/kiali/kiali/config"
 )
 
+// PartialDataError is returned along with the results of the request rates queries when they may miss some rates: either the
+// backend warned about missing data, like Thanos or Cortex answering with a partial response when some of their stores fail, or
+// the results exceeded the max samples of the health queries and were truncated. The results are still usable.
+type PartialDataError struct {
+	Reason string
+}
+
+func (e *PartialDataError) Error() string {
+	return "partial data: " + e.Reason
+}
+
+// partialDataWarningFragments are the lower case fragments of the warnings sent by Thanos and Cortex when some of the stores,
+// or ingesters, they query failed or weren't matched and their data is missing from the results
+var partialDataWarningFragments = []string{
+	"partial response",
+	"partial data",
+	"no storeapis matched",
+	"fetch series",
+	"receive series",
+}
+
+// IsPartialData returns true when an error only reports that the results returned with it are partial
+func IsPartialData(err error) bool {
+	var partialDataError *PartialDataError
+	return errors.As(err, &partialDataError)
+}
+
 // RangeQuery holds common parameters for all kinds of range queries
 type RangeQuery struct {
 	prom_v1.Range
@@ -15,14 +45,17 @@ type RangeQuery struct {
 	Quantiles    []string
 	Avg          bool
 	ByLabels     []string
+	// Exemplars tells whether the exemplars of the series are fetched along with them
+	Exemplars bool
 }
 
-// FillDefaults fills the struct with default parameters
+// FillDefaults fills the struct with default parameters, taken from the server's metrics defaults
 func (q *RangeQuery) FillDefaults() {
+	defaults := config.Get().Server.MetricsQueryDefaults
 	q.End = time.Now()
-	q.Start = q.End.Add(-30 * time.Minute)
-	q.Step = 15 * time.Second
-	q.RateInterval = "1m"
+	q.Start = q.End.Add(-time.Duration(defaults.Duration) * time.Second)
+	q.Step = time.Duration(defaults.Step) * time.Second
+	q.RateInterval = defaults.RateInterval
 	q.RateFunc = "rate"
 	q.Avg = true
 }
@@ -36,7 +69,9 @@ type Metrics struct {
 // Metric holds the Prometheus Matrix model, which contains one or more time series (depending on grouping)
 type Metric struct {
 	Matrix model.Matrix `json:"matrix"`
-	Err    error        `json:"-"`
+	// Exemplars of the raw series queried, when requested and stored by the backend
+	Exemplars []prom_v1.ExemplarQueryResult `json:"-"`
+	Err       error                         `json:"-"`
 }
 
 // Histogram contains Metric objects for several histogram-kind statistics
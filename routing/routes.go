@@ -228,13 +228,14 @@ func NewRoutes() (r *Routes) {
 		//
 		// responses:
 		//      500: internalError
+		//      304: notModified
 		//      200: istioConfigList
 		//
 		{
 			"IstioConfigList",
 			"GET",
 			"/api/namespaces/{namespace}/istio",
-			handlers.IstioConfigList,
+			handlers.WithETag(handlers.IstioConfigList),
 			true,
 		},
 		// swagger:route GET /istio config istioConfigListAll
@@ -248,13 +249,14 @@ func NewRoutes() (r *Routes) {
 		//
 		// responses:
 		//      500: internalError
+		//      304: notModified
 		//      200: istioConfigList
 		//
 		{
 			"IstioConfigListAll",
 			"GET",
 			"/api/istio/config",
-			handlers.IstioConfigList,
+			handlers.WithETag(handlers.IstioConfigList),
 			true,
 		},
 		// swagger:route GET /namespaces/{namespace}/istio/{object_type}/{object} config istioConfigDetails
@@ -703,6 +705,46 @@ func NewRoutes() (r *Routes) {
 			handlers.NamespaceList,
 			true,
 		},
+		// swagger:route GET /namespaces/access namespaces namespaceAccess
+		// ---
+		// Endpoint to get, per cluster, the namespaces the user can access and what the user can do in them
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      500: internalError
+		//      200: namespaceAccessResponse
+		//
+		{
+			"NamespaceAccess",
+			"GET",
+			"/api/namespaces/access",
+			handlers.NamespaceAccess,
+			true,
+		},
+		// swagger:route GET /diagnostics/accessible_namespaces diagnostics accessibleNamespacesRBAC
+		// ---
+		// Endpoint to check, for each accessible namespace of the configuration, that the Kiali service account can list the resources Kiali needs
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      500: internalError
+		//      200: accessibleNamespacesRBACResponse
+		//
+		{
+			"AccessibleNamespacesRBAC",
+			"GET",
+			"/api/diagnostics/accessible_namespaces",
+			handlers.AccessibleNamespacesRBAC,
+			true,
+		},
 		// swagger:route PATCH /namespaces/{namespace} namespaces namespaceUpdate
 		// ---
 		// Endpoint to update the Namespace configuration using Json Merge Patch strategy.
@@ -749,6 +791,70 @@ func NewRoutes() (r *Routes) {
 			handlers.ServiceMetrics,
 			true,
 		},
+		// swagger:route GET /namespaces/{namespace}/metrics/workloads workloads workloadsMetrics
+		// ---
+		// Endpoint to fetch metrics of several workloads of a namespace, or of the workloads of an app, tagged by workload
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      503: serviceUnavailableError
+		//      200: metricsResponse
+		//
+		{
+			"WorkloadsMetrics",
+			"GET",
+			"/api/namespaces/{namespace}/metrics/workloads",
+			handlers.WorkloadsMetrics,
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/services/{service}/metrics/workloads services serviceMetricsByWorkload
+		// ---
+		// Endpoint to fetch metrics of a single service, grouped by the destination workloads that served the requests
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      503: serviceUnavailableError
+		//      200: metricsResponse
+		//
+		{
+			"ServiceMetricsByWorkload",
+			"GET",
+			"/api/namespaces/{namespace}/services/{service}/metrics/workloads",
+			handlers.ServiceMetricsByWorkload,
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/services/{service}/endpoints/health services serviceEndpointsHealth
+		// ---
+		// Endpoint to get the health of the registry endpoints of a service as seen by a proxy
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//      200: endpointsHealthResponse
+		//
+		{
+			"ServiceEndpointsHealth",
+			"GET",
+			"/api/namespaces/{namespace}/services/{service}/endpoints/health",
+			handlers.ServiceEndpointsHealth,
+			true,
+		},
 		// swagger:route GET /namespaces/{namespace}/aggregates/{aggregate}/{aggregateValue}/metrics aggregates aggregateMetrics
 		// ---
 		// Endpoint to fetch metrics to be displayed, related to a single aggregate
@@ -959,6 +1065,30 @@ func NewRoutes() (r *Routes) {
 			handlers.NamespaceValidationSummary,
 			true,
 		},
+		// swagger:route POST /namespaces/{namespace}/validations namespaces namespaceBundleValidations
+		// ---
+		// Validate a multi-document YAML bundle of Istio objects before applying it to the given namespace
+		//
+		//     Consumes:
+		//     - application/yaml
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      200: istioValidationsResponse
+		//      400: badRequestError
+		//      500: internalError
+		//
+		{
+			"IstioConfigBundleValidate",
+			"POST",
+			"/api/namespaces/{namespace}/validations",
+			handlers.IstioConfigBundleValidate,
+			true,
+		},
 		// swagger:route GET /istio/validations namespaces namespacesValidations
 		// ---
 		// Get validation summary for all objects in the given namespaces
@@ -1084,6 +1214,50 @@ func NewRoutes() (r *Routes) {
 			handlers.GraphNamespaces,
 			true,
 		},
+		// swagger:route GET /namespaces/graph/snapshot graphs graphSnapshot
+		// ---
+		// A snapshot of a namespaces graph, with the Istio config and the health of the namespaces.
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      500: internalError
+		//      200: graphSnapshotResponse
+		//
+		{
+			"GraphSnapshot",
+			"GET",
+			"/api/namespaces/graph/snapshot",
+			handlers.GraphSnapshot,
+			true,
+		},
+		// swagger:route POST /namespaces/graph/snapshot graphs graphSnapshotLoad
+		// ---
+		// Loads back a snapshot of a namespaces graph, for a read-only view.
+		//
+		//     Consumes:
+		//     - application/json
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      200: graphSnapshotResponse
+		//
+		{
+			"GraphSnapshotLoad",
+			"POST",
+			"/api/namespaces/graph/snapshot",
+			handlers.GraphSnapshotLoad,
+			true,
+		},
 		// swagger:route GET /namespaces/{namespace}/aggregates/{aggregate}/{aggregateValue}/graph graphs graphAggregate
 		// ---
 		// The backing JSON for an aggregate node detail graph. (supported graphTypes: app | versionedApp | workload)
@@ -1298,6 +1472,49 @@ func NewRoutes() (r *Routes) {
 			handlers.PodLogs,
 			true,
 		},
+		// swagger:route GET /namespaces/{namespace}/workloads/{workload}/logs workloads workloadLogs
+		// ---
+		// Endpoint to get the logs of all the pods of a workload, merged chronologically
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      500: internalError
+		//      404: notFoundError
+		//      400: badRequestError
+		//      200: workloadLogs
+		//
+		{
+			"WorkloadLogs",
+			"GET",
+			"/api/namespaces/{namespace}/workloads/{workload}/logs",
+			handlers.WorkloadLogs,
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/workloads/{workload}/debug_bundle workloads workloadDebugBundle
+		// ---
+		// Endpoint to download a zip archive with the pods, logs, proxy config dumps, proxy statuses and Istio config of a workload
+		//
+		//     Produces:
+		//     - application/zip
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      500: internalError
+		//      404: notFoundError
+		//      200: workloadDebugBundle
+		//
+		{
+			"WorkloadDebugBundle",
+			"GET",
+			"/api/namespaces/{namespace}/workloads/{workload}/debug_bundle",
+			handlers.WorkloadDebugBundle,
+			true,
+		},
 		// swagger:route GET /namespaces/{namespace}/pods/{pod}/config_dump pods podProxyDump
 		// ---
 		// Endpoint to get pod proxy dump
@@ -1402,6 +1619,25 @@ func NewRoutes() (r *Routes) {
 			handlers.GetClusters,
 			true,
 		},
+		// swagger:route POST /api/clusters/refresh
+		// ---
+		// Endpoint to rediscover the Kiali instances of the clusters that are hosting the service mesh.
+		//              Produces:
+		//              - application/json
+		//
+		//              Schemes: http, https
+		//
+		// responses:
+		//              503: serviceUnavailableError
+		//              500: internalError
+		//              200: clustersResponse
+		{
+			"RefreshClusters",
+			"POST",
+			"/api/clusters/refresh",
+			handlers.RefreshClusters,
+			true,
+		},
 		// swagger:route GET /api/mesh/outbound_traffic_policy/mode
 		// ---
 		// Endpoint to get the OutboundTrafficPolicy Mode configured in the service mesh.
@@ -1456,6 +1692,44 @@ func NewRoutes() (r *Routes) {
 			handlers.IstiodCanariesStatus,
 			true,
 		},
+		// swagger:route GET /api/mesh/config meshConfig
+		// ---
+		// Endpoint to get the mesh config of a control plane revision.
+		//              Produces:
+		//              - application/json
+		//
+		//              Schemes: http, https
+		//
+		// responses:
+		//              404: notFoundError
+		//              500: internalError
+		//              200: meshConfigResponse
+		{
+			"MeshConfig",
+			"GET",
+			"/api/mesh/config",
+			handlers.MeshConfig,
+			true,
+		},
+		// swagger:route GET /api/mesh/proxy_status proxyStatuses
+		// ---
+		// Endpoint to get a page of the sync status of the proxies of the mesh.
+		//              Produces:
+		//              - application/json
+		//
+		//              Schemes: http, https
+		//
+		// responses:
+		//              400: badRequestError
+		//              500: internalError
+		//              200: proxyStatusesResponse
+		{
+			"ProxyStatuses",
+			"GET",
+			"/api/mesh/proxy_status",
+			handlers.ProxyStatuses,
+			true,
+		},
 	}
 
 	return
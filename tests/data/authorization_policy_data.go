@@ -80,3 +80,12 @@ func CreateAuthorizationPolicyWithPrincipals(name, namespace string, principalsL
 	}
 	return &ap
 }
+
+func CreateCustomAuthorizationPolicy(name, namespace, provider string) *security_v1beta1.AuthorizationPolicy {
+	ap := CreateEmptyAuthorizationPolicy(name, namespace)
+	ap.Spec.Action = api_security_v1beta1.AuthorizationPolicy_CUSTOM
+	ap.Spec.ActionDetail = &api_security_v1beta1.AuthorizationPolicy_Provider{
+		Provider: &api_security_v1beta1.AuthorizationPolicy_ExtensionProvider{Name: provider},
+	}
+	return ap
+}
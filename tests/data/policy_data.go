@@ -43,6 +43,14 @@ func CreateMTLS(mode string) *api_security_v1beta1.PeerAuthentication_MutualTLS
 	return &mtls
 }
 
+func AddPortLevelMtlsToPeerAuthn(port uint32, mode string, mp *security_v1beta1.PeerAuthentication) *security_v1beta1.PeerAuthentication {
+	if mp.Spec.PortLevelMtls == nil {
+		mp.Spec.PortLevelMtls = map[uint32]*api_security_v1beta1.PeerAuthentication_MutualTLS{}
+	}
+	mp.Spec.PortLevelMtls[port] = CreateMTLS(mode)
+	return mp
+}
+
 func CreateOneLabelSelector(value string) map[string]string {
 	return map[string]string{
 		"app": value,
@@ -0,0 +1,21 @@
+package data
+
+import (
+	"github.com/golang/protobuf/ptypes/wrappers"
+	api_extensions_v1alpha1 "istio.io/api/extensions/v1alpha1"
+	api_v1beta1 "istio.io/api/type/v1beta1"
+	extentions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
+)
+
+func CreateWasmPlugin(name, namespace string, selector map[string]string, phase api_extensions_v1alpha1.PluginPhase, priority int64) *extentions_v1alpha1.WasmPlugin {
+	wp := extentions_v1alpha1.WasmPlugin{}
+	wp.Name = name
+	wp.Namespace = namespace
+	wp.Spec.Url = "oci://ghcr.io/istio-ecosystem/wasm-extensions/" + name + ":1.0.0"
+	wp.Spec.Phase = phase
+	wp.Spec.Priority = &wrappers.Int64Value{Value: priority}
+	if selector != nil {
+		wp.Spec.Selector = &api_v1beta1.WorkloadSelector{MatchLabels: selector}
+	}
+	return &wp
+}
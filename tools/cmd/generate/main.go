@@ -1,13 +1,17 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
 	"flag"
+	"io"
 	"os"
 	"path"
 
+	"github.com/rs/zerolog"
+	zlog "github.com/rs/zerolog/log"
 	"k8s.io/client-go/kubernetes"
 
+	"github.com/kiali/kiali/graph/config/cytoscape"
 	"github.com/kiali/kiali/log"
 	"github.com/kiali/kiali/tools/cmd"
 	"github.com/kiali/kiali/tools/generator"
@@ -15,53 +19,83 @@ import (
 
 const (
 	defaultOutputLocation = "_output"
+	// stdoutFile is the output file name that writes to stdout instead of a file.
+	stdoutFile = "-"
 )
 
 var (
-	boxFlag          bool
-	clusterFlag      string
-	numAppsFlag      int
-	numIngressesFlag int
-	outputFlag       string
-	popStratFlag     generator.PopStratValue = generator.Sparse
+	boxFlag              bool
+	clusterFlag          string
+	idleFlag             bool
+	formatFlag           generator.FormatValue = generator.FormatCytoscapeJSON
+	fromClusterFlag      bool
+	numAppsFlag          int
+	numIngressesFlag     int
+	numNamespacesFlag    int
+	namespaceWeightsFlag generator.NamespaceWeights
+	outputFileFlag       string
+	outputFlag           string
+	popStratFlag         generator.PopStratValue        = generator.Sparse
+	protocolsFlag        generator.ProtocolDistribution = map[string]int{generator.ProtocolHTTP: 1}
 )
 
 func init() {
 	flag.BoolVar(&boxFlag, "box", false, "adds boxing to the graph")
 	flag.StringVar(&clusterFlag, "cluster", "test", "nodes' cluster name")
+	flag.BoolVar(&idleFlag, "idle", false, "leaves some apps without any traffic")
+	flag.Var(&formatFlag, "format", "output format of the generated graph: 'cytoscape-json', 'csv' or 'dot'")
+	flag.BoolVar(&fromClusterFlag, "from-cluster", false, "snapshot the structure of the live cluster instead of generating a synthetic graph")
 	flag.IntVar(&numAppsFlag, "apps", 5, "number of apps to create")
 	flag.IntVar(&numIngressesFlag, "ingresses", 1, "number of ingresses to create")
-	flag.StringVar(&outputFlag, "output", path.Join(cmd.KialiProjectRoot, defaultOutputLocation), "path to output the generated json")
+	flag.IntVar(&numNamespacesFlag, "namespaces", 0, "number of namespaces to spread the apps across. Defaults to the number of namespace weights, or to the number of apps")
+	flag.Var(&namespaceWeightsFlag, "namespace-weights", "relative weight of each namespace apps are placed into e.g. '6,1,1,1,1'")
+	flag.StringVar(&outputFileFlag, "o", "", "file to write the generated graph to. Use '-' for stdout. Overrides 'output'")
+	flag.StringVar(&outputFlag, "output", path.Join(cmd.KialiProjectRoot, defaultOutputLocation), "path to output the generated graph")
 	flag.Var(&popStratFlag, "population-strategy", "whether the graph should have many or few connections")
+	flag.Var(&protocolsFlag, "protocols", "relative weight of each protocol apps communicate over e.g. 'http=3,grpc=1,grpc-web=1,tcp=1'")
 }
 
 func filename() string {
+	switch string(formatFlag) {
+	case generator.FormatCSV:
+		return "generated_graph_data.csv"
+	case generator.FormatDOT:
+		return "generated_graph_data.dot"
+	}
 	return "generated_graph_data.json"
 }
 
-// writeJSONToFile writes the contents to a JSON encoded file.
-func writeJSONToFile(fpath string, contents interface{}) error {
-	// If the file doesn't exist, create it, or append to the file
-	outputPath := path.Join(fpath, filename())
-	log.Infof("Outputting graph data to file: %s", outputPath)
-
-	b, err := json.Marshal(contents)
-	if err != nil {
-		return err
-	}
+// writeGraph writes the graph in the chosen format to the output file or stdout.
+func writeGraph(cyGraph cytoscape.Config) error {
+	var w io.Writer
+	if outputFileFlag == stdoutFile {
+		w = os.Stdout
+	} else {
+		outputPath := outputFileFlag
+		if outputPath == "" {
+			outputPath = path.Join(outputFlag, filename())
+		}
+		log.Infof("Outputting graph data to file: %s", outputPath)
 
-	err = os.WriteFile(outputPath, b, 0644)
-	if err != nil {
-		return err
+		f, err := os.Create(outputPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
 	}
 
-	return nil
+	return generator.Export(w, cyGraph, string(formatFlag))
 }
 
 func main() {
 	flag.Usage = cmd.Usage("generate")
 	flag.Parse()
 	cmd.ConfigureKialiLogger()
+	if outputFileFlag == stdoutFile {
+		// Keep the logs from getting mixed in with the graph.
+		zlog.Logger = zlog.Output(zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: zerolog.TimeFieldFormat, NoColor: true})
+	}
 
 	kubeCfg, err := cmd.GetKubeConfig()
 	if err != nil {
@@ -70,11 +104,17 @@ func main() {
 
 	popStrat := string(popStratFlag)
 	opts := generator.Options{
-		Cluster:            &clusterFlag,
-		IncludeBoxing:      &boxFlag,
-		NumberOfApps:       &numAppsFlag,
-		NumberOfIngress:    &numIngressesFlag,
-		PopulationStrategy: &popStrat,
+		Cluster:              &clusterFlag,
+		IncludeBoxing:        &boxFlag,
+		IncludeIdleNodes:     &idleFlag,
+		NumberOfApps:         &numAppsFlag,
+		NumberOfIngress:      &numIngressesFlag,
+		NamespaceWeights:     namespaceWeightsFlag,
+		PopulationStrategy:   &popStrat,
+		ProtocolDistribution: protocolsFlag,
+	}
+	if numNamespacesFlag != 0 {
+		opts.NumberOfNamespaces = &numNamespacesFlag
 	}
 
 	if kubeCfg != nil {
@@ -91,10 +131,19 @@ func main() {
 		log.Fatal(err)
 	}
 
-	log.Info("Generating graph...")
-	graph := g.Generate()
+	var graph cytoscape.Config
+	if fromClusterFlag {
+		log.Info("Taking graph snapshot of live cluster...")
+		graph, err = g.Snapshot(context.TODO())
+		if err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		log.Info("Generating graph...")
+		graph = g.Generate()
+	}
 
-	err = writeJSONToFile(outputFlag, graph)
+	err = writeGraph(graph)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -24,11 +24,15 @@ var homeDir, _ = os.UserHomeDir()
 
 // Generate flags
 var (
-	boxFlag          bool
-	clusterFlag      string
-	numAppsFlag      int
-	numIngressesFlag int
-	popStratFlag     generator.PopStratValue = generator.Sparse
+	boxFlag              bool
+	clusterFlag          string
+	idleFlag             bool
+	numAppsFlag          int
+	numIngressesFlag     int
+	numNamespacesFlag    int
+	namespaceWeightsFlag generator.NamespaceWeights
+	popStratFlag         generator.PopStratValue        = generator.Sparse
+	protocolsFlag        generator.ProtocolDistribution = map[string]int{generator.ProtocolHTTP: 1}
 )
 
 // Proxy specific flags
@@ -51,9 +55,13 @@ func init() {
 	// Generate flags
 	flag.BoolVar(&boxFlag, "box", false, "adds boxing to the graph")
 	flag.StringVar(&clusterFlag, "cluster", "test", "nodes' cluster name")
+	flag.BoolVar(&idleFlag, "idle", false, "leaves some apps without any traffic")
 	flag.IntVar(&numAppsFlag, "apps", 5, "number of apps to create")
 	flag.IntVar(&numIngressesFlag, "ingresses", 1, "number of ingresses to create")
+	flag.IntVar(&numNamespacesFlag, "namespaces", 0, "number of namespaces to spread the apps across. Defaults to the number of namespace weights, or to the number of apps")
+	flag.Var(&namespaceWeightsFlag, "namespace-weights", "relative weight of each namespace apps are placed into e.g. '6,1,1,1,1'")
 	flag.Var(&popStratFlag, "population-strategy", "whether the graph should have many or few connections")
+	flag.Var(&protocolsFlag, "protocols", "relative weight of each protocol apps communicate over e.g. 'http=3,grpc=1,grpc-web=1,tcp=1'")
 }
 
 func loadGraphFromFile(filename string) (*cytoscape.Config, error) {
@@ -141,9 +149,15 @@ func main() {
 	}
 
 	opts := generator.Options{
-		NumberOfApps:    &numAppsFlag,
-		NumberOfIngress: &numIngressesFlag,
-		IncludeBoxing:   &boxFlag,
+		NumberOfApps:         &numAppsFlag,
+		NumberOfIngress:      &numIngressesFlag,
+		NamespaceWeights:     namespaceWeightsFlag,
+		IncludeBoxing:        &boxFlag,
+		IncludeIdleNodes:     &idleFlag,
+		ProtocolDistribution: protocolsFlag,
+	}
+	if numNamespacesFlag != 0 {
+		opts.NumberOfNamespaces = &numNamespacesFlag
 	}
 
 	kubeCfg, err := cmd.GetKubeConfig()
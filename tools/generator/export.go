@@ -0,0 +1,159 @@
+package generator
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/graph/config/cytoscape"
+)
+
+// Output formats the generated graph can be exported to.
+const (
+	// FormatCytoscapeJSON is the same json the kiali api returns for a graph.
+	FormatCytoscapeJSON = "cytoscape-json"
+	// FormatCSV is a compact csv with a row per node and per edge.
+	FormatCSV = "csv"
+	// FormatDOT is a GraphViz dot digraph where boxes are rendered as subgraphs.
+	FormatDOT = "dot"
+)
+
+var supportedFormats = []string{FormatCytoscapeJSON, FormatCSV, FormatDOT}
+
+// CSV row kinds.
+const (
+	csvKindEdge = "edge"
+	csvKindNode = "node"
+)
+
+// csvHeader are the columns of the csv export. Node rows leave the edge columns
+// empty and edge rows leave the node columns empty.
+var csvHeader = []string{"kind", "id", "parent", "nodeType", "cluster", "namespace", "workload", "app", "version", "service", "isBox", "source", "target", "protocol", "rates"}
+
+// FormatValue implements flag.Value interface so the output format can be used
+// as a flag.
+type FormatValue string
+
+func (f *FormatValue) String() string {
+	return fmt.Sprint(*f)
+}
+
+func (f *FormatValue) Set(value string) error {
+	for _, format := range supportedFormats {
+		if value == format {
+			*f = FormatValue(value)
+			return nil
+		}
+	}
+	return fmt.Errorf("%s is not valid. Use: '%s'", value, strings.Join(supportedFormats, "', '"))
+}
+
+// Export writes the graph to w in the given format.
+func Export(w io.Writer, cyGraph cytoscape.Config, format string) error {
+	switch format {
+	case FormatCytoscapeJSON:
+		return json.NewEncoder(w).Encode(cyGraph)
+	case FormatCSV:
+		return ExportCSV(w, cyGraph)
+	case FormatDOT:
+		return ExportDOT(w, cyGraph)
+	}
+	return fmt.Errorf("%s is not a supported format. Use: '%s'", format, strings.Join(supportedFormats, "', '"))
+}
+
+// ExportCSV writes the graph as a csv with a row for every node followed by a row
+// for every edge. The edge's rates are written as a ';' separated list of 'rate=value'.
+func ExportCSV(w io.Writer, cyGraph cytoscape.Config) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, n := range cyGraph.Elements.Nodes {
+		nd := n.Data
+		row := []string{csvKindNode, nd.ID, nd.Parent, nd.NodeType, nd.Cluster, nd.Namespace, nd.Workload, nd.App, nd.Version, nd.Service, nd.IsBox, "", "", "", ""}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	for _, e := range cyGraph.Elements.Edges {
+		ed := e.Data
+		row := []string{csvKindEdge, ed.ID, "", "", "", "", "", "", "", "", "", ed.Source, ed.Target, ed.Traffic.Protocol, formatRates(ed.Traffic.Rates)}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// ExportDOT writes the graph as a GraphViz digraph. Box nodes are written as
+// 'cluster_' subgraphs so that GraphViz draws a box around their children.
+func ExportDOT(w io.Writer, cyGraph cytoscape.Config) error {
+	children := make(map[string][]*cytoscape.NodeData)
+	for _, n := range cyGraph.Elements.Nodes {
+		children[n.Data.Parent] = append(children[n.Data.Parent], n.Data)
+	}
+
+	var b strings.Builder
+	b.WriteString("digraph kiali {\n")
+	// Nodes without a parent are at the top level.
+	writeDOTNodes(&b, children, "", 1)
+	for _, e := range cyGraph.Elements.Edges {
+		fmt.Fprintf(&b, "\t%q -> %q", e.Data.Source, e.Data.Target)
+		if e.Data.Traffic.Protocol != "" {
+			fmt.Fprintf(&b, " [label=%q]", e.Data.Traffic.Protocol)
+		}
+		b.WriteString(";\n")
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeDOTNodes(b *strings.Builder, children map[string][]*cytoscape.NodeData, parent string, depth int) {
+	indent := strings.Repeat("\t", depth)
+	for _, nd := range children[parent] {
+		if nd.IsBox != "" {
+			fmt.Fprintf(b, "%ssubgraph %q {\n", indent, "cluster_"+nd.ID)
+			fmt.Fprintf(b, "%s\tlabel=%q;\n", indent, nodeLabel(nd))
+			writeDOTNodes(b, children, nd.ID, depth+1)
+			fmt.Fprintf(b, "%s}\n", indent)
+			continue
+		}
+		fmt.Fprintf(b, "%s%q [label=%q];\n", indent, nd.ID, nodeLabel(nd))
+	}
+}
+
+// nodeLabel returns a human readable name for the node.
+func nodeLabel(nd *cytoscape.NodeData) string {
+	switch {
+	case nd.IsBox == graph.BoxByCluster:
+		return nd.Cluster
+	case nd.IsBox == graph.BoxByNamespace:
+		return nd.Namespace
+	case nd.IsBox == graph.BoxByApp:
+		return nd.App
+	case nd.Service != "":
+		return nd.Service
+	case nd.Workload != "":
+		return nd.Workload
+	}
+	return nd.App
+}
+
+func formatRates(rates map[string]string) string {
+	var r []string
+	for name, val := range rates {
+		r = append(r, name+"="+val)
+	}
+	sort.Strings(r)
+	return strings.Join(r, ";")
+}
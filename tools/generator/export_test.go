@@ -0,0 +1,154 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/graph/config/cytoscape"
+)
+
+func generateForExport(t *testing.T) cytoscape.Config {
+	t.Helper()
+	config.Set(config.NewConfig())
+
+	numApps := 6
+	includeIdle := true
+	g, err := New(Options{
+		NumberOfApps:         &numApps,
+		IncludeIdleNodes:     &includeIdle,
+		ProtocolDistribution: ProtocolDistribution{ProtocolHTTP: 1, ProtocolGRPC: 1, ProtocolTCP: 1},
+	})
+	require.NoError(t, err)
+
+	return g.Generate()
+}
+
+func TestExportCytoscapeJSON(t *testing.T) {
+	require := require.New(t)
+	cyGraph := generateForExport(t)
+
+	var b bytes.Buffer
+	require.NoError(Export(&b, cyGraph, FormatCytoscapeJSON))
+
+	var roundTripped cytoscape.Config
+	require.NoError(json.Unmarshal(b.Bytes(), &roundTripped))
+	require.Equal(cyGraph, roundTripped)
+}
+
+func TestExportCSV(t *testing.T) {
+	require := require.New(t)
+	cyGraph := generateForExport(t)
+
+	var b bytes.Buffer
+	require.NoError(Export(&b, cyGraph, FormatCSV))
+
+	rows, err := csv.NewReader(&b).ReadAll()
+	require.NoError(err)
+	require.Equal(csvHeader, rows[0])
+	require.Len(rows[1:], len(cyGraph.Elements.Nodes)+len(cyGraph.Elements.Edges))
+
+	nodes := make(map[string][]string)
+	edges := make(map[string][]string)
+	for _, row := range rows[1:] {
+		switch row[0] {
+		case csvKindNode:
+			nodes[row[1]] = row
+		case csvKindEdge:
+			edges[row[1]] = row
+		default:
+			t.Fatalf("unexpected row kind: %s", row[0])
+		}
+	}
+
+	for _, n := range cyGraph.Elements.Nodes {
+		nd := n.Data
+		row, found := nodes[nd.ID]
+		require.True(found, "node %s is missing", nd.ID)
+		require.Equal([]string{nd.Parent, nd.NodeType, nd.Cluster, nd.Namespace, nd.Workload, nd.App, nd.Version, nd.Service, nd.IsBox}, row[2:11])
+	}
+	for _, e := range cyGraph.Elements.Edges {
+		ed := e.Data
+		row, found := edges[ed.ID]
+		require.True(found, "edge %s is missing", ed.ID)
+		require.Equal([]string{ed.Source, ed.Target, ed.Traffic.Protocol}, row[11:14])
+		for rate, val := range ed.Traffic.Rates {
+			require.Contains(strings.Split(row[14], ";"), rate+"="+val)
+		}
+	}
+}
+
+func TestExportDOT(t *testing.T) {
+	require := require.New(t)
+	cyGraph := generateForExport(t)
+
+	var b bytes.Buffer
+	require.NoError(Export(&b, cyGraph, FormatDOT))
+
+	// Walk the dot output keeping track of which subgraph each node is declared in.
+	parents := make(map[string]string)
+	edges := make(map[string]bool)
+	var subgraphs []string
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	require.Equal("digraph kiali {", lines[0])
+	require.Equal("}", lines[len(lines)-1])
+	for _, line := range lines[1 : len(lines)-1] {
+		line = strings.TrimSpace(line)
+		var id, source, target string
+		switch {
+		case strings.HasPrefix(line, "subgraph "):
+			_, err := fmt.Sscanf(line, "subgraph %q {", &id)
+			require.NoError(err)
+			id = strings.TrimPrefix(id, "cluster_")
+			parents[id] = currentSubgraph(subgraphs)
+			subgraphs = append(subgraphs, id)
+		case line == "}":
+			subgraphs = subgraphs[:len(subgraphs)-1]
+		case strings.HasPrefix(line, "label="):
+		case strings.Contains(line, " -> "):
+			_, err := fmt.Sscanf(line, "%q -> %q", &source, &target)
+			require.NoError(err)
+			edges[source+"->"+target] = true
+		default:
+			_, err := fmt.Sscanf(line, "%q", &id)
+			require.NoError(err)
+			parents[id] = currentSubgraph(subgraphs)
+		}
+	}
+	require.Empty(subgraphs, "unbalanced subgraphs")
+
+	require.Len(parents, len(cyGraph.Elements.Nodes))
+	for _, n := range cyGraph.Elements.Nodes {
+		parent, found := parents[n.Data.ID]
+		require.True(found, "node %s is missing", n.Data.ID)
+		require.Equal(n.Data.Parent, parent, "node %s is in the wrong subgraph", n.Data.ID)
+	}
+
+	require.Len(edges, len(cyGraph.Elements.Edges))
+	for _, e := range cyGraph.Elements.Edges {
+		require.True(edges[e.Data.Source+"->"+e.Data.Target], "edge %s is missing", e.Data.ID)
+	}
+}
+
+func TestExportUnknownFormat(t *testing.T) {
+	var b bytes.Buffer
+	require.Error(t, Export(&b, cytoscape.Config{}, "yaml"))
+
+	var format FormatValue
+	require.Error(t, format.Set("yaml"))
+	require.NoError(t, format.Set(FormatDOT))
+	require.Equal(t, FormatDOT, format.String())
+}
+
+func currentSubgraph(subgraphs []string) string {
+	if len(subgraphs) == 0 {
+		return ""
+	}
+	return subgraphs[len(subgraphs)-1]
+}
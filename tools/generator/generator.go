@@ -27,14 +27,40 @@ const (
 	Sparse = "sparse"
 
 	maxWorkloadVersions = 3
+
+	// idleAppInterval controls how often an app is left idle when idle nodes are included.
+	// Every idleAppInterval-th app, starting with the first, receives no traffic.
+	idleAppInterval = 4
+)
+
+// Protocols that apps can communicate over.
+const (
+	ProtocolGRPC    = "grpc"
+	ProtocolGRPCWeb = "grpc-web"
+	ProtocolHTTP    = "http"
+	ProtocolTCP     = "tcp"
 )
 
+var supportedProtocols = []string{ProtocolGRPC, ProtocolGRPCWeb, ProtocolHTTP, ProtocolTCP}
+
+func isSupportedProtocol(protocol string) bool {
+	for _, p := range supportedProtocols {
+		if p == protocol {
+			return true
+		}
+	}
+	return false
+}
+
 type app struct {
 	Box       string
 	Cluster   string
 	Name      string
 	Namespace string
+	IsIdle    bool
 	IsIngress bool
+	// Protocol the app's service and workloads receive traffic over.
+	Protocol string
 }
 
 // Generator creates cytoscape graph data based on the options provided.
@@ -51,15 +77,28 @@ type Generator struct {
 	// IncludeBoxing determines whether nodes will include boxing or not.
 	IncludeBoxing bool
 
+	// IncludeIdleNodes determines whether some apps are left without any traffic.
+	IncludeIdleNodes bool
+
 	// NumberOfApps sets how many apps to create.
 	NumberOfApps int
 
 	// NumberOfIngress sets how many ingress to create.
 	NumberOfIngress int
 
+	// NumberOfNamespaces sets how many namespaces the apps are spread across.
+	NumberOfNamespaces int
+
+	// NamespaceWeights sets the relative weight of each namespace when placing apps.
+	// Apps are spread uniformly when there are no weights.
+	NamespaceWeights NamespaceWeights
+
 	// PopulationStrategy determines how many connections from ingress i.e. dense or sparse.
 	PopulationStrategy string
 
+	// ProtocolDistribution sets the relative weight of each protocol apps communicate over.
+	ProtocolDistribution ProtocolDistribution
+
 	kubeClient      kubernetes.Interface
 	namespaceLister corev1listers.NamespaceLister
 }
@@ -73,6 +112,45 @@ func New(opts Options) (*Generator, error) {
 		NumberOfApps:       10,
 		NumberOfIngress:    1,
 		PopulationStrategy: Dense,
+		ProtocolDistribution: ProtocolDistribution{
+			ProtocolHTTP: 1,
+		},
+	}
+
+	if opts.Cluster != nil {
+		g.Cluster = *opts.Cluster
+	}
+	if opts.IncludeBoxing != nil {
+		g.IncludeBoxing = *opts.IncludeBoxing
+	}
+	if opts.IncludeIdleNodes != nil {
+		g.IncludeIdleNodes = *opts.IncludeIdleNodes
+	}
+	if opts.NumberOfApps != nil {
+		g.NumberOfApps = *opts.NumberOfApps
+	}
+	if opts.NumberOfIngress != nil {
+		g.NumberOfIngress = *opts.NumberOfIngress
+	}
+	if opts.PopulationStrategy != nil {
+		g.PopulationStrategy = *opts.PopulationStrategy
+	}
+	g.NamespaceWeights = opts.NamespaceWeights
+	switch {
+	case opts.NumberOfNamespaces != nil:
+		g.NumberOfNamespaces = *opts.NumberOfNamespaces
+	case len(g.NamespaceWeights) > 0:
+		g.NumberOfNamespaces = len(g.NamespaceWeights)
+	default:
+		// Creates at most a namespace per app.
+		g.NumberOfNamespaces = g.NumberOfApps
+	}
+	if opts.ProtocolDistribution != nil {
+		g.ProtocolDistribution = opts.ProtocolDistribution
+	}
+
+	if err := g.validate(); err != nil {
+		return nil, err
 	}
 
 	// Kube specific options
@@ -91,23 +169,51 @@ func New(opts Options) (*Generator, error) {
 		}
 	}
 
-	if opts.Cluster != nil {
-		g.Cluster = *opts.Cluster
+	return &g, nil
+}
+
+// validate checks that the generator's options make sense together.
+// Invalid combinations would otherwise produce an empty graph or panic.
+func (g *Generator) validate() error {
+	if g.NumberOfApps <= 0 {
+		return fmt.Errorf("number of apps must be greater than 0 but was %d", g.NumberOfApps)
 	}
-	if opts.IncludeBoxing != nil {
-		g.IncludeBoxing = *opts.IncludeBoxing
+	if g.NumberOfIngress <= 0 {
+		return fmt.Errorf("number of ingresses must be greater than 0 but was %d", g.NumberOfIngress)
 	}
-	if opts.NumberOfApps != nil {
-		g.NumberOfApps = *opts.NumberOfApps
+	if g.NumberOfIngress > g.NumberOfApps {
+		return fmt.Errorf("number of ingresses (%d) cannot exceed the number of apps (%d) since every ingress needs at least one app", g.NumberOfIngress, g.NumberOfApps)
 	}
-	if opts.NumberOfIngress != nil {
-		g.NumberOfIngress = *opts.NumberOfIngress
+	if g.NumberOfNamespaces <= 0 {
+		return fmt.Errorf("number of namespaces must be greater than 0 but was %d", g.NumberOfNamespaces)
 	}
-	if opts.PopulationStrategy != nil {
-		g.PopulationStrategy = *opts.PopulationStrategy
+	if len(g.NamespaceWeights) > 0 && len(g.NamespaceWeights) != g.NumberOfNamespaces {
+		return fmt.Errorf("number of namespace weights (%d) must match the number of namespaces (%d)", len(g.NamespaceWeights), g.NumberOfNamespaces)
+	}
+	for i, weight := range g.NamespaceWeights {
+		if weight <= 0 {
+			return fmt.Errorf("weight for namespace '%s' must be greater than 0 but was %d", generateNamespaceName(i+1), weight)
+		}
+	}
+	if g.PopulationStrategy != Dense && g.PopulationStrategy != Sparse {
+		return fmt.Errorf("population strategy '%s' is not valid. Use: '%s' or '%s'", g.PopulationStrategy, Dense, Sparse)
 	}
 
-	return &g, nil
+	total := 0
+	for protocol, weight := range g.ProtocolDistribution {
+		if !isSupportedProtocol(protocol) {
+			return fmt.Errorf("protocol '%s' is not supported. Use: '%s'", protocol, strings.Join(supportedProtocols, "', '"))
+		}
+		if weight < 0 {
+			return fmt.Errorf("weight for protocol '%s' must not be negative but was %d", protocol, weight)
+		}
+		total += weight
+	}
+	if total == 0 {
+		return fmt.Errorf("protocol distribution must give at least one protocol a weight greater than 0")
+	}
+
+	return nil
 }
 
 // EnsureNamespaces makes sure a kube namespace exists for the nodes.
@@ -131,7 +237,17 @@ func (g *Generator) EnsureNamespaces(cyGraph cytoscape.Config) error {
 // 2. Services send requests to the workloads in their app.
 // 3. Ingress workloads are root nodes.
 func (g *Generator) Generate() cytoscape.Config {
-	nodes := g.generate()
+	cyGraph := g.toConfig(g.generate())
+
+	if err := g.EnsureNamespaces(cyGraph); err != nil {
+		log.Errorf("unable to ensure namespaces exist. Err: %s", err)
+	}
+
+	return cyGraph
+}
+
+// toConfig converts the generated nodes into cytoscape graph data.
+func (g *Generator) toConfig(nodes []*graph.Node) cytoscape.Config {
 	traffic := graph.NewTrafficMap()
 	for _, node := range nodes {
 		traffic[node.ID] = node
@@ -147,13 +263,7 @@ func (g *Generator) Generate() cytoscape.Config {
 		},
 		BoxBy: strings.Join([]string{graph.BoxByApp, graph.BoxByNamespace}, ","),
 	}
-	cyGraph := cytoscape.NewConfig(traffic, opts)
-
-	if err := g.EnsureNamespaces(cyGraph); err != nil {
-		log.Errorf("unable to ensure namespaces exist. Err: %s", err)
-	}
-
-	return cyGraph
+	return cytoscape.NewConfig(traffic, opts)
 }
 
 func (g *Generator) strategyLimit() int {
@@ -179,31 +289,38 @@ func (g *Generator) genAppsWithIngress(index int, numApps int) []*graph.Node {
 	}
 	iNodes := []*graph.Node{g.newWorkloadNode(ingress, "latest")}
 
+	// Tracks which protocol each service receives traffic over.
+	protocols := make(map[string]string, numApps)
+
 	// Then create the rest of them.
 	for i := 1; i <= numApps; i++ {
 		app := app{
 			Cluster: g.Cluster,
 			Name:    fmt.Sprintf("app-%d", i),
-			// Creates at most a namespace per app.
 			// Multiple apps can land in the same namespace.
-			// TODO: Provide option to control this.
-			Namespace: getRandomNamespace(1, g.NumberOfApps),
+			Namespace: g.randomNamespace(),
+			Protocol:  g.randomProtocol(),
+			IsIdle:    g.IncludeIdleNodes && i%idleAppInterval == 1,
 		}
 		appNodes := g.genApp(app)
+		for _, svc := range filterByService(appNodes) {
+			protocols[svc.ID] = app.Protocol
+		}
 		nodes = append(nodes, appNodes...)
 	}
 
 	// Add edges from the ingress workload to each of the app's service node.
 	// This simulates traffic coming in from ingress and going out to each of
-	// the service nodes in the graph.
+	// the service nodes in the graph. The ingress talks to each service over
+	// whichever protocol the service's app uses.
 	iWorkloads := filterByApp(iNodes)
-	svcs := filterByService(nodes)
+	svcs := filterByActive(filterByService(nodes))
 
 	for _, wk := range iWorkloads {
 		for i := 0; i < g.strategyLimit() && i < len(svcs); i++ {
 			svc := svcs[i]
 			e := wk.AddEdge(svc)
-			addFakeEdgeTraffic(e, svc.Service)
+			addFakeEdgeTraffic(e, protocols[svc.ID], svc.Service)
 		}
 	}
 
@@ -237,31 +354,69 @@ func (g *Generator) genApp(app app) []*graph.Node {
 	for i := 1; i <= numVersions; i++ {
 		workload := g.newWorkloadNode(app, fmt.Sprintf("v%d", i))
 		nodes = append(nodes, workload)
+		// Idle apps don't have any traffic so no edges are added between the nodes.
+		if app.IsIdle {
+			continue
+		}
 		e := svc.AddEdge(workload)
-		addFakeEdgeTraffic(e, svc.Service)
+		addFakeEdgeTraffic(e, app.Protocol, svc.Service)
 	}
 
 	return nodes
 }
 
-func addFakeEdgeTraffic(e *graph.Edge, destination string) {
-	e.Metadata[graph.ProtocolKey] = "http"
-	e.Metadata[graph.HTTP.EdgeResponses] = graph.Responses{
-		"200": &graph.ResponseDetail{
-			Flags: graph.ResponseFlags{
-				"-": 0.3333999999,
-			},
-			Hosts: graph.ResponseHosts{
-				destination: 0.3333999999,
-			},
-		},
+// randomProtocol picks a protocol based on the generator's protocol distribution.
+func (g *Generator) randomProtocol() string {
+	// Iterate over the protocols in a fixed order so that the distribution
+	// is applied consistently.
+	total := 0
+	for _, protocol := range supportedProtocols {
+		total += g.ProtocolDistribution[protocol]
 	}
-	e.Metadata[graph.MetadataKey("http")] = 1.00
+	if total <= 0 {
+		return ProtocolHTTP
+	}
+
+	n := rand.Intn(total)
+	for _, protocol := range supportedProtocols {
+		n -= g.ProtocolDistribution[protocol]
+		if n < 0 {
+			return protocol
+		}
+	}
+
+	return ProtocolHTTP
+}
+
+// addFakeEdgeTraffic adds traffic to the edge, and its source and dest nodes, using the
+// same rate keys the telemetry vendor would for the given protocol.
+func addFakeEdgeTraffic(e *graph.Edge, protocol string, destination string) {
+	var graphProtocol, code string
+	val := 1.00
+	switch protocol {
+	case ProtocolGRPC:
+		graphProtocol, code = graph.GRPC.Name, "0"
+	case ProtocolGRPCWeb:
+		// gRPC-Web is reported as grpc traffic but since it is proxied over
+		// HTTP/1.1 the response codes are HTTP codes.
+		graphProtocol, code = graph.GRPC.Name, "200"
+	case ProtocolTCP:
+		// tcp rates are in bytes per second rather than requests per second.
+		graphProtocol, code, val = graph.TCP.Name, "-", 1024.00
+	default:
+		graphProtocol, code = graph.HTTP.Name, "200"
+	}
+
+	e.Metadata[graph.ProtocolKey] = graphProtocol
+	graph.AddToMetadata(graphProtocol, val, code, "-", destination, e.Source.Metadata, e.Dest.Metadata, e.Metadata)
 }
 
 func (g *Generator) newServiceNode(app app) *graph.Node {
 	// It is important to leave app name blank here, otherwise this node will be considered a workload.
 	s, _ := graph.NewNode(app.Cluster, app.Namespace, app.Name, app.Namespace, "", "", "", g.GraphType)
+	if app.IsIdle {
+		markIdle(s)
+	}
 	return s
 }
 
@@ -273,9 +428,20 @@ func (g *Generator) newWorkloadNode(app app, version string) *graph.Node {
 		node.Metadata[graph.IsIngressGateway] = graph.GatewaysMetadata{node.Workload: []string{"*"}}
 		node.Metadata[graph.IsOutside] = true
 	}
+	if app.IsIdle {
+		markIdle(node)
+	}
 	return node
 }
 
+// markIdle sets the same metadata on the node that the idle node appender does.
+func markIdle(node *graph.Node) {
+	// note: we don't know what the protocol really should be, http is most common, it's a dead edge anyway
+	node.Metadata[graph.MetadataKey("httpIn")] = 0.0
+	node.Metadata[graph.MetadataKey("httpOut")] = 0.0
+	node.Metadata[graph.IsIdle] = true
+}
+
 func (g *Generator) ensureNamespace(name string) error {
 	if _, err := g.namespaceLister.Get(name); err != nil {
 		if kubeerrors.IsNotFound(err) {
@@ -303,6 +469,17 @@ func filterByApp(nodes []*graph.Node) []*graph.Node {
 	return workloads
 }
 
+// filterByActive removes idle nodes.
+func filterByActive(nodes []*graph.Node) []*graph.Node {
+	var active []*graph.Node
+	for i, n := range nodes {
+		if isIdle, ok := n.Metadata[graph.IsIdle]; !ok || !isIdle.(bool) {
+			active = append(active, nodes[i])
+		}
+	}
+	return active
+}
+
 func filterByService(nodes []*graph.Node) []*graph.Node {
 	var services []*graph.Node
 	for i, n := range nodes {
@@ -317,7 +494,25 @@ func generateNamespaceName(numNamespace int) string {
 	return fmt.Sprintf("n%d", numNamespace)
 }
 
-func getRandomNamespace(from, to int) string {
-	numNamespace := from + rand.Intn(to)
-	return generateNamespaceName(numNamespace)
+// randomNamespace picks one of the generator's namespaces based on the namespace weights,
+// or uniformly when there are none.
+func (g *Generator) randomNamespace() string {
+	if len(g.NamespaceWeights) == 0 {
+		return generateNamespaceName(1 + rand.Intn(g.NumberOfNamespaces))
+	}
+
+	total := 0
+	for _, weight := range g.NamespaceWeights {
+		total += weight
+	}
+
+	n := rand.Intn(total)
+	for i, weight := range g.NamespaceWeights {
+		n -= weight
+		if n < 0 {
+			return generateNamespaceName(i + 1)
+		}
+	}
+
+	return generateNamespaceName(len(g.NamespaceWeights))
 }
@@ -0,0 +1,297 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/graph/config/cytoscape"
+)
+
+func generateWithProtocol(t *testing.T, protocol string) cytoscape.Config {
+	t.Helper()
+	config.Set(config.NewConfig())
+
+	numApps := 5
+	g, err := New(Options{
+		NumberOfApps:         &numApps,
+		ProtocolDistribution: ProtocolDistribution{protocol: 1},
+	})
+	require.NoError(t, err)
+
+	cyGraph := g.Generate()
+	require.NotEmpty(t, cyGraph.Elements.Edges)
+	return cyGraph
+}
+
+func TestGenerateEdgeRatesPerProtocol(t *testing.T) {
+	cases := map[string]struct {
+		protocol         string
+		expectedProtocol string
+		expectedRates    []string
+		expectedCode     string
+	}{
+		"http": {
+			protocol:         ProtocolHTTP,
+			expectedProtocol: "http",
+			expectedRates:    []string{"http", "httpPercentReq"},
+			expectedCode:     "200",
+		},
+		"grpc": {
+			protocol:         ProtocolGRPC,
+			expectedProtocol: "grpc",
+			expectedRates:    []string{"grpc", "grpcPercentReq"},
+			expectedCode:     "0",
+		},
+		"grpc-web": {
+			protocol:         ProtocolGRPCWeb,
+			expectedProtocol: "grpc",
+			expectedRates:    []string{"grpc", "grpcPercentReq"},
+			expectedCode:     "200",
+		},
+		"tcp": {
+			protocol:         ProtocolTCP,
+			expectedProtocol: "tcp",
+			expectedRates:    []string{"tcp"},
+			expectedCode:     "-",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			cyGraph := generateWithProtocol(t, tc.protocol)
+
+			for _, edge := range cyGraph.Elements.Edges {
+				traffic := edge.Data.Traffic
+				require.Equal(tc.expectedProtocol, traffic.Protocol)
+				for _, rate := range tc.expectedRates {
+					require.Contains(traffic.Rates, rate)
+				}
+				for rate := range traffic.Rates {
+					require.Contains(tc.expectedRates, rate)
+				}
+				require.Contains(traffic.Responses, tc.expectedCode)
+			}
+		})
+	}
+}
+
+func TestProtocolDistributionFlag(t *testing.T) {
+	require := require.New(t)
+
+	var dist ProtocolDistribution
+	require.NoError(dist.Set("http=3,tcp=1"))
+	require.Equal(ProtocolDistribution{ProtocolHTTP: 3, ProtocolTCP: 1}, dist)
+	require.Equal("http=3,tcp=1", dist.String())
+
+	require.Error(dist.Set("http"))
+	require.Error(dist.Set("udp=1"))
+	require.Error(dist.Set("http=-1"))
+}
+
+func TestGenerateIncludeIdleNodes(t *testing.T) {
+	require := require.New(t)
+	config.Set(config.NewConfig())
+
+	numApps := 8
+	includeIdle := true
+	g, err := New(Options{
+		NumberOfApps:     &numApps,
+		IncludeIdleNodes: &includeIdle,
+	})
+	require.NoError(err)
+
+	cyGraph := g.Generate()
+
+	nodeIDs := make(map[string]bool)
+	nodesWithEdges := make(map[string]bool)
+	for _, node := range cyGraph.Elements.Nodes {
+		nodeIDs[node.Data.ID] = true
+	}
+	for _, edge := range cyGraph.Elements.Edges {
+		nodesWithEdges[edge.Data.Source] = true
+		nodesWithEdges[edge.Data.Target] = true
+	}
+
+	var idleNodes []*cytoscape.NodeData
+	for _, node := range cyGraph.Elements.Nodes {
+		if node.Data.IsIdle {
+			idleNodes = append(idleNodes, node.Data)
+		}
+	}
+	require.NotEmpty(idleNodes)
+
+	for _, node := range idleNodes {
+		require.False(nodesWithEdges[node.ID], "idle node %s should not have any edges", node.ID)
+		require.NotEmpty(node.Parent, "idle node %s should be boxed", node.ID)
+		require.True(nodeIDs[node.Parent], "box %s of idle node %s does not exist", node.Parent, node.ID)
+	}
+}
+
+func TestGenerateWithoutIdleNodes(t *testing.T) {
+	config.Set(config.NewConfig())
+
+	g, err := New(Options{})
+	require.NoError(t, err)
+
+	for _, node := range g.Generate().Elements.Nodes {
+		require.False(t, node.Data.IsIdle)
+	}
+}
+
+func TestNewValidatesOptions(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+	strPtr := func(s string) *string { return &s }
+
+	cases := map[string]struct {
+		opts        Options
+		expectedErr string
+	}{
+		"zero apps": {
+			opts:        Options{NumberOfApps: intPtr(0)},
+			expectedErr: "number of apps",
+		},
+		"negative apps": {
+			opts:        Options{NumberOfApps: intPtr(-1)},
+			expectedErr: "number of apps",
+		},
+		"zero ingresses": {
+			opts:        Options{NumberOfIngress: intPtr(0)},
+			expectedErr: "number of ingresses",
+		},
+		"negative ingresses": {
+			opts:        Options{NumberOfIngress: intPtr(-3)},
+			expectedErr: "number of ingresses",
+		},
+		"more ingresses than apps": {
+			opts:        Options{NumberOfApps: intPtr(2), NumberOfIngress: intPtr(3)},
+			expectedErr: "cannot exceed the number of apps",
+		},
+		"unknown population strategy": {
+			opts:        Options{PopulationStrategy: strPtr("crowded")},
+			expectedErr: "population strategy 'crowded' is not valid",
+		},
+		"unknown protocol": {
+			opts:        Options{ProtocolDistribution: ProtocolDistribution{"udp": 1}},
+			expectedErr: "protocol 'udp' is not supported",
+		},
+		"negative protocol weight": {
+			opts:        Options{ProtocolDistribution: ProtocolDistribution{ProtocolHTTP: -1}},
+			expectedErr: "must not be negative",
+		},
+		"all protocol weights zero": {
+			opts:        Options{ProtocolDistribution: ProtocolDistribution{ProtocolHTTP: 0}},
+			expectedErr: "at least one protocol",
+		},
+		"zero namespaces": {
+			opts:        Options{NumberOfNamespaces: intPtr(0)},
+			expectedErr: "number of namespaces",
+		},
+		"namespace weights not matching namespaces": {
+			opts:        Options{NumberOfNamespaces: intPtr(3), NamespaceWeights: NamespaceWeights{6, 1}},
+			expectedErr: "must match the number of namespaces",
+		},
+		"zero namespace weight": {
+			opts:        Options{NamespaceWeights: NamespaceWeights{6, 0, 1}},
+			expectedErr: "weight for namespace 'n2' must be greater than 0",
+		},
+		"negative namespace weight": {
+			opts:        Options{NamespaceWeights: NamespaceWeights{-1}},
+			expectedErr: "weight for namespace 'n1' must be greater than 0",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			g, err := New(tc.opts)
+			require.ErrorContains(t, err, tc.expectedErr)
+			require.Nil(t, g)
+		})
+	}
+}
+
+func TestNewValidOptions(t *testing.T) {
+	require := require.New(t)
+
+	numApps := 4
+	numIngress := 4
+	popStrat := Sparse
+	g, err := New(Options{
+		NumberOfApps:         &numApps,
+		NumberOfIngress:      &numIngress,
+		PopulationStrategy:   &popStrat,
+		ProtocolDistribution: ProtocolDistribution{ProtocolHTTP: 1, ProtocolTCP: 0},
+	})
+	require.NoError(err)
+	require.Equal(4, g.NumberOfApps)
+	require.Equal(4, g.NumberOfIngress)
+	require.Equal(Sparse, g.PopulationStrategy)
+}
+
+func TestNamespaceWeightsFlag(t *testing.T) {
+	require := require.New(t)
+
+	var weights NamespaceWeights
+	require.NoError(weights.Set("6,1,1"))
+	require.Equal(NamespaceWeights{6, 1, 1}, weights)
+	require.Equal("6,1,1", weights.String())
+
+	require.Error(weights.Set("6,a"))
+	require.Error(weights.Set("6,0"))
+	require.Error(weights.Set("-1"))
+}
+
+func TestGenerateNamespaceWeights(t *testing.T) {
+	require := require.New(t)
+	config.Set(config.NewConfig())
+
+	numApps := 2000
+	weights := NamespaceWeights{6, 1, 1, 1, 1}
+	g, err := New(Options{
+		NumberOfApps:     &numApps,
+		NamespaceWeights: weights,
+	})
+	require.NoError(err)
+	require.Equal(len(weights), g.NumberOfNamespaces)
+
+	appsPerNamespace := make(map[string]int)
+	for _, node := range g.Generate().Elements.Nodes {
+		if node.Data.NodeType == graph.NodeTypeService {
+			appsPerNamespace[node.Data.Namespace]++
+		}
+	}
+
+	total := 0
+	for _, weight := range weights {
+		total += weight
+	}
+	require.Len(appsPerNamespace, len(weights))
+	for i, weight := range weights {
+		namespace := generateNamespaceName(i + 1)
+		expected := float64(weight) / float64(total)
+		actual := float64(appsPerNamespace[namespace]) / float64(numApps)
+		require.InDelta(expected, actual, 0.05, "namespace %s got %.2f of the apps instead of %.2f", namespace, actual, expected)
+	}
+}
+
+func TestGenerateNumberOfNamespaces(t *testing.T) {
+	require := require.New(t)
+	config.Set(config.NewConfig())
+
+	numApps := 50
+	numNamespaces := 2
+	g, err := New(Options{
+		NumberOfApps:       &numApps,
+		NumberOfNamespaces: &numNamespaces,
+	})
+	require.NoError(err)
+
+	for _, node := range g.Generate().Elements.Nodes {
+		if node.Data.NodeType == graph.NodeTypeService {
+			require.Contains([]string{"n1", "n2"}, node.Data.Namespace)
+		}
+	}
+}
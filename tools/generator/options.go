@@ -2,6 +2,9 @@ package generator
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
 	"k8s.io/client-go/kubernetes"
 )
@@ -14,6 +17,9 @@ type Options struct {
 	// IncludeBoxing determines whether nodes will include boxing or not.
 	IncludeBoxing *bool
 
+	// IncludeIdleNodes determines whether some apps are left without any traffic.
+	IncludeIdleNodes *bool
+
 	// KubeClient if passed enables talking to the kube api to get/create namespaces.
 	KubeClient kubernetes.Interface
 
@@ -23,8 +29,20 @@ type Options struct {
 	// NumberOfIngress sets how many ingress to create.
 	NumberOfIngress *int
 
+	// NumberOfNamespaces sets how many namespaces the apps are spread across.
+	// Defaults to the number of namespace weights if any, otherwise to the number of apps.
+	NumberOfNamespaces *int
+
+	// NamespaceWeights sets the relative weight of each namespace when placing apps.
+	// Defaults to a uniform distribution.
+	NamespaceWeights NamespaceWeights
+
 	// PopulationStrategy determines how many connections from ingress i.e. dense or sparse.
 	PopulationStrategy *string
+
+	// ProtocolDistribution sets the relative weight of each protocol apps communicate over.
+	// Defaults to http only.
+	ProtocolDistribution ProtocolDistribution
 }
 
 // PopStratValue implements flag.Value interface so pop strategy can be used
@@ -39,5 +57,80 @@ func (i *PopStratValue) Set(value string) error {
 	if value != Dense && value != Sparse {
 		return fmt.Errorf("%s is not valid. Use: '%s' or '%s'", value, Dense, Sparse)
 	}
+	*i = PopStratValue(value)
+	return nil
+}
+
+// ProtocolDistribution maps a protocol e.g. 'grpc' to its relative weight when
+// choosing which protocol an app communicates over. A distribution of http=3,tcp=1
+// means roughly one in four apps will use tcp.
+//
+// It implements the flag.Value interface so the distribution can be used as a
+// flag e.g. '--protocols http=3,grpc=1,tcp=1'.
+type ProtocolDistribution map[string]int
+
+func (p *ProtocolDistribution) String() string {
+	if p == nil {
+		return ""
+	}
+
+	var protocols []string
+	for protocol, weight := range *p {
+		protocols = append(protocols, fmt.Sprintf("%s=%d", protocol, weight))
+	}
+	sort.Strings(protocols)
+	return strings.Join(protocols, ",")
+}
+
+func (p *ProtocolDistribution) Set(value string) error {
+	dist := make(ProtocolDistribution)
+	for _, entry := range strings.Split(value, ",") {
+		protocol, weightStr, found := strings.Cut(entry, "=")
+		if !found {
+			return fmt.Errorf("%s is not valid. Use: '<protocol>=<weight>'", entry)
+		}
+		if !isSupportedProtocol(protocol) {
+			return fmt.Errorf("%s is not a supported protocol. Use: '%s'", protocol, strings.Join(supportedProtocols, "', '"))
+		}
+		weight, err := strconv.Atoi(weightStr)
+		if err != nil || weight < 0 {
+			return fmt.Errorf("weight for %s must be a non-negative integer", protocol)
+		}
+		dist[protocol] = weight
+	}
+	*p = dist
+	return nil
+}
+
+// NamespaceWeights is the relative weight of each generated namespace, in order, when
+// choosing which namespace an app is placed into. Weights of 6,1,1,1,1 place roughly
+// 60% of the apps into the first namespace.
+//
+// It implements the flag.Value interface so the weights can be used as a
+// flag e.g. '--namespace-weights 6,1,1,1,1'.
+type NamespaceWeights []int
+
+func (n *NamespaceWeights) String() string {
+	if n == nil {
+		return ""
+	}
+
+	weights := make([]string, len(*n))
+	for i, weight := range *n {
+		weights[i] = strconv.Itoa(weight)
+	}
+	return strings.Join(weights, ",")
+}
+
+func (n *NamespaceWeights) Set(value string) error {
+	var weights NamespaceWeights
+	for _, weightStr := range strings.Split(value, ",") {
+		weight, err := strconv.Atoi(weightStr)
+		if err != nil || weight <= 0 {
+			return fmt.Errorf("namespace weight %s must be a positive integer", weightStr)
+		}
+		weights = append(weights, weight)
+	}
+	*n = weights
 	return nil
 }
@@ -0,0 +1,121 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/graph/config/cytoscape"
+	"github.com/kiali/kiali/log"
+)
+
+// liveWorkload is the subset of a kube workload needed to place it in the graph.
+type liveWorkload struct {
+	Name      string
+	Namespace string
+	// PodLabels are the labels of the workload's pod template.
+	PodLabels map[string]string
+}
+
+// Snapshot reads the namespaces, services and workloads that currently exist in the
+// cluster and turns them into a static graph. Only the structure is captured: services
+// have an edge to every workload their selector matches but no traffic is reported on
+// any of the edges. Like the rest of the generator, this is only intended for testing
+// e.g. saving the graph of a cluster where a bug was seen so that it can be replayed offline.
+// Requires a kubeclient.
+func (g *Generator) Snapshot(ctx context.Context) (cytoscape.Config, error) {
+	if g.kubeClient == nil {
+		return cytoscape.Config{}, fmt.Errorf("a kube client is required to snapshot a live cluster")
+	}
+
+	namespaces, err := g.kubeClient.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return cytoscape.Config{}, err
+	}
+
+	var nodes []*graph.Node
+	for _, ns := range namespaces.Items {
+		nsNodes, err := g.snapshotNamespace(ctx, ns.Name)
+		if err != nil {
+			return cytoscape.Config{}, err
+		}
+		nodes = append(nodes, nsNodes...)
+	}
+
+	log.Infof("Snapshot found %d nodes across %d namespaces", len(nodes), len(namespaces.Items))
+
+	return g.toConfig(nodes), nil
+}
+
+func (g *Generator) snapshotNamespace(ctx context.Context, namespace string) ([]*graph.Node, error) {
+	workloads, err := g.listLiveWorkloads(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	services, err := g.kubeClient.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	appLabel := config.Get().IstioLabels.AppLabelName
+	versionLabel := config.Get().IstioLabels.VersionLabelName
+
+	var nodes []*graph.Node
+	workloadNodes := make(map[string]*graph.Node, len(workloads))
+	for _, wk := range workloads {
+		node, err := graph.NewNode(g.Cluster, namespace, "", namespace, wk.Name, wk.PodLabels[appLabel], wk.PodLabels[versionLabel], g.GraphType)
+		if err != nil {
+			return nil, err
+		}
+		workloadNodes[wk.Name] = node
+		nodes = append(nodes, node)
+	}
+
+	for _, svc := range services.Items {
+		svcNode, err := graph.NewNode(g.Cluster, namespace, svc.Name, "", "", "", "", g.GraphType)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, svcNode)
+
+		// Services without a selector don't select any pods e.g. ExternalName services.
+		if len(svc.Spec.Selector) == 0 {
+			continue
+		}
+		selector := labels.SelectorFromSet(svc.Spec.Selector)
+		for _, wk := range workloads {
+			if selector.Matches(labels.Set(wk.PodLabels)) {
+				svcNode.AddEdge(workloadNodes[wk.Name])
+			}
+		}
+	}
+
+	return nodes, nil
+}
+
+func (g *Generator) listLiveWorkloads(ctx context.Context, namespace string) ([]liveWorkload, error) {
+	var workloads []liveWorkload
+
+	deployments, err := g.kubeClient.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range deployments.Items {
+		workloads = append(workloads, liveWorkload{Name: d.Name, Namespace: d.Namespace, PodLabels: d.Spec.Template.Labels})
+	}
+
+	statefulSets, err := g.kubeClient.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range statefulSets.Items {
+		workloads = append(workloads, liveWorkload{Name: s.Name, Namespace: s.Namespace, PodLabels: s.Spec.Template.Labels})
+	}
+
+	return workloads, nil
+}
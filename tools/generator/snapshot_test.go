@@ -0,0 +1,76 @@
+package generator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/graph"
+)
+
+func fakeDeployment(name, namespace string, labels map[string]string) *apps_v1.Deployment {
+	return &apps_v1.Deployment{
+		ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: apps_v1.DeploymentSpec{
+			Template: core_v1.PodTemplateSpec{
+				ObjectMeta: meta_v1.ObjectMeta{Labels: labels},
+			},
+		},
+	}
+}
+
+func TestSnapshotFromLiveCluster(t *testing.T) {
+	require := require.New(t)
+	config.Set(config.NewConfig())
+
+	kubeClient := fake.NewSimpleClientset(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}},
+		&core_v1.Service{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"},
+			Spec:       core_v1.ServiceSpec{Selector: map[string]string{"app": "reviews"}},
+		},
+		fakeDeployment("reviews-v1", "bookinfo", map[string]string{"app": "reviews", "version": "v1"}),
+		fakeDeployment("reviews-v2", "bookinfo", map[string]string{"app": "reviews", "version": "v2"}),
+	)
+
+	cluster := "east"
+	g, err := New(Options{Cluster: &cluster, KubeClient: kubeClient})
+	require.NoError(err)
+
+	cyGraph, err := g.Snapshot(context.TODO())
+	require.NoError(err)
+
+	var services, apps []string
+	for _, node := range cyGraph.Elements.Nodes {
+		require.Equal("east", node.Data.Cluster)
+		require.Equal("bookinfo", node.Data.Namespace)
+		switch node.Data.NodeType {
+		case graph.NodeTypeService:
+			services = append(services, node.Data.Service)
+		case graph.NodeTypeApp:
+			apps = append(apps, node.Data.Workload)
+		}
+	}
+	require.ElementsMatch([]string{"reviews"}, services)
+	require.ElementsMatch([]string{"reviews-v1", "reviews-v2"}, apps)
+
+	require.Len(cyGraph.Elements.Edges, 2)
+	for _, edge := range cyGraph.Elements.Edges {
+		// Snapshots only capture structure so there shouldn't be any traffic.
+		require.Empty(edge.Data.Traffic.Rates)
+	}
+}
+
+func TestSnapshotRequiresKubeClient(t *testing.T) {
+	g, err := New(Options{})
+	require.NoError(t, err)
+
+	_, err = g.Snapshot(context.TODO())
+	require.Error(t, err)
+}
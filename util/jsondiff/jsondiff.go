@@ -0,0 +1,102 @@
+package jsondiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Operations of a Difference, applied to the first document to get the second one
+const (
+	OperationAdded   = "added"
+	OperationRemoved = "removed"
+	OperationChanged = "changed"
+)
+
+// Difference is a value differing between two JSON documents
+type Difference struct {
+	// Path of the value, like "spec.template.spec.containers[0].image"
+	Path string `json:"path"`
+	// Operation applied to the value of the first document: "added", "removed" or "changed"
+	Operation string `json:"operation"`
+	// Value in the first document, nil when added
+	From interface{} `json:"from,omitempty"`
+	// Value in the second document, nil when removed
+	To interface{} `json:"to,omitempty"`
+}
+
+// Normalize converts an object to its generic JSON representation, made of maps, slices and scalars, to be compared
+func Normalize(object interface{}) (interface{}, error) {
+	raw, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(raw, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// Diff returns the differences between two normalized JSON documents, sorted by path.
+// The objects are compared field by field and the arrays item by item, the other values are compared as a whole.
+func Diff(from, to interface{}) []Difference {
+	differences := []Difference{}
+	diff("", from, to, &differences)
+	sort.SliceStable(differences, func(i, j int) bool {
+		return differences[i].Path < differences[j].Path
+	})
+	return differences
+}
+
+func diff(path string, from, to interface{}, differences *[]Difference) {
+	switch fromValue := from.(type) {
+	case map[string]interface{}:
+		if toValue, ok := to.(map[string]interface{}); ok {
+			for key, value := range fromValue {
+				if toField, found := toValue[key]; found {
+					diff(fieldPath(path, key), value, toField, differences)
+				} else {
+					*differences = append(*differences, Difference{Path: fieldPath(path, key), Operation: OperationRemoved, From: value})
+				}
+			}
+			for key, value := range toValue {
+				if _, found := fromValue[key]; !found {
+					*differences = append(*differences, Difference{Path: fieldPath(path, key), Operation: OperationAdded, To: value})
+				}
+			}
+			return
+		}
+	case []interface{}:
+		if toValue, ok := to.([]interface{}); ok {
+			for i := 0; i < len(fromValue) || i < len(toValue); i++ {
+				itemPath := fmt.Sprintf("%s[%d]", path, i)
+				switch {
+				case i >= len(toValue):
+					*differences = append(*differences, Difference{Path: itemPath, Operation: OperationRemoved, From: fromValue[i]})
+				case i >= len(fromValue):
+					*differences = append(*differences, Difference{Path: itemPath, Operation: OperationAdded, To: toValue[i]})
+				default:
+					diff(itemPath, fromValue[i], toValue[i], differences)
+				}
+			}
+			return
+		}
+	}
+	if !reflect.DeepEqual(from, to) {
+		*differences = append(*differences, Difference{Path: path, Operation: OperationChanged, From: from, To: to})
+	}
+}
+
+// fieldPath appends a field to a path, quoting the fields which are not plain identifiers, like the annotation keys
+func fieldPath(path, field string) string {
+	if strings.ContainsAny(field, ".[]/ ") {
+		return fmt.Sprintf("%s[%q]", path, field)
+	}
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
@@ -0,0 +1,59 @@
+package jsondiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	from, err := Normalize(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]string{"app.kubernetes.io/name": "reviews", "version": "v1"},
+		},
+		"spec": map[string]interface{}{
+			"replicas": 2,
+			"ports":    []interface{}{map[string]interface{}{"port": 9080}, map[string]interface{}{"port": 9090}},
+		},
+	})
+	require.NoError(err)
+	to, err := Normalize(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]string{"app.kubernetes.io/name": "reviews"},
+		},
+		"spec": map[string]interface{}{
+			"replicas": 3,
+			"ports":    []interface{}{map[string]interface{}{"port": 9080, "protocol": "TCP"}},
+			"type":     "ClusterIP",
+		},
+	})
+	require.NoError(err)
+
+	assert.Equal([]Difference{
+		{Path: "metadata.labels.version", Operation: OperationRemoved, From: "v1"},
+		{Path: "spec.ports[0].protocol", Operation: OperationAdded, To: "TCP"},
+		{Path: "spec.ports[1]", Operation: OperationRemoved, From: map[string]interface{}{"port": float64(9090)}},
+		{Path: "spec.replicas", Operation: OperationChanged, From: float64(2), To: float64(3)},
+		{Path: "spec.type", Operation: OperationAdded, To: "ClusterIP"},
+	}, Diff(from, to))
+
+	assert.Empty(Diff(from, from))
+}
+
+func TestDiffTypeChange(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal([]Difference{
+		{Path: "spec", Operation: OperationChanged, From: "none", To: map[string]interface{}{"replicas": float64(1)}},
+	}, Diff(map[string]interface{}{"spec": "none"}, map[string]interface{}{"spec": map[string]interface{}{"replicas": float64(1)}}))
+	assert.Equal([]Difference{
+		{Path: `metadata.annotations["sidecar.istio.io/inject"]`, Operation: OperationChanged, From: "true", To: "false"},
+	}, Diff(
+		map[string]interface{}{"metadata": map[string]interface{}{"annotations": map[string]interface{}{"sidecar.istio.io/inject": "true"}}},
+		map[string]interface{}{"metadata": map[string]interface{}{"annotations": map[string]interface{}{"sidecar.istio.io/inject": "false"}}},
+	))
+}